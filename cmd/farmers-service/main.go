@@ -102,6 +102,11 @@ func main() {
 		serviceFactory.ReconciliationJob.Start()
 	}
 
+	// Start bulk job worker; recovers bulk operations orphaned by a previous run
+	if serviceFactory.BulkJobWorker != nil {
+		serviceFactory.BulkJobWorker.Start()
+	}
+
 	// Get port from configuration
 	port := cfg.Server.Port
 
@@ -132,6 +137,11 @@ func main() {
 		serviceFactory.ReconciliationJob.Stop()
 	}
 
+	// Stop bulk job worker; unprocessed records are released to other replicas
+	if serviceFactory.BulkJobWorker != nil {
+		serviceFactory.BulkJobWorker.Stop()
+	}
+
 	// Close database connection before exit
 	if err := dbManager.Close(); err != nil {
 		log.Printf("Error closing database connection: %v", err)
@@ -93,12 +93,33 @@ var RoutePermissionMap = map[string]Permission{
 	"PUT /api/v1/farmer-links/kisan-sathi": {Resource: "farmer", Action: "assign_kisan_sathi"},
 
 	// Farm management routes
-	"POST /api/v1/farms":                    {Resource: "farm", Action: "create"},
-	"GET /api/v1/farms/:id":                 {Resource: "farm", Action: "read"},
-	"PUT /api/v1/farms/:id":                 {Resource: "farm", Action: "update"},
-	"DELETE /api/v1/farms/:id":              {Resource: "farm", Action: "delete"},
-	"GET /api/v1/farms":                     {Resource: "farm", Action: "list"},
-	"GET /api/v1/farms/:id/area-allocation": {Resource: "farm", Action: "read"},
+	"POST /api/v1/farms":                      {Resource: "farm", Action: "create"},
+	"GET /api/v1/farms/:id":                   {Resource: "farm", Action: "read"},
+	"PUT /api/v1/farms/:id":                   {Resource: "farm", Action: "update"},
+	"DELETE /api/v1/farms/:id":                {Resource: "farm", Action: "delete"},
+	"GET /api/v1/farms":                       {Resource: "farm", Action: "list"},
+	"GET /api/v1/farms/:id/area-allocation":   {Resource: "farm", Action: "read"},
+	"GET /api/v1/farms/:id/costs":             {Resource: "farm", Action: "read"},
+	"GET /api/v1/farms/:id/geometry-versions": {Resource: "farm", Action: "read"},
+	"GET /api/v1/farms/:id/geometry-diff":     {Resource: "farm", Action: "read"},
+	"GET /api/v1/farms/:id/geometry":          {Resource: "farm", Action: "read"},
+	"GET /api/v1/farms/export.geojson":        {Resource: "farm", Action: "list"},
+	"POST /api/v1/farms/search":               {Resource: "farm", Action: "list"},
+	"GET /api/v1/farms/tiles/:z/:x/:y":        {Resource: "farm", Action: "list"},
+
+	// Land parcel routes; parcels are part of their farm
+	"POST /api/v1/farms/:id/parcels":              {Resource: "farm", Action: "update"},
+	"GET /api/v1/farms/:id/parcels":               {Resource: "farm", Action: "read"},
+	"PUT /api/v1/farms/:id/parcels/:parcel_id":    {Resource: "farm", Action: "update"},
+	"DELETE /api/v1/farms/:id/parcels/:parcel_id": {Resource: "farm", Action: "update"},
+	"GET /api/v1/land-parcels":                    {Resource: "farm", Action: "list"},
+
+	// Plot routes; plots are part of their farm
+	"POST /api/v1/farms/:id/plots":            {Resource: "farm", Action: "update"},
+	"GET /api/v1/farms/:id/plots":             {Resource: "farm", Action: "read"},
+	"GET /api/v1/farms/:id/plots/map":         {Resource: "farm", Action: "read"},
+	"PUT /api/v1/farms/:id/plots/:plot_id":    {Resource: "farm", Action: "update"},
+	"DELETE /api/v1/farms/:id/plots/:plot_id": {Resource: "farm", Action: "update"},
 
 	// Crop master data routes
 	"POST /api/v1/crops":       {Resource: "crop", Action: "create"},
@@ -115,19 +136,36 @@ var RoutePermissionMap = map[string]Permission{
 	"GET /api/v1/varieties":          {Resource: "crop", Action: "list"},
 	"GET /api/v1/crop-varieties/:id": {Resource: "crop", Action: "list"},
 
+	// Input master routes
+	"POST /api/v1/inputs":       {Resource: "input", Action: "create"},
+	"GET /api/v1/inputs/:id":    {Resource: "input", Action: "read"},
+	"PUT /api/v1/inputs/:id":    {Resource: "input", Action: "update"},
+	"DELETE /api/v1/inputs/:id": {Resource: "input", Action: "delete"},
+	"GET /api/v1/inputs":        {Resource: "input", Action: "list"},
+
 	// Crop cycle routes
-	"POST /api/v1/crops/cycles":        {Resource: "cycle", Action: "start"},
-	"GET /api/v1/crops/cycles/:id":     {Resource: "cycle", Action: "read"},
-	"PUT /api/v1/crops/cycles/:id":     {Resource: "cycle", Action: "update"},
-	"PUT /api/v1/crops/cycles/:id/end": {Resource: "cycle", Action: "end"},
-	"DELETE /api/v1/crops/cycles/:id":  {Resource: "cycle", Action: "end"},
-	"GET /api/v1/crops/cycles":         {Resource: "cycle", Action: "list"},
+	"POST /api/v1/crops/cycles":                    {Resource: "cycle", Action: "start"},
+	"GET /api/v1/crops/cycles/:id":                 {Resource: "cycle", Action: "read"},
+	"PUT /api/v1/crops/cycles/:id":                 {Resource: "cycle", Action: "update"},
+	"PUT /api/v1/crops/cycles/:id/end":             {Resource: "cycle", Action: "end"},
+	"POST /api/v1/crops/cycles/:id/replan":         {Resource: "cycle", Action: "update"},
+	"POST /api/v1/crops/cycles/:id/stages/advance": {Resource: "cycle", Action: "update"},
+	"GET /api/v1/crops/cycles/:id/costs":           {Resource: "cycle", Action: "read"},
+	"GET /api/v1/crops/cycles/:id/progress":        {Resource: "cycle", Action: "read"},
+	"GET /api/v1/crops/cycles/delayed":             {Resource: "cycle", Action: "list"},
+	"GET /api/v1/crops/cycles/forecast":            {Resource: "cycle", Action: "list"},
+	"GET /api/v1/crops/cycles/:id/yield-estimate":  {Resource: "cycle", Action: "read"},
+	"DELETE /api/v1/crops/cycles/:id":              {Resource: "cycle", Action: "end"},
+	"GET /api/v1/crops/cycles":                     {Resource: "cycle", Action: "list"},
 
 	// Farm activity routes
 	"POST /api/v1/crops/activities":             {Resource: "activity", Action: "create"},
 	"GET /api/v1/crops/activities/:id":          {Resource: "activity", Action: "read"},
 	"PUT /api/v1/crops/activities/:id":          {Resource: "activity", Action: "update"},
 	"PUT /api/v1/crops/activities/:id/complete": {Resource: "activity", Action: "complete"},
+	"PUT /api/v1/crops/activities/:id/start":    {Resource: "activity", Action: "update"},
+	"PUT /api/v1/crops/activities/:id/inputs":   {Resource: "activity", Action: "update"},
+	"GET /api/v1/crops/activities/:id/inputs":   {Resource: "activity", Action: "read"},
 	"GET /api/v1/crops/activities":              {Resource: "activity", Action: "list"},
 
 	// Lookup/Dropdown data routes
@@ -137,6 +175,8 @@ var RoutePermissionMap = map[string]Permission{
 	"GET /api/v1/lookups/crop-seasons":       {Resource: "crop", Action: "list"},
 	"GET /api/v1/lookups/soil-types":         {Resource: "farm", Action: "list"},
 	"GET /api/v1/lookups/irrigation-sources": {Resource: "farm", Action: "list"},
+	"GET /api/v1/lookups/admin-boundaries":   {Resource: "farm", Action: "list"},
+	"GET /api/v1/lookups/units":              {Resource: "farm", Action: "list"},
 
 	// Stage master data routes
 	"POST /api/v1/stages":       {Resource: "stage", Action: "create"},
@@ -153,12 +193,23 @@ var RoutePermissionMap = map[string]Permission{
 	"PUT /api/v1/crops/:id/stages/:stage_id":    {Resource: "crop_stage", Action: "update"},
 	"DELETE /api/v1/crops/:id/stages/:stage_id": {Resource: "crop_stage", Action: "delete"},
 
+	// Activity templates of crop stages
+	"POST /api/v1/crops/:id/stages/:stage_id/activity-templates":                {Resource: "crop_stage", Action: "create"},
+	"GET /api/v1/crops/:id/stages/:stage_id/activity-templates":                 {Resource: "crop_stage", Action: "read"},
+	"PUT /api/v1/crops/:id/stages/:stage_id/activity-templates/:template_id":    {Resource: "crop_stage", Action: "update"},
+	"DELETE /api/v1/crops/:id/stages/:stage_id/activity-templates/:template_id": {Resource: "crop_stage", Action: "delete"},
+
 	// Data quality routes
 	"POST /api/v1/data-quality/validate-geometry":       {Resource: "farm", Action: "audit"},
 	"POST /api/v1/data-quality/reconcile-aaa-links":     {Resource: "admin", Action: "maintain"},
 	"POST /api/v1/data-quality/rebuild-spatial-indexes": {Resource: "admin", Action: "maintain"},
 	"POST /api/v1/data-quality/detect-farm-overlaps":    {Resource: "farm", Action: "audit"},
 
+	// Farm overlap conflict workflow
+	"GET /api/v1/data-quality/overlap-conflicts":                       {Resource: "farm", Action: "audit"},
+	"PUT /api/v1/data-quality/overlap-conflicts/:conflict_id":          {Resource: "farm", Action: "audit"},
+	"POST /api/v1/data-quality/overlap-conflicts/:conflict_id/resolve": {Resource: "farm", Action: "audit"},
+
 	// Reporting routes
 	"GET /api/v1/reports/farmer-portfolio": {Resource: "report", Action: "read"},
 	"GET /api/v1/reports/org-dashboard":    {Resource: "report", Action: "read"},
@@ -171,14 +222,34 @@ var RoutePermissionMap = map[string]Permission{
 	"GET /api/v1/admin/audit":             {Resource: "admin", Action: "audit"},
 	"GET /api/v1/health":                  {Resource: "system", Action: "health"},
 
+	// Administrative boundary master data
+	"POST /api/v1/admin/boundaries/import":   {Resource: "admin", Action: "maintain"},
+	"POST /api/v1/admin/boundaries/backfill": {Resource: "admin", Action: "maintain"},
+
 	// Bulk operation routes
 	"POST /api/v1/bulk/farmers/add":          {Resource: "farmer", Action: "bulk_create"},
+	"POST /api/v1/bulk/farms/import":         {Resource: "farm", Action: "bulk_create"},
+	"POST /api/v1/bulk/crop-cycles/import":   {Resource: "cycle", Action: "bulk_create"},
 	"GET /api/v1/bulk/status/:operation_id":  {Resource: "bulk_operation", Action: "read"},
 	"POST /api/v1/bulk/cancel/:operation_id": {Resource: "bulk_operation", Action: "cancel"},
+	"POST /api/v1/bulk/pause/:operation_id":  {Resource: "bulk_operation", Action: "pause"},
+	"POST /api/v1/bulk/resume/:operation_id": {Resource: "bulk_operation", Action: "resume"},
 	"POST /api/v1/bulk/retry/:operation_id":  {Resource: "bulk_operation", Action: "retry"},
 	"GET /api/v1/bulk/results/:operation_id": {Resource: "bulk_operation", Action: "read"},
 	"GET /api/v1/bulk/template":              {Resource: "farmer", Action: "read"},
 	"POST /api/v1/bulk/validate":             {Resource: "farmer", Action: "validate"},
+
+	// Bulk column mapping profiles
+	"POST /api/v1/bulk/mapping-profiles":               {Resource: "bulk_mapping_profile", Action: "create"},
+	"GET /api/v1/bulk/mapping-profiles":                {Resource: "bulk_mapping_profile", Action: "list"},
+	"POST /api/v1/bulk/mapping-profiles/preview":       {Resource: "bulk_mapping_profile", Action: "read"},
+	"GET /api/v1/bulk/mapping-profiles/:profile_id":    {Resource: "bulk_mapping_profile", Action: "read"},
+	"PUT /api/v1/bulk/mapping-profiles/:profile_id":    {Resource: "bulk_mapping_profile", Action: "update"},
+	"DELETE /api/v1/bulk/mapping-profiles/:profile_id": {Resource: "bulk_mapping_profile", Action: "delete"},
+
+	// Offline sync routes
+	"GET /api/v1/sync/changes": {Resource: "sync", Action: "read"},
+	"POST /api/v1/sync/push":   {Resource: "sync", Action: "push"},
 }
 
 // GetPermissionForRoute returns the required permission for a given HTTP method and path
@@ -243,6 +314,23 @@ func normalizePath(path string) string {
 		}
 	}
 
+	// Handle crop cycle routes: /api/v1/crops/cycles/...
+	if len(segments) >= 5 && segments[1] == "api" && segments[2] == "v1" && segments[3] == "crops" && segments[4] == "cycles" {
+		if len(segments) == 6 && (segments[5] == "delayed" || segments[5] == "forecast") {
+			// Pattern: /api/v1/crops/cycles/delayed, /api/v1/crops/cycles/forecast (no normalization needed)
+			return path
+		}
+		if len(segments) == 6 {
+			// Pattern: /api/v1/crops/cycles/CRCY123 -> /api/v1/crops/cycles/:id
+			return "/api/v1/crops/cycles/:id"
+		}
+		if len(segments) >= 7 {
+			// Pattern: /api/v1/crops/cycles/CRCY123/yield-estimate -> /api/v1/crops/cycles/:id/yield-estimate
+			return "/api/v1/crops/cycles/:id/" + strings.Join(segments[6:], "/")
+		}
+		return path
+	}
+
 	// Handle nested crop-stage routes: /api/v1/crops/:id/stages/...
 	if len(segments) >= 6 && segments[1] == "api" && segments[2] == "v1" && segments[3] == "crops" && segments[5] == "stages" {
 		if len(segments) == 6 {
@@ -258,6 +346,14 @@ func normalizePath(path string) string {
 			// Pattern: /api/v1/crops/CROP123/stages/STGE456 -> /api/v1/crops/:id/stages/:stage_id
 			return "/api/v1/crops/:id/stages/:stage_id"
 		}
+		if len(segments) == 8 && segments[7] == "activity-templates" {
+			// Pattern: /api/v1/crops/CROP123/stages/STGE456/activity-templates -> /api/v1/crops/:id/stages/:stage_id/activity-templates
+			return "/api/v1/crops/:id/stages/:stage_id/activity-templates"
+		}
+		if len(segments) == 9 && segments[7] == "activity-templates" {
+			// Pattern: /api/v1/crops/CROP123/stages/STGE456/activity-templates/CSAT789 -> /api/v1/crops/:id/stages/:stage_id/activity-templates/:template_id
+			return "/api/v1/crops/:id/stages/:stage_id/activity-templates/:template_id"
+		}
 	}
 
 	// Handle stage special routes before generic ID pattern
@@ -283,15 +379,80 @@ func normalizePath(path string) string {
 			// Pattern: /api/v1/bulk/template -> /api/v1/bulk/template (no normalization needed)
 			return path
 		}
+		if len(segments) == 6 && (segments[4] == "farmers" || segments[4] == "farms" || segments[4] == "crop-cycles") {
+			// Pattern: /api/v1/bulk/farmers/add, /api/v1/bulk/farms/import, /api/v1/bulk/crop-cycles/import (no normalization needed)
+			return path
+		}
+		if len(segments) == 6 && segments[4] == "mapping-profiles" {
+			if segments[5] == "preview" {
+				return path
+			}
+			// Pattern: /api/v1/bulk/mapping-profiles/BLKM123 -> /api/v1/bulk/mapping-profiles/:profile_id
+			return "/api/v1/bulk/mapping-profiles/:profile_id"
+		}
 		if len(segments) == 6 {
 			// Pattern: /api/v1/bulk/status/OPER123 -> /api/v1/bulk/status/:operation_id
 			// Pattern: /api/v1/bulk/cancel/OPER123 -> /api/v1/bulk/cancel/:operation_id
+			// Pattern: /api/v1/bulk/pause/OPER123 -> /api/v1/bulk/pause/:operation_id
+			// Pattern: /api/v1/bulk/resume/OPER123 -> /api/v1/bulk/resume/:operation_id
 			// Pattern: /api/v1/bulk/retry/OPER123 -> /api/v1/bulk/retry/:operation_id
 			// Pattern: /api/v1/bulk/results/OPER123 -> /api/v1/bulk/results/:operation_id
 			return fmt.Sprintf("/api/v1/bulk/%s/:operation_id", segments[4])
 		}
 	}
 
+	// Handle farm export and tile routes before the generic ID pattern
+	if len(segments) >= 5 && segments[1] == "api" && segments[2] == "v1" && segments[3] == "farms" {
+		if len(segments) == 5 && (segments[4] == "export.geojson" || segments[4] == "search") {
+			// Pattern: /api/v1/farms/export.geojson, /api/v1/farms/search (no normalization needed)
+			return path
+		}
+		if len(segments) == 8 && segments[4] == "tiles" {
+			// Pattern: /api/v1/farms/tiles/14/11652/7287.mvt -> /api/v1/farms/tiles/:z/:x/:y
+			return "/api/v1/farms/tiles/:z/:x/:y"
+		}
+		if len(segments) == 7 && segments[5] == "parcels" {
+			// Pattern: /api/v1/farms/FARM123/parcels/LPCL456 -> /api/v1/farms/:id/parcels/:parcel_id
+			return "/api/v1/farms/:id/parcels/:parcel_id"
+		}
+		if len(segments) == 7 && segments[5] == "plots" {
+			// Pattern: /api/v1/farms/FARM123/plots/map -> /api/v1/farms/:id/plots/map
+			// Pattern: /api/v1/farms/FARM123/plots/PLOT456 -> /api/v1/farms/:id/plots/:plot_id
+			if segments[6] == "map" {
+				return "/api/v1/farms/:id/plots/map"
+			}
+			return "/api/v1/farms/:id/plots/:plot_id"
+		}
+	}
+
+	// Handle offline sync routes: /api/v1/sync/changes, /api/v1/sync/push (no normalization needed)
+	if len(segments) == 5 && segments[1] == "api" && segments[2] == "v1" && segments[3] == "sync" {
+		return path
+	}
+
+	// Handle lookup routes: /api/v1/lookups/soil-types, /api/v1/lookups/admin-boundaries (no normalization needed)
+	if len(segments) == 5 && segments[1] == "api" && segments[2] == "v1" && segments[3] == "lookups" {
+		return path
+	}
+
+	// Handle data quality routes: /api/v1/data-quality/detect-farm-overlaps, /api/v1/data-quality/overlap-conflicts/...
+	if len(segments) >= 5 && segments[1] == "api" && segments[2] == "v1" && segments[3] == "data-quality" {
+		if len(segments) == 6 && segments[4] == "overlap-conflicts" {
+			// Pattern: /api/v1/data-quality/overlap-conflicts/FOVC123 -> /api/v1/data-quality/overlap-conflicts/:conflict_id
+			return "/api/v1/data-quality/overlap-conflicts/:conflict_id"
+		}
+		if len(segments) == 7 && segments[4] == "overlap-conflicts" {
+			// Pattern: /api/v1/data-quality/overlap-conflicts/FOVC123/resolve -> /api/v1/data-quality/overlap-conflicts/:conflict_id/resolve
+			return fmt.Sprintf("/api/v1/data-quality/overlap-conflicts/:conflict_id/%s", segments[6])
+		}
+		return path
+	}
+
+	// Handle admin boundary routes: /api/v1/admin/boundaries/import (no normalization needed)
+	if len(segments) == 6 && segments[1] == "api" && segments[2] == "v1" && segments[3] == "admin" && segments[4] == "boundaries" {
+		return path
+	}
+
 	// Handle other routes
 	if len(segments) >= 4 {
 		// Check for common API patterns
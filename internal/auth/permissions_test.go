@@ -161,3 +161,236 @@ func TestGetPermissionForRoute_StageNormalization(t *testing.T) {
 		})
 	}
 }
+
+func TestGetPermissionForRoute_BulkMappingProfileRoutes(t *testing.T) {
+	tests := []struct {
+		method     string
+		path       string
+		wantAction string
+	}{
+		{"POST", "/api/v1/bulk/mapping-profiles", "create"},
+		{"GET", "/api/v1/bulk/mapping-profiles?fpo_org_id=org_123", "list"},
+		{"POST", "/api/v1/bulk/mapping-profiles/preview", "read"},
+		{"GET", "/api/v1/bulk/mapping-profiles/BLKM00000001", "read"},
+		{"PUT", "/api/v1/bulk/mapping-profiles/BLKM00000001", "update"},
+		{"DELETE", "/api/v1/bulk/mapping-profiles/BLKM00000001", "delete"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			permission, exists := GetPermissionForRoute(tt.method, tt.path)
+			assert.True(t, exists)
+			assert.Equal(t, "bulk_mapping_profile", permission.Resource)
+			assert.Equal(t, tt.wantAction, permission.Action)
+		})
+	}
+
+	// Operation routes keep their operation ID normalization
+	permission, exists := GetPermissionForRoute("GET", "/api/v1/bulk/status/BLKO00000001")
+	assert.True(t, exists)
+	assert.Equal(t, "bulk_operation", permission.Resource)
+}
+
+func TestGetPermissionForRoute_BulkCreateRoutes(t *testing.T) {
+	permission, exists := GetPermissionForRoute("POST", "/api/v1/bulk/farms/import")
+	assert.True(t, exists)
+	assert.Equal(t, "farm", permission.Resource)
+	assert.Equal(t, "bulk_create", permission.Action)
+
+	permission, exists = GetPermissionForRoute("POST", "/api/v1/bulk/farmers/add")
+	assert.True(t, exists)
+	assert.Equal(t, "farmer", permission.Resource)
+	assert.Equal(t, "bulk_create", permission.Action)
+
+	permission, exists = GetPermissionForRoute("POST", "/api/v1/bulk/crop-cycles/import")
+	assert.True(t, exists)
+	assert.Equal(t, "cycle", permission.Resource)
+	assert.Equal(t, "bulk_create", permission.Action)
+}
+
+func TestGetPermissionForRoute_SyncRoutes(t *testing.T) {
+	permission, exists := GetPermissionForRoute("GET", "/api/v1/sync/changes?cursor=abc&limit=50")
+	assert.True(t, exists)
+	assert.Equal(t, "sync", permission.Resource)
+	assert.Equal(t, "read", permission.Action)
+
+	permission, exists = GetPermissionForRoute("POST", "/api/v1/sync/push")
+	assert.True(t, exists)
+	assert.Equal(t, "sync", permission.Resource)
+	assert.Equal(t, "push", permission.Action)
+}
+
+func TestGetPermissionForRoute_FarmExportAndTileRoutes(t *testing.T) {
+	permission, exists := GetPermissionForRoute("GET", "/api/v1/farms/export.geojson")
+	assert.True(t, exists)
+	assert.Equal(t, "farm", permission.Resource)
+	assert.Equal(t, "list", permission.Action)
+
+	permission, exists = GetPermissionForRoute("GET", "/api/v1/farms/tiles/14/11652/7287.mvt")
+	assert.True(t, exists)
+	assert.Equal(t, "farm", permission.Resource)
+	assert.Equal(t, "list", permission.Action)
+
+	// Farm IDs still resolve to the farm read permission
+	permission, exists = GetPermissionForRoute("GET", "/api/v1/farms/FARM00000001")
+	assert.True(t, exists)
+	assert.Equal(t, "read", permission.Action)
+}
+
+func TestGetPermissionForRoute_FarmGeometryHistoryRoutes(t *testing.T) {
+	for _, path := range []string{
+		"/api/v1/farms/FARM00000001/geometry-versions",
+		"/api/v1/farms/FARM00000001/geometry-diff?from=1&to=2",
+		"/api/v1/farms/FARM00000001/geometry?as_of=2024-06-30",
+	} {
+		permission, exists := GetPermissionForRoute("GET", path)
+		assert.True(t, exists, path)
+		assert.Equal(t, "farm", permission.Resource, path)
+		assert.Equal(t, "read", permission.Action, path)
+	}
+}
+
+func TestGetPermissionForRoute_LandParcelRoutes(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		action string
+	}{
+		{"POST", "/api/v1/farms/FARM00000001/parcels", "update"},
+		{"GET", "/api/v1/farms/FARM00000001/parcels", "read"},
+		{"PUT", "/api/v1/farms/FARM00000001/parcels/LPCL0000000001", "update"},
+		{"DELETE", "/api/v1/farms/FARM00000001/parcels/LPCL0000000001", "update"},
+		{"GET", "/api/v1/land-parcels?village_code=563214&survey_number=123", "list"},
+	}
+	for _, tt := range tests {
+		permission, exists := GetPermissionForRoute(tt.method, tt.path)
+		assert.True(t, exists, tt.path)
+		assert.Equal(t, "farm", permission.Resource, tt.path)
+		assert.Equal(t, tt.action, permission.Action, tt.path)
+	}
+}
+
+func TestGetPermissionForRoute_FarmSearchRoute(t *testing.T) {
+	permission, exists := GetPermissionForRoute("POST", "/api/v1/farms/search")
+	assert.True(t, exists)
+	assert.Equal(t, "farm", permission.Resource)
+	assert.Equal(t, "list", permission.Action)
+}
+
+func TestGetPermissionForRoute_AdminBoundaryRoutes(t *testing.T) {
+	tests := []struct {
+		method, path, resource, action string
+	}{
+		{"POST", "/api/v1/admin/boundaries/import", "admin", "maintain"},
+		{"POST", "/api/v1/admin/boundaries/backfill", "admin", "maintain"},
+		{"GET", "/api/v1/lookups/admin-boundaries?level=BLOCK&parent_code=523", "farm", "list"},
+		{"GET", "/api/v1/lookups/units?dimension=AREA", "farm", "list"},
+	}
+	for _, tt := range tests {
+		permission, exists := GetPermissionForRoute(tt.method, tt.path)
+		assert.True(t, exists, tt.path)
+		assert.Equal(t, tt.resource, permission.Resource, tt.path)
+		assert.Equal(t, tt.action, permission.Action, tt.path)
+	}
+}
+
+func TestGetPermissionForRoute_DataQualityRoutes(t *testing.T) {
+	tests := []struct {
+		method, path, resource, action string
+	}{
+		{"POST", "/api/v1/data-quality/detect-farm-overlaps", "farm", "audit"},
+		{"POST", "/api/v1/data-quality/rebuild-spatial-indexes", "admin", "maintain"},
+		{"GET", "/api/v1/data-quality/overlap-conflicts?status=OPEN", "farm", "audit"},
+		{"PUT", "/api/v1/data-quality/overlap-conflicts/FOVC0000000001", "farm", "audit"},
+		{"POST", "/api/v1/data-quality/overlap-conflicts/FOVC0000000001/resolve", "farm", "audit"},
+	}
+	for _, tt := range tests {
+		permission, exists := GetPermissionForRoute(tt.method, tt.path)
+		assert.True(t, exists, tt.path)
+		assert.Equal(t, tt.resource, permission.Resource, tt.path)
+		assert.Equal(t, tt.action, permission.Action, tt.path)
+	}
+}
+
+func TestGetPermissionForRoute_PlotRoutes(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		action string
+	}{
+		{"POST", "/api/v1/farms/FARM00000001/plots", "update"},
+		{"GET", "/api/v1/farms/FARM00000001/plots", "read"},
+		{"GET", "/api/v1/farms/FARM00000001/plots/map?season=KHARIF&year=2024", "read"},
+		{"PUT", "/api/v1/farms/FARM00000001/plots/PLOT00000001", "update"},
+		{"DELETE", "/api/v1/farms/FARM00000001/plots/PLOT00000001", "update"},
+	}
+	for _, tt := range tests {
+		permission, exists := GetPermissionForRoute(tt.method, tt.path)
+		assert.True(t, exists, tt.path)
+		assert.Equal(t, "farm", permission.Resource, tt.path)
+		assert.Equal(t, tt.action, permission.Action, tt.path)
+	}
+}
+
+func TestGetPermissionForRoute_ActivityTemplateRoutes(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		action string
+	}{
+		{"POST", "/api/v1/crops/CROP00000001/stages/STGE00000001/activity-templates", "create"},
+		{"GET", "/api/v1/crops/CROP00000001/stages/STGE00000001/activity-templates", "read"},
+		{"PUT", "/api/v1/crops/CROP00000001/stages/STGE00000001/activity-templates/CSAT00000001", "update"},
+		{"DELETE", "/api/v1/crops/CROP00000001/stages/STGE00000001/activity-templates/CSAT00000001", "delete"},
+	}
+	for _, tt := range tests {
+		permission, exists := GetPermissionForRoute(tt.method, tt.path)
+		assert.True(t, exists, tt.path)
+		assert.Equal(t, "crop_stage", permission.Resource, tt.path)
+		assert.Equal(t, tt.action, permission.Action, tt.path)
+	}
+}
+
+func TestGetPermissionForRoute_InputRoutes(t *testing.T) {
+	tests := []struct {
+		method   string
+		path     string
+		resource string
+		action   string
+	}{
+		{"POST", "/api/v1/inputs", "input", "create"},
+		{"GET", "/api/v1/inputs?category=FERTILIZER", "input", "list"},
+		{"GET", "/api/v1/inputs/INPD00000001", "input", "read"},
+		{"PUT", "/api/v1/inputs/INPD00000001", "input", "update"},
+		{"DELETE", "/api/v1/inputs/INPD00000001", "input", "delete"},
+		{"GET", "/api/v1/farms/FARM00000001/costs?season=KHARIF", "farm", "read"},
+	}
+	for _, tt := range tests {
+		permission, exists := GetPermissionForRoute(tt.method, tt.path)
+		assert.True(t, exists, tt.path)
+		assert.Equal(t, tt.resource, permission.Resource, tt.path)
+		assert.Equal(t, tt.action, permission.Action, tt.path)
+	}
+}
+
+func TestGetPermissionForRoute_CropCycleRoutes(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		action string
+	}{
+		{"GET", "/api/v1/crops/cycles", "list"},
+		{"GET", "/api/v1/crops/cycles/CRCY00000001", "read"},
+		{"PUT", "/api/v1/crops/cycles/CRCY00000001/end", "end"},
+		{"POST", "/api/v1/crops/cycles/CRCY00000001/stages/advance", "update"},
+		{"GET", "/api/v1/crops/cycles/delayed", "list"},
+		{"GET", "/api/v1/crops/cycles/CRCY00000001/yield-estimate", "read"},
+		{"GET", "/api/v1/crops/cycles/forecast?season=KHARIF&year=2024", "list"},
+	}
+	for _, tt := range tests {
+		permission, exists := GetPermissionForRoute(tt.method, tt.path)
+		assert.True(t, exists, tt.path)
+		assert.Equal(t, "cycle", permission.Resource, tt.path)
+		assert.Equal(t, tt.action, permission.Action, tt.path)
+	}
+}
@@ -18,6 +18,7 @@ type Config struct {
 	AAA           AAAConfig
 	Observability ObservabilityConfig
 	CORS          CORSConfig
+	Idempotency   IdempotencyConfig
 }
 
 // DatabaseConfig holds database configuration matching kisanlink-db
@@ -65,6 +66,11 @@ type CORSConfig struct {
 	AllowCredentials bool
 }
 
+// IdempotencyConfig holds configuration of Idempotency-Key handling
+type IdempotencyConfig struct {
+	KeyTTL string // how long responses are replayed for a key, as a duration such as "24h"
+}
+
 // Load loads configuration from environment variables
 func Load() *Config {
 	// Load .env file if it exists (ignore error if file doesn't exist)
@@ -110,6 +116,9 @@ func Load() *Config {
 			AllowedOrigins:   getEnvAsSlice("CORS_ALLOWED_ORIGINS", []string{"http://localhost:3000", "http://localhost:5173", "http://localhost:5174", "http://localhost:5175"}),
 			AllowCredentials: getEnvAsBool("CORS_ALLOW_CREDENTIALS", true),
 		},
+		Idempotency: IdempotencyConfig{
+			KeyTTL: getEnv("IDEMPOTENCY_KEY_TTL", "24h"),
+		},
 	}
 
 	// Validate configuration
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
 
+	"github.com/Kisanlink/farmers-module/internal/entities/admin_boundary"
 	"github.com/Kisanlink/farmers-module/internal/entities/bulk"
 	"github.com/Kisanlink/farmers-module/internal/entities/crop"
 	"github.com/Kisanlink/farmers-module/internal/entities/crop_cycle"
@@ -12,11 +14,16 @@ import (
 	"github.com/Kisanlink/farmers-module/internal/entities/farm"
 	"github.com/Kisanlink/farmers-module/internal/entities/farm_activity"
 	"github.com/Kisanlink/farmers-module/internal/entities/farm_irrigation_source"
+	"github.com/Kisanlink/farmers-module/internal/entities/farm_overlap"
 	"github.com/Kisanlink/farmers-module/internal/entities/farm_soil_type"
 	"github.com/Kisanlink/farmers-module/internal/entities/farmer"
 	"github.com/Kisanlink/farmers-module/internal/entities/fpo"
 	"github.com/Kisanlink/farmers-module/internal/entities/fpo_config"
+	"github.com/Kisanlink/farmers-module/internal/entities/idempotency"
+	"github.com/Kisanlink/farmers-module/internal/entities/input_product"
 	"github.com/Kisanlink/farmers-module/internal/entities/irrigation_source"
+	"github.com/Kisanlink/farmers-module/internal/entities/land_parcel"
+	"github.com/Kisanlink/farmers-module/internal/entities/plot"
 	"github.com/Kisanlink/farmers-module/internal/entities/soil_type"
 	"github.com/Kisanlink/farmers-module/internal/entities/stage"
 	"github.com/Kisanlink/farmers-module/internal/migrations"
@@ -101,6 +108,7 @@ func SetupDatabase(postgresManager *db.PostgresManager) error {
 			&soil_type.SoilType{},
 			&irrigation_source.IrrigationSource{},
 			&crop.Crop{},
+			&input_product.InputProduct{},
 
 			// Address table (no dependencies)
 			&farmer.Address{},
@@ -115,6 +123,7 @@ func SetupDatabase(postgresManager *db.PostgresManager) error {
 			// Stage tables (depends on Crop)
 			&stage.Stage{},
 			&stage.CropStage{},
+			&stage.ActivityTemplate{},
 
 			// Farm entity skipped (requires PostGIS)
 
@@ -124,6 +133,10 @@ func SetupDatabase(postgresManager *db.PostgresManager) error {
 			// Bulk operations (last)
 			&bulk.BulkOperation{},
 			&bulk.ProcessingDetail{},
+			&bulk.ColumnMappingProfile{},
+
+			// Idempotency keys of mutating API requests
+			&idempotency.IdempotencyKey{},
 		}
 
 		if err := postgresManager.AutoMigrateModels(ctx, models...); err != nil {
@@ -159,6 +172,7 @@ func SetupDatabase(postgresManager *db.PostgresManager) error {
 			&soil_type.SoilType{},
 			&irrigation_source.IrrigationSource{},
 			&crop.Crop{},
+			&input_product.InputProduct{},
 
 			// Address table (no dependencies)
 			&farmer.Address{},
@@ -170,19 +184,42 @@ func SetupDatabase(postgresManager *db.PostgresManager) error {
 			// Farm (depends on Farmer, uses PostGIS)
 			&farm.Farm{},
 
+			// Farm boundary history (depends on Farm, uses PostGIS)
+			&farm.FarmGeometryVersion{},
+
+			// Land record parcels (depends on Farm)
+			&land_parcel.LandParcel{},
+
+			// Administrative boundary master data (uses PostGIS)
+			&admin_boundary.AdminBoundary{},
+
+			// Farm overlap conflicts and detection runs (depend on Farm, use PostGIS)
+			&farm_overlap.FarmOverlapConflict{},
+			&farm_overlap.FarmOverlapDetectionRun{},
+
 			// Crop variety (depends on Crop)
 			&crop_variety.CropVariety{},
 
 			// Stage tables (depends on Crop)
 			&stage.Stage{},
 			&stage.CropStage{},
+			&stage.ActivityTemplate{},
+
+			// Plots of farms (depend on Farm, use PostGIS)
+			&plot.Plot{},
 
-			// Crop cycle (depends on Farm, Farmer, Crop, CropVariety)
+			// Crop cycle (depends on Farm, Farmer, Crop, CropVariety, Plot)
 			&crop_cycle.CropCycle{},
 
 			// Farm activity (depends on CropCycle)
 			&farm_activity.FarmActivity{},
 
+			// Input line items of farm activities (depend on FarmActivity, InputProduct)
+			&farm_activity.ActivityInput{},
+
+			// Stage transitions of crop cycles (depend on CropCycle, CropStage)
+			&crop_cycle.StageTransition{},
+
 			// Junction tables (depend on Farm and master tables)
 			&farm_soil_type.FarmSoilType{},
 			&farm_irrigation_source.FarmIrrigationSource{},
@@ -190,6 +227,10 @@ func SetupDatabase(postgresManager *db.PostgresManager) error {
 			// Bulk operations (last)
 			&bulk.BulkOperation{},
 			&bulk.ProcessingDetail{},
+			&bulk.ColumnMappingProfile{},
+
+			// Idempotency keys of mutating API requests
+			&idempotency.IdempotencyKey{},
 		}
 
 		if err := postgresManager.AutoMigrateModels(ctx, models...); err != nil {
@@ -229,8 +270,9 @@ func createEnums(gormDB *gorm.DB) {
 
 	// Activity status enum
 	gormDB.Exec(`DO $$ BEGIN
-		CREATE TYPE activity_status AS ENUM ('PLANNED','COMPLETED','CANCELLED');
+		CREATE TYPE activity_status AS ENUM ('PLANNED','IN_PROGRESS','COMPLETED','CANCELLED');
 	EXCEPTION WHEN duplicate_object THEN NULL; END $$;`)
+	gormDB.Exec(`ALTER TYPE activity_status ADD VALUE IF NOT EXISTS 'IN_PROGRESS' BEFORE 'COMPLETED'`)
 
 	// Link status enum
 	gormDB.Exec(`DO $$ BEGIN
@@ -281,6 +323,28 @@ func fixFarmSchema(gormDB *gorm.DB) {
 		}
 	}
 
+	// Farm boundaries were stored as geometry(POLYGON); widen the column to MULTIPOLYGON so
+	// farms can have several parts. ST_Multi keeps every existing ring, holes included.
+	// area_ha_computed depends on the column, so it is dropped here and recreated by
+	// setupPostMigration.
+	var geometryType string
+	if err := gormDB.Raw(`SELECT type FROM geometry_columns
+		WHERE f_table_name = 'farms' AND f_geometry_column = 'geometry'`).Scan(&geometryType).Error; err == nil &&
+		strings.EqualFold(geometryType, "POLYGON") {
+		log.Println("Converting farms.geometry from POLYGON to MULTIPOLYGON...")
+		if err := gormDB.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec(`ALTER TABLE farms DROP COLUMN IF EXISTS area_ha_computed;`).Error; err != nil {
+				return err
+			}
+			return tx.Exec(`ALTER TABLE farms ALTER COLUMN geometry TYPE geometry(MULTIPOLYGON,4326)
+				USING ST_Multi(geometry);`).Error
+		}); err != nil {
+			log.Printf("Warning: Failed to convert farms.geometry to MULTIPOLYGON: %v", err)
+		} else {
+			log.Println("✅ farms.geometry converted to MULTIPOLYGON")
+		}
+	}
+
 	log.Println("Farm schema fix completed")
 }
 
@@ -294,27 +358,26 @@ func setupPostMigration(gormDB *gorm.DB) {
 	}
 
 	if postgisAvailable {
-		// Check if area_ha_computed column exists and drop it to recreate with correct formula
-		// This ensures we use geography (accurate area) instead of geometry (square degrees)
-		var columnExists bool
-		gormDB.Raw(`SELECT EXISTS (
-			SELECT 1 FROM information_schema.columns
-			WHERE table_name = 'farms' AND column_name = 'area_ha_computed'
-		)`).Scan(&columnExists)
-
-		if columnExists {
-			// Drop the existing generated column to recreate with correct formula
+		// area_ha_computed must be generated from the geography cast: ST_Area on the
+		// geometry itself returns square degrees. Recreate the column when it is missing, is a
+		// plain column added by AutoMigrate or uses another formula; recreating a stored
+		// generated column recomputes every row, which backfills wrong stored areas.
+		var generationExpression string
+		gormDB.Raw(`SELECT COALESCE(generation_expression, '') FROM information_schema.columns
+			WHERE table_name = 'farms' AND column_name = 'area_ha_computed'`).Scan(&generationExpression)
+
+		if !strings.Contains(strings.ToLower(generationExpression), "geography") {
 			log.Println("Recreating area_ha_computed column with correct geography-based formula")
 			gormDB.Exec(`ALTER TABLE farms DROP COLUMN IF EXISTS area_ha_computed;`)
-		}
 
-		// Add computed area column for farms with PostGIS using geography for accurate area calculation
-		// ST_Area(geometry::geography) returns area in square meters, divide by 10000 for hectares
-		gormDB.Exec(`ALTER TABLE farms ADD COLUMN IF NOT EXISTS area_ha_computed NUMERIC(12,4)
-			GENERATED ALWAYS AS (ST_Area(geometry::geography)/10000.0) STORED;`)
+			// ST_Area(geometry::geography) returns area in square meters, divide by 10000 for hectares
+			gormDB.Exec(`ALTER TABLE farms ADD COLUMN IF NOT EXISTS area_ha_computed NUMERIC(12,4)
+				GENERATED ALWAYS AS (ST_Area(geometry::geography)/10000.0) STORED;`)
+		}
 
 		// Create spatial indexes
 		gormDB.Exec(`CREATE INDEX IF NOT EXISTS farms_geometry_gist ON farms USING GIST (geometry::geometry);`)
+		gormDB.Exec(`CREATE INDEX IF NOT EXISTS admin_boundaries_geometry_gist ON admin_boundaries USING GIST (geometry);`)
 
 		// Add SRID validation constraint
 		gormDB.Exec(`ALTER TABLE farms ADD CONSTRAINT IF NOT EXISTS farms_geometry_srid_check
@@ -480,6 +543,8 @@ func initializeCounters(gormDB *gorm.DB) error {
 		{"irrigation_sources", "IRRG", hash.Tiny},
 		{"bulk_operations", "BLKO", hash.Medium},
 		{"bulk_processing_details", "BLKD", hash.Large},
+		{"bulk_column_mapping_profiles", "BLKM", hash.Small},
+		{"idempotency_keys", "IDEM", hash.Large},
 	}
 
 	for _, table := range tables {
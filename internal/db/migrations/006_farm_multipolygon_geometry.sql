@@ -0,0 +1,21 @@
+-- Migration: Store farm boundaries as MULTIPOLYGON
+-- Farms may now consist of several disjoint parts and may have holes (interior rings)
+--
+-- Issue: farms.geometry was geometry(POLYGON,4326), which rejects multi-part boundaries
+-- Fix: Widen the column to geometry(MULTIPOLYGON,4326); ST_Multi wraps each existing
+--      polygon, holes included, as a single-part multipolygon without changing its shape
+--
+-- Date: 2026-10-15
+-- Related: Farm entity geometry field, area_ha_computed column
+
+-- area_ha_computed is generated from geometry and must be dropped before the type change
+ALTER TABLE farms DROP COLUMN IF EXISTS area_ha_computed;
+
+ALTER TABLE farms ALTER COLUMN geometry TYPE geometry(MULTIPOLYGON,4326)
+    USING ST_Multi(geometry);
+
+-- Re-create the area column; ST_Area over the geography sums every part and subtracts holes
+ALTER TABLE farms ADD COLUMN area_ha_computed NUMERIC(12,4)
+    GENERATED ALWAYS AS (ST_Area(geometry::geography)/10000.0) STORED;
+
+COMMENT ON COLUMN farms.area_ha_computed IS 'Automatically calculated farm area in hectares using PostGIS geography calculations. Generated from geometry field.';
@@ -0,0 +1,90 @@
+package admin_boundary
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/Kisanlink/kisanlink-db/pkg/base"
+	"github.com/Kisanlink/kisanlink-db/pkg/core/hash"
+)
+
+// AdminLevel is a tier of the administrative hierarchy
+type AdminLevel string
+
+const (
+	AdminLevelState    AdminLevel = "STATE"
+	AdminLevelDistrict AdminLevel = "DISTRICT"
+	AdminLevelBlock    AdminLevel = "BLOCK" // Block, tehsil or taluka
+	AdminLevelVillage  AdminLevel = "VILLAGE"
+)
+
+// AdminLevels lists the administrative levels from the largest to the smallest
+var AdminLevels = []AdminLevel{AdminLevelState, AdminLevelDistrict, AdminLevelBlock, AdminLevelVillage}
+
+// ParseAdminLevel reads an administrative level, ignoring case
+func ParseAdminLevel(s string) (AdminLevel, bool) {
+	level := AdminLevel(strings.ToUpper(strings.TrimSpace(s)))
+	for _, known := range AdminLevels {
+		if level == known {
+			return level, true
+		}
+	}
+	return "", false
+}
+
+// FarmColumn returns the farms column that holds the code of the farm's area at this level
+func (l AdminLevel) FarmColumn() string {
+	return strings.ToLower(string(l)) + "_code"
+}
+
+// AdminBoundary is the polygon of a state, district, block or village, keyed by its code in
+// the official census or LGD directory. Farms are tagged with the codes of the boundaries
+// that contain them.
+type AdminBoundary struct {
+	base.BaseModel
+	Level      AdminLevel `json:"level" gorm:"type:varchar(20);not null;uniqueIndex:idx_admin_boundaries_level_code,priority:1"`
+	Code       string     `json:"code" gorm:"type:varchar(20);not null;uniqueIndex:idx_admin_boundaries_level_code,priority:2"`
+	Name       string     `json:"name" gorm:"type:varchar(255);not null"`
+	ParentCode *string    `json:"parent_code,omitempty" gorm:"type:varchar(20);index"` // Code of the enclosing area one level up
+	Geometry   string     `json:"geometry" gorm:"type:geometry(MULTIPOLYGON,4326);not null"`
+}
+
+// TableName returns the table name for AdminBoundary
+func (b *AdminBoundary) TableName() string {
+	return "admin_boundaries"
+}
+
+// GetTableIdentifier returns the table identifier for ID generation
+func (b *AdminBoundary) GetTableIdentifier() string {
+	return "ADMB"
+}
+
+// GetTableSize returns the table size for ID generation
+func (b *AdminBoundary) GetTableSize() hash.TableSize {
+	return hash.Large
+}
+
+// NewAdminBoundary creates a new administrative boundary with proper initialization
+func NewAdminBoundary() *AdminBoundary {
+	baseModel := base.NewBaseModel("ADMB", hash.Large)
+	return &AdminBoundary{
+		BaseModel: *baseModel,
+	}
+}
+
+// Validate validates the administrative boundary
+func (b *AdminBoundary) Validate() error {
+	if _, ok := ParseAdminLevel(string(b.Level)); !ok {
+		return errors.New("level must be STATE, DISTRICT, BLOCK or VILLAGE")
+	}
+	if strings.TrimSpace(b.Code) == "" {
+		return errors.New("code is required")
+	}
+	if strings.TrimSpace(b.Name) == "" {
+		return errors.New("name is required")
+	}
+	if b.Geometry == "" {
+		return errors.New("geometry is required")
+	}
+	return nil
+}
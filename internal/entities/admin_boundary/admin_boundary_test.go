@@ -0,0 +1,50 @@
+package admin_boundary
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAdminLevel(t *testing.T) {
+	level, ok := ParseAdminLevel(" village ")
+	assert.True(t, ok)
+	assert.Equal(t, AdminLevelVillage, level)
+	assert.Equal(t, "village_code", level.FarmColumn())
+
+	_, ok = ParseAdminLevel("TEHSIL")
+	assert.False(t, ok)
+}
+
+func TestAdminBoundaryValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(b *AdminBoundary)
+		wantErr bool
+	}{
+		{"valid boundary", func(b *AdminBoundary) {}, false},
+		{"unknown level", func(b *AdminBoundary) { b.Level = "TALUKA" }, true},
+		{"missing code", func(b *AdminBoundary) { b.Code = " " }, true},
+		{"missing name", func(b *AdminBoundary) { b.Name = "" }, true},
+		{"missing geometry", func(b *AdminBoundary) { b.Geometry = "" }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewAdminBoundary()
+			b.Level = AdminLevelBlock
+			b.Code = "4187"
+			b.Name = "Haveli"
+			b.Geometry = "POLYGON((73.8 18.4, 74.1 18.4, 74.1 18.7, 73.8 18.7, 73.8 18.4))"
+			tt.modify(b)
+			err := b.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	assert.Equal(t, "ADMB", NewAdminBoundary().GetTableIdentifier())
+}
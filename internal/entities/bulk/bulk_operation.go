@@ -16,6 +16,7 @@ const (
 	StatusCompleted  OperationStatus = "COMPLETED"
 	StatusFailed     OperationStatus = "FAILED"
 	StatusCancelled  OperationStatus = "CANCELLED"
+	StatusPaused     OperationStatus = "PAUSED"
 )
 
 // ProcessingMode represents the processing mode for bulk operations
@@ -34,11 +35,26 @@ const (
 	FormatCSV   InputFormat = "CSV"
 	FormatExcel InputFormat = "EXCEL"
 	FormatJSON  InputFormat = "JSON"
+
+	// Boundary formats used by farm imports
+	FormatGeoJSON   InputFormat = "GEOJSON"
+	FormatKML       InputFormat = "KML"
+	FormatShapefile InputFormat = "SHAPEFILE"
 )
 
-// BulkOperation represents a bulk farmer addition operation
+// OperationType represents what a bulk operation creates
+type OperationType string
+
+const (
+	OperationFarmerAddition  OperationType = "FARMER_ADDITION"
+	OperationFarmImport      OperationType = "FARM_IMPORT"
+	OperationCropCycleImport OperationType = "CROP_CYCLE_IMPORT"
+)
+
+// BulkOperation represents a bulk farmer addition or farm import operation
 type BulkOperation struct {
 	base.BaseModel
+	OperationType     OperationType          `json:"operation_type" gorm:"type:varchar(50);not null;default:'FARMER_ADDITION';index:idx_bulk_ops_type"`
 	FPOOrgID          string                 `json:"fpo_org_id" gorm:"type:varchar(255);not null;index:idx_bulk_ops_fpo_status,priority:1;index:idx_bulk_ops_fpo"`
 	InitiatedBy       string                 `json:"initiated_by" gorm:"type:varchar(255);not null;index:idx_bulk_ops_initiated"`
 	Status            OperationStatus        `json:"status" gorm:"type:varchar(50);not null;default:'PENDING';index:idx_bulk_ops_fpo_status,priority:2;index:idx_bulk_ops_status"`
@@ -56,6 +72,16 @@ type BulkOperation struct {
 	ErrorSummary      map[string]int         `json:"error_summary" gorm:"type:jsonb;default:'{}';serializer:json"`
 	Options           map[string]interface{} `json:"options" gorm:"type:jsonb;default:'{}';serializer:json"`
 	Metadata          map[string]interface{} `json:"metadata" gorm:"type:jsonb;default:'{}';serializer:json"`
+
+	// Queue and lease state. Queued operations are processed by the job workers of any
+	// replica; HeartbeatAt is refreshed while an instance is working on the operation.
+	Queued      bool       `json:"queued" gorm:"not null;default:false;index:idx_bulk_ops_queued"`
+	LeaseOwner  *string    `json:"lease_owner,omitempty" gorm:"type:varchar(255)"`
+	HeartbeatAt *time.Time `json:"heartbeat_at,omitempty"`
+
+	// Loading is set while the records of a streamed upload are still being read. Queued
+	// records are processed meanwhile, but the operation does not complete until loading ends.
+	Loading bool `json:"loading" gorm:"not null;default:false"`
 }
 
 // TableName returns the table name for BulkOperation
@@ -77,14 +103,21 @@ func (b *BulkOperation) GetTableSize() hash.TableSize {
 func NewBulkOperation() *BulkOperation {
 	baseModel := base.NewBaseModel("BLKO", hash.Medium)
 	return &BulkOperation{
-		BaseModel:    *baseModel,
-		Status:       StatusPending,
-		ErrorSummary: make(map[string]int),
-		Options:      make(map[string]interface{}),
-		Metadata:     make(map[string]interface{}),
+		BaseModel:     *baseModel,
+		OperationType: OperationFarmerAddition,
+		Status:        StatusPending,
+		ErrorSummary:  make(map[string]int),
+		Options:       make(map[string]interface{}),
+		Metadata:      make(map[string]interface{}),
 	}
 }
 
+// IsFarmerAddition returns true if the operation adds farmers. Operations created before
+// operation types were recorded have no type and are farmer additions.
+func (b *BulkOperation) IsFarmerAddition() bool {
+	return b.OperationType == "" || b.OperationType == OperationFarmerAddition
+}
+
 // UpdateProgress updates the progress of the bulk operation
 func (b *BulkOperation) UpdateProgress(processed, successful, failed, skipped int) {
 	b.ProcessedRecords = processed
@@ -115,6 +148,16 @@ func (b *BulkOperation) IsComplete() bool {
 	return b.Status == StatusCompleted || b.Status == StatusFailed || b.Status == StatusCancelled
 }
 
+// CanPause returns true if the operation can be paused
+func (b *BulkOperation) CanPause() bool {
+	return b.Status == StatusPending || b.Status == StatusProcessing
+}
+
+// CanResume returns true if the operation can be resumed
+func (b *BulkOperation) CanResume() bool {
+	return b.Status == StatusPaused
+}
+
 // CanRetry returns true if the operation can be retried
 func (b *BulkOperation) CanRetry() bool {
 	return b.Status == StatusFailed || b.Status == StatusCompleted && b.FailedRecords > 0
@@ -0,0 +1,175 @@
+package bulk
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Kisanlink/kisanlink-db/pkg/base"
+	"github.com/Kisanlink/kisanlink-db/pkg/core/hash"
+)
+
+// MappingTransform represents how a column mapping derives its value
+type MappingTransform string
+
+const (
+	TransformCopy      MappingTransform = "copy"       // value of the source column
+	TransformSplitName MappingTransform = "split_name" // first word or remainder of a full name column
+	TransformConcat    MappingTransform = "concat"     // source columns joined with a separator
+	TransformDefault   MappingTransform = "default"    // constant default value
+	TransformLookup    MappingTransform = "lookup"     // source value translated through a lookup table
+)
+
+// Name parts for the split_name transform
+const (
+	NamePartFirst = "first"
+	NamePartLast  = "last"
+)
+
+// CustomFieldPrefix marks mapping targets that are stored as custom fields
+const CustomFieldPrefix = "custom_fields."
+
+// MappableFields are the farmer bulk data fields a column mapping can target
+var MappableFields = []string{
+	"first_name",
+	"last_name",
+	"country_code",
+	"phone_number",
+	"email",
+	"date_of_birth",
+	"gender",
+	"street_address",
+	"city",
+	"state",
+	"postal_code",
+	"country",
+	"land_ownership_type",
+	"external_id",
+}
+
+// ColumnMapping maps one or more source columns of an upload to a farmer field. Mappings
+// to the same target are tried in order and the first non-empty value is used.
+type ColumnMapping struct {
+	Target    string            `json:"target"`
+	Source    string            `json:"source,omitempty"`
+	Sources   []string          `json:"sources,omitempty"`
+	Transform MappingTransform  `json:"transform,omitempty"`
+	Part      string            `json:"part,omitempty"`
+	Separator string            `json:"separator,omitempty"`
+	Lookup    map[string]string `json:"lookup,omitempty"`
+	Default   string            `json:"default,omitempty"`
+}
+
+// ColumnMappingProfile is a saved set of column mappings for an FPO's upload spreadsheets
+type ColumnMappingProfile struct {
+	base.BaseModel
+	FPOOrgID     string                 `json:"fpo_org_id" gorm:"type:varchar(255);not null;uniqueIndex:idx_mapping_profiles_fpo_name,priority:1"`
+	Name         string                 `json:"name" gorm:"type:varchar(255);not null;uniqueIndex:idx_mapping_profiles_fpo_name,priority:2"`
+	Description  string                 `json:"description" gorm:"type:text"`
+	Mappings     []ColumnMapping        `json:"mappings" gorm:"type:jsonb;default:'[]';serializer:json"`
+	KeepUnmapped bool                   `json:"keep_unmapped" gorm:"not null;default:false"` // pass unmapped columns through as template columns or custom fields
+	Metadata     map[string]interface{} `json:"metadata" gorm:"type:jsonb;default:'{}';serializer:json"`
+}
+
+// TableName returns the table name for ColumnMappingProfile
+func (p *ColumnMappingProfile) TableName() string {
+	return "bulk_column_mapping_profiles"
+}
+
+// GetTableIdentifier returns the table identifier for ID generation
+func (p *ColumnMappingProfile) GetTableIdentifier() string {
+	return "BLKM"
+}
+
+// GetTableSize returns the table size for ID generation
+func (p *ColumnMappingProfile) GetTableSize() hash.TableSize {
+	return hash.Small
+}
+
+// NewColumnMappingProfile creates a new column mapping profile with proper initialization
+func NewColumnMappingProfile() *ColumnMappingProfile {
+	baseModel := base.NewBaseModel("BLKM", hash.Small)
+	return &ColumnMappingProfile{
+		BaseModel: *baseModel,
+		Mappings:  []ColumnMapping{},
+		Metadata:  make(map[string]interface{}),
+	}
+}
+
+// Validate checks that the profile has a name and that every mapping is complete
+func (p *ColumnMappingProfile) Validate() error {
+	if strings.TrimSpace(p.FPOOrgID) == "" {
+		return fmt.Errorf("fpo_org_id is required")
+	}
+	if strings.TrimSpace(p.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	if len(p.Mappings) == 0 {
+		return fmt.Errorf("at least one mapping is required")
+	}
+
+	for i, mapping := range p.Mappings {
+		if err := mapping.Validate(); err != nil {
+			return fmt.Errorf("mapping %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Validate checks that the mapping targets a known field and has what its transform needs
+func (m ColumnMapping) Validate() error {
+	if !isMappableTarget(m.Target) {
+		return fmt.Errorf("unknown target field %q", m.Target)
+	}
+
+	switch m.EffectiveTransform() {
+	case TransformCopy:
+		if m.Source == "" {
+			return fmt.Errorf("source is required for %s", TransformCopy)
+		}
+	case TransformSplitName:
+		if m.Source == "" {
+			return fmt.Errorf("source is required for %s", TransformSplitName)
+		}
+		if m.Part != NamePartFirst && m.Part != NamePartLast {
+			return fmt.Errorf("part must be %q or %q for %s", NamePartFirst, NamePartLast, TransformSplitName)
+		}
+	case TransformConcat:
+		if len(m.Sources) < 2 {
+			return fmt.Errorf("at least two sources are required for %s", TransformConcat)
+		}
+	case TransformDefault:
+		if m.Default == "" {
+			return fmt.Errorf("default is required for %s", TransformDefault)
+		}
+	case TransformLookup:
+		if m.Source == "" {
+			return fmt.Errorf("source is required for %s", TransformLookup)
+		}
+		if len(m.Lookup) == 0 {
+			return fmt.Errorf("lookup table is required for %s", TransformLookup)
+		}
+	default:
+		return fmt.Errorf("unknown transform %q", m.Transform)
+	}
+	return nil
+}
+
+// EffectiveTransform returns the transform of the mapping, defaulting to copy
+func (m ColumnMapping) EffectiveTransform() MappingTransform {
+	if m.Transform == "" {
+		return TransformCopy
+	}
+	return m.Transform
+}
+
+func isMappableTarget(target string) bool {
+	if strings.HasPrefix(target, CustomFieldPrefix) {
+		return len(target) > len(CustomFieldPrefix)
+	}
+	for _, field := range MappableFields {
+		if target == field {
+			return true
+		}
+	}
+	return false
+}
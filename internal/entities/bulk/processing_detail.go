@@ -33,6 +33,10 @@ type ProcessingDetail struct {
 	ProcessingTime  int64                  `json:"processing_time" gorm:"type:bigint"` // in milliseconds
 	RetryCount      int                    `json:"retry_count" gorm:"type:integer;not null;default:0;index:idx_processing_details_op_retry,priority:3,where:status = 'FAILED'"`
 	Metadata        map[string]interface{} `json:"metadata" gorm:"type:jsonb;default:'{}';serializer:json"`
+
+	// Lease held by the job worker processing the record; expired leases can be claimed again
+	LeaseOwner     *string    `json:"lease_owner,omitempty" gorm:"type:varchar(255)"`
+	LeaseExpiresAt *time.Time `json:"lease_expires_at,omitempty" gorm:"index:idx_processing_details_lease"`
 }
 
 // TableName returns the table name for ProcessingDetail
@@ -70,6 +74,7 @@ func (p *ProcessingDetail) SetSuccess(farmerID, aaaUserID string) {
 	p.AAAUserID = &aaaUserID
 	now := time.Now()
 	p.ProcessedAt = &now
+	p.releaseLease()
 }
 
 // SetFailed marks the processing detail as failed
@@ -79,6 +84,7 @@ func (p *ProcessingDetail) SetFailed(error string, errorCode string) {
 	p.ErrorCode = &errorCode
 	now := time.Now()
 	p.ProcessedAt = &now
+	p.releaseLease()
 }
 
 // SetSkipped marks the processing detail as skipped
@@ -87,6 +93,13 @@ func (p *ProcessingDetail) SetSkipped(reason string) {
 	p.Error = &reason
 	now := time.Now()
 	p.ProcessedAt = &now
+	p.releaseLease()
+}
+
+// releaseLease clears the worker lease of a record that has been processed
+func (p *ProcessingDetail) releaseLease() {
+	p.LeaseOwner = nil
+	p.LeaseExpiresAt = nil
 }
 
 // CanRetry returns true if the record can be retried
@@ -0,0 +1,149 @@
+package changefeed
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Entity identifies a kind of record offered to offline clients
+type Entity string
+
+const (
+	EntityFarmer       Entity = "farmer"
+	EntityFarm         Entity = "farm"
+	EntityCropCycle    Entity = "crop_cycle"
+	EntityFarmActivity Entity = "farm_activity"
+)
+
+// Entities lists the synced entities in feed order: changes with the same timestamp are
+// returned parents first, so a client never receives a record before its farmer or farm
+var Entities = []Entity{EntityFarmer, EntityFarm, EntityCropCycle, EntityFarmActivity}
+
+// Rank returns the position of the entity in feed order, or -1 for an unknown entity
+func (e Entity) Rank() int {
+	for i, entity := range Entities {
+		if entity == e {
+			return i
+		}
+	}
+	return -1
+}
+
+// IsValid reports whether the entity is synced
+func (e Entity) IsValid() bool {
+	return e.Rank() >= 0
+}
+
+// Operation is a change made offline by a client and pushed to the server
+type Operation string
+
+const (
+	OperationCreate   Operation = "create"
+	OperationUpdate   Operation = "update"
+	OperationDelete   Operation = "delete"
+	OperationEnd      Operation = "end"      // crop cycles only
+	OperationComplete Operation = "complete" // farm activities only
+)
+
+// Supports reports whether the entity accepts the operation
+func (e Entity) Supports(op Operation) bool {
+	switch op {
+	case OperationCreate, OperationUpdate:
+		return e.IsValid()
+	case OperationDelete:
+		// Crop cycles are ended rather than deleted
+		return e.IsValid() && e != EntityCropCycle
+	case OperationEnd:
+		return e == EntityCropCycle
+	case OperationComplete:
+		return e == EntityFarmActivity
+	}
+	return false
+}
+
+// MutationStatus is the outcome of applying a pushed operation
+type MutationStatus string
+
+const (
+	// MutationApplied means the operation was applied, or had already been applied
+	MutationApplied MutationStatus = "APPLIED"
+	// MutationConflict means the record changed on the server since the client's copy
+	MutationConflict MutationStatus = "CONFLICT"
+	// MutationRejected means the operation is invalid or not permitted and must not be retried
+	MutationRejected MutationStatus = "REJECTED"
+	// MutationFailed means the operation could not be applied and may be retried
+	MutationFailed MutationStatus = "FAILED"
+)
+
+// Change is the latest state of a record changed since a cursor. Soft-deleted records are
+// reported with DeletedAt set so that clients can drop their copy.
+type Change struct {
+	Entity    Entity
+	ID        string
+	FarmerID  string
+	UpdatedAt time.Time
+	DeletedAt *time.Time
+	Record    interface{}
+}
+
+// Deleted reports whether the record was soft deleted
+func (c *Change) Deleted() bool {
+	return c.DeletedAt != nil
+}
+
+// ChangedAt returns when the record last changed, which is its deletion time for deleted
+// records
+func (c *Change) ChangedAt() time.Time {
+	if c.DeletedAt != nil && c.DeletedAt.After(c.UpdatedAt) {
+		return *c.DeletedAt
+	}
+	return c.UpdatedAt
+}
+
+// Cursor is a position in the change feed. Changes are ordered by change time, then entity
+// rank, then record ID, and a cursor points at the last change a client has received.
+type Cursor struct {
+	ChangedAt time.Time `json:"t"`
+	Entity    Entity    `json:"e"`
+	ID        string    `json:"i"`
+}
+
+// CursorAt returns the cursor positioned at a change
+func CursorAt(change *Change) Cursor {
+	return Cursor{ChangedAt: change.ChangedAt(), Entity: change.Entity, ID: change.ID}
+}
+
+// IsZero reports whether the cursor is the start of the feed
+func (c Cursor) IsZero() bool {
+	return c.ChangedAt.IsZero() && c.Entity == "" && c.ID == ""
+}
+
+// Encode returns the opaque form of the cursor handed to clients
+func (c Cursor) Encode() string {
+	if c.IsZero() {
+		return ""
+	}
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses a cursor returned by Encode. The empty string is the start of the feed.
+func DecodeCursor(s string) (Cursor, error) {
+	var cursor Cursor
+	if s == "" {
+		return cursor, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return cursor, fmt.Errorf("malformed sync cursor")
+	}
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return cursor, fmt.Errorf("malformed sync cursor")
+	}
+	if cursor.ChangedAt.IsZero() || !cursor.Entity.IsValid() || cursor.ID == "" {
+		return Cursor{}, fmt.Errorf("malformed sync cursor")
+	}
+	return cursor, nil
+}
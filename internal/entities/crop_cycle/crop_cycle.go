@@ -17,6 +17,7 @@ type CropCycle struct {
 	base.BaseModel
 	FarmID    string         `json:"farm_id" gorm:"type:varchar(255);not null;index"`
 	FarmerID  string         `json:"farmer_id" gorm:"type:varchar(255);not null;index"`
+	PlotID    *string        `json:"plot_id,omitempty" gorm:"type:varchar(255);index"` // Plot of the farm the crop is grown on
 	AreaHa    *float64       `json:"area_ha" gorm:"type:decimal(12,4);check:area_ha > 0;index:idx_crop_cycles_farm_area"`
 	Season    string         `json:"season" gorm:"type:season;not null"`
 	Status    string         `json:"status" gorm:"type:cycle_status;not null;default:'PLANNED'"`
@@ -26,6 +27,10 @@ type CropCycle struct {
 	VarietyID *string        `json:"variety_id" gorm:"type:uuid;index"`
 	Outcome   entities.JSONB `json:"outcome" gorm:"type:jsonb;default:'{}';serializer:json"`
 
+	// Trees of a perennial crop, for estimating its yield from the variety's yield per tree
+	TreeCount    *int `json:"tree_count,omitempty" gorm:"type:integer;check:tree_count > 0"`
+	PlantingYear *int `json:"planting_year,omitempty" gorm:"type:integer"` // Year the trees were planted, for their age
+
 	// Relationships
 	Farmer  *farmer.Farmer            `json:"farmer,omitempty" gorm:"foreignKey:FarmerID;references:ID;constraint:OnDelete:CASCADE"`
 	Crop    *crop.Crop                `json:"crop,omitempty" gorm:"foreignKey:CropID;references:ID"`
@@ -69,6 +74,13 @@ func (cc *CropCycle) Validate() error {
 	if cc.StartDate != nil && cc.EndDate != nil && cc.EndDate.Before(*cc.StartDate) {
 		return common.ErrInvalidCropCycleData
 	}
+	// Tree validation: trees cannot be planted after the cycle starts
+	if cc.TreeCount != nil && *cc.TreeCount <= 0 {
+		return common.ErrInvalidCropCycleData
+	}
+	if cc.PlantingYear != nil && (*cc.PlantingYear <= 0 || (cc.StartDate != nil && *cc.PlantingYear > cc.StartDate.Year())) {
+		return common.ErrInvalidCropCycleData
+	}
 	return nil
 }
 
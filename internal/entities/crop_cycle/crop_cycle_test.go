@@ -9,6 +9,9 @@ import (
 )
 
 func TestCropCycleValidate(t *testing.T) {
+	startDate := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	treeCount, plantingYear, noTrees := 120, 2025, 0
+
 	tests := []struct {
 		name      string
 		cropCycle *CropCycle
@@ -52,6 +55,32 @@ func TestCropCycleValidate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "trees planted after the cycle starts",
+			cropCycle: &CropCycle{
+				FarmID:       "farm123",
+				FarmerID:     "farmer123",
+				Season:       "PERENNIAL",
+				Status:       "PLANNED",
+				CropID:       "crop123",
+				StartDate:    &startDate,
+				TreeCount:    &treeCount,
+				PlantingYear: &plantingYear,
+			},
+			wantErr: true,
+		},
+		{
+			name: "no trees",
+			cropCycle: &CropCycle{
+				FarmID:    "farm123",
+				FarmerID:  "farmer123",
+				Season:    "PERENNIAL",
+				Status:    "PLANNED",
+				CropID:    "crop123",
+				TreeCount: &noTrees,
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1,6 +1,10 @@
 package crop_cycle
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/Kisanlink/farmers-module/pkg/units"
+)
 
 // PerennialCropOutcome represents yield data for perennial crops
 // Used when Season = PERENNIAL
@@ -13,16 +17,35 @@ type PerennialCropOutcome struct {
 	TotalYield    *float64 `json:"total_yield,omitempty" validate:"omitempty,gt=0" example:"5050"`
 	QualityGrade  string   `json:"quality_grade,omitempty" example:"A"`
 	Notes         string   `json:"notes,omitempty" example:"Trees in prime productive age"`
+
+	// Set when the cycle is ended
+	Normalized *NormalizedYield `json:"normalized,omitempty"`
 }
 
 // AnnualCropOutcome represents yield data for annual crops
-// Used when Season = RABI, KHARIF, ZAID, or OTHER
+// Used when Season = RABI, KHARIF, ZAID, or OTHER. The yield may be given per another area
+// unit in yield_per_area and area_unit instead of per hectare, e.g. quintals per acre; its
+// yield_per_hectare is then worked out when the cycle is ended.
 type AnnualCropOutcome struct {
-	YieldPerHectare float64  `json:"yield_per_hectare" validate:"required,gt=0" example:"2500"`
+	YieldPerHectare float64  `json:"yield_per_hectare" validate:"required_without=YieldPerArea" example:"2500"`
+	YieldPerArea    *float64 `json:"yield_per_area,omitempty" validate:"omitempty,gt=0" example:"10"`
+	AreaUnit        string   `json:"area_unit,omitempty" validate:"required_with=YieldPerArea" example:"acre"`
 	YieldUnit       string   `json:"yield_unit" validate:"required" example:"kg"`
 	TotalYield      *float64 `json:"total_yield,omitempty" validate:"omitempty,gt=0" example:"12500"`
 	QualityGrade    string   `json:"quality_grade,omitempty" example:"A"`
 	Notes           string   `json:"notes,omitempty" example:"Good weather conditions"`
+
+	// Set when the cycle is ended
+	Normalized *NormalizedYield `json:"normalized,omitempty"`
+}
+
+// NormalizedYield represents an outcome's yield in the canonical unit of its yield unit:
+// kilograms, or numbers for crops counted rather than weighed
+type NormalizedYield struct {
+	YieldUnit       string   `json:"yield_unit" example:"kg"`
+	YieldPerHectare *float64 `json:"yield_per_hectare,omitempty" example:"2500"`
+	YieldPerTree    *float64 `json:"yield_per_tree,omitempty" example:"50.5"`
+	TotalYield      *float64 `json:"total_yield,omitempty" example:"12500"`
 }
 
 // ValidatePerennialOutcome validates outcome data for perennial crops
@@ -150,7 +173,10 @@ func ValidateAnnualOutcome(outcome map[string]interface{}) error {
 	yieldPerHectare, hasYield := outcome["yield_per_hectare"]
 	yieldUnit, hasUnit := outcome["yield_unit"]
 
-	if !hasYield {
+	yieldPerArea, hasPerArea := outcome["yield_per_area"]
+	areaUnit, hasAreaUnit := outcome["area_unit"]
+
+	if !hasYield && !hasPerArea {
 		return fmt.Errorf("annual crop outcome must include yield_per_hectare")
 	}
 	if !hasUnit {
@@ -158,18 +184,45 @@ func ValidateAnnualOutcome(outcome map[string]interface{}) error {
 	}
 
 	// Convert and validate yield_per_hectare
-	var yieldValue float64
-	switch v := yieldPerHectare.(type) {
-	case float64:
-		yieldValue = v
-	case int:
-		yieldValue = float64(v)
-	default:
-		return fmt.Errorf("yield_per_hectare must be a number")
+	if hasYield {
+		var yieldValue float64
+		switch v := yieldPerHectare.(type) {
+		case float64:
+			yieldValue = v
+		case int:
+			yieldValue = float64(v)
+		default:
+			return fmt.Errorf("yield_per_hectare must be a number")
+		}
+
+		if yieldValue <= 0 {
+			return fmt.Errorf("yield_per_hectare must be greater than 0")
+		}
 	}
 
-	if yieldValue <= 0 {
-		return fmt.Errorf("yield_per_hectare must be greater than 0")
+	// Convert and validate yield_per_area, which needs the area unit it is per
+	if hasPerArea {
+		var perAreaValue float64
+		switch v := yieldPerArea.(type) {
+		case float64:
+			perAreaValue = v
+		case int:
+			perAreaValue = float64(v)
+		default:
+			return fmt.Errorf("yield_per_area must be a number")
+		}
+
+		if perAreaValue <= 0 {
+			return fmt.Errorf("yield_per_area must be greater than 0")
+		}
+		if !hasAreaUnit {
+			return fmt.Errorf("annual crop outcome with yield_per_area must include area_unit")
+		}
+	}
+	if hasAreaUnit {
+		if _, ok := areaUnit.(string); !ok {
+			return fmt.Errorf("area_unit must be a string")
+		}
 	}
 
 	// Validate yield_unit is a string
@@ -196,3 +249,98 @@ func ValidateAnnualOutcome(outcome map[string]interface{}) error {
 
 	return nil
 }
+
+// NormalizeOutcome checks the units of the cycle's validated outcome against the unit
+// registry and records its yield in canonical units under "normalized", keeping the values
+// as entered. An annual yield given per another area unit gets its yield_per_hectare worked
+// out. The state code sizes local area units such as the bigha.
+func (cc *CropCycle) NormalizeOutcome(stateCode string) error {
+	if len(cc.Outcome) == 0 {
+		return nil
+	}
+
+	yieldUnit, _ := cc.Outcome["yield_unit"].(string)
+	unit, err := units.Parse(yieldUnit)
+	if err != nil {
+		return fmt.Errorf("yield_unit: %w", err)
+	}
+	if unit.Dimension != units.DimensionMass && unit.Dimension != units.DimensionCount {
+		return fmt.Errorf("yield_unit %s is not a unit of mass or a count", unit.Code)
+	}
+
+	if !cc.IsPerennial() {
+		if areaUnit, ok := cc.Outcome["area_unit"].(string); ok {
+			perHectare, err := units.Convert(1, units.Hectare, areaUnit, stateCode)
+			if err != nil {
+				return fmt.Errorf("area_unit: %w", err)
+			}
+			perArea := outcomeNumber(cc.Outcome, "yield_per_area")
+			if _, hasYield := cc.Outcome["yield_per_hectare"]; !hasYield && perArea != nil {
+				cc.Outcome["yield_per_hectare"] = *perArea * perHectare
+			}
+		}
+	}
+
+	normalized := map[string]interface{}{"yield_unit": unit.Dimension.Canonical()}
+	for _, key := range []string{"yield_per_hectare", "yield_per_tree", "total_yield"} {
+		if value := outcomeNumber(cc.Outcome, key); value != nil {
+			normalized[key] = *value * unit.Factor
+		}
+	}
+	cc.Outcome["normalized"] = normalized
+	return nil
+}
+
+// CanonicalYield returns the yield recorded in the cycle's outcome like OutcomeYield, but in
+// the canonical unit of its yield unit so that cycles can be compared and added up. It
+// returns nils when the outcome has no yield or its unit is not known.
+func (cc *CropCycle) CanonicalYield() (perHectare, total *float64, unit string) {
+	perHectare, total, yieldUnit := cc.OutcomeYield()
+	u, ok := units.Lookup(yieldUnit)
+	if !ok || u.IsLocal() || (u.Dimension != units.DimensionMass && u.Dimension != units.DimensionCount) {
+		return nil, nil, ""
+	}
+	if perHectare != nil {
+		value := *perHectare * u.Factor
+		perHectare = &value
+	}
+	if total != nil {
+		value := *total * u.Factor
+		total = &value
+	}
+	return perHectare, total, u.Dimension.Canonical()
+}
+
+// OutcomeYield returns the yield recorded in the cycle's outcome: the yield per hectare, the
+// total yield and their unit. Perennial outcomes record yield per tree, so their yield per
+// hectare is the total yield over the cycle's area when both are known. Nil values were not
+// recorded.
+func (cc *CropCycle) OutcomeYield() (perHectare, total *float64, unit string) {
+	if len(cc.Outcome) == 0 {
+		return nil, nil, ""
+	}
+	unit, _ = cc.Outcome["yield_unit"].(string)
+	total = outcomeNumber(cc.Outcome, "total_yield")
+	if cc.IsPerennial() {
+		if total != nil && cc.AreaHa != nil && *cc.AreaHa > 0 {
+			value := *total / *cc.AreaHa
+			perHectare = &value
+		}
+		return perHectare, total, unit
+	}
+	return outcomeNumber(cc.Outcome, "yield_per_hectare"), total, unit
+}
+
+// outcomeNumber returns a numeric outcome field, or nil when it is missing or not a number
+func outcomeNumber(outcome map[string]interface{}, key string) *float64 {
+	var value float64
+	switch v := outcome[key].(type) {
+	case float64:
+		value = v
+	case int:
+		value = float64(v)
+	default:
+		return nil
+	}
+	return &value
+}
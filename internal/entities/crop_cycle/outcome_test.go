@@ -288,6 +288,32 @@ func TestValidateAnnualOutcome(t *testing.T) {
 			},
 			expectedError: "total_yield must be greater than 0",
 		},
+		{
+			name: "valid annual outcome with yield per area unit",
+			outcome: map[string]interface{}{
+				"yield_per_area": 10,
+				"area_unit":      "acre",
+				"yield_unit":     "quintal",
+			},
+			expectedError: "",
+		},
+		{
+			name: "yield_per_area without area_unit",
+			outcome: map[string]interface{}{
+				"yield_per_area": 10,
+				"yield_unit":     "quintal",
+			},
+			expectedError: "annual crop outcome with yield_per_area must include area_unit",
+		},
+		{
+			name: "zero yield_per_area",
+			outcome: map[string]interface{}{
+				"yield_per_area": 0,
+				"area_unit":      "acre",
+				"yield_unit":     "quintal",
+			},
+			expectedError: "yield_per_area must be greater than 0",
+		},
 	}
 
 	for _, tt := range tests {
@@ -414,3 +440,102 @@ func TestCropCycle_IsPerennial(t *testing.T) {
 		})
 	}
 }
+
+func TestCropCycle_OutcomeYield(t *testing.T) {
+	area := 2.0
+
+	annual := &CropCycle{Season: "KHARIF", AreaHa: &area, Outcome: map[string]interface{}{
+		"yield_per_hectare": 2500.0,
+		"yield_unit":        "kg",
+		"total_yield":       5000,
+	}}
+	perHectare, total, unit := annual.OutcomeYield()
+	assert.Equal(t, 2500.0, *perHectare)
+	assert.Equal(t, 5000.0, *total)
+	assert.Equal(t, "kg", unit)
+
+	perennial := &CropCycle{Season: "PERENNIAL", AreaHa: &area, Outcome: map[string]interface{}{
+		"age_range_min":  5,
+		"age_range_max":  10,
+		"yield_per_tree": 50.0,
+		"yield_unit":     "kg",
+		"total_yield":    6000.0,
+	}}
+	perHectare, total, _ = perennial.OutcomeYield()
+	assert.Equal(t, 3000.0, *perHectare)
+	assert.Equal(t, 6000.0, *total)
+
+	perHectare, total, unit = (&CropCycle{Season: "RABI"}).OutcomeYield()
+	assert.Nil(t, perHectare)
+	assert.Nil(t, total)
+	assert.Empty(t, unit)
+}
+
+func TestCropCycle_NormalizeOutcome(t *testing.T) {
+	annual := &CropCycle{Season: "KHARIF", Outcome: map[string]interface{}{
+		"yield_per_hectare": 25.0,
+		"yield_unit":        "Qtl",
+		"total_yield":       50,
+	}}
+	assert.NoError(t, annual.NormalizeOutcome(""))
+	assert.Equal(t, 25.0, annual.Outcome["yield_per_hectare"])
+	assert.Equal(t, "Qtl", annual.Outcome["yield_unit"])
+	normalized := annual.Outcome["normalized"].(map[string]interface{})
+	assert.Equal(t, "kg", normalized["yield_unit"])
+	assert.InEpsilon(t, 2500.0, normalized["yield_per_hectare"], 1e-9)
+	assert.InEpsilon(t, 5000.0, normalized["total_yield"], 1e-9)
+
+	// Quintals per bigha in West Bengal, where a bigha is 14,400 sq ft
+	perBigha := &CropCycle{Season: "RABI", Outcome: map[string]interface{}{
+		"yield_per_area": 5.0,
+		"area_unit":      "bigha",
+		"yield_unit":     "quintal",
+	}}
+	assert.NoError(t, perBigha.NormalizeOutcome("19"))
+	assert.InEpsilon(t, 37.374, perBigha.Outcome["yield_per_hectare"], 1e-4)
+	assert.InEpsilon(t, 3737.4, perBigha.Outcome["normalized"].(map[string]interface{})["yield_per_hectare"], 1e-4)
+	assert.Equal(t, 5.0, perBigha.Outcome["yield_per_area"])
+
+	err := (&CropCycle{Season: "RABI", Outcome: map[string]interface{}{
+		"yield_per_area": 5.0, "area_unit": "bigha", "yield_unit": "quintal",
+	}}).NormalizeOutcome("")
+	assert.ErrorContains(t, err, "area_unit: the size of a bigha differs between states")
+
+	perennial := &CropCycle{Season: "PERENNIAL", Outcome: map[string]interface{}{
+		"age_range_min":  5,
+		"age_range_max":  10,
+		"yield_per_tree": 8,
+		"yield_unit":     "dozen",
+	}}
+	assert.NoError(t, perennial.NormalizeOutcome(""))
+	normalized = perennial.Outcome["normalized"].(map[string]interface{})
+	assert.Equal(t, "nos", normalized["yield_unit"])
+	assert.InEpsilon(t, 96.0, normalized["yield_per_tree"], 1e-9)
+
+	err = (&CropCycle{Season: "RABI", Outcome: map[string]interface{}{"yield_per_hectare": 2.0, "yield_unit": "bags"}}).NormalizeOutcome("")
+	assert.EqualError(t, err, `yield_unit: unknown unit "bags"`)
+
+	err = (&CropCycle{Season: "RABI", Outcome: map[string]interface{}{"yield_per_hectare": 2.0, "yield_unit": "acre"}}).NormalizeOutcome("")
+	assert.EqualError(t, err, "yield_unit acre is not a unit of mass or a count")
+
+	assert.NoError(t, (&CropCycle{Season: "RABI"}).NormalizeOutcome(""))
+}
+
+func TestCropCycle_CanonicalYield(t *testing.T) {
+	area := 2.0
+	cycle := &CropCycle{Season: "KHARIF", AreaHa: &area, Outcome: map[string]interface{}{
+		"yield_per_hectare": 2.5,
+		"yield_unit":        "tonne",
+		"total_yield":       5.0,
+	}}
+	perHectare, total, unit := cycle.CanonicalYield()
+	assert.InEpsilon(t, 2500.0, *perHectare, 1e-9)
+	assert.InEpsilon(t, 5000.0, *total, 1e-9)
+	assert.Equal(t, "kg", unit)
+
+	cycle.Outcome["yield_unit"] = "baskets"
+	perHectare, total, unit = cycle.CanonicalYield()
+	assert.Nil(t, perHectare)
+	assert.Nil(t, total)
+	assert.Empty(t, unit)
+}
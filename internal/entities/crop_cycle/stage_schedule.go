@@ -0,0 +1,125 @@
+package crop_cycle
+
+import (
+	"time"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/stage"
+)
+
+// ScheduleStatus represents whether a crop cycle is keeping to its crop's stage calendar
+type ScheduleStatus string
+
+const (
+	ScheduleStatusOnTrack     ScheduleStatus = "ON_TRACK"
+	ScheduleStatusBehind      ScheduleStatus = "BEHIND"
+	ScheduleStatusUnscheduled ScheduleStatus = "UNSCHEDULED" // No start date, or the current stage's window is unknown
+)
+
+// StageWindow represents when a stage of a crop cycle was expected to run and when it did
+type StageWindow struct {
+	CropStage     *stage.CropStage
+	ExpectedStart *time.Time
+	ExpectedEnd   *time.Time
+	ActualStart   *time.Time
+	ActualEnd     *time.Time // When a later stage started
+}
+
+// StartDelayDays returns how many days after its expected start the stage started, or nil
+// when either is unknown
+func (w *StageWindow) StartDelayDays() *int {
+	if w.ExpectedStart == nil || w.ActualStart == nil {
+		return nil
+	}
+	days := daysBetween(*w.ExpectedStart, *w.ActualStart)
+	return &days
+}
+
+// StageSchedule represents the stage calendar of a crop cycle
+type StageSchedule struct {
+	Stages    []*StageWindow
+	Current   *StageWindow // The latest stage the cycle has entered, nil before sowing
+	Status    ScheduleStatus
+	DelayDays int // Days the cycle is behind its calendar, 0 unless Status is BEHIND
+}
+
+// BuildStageSchedule lays a crop's stages, given in order, out from a cycle's start date and
+// matches them with the recorded stage transitions. A cycle is in its first stage from the
+// start date until another stage is recorded. The cycle is behind schedule when its current
+// stage started after its expected start or is running past its expected end at now.
+func BuildStageSchedule(stages []*stage.CropStage, startDate *time.Time, transitions []*StageTransition, now time.Time) *StageSchedule {
+	var expectedStarts map[string]time.Time
+	if startDate != nil {
+		expectedStarts = stage.StageStarts(stages, *startDate)
+	}
+
+	actualStarts := make(map[string]time.Time, len(transitions))
+	for _, t := range transitions {
+		if started, ok := actualStarts[t.CropStageID]; !ok || t.StartedAt.Before(started) {
+			actualStarts[t.CropStageID] = t.StartedAt
+		}
+	}
+	if len(stages) > 0 && startDate != nil && !startDate.After(now) {
+		if _, ok := actualStarts[stages[0].ID]; !ok {
+			actualStarts[stages[0].ID] = *startDate
+		}
+	}
+
+	schedule := &StageSchedule{Stages: make([]*StageWindow, len(stages)), Status: ScheduleStatusUnscheduled}
+	for i, cs := range stages {
+		window := &StageWindow{CropStage: cs}
+		if start, ok := expectedStarts[cs.ID]; ok {
+			window.ExpectedStart = &start
+			if end, ok := cs.EndFrom(start); ok {
+				window.ExpectedEnd = &end
+			}
+		}
+		if start, ok := actualStarts[cs.ID]; ok {
+			window.ActualStart = &start
+			schedule.Current = window
+		}
+		schedule.Stages[i] = window
+	}
+
+	// A stage ends when the next stage the cycle entered starts
+	var nextStart *time.Time
+	for i := len(schedule.Stages) - 1; i >= 0; i-- {
+		window := schedule.Stages[i]
+		if window.ActualStart == nil {
+			continue
+		}
+		window.ActualEnd = nextStart
+		nextStart = window.ActualStart
+	}
+
+	if startDate == nil {
+		return schedule
+	}
+	if schedule.Current == nil {
+		// Not sown yet
+		schedule.Status = ScheduleStatusOnTrack
+		return schedule
+	}
+
+	current := schedule.Current
+	if current.ExpectedStart == nil && current.ExpectedEnd == nil {
+		return schedule
+	}
+	if startDelay := current.StartDelayDays(); startDelay != nil && *startDelay > schedule.DelayDays {
+		schedule.DelayDays = *startDelay
+	}
+	if current.ExpectedEnd != nil && now.After(*current.ExpectedEnd) {
+		if overrun := daysBetween(*current.ExpectedEnd, now); overrun > schedule.DelayDays {
+			schedule.DelayDays = overrun
+		}
+	}
+	schedule.Status = ScheduleStatusOnTrack
+	if schedule.DelayDays > 0 {
+		schedule.Status = ScheduleStatusBehind
+	}
+	return schedule
+}
+
+// daysBetween returns the whole days from a to b, negative when b is before a
+func daysBetween(a, b time.Time) int {
+	return int(b.Sub(a).Hours() / 24)
+}
@@ -0,0 +1,103 @@
+package crop_cycle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/stage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func scheduleStages() []*stage.CropStage {
+	days := func(v int) *int { return &v }
+	stages := make([]*stage.CropStage, 3)
+	for i, d := range []*int{days(10), days(30), days(20)} {
+		stages[i] = stage.NewCropStage()
+		stages[i].ID = []string{"CSTG1", "CSTG2", "CSTG3"}[i]
+		stages[i].StageOrder = i + 1
+		stages[i].DurationDays = d
+	}
+	return stages
+}
+
+func transition(cropStageID string, startedAt time.Time) *StageTransition {
+	t := NewStageTransition()
+	t.CropStageID = cropStageID
+	t.StartedAt = startedAt
+	return t
+}
+
+func TestBuildStageSchedule(t *testing.T) {
+	sowing := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("first stage runs from sowing", func(t *testing.T) {
+		schedule := BuildStageSchedule(scheduleStages(), &sowing, nil, sowing.AddDate(0, 0, 5))
+		require.NotNil(t, schedule.Current)
+		assert.Equal(t, "CSTG1", schedule.Current.CropStage.ID)
+		assert.Equal(t, sowing, *schedule.Current.ActualStart)
+		assert.Equal(t, time.Date(2024, 6, 11, 0, 0, 0, 0, time.UTC), *schedule.Stages[1].ExpectedStart)
+		assert.Equal(t, time.Date(2024, 7, 31, 0, 0, 0, 0, time.UTC), *schedule.Stages[2].ExpectedEnd)
+		assert.Equal(t, ScheduleStatusOnTrack, schedule.Status)
+		assert.Equal(t, 0, schedule.DelayDays)
+	})
+
+	t.Run("stage running past its window", func(t *testing.T) {
+		schedule := BuildStageSchedule(scheduleStages(), &sowing, nil, sowing.AddDate(0, 0, 14))
+		assert.Equal(t, ScheduleStatusBehind, schedule.Status)
+		assert.Equal(t, 4, schedule.DelayDays)
+	})
+
+	t.Run("stage started late", func(t *testing.T) {
+		transitions := []*StageTransition{transition("CSTG2", sowing.AddDate(0, 0, 16))}
+		schedule := BuildStageSchedule(scheduleStages(), &sowing, transitions, sowing.AddDate(0, 0, 20))
+		assert.Equal(t, "CSTG2", schedule.Current.CropStage.ID)
+		assert.Equal(t, sowing.AddDate(0, 0, 16), *schedule.Stages[0].ActualEnd)
+		assert.Equal(t, 6, *schedule.Current.StartDelayDays())
+		assert.Equal(t, ScheduleStatusBehind, schedule.Status)
+		assert.Equal(t, 6, schedule.DelayDays)
+	})
+
+	t.Run("stage started on time", func(t *testing.T) {
+		transitions := []*StageTransition{transition("CSTG2", sowing.AddDate(0, 0, 9))}
+		schedule := BuildStageSchedule(scheduleStages(), &sowing, transitions, sowing.AddDate(0, 0, 20))
+		assert.Equal(t, ScheduleStatusOnTrack, schedule.Status)
+		assert.Nil(t, schedule.Current.ActualEnd)
+	})
+
+	t.Run("not sown yet", func(t *testing.T) {
+		schedule := BuildStageSchedule(scheduleStages(), &sowing, nil, sowing.AddDate(0, 0, -3))
+		assert.Nil(t, schedule.Current)
+		assert.Equal(t, ScheduleStatusOnTrack, schedule.Status)
+	})
+
+	t.Run("no start date", func(t *testing.T) {
+		schedule := BuildStageSchedule(scheduleStages(), nil, nil, sowing)
+		assert.Nil(t, schedule.Current)
+		assert.Nil(t, schedule.Stages[0].ExpectedStart)
+		assert.Equal(t, ScheduleStatusUnscheduled, schedule.Status)
+	})
+
+	t.Run("stage after one without a duration", func(t *testing.T) {
+		stages := scheduleStages()
+		stages[0].DurationDays = nil
+		transitions := []*StageTransition{transition("CSTG2", sowing.AddDate(0, 0, 12))}
+		schedule := BuildStageSchedule(stages, &sowing, transitions, sowing.AddDate(0, 0, 20))
+		assert.Nil(t, schedule.Current.ExpectedStart)
+		assert.Equal(t, ScheduleStatusUnscheduled, schedule.Status)
+	})
+}
+
+func TestStageTransitionValidate(t *testing.T) {
+	st := transition("CSTG1", time.Now())
+	st.CropCycleID = "CRCY1"
+	st.Source = StageTransitionSourceManual
+	assert.NoError(t, st.Validate())
+
+	st.Source = "GUESS"
+	assert.Error(t, st.Validate())
+
+	st.Source = StageTransitionSourceActivity
+	st.StartedAt = time.Time{}
+	assert.Error(t, st.Validate())
+}
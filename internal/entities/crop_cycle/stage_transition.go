@@ -0,0 +1,63 @@
+package crop_cycle
+
+import (
+	"time"
+
+	"github.com/Kisanlink/farmers-module/pkg/common"
+	"github.com/Kisanlink/kisanlink-db/pkg/base"
+	"github.com/Kisanlink/kisanlink-db/pkg/core/hash"
+)
+
+// StageTransitionSource represents how the start of a stage in a crop cycle was recorded
+type StageTransitionSource string
+
+const (
+	StageTransitionSourceActivity StageTransitionSource = "ACTIVITY" // The first activity of the stage started or was completed
+	StageTransitionSourceManual   StageTransitionSource = "MANUAL"   // The stage was advanced explicitly
+)
+
+// StageTransition records when a crop cycle actually entered one of its crop's stages. A stage
+// ends when a later stage of the cycle starts.
+type StageTransition struct {
+	base.BaseModel
+	CropCycleID string                `json:"crop_cycle_id" gorm:"type:varchar(255);not null;index:idx_stage_transitions_cycle_stage"`
+	CropStageID string                `json:"crop_stage_id" gorm:"type:varchar(20);not null;index:idx_stage_transitions_cycle_stage"`
+	StartedAt   time.Time             `json:"started_at" gorm:"type:timestamptz;not null"`
+	Source      StageTransitionSource `json:"source" gorm:"type:varchar(20);not null"`
+	ActivityID  *string               `json:"activity_id,omitempty" gorm:"type:varchar(255)"` // The activity that started the stage
+	Notes       *string               `json:"notes,omitempty" gorm:"type:text"`
+}
+
+// TableName returns the table name for the StageTransition model
+func (st *StageTransition) TableName() string {
+	return "crop_cycle_stage_transitions"
+}
+
+// GetTableIdentifier returns the table identifier for ID generation
+func (st *StageTransition) GetTableIdentifier() string {
+	return "CCST"
+}
+
+// GetTableSize returns the table size for ID generation
+func (st *StageTransition) GetTableSize() hash.TableSize {
+	return hash.Medium
+}
+
+// NewStageTransition creates a new stage transition model with proper initialization
+func NewStageTransition() *StageTransition {
+	baseModel := base.NewBaseModel("CCST", hash.Medium)
+	return &StageTransition{
+		BaseModel: *baseModel,
+	}
+}
+
+// Validate validates the stage transition model
+func (st *StageTransition) Validate() error {
+	if st.CropCycleID == "" || st.CropStageID == "" || st.StartedAt.IsZero() {
+		return common.ErrInvalidInput
+	}
+	if st.Source != StageTransitionSourceActivity && st.Source != StageTransitionSourceManual {
+		return common.ErrInvalidInput
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package crop_cycle
+
+import (
+	"math"
+	"time"
+
+	"github.com/Kisanlink/farmers-module/pkg/units"
+)
+
+// YieldEstimate represents the yield expected of a crop cycle from its variety's benchmarks,
+// in the canonical unit of its crop's unit (kg, or numbers for crops counted rather than
+// weighed), or in the crop's unit itself when the unit registry does not know it
+type YieldEstimate struct {
+	YieldUnit       string   `json:"yield_unit" example:"kg"`
+	YieldPerHectare *float64 `json:"yield_per_hectare,omitempty" example:"2965.24"`
+	TotalYield      *float64 `json:"total_yield,omitempty" example:"3558.29"`
+	YieldPerTree    *float64 `json:"yield_per_tree,omitempty" example:"40"`
+	TreeCount       *int     `json:"tree_count,omitempty" example:"120"`
+	TreeAgeYears    *int     `json:"tree_age_years,omitempty" example:"8"`
+}
+
+// YieldComparison compares an actual yield with the expected one
+type YieldComparison struct {
+	Expected        float64  `json:"expected" example:"2965.24"`
+	Actual          float64  `json:"actual" example:"2700"`
+	Variance        float64  `json:"variance" example:"-265.24"`                 // Actual less expected
+	VariancePercent *float64 `json:"variance_percent,omitempty" example:"-8.94"` // Of the expected yield
+}
+
+// YieldVariance represents how the yield recorded in a crop cycle's outcome compares with
+// its expected yield, per hectare and in total
+type YieldVariance struct {
+	YieldUnit  string           `json:"yield_unit" example:"kg"`
+	PerHectare *YieldComparison `json:"per_hectare,omitempty"`
+	Total      *YieldComparison `json:"total,omitempty"`
+}
+
+// TreeAgeYears returns the age of the cycle's trees in the year the cycle starts, or in the
+// current year when it has no start date. It is nil when the planting year is not known.
+func (cc *CropCycle) TreeAgeYears() *int {
+	if cc.PlantingYear == nil {
+		return nil
+	}
+	year := time.Now().Year()
+	if cc.StartDate != nil {
+		year = cc.StartDate.Year()
+	}
+	age := year - *cc.PlantingYear
+	return &age
+}
+
+// EstimateYield estimates the cycle's yield from the benchmarks of its variety, which must be
+// preloaded along with its crop. Benchmarks are taken to be in the crop's unit. Perennial
+// cycles with a tree count use the variety's yield per tree at the trees' age, or its
+// overall yield per tree; other cycles use its yield per acre over the cycle's area. It
+// returns nil when no benchmark applies.
+func (cc *CropCycle) EstimateYield() *YieldEstimate {
+	if cc.Variety == nil {
+		return nil
+	}
+	variety := cc.Variety
+	estimate := &YieldEstimate{}
+
+	if cc.IsPerennial() && cc.TreeCount != nil {
+		perTree := variety.YieldPerTree
+		age := cc.TreeAgeYears()
+		if age != nil {
+			perTree = variety.GetYieldForAge(*age)
+		}
+		if perTree != nil {
+			yieldPerTree := *perTree
+			total := yieldPerTree * float64(*cc.TreeCount)
+			estimate.YieldPerTree = &yieldPerTree
+			estimate.TreeCount = cc.TreeCount
+			estimate.TreeAgeYears = age
+			estimate.TotalYield = &total
+			if cc.AreaHa != nil && *cc.AreaHa > 0 {
+				perHectare := total / *cc.AreaHa
+				estimate.YieldPerHectare = &perHectare
+			}
+		}
+	}
+
+	if estimate.TotalYield == nil && variety.YieldPerAcre != nil {
+		acresPerHectare, _ := units.Convert(1, units.Hectare, "acre", "")
+		perHectare := *variety.YieldPerAcre * acresPerHectare
+		estimate.YieldPerHectare = &perHectare
+		if cc.AreaHa != nil {
+			total := perHectare * *cc.AreaHa
+			estimate.TotalYield = &total
+		}
+	}
+
+	if estimate.YieldPerHectare == nil && estimate.TotalYield == nil {
+		return nil
+	}
+
+	// Benchmarks in a known unit are given in its canonical unit
+	factor := 1.0
+	if cc.Crop != nil {
+		estimate.YieldUnit = cc.Crop.Unit
+		if unit, ok := units.Lookup(cc.Crop.Unit); ok && !unit.IsLocal() &&
+			(unit.Dimension == units.DimensionMass || unit.Dimension == units.DimensionCount) {
+			estimate.YieldUnit = unit.Dimension.Canonical()
+			factor = unit.Factor
+		}
+	}
+	for _, value := range []*float64{estimate.YieldPerHectare, estimate.TotalYield, estimate.YieldPerTree} {
+		if value != nil {
+			*value = roundYield(*value * factor)
+		}
+	}
+	return estimate
+}
+
+// YieldVariance compares the yield recorded in the cycle's outcome with an estimate of it,
+// per hectare and in total. An annual outcome without a total yield counts its yield per
+// hectare over the cycle's area. It returns nil when the outcome has no yield in a known
+// unit or it is not in the estimate's unit.
+func (cc *CropCycle) YieldVariance(estimate *YieldEstimate) *YieldVariance {
+	if estimate == nil {
+		return nil
+	}
+	perHectare, total, unit := cc.CanonicalYield()
+	if unit == "" || unit != estimate.YieldUnit {
+		return nil
+	}
+	if total == nil && perHectare != nil && cc.AreaHa != nil {
+		value := *perHectare * *cc.AreaHa
+		total = &value
+	}
+
+	variance := &YieldVariance{
+		YieldUnit:  unit,
+		PerHectare: compareYield(estimate.YieldPerHectare, perHectare),
+		Total:      compareYield(estimate.TotalYield, total),
+	}
+	if variance.PerHectare == nil && variance.Total == nil {
+		return nil
+	}
+	return variance
+}
+
+// RecordYieldVariance records an estimate of the cycle's yield in its outcome under
+// "expected_yield" and, when the outcome's yield can be compared with it, the variance under
+// "yield_variance", so that they keep the benchmarks as they were when the cycle ended
+func (cc *CropCycle) RecordYieldVariance(estimate *YieldEstimate) {
+	if estimate == nil || len(cc.Outcome) == 0 {
+		return
+	}
+	cc.Outcome["expected_yield"] = estimate
+	if variance := cc.YieldVariance(estimate); variance != nil {
+		cc.Outcome["yield_variance"] = variance
+	}
+}
+
+// compareYield compares an actual yield with the expected one, or returns nil when either is
+// not known
+func compareYield(expected, actual *float64) *YieldComparison {
+	if expected == nil || actual == nil {
+		return nil
+	}
+	comparison := &YieldComparison{
+		Expected: roundYield(*expected),
+		Actual:   roundYield(*actual),
+		Variance: roundYield(*actual - *expected),
+	}
+	if *expected > 0 {
+		percent := roundYield((*actual - *expected) / *expected * 100)
+		comparison.VariancePercent = &percent
+	}
+	return comparison
+}
+
+// roundYield rounds a yield to two decimal places
+func roundYield(value float64) float64 {
+	return math.Round(value*100) / 100
+}
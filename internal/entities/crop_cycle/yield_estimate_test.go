@@ -0,0 +1,118 @@
+package crop_cycle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/crop"
+	"github.com/Kisanlink/farmers-module/internal/entities/crop_variety"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCropCycle_EstimateYield_Annual(t *testing.T) {
+	area := 2.0
+	perAcre := 12.0 // quintals
+	cycle := &CropCycle{
+		Season:  "RABI",
+		AreaHa:  &area,
+		Crop:    &crop.Crop{Unit: "quintal"},
+		Variety: &crop_variety.CropVariety{YieldPerAcre: &perAcre},
+	}
+
+	estimate := cycle.EstimateYield()
+	require.NotNil(t, estimate)
+	assert.Equal(t, "kg", estimate.YieldUnit)
+	assert.Equal(t, 2965.26, *estimate.YieldPerHectare)
+	assert.Equal(t, 5930.53, *estimate.TotalYield)
+	assert.Nil(t, estimate.YieldPerTree)
+
+	// Without an area only the yield per hectare is known
+	cycle.AreaHa = nil
+	estimate = cycle.EstimateYield()
+	assert.Equal(t, 2965.26, *estimate.YieldPerHectare)
+	assert.Nil(t, estimate.TotalYield)
+
+	// No variety, or a variety without benchmarks, gives no estimate
+	assert.Nil(t, (&CropCycle{Season: "RABI"}).EstimateYield())
+	assert.Nil(t, (&CropCycle{Season: "RABI", Variety: &crop_variety.CropVariety{}}).EstimateYield())
+}
+
+func TestCropCycle_EstimateYield_Perennial(t *testing.T) {
+	area := 1.5
+	trees := 150
+	planted := 2016
+	perTree := 30.0
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	cycle := &CropCycle{
+		Season:       "PERENNIAL",
+		AreaHa:       &area,
+		StartDate:    &start,
+		TreeCount:    &trees,
+		PlantingYear: &planted,
+		Crop:         &crop.Crop{Unit: "kg"},
+		Variety: &crop_variety.CropVariety{
+			YieldPerTree: &perTree,
+			YieldByAge: []crop_variety.YieldByAge{
+				{AgeFrom: 3, AgeTo: 6, YieldPerTree: 15},
+				{AgeFrom: 7, AgeTo: 15, YieldPerTree: 45},
+			},
+		},
+	}
+
+	estimate := cycle.EstimateYield()
+	require.NotNil(t, estimate)
+	assert.Equal(t, 8, *estimate.TreeAgeYears)
+	assert.Equal(t, 45.0, *estimate.YieldPerTree)
+	assert.Equal(t, 6750.0, *estimate.TotalYield)
+	assert.Equal(t, 4500.0, *estimate.YieldPerHectare)
+
+	// Trees of unknown age use the variety's yield per tree
+	cycle.PlantingYear = nil
+	estimate = cycle.EstimateYield()
+	assert.Nil(t, estimate.TreeAgeYears)
+	assert.Equal(t, 4500.0, *estimate.TotalYield)
+
+	// Without a tree count the yield per acre is used, when the variety has one
+	cycle.TreeCount = nil
+	assert.Nil(t, cycle.EstimateYield())
+	perAcre := 2000.0
+	cycle.Variety.YieldPerAcre = &perAcre
+	assert.Equal(t, 7413.16, *cycle.EstimateYield().TotalYield)
+}
+
+func TestCropCycle_YieldVariance(t *testing.T) {
+	area := 2.0
+	perAcre := 10.0
+	cycle := &CropCycle{
+		Season:  "KHARIF",
+		Status:  "COMPLETED",
+		AreaHa:  &area,
+		Crop:    &crop.Crop{Unit: "quintal"},
+		Variety: &crop_variety.CropVariety{YieldPerAcre: &perAcre},
+		Outcome: map[string]interface{}{
+			"yield_per_hectare": 22.0,
+			"yield_unit":        "qtl",
+		},
+	}
+	estimate := cycle.EstimateYield()
+	require.NotNil(t, estimate)
+
+	variance := cycle.YieldVariance(estimate)
+	require.NotNil(t, variance)
+	assert.Equal(t, "kg", variance.YieldUnit)
+	assert.Equal(t, 2471.05, variance.PerHectare.Expected)
+	assert.Equal(t, 2200.0, variance.PerHectare.Actual)
+	assert.Equal(t, -271.05, variance.PerHectare.Variance)
+	assert.Equal(t, -10.97, *variance.PerHectare.VariancePercent)
+	assert.Equal(t, 4400.0, variance.Total.Actual)
+
+	cycle.RecordYieldVariance(estimate)
+	assert.Same(t, estimate, cycle.Outcome["expected_yield"])
+	assert.NotNil(t, cycle.Outcome["yield_variance"])
+
+	// Yields that cannot be compared in the same unit have no variance
+	cycle.Outcome["yield_unit"] = "nos"
+	assert.Nil(t, cycle.YieldVariance(estimate))
+	assert.Nil(t, cycle.YieldVariance(nil))
+}
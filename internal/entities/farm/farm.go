@@ -11,6 +11,7 @@ import (
 	"github.com/Kisanlink/farmers-module/internal/entities/irrigation_source"
 	"github.com/Kisanlink/farmers-module/internal/entities/soil_type"
 	"github.com/Kisanlink/farmers-module/pkg/common"
+	"github.com/Kisanlink/farmers-module/pkg/geo"
 	"github.com/Kisanlink/kisanlink-db/pkg/base"
 	"github.com/Kisanlink/kisanlink-db/pkg/core/hash"
 	"gorm.io/gorm"
@@ -65,7 +66,7 @@ type Farm struct {
 	AAAOrgID                  string        `json:"aaa_org_id" gorm:"type:varchar(255);not null"`
 	Name                      *string       `json:"name" gorm:"type:varchar(255)"`
 	OwnershipType             OwnershipType `json:"ownership_type" gorm:"type:varchar(20);default:'OWN'"`
-	Geometry                  string        `json:"geometry" gorm:"type:geometry(POLYGON,4326)"`
+	Geometry                  string        `json:"geometry" gorm:"type:geometry(MULTIPOLYGON,4326)"`
 	AreaHa                    float64       `json:"area_ha" gorm:"column:area_ha;type:numeric(12,4)"`
 	AreaHaComputed            float64       `json:"area_ha_computed" gorm:"column:area_ha_computed;type:numeric(12,4);->"`
 	SoilTypeID                *string       `json:"soil_type_id" gorm:"type:varchar(255);index"`
@@ -74,6 +75,12 @@ type Farm struct {
 	OtherIrrigationDetails    *string       `json:"other_irrigation_details" gorm:"type:text"`
 	Metadata                  Metadata      `json:"metadata" gorm:"type:jsonb;default:'{}';serializer:json"`
 
+	// Codes of the administrative areas containing the farm, assigned from its boundary
+	StateCode    *string `json:"state_code,omitempty" gorm:"type:varchar(20);index"`
+	DistrictCode *string `json:"district_code,omitempty" gorm:"type:varchar(20);index"`
+	BlockCode    *string `json:"block_code,omitempty" gorm:"type:varchar(20);index"`
+	VillageCode  *string `json:"village_code,omitempty" gorm:"type:varchar(20);index"`
+
 	// Relationships
 	Farmer                  *farmer.Farmer                                `json:"farmer,omitempty" gorm:"foreignKey:FarmerID;references:ID"`
 	SoilType                *soil_type.SoilType                           `json:"soil_type,omitempty" gorm:"foreignKey:SoilTypeID;references:ID"`
@@ -136,6 +143,16 @@ func (f *Farm) Validate() error {
 	return nil
 }
 
+// BeforeSave is a GORM hook that stores POLYGON boundaries as single-part MULTIPOLYGONs,
+// the type of the geometry column. Holes are kept; hex EWKB read back from the column is
+// left unchanged.
+func (f *Farm) BeforeSave(tx *gorm.DB) error {
+	if geometry, err := geo.ParseWKT(f.Geometry); err == nil {
+		f.Geometry = geometry.MultiWKT()
+	}
+	return nil
+}
+
 // AfterCreate is a GORM hook that runs after a farm is created
 func (f *Farm) AfterCreate(tx interface{}) error {
 	return updateFarmerStats(tx, f.FarmerID)
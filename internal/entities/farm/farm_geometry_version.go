@@ -0,0 +1,79 @@
+package farm
+
+import (
+	"time"
+
+	"github.com/Kisanlink/farmers-module/pkg/geo"
+	"github.com/Kisanlink/kisanlink-db/pkg/base"
+	"github.com/Kisanlink/kisanlink-db/pkg/core/hash"
+	"gorm.io/gorm"
+)
+
+// GeometrySource represents how a farm boundary was captured
+type GeometrySource string
+
+const (
+	GeometrySourceGPSWalk   GeometrySource = "GPS_WALK"
+	GeometrySourceDigitised GeometrySource = "DIGITISED"
+	GeometrySourceCadastral GeometrySource = "CADASTRAL"
+	GeometrySourceUnknown   GeometrySource = "UNKNOWN" // Boundaries captured before versioning began
+)
+
+// IsValid reports whether the source is a known capture method
+func (s GeometrySource) IsValid() bool {
+	switch s {
+	case GeometrySourceGPSWalk, GeometrySourceDigitised, GeometrySourceCadastral, GeometrySourceUnknown:
+		return true
+	}
+	return false
+}
+
+// FarmGeometryVersion records one boundary of a farm and the period it was in force. Every
+// boundary change adds a version and closes the previous one, so the boundary of a farm on
+// any date can be read back for land disputes, lease changes and historic seasons.
+type FarmGeometryVersion struct {
+	base.BaseModel
+	FarmID      string         `json:"farm_id" gorm:"type:varchar(255);not null;uniqueIndex:idx_farm_geometry_versions_farm_version,priority:1"`
+	Version     int            `json:"version" gorm:"not null;uniqueIndex:idx_farm_geometry_versions_farm_version,priority:2"`
+	Geometry    string         `json:"geometry" gorm:"type:geometry(MULTIPOLYGON,4326);not null"`
+	AreaHa      float64        `json:"area_ha" gorm:"type:numeric(12,4);not null;default:0"`
+	AreaDeltaHa float64        `json:"area_delta_ha" gorm:"type:numeric(12,4);not null;default:0"` // Change from the previous version
+	Source      GeometrySource `json:"source" gorm:"type:varchar(20);not null;default:'UNKNOWN'"`
+	Reason      *string        `json:"reason,omitempty" gorm:"type:text"`
+	AuthorID    string         `json:"author_id" gorm:"type:varchar(255)"` // AAA user ID of whoever made the change
+	ValidFrom   time.Time      `json:"valid_from" gorm:"not null"`
+	ValidTo     *time.Time     `json:"valid_to,omitempty"` // Nil for the current boundary
+}
+
+// TableName returns the table name for FarmGeometryVersion
+func (v *FarmGeometryVersion) TableName() string {
+	return "farm_geometry_versions"
+}
+
+// GetTableIdentifier returns the table identifier for ID generation
+func (v *FarmGeometryVersion) GetTableIdentifier() string {
+	return "FGVR"
+}
+
+// GetTableSize returns the table size for ID generation
+func (v *FarmGeometryVersion) GetTableSize() hash.TableSize {
+	return hash.Large
+}
+
+// NewFarmGeometryVersion creates a new farm geometry version with proper initialization
+func NewFarmGeometryVersion() *FarmGeometryVersion {
+	baseModel := base.NewBaseModel("FGVR", hash.Large)
+	return &FarmGeometryVersion{
+		BaseModel: *baseModel,
+		Source:    GeometrySourceUnknown,
+	}
+}
+
+// BeforeSave is a GORM hook that stores POLYGON boundaries as single-part MULTIPOLYGONs,
+// as Farm does
+func (v *FarmGeometryVersion) BeforeSave(tx *gorm.DB) error {
+	if geometry, err := geo.ParseWKT(v.Geometry); err == nil {
+		v.Geometry = geometry.MultiWKT()
+	}
+	return nil
+}
@@ -87,3 +87,25 @@ func TestFarmGetTableIdentifier(t *testing.T) {
 	farm := &Farm{}
 	assert.Equal(t, "FARM", farm.GetTableIdentifier())
 }
+
+func TestFarmBeforeSave(t *testing.T) {
+	farm := &Farm{Geometry: "POLYGON((78 17, 78.002 17, 78.002 17.002, 78 17.002, 78 17),(78.0005 17.0005, 78.0005 17.0015, 78.0015 17.0015, 78.0015 17.0005, 78.0005 17.0005))"}
+	assert.NoError(t, farm.BeforeSave(nil))
+	assert.Equal(t, "MULTIPOLYGON(((78 17, 78.002 17, 78.002 17.002, 78 17.002, 78 17),(78.0005 17.0005, 78.0005 17.0015, 78.0015 17.0015, 78.0015 17.0005, 78.0005 17.0005)))", farm.Geometry)
+
+	stored := "0106000020E6100000"
+	farm = &Farm{Geometry: stored}
+	assert.NoError(t, farm.BeforeSave(nil))
+	assert.Equal(t, stored, farm.Geometry)
+}
+
+func TestFarmGeometryVersion(t *testing.T) {
+	version := NewFarmGeometryVersion()
+	assert.Equal(t, "farm_geometry_versions", version.TableName())
+	assert.Equal(t, "FGVR", version.GetTableIdentifier())
+	assert.Equal(t, GeometrySourceUnknown, version.Source)
+
+	assert.True(t, GeometrySourceGPSWalk.IsValid())
+	assert.True(t, GeometrySourceCadastral.IsValid())
+	assert.False(t, GeometrySource("SATELLITE").IsValid())
+}
@@ -0,0 +1,82 @@
+package farm_activity
+
+import (
+	"github.com/Kisanlink/farmers-module/internal/entities/input_product"
+	"github.com/Kisanlink/farmers-module/pkg/common"
+	"github.com/Kisanlink/kisanlink-db/pkg/base"
+	"github.com/Kisanlink/kisanlink-db/pkg/core/hash"
+)
+
+// ActivityInput represents an input line item of a farm activity: how much of an input
+// master product was used and what it cost. Costs are in rupees. The crop cycle and the
+// product's category are copied from the activity and the product for cost roll-ups.
+type ActivityInput struct {
+	base.BaseModel
+	FarmActivityID string                      `json:"farm_activity_id" gorm:"type:varchar(255);not null;index"`
+	CropCycleID    string                      `json:"crop_cycle_id" gorm:"type:varchar(255);not null;index"`
+	InputProductID string                      `json:"input_product_id" gorm:"type:varchar(20);not null;index"`
+	Category       input_product.InputCategory `json:"category" gorm:"type:varchar(20);not null"`
+	Quantity       float64                     `json:"quantity" gorm:"type:decimal(14,4);not null"`
+	Unit           string                      `json:"unit" gorm:"type:varchar(50);not null"`
+	UnitCost       *float64                    `json:"unit_cost,omitempty" gorm:"type:decimal(14,2)"`
+	TotalCost      float64                     `json:"total_cost" gorm:"type:decimal(14,2);not null;default:0"`
+	Supplier       *string                     `json:"supplier,omitempty" gorm:"type:varchar(255)"`
+	Notes          *string                     `json:"notes,omitempty" gorm:"type:text"`
+
+	// Relationships
+	InputProduct *input_product.InputProduct `json:"input_product,omitempty" gorm:"foreignKey:InputProductID;references:ID"`
+}
+
+// TableName returns the table name for the ActivityInput model
+func (ai *ActivityInput) TableName() string {
+	return "farm_activity_inputs"
+}
+
+// GetTableIdentifier returns the table identifier for ID generation
+func (ai *ActivityInput) GetTableIdentifier() string {
+	return "FAIN"
+}
+
+// GetTableSize returns the table size for ID generation
+func (ai *ActivityInput) GetTableSize() hash.TableSize {
+	return hash.Medium
+}
+
+// NewActivityInput creates a new activity input model with proper initialization
+func NewActivityInput() *ActivityInput {
+	baseModel := base.NewBaseModel("FAIN", hash.Medium)
+	return &ActivityInput{
+		BaseModel: *baseModel,
+	}
+}
+
+// SetCost sets the line item's cost from its total cost or, when that is not given, from
+// its quantity and unit cost
+func (ai *ActivityInput) SetCost(unitCost, totalCost *float64) {
+	ai.UnitCost = unitCost
+	switch {
+	case totalCost != nil:
+		ai.TotalCost = *totalCost
+	case unitCost != nil:
+		ai.TotalCost = ai.Quantity * *unitCost
+	default:
+		ai.TotalCost = 0
+	}
+}
+
+// Validate validates the activity input model
+func (ai *ActivityInput) Validate() error {
+	if ai.FarmActivityID == "" || ai.CropCycleID == "" || ai.InputProductID == "" || ai.Unit == "" {
+		return common.ErrInvalidInput
+	}
+	if !ai.Category.IsValid() {
+		return common.ErrInvalidInput
+	}
+	if ai.Quantity <= 0 || ai.TotalCost < 0 {
+		return common.ErrInvalidInput
+	}
+	if ai.UnitCost != nil && *ai.UnitCost < 0 {
+		return common.ErrInvalidInput
+	}
+	return nil
+}
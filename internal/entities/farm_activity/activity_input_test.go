@@ -0,0 +1,65 @@
+package farm_activity
+
+import (
+	"testing"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/input_product"
+	"github.com/stretchr/testify/assert"
+)
+
+func newActivityInput() *ActivityInput {
+	ai := NewActivityInput()
+	ai.FarmActivityID = "FACT00000001"
+	ai.CropCycleID = "CRCY00000001"
+	ai.InputProductID = "INPD00000001"
+	ai.Category = input_product.InputCategoryFertilizer
+	ai.Quantity = 50
+	ai.Unit = "kg"
+	return ai
+}
+
+func TestActivityInputSetCost(t *testing.T) {
+	unitCost := 26.5
+	totalCost := 1400.0
+
+	ai := newActivityInput()
+	ai.SetCost(&unitCost, nil)
+	assert.Equal(t, 1325.0, ai.TotalCost)
+
+	ai.SetCost(&unitCost, &totalCost)
+	assert.Equal(t, 1400.0, ai.TotalCost)
+	assert.Equal(t, 26.5, *ai.UnitCost)
+
+	ai.SetCost(nil, nil)
+	assert.Equal(t, 0.0, ai.TotalCost)
+	assert.Nil(t, ai.UnitCost)
+}
+
+func TestActivityInputValidate(t *testing.T) {
+	negative := -1.0
+	tests := []struct {
+		name    string
+		modify  func(ai *ActivityInput)
+		wantErr bool
+	}{
+		{"valid input", func(ai *ActivityInput) {}, false},
+		{"missing product", func(ai *ActivityInput) { ai.InputProductID = "" }, true},
+		{"missing unit", func(ai *ActivityInput) { ai.Unit = "" }, true},
+		{"unknown category", func(ai *ActivityInput) { ai.Category = "FUEL" }, true},
+		{"zero quantity", func(ai *ActivityInput) { ai.Quantity = 0 }, true},
+		{"negative unit cost", func(ai *ActivityInput) { ai.UnitCost = &negative }, true},
+		{"negative total cost", func(ai *ActivityInput) { ai.TotalCost = -10 }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ai := newActivityInput()
+			tt.modify(ai)
+			if tt.wantErr {
+				assert.Error(t, ai.Validate())
+			} else {
+				assert.NoError(t, ai.Validate())
+			}
+		})
+	}
+}
@@ -14,16 +14,18 @@ import (
 // FarmActivity represents an individual activity within a crop cycle
 type FarmActivity struct {
 	base.BaseModel
-	CropCycleID  string         `json:"crop_cycle_id" gorm:"type:varchar(255);not null;index"`
-	CropStageID  *string        `json:"crop_stage_id" gorm:"type:varchar(20);index:idx_farm_activities_cycle_stage"`
-	FarmerID     string         `json:"farmer_id" gorm:"type:varchar(255);not null;index"`
-	ActivityType string         `json:"activity_type" gorm:"type:varchar(255);not null"`
-	PlannedAt    *time.Time     `json:"planned_at" gorm:"type:timestamptz"`
-	CompletedAt  *time.Time     `json:"completed_at" gorm:"type:timestamptz"`
-	CreatedBy    string         `json:"created_by" gorm:"type:varchar(255);not null"`
-	Status       string         `json:"status" gorm:"type:activity_status;not null;default:'PLANNED'"`
-	Output       entities.JSONB `json:"output" gorm:"type:jsonb;default:'{}';serializer:json"`
-	Metadata     entities.JSONB `json:"metadata" gorm:"type:jsonb;default:'{}';serializer:json"`
+	CropCycleID        string         `json:"crop_cycle_id" gorm:"type:varchar(255);not null;index"`
+	CropStageID        *string        `json:"crop_stage_id" gorm:"type:varchar(20);index:idx_farm_activities_cycle_stage"`
+	FarmerID           string         `json:"farmer_id" gorm:"type:varchar(255);not null;index"`
+	ActivityTemplateID *string        `json:"activity_template_id,omitempty" gorm:"type:varchar(20);index"` // Set on activities planned from an activity template
+	ActivityType       string         `json:"activity_type" gorm:"type:varchar(255);not null"`
+	PlannedAt          *time.Time     `json:"planned_at" gorm:"type:timestamptz"`
+	StartedAt          *time.Time     `json:"started_at,omitempty" gorm:"type:timestamptz"`
+	CompletedAt        *time.Time     `json:"completed_at" gorm:"type:timestamptz"`
+	CreatedBy          string         `json:"created_by" gorm:"type:varchar(255);not null"`
+	Status             string         `json:"status" gorm:"type:activity_status;not null;default:'PLANNED'"`
+	Output             entities.JSONB `json:"output" gorm:"type:jsonb;default:'{}';serializer:json"`
+	Metadata           entities.JSONB `json:"metadata" gorm:"type:jsonb;default:'{}';serializer:json"`
 
 	// Relationships
 	Farmer    *farmer.Farmer   `json:"farmer,omitempty" gorm:"foreignKey:FarmerID;references:ID;constraint:OnDelete:CASCADE"`
@@ -0,0 +1,187 @@
+package farm_overlap
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Kisanlink/farmers-module/pkg/geo"
+	"github.com/Kisanlink/kisanlink-db/pkg/base"
+	"github.com/Kisanlink/kisanlink-db/pkg/core/hash"
+	"gorm.io/gorm"
+)
+
+// ConflictStatus represents where an overlap conflict is in its investigation
+type ConflictStatus string
+
+const (
+	ConflictStatusOpen          ConflictStatus = "OPEN"
+	ConflictStatusAcknowledged  ConflictStatus = "ACKNOWLEDGED"
+	ConflictStatusResolved      ConflictStatus = "RESOLVED"
+	ConflictStatusFalsePositive ConflictStatus = "FALSE_POSITIVE"
+)
+
+// IsValid reports whether the status is a known conflict status
+func (s ConflictStatus) IsValid() bool {
+	switch s {
+	case ConflictStatusOpen, ConflictStatusAcknowledged, ConflictStatusResolved, ConflictStatusFalsePositive:
+		return true
+	}
+	return false
+}
+
+// IsUnresolved reports whether the conflict still needs attention
+func (s ConflictStatus) IsUnresolved() bool {
+	return s == ConflictStatusOpen || s == ConflictStatusAcknowledged
+}
+
+// CanTransitionTo reports whether a conflict may move from this status to next. Resolved and
+// false positive conflicts can only be reopened.
+func (s ConflictStatus) CanTransitionTo(next ConflictStatus) bool {
+	if !next.IsValid() {
+		return false
+	}
+	if s == next || s.IsUnresolved() {
+		return true
+	}
+	return next == ConflictStatusOpen
+}
+
+// ResolutionAction records how a conflict was resolved
+type ResolutionAction string
+
+const (
+	ResolutionTrim                ResolutionAction = "TRIM"                  // One farm gave up the shared area to the other
+	ResolutionSplit               ResolutionAction = "SPLIT"                 // The shared area was divided between the farms
+	ResolutionManual              ResolutionAction = "MANUAL"                // Marked resolved without changing either boundary
+	ResolutionNoLongerOverlapping ResolutionAction = "NO_LONGER_OVERLAPPING" // A later boundary change or deletion removed the overlap
+)
+
+// MinConflictAreaHa is the smallest overlap, 1 m², recorded as a conflict. Smaller overlaps
+// are slivers left by digitising or by trimming one boundary to another.
+const MinConflictAreaHa = 0.0001
+
+// FarmOverlapConflict records an overlap between the boundaries of two farms of an
+// organization so that it can be investigated and resolved. Each pair of farms has one
+// conflict, with Farm1ID ordered before Farm2ID; detection updates it and reopens it when a
+// resolved overlap comes back.
+type FarmOverlapConflict struct {
+	base.BaseModel
+	AAAOrgID         string            `json:"aaa_org_id" gorm:"type:varchar(255);not null;index"`
+	Farm1ID          string            `json:"farm1_id" gorm:"type:varchar(255);not null;uniqueIndex:idx_farm_overlap_conflicts_pair,priority:1"`
+	Farm2ID          string            `json:"farm2_id" gorm:"type:varchar(255);not null;uniqueIndex:idx_farm_overlap_conflicts_pair,priority:2;index"`
+	OverlapGeometry  *string           `json:"overlap_geometry,omitempty" gorm:"type:geometry(MULTIPOLYGON,4326)"`
+	OverlapAreaHa    float64           `json:"overlap_area_ha" gorm:"type:numeric(12,4);not null;default:0"`
+	Status           ConflictStatus    `json:"status" gorm:"type:varchar(20);not null;default:'OPEN';index"`
+	AssigneeID       *string           `json:"assignee_id,omitempty" gorm:"type:varchar(255);index"` // AAA user ID of the investigator
+	ResolutionNotes  *string           `json:"resolution_notes,omitempty" gorm:"type:text"`
+	ResolutionAction *ResolutionAction `json:"resolution_action,omitempty" gorm:"type:varchar(30)"`
+	DetectedAt       time.Time         `json:"detected_at" gorm:"not null"`
+	LastDetectedAt   time.Time         `json:"last_detected_at" gorm:"not null"`
+	ResolvedAt       *time.Time        `json:"resolved_at,omitempty"`
+	ResolvedBy       *string           `json:"resolved_by,omitempty" gorm:"type:varchar(255)"`
+}
+
+// TableName returns the table name for FarmOverlapConflict
+func (c *FarmOverlapConflict) TableName() string {
+	return "farm_overlap_conflicts"
+}
+
+// GetTableIdentifier returns the table identifier for ID generation
+func (c *FarmOverlapConflict) GetTableIdentifier() string {
+	return "FOVC"
+}
+
+// GetTableSize returns the table size for ID generation
+func (c *FarmOverlapConflict) GetTableSize() hash.TableSize {
+	return hash.Large
+}
+
+// NewFarmOverlapConflict creates a new open conflict between two farms, ordering the pair
+func NewFarmOverlapConflict(orgID, farmID, otherFarmID string) *FarmOverlapConflict {
+	baseModel := base.NewBaseModel("FOVC", hash.Large)
+	farm1ID, farm2ID := OrderedPair(farmID, otherFarmID)
+	return &FarmOverlapConflict{
+		BaseModel: *baseModel,
+		AAAOrgID:  orgID,
+		Farm1ID:   farm1ID,
+		Farm2ID:   farm2ID,
+		Status:    ConflictStatusOpen,
+	}
+}
+
+// OrderedPair returns two farm IDs in the order conflicts store them
+func OrderedPair(farmID, otherFarmID string) (string, string) {
+	if otherFarmID < farmID {
+		return otherFarmID, farmID
+	}
+	return farmID, otherFarmID
+}
+
+// OtherFarmID returns the farm of the pair that is not farmID
+func (c *FarmOverlapConflict) OtherFarmID(farmID string) string {
+	if farmID == c.Farm1ID {
+		return c.Farm2ID
+	}
+	return c.Farm1ID
+}
+
+// Validate validates the conflict
+func (c *FarmOverlapConflict) Validate() error {
+	if c.AAAOrgID == "" {
+		return errors.New("aaa_org_id is required")
+	}
+	if c.Farm1ID == "" || c.Farm2ID == "" {
+		return errors.New("both farm IDs are required")
+	}
+	if c.Farm1ID >= c.Farm2ID {
+		return errors.New("farm1_id must be ordered before farm2_id")
+	}
+	if !c.Status.IsValid() {
+		return errors.New("status must be OPEN, ACKNOWLEDGED, RESOLVED or FALSE_POSITIVE")
+	}
+	return nil
+}
+
+// BeforeSave is a GORM hook that stores POLYGON overlaps as single-part MULTIPOLYGONs, as
+// Farm does
+func (c *FarmOverlapConflict) BeforeSave(tx *gorm.DB) error {
+	if c.OverlapGeometry == nil {
+		return nil
+	}
+	if geometry, err := geo.ParseWKT(*c.OverlapGeometry); err == nil {
+		multi := geometry.MultiWKT()
+		c.OverlapGeometry = &multi
+	}
+	return nil
+}
+
+// FarmOverlapDetectionRun records when overlap detection last covered an organization, so
+// that the next incremental run only re-checks farms changed since
+type FarmOverlapDetectionRun struct {
+	base.BaseModel
+	AAAOrgID       string    `json:"aaa_org_id" gorm:"type:varchar(255);not null;uniqueIndex"`
+	CheckedThrough time.Time `json:"checked_through" gorm:"not null"` // Farm changes up to this time have been checked
+}
+
+// TableName returns the table name for FarmOverlapDetectionRun
+func (r *FarmOverlapDetectionRun) TableName() string {
+	return "farm_overlap_detection_runs"
+}
+
+// GetTableIdentifier returns the table identifier for ID generation
+func (r *FarmOverlapDetectionRun) GetTableIdentifier() string {
+	return "FODR"
+}
+
+// GetTableSize returns the table size for ID generation
+func (r *FarmOverlapDetectionRun) GetTableSize() hash.TableSize {
+	return hash.Small
+}
+
+// NewFarmOverlapDetectionRun creates a new detection run record
+func NewFarmOverlapDetectionRun() *FarmOverlapDetectionRun {
+	baseModel := base.NewBaseModel("FODR", hash.Small)
+	return &FarmOverlapDetectionRun{
+		BaseModel: *baseModel,
+	}
+}
@@ -0,0 +1,42 @@
+package farm_overlap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFarmOverlapConflictOrdersPair(t *testing.T) {
+	conflict := NewFarmOverlapConflict("org1", "FARM00000009", "FARM00000002")
+	assert.Equal(t, "FARM00000002", conflict.Farm1ID)
+	assert.Equal(t, "FARM00000009", conflict.Farm2ID)
+	assert.Equal(t, ConflictStatusOpen, conflict.Status)
+	assert.Equal(t, "FARM00000009", conflict.OtherFarmID("FARM00000002"))
+	assert.Equal(t, "FOVC", conflict.GetTableIdentifier())
+	assert.NoError(t, conflict.Validate())
+
+	conflict.Farm1ID, conflict.Farm2ID = conflict.Farm2ID, conflict.Farm1ID
+	assert.Error(t, conflict.Validate())
+}
+
+func TestConflictStatusTransitions(t *testing.T) {
+	tests := []struct {
+		from, to ConflictStatus
+		allowed  bool
+	}{
+		{ConflictStatusOpen, ConflictStatusAcknowledged, true},
+		{ConflictStatusOpen, ConflictStatusFalsePositive, true},
+		{ConflictStatusAcknowledged, ConflictStatusResolved, true},
+		{ConflictStatusAcknowledged, ConflictStatusOpen, true},
+		{ConflictStatusResolved, ConflictStatusOpen, true},
+		{ConflictStatusResolved, ConflictStatusAcknowledged, false},
+		{ConflictStatusFalsePositive, ConflictStatusResolved, false},
+		{ConflictStatusOpen, "CLOSED", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.allowed, tt.from.CanTransitionTo(tt.to), "%s -> %s", tt.from, tt.to)
+	}
+
+	assert.True(t, ConflictStatusAcknowledged.IsUnresolved())
+	assert.False(t, ConflictStatusFalsePositive.IsUnresolved())
+}
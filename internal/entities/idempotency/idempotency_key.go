@@ -0,0 +1,62 @@
+package idempotency
+
+import (
+	"time"
+
+	"github.com/Kisanlink/kisanlink-db/pkg/base"
+	"github.com/Kisanlink/kisanlink-db/pkg/core/hash"
+)
+
+// KeyStatus represents the state of a request made with an idempotency key
+type KeyStatus string
+
+const (
+	KeyStatusInProgress KeyStatus = "IN_PROGRESS"
+	KeyStatusCompleted  KeyStatus = "COMPLETED"
+)
+
+// IdempotencyKey records a mutating request made with an Idempotency-Key header and the
+// response it produced, so a retry with the same key is answered with the stored response
+// instead of being executed again. Keys are scoped to a tenant and expire after a TTL.
+type IdempotencyKey struct {
+	base.BaseModel
+	TenantID            string    `json:"tenant_id" gorm:"type:varchar(255);not null;uniqueIndex:idx_idempotency_keys_tenant_key,priority:1"`
+	Key                 string    `json:"key" gorm:"column:idempotency_key;type:varchar(255);not null;uniqueIndex:idx_idempotency_keys_tenant_key,priority:2"`
+	Method              string    `json:"method" gorm:"type:varchar(10);not null"`
+	Path                string    `json:"path" gorm:"type:text;not null"`
+	RequestHash         string    `json:"request_hash" gorm:"type:varchar(64);not null"` // SHA-256 of method, path and body
+	Status              KeyStatus `json:"status" gorm:"type:varchar(20);not null;default:'IN_PROGRESS'"`
+	ResponseStatus      int       `json:"response_status" gorm:"type:integer;not null;default:0"`
+	ResponseContentType string    `json:"response_content_type" gorm:"type:varchar(255)"`
+	ResponseBody        []byte    `json:"-" gorm:"type:bytea"`
+	ExpiresAt           time.Time `json:"expires_at" gorm:"not null;index:idx_idempotency_keys_expires"`
+}
+
+// TableName returns the table name for IdempotencyKey
+func (k *IdempotencyKey) TableName() string {
+	return "idempotency_keys"
+}
+
+// GetTableIdentifier returns the table identifier for ID generation
+func (k *IdempotencyKey) GetTableIdentifier() string {
+	return "IDEM"
+}
+
+// GetTableSize returns the table size for ID generation
+func (k *IdempotencyKey) GetTableSize() hash.TableSize {
+	return hash.Large
+}
+
+// NewIdempotencyKey creates a new in-progress idempotency key with proper initialization
+func NewIdempotencyKey() *IdempotencyKey {
+	baseModel := base.NewBaseModel("IDEM", hash.Large)
+	return &IdempotencyKey{
+		BaseModel: *baseModel,
+		Status:    KeyStatusInProgress,
+	}
+}
+
+// IsExpired reports whether the key may be used for a new request
+func (k *IdempotencyKey) IsExpired(now time.Time) bool {
+	return !now.Before(k.ExpiresAt)
+}
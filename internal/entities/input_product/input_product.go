@@ -0,0 +1,90 @@
+package input_product
+
+import (
+	"strings"
+
+	"github.com/Kisanlink/farmers-module/pkg/common"
+	"github.com/Kisanlink/kisanlink-db/pkg/base"
+	"github.com/Kisanlink/kisanlink-db/pkg/core/hash"
+)
+
+// InputCategory represents the kind of input used in cultivation
+type InputCategory string
+
+const (
+	InputCategorySeed       InputCategory = "SEED"
+	InputCategoryFertilizer InputCategory = "FERTILIZER"
+	InputCategoryPesticide  InputCategory = "PESTICIDE"
+	InputCategoryLabour     InputCategory = "LABOUR"
+	InputCategoryMachinery  InputCategory = "MACHINERY"
+	InputCategoryIrrigation InputCategory = "IRRIGATION"
+	InputCategoryOther      InputCategory = "OTHER"
+)
+
+// InputCategories lists the input categories in reporting order
+var InputCategories = []InputCategory{
+	InputCategorySeed,
+	InputCategoryFertilizer,
+	InputCategoryPesticide,
+	InputCategoryLabour,
+	InputCategoryMachinery,
+	InputCategoryIrrigation,
+	InputCategoryOther,
+}
+
+// IsValid reports whether the category is one of the known input categories
+func (c InputCategory) IsValid() bool {
+	for _, category := range InputCategories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// InputProduct represents an entry of the input master: a seed, fertiliser or pesticide
+// product, or a labour or machinery service, with the unit its quantities are recorded in
+type InputProduct struct {
+	base.BaseModel
+	Name         string        `json:"name" gorm:"type:varchar(255);not null;index"`
+	Category     InputCategory `json:"category" gorm:"type:varchar(20);not null;index"`
+	Unit         string        `json:"unit" gorm:"type:varchar(50);not null"` // e.g. kg, litre, bag, man-day, hour
+	Manufacturer *string       `json:"manufacturer,omitempty" gorm:"type:varchar(255)"`
+	Description  *string       `json:"description,omitempty" gorm:"type:text"`
+	IsActive     bool          `json:"is_active" gorm:"type:boolean;not null;default:true"`
+}
+
+// TableName returns the table name for the InputProduct model
+func (p *InputProduct) TableName() string {
+	return "input_products"
+}
+
+// GetTableIdentifier returns the table identifier for ID generation
+func (p *InputProduct) GetTableIdentifier() string {
+	return "INPD"
+}
+
+// GetTableSize returns the table size for ID generation
+func (p *InputProduct) GetTableSize() hash.TableSize {
+	return hash.Medium
+}
+
+// NewInputProduct creates a new input product model with proper initialization
+func NewInputProduct() *InputProduct {
+	baseModel := base.NewBaseModel("INPD", hash.Medium)
+	return &InputProduct{
+		BaseModel: *baseModel,
+		IsActive:  true,
+	}
+}
+
+// Validate validates the input product model
+func (p *InputProduct) Validate() error {
+	if strings.TrimSpace(p.Name) == "" || strings.TrimSpace(p.Unit) == "" {
+		return common.ErrInvalidInput
+	}
+	if !p.Category.IsValid() {
+		return common.ErrInvalidInput
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package land_parcel
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/farm"
+	"github.com/Kisanlink/kisanlink-db/pkg/base"
+	"github.com/Kisanlink/kisanlink-db/pkg/core/hash"
+)
+
+// TenureType represents how a farmer holds a land parcel
+type TenureType string
+
+const (
+	TenureOwned  TenureType = "OWNED"  // Owned outright or as a co-owner with a share
+	TenureLeased TenureType = "LEASED" // Leased in from the owner for the lease period
+)
+
+// LandParcel links a farm to a parcel in the state land records, identified by state,
+// district, tehsil and village codes and a survey (khasra) number. A farm may span several
+// parcels, and a parcel may be shared: each claim carries the percentage share it covers.
+// Location codes and survey numbers are stored trimmed and upper-cased so that claims on the
+// same parcel compare equal.
+type LandParcel struct {
+	base.BaseModel
+	FarmID            string     `json:"farm_id" gorm:"type:varchar(255);not null;index"`
+	FarmerID          string     `json:"farmer_id" gorm:"type:varchar(255);not null;index"`
+	AAAOrgID          string     `json:"aaa_org_id" gorm:"type:varchar(255);not null;index"`
+	StateCode         string     `json:"state_code" gorm:"type:varchar(20);not null;index:idx_land_parcels_record,priority:1"`
+	DistrictCode      string     `json:"district_code" gorm:"type:varchar(20);not null;index:idx_land_parcels_record,priority:2"`
+	TehsilCode        string     `json:"tehsil_code" gorm:"type:varchar(20);not null;index:idx_land_parcels_record,priority:3"`
+	VillageCode       string     `json:"village_code" gorm:"type:varchar(20);not null;index:idx_land_parcels_record,priority:4"`
+	SurveyNumber      string     `json:"survey_number" gorm:"type:varchar(50);not null;index:idx_land_parcels_record,priority:5"` // Survey or khasra number, with any sub-division (e.g. 123/2A)
+	KhataNumber       *string    `json:"khata_number,omitempty" gorm:"type:varchar(50)"`                                          // Account (khata/khatauni) number of the holding
+	AreaHa            *float64   `json:"area_ha,omitempty" gorm:"type:numeric(12,4)"`                                             // Area of the parcel as recorded
+	SharePct          float64    `json:"share_pct" gorm:"type:numeric(5,2);not null;default:100"`                                 // Share of the parcel held, 0-100
+	Tenure            TenureType `json:"tenure" gorm:"type:varchar(20);not null;default:'OWNED'"`
+	LeaseStartDate    *time.Time `json:"lease_start_date,omitempty" gorm:"type:date"`
+	LeaseEndDate      *time.Time `json:"lease_end_date,omitempty" gorm:"type:date"` // Nil for an open-ended lease
+	LessorName        *string    `json:"lessor_name,omitempty" gorm:"type:varchar(255)"`
+	DocumentType      *string    `json:"document_type,omitempty" gorm:"type:varchar(50)"` // Ownership or lease document, e.g. 7/12 extract, RoR, lease deed
+	DocumentReference *string    `json:"document_reference,omitempty" gorm:"type:text"`   // Document number or URL
+}
+
+// TableName returns the table name for LandParcel
+func (p *LandParcel) TableName() string {
+	return "land_parcels"
+}
+
+// GetTableIdentifier returns the table identifier for ID generation
+func (p *LandParcel) GetTableIdentifier() string {
+	return "LPCL"
+}
+
+// GetTableSize returns the table size for ID generation
+func (p *LandParcel) GetTableSize() hash.TableSize {
+	return hash.Large
+}
+
+// NewLandParcel creates a new land parcel with proper initialization
+func NewLandParcel() *LandParcel {
+	baseModel := base.NewBaseModel("LPCL", hash.Large)
+	return &LandParcel{
+		BaseModel: *baseModel,
+		SharePct:  100,
+		Tenure:    TenureOwned,
+	}
+}
+
+// Normalize trims and upper-cases the land record identifiers
+func (p *LandParcel) Normalize() {
+	for _, code := range []*string{&p.StateCode, &p.DistrictCode, &p.TehsilCode, &p.VillageCode, &p.SurveyNumber} {
+		*code = strings.ToUpper(strings.TrimSpace(*code))
+	}
+}
+
+// RecordKey identifies the parcel in the land records, the same for every claim on it
+func (p *LandParcel) RecordKey() string {
+	return strings.Join([]string{p.StateCode, p.DistrictCode, p.TehsilCode, p.VillageCode, p.SurveyNumber}, "/")
+}
+
+// Validate validates the land parcel
+func (p *LandParcel) Validate() error {
+	if p.FarmID == "" || p.FarmerID == "" || p.AAAOrgID == "" {
+		return errors.New("farm_id, farmer_id and aaa_org_id are required")
+	}
+	if p.StateCode == "" || p.DistrictCode == "" || p.TehsilCode == "" || p.VillageCode == "" || p.SurveyNumber == "" {
+		return errors.New("state, district, tehsil and village codes and the survey number are required")
+	}
+	if p.SharePct <= 0 || p.SharePct > 100 {
+		return errors.New("share_pct must be greater than 0 and at most 100")
+	}
+	if p.AreaHa != nil && *p.AreaHa <= 0 {
+		return errors.New("area_ha must be positive")
+	}
+	switch p.Tenure {
+	case TenureOwned:
+		if p.LeaseStartDate != nil || p.LeaseEndDate != nil {
+			return errors.New("lease dates apply only to leased parcels")
+		}
+	case TenureLeased:
+		if p.LeaseStartDate == nil {
+			return errors.New("lease_start_date is required for leased parcels")
+		}
+		if p.LeaseEndDate != nil && !p.LeaseEndDate.After(*p.LeaseStartDate) {
+			return errors.New("lease_end_date must be after lease_start_date")
+		}
+	default:
+		return errors.New("tenure must be OWNED or LEASED")
+	}
+	return nil
+}
+
+// LeaseActiveOn reports whether the parcel is leased in on the given day
+func (p *LandParcel) LeaseActiveOn(t time.Time) bool {
+	if p.Tenure != TenureLeased || p.LeaseStartDate == nil || t.Before(*p.LeaseStartDate) {
+		return false
+	}
+	return p.LeaseEndDate == nil || t.Before(*p.LeaseEndDate)
+}
+
+// SharesClaimWith reports whether the shares of two claims on the same survey number count
+// against the same 100%: owned shares always do, leases only when their periods overlap
+func (p *LandParcel) SharesClaimWith(other *LandParcel) bool {
+	if p.Tenure != other.Tenure {
+		return false
+	}
+	if p.Tenure != TenureLeased {
+		return true
+	}
+	if p.LeaseStartDate == nil || other.LeaseStartDate == nil {
+		return false
+	}
+	// An open-ended lease runs for ever
+	startsBefore := func(start *time.Time, end *time.Time) bool {
+		return end == nil || start.Before(*end)
+	}
+	return startsBefore(other.LeaseStartDate, p.LeaseEndDate) && startsBefore(p.LeaseStartDate, other.LeaseEndDate)
+}
+
+// DeriveOwnershipType returns the ownership type of a farm made up of the given parcels: LEASE
+// while any of its leases is running, otherwise SHARED when any owned parcel is held only in
+// part, otherwise OWN. It reports false when the parcels say nothing, because the farm has no
+// owned parcels and no running lease, and the farm's ownership type should be left alone.
+func DeriveOwnershipType(parcels []*LandParcel, now time.Time) (farm.OwnershipType, bool) {
+	owned, partial := false, false
+	for _, parcel := range parcels {
+		if parcel.LeaseActiveOn(now) {
+			return farm.OwnershipLease, true
+		}
+		if parcel.Tenure == TenureOwned {
+			owned = true
+			partial = partial || parcel.SharePct < 100
+		}
+	}
+	switch {
+	case partial:
+		return farm.OwnershipShared, true
+	case owned:
+		return farm.OwnershipOwn, true
+	}
+	return "", false
+}
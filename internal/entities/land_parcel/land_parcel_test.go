@@ -0,0 +1,120 @@
+package land_parcel
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/farm"
+	"github.com/stretchr/testify/assert"
+)
+
+func date(year int, month time.Month, day int) *time.Time {
+	t := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	return &t
+}
+
+func newParcel() *LandParcel {
+	p := NewLandParcel()
+	p.FarmID = "FARM00000001"
+	p.FarmerID = "FMRR0000000001"
+	p.AAAOrgID = "org123"
+	p.StateCode = " 27 "
+	p.DistrictCode = "523"
+	p.TehsilCode = "4187"
+	p.VillageCode = "563214"
+	p.SurveyNumber = "123/2a"
+	p.Normalize()
+	return p
+}
+
+func TestLandParcelNormalize(t *testing.T) {
+	p := newParcel()
+	assert.Equal(t, "27/523/4187/563214/123/2A", p.RecordKey())
+	assert.Equal(t, 100.0, p.SharePct)
+	assert.Equal(t, TenureOwned, p.Tenure)
+	assert.Equal(t, "LPCL", p.GetTableIdentifier())
+}
+
+func TestLandParcelValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(p *LandParcel)
+		wantErr bool
+	}{
+		{"owned parcel", func(p *LandParcel) {}, false},
+		{"partial share", func(p *LandParcel) { p.SharePct = 33.33 }, false},
+		{"missing survey number", func(p *LandParcel) { p.SurveyNumber = "" }, true},
+		{"zero share", func(p *LandParcel) { p.SharePct = 0 }, true},
+		{"share above 100", func(p *LandParcel) { p.SharePct = 100.5 }, true},
+		{"owned with lease dates", func(p *LandParcel) { p.LeaseStartDate = date(2024, 6, 1) }, true},
+		{"lease without start", func(p *LandParcel) { p.Tenure = TenureLeased }, true},
+		{"open-ended lease", func(p *LandParcel) {
+			p.Tenure = TenureLeased
+			p.LeaseStartDate = date(2024, 6, 1)
+		}, false},
+		{"lease ending before it starts", func(p *LandParcel) {
+			p.Tenure = TenureLeased
+			p.LeaseStartDate = date(2024, 6, 1)
+			p.LeaseEndDate = date(2024, 5, 1)
+		}, true},
+		{"unknown tenure", func(p *LandParcel) { p.Tenure = "RENTED" }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newParcel()
+			tt.modify(p)
+			err := p.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDeriveOwnershipType(t *testing.T) {
+	now := *date(2025, 1, 15)
+
+	owned := newParcel()
+	shared := newParcel()
+	shared.SharePct = 50
+	lease := newParcel()
+	lease.Tenure = TenureLeased
+	lease.LeaseStartDate = date(2024, 6, 1)
+	lease.LeaseEndDate = date(2025, 5, 31)
+	expired := newParcel()
+	expired.Tenure = TenureLeased
+	expired.LeaseStartDate = date(2023, 6, 1)
+	expired.LeaseEndDate = date(2024, 5, 31)
+	future := newParcel()
+	future.Tenure = TenureLeased
+	future.LeaseStartDate = date(2025, 6, 1)
+
+	tests := []struct {
+		name    string
+		parcels []*LandParcel
+		want    farm.OwnershipType
+		wantOK  bool
+	}{
+		{"no parcels", nil, "", false},
+		{"owned", []*LandParcel{owned}, farm.OwnershipOwn, true},
+		{"partly owned", []*LandParcel{owned, shared}, farm.OwnershipShared, true},
+		{"running lease", []*LandParcel{owned, lease}, farm.OwnershipLease, true},
+		{"expired lease", []*LandParcel{owned, expired}, farm.OwnershipOwn, true},
+		{"only leases not running", []*LandParcel{expired, future}, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := DeriveOwnershipType(tt.parcels, now)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	// The lease ends at the start of its end date
+	assert.True(t, lease.LeaseActiveOn(*date(2025, 5, 30)))
+	assert.False(t, lease.LeaseActiveOn(*date(2025, 5, 31)))
+}
@@ -0,0 +1,117 @@
+package plot
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Kisanlink/farmers-module/pkg/geo"
+	"github.com/Kisanlink/kisanlink-db/pkg/base"
+	"github.com/Kisanlink/kisanlink-db/pkg/core/hash"
+	"gorm.io/gorm"
+)
+
+// ToleranceHa is the area, 1 m², below which a plot sticking out of its farm or two plots
+// overlapping is put down to digitising and ignored
+const ToleranceHa = 0.0001
+
+// Plot is a field of a farm with its own boundary, which crop cycles can be grown on. A
+// plot lies within its farm's boundary.
+type Plot struct {
+	base.BaseModel
+	FarmID   string  `json:"farm_id" gorm:"type:varchar(255);not null;index"`
+	AAAOrgID string  `json:"aaa_org_id" gorm:"type:varchar(255);not null;index"`
+	Name     string  `json:"name" gorm:"type:varchar(255);not null"`
+	Geometry string  `json:"geometry" gorm:"type:geometry(MULTIPOLYGON,4326);not null"`
+	AreaHa   float64 `json:"area_ha" gorm:"type:numeric(12,4);not null;default:0"` // Geodesic area of the boundary
+}
+
+// TableName returns the table name for Plot
+func (p *Plot) TableName() string {
+	return "plots"
+}
+
+// GetTableIdentifier returns the table identifier for ID generation
+func (p *Plot) GetTableIdentifier() string {
+	return "PLOT"
+}
+
+// GetTableSize returns the table size for ID generation
+func (p *Plot) GetTableSize() hash.TableSize {
+	return hash.Medium
+}
+
+// NewPlot creates a new plot
+func NewPlot() *Plot {
+	baseModel := base.NewBaseModel("PLOT", hash.Medium)
+	return &Plot{
+		BaseModel: *baseModel,
+	}
+}
+
+// SetGeometry sets the plot's boundary from WKT and computes its area
+func (p *Plot) SetGeometry(wkt string) error {
+	geometry, err := geo.ParseWKT(wkt)
+	if err != nil {
+		return fmt.Errorf("invalid geometry: %w", err)
+	}
+	p.Geometry = geometry.MultiWKT()
+	p.AreaHa = geo.AreaHa(geometry)
+	return nil
+}
+
+// Shape returns the plot's boundary, whether it holds WKT or the hex EWKB PostGIS returns
+func (p *Plot) Shape() (geo.MultiPolygon, error) {
+	return geo.ParseGeometry(p.Geometry)
+}
+
+// Validate validates the plot
+func (p *Plot) Validate() error {
+	if p.FarmID == "" {
+		return errors.New("farm_id is required")
+	}
+	if p.AAAOrgID == "" {
+		return errors.New("aaa_org_id is required")
+	}
+	if strings.TrimSpace(p.Name) == "" {
+		return errors.New("name is required")
+	}
+	if p.Geometry == "" {
+		return errors.New("geometry is required")
+	}
+	if p.AreaHa < ToleranceHa {
+		return errors.New("plot area must be at least 1 m²")
+	}
+	return nil
+}
+
+// OutsideAreaHa returns how much of a plot lies outside its farm's boundary
+func OutsideAreaHa(plot, farm geo.MultiPolygon) float64 {
+	outside := geo.AreaHa(plot) - geo.IntersectionAreaHa(plot, farm)
+	if outside < 0 {
+		return 0
+	}
+	return outside
+}
+
+// OverlapAreaHa returns the area two plots share, or 0 when either boundary cannot be read
+func OverlapAreaHa(a, b *Plot) float64 {
+	shapeA, err := a.Shape()
+	if err != nil {
+		return 0
+	}
+	shapeB, err := b.Shape()
+	if err != nil {
+		return 0
+	}
+	return geo.IntersectionAreaHa(shapeA, shapeB)
+}
+
+// BeforeSave is a GORM hook that stores POLYGON boundaries as single-part MULTIPOLYGONs, as
+// Farm does
+func (p *Plot) BeforeSave(tx *gorm.DB) error {
+	if geometry, err := geo.ParseWKT(p.Geometry); err == nil {
+		p.Geometry = geometry.MultiWKT()
+	}
+	return nil
+}
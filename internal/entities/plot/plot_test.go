@@ -0,0 +1,87 @@
+package plot
+
+import (
+	"testing"
+
+	"github.com/Kisanlink/farmers-module/pkg/geo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// A farm of about 4.5 ha near Indore and plots of half of it
+const (
+	farmWKT  = "POLYGON((75.85 22.71, 75.852 22.71, 75.852 22.712, 75.85 22.712, 75.85 22.71))"
+	westWKT  = "POLYGON((75.85 22.71, 75.851 22.71, 75.851 22.712, 75.85 22.712, 75.85 22.71))"
+	eastWKT  = "POLYGON((75.851 22.71, 75.852 22.71, 75.852 22.712, 75.851 22.712, 75.851 22.71))"
+	strayWKT = "POLYGON((75.8515 22.71, 75.8525 22.71, 75.8525 22.712, 75.8515 22.712, 75.8515 22.71))"
+)
+
+func newPlot(t *testing.T, wkt string) *Plot {
+	p := NewPlot()
+	p.FarmID = "FARM00000001"
+	p.AAAOrgID = "org123"
+	p.Name = "North field"
+	require.NoError(t, p.SetGeometry(wkt))
+	return p
+}
+
+func TestPlotSetGeometry(t *testing.T) {
+	p := newPlot(t, westWKT)
+	assert.Equal(t, "PLOT", p.GetTableIdentifier())
+	assert.Contains(t, p.Geometry, "MULTIPOLYGON")
+	assert.InDelta(t, 2.28, p.AreaHa, 0.05)
+
+	assert.Error(t, p.SetGeometry("LINESTRING(75.85 22.71, 75.852 22.71)"))
+}
+
+func TestPlotValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(p *Plot)
+		wantErr bool
+	}{
+		{"valid plot", func(p *Plot) {}, false},
+		{"missing farm", func(p *Plot) { p.FarmID = "" }, true},
+		{"missing org", func(p *Plot) { p.AAAOrgID = "" }, true},
+		{"blank name", func(p *Plot) { p.Name = "  " }, true},
+		{"missing geometry", func(p *Plot) { p.Geometry = "" }, true},
+		{"no area", func(p *Plot) { p.AreaHa = 0 }, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newPlot(t, westWKT)
+			tt.modify(p)
+			if tt.wantErr {
+				assert.Error(t, p.Validate())
+			} else {
+				assert.NoError(t, p.Validate())
+			}
+		})
+	}
+}
+
+func TestOutsideAreaHa(t *testing.T) {
+	farm, err := geo.ParseWKT(farmWKT)
+	require.NoError(t, err)
+	west, err := geo.ParseWKT(westWKT)
+	require.NoError(t, err)
+	stray, err := geo.ParseWKT(strayWKT)
+	require.NoError(t, err)
+
+	assert.Less(t, OutsideAreaHa(west, farm), ToleranceHa)
+	// Half of the stray plot lies east of the farm
+	assert.InDelta(t, geo.AreaHa(stray)/2, OutsideAreaHa(stray, farm), 0.01)
+}
+
+func TestOverlapAreaHa(t *testing.T) {
+	west := newPlot(t, westWKT)
+	east := newPlot(t, eastWKT)
+	stray := newPlot(t, strayWKT)
+
+	assert.Less(t, OverlapAreaHa(west, east), ToleranceHa)
+	assert.InDelta(t, east.AreaHa/2, OverlapAreaHa(east, stray), 0.01)
+
+	unreadable := newPlot(t, westWKT)
+	unreadable.Geometry = "not a geometry"
+	assert.Zero(t, OverlapAreaHa(west, unreadable))
+}
@@ -0,0 +1,78 @@
+package requests
+
+// AdminAreaFilter selects records by the administrative area of their farms; empty codes
+// match any area
+type AdminAreaFilter struct {
+	StateCode    string `json:"state_code,omitempty" example:"27"`
+	DistrictCode string `json:"district_code,omitempty" example:"523"`
+	BlockCode    string `json:"block_code,omitempty" example:"4187"`
+	VillageCode  string `json:"village_code,omitempty" example:"563214"`
+}
+
+// IsEmpty reports whether no area code is set
+func (f AdminAreaFilter) IsEmpty() bool {
+	return f.StateCode == "" && f.DistrictCode == "" && f.BlockCode == "" && f.VillageCode == ""
+}
+
+// Columns returns the farms columns to match, keyed by column name, for the codes that are set
+func (f AdminAreaFilter) Columns() map[string]string {
+	columns := make(map[string]string, 4)
+	for column, code := range map[string]string{
+		"state_code":    f.StateCode,
+		"district_code": f.DistrictCode,
+		"block_code":    f.BlockCode,
+		"village_code":  f.VillageCode,
+	} {
+		if code != "" {
+			columns[column] = code
+		}
+	}
+	return columns
+}
+
+// ImportAdminBoundariesRequest represents an upload of administrative boundaries of one level.
+// Each feature's code, name and parent code are read from the named properties.
+type ImportAdminBoundariesRequest struct {
+	BaseRequest
+	Level              string `json:"level" validate:"required,oneof=STATE DISTRICT BLOCK VILLAGE" example:"VILLAGE"`
+	InputFormat        string `json:"input_format" validate:"required,oneof=geojson shapefile" example:"geojson"`
+	CodeProperty       string `json:"code_property" validate:"required" example:"village_code"`
+	NameProperty       string `json:"name_property" validate:"required" example:"village_name"`
+	ParentCodeProperty string `json:"parent_code_property,omitempty" example:"block_code"`
+	Data               []byte `json:"data" validate:"required"`
+}
+
+// BackfillFarmAdminCodesRequest represents a request to assign administrative area codes to
+// every farm from its boundary
+type BackfillFarmAdminCodesRequest struct {
+	BaseRequest
+}
+
+// ListAdminBoundariesRequest represents a request to list the administrative areas of a level,
+// optionally those within one parent area
+type ListAdminBoundariesRequest struct {
+	BaseRequest
+	Level      string `json:"level" validate:"required,oneof=STATE DISTRICT BLOCK VILLAGE" example:"BLOCK"`
+	ParentCode string `json:"parent_code,omitempty" example:"523"`
+}
+
+// NewImportAdminBoundariesRequest creates a new import admin boundaries request
+func NewImportAdminBoundariesRequest() ImportAdminBoundariesRequest {
+	return ImportAdminBoundariesRequest{
+		BaseRequest: NewBaseRequest(),
+	}
+}
+
+// NewBackfillFarmAdminCodesRequest creates a new backfill farm admin codes request
+func NewBackfillFarmAdminCodesRequest() BackfillFarmAdminCodesRequest {
+	return BackfillFarmAdminCodesRequest{
+		BaseRequest: NewBaseRequest(),
+	}
+}
+
+// NewListAdminBoundariesRequest creates a new list admin boundaries request
+func NewListAdminBoundariesRequest() ListAdminBoundariesRequest {
+	return ListAdminBoundariesRequest{
+		BaseRequest: NewBaseRequest(),
+	}
+}
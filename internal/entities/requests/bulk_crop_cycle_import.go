@@ -0,0 +1,38 @@
+package requests
+
+// BulkCropCycleImportRequest represents a request to create crop cycles and farm activities
+// from a sowing survey spreadsheet
+type BulkCropCycleImportRequest struct {
+	BaseRequest
+	FPOOrgID    string                 `json:"fpo_org_id" validate:"required" example:"org_123e4567-e89b-12d3-a456-426614174000"`
+	InputFormat string                 `json:"input_format" validate:"required,oneof=csv excel" example:"csv"`
+	Data        []byte                 `json:"data,omitempty"`
+	Options     CropCycleImportOptions `json:"options"`
+}
+
+// CropCycleImportOptions represents options for a bulk crop cycle import
+type CropCycleImportOptions struct {
+	Season          string `json:"season,omitempty" validate:"omitempty,oneof=RABI KHARIF ZAID PERENNIAL OTHER" example:"KHARIF"` // used for rows without a season
+	ContinueOnError bool   `json:"continue_on_error" example:"true"`                                                              // continue with the next row when one fails
+}
+
+// CropCycleBulkData represents a single row of a crop cycle import. Values are kept as
+// entered so that rejected rows can be reported back unchanged; they are interpreted by
+// the crop cycle pipeline stages.
+//
+// Rows with the same farm, crop, season and sowing date belong to the same crop cycle: the
+// first such row creates the cycle and the others only add their activity to it.
+type CropCycleBulkData struct {
+	FarmID        string                 `json:"farm_id" example:"FARM00000001"`
+	Crop          string                 `json:"crop" example:"Paddy"`
+	Variety       string                 `json:"variety,omitempty" example:"IR-64"`
+	Season        string                 `json:"season,omitempty" example:"KHARIF"`
+	AreaHa        string                 `json:"area_ha,omitempty" example:"1.5"`
+	SowingDate    string                 `json:"sowing_date" example:"2024-06-20"`
+	ActivityType  string                 `json:"activity_type,omitempty" example:"SOWING"`
+	ActivityDate  string                 `json:"activity_date,omitempty" example:"2024-06-20"`
+	CompletedDate string                 `json:"completed_date,omitempty" example:"2024-06-21"` // marks the activity as completed
+	Notes         string                 `json:"notes,omitempty" example:"Line sowing with seed drill"`
+	ExternalID    string                 `json:"external_id,omitempty" example:"SURVEY_2024_0001"`
+	CustomFields  map[string]interface{} `json:"custom_fields,omitempty"` // unrecognised columns, echoed in result files
+}
@@ -0,0 +1,25 @@
+package requests
+
+// BulkFarmImportRequest represents a request to create farms from a boundary file. Every
+// feature of the file becomes one farm of the FPO farmer it is matched to.
+type BulkFarmImportRequest struct {
+	BaseRequest
+	FPOOrgID    string            `json:"fpo_org_id" validate:"required" example:"org_123e4567-e89b-12d3-a456-426614174000"`
+	InputFormat string            `json:"input_format" validate:"required,oneof=geojson kml shapefile" example:"geojson"` // shapefile uploads are zip archives
+	Data        []byte            `json:"data,omitempty"`
+	Options     FarmImportOptions `json:"options"`
+}
+
+// FarmImportOptions represents options for a bulk farm import
+type FarmImportOptions struct {
+	MatchBy         string `json:"match_by,omitempty" example:"phone"`     // phone, aaa_user_id; by default aaa_user_id when the feature has one, else phone
+	AllowOverlap    bool   `json:"allow_overlap" example:"false"`          // create farms that overlap existing farms of the FPO
+	ContinueOnError bool   `json:"continue_on_error" example:"true"`       // continue with the next feature when one fails
+	OwnershipType   string `json:"ownership_type,omitempty" example:"OWN"` // used when a feature has no ownership_type property
+}
+
+// Farm import matching modes
+const (
+	FarmImportMatchByPhone     = "phone"
+	FarmImportMatchByAAAUserID = "aaa_user_id"
+)
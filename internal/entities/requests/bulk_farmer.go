@@ -13,6 +13,11 @@ type BulkFarmerAdditionRequest struct {
 	FileURL        string                `json:"file_url,omitempty" example:"https://storage.example.com/uploads/farmers_batch_001.csv"` // For file URL reference
 	ProcessingMode string                `json:"processing_mode" validate:"required,oneof=sync async batch" example:"async"`
 	Options        BulkProcessingOptions `json:"options"`
+	// MappingProfileID selects a saved column mapping profile for files that do not use the template headers
+	MappingProfileID string `json:"mapping_profile_id,omitempty" example:"BLKM00000001"`
+	// File is the upload of a multipart request. It is read into Data when the upload is
+	// parsed whole; streamed uploads are copied to disk instead of being held in memory.
+	File *multipart.FileHeader `json:"-"`
 }
 
 // BulkProcessingOptions represents options for bulk processing
@@ -85,10 +90,12 @@ type RetryBulkOperationRequest struct {
 // ValidateBulkDataRequest represents a request to validate bulk data
 type ValidateBulkDataRequest struct {
 	BaseRequest
-	FPOOrgID    string           `json:"fpo_org_id" validate:"required" example:"org_123e4567-e89b-12d3-a456-426614174000"`
-	InputFormat string           `json:"input_format" validate:"required,oneof=csv excel json" example:"json"`
-	Data        []byte           `json:"data,omitempty"`
-	Farmers     []FarmerBulkData `json:"farmers,omitempty"`
+	FPOOrgID          string           `json:"fpo_org_id" validate:"required" example:"org_123e4567-e89b-12d3-a456-426614174000"`
+	InputFormat       string           `json:"input_format" validate:"required,oneof=csv excel json" example:"json"`
+	Data              []byte           `json:"data,omitempty"`
+	Farmers           []FarmerBulkData `json:"farmers,omitempty"`
+	DeduplicationMode string           `json:"deduplication_mode,omitempty" example:"skip"` // skip, update, error - duplicates only fail validation in error mode
+	MappingProfileID  string           `json:"mapping_profile_id,omitempty" example:"BLKM00000001"`
 }
 
 // GetBulkTemplateRequest represents a request to get a bulk upload template
@@ -107,6 +114,42 @@ type DownloadBulkResultsRequest struct {
 	IncludeAll  bool   `json:"include_all" example:"false"` // Include all records or just failures
 }
 
+// ColumnMappingRule maps source columns of an upload to a farmer field
+type ColumnMappingRule struct {
+	Target    string            `json:"target" validate:"required" example:"phone_number"` // farmer field, or custom_fields.<name>
+	Source    string            `json:"source,omitempty" example:"Mobile No"`
+	Sources   []string          `json:"sources,omitempty" example:"Village,Tehsil"` // concat only
+	Transform string            `json:"transform,omitempty" example:"copy"`         // copy, split_name, concat, default, lookup
+	Part      string            `json:"part,omitempty" example:"first"`             // split_name only: first, last
+	Separator string            `json:"separator,omitempty" example:", "`           // concat only, defaults to a space
+	Lookup    map[string]string `json:"lookup,omitempty" example:"M:male,F:female"` // lookup only, matched case-insensitively
+	Default   string            `json:"default,omitempty" example:"India"`          // used when the mapped value is empty
+}
+
+// ColumnMappingProfileRequest represents a request to create or update a column mapping profile
+type ColumnMappingProfileRequest struct {
+	BaseRequest
+	ProfileID    string              `json:"-"`
+	FPOOrgID     string              `json:"fpo_org_id" validate:"required" example:"org_123e4567-e89b-12d3-a456-426614174000"`
+	Name         string              `json:"name" validate:"required" example:"Khandwa FPO register"`
+	Description  string              `json:"description,omitempty" example:"Member register exported from the FPO's accounting software"`
+	Mappings     []ColumnMappingRule `json:"mappings" validate:"required,min=1"`
+	KeepUnmapped bool                `json:"keep_unmapped" example:"true"` // pass unmapped columns through as template columns or custom fields
+}
+
+// PreviewColumnMappingRequest represents a request to preview how a file is mapped, either
+// with a saved profile or with inline mappings that have not been saved yet
+type PreviewColumnMappingRequest struct {
+	BaseRequest
+	FPOOrgID     string              `json:"fpo_org_id" validate:"required" example:"org_123e4567-e89b-12d3-a456-426614174000"`
+	InputFormat  string              `json:"input_format" validate:"required,oneof=csv excel json" example:"csv"`
+	Data         []byte              `json:"data"`
+	ProfileID    string              `json:"profile_id,omitempty" example:"BLKM00000001"`
+	Mappings     []ColumnMappingRule `json:"mappings,omitempty"`
+	KeepUnmapped bool                `json:"keep_unmapped" example:"false"`
+	Limit        int                 `json:"limit,omitempty" example:"20"` // number of mapped records to return (default: 20)
+}
+
 // NewBulkFarmerAdditionRequest creates a new bulk farmer addition request
 func NewBulkFarmerAdditionRequest() BulkFarmerAdditionRequest {
 	return BulkFarmerAdditionRequest{
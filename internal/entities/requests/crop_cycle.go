@@ -9,22 +9,32 @@ import (
 type StartCycleRequest struct {
 	BaseRequest
 	FarmID    string    `json:"farm_id" validate:"required" example:"farm_123e4567-e89b-12d3-a456-426614174000"`
+	PlotID    *string   `json:"plot_id,omitempty" example:"PLOT00000001"` // Defaults area_ha to the plot's area
 	AreaHa    *float64  `json:"area_ha" validate:"omitempty,gt=0" example:"5.5"`
 	Season    string    `json:"season" validate:"required,oneof=RABI KHARIF ZAID PERENNIAL OTHER" example:"RABI"`
 	StartDate time.Time `json:"start_date" validate:"required" example:"2024-11-01T00:00:00Z"`
 	CropID    string    `json:"crop_id" validate:"required" example:"crop_123e4567-e89b-12d3-a456-426614174000"`
 	VarietyID *string   `json:"variety_id,omitempty" example:"variety_123e4567-e89b-12d3-a456-426614174000"`
+	// Trees of a perennial crop, for estimating its yield from the variety's yield per tree
+	TreeCount    *int `json:"tree_count,omitempty" validate:"omitempty,gt=0" example:"120"`
+	PlantingYear *int `json:"planting_year,omitempty" validate:"omitempty,gt=0" example:"2016"`
+	// Plan the activities of the crop's activity templates, counted from start_date
+	GenerateActivities bool `json:"generate_activities,omitempty" example:"true"`
 }
 
 // UpdateCycleRequest represents a request to update an existing crop cycle
 type UpdateCycleRequest struct {
 	BaseRequest
 	ID        string     `json:"id" validate:"required" example:"cycle_123e4567-e89b-12d3-a456-426614174000"`
+	PlotID    *string    `json:"plot_id,omitempty" example:"PLOT00000001"` // An empty plot_id detaches the cycle from its plot
 	AreaHa    *float64   `json:"area_ha,omitempty" validate:"omitempty,gt=0" example:"6.0"`
 	Season    *string    `json:"season,omitempty" validate:"omitempty,oneof=RABI KHARIF ZAID PERENNIAL OTHER" example:"RABI"`
 	StartDate *time.Time `json:"start_date,omitempty" example:"2024-11-05T00:00:00Z"`
 	CropID    *string    `json:"crop_id,omitempty" example:"crop_123e4567-e89b-12d3-a456-426614174000"`
 	VarietyID *string    `json:"variety_id,omitempty" example:"variety_123e4567-e89b-12d3-a456-426614174000"`
+	// Trees of a perennial crop, for estimating its yield from the variety's yield per tree
+	TreeCount    *int `json:"tree_count,omitempty" validate:"omitempty,gt=0" example:"120"`
+	PlantingYear *int `json:"planting_year,omitempty" validate:"omitempty,gt=0" example:"2016"`
 }
 
 // EndCycleRequest represents a request to end a crop cycle
@@ -36,6 +46,14 @@ type EndCycleRequest struct {
 	Outcome map[string]interface{} `json:"outcome,omitempty" example:"yield_kg:2500,quality:good,notes:good_harvest"`
 }
 
+// ReplanCycleRequest represents a request to move a crop cycle's sowing date, shifting its
+// planned activities with it
+type ReplanCycleRequest struct {
+	BaseRequest
+	ID        string    `json:"id" validate:"required" example:"CRCY000000001"`
+	StartDate time.Time `json:"start_date" validate:"required" example:"2024-11-05T00:00:00Z"`
+}
+
 // ListCyclesRequest represents a request to list crop cycles with filtering
 type ListCyclesRequest struct {
 	FilterRequest
@@ -53,6 +71,22 @@ type GetCycleRequest struct {
 	ID string `json:"id" validate:"required" example:"cycle_123e4567-e89b-12d3-a456-426614174000"`
 }
 
+// GetYieldEstimateRequest represents a request for the expected yield of a crop cycle
+type GetYieldEstimateRequest struct {
+	BaseRequest
+	ID string `json:"id" validate:"required" example:"CRCY000000001"`
+}
+
+// ProductionForecastRequest represents a request for the estimated production of an
+// organisation's planned and active crop cycles by crop and season
+type ProductionForecastRequest struct {
+	BaseRequest
+	FarmID string `json:"farm_id,omitempty" example:"FARM00000001"`
+	CropID string `json:"crop_id,omitempty" example:"CROP00000001"`
+	Season string `json:"season,omitempty" validate:"omitempty,oneof=RABI KHARIF ZAID PERENNIAL OTHER" example:"KHARIF"`
+	Year   int    `json:"year,omitempty" example:"2024"` // Of the cycles' start date
+}
+
 // NewStartCycleRequest creates a new start cycle request
 func NewStartCycleRequest() StartCycleRequest {
 	return StartCycleRequest{
@@ -75,6 +109,13 @@ func NewEndCycleRequest() EndCycleRequest {
 	}
 }
 
+// NewReplanCycleRequest creates a new replan cycle request
+func NewReplanCycleRequest() ReplanCycleRequest {
+	return ReplanCycleRequest{
+		BaseRequest: NewBaseRequest(),
+	}
+}
+
 // NewListCyclesRequest creates a new list cycles request
 func NewListCyclesRequest() ListCyclesRequest {
 	return ListCyclesRequest{
@@ -91,6 +132,20 @@ func NewGetCycleRequest() GetCycleRequest {
 	}
 }
 
+// NewGetYieldEstimateRequest creates a new get yield estimate request
+func NewGetYieldEstimateRequest() GetYieldEstimateRequest {
+	return GetYieldEstimateRequest{
+		BaseRequest: NewBaseRequest(),
+	}
+}
+
+// NewProductionForecastRequest creates a new production forecast request
+func NewProductionForecastRequest() ProductionForecastRequest {
+	return ProductionForecastRequest{
+		BaseRequest: NewBaseRequest(),
+	}
+}
+
 // Validate validates the start cycle request
 func (req *StartCycleRequest) Validate() error {
 	if req.CropID == "" {
@@ -3,8 +3,10 @@ package requests
 // ValidateGeometryRequest represents a request to validate WKT geometry
 type ValidateGeometryRequest struct {
 	BaseRequest
-	WKT         string `json:"wkt" validate:"required" example:"POLYGON((75.85 22.71, 75.85663 22.71, 75.85663 22.71663, 75.85 22.71663, 75.85 22.71))"`
-	CheckBounds bool   `json:"check_bounds,omitempty" example:"true"` // Whether to check if geometry is within India bounds (~50 ha)
+	WKT                string  `json:"wkt" validate:"required" example:"POLYGON((75.85 22.71, 75.85663 22.71, 75.85663 22.71663, 75.85 22.71663, 75.85 22.71))"`
+	CheckBounds        bool    `json:"check_bounds,omitempty" example:"true"`        // Whether to check if geometry is within India bounds (~50 ha)
+	Repair             bool    `json:"repair,omitempty" example:"true"`              // Also return a repaired geometry for the user to accept or reject
+	SimplifyToleranceM float64 `json:"simplify_tolerance_m,omitempty" example:"0.5"` // Simplification tolerance in metres when repairing
 }
 
 // ReconcileAAALinksRequest represents a request to reconcile AAA links
@@ -23,4 +25,55 @@ type DetectFarmOverlapsRequest struct {
 	BaseRequest
 	MinOverlapAreaHa *float64 `json:"min_overlap_area_ha,omitempty" example:"0.1"` // Minimum overlap area in hectares to report
 	Limit            *int     `json:"limit,omitempty" example:"100"`               // Maximum number of overlaps to return
+	Incremental      bool     `json:"incremental,omitempty" example:"true"`        // Only re-check farms changed since the last complete run
+}
+
+// ListOverlapConflictsRequest represents a request to list an organization's farm overlap
+// conflicts
+type ListOverlapConflictsRequest struct {
+	PaginationRequest
+	Status     string `json:"status,omitempty" example:"OPEN"`
+	FarmID     string `json:"farm_id,omitempty" example:"FARM00000001"`
+	AssigneeID string `json:"assignee_id,omitempty" example:"usr_123e4567-e89b-12d3-a456-426614174000"`
+}
+
+// UpdateOverlapConflictRequest represents a change to the status, assignee or notes of a
+// farm overlap conflict. Omitted fields are left unchanged.
+type UpdateOverlapConflictRequest struct {
+	BaseRequest
+	ConflictID      string  `json:"-"`
+	Status          *string `json:"status,omitempty" validate:"omitempty,oneof=OPEN ACKNOWLEDGED RESOLVED FALSE_POSITIVE" example:"ACKNOWLEDGED"`
+	AssigneeID      *string `json:"assignee_id,omitempty" example:"usr_123e4567-e89b-12d3-a456-426614174000"`
+	ResolutionNotes *string `json:"resolution_notes,omitempty" example:"Field visit booked with both farmers"`
+}
+
+// ResolveOverlapConflictRequest represents the resolution of a farm overlap conflict by
+// changing the farm boundaries
+type ResolveOverlapConflictRequest struct {
+	BaseRequest
+	ConflictID      string  `json:"-"`
+	Action          string  `json:"action" validate:"required,oneof=TRIM SPLIT" example:"TRIM"`
+	TrimFarmID      string  `json:"trim_farm_id,omitempty" example:"FARM00000002"` // For TRIM, the farm that gives up the shared area
+	ResolutionNotes *string `json:"resolution_notes,omitempty" example:"Survey confirmed the shared strip belongs to FARM00000001"`
+}
+
+// NewListOverlapConflictsRequest creates a new list overlap conflicts request
+func NewListOverlapConflictsRequest() ListOverlapConflictsRequest {
+	return ListOverlapConflictsRequest{
+		PaginationRequest: NewPaginationRequest(1, 20),
+	}
+}
+
+// NewUpdateOverlapConflictRequest creates a new update overlap conflict request
+func NewUpdateOverlapConflictRequest() UpdateOverlapConflictRequest {
+	return UpdateOverlapConflictRequest{
+		BaseRequest: NewBaseRequest(),
+	}
+}
+
+// NewResolveOverlapConflictRequest creates a new resolve overlap conflict request
+func NewResolveOverlapConflictRequest() ResolveOverlapConflictRequest {
+	return ResolveOverlapConflictRequest{
+		BaseRequest: NewBaseRequest(),
+	}
 }
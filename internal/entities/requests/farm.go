@@ -1,6 +1,13 @@
 package requests
 
-import "errors"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Kisanlink/farmers-module/pkg/geo"
+)
 
 // CreateFarmRequest represents a request to create a new farm
 // Either farmer_id or aaa_user_id must be provided. If only aaa_user_id is provided,
@@ -20,6 +27,7 @@ type CreateFarmRequest struct {
 	OtherIrrigationDetails    *string                   `json:"other_irrigation_details,omitempty" example:"Canal irrigation available during monsoon"`
 	IrrigationSources         []IrrigationSourceRequest `json:"irrigation_sources,omitempty"`
 	Metadata                  map[string]interface{}    `json:"metadata,omitempty"`
+	BlockOnOverlap            bool                      `json:"block_on_overlap,omitempty" example:"true"` // Reject the farm if its boundary overlaps another farm of the organization
 }
 
 // Validate validates the CreateFarmRequest
@@ -76,6 +84,11 @@ type ListFarmsRequest struct {
 	AAAOrgID  string   `json:"aaa_org_id,omitempty" example:"org_123e4567-e89b-12d3-a456-426614174000"`
 	MinArea   *float64 `json:"min_area,omitempty" example:"1.0"`
 	MaxArea   *float64 `json:"max_area,omitempty" example:"10.0"`
+
+	OwnershipType      string `json:"ownership_type,omitempty" validate:"omitempty,oneof=OWN LEASE SHARED" example:"OWN"`
+	SoilTypeID         string `json:"soil_type_id,omitempty" example:"soil_123e4567-e89b-12d3-a456-426614174000"`
+	IrrigationSourceID string `json:"irrigation_source_id,omitempty" example:"irr_123e4567-e89b-12d3-a456-426614174000"` // Primary irrigation source
+	AdminAreaFilter
 }
 
 // GetFarmsByFarmerRequest represents a request to get farms by farmer
@@ -102,6 +115,52 @@ type GetFarmsByOrgRequest struct {
 	AAAOrgID string `json:"aaa_org_id,omitempty" example:"org_123e4567-e89b-12d3-a456-426614174000"`
 }
 
+// FarmTileRequest represents a request for a vector tile of the caller's organization's
+// farms, addressed by zoom level and XYZ tile coordinates
+type FarmTileRequest struct {
+	BaseRequest
+	Z int `json:"z" example:"14"`
+	X int `json:"x" example:"11652"`
+	Y int `json:"y" example:"7287"`
+}
+
+// MaxFarmTileZoom is the deepest zoom level served as vector tiles
+const MaxFarmTileZoom = 22
+
+// Validate validates the FarmTileRequest
+func (r *FarmTileRequest) Validate() error {
+	if r.Z < 0 || r.Z > MaxFarmTileZoom {
+		return fmt.Errorf("zoom must be between 0 and %d", MaxFarmTileZoom)
+	}
+	if n := 1 << r.Z; r.X < 0 || r.X >= n || r.Y < 0 || r.Y >= n {
+		return fmt.Errorf("tile %d/%d/%d does not exist", r.Z, r.X, r.Y)
+	}
+	return nil
+}
+
+// FarmGeometryDiffRequest represents a request to compare two boundary versions of a farm
+type FarmGeometryDiffRequest struct {
+	BaseRequest
+	FarmID      string `json:"farm_id" validate:"required" example:"FARM00000001"`
+	FromVersion int    `json:"from_version" validate:"required,min=1" example:"1"`
+	ToVersion   int    `json:"to_version" validate:"required,min=1" example:"2"`
+}
+
+// Validate validates the FarmGeometryDiffRequest
+func (r *FarmGeometryDiffRequest) Validate() error {
+	if r.FromVersion < 1 || r.ToVersion < 1 {
+		return errors.New("from and to must be version numbers of 1 or more")
+	}
+	return nil
+}
+
+// FarmGeometryAsOfRequest represents a request for the boundary a farm had at a point in time
+type FarmGeometryAsOfRequest struct {
+	BaseRequest
+	FarmID string    `json:"farm_id" validate:"required" example:"FARM00000001"`
+	AsOf   time.Time `json:"as_of" validate:"required" example:"2024-06-30T23:59:59Z"`
+}
+
 // CheckFarmOverlapRequest represents a request to check farm overlap
 type CheckFarmOverlapRequest struct {
 	BaseRequest
@@ -117,10 +176,130 @@ type BoundingBox struct {
 	MaxLon float64 `json:"max_lon" validate:"required,min=-180,max=180" example:"75.87"`
 }
 
-// GeometryData represents geometric data for farms
+// SearchFarmsRequest represents a spatial farm search. Exactly one of the spatial criteria is
+// given: farms within radius_m metres of a point, farms inside a boundary such as a village
+// (or also those crossing it, with intersects), the nearest farms to a point, or farms in a
+// bounding box. The ListFarms filters narrow the search further.
+type SearchFarmsRequest struct {
+	ListFarmsRequest
+	Lat        *float64      `json:"lat,omitempty" example:"22.715"`
+	Lon        *float64      `json:"lon,omitempty" example:"75.855"`
+	RadiusM    *float64      `json:"radius_m,omitempty" example:"2000"`
+	Within     *GeometryData `json:"within,omitempty"`
+	Intersects bool          `json:"intersects,omitempty" example:"false"`
+	Nearest    *int          `json:"nearest,omitempty" example:"10"`
+	BBox       *BoundingBox  `json:"bbox,omitempty"`
+}
+
+const (
+	// MaxFarmSearchRadiusM bounds point-radius searches
+	MaxFarmSearchRadiusM = 100000.0
+	// MaxNearestFarms bounds nearest-N searches
+	MaxNearestFarms = 500
+)
+
+// Validate validates the SearchFarmsRequest and resolves a GeoJSON boundary to WKT
+func (r *SearchFarmsRequest) Validate() error {
+	criteria := 0
+	for _, given := range []bool{r.RadiusM != nil, r.Within != nil, r.Nearest != nil, r.BBox != nil} {
+		if given {
+			criteria++
+		}
+	}
+	if criteria != 1 {
+		return errors.New("exactly one of radius_m, within, nearest or bbox must be given")
+	}
+
+	if r.RadiusM != nil || r.Nearest != nil {
+		if r.Lat == nil || r.Lon == nil {
+			return errors.New("lat and lon are required for radius and nearest searches")
+		}
+		if *r.Lat < -90 || *r.Lat > 90 || *r.Lon < -180 || *r.Lon > 180 {
+			return errors.New("lat must be between -90 and 90 and lon between -180 and 180")
+		}
+	}
+	if r.RadiusM != nil && (*r.RadiusM <= 0 || *r.RadiusM > MaxFarmSearchRadiusM) {
+		return fmt.Errorf("radius_m must be greater than 0 and at most %g", MaxFarmSearchRadiusM)
+	}
+	if r.Nearest != nil && (*r.Nearest < 1 || *r.Nearest > MaxNearestFarms) {
+		return fmt.Errorf("nearest must be between 1 and %d", MaxNearestFarms)
+	}
+	if r.BBox != nil && (r.BBox.MinLat >= r.BBox.MaxLat || r.BBox.MinLon >= r.BBox.MaxLon) {
+		return errors.New("bbox minimums must be below its maximums")
+	}
+	if r.Within != nil {
+		if err := r.Within.ResolveWKT(); err != nil {
+			return err
+		}
+		if _, err := geo.ParseWKT(r.Within.WKT); err != nil {
+			return fmt.Errorf("invalid within boundary: %w", err)
+		}
+	}
+	return nil
+}
+
+// GeometryData represents geometric data for farms: a POLYGON, which may have holes, or a
+// MULTIPOLYGON for farms in several parts. The geometry may be given as WKT, as
+// RFC 7946 GeoJSON in the geojson field, or as a bare GeoJSON geometry or Feature in place
+// of this object, as map clients such as Leaflet and MapLibre produce it.
+//
+// With repair set, the boundary is repaired before it is validated and stored: duplicate
+// vertices and spikes are removed, self-intersections resolved, winding normalized and the
+// boundary simplified within simplify_tolerance_m metres.
+//
+// Every boundary change is kept in the farm's boundary history with its source and reason.
 type GeometryData struct {
-	WKT string `json:"wkt,omitempty" example:"POLYGON((75.85 22.71, 75.85663 22.71, 75.85663 22.71663, 75.85 22.71663, 75.85 22.71))"` // Well-Known Text format (~50 ha)
-	WKB []byte `json:"wkb,omitempty"`                                                                                                  // Well-Known Binary format
+	WKT                string          `json:"wkt,omitempty" example:"POLYGON((75.85 22.71, 75.85663 22.71, 75.85663 22.71663, 75.85 22.71663, 75.85 22.71))"` // Well-Known Text format (~50 ha)
+	WKB                []byte          `json:"wkb,omitempty"`                                                                                                  // Well-Known Binary format
+	GeoJSON            json.RawMessage `json:"geojson,omitempty" swaggertype:"object"`                                                                         // RFC 7946 Polygon, MultiPolygon or Feature
+	Repair             bool            `json:"repair,omitempty" example:"true"`                                                                                // Repair GPS capture errors before validation
+	SimplifyToleranceM float64         `json:"simplify_tolerance_m,omitempty" example:"0.5"`                                                                   // Simplification tolerance in metres when repairing
+	Source             string          `json:"source,omitempty" validate:"omitempty,oneof=GPS_WALK DIGITISED CADASTRAL" example:"GPS_WALK"`                    // How the boundary was captured
+	ChangeReason       *string         `json:"change_reason,omitempty" example:"Boundary re-walked after lease change"`                                        // Why the boundary changed, kept in its history
+}
+
+// MaxSimplifyToleranceM bounds the simplification tolerance of a geometry repair, so that
+// simplifying cannot noticeably change a farm's shape
+const MaxSimplifyToleranceM = 10.0
+
+// UnmarshalJSON accepts a bare GeoJSON geometry or Feature as well as the object form
+func (g *GeometryData) UnmarshalJSON(data []byte) error {
+	var probe struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &probe); err == nil && probe.Type != "" {
+		*g = GeometryData{GeoJSON: append(json.RawMessage(nil), data...)}
+		return nil
+	}
+
+	type geometryData GeometryData
+	return json.Unmarshal(data, (*geometryData)(g))
+}
+
+// ResolveWKT converts GeoJSON input to WKT, so that services only deal with WKT. WKT takes
+// precedence when both are given. It also rejects an out of range repair tolerance or an
+// unknown source.
+func (g *GeometryData) ResolveWKT() error {
+	if g == nil {
+		return nil
+	}
+	if g.SimplifyToleranceM < 0 || g.SimplifyToleranceM > MaxSimplifyToleranceM {
+		return fmt.Errorf("simplify_tolerance_m must be between 0 and %g", MaxSimplifyToleranceM)
+	}
+	switch g.Source {
+	case "", "GPS_WALK", "DIGITISED", "CADASTRAL":
+	default:
+		return fmt.Errorf("source must be one of GPS_WALK, DIGITISED, CADASTRAL")
+	}
+	if g.WKT != "" || len(g.GeoJSON) == 0 || string(g.GeoJSON) == "null" {
+		return nil
+	}
+	geometry, err := geo.ParseGeoJSON(g.GeoJSON)
+	if err != nil {
+		return fmt.Errorf("invalid geometry: %w", err)
+	}
+	g.WKT = geometry.WKT()
+	return nil
 }
 
 // IrrigationSourceRequest represents irrigation source data in farm requests
@@ -170,6 +349,13 @@ func NewListFarmsRequest() ListFarmsRequest {
 	}
 }
 
+// NewSearchFarmsRequest creates a new search farms request
+func NewSearchFarmsRequest() SearchFarmsRequest {
+	return SearchFarmsRequest{
+		ListFarmsRequest: NewListFarmsRequest(),
+	}
+}
+
 // NewGetFarmsByFarmerRequest creates a new get farms by farmer request
 func NewGetFarmsByFarmerRequest() GetFarmsByFarmerRequest {
 	return GetFarmsByFarmerRequest{
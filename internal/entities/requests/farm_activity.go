@@ -18,10 +18,17 @@ type CreateActivityRequest struct {
 type CompleteActivityRequest struct {
 	BaseRequest
 	ID          string                 `json:"id" validate:"required" example:"activity_123e4567-e89b-12d3-a456-426614174000"`
-	CompletedAt time.Time              `json:"completed_at" validate:"required" example:"2024-11-11T16:30:00Z"`
+	CompletedAt time.Time              `json:"completed_at" example:"2024-11-11T16:30:00Z"` // Defaults to now
 	Output      map[string]interface{} `json:"output"`
 }
 
+// StartActivityRequest represents the request to start a farm activity
+type StartActivityRequest struct {
+	BaseRequest
+	ID        string     `json:"id" validate:"required" example:"activity_123e4567-e89b-12d3-a456-426614174000"`
+	StartedAt *time.Time `json:"started_at,omitempty" example:"2024-11-11T08:00:00Z"` // Defaults to now
+}
+
 // UpdateActivityRequest represents the request to update a farm activity
 type UpdateActivityRequest struct {
 	BaseRequest
@@ -44,3 +51,32 @@ type ListActivitiesRequest struct {
 	Page         int    `json:"page" validate:"min=1" example:"1"`
 	PageSize     int    `json:"page_size" validate:"min=1,max=100" example:"20"`
 }
+
+// AdvanceStageRequest represents the request to record that a crop cycle entered a stage
+type AdvanceStageRequest struct {
+	BaseRequest
+	CropCycleID string     `json:"-"`
+	CropStageID *string    `json:"crop_stage_id,omitempty" example:"CSTG00000002"`      // Defaults to the stage after the current one
+	StartedAt   *time.Time `json:"started_at,omitempty" example:"2024-07-10T00:00:00Z"` // Defaults to now
+	Notes       *string    `json:"notes,omitempty" example:"First flowers seen across the plot"`
+}
+
+// ListDelayedCyclesRequest represents the request to list the crop cycles of an organisation
+// running behind their stage calendar
+type ListDelayedCyclesRequest struct {
+	BaseRequest
+	FarmID       string `json:"farm_id,omitempty" example:"FARM00000001"`
+	MinDelayDays int    `json:"min_delay_days" example:"1"`
+	Page         int    `json:"page" validate:"min=1" example:"1"`
+	PageSize     int    `json:"page_size" validate:"min=1,max=100" example:"20"`
+}
+
+// NewListDelayedCyclesRequest creates a new list delayed cycles request
+func NewListDelayedCyclesRequest() ListDelayedCyclesRequest {
+	return ListDelayedCyclesRequest{
+		BaseRequest:  NewBaseRequest(),
+		MinDelayDays: 1,
+		Page:         1,
+		PageSize:     20,
+	}
+}
@@ -0,0 +1,62 @@
+package requests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchFarmsRequest_Validate(t *testing.T) {
+	lat, lon := 22.715, 75.855
+	float := func(v float64) *float64 { return &v }
+	count := func(v int) *int { return &v }
+	square := &GeometryData{WKT: "POLYGON((75.8 22.7,75.9 22.7,75.9 22.8,75.8 22.8,75.8 22.7))"}
+
+	tests := []struct {
+		name    string
+		req     SearchFarmsRequest
+		wantErr string
+	}{
+		{"radius", SearchFarmsRequest{Lat: &lat, Lon: &lon, RadiusM: float(2000)}, ""},
+		{"within", SearchFarmsRequest{Within: square, Intersects: true}, ""},
+		{"nearest", SearchFarmsRequest{Lat: &lat, Lon: &lon, Nearest: count(10)}, ""},
+		{"bbox", SearchFarmsRequest{BBox: &BoundingBox{MinLat: 22.71, MaxLat: 22.73, MinLon: 75.85, MaxLon: 75.87}}, ""},
+
+		{"no criterion", SearchFarmsRequest{Lat: &lat, Lon: &lon}, "exactly one of"},
+		{"two criteria", SearchFarmsRequest{Lat: &lat, Lon: &lon, RadiusM: float(2000), Nearest: count(10)}, "exactly one of"},
+
+		{"radius without lat", SearchFarmsRequest{Lon: &lon, RadiusM: float(2000)}, "lat and lon are required"},
+		{"nearest without lon", SearchFarmsRequest{Lat: &lat, Nearest: count(10)}, "lat and lon are required"},
+		{"lat out of range", SearchFarmsRequest{Lat: float(90.5), Lon: &lon, RadiusM: float(2000)}, "lat must be between"},
+		{"lon out of range", SearchFarmsRequest{Lat: &lat, Lon: float(-181), Nearest: count(10)}, "lat must be between"},
+
+		{"zero radius", SearchFarmsRequest{Lat: &lat, Lon: &lon, RadiusM: float(0)}, "radius_m must be"},
+		{"largest radius", SearchFarmsRequest{Lat: &lat, Lon: &lon, RadiusM: float(MaxFarmSearchRadiusM)}, ""},
+		{"radius too large", SearchFarmsRequest{Lat: &lat, Lon: &lon, RadiusM: float(MaxFarmSearchRadiusM + 1)}, "radius_m must be"},
+		{"no nearest farms", SearchFarmsRequest{Lat: &lat, Lon: &lon, Nearest: count(0)}, "nearest must be"},
+		{"most nearest farms", SearchFarmsRequest{Lat: &lat, Lon: &lon, Nearest: count(MaxNearestFarms)}, ""},
+		{"too many nearest farms", SearchFarmsRequest{Lat: &lat, Lon: &lon, Nearest: count(MaxNearestFarms + 1)}, "nearest must be"},
+
+		{"bbox latitudes reversed", SearchFarmsRequest{BBox: &BoundingBox{MinLat: 22.73, MaxLat: 22.71, MinLon: 75.85, MaxLon: 75.87}}, "bbox minimums"},
+		{"bbox longitudes reversed", SearchFarmsRequest{BBox: &BoundingBox{MinLat: 22.71, MaxLat: 22.73, MinLon: 75.87, MaxLon: 75.85}}, "bbox minimums"},
+		{"empty bbox", SearchFarmsRequest{BBox: &BoundingBox{MinLat: 22.71, MaxLat: 22.71, MinLon: 75.85, MaxLon: 75.87}}, "bbox minimums"},
+
+		{"invalid within boundary", SearchFarmsRequest{Within: &GeometryData{WKT: "POLYGON((75.8 22.7"}}, "invalid within boundary"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSearchFarmsRequest_ValidateResolvesGeoJSON(t *testing.T) {
+	req := SearchFarmsRequest{Within: &GeometryData{GeoJSON: []byte(`{"type":"Polygon","coordinates":[[[75.8,22.7],[75.9,22.7],[75.9,22.8],[75.8,22.8],[75.8,22.7]]]}`)}}
+	assert.NoError(t, req.Validate())
+	assert.Contains(t, req.Within.WKT, "POLYGON")
+}
@@ -50,6 +50,9 @@ type ListFarmersRequest struct {
 	PhoneNumber      string `json:"phone_number,omitempty" example:"9876543210"`
 	Page             int    `json:"page,omitempty" example:"1"`
 	PageSize         int    `json:"page_size,omitempty" example:"20"`
+
+	// Farmers with a farm in the area
+	AdminAreaFilter
 }
 
 // FarmerProfileData represents the profile data for a farmer
@@ -0,0 +1,123 @@
+package requests
+
+// InputProductRequest holds the fields of an input master product
+type InputProductRequest struct {
+	Name         string  `json:"name" validate:"required" example:"Urea 46% N"`
+	Category     string  `json:"category" validate:"required,oneof=SEED FERTILIZER PESTICIDE LABOUR MACHINERY IRRIGATION OTHER" example:"FERTILIZER"`
+	Unit         string  `json:"unit" validate:"required" example:"kg"`
+	Manufacturer *string `json:"manufacturer,omitempty" example:"IFFCO"`
+	Description  *string `json:"description,omitempty" example:"Nitrogenous fertiliser, 45 kg bag"`
+	IsActive     *bool   `json:"is_active,omitempty" example:"true"` // Kept when omitted on update
+}
+
+// CreateInputProductRequest represents a request to add a product to the input master
+type CreateInputProductRequest struct {
+	BaseRequest
+	InputProductRequest
+}
+
+// UpdateInputProductRequest represents a request to update an input master product
+type UpdateInputProductRequest struct {
+	BaseRequest
+	ID string `json:"id" validate:"required" example:"INPD00000001"`
+	InputProductRequest
+}
+
+// GetInputProductRequest represents a request to get an input master product
+type GetInputProductRequest struct {
+	BaseRequest
+	ID string `json:"id" validate:"required" example:"INPD00000001"`
+}
+
+// DeleteInputProductRequest represents a request to remove a product from the input master
+type DeleteInputProductRequest struct {
+	BaseRequest
+	ID string `json:"id" validate:"required" example:"INPD00000001"`
+}
+
+// ListInputProductsRequest represents a request to list the input master
+type ListInputProductsRequest struct {
+	BaseRequest
+	Category   string `json:"category,omitempty" example:"FERTILIZER"`
+	Search     string `json:"search,omitempty" example:"urea"`
+	ActiveOnly bool   `json:"active_only" example:"true"`
+	Page       int    `json:"page" validate:"min=1" example:"1"`
+	PageSize   int    `json:"page_size" validate:"min=1,max=100" example:"20"`
+}
+
+// ActivityInputItem represents an input line item of a farm activity. The cost is the
+// total cost when given, otherwise the quantity times the unit cost. Costs are in rupees.
+type ActivityInputItem struct {
+	InputProductID string   `json:"input_product_id" validate:"required" example:"INPD00000001"`
+	Quantity       float64  `json:"quantity" validate:"required,gt=0" example:"50"`
+	Unit           string   `json:"unit,omitempty" example:"kg"` // Defaults to the product's unit; other units of its kind are converted to it
+	UnitCost       *float64 `json:"unit_cost,omitempty" validate:"omitempty,gte=0" example:"5.9"`
+	TotalCost      *float64 `json:"total_cost,omitempty" validate:"omitempty,gte=0" example:"295"`
+	Supplier       *string  `json:"supplier,omitempty" example:"Shree Krishi Kendra, Dewas"`
+	Notes          *string  `json:"notes,omitempty" example:"Top dressing after first weeding"`
+}
+
+// SetActivityInputsRequest represents a request to replace the input line items of a farm
+// activity. An empty list clears them.
+type SetActivityInputsRequest struct {
+	BaseRequest
+	ActivityID string              `json:"-"`
+	Inputs     []ActivityInputItem `json:"inputs"`
+}
+
+// ListActivityInputsRequest represents a request for the input line items of a farm activity
+type ListActivityInputsRequest struct {
+	BaseRequest
+	ActivityID string `json:"activity_id" validate:"required" example:"FACT000000001"`
+}
+
+// CycleCostsRequest represents a request for the cost of cultivation of a crop cycle
+type CycleCostsRequest struct {
+	BaseRequest
+	CropCycleID string `json:"crop_cycle_id" validate:"required" example:"CRCY000000001"`
+}
+
+// FarmCostsRequest represents a request for the cost of cultivation of a farm's crop cycles
+type FarmCostsRequest struct {
+	BaseRequest
+	FarmID string `json:"farm_id" validate:"required" example:"FARM00000001"`
+	Season string `json:"season,omitempty" validate:"omitempty,oneof=RABI KHARIF ZAID PERENNIAL OTHER" example:"KHARIF"`
+	Year   int    `json:"year,omitempty" example:"2024"` // Year the cycles started in
+}
+
+// NewCreateInputProductRequest creates a new create input product request
+func NewCreateInputProductRequest() CreateInputProductRequest {
+	return CreateInputProductRequest{
+		BaseRequest: NewBaseRequest(),
+	}
+}
+
+// NewUpdateInputProductRequest creates a new update input product request
+func NewUpdateInputProductRequest() UpdateInputProductRequest {
+	return UpdateInputProductRequest{
+		BaseRequest: NewBaseRequest(),
+	}
+}
+
+// NewListInputProductsRequest creates a new list input products request with default values
+func NewListInputProductsRequest() ListInputProductsRequest {
+	return ListInputProductsRequest{
+		BaseRequest: NewBaseRequest(),
+		Page:        1,
+		PageSize:    20,
+	}
+}
+
+// NewSetActivityInputsRequest creates a new set activity inputs request
+func NewSetActivityInputsRequest() SetActivityInputsRequest {
+	return SetActivityInputsRequest{
+		BaseRequest: NewBaseRequest(),
+	}
+}
+
+// NewFarmCostsRequest creates a new farm costs request
+func NewFarmCostsRequest() FarmCostsRequest {
+	return FarmCostsRequest{
+		BaseRequest: NewBaseRequest(),
+	}
+}
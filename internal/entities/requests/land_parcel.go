@@ -0,0 +1,76 @@
+package requests
+
+import "time"
+
+// LandParcelRequest holds the land record details of a parcel a farm is made up of
+type LandParcelRequest struct {
+	StateCode         string     `json:"state_code" validate:"required" example:"27"`
+	DistrictCode      string     `json:"district_code" validate:"required" example:"523"`
+	TehsilCode        string     `json:"tehsil_code" validate:"required" example:"4187"`
+	VillageCode       string     `json:"village_code" validate:"required" example:"563214"`
+	SurveyNumber      string     `json:"survey_number" validate:"required" example:"123/2A"`
+	KhataNumber       *string    `json:"khata_number,omitempty" example:"456"`
+	AreaHa            *float64   `json:"area_ha,omitempty" validate:"omitempty,gt=0" example:"1.2"`
+	SharePct          *float64   `json:"share_pct,omitempty" validate:"omitempty,gt=0,max=100" example:"50"` // Defaults to 100
+	Tenure            string     `json:"tenure,omitempty" validate:"omitempty,oneof=OWNED LEASED" example:"OWNED"`
+	LeaseStartDate    *time.Time `json:"lease_start_date,omitempty" example:"2024-06-01T00:00:00Z"`
+	LeaseEndDate      *time.Time `json:"lease_end_date,omitempty" example:"2027-05-31T00:00:00Z"`
+	LessorName        *string    `json:"lessor_name,omitempty" example:"Ramesh Patil"`
+	DocumentType      *string    `json:"document_type,omitempty" example:"7/12 extract"`
+	DocumentReference *string    `json:"document_reference,omitempty" example:"https://records.example.org/doc/123"`
+}
+
+// CreateLandParcelRequest represents a request to link a land parcel to a farm
+type CreateLandParcelRequest struct {
+	BaseRequest
+	FarmID string `json:"farm_id" validate:"required" example:"FARM00000001"`
+	LandParcelRequest
+}
+
+// UpdateLandParcelRequest represents a request to replace the land record details of a parcel
+type UpdateLandParcelRequest struct {
+	BaseRequest
+	ID     string `json:"id" validate:"required" example:"LPCL0000000001"`
+	FarmID string `json:"farm_id" validate:"required" example:"FARM00000001"`
+	LandParcelRequest
+}
+
+// DeleteLandParcelRequest represents a request to unlink a land parcel from a farm
+type DeleteLandParcelRequest struct {
+	BaseRequest
+	ID     string `json:"id" validate:"required" example:"LPCL0000000001"`
+	FarmID string `json:"farm_id" validate:"required" example:"FARM00000001"`
+}
+
+// SearchLandParcelsRequest represents a search for parcels by their land record identifiers
+// within an organization
+type SearchLandParcelsRequest struct {
+	PaginationRequest
+	AAAOrgID     string `json:"aaa_org_id,omitempty" example:"org_123e4567-e89b-12d3-a456-426614174000"`
+	StateCode    string `json:"state_code,omitempty" example:"27"`
+	DistrictCode string `json:"district_code,omitempty" example:"523"`
+	TehsilCode   string `json:"tehsil_code,omitempty" example:"4187"`
+	VillageCode  string `json:"village_code,omitempty" example:"563214"`
+	SurveyNumber string `json:"survey_number,omitempty" example:"123/2A"`
+}
+
+// NewCreateLandParcelRequest creates a new create land parcel request
+func NewCreateLandParcelRequest() CreateLandParcelRequest {
+	return CreateLandParcelRequest{
+		BaseRequest: NewBaseRequest(),
+	}
+}
+
+// NewUpdateLandParcelRequest creates a new update land parcel request
+func NewUpdateLandParcelRequest() UpdateLandParcelRequest {
+	return UpdateLandParcelRequest{
+		BaseRequest: NewBaseRequest(),
+	}
+}
+
+// NewSearchLandParcelsRequest creates a new search land parcels request
+func NewSearchLandParcelsRequest() SearchLandParcelsRequest {
+	return SearchLandParcelsRequest{
+		PaginationRequest: NewPaginationRequest(1, 20),
+	}
+}
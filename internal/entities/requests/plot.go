@@ -0,0 +1,60 @@
+package requests
+
+// PlotRequest holds the name and boundary of a plot of a farm. The boundary must lie within
+// the farm's boundary.
+type PlotRequest struct {
+	Name     string        `json:"name" validate:"required" example:"North field"`
+	Geometry *GeometryData `json:"geometry,omitempty"` // Required when creating a plot; kept when omitted on update
+}
+
+// CreatePlotRequest represents a request to add a plot to a farm
+type CreatePlotRequest struct {
+	BaseRequest
+	FarmID string `json:"farm_id" validate:"required" example:"FARM00000001"`
+	PlotRequest
+}
+
+// UpdatePlotRequest represents a request to rename a plot or redraw its boundary
+type UpdatePlotRequest struct {
+	BaseRequest
+	ID     string `json:"id" validate:"required" example:"PLOT00000001"`
+	FarmID string `json:"farm_id" validate:"required" example:"FARM00000001"`
+	PlotRequest
+}
+
+// DeletePlotRequest represents a request to remove a plot from a farm
+type DeletePlotRequest struct {
+	BaseRequest
+	ID     string `json:"id" validate:"required" example:"PLOT00000001"`
+	FarmID string `json:"farm_id" validate:"required" example:"FARM00000001"`
+}
+
+// PlotMapRequest represents a request for the map of a farm's plots and the crop cycles
+// grown on them in a season
+type PlotMapRequest struct {
+	BaseRequest
+	FarmID string `json:"farm_id" validate:"required" example:"FARM00000001"`
+	Season string `json:"season,omitempty" validate:"omitempty,oneof=RABI KHARIF ZAID PERENNIAL OTHER" example:"KHARIF"` // Planned and active cycles when omitted
+	Year   int    `json:"year,omitempty" example:"2024"`                                                                 // Year the cycles started in
+}
+
+// NewCreatePlotRequest creates a new create plot request
+func NewCreatePlotRequest() CreatePlotRequest {
+	return CreatePlotRequest{
+		BaseRequest: NewBaseRequest(),
+	}
+}
+
+// NewUpdatePlotRequest creates a new update plot request
+func NewUpdatePlotRequest() UpdatePlotRequest {
+	return UpdatePlotRequest{
+		BaseRequest: NewBaseRequest(),
+	}
+}
+
+// NewPlotMapRequest creates a new plot map request
+func NewPlotMapRequest() PlotMapRequest {
+	return PlotMapRequest{
+		BaseRequest: NewBaseRequest(),
+	}
+}
@@ -20,4 +20,7 @@ type OrgDashboardCountersRequest struct {
 	Season    string     `json:"season,omitempty" validate:"omitempty,oneof=RABI KHARIF ZAID PERENNIAL OTHER" example:"RABI"`
 	StartDate *time.Time `json:"start_date,omitempty" example:"2024-01-01T00:00:00Z"`
 	EndDate   *time.Time `json:"end_date,omitempty" example:"2024-12-31T23:59:59Z"`
+
+	// Count only the farms in the area, and their farmers
+	AdminAreaFilter
 }
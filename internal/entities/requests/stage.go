@@ -87,3 +87,48 @@ type ReorderCropStagesRequest struct {
 	CropID      string         `json:"-"`
 	StageOrders map[string]int `json:"stage_orders" binding:"required"` // map[stage_id]order
 }
+
+// ActivityTemplateRequest represents the fields of a crop stage's activity template
+type ActivityTemplateRequest struct {
+	Name         string         `json:"name" binding:"required,min=1,max=255" example:"First irrigation"`
+	ActivityType string         `json:"activity_type" binding:"required" example:"IRRIGATION"`
+	OffsetDays   int            `json:"offset_days" binding:"min=0" example:"21"`
+	OffsetAnchor string         `json:"offset_anchor,omitempty" binding:"omitempty,oneof=SOWING STAGE_START" example:"SOWING"` // Defaults to SOWING
+	Description  *string        `json:"description,omitempty" example:"Light irrigation at crown root initiation"`
+	Metadata     entities.JSONB `json:"metadata,omitempty" swaggertype:"object"`
+	IsActive     *bool          `json:"is_active,omitempty" example:"true"`
+}
+
+// CreateActivityTemplateRequest represents the request to add an activity template to a crop stage
+type CreateActivityTemplateRequest struct {
+	BaseRequest
+	CropID  string `json:"-"`
+	StageID string `json:"-"`
+
+	ActivityTemplateRequest `json:"-"` // Bound from the request body on its own
+}
+
+// UpdateActivityTemplateRequest represents the request to update an activity template
+type UpdateActivityTemplateRequest struct {
+	BaseRequest
+	ID      string `json:"-"`
+	CropID  string `json:"-"`
+	StageID string `json:"-"`
+
+	ActivityTemplateRequest `json:"-"` // Bound from the request body on its own
+}
+
+// DeleteActivityTemplateRequest represents the request to delete an activity template
+type DeleteActivityTemplateRequest struct {
+	BaseRequest
+	ID      string `json:"-"`
+	CropID  string `json:"-"`
+	StageID string `json:"-"`
+}
+
+// ListActivityTemplatesRequest represents the request to list the activity templates of a crop stage
+type ListActivityTemplatesRequest struct {
+	BaseRequest
+	CropID  string `json:"-"`
+	StageID string `json:"-"`
+}
@@ -0,0 +1,32 @@
+package requests
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// SyncChangesRequest represents a request for the records changed since a sync cursor
+type SyncChangesRequest struct {
+	BaseRequest
+	Cursor string `json:"cursor,omitempty" example:"eyJ0IjoiMjAyNC0xMS0wMVQxMDozMDowMFoiLCJlIjoiZmFybSIsImkiOiJGQVJNMDAwMDAwMDEifQ"`
+	Limit  int    `json:"limit,omitempty" validate:"omitempty,min=1,max=1000" example:"200"`
+}
+
+// SyncMutation is a change made offline to a farmer, farm, crop cycle or farm activity.
+// Data holds the body of the matching create, update, end or complete API request. Records
+// created offline carry a client-chosen ID, which later mutations of the same push may use
+// as id, farmer_id, farm_id or crop_cycle_id until the server ID is known.
+type SyncMutation struct {
+	MutationID    string          `json:"mutation_id" validate:"required" example:"mut_0001"`
+	Entity        string          `json:"entity" validate:"required,oneof=farmer farm crop_cycle farm_activity" example:"farm"`
+	Operation     string          `json:"operation" validate:"required,oneof=create update delete end complete" example:"update"`
+	ID            string          `json:"id,omitempty" example:"FARM00000001"`
+	BaseUpdatedAt *time.Time      `json:"base_updated_at,omitempty" example:"2024-11-01T10:30:00Z"` // updated_at of the client's copy; required unless creating
+	Data          json.RawMessage `json:"data,omitempty" swaggertype:"object"`
+}
+
+// SyncPushRequest represents a batch of offline mutations, applied in order
+type SyncPushRequest struct {
+	BaseRequest
+	Mutations []SyncMutation `json:"mutations" validate:"required,min=1,max=500,dive"`
+}
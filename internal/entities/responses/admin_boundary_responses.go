@@ -0,0 +1,91 @@
+package responses
+
+import (
+	"github.com/Kisanlink/kisanlink-db/pkg/base"
+)
+
+// AdminBoundaryImportResponse represents the result of an administrative boundary import
+type AdminBoundaryImportResponse struct {
+	*base.BaseResponse `json:",inline"`
+	Data               *AdminBoundaryImportData `json:"data"`
+}
+
+// AdminBoundaryImportData summarizes an administrative boundary import
+type AdminBoundaryImportData struct {
+	Level       string                        `json:"level" example:"VILLAGE"`
+	Imported    int                           `json:"imported" example:"1432"`
+	Skipped     []AdminBoundaryImportSkipData `json:"skipped"`
+	FarmsTagged int64                         `json:"farms_tagged" example:"5210"` // Farms tagged again with the imported boundaries
+}
+
+// AdminBoundaryImportSkipData describes a feature that was not imported
+type AdminBoundaryImportSkipData struct {
+	Index int    `json:"index" example:"12"`
+	Code  string `json:"code,omitempty" example:"563214"`
+	Error string `json:"error" example:"feature has no name property village_name"`
+}
+
+// FarmAdminCodesBackfillResponse represents the result of tagging farms with admin codes
+type FarmAdminCodesBackfillResponse struct {
+	*base.BaseResponse `json:",inline"`
+	FarmsTagged        int64 `json:"farms_tagged" example:"5210"`
+}
+
+// AdminBoundaryListResponse represents the administrative areas of a level
+type AdminBoundaryListResponse struct {
+	*base.BaseResponse `json:",inline"`
+	Data               []*AdminBoundaryData `json:"data"`
+}
+
+// AdminBoundaryData represents an administrative area without its boundary
+type AdminBoundaryData struct {
+	Level      string  `json:"level" example:"BLOCK"`
+	Code       string  `json:"code" example:"4187"`
+	Name       string  `json:"name" example:"Haveli"`
+	ParentCode *string `json:"parent_code,omitempty" example:"523"`
+}
+
+// NewAdminBoundaryImportResponse creates a new admin boundary import response
+func NewAdminBoundaryImportResponse(data *AdminBoundaryImportData, message string) AdminBoundaryImportResponse {
+	if data.Skipped == nil {
+		data.Skipped = []AdminBoundaryImportSkipData{}
+	}
+	return AdminBoundaryImportResponse{
+		BaseResponse: base.NewSuccessResponse(message, data),
+		Data:         data,
+	}
+}
+
+// NewFarmAdminCodesBackfillResponse creates a new farm admin codes backfill response
+func NewFarmAdminCodesBackfillResponse(farmsTagged int64, message string) FarmAdminCodesBackfillResponse {
+	return FarmAdminCodesBackfillResponse{
+		BaseResponse: base.NewSuccessResponse(message, map[string]int64{"farms_tagged": farmsTagged}),
+		FarmsTagged:  farmsTagged,
+	}
+}
+
+// NewAdminBoundaryListResponse creates a new admin boundary list response
+func NewAdminBoundaryListResponse(boundaries []*AdminBoundaryData, message string) AdminBoundaryListResponse {
+	if boundaries == nil {
+		boundaries = []*AdminBoundaryData{}
+	}
+	return AdminBoundaryListResponse{
+		BaseResponse: base.NewSuccessResponse(message, boundaries),
+		Data:         boundaries,
+	}
+}
+
+// SetRequestID sets the request ID for tracking
+func (r *AdminBoundaryImportResponse) SetRequestID(requestID string) {
+	r.BaseResponse.RequestID = requestID
+}
+
+// SetRequestID sets the request ID for tracking
+func (r *FarmAdminCodesBackfillResponse) SetRequestID(requestID string) {
+	r.BaseResponse.RequestID = requestID
+}
+
+// SetRequestID sets the request ID for tracking
+func (r *AdminBoundaryListResponse) SetRequestID(requestID string) {
+	r.BaseResponse.RequestID = requestID
+}
@@ -21,6 +21,20 @@ type AreaAllocationSummaryData struct {
 	ActiveCyclesCount  int64              `json:"active_cycles_count"`
 	PlannedCyclesCount int64              `json:"planned_cycles_count"`
 	Allocations        []*CycleAllocation `json:"allocations,omitempty"`
+
+	// Plots: the summed areas may fit the farm while two cycles share the same ground
+	PlottedAreaHa float64         `json:"plotted_area_ha"`
+	PlotConflicts []*PlotConflict `json:"plot_conflicts,omitempty"`
+	PlotsDisjoint bool            `json:"plots_disjoint"` // No two planned or active cycles are grown on overlapping plots
+}
+
+// PlotConflict represents two planned or active crop cycles grown on overlapping plots
+type PlotConflict struct {
+	CropCycleID      string  `json:"crop_cycle_id" example:"CRCY000000001"`
+	PlotID           string  `json:"plot_id" example:"PLOT00000001"`
+	OtherCropCycleID string  `json:"other_crop_cycle_id" example:"CRCY000000002"`
+	OtherPlotID      string  `json:"other_plot_id" example:"PLOT00000002"`
+	OverlapAreaHa    float64 `json:"overlap_area_ha" example:"0.15"`
 }
 
 // CycleAllocation represents a single crop cycle allocation
@@ -2,6 +2,8 @@ package responses
 
 import (
 	"time"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/bulk"
 )
 
 // BulkOperationResponse represents the response for a bulk operation
@@ -35,6 +37,7 @@ type BulkOperationStatusResponse struct {
 // BulkOperationStatusData contains detailed status information
 type BulkOperationStatusData struct {
 	OperationID         string                 `json:"operation_id"`
+	OperationType       string                 `json:"operation_type,omitempty"`
 	FPOOrgID            string                 `json:"fpo_org_id"`
 	Status              string                 `json:"status"`
 	Progress            ProgressInfo           `json:"progress"`
@@ -71,12 +74,36 @@ type BulkValidationResponse struct {
 
 // BulkValidationData contains validation results
 type BulkValidationData struct {
-	IsValid      bool                   `json:"is_valid"`
-	TotalRecords int                    `json:"total_records"`
-	ValidRecords int                    `json:"valid_records"`
-	Errors       []ValidationError      `json:"errors,omitempty"`
-	Warnings     []ValidationWarning    `json:"warnings,omitempty"`
-	Summary      map[string]interface{} `json:"summary,omitempty"`
+	IsValid          bool                     `json:"is_valid"`
+	TotalRecords     int                      `json:"total_records"`
+	ValidRecords     int                      `json:"valid_records"`
+	InvalidRecords   int                      `json:"invalid_records"`
+	DuplicateRecords int                      `json:"duplicate_records"`
+	Errors           []ValidationError        `json:"errors,omitempty"`
+	Warnings         []ValidationWarning      `json:"warnings,omitempty"`
+	Records          []RecordValidationResult `json:"records,omitempty"`
+	Summary          map[string]interface{}   `json:"summary,omitempty"`
+}
+
+// RecordValidationResult contains the dry-run outcome of a single input record
+type RecordValidationResult struct {
+	RecordIndex int                 `json:"record_index" example:"0"`
+	ExternalID  string              `json:"external_id,omitempty" example:"FPO-F-001"`
+	PhoneNumber string              `json:"phone_number,omitempty" example:"9876543210"`
+	Status      string              `json:"status" example:"VALID"` // VALID, INVALID, DUPLICATE
+	Errors      []ValidationError   `json:"errors,omitempty"`
+	Warnings    []ValidationWarning `json:"warnings,omitempty"`
+	Duplicates  []DuplicateInfo     `json:"duplicates,omitempty"`
+}
+
+// DuplicateInfo describes a duplicate detected during validation
+type DuplicateInfo struct {
+	Source            string `json:"source" example:"file"` // file, fpo
+	Field             string `json:"field" example:"phone_number"`
+	Value             string `json:"value" example:"9876543210"`
+	DuplicateOfIndex  *int   `json:"duplicate_of_index,omitempty" example:"3"`
+	ExistingFarmerID  string `json:"existing_farmer_id,omitempty" example:"FMRR0000000001"`
+	ExistingAAAUserID string `json:"existing_aaa_user_id,omitempty" example:"USER00000001"`
 }
 
 // ValidationError represents a validation error
@@ -165,6 +192,64 @@ type FieldInfo struct {
 	Description string `json:"description,omitempty"`
 }
 
+// ColumnMappingProfileResponse represents the response for a column mapping profile
+type ColumnMappingProfileResponse struct {
+	Success   bool                      `json:"success"`
+	Message   string                    `json:"message"`
+	RequestID string                    `json:"request_id,omitempty"`
+	Timestamp time.Time                 `json:"timestamp"`
+	Data      *ColumnMappingProfileData `json:"data,omitempty"`
+}
+
+// ColumnMappingProfileListResponse represents the response for the mapping profiles of an FPO
+type ColumnMappingProfileListResponse struct {
+	Success   bool                        `json:"success"`
+	Message   string                      `json:"message"`
+	RequestID string                      `json:"request_id,omitempty"`
+	Timestamp time.Time                   `json:"timestamp"`
+	Data      []*ColumnMappingProfileData `json:"data"`
+}
+
+// ColumnMappingProfileData contains a saved column mapping profile
+type ColumnMappingProfileData struct {
+	ID           string               `json:"id" example:"BLKM00000001"`
+	FPOOrgID     string               `json:"fpo_org_id" example:"org_123e4567-e89b-12d3-a456-426614174000"`
+	Name         string               `json:"name" example:"Khandwa FPO register"`
+	Description  string               `json:"description,omitempty"`
+	Mappings     []bulk.ColumnMapping `json:"mappings"`
+	KeepUnmapped bool                 `json:"keep_unmapped"`
+	CreatedAt    time.Time            `json:"created_at"`
+	UpdatedAt    time.Time            `json:"updated_at"`
+}
+
+// ColumnMappingPreviewResponse represents the response for a column mapping preview
+type ColumnMappingPreviewResponse struct {
+	Success   bool                      `json:"success"`
+	Message   string                    `json:"message"`
+	RequestID string                    `json:"request_id,omitempty"`
+	Timestamp time.Time                 `json:"timestamp"`
+	Data      *ColumnMappingPreviewData `json:"data,omitempty"`
+}
+
+// ColumnMappingPreviewData shows how an uploaded file maps to farmer records
+type ColumnMappingPreviewData struct {
+	SourceHeaders   []string              `json:"source_headers"`
+	UnmappedHeaders []string              `json:"unmapped_headers,omitempty"`
+	MissingSources  []string              `json:"missing_sources,omitempty"`  // source columns used by the profile but absent from the file
+	MissingRequired []string              `json:"missing_required,omitempty"` // required farmer fields no mapping produces
+	TotalRecords    int                   `json:"total_records"`
+	ValidRecords    int                   `json:"valid_records"` // among the previewed records
+	Records         []MappedRecordPreview `json:"records"`
+}
+
+// MappedRecordPreview contains a source row and the farmer record mapped from it
+type MappedRecordPreview struct {
+	RowNumber int                    `json:"row_number" example:"2"`
+	Source    map[string]string      `json:"source"`
+	Mapped    map[string]interface{} `json:"mapped"`
+	Error     string                 `json:"error,omitempty"`
+}
+
 // NewBulkOperationResponse creates a new bulk operation response
 func NewBulkOperationResponse(data *BulkOperationData, message string) *BulkOperationResponse {
 	return &BulkOperationResponse{
@@ -208,3 +293,33 @@ func NewBulkTemplateResponse(data *BulkTemplateData) *BulkTemplateResponse {
 		Data:      data,
 	}
 }
+
+// NewColumnMappingProfileResponse creates a new column mapping profile response
+func NewColumnMappingProfileResponse(data *ColumnMappingProfileData, message string) *ColumnMappingProfileResponse {
+	return &ColumnMappingProfileResponse{
+		Success:   true,
+		Message:   message,
+		Timestamp: time.Now(),
+		Data:      data,
+	}
+}
+
+// NewColumnMappingProfileListResponse creates a new column mapping profile list response
+func NewColumnMappingProfileListResponse(data []*ColumnMappingProfileData) *ColumnMappingProfileListResponse {
+	return &ColumnMappingProfileListResponse{
+		Success:   true,
+		Message:   "Mapping profiles retrieved successfully",
+		Timestamp: time.Now(),
+		Data:      data,
+	}
+}
+
+// NewColumnMappingPreviewResponse creates a new column mapping preview response
+func NewColumnMappingPreviewResponse(data *ColumnMappingPreviewData) *ColumnMappingPreviewResponse {
+	return &ColumnMappingPreviewResponse{
+		Success:   true,
+		Message:   "Mapping preview generated successfully",
+		Timestamp: time.Now(),
+		Data:      data,
+	}
+}
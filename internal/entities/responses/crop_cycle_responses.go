@@ -9,7 +9,8 @@ import (
 // CropCycleResponse represents a single crop cycle response
 type CropCycleResponse struct {
 	*base.BaseResponse `json:",inline"`
-	Data               *CropCycleData `json:"data"`
+	Data               *CropCycleData    `json:"data"`
+	Calendar           *CropCalendarData `json:"calendar,omitempty"` // Set when the cycle was started with generate_activities
 }
 
 // CropCycleListResponse represents a list of crop cycles response
@@ -20,21 +21,54 @@ type CropCycleListResponse struct {
 
 // CropCycleData represents crop cycle data in responses
 type CropCycleData struct {
-	ID          string                 `json:"id"`
-	FarmID      string                 `json:"farm_id"`
-	FarmerID    string                 `json:"farmer_id"`
-	AreaHa      *float64               `json:"area_ha,omitempty"`
-	Season      string                 `json:"season"`
-	Status      string                 `json:"status"`
-	StartDate   *time.Time             `json:"start_date"`
-	EndDate     *time.Time             `json:"end_date"`
-	CropID      string                 `json:"crop_id"`
-	VarietyID   *string                `json:"variety_id,omitempty"`
-	CropName    string                 `json:"crop_name,omitempty"`
-	VarietyName *string                `json:"variety_name,omitempty"`
-	Outcome     map[string]interface{} `json:"outcome"`
-	CreatedAt   time.Time              `json:"created_at"`
-	UpdatedAt   time.Time              `json:"updated_at"`
+	ID           string                 `json:"id"`
+	FarmID       string                 `json:"farm_id"`
+	FarmerID     string                 `json:"farmer_id"`
+	PlotID       *string                `json:"plot_id,omitempty"`
+	AreaHa       *float64               `json:"area_ha,omitempty"`
+	Season       string                 `json:"season"`
+	Status       string                 `json:"status"`
+	StartDate    *time.Time             `json:"start_date"`
+	EndDate      *time.Time             `json:"end_date"`
+	CropID       string                 `json:"crop_id"`
+	VarietyID    *string                `json:"variety_id,omitempty"`
+	CropName     string                 `json:"crop_name,omitempty"`
+	VarietyName  *string                `json:"variety_name,omitempty"`
+	TreeCount    *int                   `json:"tree_count,omitempty"`
+	PlantingYear *int                   `json:"planting_year,omitempty"`
+	Outcome      map[string]interface{} `json:"outcome"`
+	CreatedAt    time.Time              `json:"created_at"`
+	UpdatedAt    time.Time              `json:"updated_at"`
+}
+
+// CropCalendarData represents the activities planned for a crop cycle from the activity
+// templates of its crop's stages
+type CropCalendarData struct {
+	PlannedActivities  []*FarmActivityData      `json:"planned_activities"`
+	UnplannedTemplates []*UnplannedTemplateData `json:"unplanned_templates,omitempty"`
+}
+
+// UnplannedTemplateData represents an activity template no activity could be planned from
+type UnplannedTemplateData struct {
+	TemplateID  string `json:"template_id" example:"CSAT00000001"`
+	CropStageID string `json:"crop_stage_id" example:"CSTG00000003"`
+	Name        string `json:"name" example:"Top dressing"`
+	Reason      string `json:"reason" example:"an earlier stage of the crop has no duration, so the stage's start date is unknown"`
+}
+
+// ReplanCycleResponse represents the result of moving a crop cycle's sowing date
+type ReplanCycleResponse struct {
+	*base.BaseResponse `json:",inline"`
+	Data               *ReplanCycleData `json:"data"`
+}
+
+// ReplanCycleData represents how a crop cycle and its planned activities were moved
+type ReplanCycleData struct {
+	CropCycleID       string     `json:"crop_cycle_id" example:"CRCY000000001"`
+	PreviousStartDate *time.Time `json:"previous_start_date" example:"2024-11-01T00:00:00Z"`
+	StartDate         time.Time  `json:"start_date" example:"2024-11-05T00:00:00Z"`
+	ShiftDays         int        `json:"shift_days" example:"4"`
+	ShiftedActivities int64      `json:"shifted_activities" example:"6"`
 }
 
 // NewCropCycleResponse creates a new crop cycle response
@@ -60,6 +94,19 @@ func NewCropCycleListResponse(cycles []*CropCycleData, page, pageSize int, total
 	}
 }
 
+// NewReplanCycleResponse creates a new replan cycle response
+func NewReplanCycleResponse(data *ReplanCycleData, message string) ReplanCycleResponse {
+	return ReplanCycleResponse{
+		BaseResponse: base.NewSuccessResponse(message, data),
+		Data:         data,
+	}
+}
+
+// SetRequestID sets the request ID for tracking
+func (r *ReplanCycleResponse) SetRequestID(requestID string) {
+	r.BaseResponse.RequestID = requestID
+}
+
 // SetRequestID sets the request ID for tracking
 func (r *CropCycleResponse) SetRequestID(requestID string) {
 	r.BaseResponse.RequestID = requestID
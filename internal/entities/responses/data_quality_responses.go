@@ -1,14 +1,17 @@
 package responses
 
+import "time"
+
 // ValidateGeometryResponse represents the response from geometry validation
 type ValidateGeometryResponse struct {
 	BaseResponse
-	WKT      string   `json:"wkt"`
-	IsValid  bool     `json:"is_valid"`
-	Errors   []string `json:"errors,omitempty"`
-	Warnings []string `json:"warnings,omitempty"`
-	SRID     int      `json:"srid"`
-	AreaHa   *float64 `json:"area_ha,omitempty"`
+	WKT      string              `json:"wkt"`
+	IsValid  bool                `json:"is_valid"`
+	Errors   []string            `json:"errors,omitempty"`
+	Warnings []string            `json:"warnings,omitempty"`
+	SRID     int                 `json:"srid"`
+	AreaHa   *float64            `json:"area_ha,omitempty"`
+	Repair   *GeometryRepairData `json:"repair,omitempty"` // Set when a repair was requested
 }
 
 // ReconcileAAALinksResponse represents the response from AAA links reconciliation
@@ -40,6 +43,8 @@ type FarmOverlap struct {
 	Farm2AreaHa            float64 `json:"farm2_area_ha"`
 	OverlapPercentageFarm1 float64 `json:"overlap_percentage_farm1"`
 	OverlapPercentageFarm2 float64 `json:"overlap_percentage_farm2"`
+	ConflictID             string  `json:"conflict_id,omitempty"`     // The conflict recording this overlap
+	ConflictStatus         string  `json:"conflict_status,omitempty"` // FALSE_POSITIVE conflicts stay dismissed when detected again
 }
 
 // DetectFarmOverlapsResponse represents the response from farm overlap detection
@@ -47,4 +52,11 @@ type DetectFarmOverlapsResponse struct {
 	BaseResponse
 	Overlaps      []FarmOverlap `json:"overlaps"`
 	TotalOverlaps int           `json:"total_overlaps"`
+
+	// Conflict records kept up to date by the run
+	Incremental       bool       `json:"incremental"`
+	CheckedSince      *time.Time `json:"checked_since,omitempty"` // Farms changed after this time were re-checked
+	NewConflicts      int        `json:"new_conflicts"`
+	ReopenedConflicts int        `json:"reopened_conflicts"`
+	ResolvedConflicts int        `json:"resolved_conflicts"` // Conflicts whose overlap is gone
 }
@@ -20,19 +20,21 @@ type FarmActivityListResponse struct {
 
 // FarmActivityData represents farm activity data in responses
 type FarmActivityData struct {
-	ID           string                 `json:"id"`
-	CropCycleID  string                 `json:"crop_cycle_id"`
-	CropStageID  *string                `json:"crop_stage_id,omitempty"`
-	CropStage    *CropStageData         `json:"crop_stage,omitempty"`
-	ActivityType string                 `json:"activity_type"`
-	PlannedAt    *time.Time             `json:"planned_at"`
-	CompletedAt  *time.Time             `json:"completed_at"`
-	CreatedBy    string                 `json:"created_by"`
-	Status       string                 `json:"status"`
-	Output       map[string]interface{} `json:"output"`
-	Metadata     map[string]interface{} `json:"metadata"`
-	CreatedAt    time.Time              `json:"created_at"`
-	UpdatedAt    time.Time              `json:"updated_at"`
+	ID                 string                 `json:"id"`
+	CropCycleID        string                 `json:"crop_cycle_id"`
+	CropStageID        *string                `json:"crop_stage_id,omitempty"`
+	CropStage          *CropStageData         `json:"crop_stage,omitempty"`
+	ActivityTemplateID *string                `json:"activity_template_id,omitempty"`
+	ActivityType       string                 `json:"activity_type"`
+	PlannedAt          *time.Time             `json:"planned_at"`
+	StartedAt          *time.Time             `json:"started_at,omitempty"`
+	CompletedAt        *time.Time             `json:"completed_at"`
+	CreatedBy          string                 `json:"created_by"`
+	Status             string                 `json:"status"`
+	Output             map[string]interface{} `json:"output"`
+	Metadata           map[string]interface{} `json:"metadata"`
+	CreatedAt          time.Time              `json:"created_at"`
+	UpdatedAt          time.Time              `json:"updated_at"`
 }
 
 // NewFarmActivityResponse creates a new farm activity response
@@ -79,10 +81,13 @@ type StageProgressData struct {
 	CropCycleID    string                 `json:"crop_cycle_id"`
 	CropID         string                 `json:"crop_id"`
 	CropName       string                 `json:"crop_name"`
+	StartDate      *time.Time             `json:"start_date,omitempty"`
 	CurrentStage   *StageCompletionStat   `json:"current_stage,omitempty"`
 	Stages         []*StageCompletionStat `json:"stages"`
 	OverallPercent float64                `json:"overall_completion_percent"`
 	TotalStages    int                    `json:"total_stages"`
+	ScheduleStatus string                 `json:"schedule_status" example:"BEHIND"` // ON_TRACK, BEHIND or UNSCHEDULED
+	DelayDays      int                    `json:"delay_days" example:"6"`
 }
 
 // StageCompletionStat represents completion statistics for a single stage
@@ -97,6 +102,39 @@ type StageCompletionStat struct {
 	InProgressActivities int     `json:"in_progress_activities"`
 	PlannedActivities    int     `json:"planned_activities"`
 	CompletionPercent    float64 `json:"completion_percent"`
+
+	// Expected window from the cycle's start date and the stage durations, and the actual
+	// dates the cycle entered and left the stage
+	ExpectedStartDate *time.Time `json:"expected_start_date,omitempty"`
+	ExpectedEndDate   *time.Time `json:"expected_end_date,omitempty"`
+	ActualStartDate   *time.Time `json:"actual_start_date,omitempty"`
+	ActualEndDate     *time.Time `json:"actual_end_date,omitempty"`
+	StartDelayDays    *int       `json:"start_delay_days,omitempty"`
+}
+
+// DelayedCyclesResponse represents the crop cycles of an organisation running behind their
+// stage calendar
+type DelayedCyclesResponse struct {
+	*base.PaginatedResponse `json:",inline"`
+	Data                    []*DelayedCycleData `json:"data"`
+}
+
+// DelayedCycleData represents a crop cycle running behind its stage calendar
+type DelayedCycleData struct {
+	CropCycleID       string     `json:"crop_cycle_id" example:"CRCY000000001"`
+	FarmID            string     `json:"farm_id" example:"FARM00000001"`
+	FarmerID          string     `json:"farmer_id" example:"FMRR0000000001"`
+	CropID            string     `json:"crop_id" example:"CROP00000001"`
+	CropName          string     `json:"crop_name,omitempty" example:"Soybean"`
+	Season            string     `json:"season" example:"KHARIF"`
+	Status            string     `json:"status" example:"ACTIVE"`
+	StartDate         *time.Time `json:"start_date" example:"2024-06-20T00:00:00Z"`
+	CurrentStageID    string     `json:"current_crop_stage_id" example:"CSTG00000002"`
+	CurrentStageName  string     `json:"current_stage_name,omitempty" example:"Vegetative"`
+	ExpectedStartDate *time.Time `json:"expected_stage_start_date,omitempty" example:"2024-07-04T00:00:00Z"`
+	ExpectedEndDate   *time.Time `json:"expected_stage_end_date,omitempty" example:"2024-08-03T00:00:00Z"`
+	ActualStartDate   *time.Time `json:"actual_stage_start_date,omitempty" example:"2024-07-10T00:00:00Z"`
+	DelayDays         int        `json:"delay_days" example:"6"`
 }
 
 // NewStageProgressResponse creates a new stage progress response
@@ -107,6 +145,28 @@ func NewStageProgressResponse(data *StageProgressData, message string) StageProg
 	}
 }
 
+// NewDelayedCyclesResponse creates a new delayed cycles response
+func NewDelayedCyclesResponse(cycles []*DelayedCycleData, page, pageSize int, totalCount int64) DelayedCyclesResponse {
+	if cycles == nil {
+		cycles = []*DelayedCycleData{}
+	}
+	data := make([]interface{}, len(cycles))
+	for i, c := range cycles {
+		data[i] = c
+	}
+
+	paginationInfo := base.NewPaginationInfo(page, pageSize, int(totalCount))
+	return DelayedCyclesResponse{
+		PaginatedResponse: base.NewPaginatedResponse("Delayed crop cycles retrieved successfully", data, paginationInfo),
+		Data:              cycles,
+	}
+}
+
+// SetRequestID sets the request ID for tracking
+func (r *DelayedCyclesResponse) SetRequestID(requestID string) {
+	r.PaginatedResponse.RequestID = requestID
+}
+
 // SetRequestID sets the request ID for tracking
 func (r *StageProgressResponse) SetRequestID(requestID string) {
 	r.BaseResponse.RequestID = requestID
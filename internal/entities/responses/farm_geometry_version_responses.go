@@ -0,0 +1,99 @@
+package responses
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/Kisanlink/kisanlink-db/pkg/base"
+)
+
+// FarmGeometryVersionResponse represents a single farm boundary version response
+type FarmGeometryVersionResponse struct {
+	*base.BaseResponse `json:",inline"`
+	Data               *FarmGeometryVersionData `json:"data"`
+}
+
+// FarmGeometryVersionListResponse represents the boundary history of a farm
+type FarmGeometryVersionListResponse struct {
+	*base.BaseResponse `json:",inline"`
+	Data               []*FarmGeometryVersionData `json:"data"`
+}
+
+// FarmGeometryDiffResponse represents the difference between two boundary versions
+type FarmGeometryDiffResponse struct {
+	*base.BaseResponse `json:",inline"`
+	Data               *FarmGeometryDiffData `json:"data"`
+}
+
+// FarmGeometryVersionData represents a farm boundary version in responses
+type FarmGeometryVersionData struct {
+	ID          string          `json:"id" example:"FGVR0000000001"`
+	FarmID      string          `json:"farm_id" example:"FARM00000001"`
+	Version     int             `json:"version" example:"2"`
+	Geometry    string          `json:"geometry" example:"POLYGON((75.85 22.71, 75.85663 22.71, 75.85663 22.71663, 75.85 22.71663, 75.85 22.71))"`
+	GeoJSON     json.RawMessage `json:"geojson,omitempty" swaggertype:"object"`
+	AreaHa      float64         `json:"area_ha" example:"50.12"`
+	AreaDeltaHa float64         `json:"area_delta_ha" example:"-0.35"` // Change from the previous version
+	Source      string          `json:"source" example:"GPS_WALK"`
+	Reason      *string         `json:"reason,omitempty" example:"Boundary re-walked after lease change"`
+	AuthorID    string          `json:"author_id" example:"usr_123e4567-e89b-12d3-a456-426614174000"`
+	ValidFrom   time.Time       `json:"valid_from" example:"2024-01-15T10:30:00Z"`
+	ValidTo     *time.Time      `json:"valid_to,omitempty" example:"2024-06-01T08:00:00Z"` // Absent for the current boundary
+}
+
+// FarmGeometryDiffData represents the area gained and lost between two boundary versions.
+// Added and removed geometries are empty when nothing was gained or lost.
+type FarmGeometryDiffData struct {
+	FarmID          string          `json:"farm_id" example:"FARM00000001"`
+	FromVersion     int             `json:"from_version" example:"1"`
+	ToVersion       int             `json:"to_version" example:"2"`
+	Added           string          `json:"added,omitempty" example:"POLYGON((75.85663 22.71, 75.857 22.71, 75.857 22.71663, 75.85663 22.71663, 75.85663 22.71))"`
+	AddedGeoJSON    json.RawMessage `json:"added_geojson,omitempty" swaggertype:"object"`
+	AddedAreaHa     float64         `json:"added_area_ha" example:"0.25"`
+	Removed         string          `json:"removed,omitempty"`
+	RemovedGeoJSON  json.RawMessage `json:"removed_geojson,omitempty" swaggertype:"object"`
+	RemovedAreaHa   float64         `json:"removed_area_ha" example:"0.6"`
+	NetAreaChangeHa float64         `json:"net_area_change_ha" example:"-0.35"`
+}
+
+// NewFarmGeometryVersionResponse creates a new farm geometry version response
+func NewFarmGeometryVersionResponse(version *FarmGeometryVersionData, message string) FarmGeometryVersionResponse {
+	return FarmGeometryVersionResponse{
+		BaseResponse: base.NewSuccessResponse(message, version),
+		Data:         version,
+	}
+}
+
+// NewFarmGeometryVersionListResponse creates a new farm geometry version list response
+func NewFarmGeometryVersionListResponse(versions []*FarmGeometryVersionData, message string) FarmGeometryVersionListResponse {
+	if versions == nil {
+		versions = []*FarmGeometryVersionData{}
+	}
+	return FarmGeometryVersionListResponse{
+		BaseResponse: base.NewSuccessResponse(message, versions),
+		Data:         versions,
+	}
+}
+
+// NewFarmGeometryDiffResponse creates a new farm geometry diff response
+func NewFarmGeometryDiffResponse(diff *FarmGeometryDiffData, message string) FarmGeometryDiffResponse {
+	return FarmGeometryDiffResponse{
+		BaseResponse: base.NewSuccessResponse(message, diff),
+		Data:         diff,
+	}
+}
+
+// SetRequestID sets the request ID for tracking
+func (r *FarmGeometryVersionResponse) SetRequestID(requestID string) {
+	r.BaseResponse.RequestID = requestID
+}
+
+// SetRequestID sets the request ID for tracking
+func (r *FarmGeometryVersionListResponse) SetRequestID(requestID string) {
+	r.BaseResponse.RequestID = requestID
+}
+
+// SetRequestID sets the request ID for tracking
+func (r *FarmGeometryDiffResponse) SetRequestID(requestID string) {
+	r.BaseResponse.RequestID = requestID
+}
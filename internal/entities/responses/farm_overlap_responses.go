@@ -0,0 +1,71 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/Kisanlink/kisanlink-db/pkg/base"
+)
+
+// OverlapConflictResponse represents a single farm overlap conflict response
+type OverlapConflictResponse struct {
+	*base.BaseResponse `json:",inline"`
+	Data               *OverlapConflictData `json:"data"`
+}
+
+// OverlapConflictListResponse represents a paginated list of farm overlap conflicts
+type OverlapConflictListResponse struct {
+	*base.PaginatedResponse `json:",inline"`
+	Data                    []*OverlapConflictData `json:"data"`
+}
+
+// OverlapConflictData represents a farm overlap conflict in responses
+type OverlapConflictData struct {
+	ID               string     `json:"id" example:"FOVC0000000001"`
+	AAAOrgID         string     `json:"aaa_org_id" example:"org_123e4567-e89b-12d3-a456-426614174000"`
+	Farm1ID          string     `json:"farm1_id" example:"FARM00000001"`
+	Farm2ID          string     `json:"farm2_id" example:"FARM00000002"`
+	OverlapAreaHa    float64    `json:"overlap_area_ha" example:"0.12"`
+	Status           string     `json:"status" example:"ACKNOWLEDGED"`
+	AssigneeID       *string    `json:"assignee_id,omitempty" example:"usr_123e4567-e89b-12d3-a456-426614174000"`
+	ResolutionNotes  *string    `json:"resolution_notes,omitempty" example:"Field visit booked with both farmers"`
+	ResolutionAction *string    `json:"resolution_action,omitempty" example:"TRIM"`
+	DetectedAt       time.Time  `json:"detected_at" example:"2024-06-01T10:30:00Z"`
+	LastDetectedAt   time.Time  `json:"last_detected_at" example:"2024-06-08T10:30:00Z"`
+	ResolvedAt       *time.Time `json:"resolved_at,omitempty" example:"2024-06-10T15:45:00Z"`
+	ResolvedBy       *string    `json:"resolved_by,omitempty" example:"usr_123e4567-e89b-12d3-a456-426614174000"`
+}
+
+// NewOverlapConflictResponse creates a new overlap conflict response
+func NewOverlapConflictResponse(conflict *OverlapConflictData, message string) OverlapConflictResponse {
+	return OverlapConflictResponse{
+		BaseResponse: base.NewSuccessResponse(message, conflict),
+		Data:         conflict,
+	}
+}
+
+// NewOverlapConflictListResponse creates a new overlap conflict list response
+func NewOverlapConflictListResponse(conflicts []*OverlapConflictData, page, pageSize int, totalCount int64) OverlapConflictListResponse {
+	if conflicts == nil {
+		conflicts = []*OverlapConflictData{}
+	}
+	data := make([]interface{}, len(conflicts))
+	for i, c := range conflicts {
+		data[i] = c
+	}
+
+	paginationInfo := base.NewPaginationInfo(page, pageSize, int(totalCount))
+	return OverlapConflictListResponse{
+		PaginatedResponse: base.NewPaginatedResponse("Overlap conflicts retrieved successfully", data, paginationInfo),
+		Data:              conflicts,
+	}
+}
+
+// SetRequestID sets the request ID for tracking
+func (r *OverlapConflictResponse) SetRequestID(requestID string) {
+	r.BaseResponse.RequestID = requestID
+}
+
+// SetRequestID sets the request ID for tracking
+func (r *OverlapConflictListResponse) SetRequestID(requestID string) {
+	r.PaginatedResponse.RequestID = requestID
+}
@@ -1,6 +1,7 @@
 package responses
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/Kisanlink/kisanlink-db/pkg/base"
@@ -33,13 +34,18 @@ type FarmData struct {
 	Name                      string                 `json:"name" example:"North Field Farm"`
 	OwnershipType             string                 `json:"ownership_type" example:"OWN"`
 	Geometry                  string                 `json:"geometry" example:"POLYGON((75.85 22.71, 75.85663 22.71, 75.85663 22.71663, 75.85 22.71663, 75.85 22.71))"`
-	AreaHa                    float64                `json:"area_ha" example:"2.5"`             // User-provided or stored area
-	AreaHaComputed            float64                `json:"area_ha_computed" example:"2.4876"` // Computed from geometry using PostGIS
+	GeoJSON                   json.RawMessage        `json:"geojson,omitempty" swaggertype:"object"` // Geometry as an RFC 7946 Polygon
+	AreaHa                    float64                `json:"area_ha" example:"2.5"`                  // User-provided or stored area
+	AreaHaComputed            float64                `json:"area_ha_computed" example:"2.4876"`      // Computed from geometry using PostGIS
 	SoilTypeID                *string                `json:"soil_type_id,omitempty"`
 	PrimaryIrrigationSourceID *string                `json:"primary_irrigation_source_id,omitempty"`
 	BoreWellCount             int                    `json:"bore_well_count" example:"2"`
 	OtherIrrigationDetails    *string                `json:"other_irrigation_details,omitempty"`
 	Metadata                  map[string]interface{} `json:"metadata"`
+	StateCode                 *string                `json:"state_code,omitempty" example:"27"`
+	DistrictCode              *string                `json:"district_code,omitempty" example:"523"`
+	BlockCode                 *string                `json:"block_code,omitempty" example:"4187"`
+	VillageCode               *string                `json:"village_code,omitempty" example:"563214"`
 	CreatedAt                 time.Time              `json:"created_at" example:"2024-01-15T10:30:00Z"`
 	UpdatedAt                 time.Time              `json:"updated_at" example:"2024-01-20T15:45:00Z"`
 	Farmer                    *FarmerBasicData       `json:"farmer,omitempty"`
@@ -47,6 +53,19 @@ type FarmData struct {
 	PrimaryIrrigationSource   *IrrigationSourceData  `json:"primary_irrigation_source,omitempty"`
 	IrrigationSources         []IrrigationSourceData `json:"irrigation_sources,omitempty"`
 	SoilTypes                 []SoilTypeData         `json:"soil_types,omitempty"`
+	GeometryRepair            *GeometryRepairData    `json:"geometry_repair,omitempty"` // Set when the geometry was repaired on capture
+	DistanceM                 *float64               `json:"distance_m,omitempty"`      // Set by point searches: geodesic distance from the search point
+}
+
+// GeometryRepairData describes a repaired farm boundary and how it differs from the one
+// captured
+type GeometryRepairData struct {
+	RepairedWKT    string   `json:"repaired_wkt" example:"POLYGON((75.85 22.71, 75.85663 22.71, 75.85663 22.71663, 75.85 22.71663, 75.85 22.71))"`
+	OriginalAreaHa float64  `json:"original_area_ha" example:"50.12"`
+	RepairedAreaHa float64  `json:"repaired_area_ha" example:"50.08"`
+	AreaChangeHa   float64  `json:"area_change_ha" example:"-0.04"`
+	AreaChangePct  float64  `json:"area_change_pct" example:"-0.08"`
+	Corrections    []string `json:"corrections" example:"removed 2 duplicate vertices,resolved self-intersection"`
 }
 
 // SoilTypeData represents soil type data in farm responses
@@ -90,6 +109,43 @@ type FarmOverlapData struct {
 	Message          string   `json:"message,omitempty" example:"Farm boundary overlaps with 2 existing farms"`
 }
 
+// FarmFeatureCollection is an RFC 7946 FeatureCollection of farms, returned as is rather
+// than in the response envelope so that GIS tools can load it directly
+type FarmFeatureCollection struct {
+	Type     string         `json:"type" example:"FeatureCollection"`
+	Features []*FarmFeature `json:"features"`
+}
+
+// FarmFeature is a farm as an RFC 7946 Feature. Farms without a usable geometry have a null
+// geometry.
+type FarmFeature struct {
+	Type       string                 `json:"type" example:"Feature"`
+	ID         string                 `json:"id" example:"FARM00000001"`
+	Geometry   json.RawMessage        `json:"geometry" swaggertype:"object"`
+	Properties *FarmFeatureProperties `json:"properties"`
+}
+
+// FarmFeatureProperties represents the farm attributes carried by a FarmFeature
+type FarmFeatureProperties struct {
+	FarmerID       string    `json:"farmer_id" example:"FMRR0000000001"`
+	AAAUserID      string    `json:"aaa_user_id" example:"usr_123e4567-e89b-12d3-a456-426614174000"`
+	AAAOrgID       string    `json:"aaa_org_id" example:"org_123e4567-e89b-12d3-a456-426614174000"`
+	Name           string    `json:"name" example:"North Field Farm"`
+	OwnershipType  string    `json:"ownership_type" example:"OWN"`
+	AreaHa         float64   `json:"area_ha" example:"2.5"`
+	AreaHaComputed float64   `json:"area_ha_computed" example:"2.4876"`
+	BoreWellCount  int       `json:"bore_well_count" example:"2"`
+	UpdatedAt      time.Time `json:"updated_at" example:"2024-01-20T15:45:00Z"`
+}
+
+// NewFarmFeatureCollection creates a new farm feature collection
+func NewFarmFeatureCollection(features []*FarmFeature) *FarmFeatureCollection {
+	if features == nil {
+		features = []*FarmFeature{}
+	}
+	return &FarmFeatureCollection{Type: "FeatureCollection", Features: features}
+}
+
 // NewFarmResponse creates a new farm response
 func NewFarmResponse(farm *FarmData, message string) FarmResponse {
 	return FarmResponse{
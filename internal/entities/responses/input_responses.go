@@ -0,0 +1,211 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/Kisanlink/kisanlink-db/pkg/base"
+)
+
+// InputProductResponse represents a single input master product response
+type InputProductResponse struct {
+	*base.BaseResponse `json:",inline"`
+	Data               *InputProductData `json:"data"`
+}
+
+// InputProductListResponse represents a list of input master products
+type InputProductListResponse struct {
+	*base.PaginatedResponse `json:",inline"`
+	Data                    []*InputProductData `json:"data"`
+}
+
+// InputProductData represents an input master product in responses
+type InputProductData struct {
+	ID           string    `json:"id" example:"INPD00000001"`
+	Name         string    `json:"name" example:"Urea 46% N"`
+	Category     string    `json:"category" example:"FERTILIZER"`
+	Unit         string    `json:"unit" example:"kg"`
+	Manufacturer *string   `json:"manufacturer,omitempty" example:"IFFCO"`
+	Description  *string   `json:"description,omitempty" example:"Nitrogenous fertiliser, 45 kg bag"`
+	IsActive     bool      `json:"is_active" example:"true"`
+	CreatedAt    time.Time `json:"created_at" example:"2024-01-15T10:30:00Z"`
+	UpdatedAt    time.Time `json:"updated_at" example:"2024-01-20T15:45:00Z"`
+}
+
+// ActivityInputsResponse represents the input line items of a farm activity
+type ActivityInputsResponse struct {
+	*base.BaseResponse `json:",inline"`
+	Data               *ActivityInputsData `json:"data"`
+}
+
+// ActivityInputsData represents the input line items of a farm activity and their total cost
+type ActivityInputsData struct {
+	FarmActivityID string               `json:"farm_activity_id" example:"FACT000000001"`
+	CropCycleID    string               `json:"crop_cycle_id" example:"CRCY000000001"`
+	Inputs         []*ActivityInputData `json:"inputs"`
+	TotalCost      float64              `json:"total_cost" example:"1720"`
+}
+
+// ActivityInputData represents an input line item of a farm activity in responses
+type ActivityInputData struct {
+	ID             string    `json:"id" example:"FAIN000000001"`
+	InputProductID string    `json:"input_product_id" example:"INPD00000001"`
+	ProductName    string    `json:"product_name,omitempty" example:"Urea 46% N"`
+	Category       string    `json:"category" example:"FERTILIZER"`
+	Quantity       float64   `json:"quantity" example:"50"`
+	Unit           string    `json:"unit" example:"kg"`
+	UnitCost       *float64  `json:"unit_cost,omitempty" example:"5.9"`
+	TotalCost      float64   `json:"total_cost" example:"295"`
+	Supplier       *string   `json:"supplier,omitempty" example:"Shree Krishi Kendra, Dewas"`
+	Notes          *string   `json:"notes,omitempty" example:"Top dressing after first weeding"`
+	CreatedAt      time.Time `json:"created_at" example:"2024-07-20T10:30:00Z"`
+}
+
+// CategoryCostData represents the input cost of a crop cycle or farm in one input category
+type CategoryCostData struct {
+	Category    string  `json:"category" example:"FERTILIZER"`
+	Cost        float64 `json:"cost" example:"4200"`         // Inputs of completed activities
+	PlannedCost float64 `json:"planned_cost" example:"1800"` // Inputs of activities still planned
+	LineItems   int64   `json:"line_items" example:"6"`
+}
+
+// CycleCostResponse represents the cost of cultivation of a crop cycle
+type CycleCostResponse struct {
+	*base.BaseResponse `json:",inline"`
+	Data               *CycleCostData `json:"data"`
+}
+
+// CycleCostData represents the cost of cultivation of a crop cycle from the input line items
+// of its activities, per hectare and per unit of the yield recorded in its outcome
+type CycleCostData struct {
+	CropCycleID      string               `json:"crop_cycle_id" example:"CRCY000000001"`
+	FarmID           string               `json:"farm_id" example:"FARM00000001"`
+	CropID           string               `json:"crop_id" example:"CROP00000001"`
+	CropName         string               `json:"crop_name,omitempty" example:"Soybean"`
+	Season           string               `json:"season" example:"KHARIF"`
+	Status           string               `json:"status" example:"COMPLETED"`
+	StartDate        *time.Time           `json:"start_date,omitempty" example:"2024-06-20T00:00:00Z"`
+	AreaHa           *float64             `json:"area_ha,omitempty" example:"1.2"`
+	TotalCost        float64              `json:"total_cost" example:"38500"`
+	PlannedCost      float64              `json:"planned_cost" example:"0"`
+	CostPerHectare   *float64             `json:"cost_per_hectare,omitempty" example:"32083.33"`
+	Categories       []*CategoryCostData  `json:"categories"`
+	YieldPerHectare  *float64             `json:"yield_per_hectare,omitempty" example:"1800"`
+	TotalYield       *float64             `json:"total_yield,omitempty" example:"2160"`
+	YieldUnit        string               `json:"yield_unit,omitempty" example:"kg"`
+	CostPerYieldUnit *float64             `json:"cost_per_yield_unit,omitempty" example:"17.82"`
+	NormalizedYield  *NormalizedYieldData `json:"normalized_yield,omitempty"` // Set when the yield unit is known
+}
+
+// NormalizedYieldData represents the yield of a crop cycle in the canonical unit of its yield
+// unit: kilograms, or numbers for crops counted rather than weighed
+type NormalizedYieldData struct {
+	YieldUnit        string   `json:"yield_unit" example:"kg"`
+	YieldPerHectare  *float64 `json:"yield_per_hectare,omitempty" example:"1800"`
+	TotalYield       *float64 `json:"total_yield,omitempty" example:"2160"`
+	CostPerYieldUnit *float64 `json:"cost_per_yield_unit,omitempty" example:"17.82"`
+}
+
+// CropProductionData represents the yield of a farm's crop cycles of a crop, added up in the
+// canonical unit of their yield units
+type CropProductionData struct {
+	CropID     string  `json:"crop_id" example:"CROP00000001"`
+	CropName   string  `json:"crop_name,omitempty" example:"Soybean"`
+	YieldUnit  string  `json:"yield_unit" example:"kg"`
+	TotalYield float64 `json:"total_yield" example:"4320"`
+	Cycles     int     `json:"cycles" example:"2"`
+}
+
+// FarmCostResponse represents the cost of cultivation of a farm's crop cycles
+type FarmCostResponse struct {
+	*base.BaseResponse `json:",inline"`
+	Data               *FarmCostData `json:"data"`
+}
+
+// FarmCostData represents the cost of cultivation of a farm's crop cycles, in total and per
+// hectare cropped
+type FarmCostData struct {
+	FarmID         string                `json:"farm_id" example:"FARM00000001"`
+	Season         string                `json:"season,omitempty" example:"KHARIF"`
+	Year           int                   `json:"year,omitempty" example:"2024"`
+	CroppedAreaHa  float64               `json:"cropped_area_ha" example:"2.4"` // Area of the cycles with an area
+	TotalCost      float64               `json:"total_cost" example:"71200"`
+	PlannedCost    float64               `json:"planned_cost" example:"4500"`
+	CostPerHectare *float64              `json:"cost_per_hectare,omitempty" example:"29666.67"`
+	Categories     []*CategoryCostData   `json:"categories"`
+	Production     []*CropProductionData `json:"production,omitempty"`
+	Cycles         []*CycleCostData      `json:"cycles"`
+}
+
+// NewInputProductResponse creates a new input product response
+func NewInputProductResponse(product *InputProductData, message string) InputProductResponse {
+	return InputProductResponse{
+		BaseResponse: base.NewSuccessResponse(message, product),
+		Data:         product,
+	}
+}
+
+// NewInputProductListResponse creates a new input product list response
+func NewInputProductListResponse(products []*InputProductData, page, pageSize int, totalCount int64) InputProductListResponse {
+	if products == nil {
+		products = []*InputProductData{}
+	}
+	data := make([]interface{}, len(products))
+	for i, p := range products {
+		data[i] = p
+	}
+
+	paginationInfo := base.NewPaginationInfo(page, pageSize, int(totalCount))
+	return InputProductListResponse{
+		PaginatedResponse: base.NewPaginatedResponse("Input products retrieved successfully", data, paginationInfo),
+		Data:              products,
+	}
+}
+
+// NewActivityInputsResponse creates a new activity inputs response
+func NewActivityInputsResponse(inputs *ActivityInputsData, message string) ActivityInputsResponse {
+	return ActivityInputsResponse{
+		BaseResponse: base.NewSuccessResponse(message, inputs),
+		Data:         inputs,
+	}
+}
+
+// NewCycleCostResponse creates a new crop cycle cost response
+func NewCycleCostResponse(cost *CycleCostData, message string) CycleCostResponse {
+	return CycleCostResponse{
+		BaseResponse: base.NewSuccessResponse(message, cost),
+		Data:         cost,
+	}
+}
+
+// NewFarmCostResponse creates a new farm cost response
+func NewFarmCostResponse(cost *FarmCostData, message string) FarmCostResponse {
+	return FarmCostResponse{
+		BaseResponse: base.NewSuccessResponse(message, cost),
+		Data:         cost,
+	}
+}
+
+// SetRequestID sets the request ID for tracking
+func (r *InputProductResponse) SetRequestID(requestID string) {
+	r.BaseResponse.RequestID = requestID
+}
+
+// SetRequestID sets the request ID for tracking
+func (r *InputProductListResponse) SetRequestID(requestID string) {
+	r.PaginatedResponse.RequestID = requestID
+}
+
+// SetRequestID sets the request ID for tracking
+func (r *ActivityInputsResponse) SetRequestID(requestID string) {
+	r.BaseResponse.RequestID = requestID
+}
+
+// SetRequestID sets the request ID for tracking
+func (r *CycleCostResponse) SetRequestID(requestID string) {
+	r.BaseResponse.RequestID = requestID
+}
+
+// SetRequestID sets the request ID for tracking
+func (r *FarmCostResponse) SetRequestID(requestID string) {
+	r.BaseResponse.RequestID = requestID
+}
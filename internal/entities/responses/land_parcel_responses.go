@@ -0,0 +1,79 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/Kisanlink/kisanlink-db/pkg/base"
+)
+
+// LandParcelResponse represents a single land parcel response
+type LandParcelResponse struct {
+	*base.BaseResponse `json:",inline"`
+	Data               *LandParcelData `json:"data"`
+}
+
+// LandParcelListResponse represents a paginated list of land parcels
+type LandParcelListResponse struct {
+	*base.PaginatedResponse `json:",inline"`
+	Data                    []*LandParcelData `json:"data"`
+}
+
+// LandParcelData represents a land parcel in responses
+type LandParcelData struct {
+	ID                string     `json:"id" example:"LPCL0000000001"`
+	FarmID            string     `json:"farm_id" example:"FARM00000001"`
+	FarmerID          string     `json:"farmer_id" example:"FMRR0000000001"`
+	AAAOrgID          string     `json:"aaa_org_id" example:"org_123e4567-e89b-12d3-a456-426614174000"`
+	StateCode         string     `json:"state_code" example:"27"`
+	DistrictCode      string     `json:"district_code" example:"523"`
+	TehsilCode        string     `json:"tehsil_code" example:"4187"`
+	VillageCode       string     `json:"village_code" example:"563214"`
+	SurveyNumber      string     `json:"survey_number" example:"123/2A"`
+	KhataNumber       *string    `json:"khata_number,omitempty" example:"456"`
+	AreaHa            *float64   `json:"area_ha,omitempty" example:"1.2"`
+	SharePct          float64    `json:"share_pct" example:"50"`
+	Tenure            string     `json:"tenure" example:"OWNED"`
+	LeaseStartDate    *time.Time `json:"lease_start_date,omitempty" example:"2024-06-01T00:00:00Z"`
+	LeaseEndDate      *time.Time `json:"lease_end_date,omitempty" example:"2027-05-31T00:00:00Z"`
+	LessorName        *string    `json:"lessor_name,omitempty" example:"Ramesh Patil"`
+	DocumentType      *string    `json:"document_type,omitempty" example:"7/12 extract"`
+	DocumentReference *string    `json:"document_reference,omitempty" example:"https://records.example.org/doc/123"`
+	FarmOwnershipType string     `json:"farm_ownership_type,omitempty" example:"SHARED"` // Set on writes: the farm's ownership type as derived from its parcels
+	CreatedAt         time.Time  `json:"created_at" example:"2024-01-15T10:30:00Z"`
+	UpdatedAt         time.Time  `json:"updated_at" example:"2024-01-20T15:45:00Z"`
+}
+
+// NewLandParcelResponse creates a new land parcel response
+func NewLandParcelResponse(parcel *LandParcelData, message string) LandParcelResponse {
+	return LandParcelResponse{
+		BaseResponse: base.NewSuccessResponse(message, parcel),
+		Data:         parcel,
+	}
+}
+
+// NewLandParcelListResponse creates a new land parcel list response
+func NewLandParcelListResponse(parcels []*LandParcelData, page, pageSize int, totalCount int64) LandParcelListResponse {
+	if parcels == nil {
+		parcels = []*LandParcelData{}
+	}
+	data := make([]interface{}, len(parcels))
+	for i, p := range parcels {
+		data[i] = p
+	}
+
+	paginationInfo := base.NewPaginationInfo(page, pageSize, int(totalCount))
+	return LandParcelListResponse{
+		PaginatedResponse: base.NewPaginatedResponse("Land parcels retrieved successfully", data, paginationInfo),
+		Data:              parcels,
+	}
+}
+
+// SetRequestID sets the request ID for tracking
+func (r *LandParcelResponse) SetRequestID(requestID string) {
+	r.BaseResponse.RequestID = requestID
+}
+
+// SetRequestID sets the request ID for tracking
+func (r *LandParcelListResponse) SetRequestID(requestID string) {
+	r.PaginatedResponse.RequestID = requestID
+}
@@ -0,0 +1,112 @@
+package responses
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/Kisanlink/kisanlink-db/pkg/base"
+)
+
+// PlotResponse represents a single plot response
+type PlotResponse struct {
+	*base.BaseResponse `json:",inline"`
+	Data               *PlotData `json:"data"`
+}
+
+// PlotListResponse represents a list of the plots of a farm
+type PlotListResponse struct {
+	*base.PaginatedResponse `json:",inline"`
+	Data                    []*PlotData `json:"data"`
+}
+
+// PlotData represents a plot in responses
+type PlotData struct {
+	ID        string          `json:"id" example:"PLOT00000001"`
+	FarmID    string          `json:"farm_id" example:"FARM00000001"`
+	AAAOrgID  string          `json:"aaa_org_id" example:"org_123e4567-e89b-12d3-a456-426614174000"`
+	Name      string          `json:"name" example:"North field"`
+	Geometry  string          `json:"geometry" example:"POLYGON((75.85 22.71, 75.852 22.71, 75.852 22.712, 75.85 22.712, 75.85 22.71))"`
+	GeoJSON   json.RawMessage `json:"geojson,omitempty" swaggertype:"object"` // Geometry as an RFC 7946 Polygon
+	AreaHa    float64         `json:"area_ha" example:"1.2"`
+	CreatedAt time.Time       `json:"created_at" example:"2024-01-15T10:30:00Z"`
+	UpdatedAt time.Time       `json:"updated_at" example:"2024-01-20T15:45:00Z"`
+}
+
+// PlotFeatureCollection is an RFC 7946 FeatureCollection of the plots of a farm, returned as
+// is rather than in the response envelope so that map clients can load it directly
+type PlotFeatureCollection struct {
+	Type     string         `json:"type" example:"FeatureCollection"`
+	Features []*PlotFeature `json:"features"`
+}
+
+// PlotFeature is a plot as an RFC 7946 Feature
+type PlotFeature struct {
+	Type       string                 `json:"type" example:"Feature"`
+	ID         string                 `json:"id" example:"PLOT00000001"`
+	Geometry   json.RawMessage        `json:"geometry" swaggertype:"object"`
+	Properties *PlotFeatureProperties `json:"properties"`
+}
+
+// PlotFeatureProperties represents the plot attributes carried by a PlotFeature, with the
+// crop cycles grown on the plot. A plot without cycles was left fallow.
+type PlotFeatureProperties struct {
+	FarmID string                 `json:"farm_id" example:"FARM00000001"`
+	Name   string                 `json:"name" example:"North field"`
+	AreaHa float64                `json:"area_ha" example:"1.2"`
+	Cycles []*PlotCycleProperties `json:"cycles"`
+}
+
+// PlotCycleProperties represents a crop cycle grown on a plot
+type PlotCycleProperties struct {
+	CropCycleID string     `json:"crop_cycle_id" example:"CRCY000000001"`
+	CropID      string     `json:"crop_id" example:"crop_123e4567-e89b-12d3-a456-426614174000"`
+	CropName    string     `json:"crop_name,omitempty" example:"Soybean"`
+	VarietyID   *string    `json:"variety_id,omitempty"`
+	Season      string     `json:"season" example:"KHARIF"`
+	Status      string     `json:"status" example:"ACTIVE"`
+	StartDate   *time.Time `json:"start_date,omitempty" example:"2024-06-20T00:00:00Z"`
+	AreaHa      *float64   `json:"area_ha,omitempty" example:"1.2"`
+}
+
+// NewPlotResponse creates a new plot response
+func NewPlotResponse(plot *PlotData, message string) PlotResponse {
+	return PlotResponse{
+		BaseResponse: base.NewSuccessResponse(message, plot),
+		Data:         plot,
+	}
+}
+
+// NewPlotListResponse creates a new plot list response
+func NewPlotListResponse(plots []*PlotData, page, pageSize int, totalCount int64) PlotListResponse {
+	if plots == nil {
+		plots = []*PlotData{}
+	}
+	data := make([]interface{}, len(plots))
+	for i, p := range plots {
+		data[i] = p
+	}
+
+	paginationInfo := base.NewPaginationInfo(page, pageSize, int(totalCount))
+	return PlotListResponse{
+		PaginatedResponse: base.NewPaginatedResponse("Plots retrieved successfully", data, paginationInfo),
+		Data:              plots,
+	}
+}
+
+// NewPlotFeatureCollection creates a new plot feature collection
+func NewPlotFeatureCollection(features []*PlotFeature) *PlotFeatureCollection {
+	if features == nil {
+		features = []*PlotFeature{}
+	}
+	return &PlotFeatureCollection{Type: "FeatureCollection", Features: features}
+}
+
+// SetRequestID sets the request ID for tracking
+func (r *PlotResponse) SetRequestID(requestID string) {
+	r.BaseResponse.RequestID = requestID
+}
+
+// SetRequestID sets the request ID for tracking
+func (r *PlotListResponse) SetRequestID(requestID string) {
+	r.PaginatedResponse.RequestID = requestID
+}
@@ -73,3 +73,30 @@ type StageLookupResponse struct {
 	*base.BaseResponse `json:",inline"`
 	Data               []*StageLookupData `json:"data"`
 }
+
+// ActivityTemplateData represents an activity template of a crop stage in responses
+type ActivityTemplateData struct {
+	ID           string         `json:"id" example:"CSAT00000001"`
+	CropStageID  string         `json:"crop_stage_id" example:"CSTG00000001"`
+	Name         string         `json:"name" example:"First irrigation"`
+	ActivityType string         `json:"activity_type" example:"IRRIGATION"`
+	OffsetDays   int            `json:"offset_days" example:"21"`
+	OffsetAnchor string         `json:"offset_anchor" example:"SOWING"`
+	Description  *string        `json:"description,omitempty" example:"Light irrigation at crown root initiation"`
+	Metadata     entities.JSONB `json:"metadata,omitempty" swaggertype:"object"`
+	IsActive     bool           `json:"is_active" example:"true"`
+	CreatedAt    time.Time      `json:"created_at" example:"2025-01-15T10:30:00Z"`
+	UpdatedAt    time.Time      `json:"updated_at" example:"2025-01-15T10:30:00Z"`
+}
+
+// ActivityTemplateResponse represents a single activity template response
+type ActivityTemplateResponse struct {
+	*base.BaseResponse `json:",inline"`
+	Data               *ActivityTemplateData `json:"data,omitempty"`
+}
+
+// ActivityTemplatesResponse represents the activity templates of a crop stage
+type ActivityTemplatesResponse struct {
+	*base.BaseResponse `json:",inline"`
+	Data               []*ActivityTemplateData `json:"data"`
+}
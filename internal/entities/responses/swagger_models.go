@@ -207,15 +207,16 @@ type SwaggerKisanSathiListResponse struct {
 
 // SwaggerValidateGeometryResponse represents a geometry validation response for Swagger
 type SwaggerValidateGeometryResponse struct {
-	Success   bool     `json:"success" example:"true"`
-	Message   string   `json:"message" example:"Geometry validated successfully"`
-	RequestID string   `json:"request_id,omitempty" example:"req_123456789"`
-	WKT       string   `json:"wkt" example:"POLYGON((...))"`
-	IsValid   bool     `json:"is_valid" example:"true"`
-	Errors    []string `json:"errors,omitempty"`
-	Warnings  []string `json:"warnings,omitempty"`
-	SRID      int      `json:"srid" example:"4326"`
-	AreaHa    *float64 `json:"area_ha,omitempty" example:"2.5"`
+	Success   bool                `json:"success" example:"true"`
+	Message   string              `json:"message" example:"Geometry validated successfully"`
+	RequestID string              `json:"request_id,omitempty" example:"req_123456789"`
+	WKT       string              `json:"wkt" example:"POLYGON((...))"`
+	IsValid   bool                `json:"is_valid" example:"true"`
+	Errors    []string            `json:"errors,omitempty"`
+	Warnings  []string            `json:"warnings,omitempty"`
+	SRID      int                 `json:"srid" example:"4326"`
+	AreaHa    *float64            `json:"area_ha,omitempty" example:"2.5"`
+	Repair    *GeometryRepairData `json:"repair,omitempty"`
 }
 
 // SwaggerReconcileAAALinksResponse represents an AAA links reconciliation response for Swagger
@@ -245,6 +246,12 @@ type SwaggerDetectFarmOverlapsResponse struct {
 	RequestID     string        `json:"request_id,omitempty" example:"req_123456789"`
 	Overlaps      []FarmOverlap `json:"overlaps"`
 	TotalOverlaps int           `json:"total_overlaps" example:"3"`
+
+	Incremental       bool   `json:"incremental" example:"true"`
+	CheckedSince      string `json:"checked_since,omitempty" example:"2024-06-01T00:00:00Z"`
+	NewConflicts      int    `json:"new_conflicts" example:"2"`
+	ReopenedConflicts int    `json:"reopened_conflicts" example:"0"`
+	ResolvedConflicts int    `json:"resolved_conflicts" example:"1"`
 }
 
 // SwaggerSoilTypesResponse represents a soil types lookup response for Swagger
@@ -0,0 +1,85 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/Kisanlink/kisanlink-db/pkg/base"
+)
+
+// SyncChangesResponse represents a page of the offline sync change feed
+type SyncChangesResponse struct {
+	*base.BaseResponse `json:",inline"`
+	Data               *SyncChangesData `json:"data"`
+}
+
+// SyncChangesData represents the changes since a cursor. Clients store next_cursor and keep
+// requesting changes while has_more is set.
+type SyncChangesData struct {
+	Changes    []*SyncRecordData `json:"changes"`
+	NextCursor string            `json:"next_cursor"`
+	HasMore    bool              `json:"has_more"`
+}
+
+// SyncRecordData represents the server copy of a synced record. Deleted records carry
+// deleted_at and no record.
+type SyncRecordData struct {
+	Entity    string      `json:"entity" example:"farm"`
+	ID        string      `json:"id" example:"FARM00000001"`
+	UpdatedAt time.Time   `json:"updated_at" example:"2024-11-01T10:30:00Z"`
+	DeletedAt *time.Time  `json:"deleted_at,omitempty"`
+	Record    interface{} `json:"record,omitempty" swaggertype:"object"`
+}
+
+// SyncPushResponse represents the outcome of a batch of offline mutations
+type SyncPushResponse struct {
+	*base.BaseResponse `json:",inline"`
+	Data               *SyncPushData `json:"data"`
+}
+
+// SyncPushData represents the per-mutation results of a push, in request order
+type SyncPushData struct {
+	Results   []*SyncMutationResult `json:"results"`
+	Applied   int                   `json:"applied"`
+	Conflicts int                   `json:"conflicts"`
+	Rejected  int                   `json:"rejected"`
+	Failed    int                   `json:"failed"`
+}
+
+// SyncMutationResult represents the outcome of one mutation. Server holds the server copy
+// of the record after an applied mutation and the conflicting copy after a conflict.
+type SyncMutationResult struct {
+	MutationID string          `json:"mutation_id" example:"mut_0001"`
+	Entity     string          `json:"entity" example:"farm"`
+	Operation  string          `json:"operation" example:"update"`
+	ClientID   string          `json:"client_id,omitempty" example:"local-farm-1"` // ID chosen offline for a created record
+	ID         string          `json:"id,omitempty" example:"FARM00000001"`
+	Status     string          `json:"status" example:"CONFLICT"`
+	Error      string          `json:"error,omitempty"`
+	Server     *SyncRecordData `json:"server,omitempty"`
+}
+
+// NewSyncChangesResponse creates a new sync changes response
+func NewSyncChangesResponse(data *SyncChangesData, message string) SyncChangesResponse {
+	return SyncChangesResponse{
+		BaseResponse: base.NewSuccessResponse(message, data),
+		Data:         data,
+	}
+}
+
+// NewSyncPushResponse creates a new sync push response
+func NewSyncPushResponse(data *SyncPushData, message string) SyncPushResponse {
+	return SyncPushResponse{
+		BaseResponse: base.NewSuccessResponse(message, data),
+		Data:         data,
+	}
+}
+
+// SetRequestID sets the request ID for tracking
+func (r *SyncChangesResponse) SetRequestID(requestID string) {
+	r.BaseResponse.RequestID = requestID
+}
+
+// SetRequestID sets the request ID for tracking
+func (r *SyncPushResponse) SetRequestID(requestID string) {
+	r.BaseResponse.RequestID = requestID
+}
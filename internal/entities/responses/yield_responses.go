@@ -0,0 +1,95 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/crop_cycle"
+	"github.com/Kisanlink/kisanlink-db/pkg/base"
+)
+
+// YieldEstimateResponse represents the expected yield of a crop cycle
+type YieldEstimateResponse struct {
+	*base.BaseResponse `json:",inline"`
+	Data               *YieldEstimateData `json:"data"`
+}
+
+// YieldEstimateData represents the yield expected of a crop cycle from its variety's
+// benchmarks and, once its outcome is recorded, how the actual yield compares with it
+type YieldEstimateData struct {
+	CropCycleID string                      `json:"crop_cycle_id" example:"CRCY000000001"`
+	FarmID      string                      `json:"farm_id" example:"FARM00000001"`
+	CropID      string                      `json:"crop_id" example:"CROP00000001"`
+	CropName    string                      `json:"crop_name,omitempty" example:"Wheat"`
+	VarietyID   *string                     `json:"variety_id,omitempty" example:"CRPV00000001"`
+	VarietyName string                      `json:"variety_name,omitempty" example:"HD 2967"`
+	Season      string                      `json:"season" example:"RABI"`
+	Status      string                      `json:"status" example:"ACTIVE"`
+	AreaHa      *float64                    `json:"area_ha,omitempty" example:"2"`
+	Estimate    *crop_cycle.YieldEstimate   `json:"estimate,omitempty"` // Not set when the variety has no benchmark that applies
+	Actual      *crop_cycle.NormalizedYield `json:"actual,omitempty"`   // Set once the outcome records a yield in a known unit
+	Variance    *crop_cycle.YieldVariance   `json:"variance,omitempty"`
+}
+
+// ProductionForecastResponse represents the estimated production of an organisation's
+// planned and active crop cycles
+type ProductionForecastResponse struct {
+	*base.BaseResponse `json:",inline"`
+	Data               *ProductionForecastData `json:"data"`
+}
+
+// ProductionForecastData represents the estimated production of an organisation's planned
+// and active crop cycles by crop and season
+type ProductionForecastData struct {
+	FarmID            string              `json:"farm_id,omitempty" example:"FARM00000001"`
+	CropID            string              `json:"crop_id,omitempty" example:"CROP00000001"`
+	Season            string              `json:"season,omitempty" example:"RABI"`
+	Year              int                 `json:"year,omitempty" example:"2024"`
+	TotalCycles       int                 `json:"total_cycles" example:"42"`
+	UnestimatedCycles int                 `json:"unestimated_cycles" example:"5"` // Without a variety benchmark, or an area or tree count to apply it to
+	Crops             []*CropForecastData `json:"crops"`
+	GeneratedAt       time.Time           `json:"generated_at" example:"2024-12-01T10:30:00Z"`
+}
+
+// CropForecastData represents the estimated production of the planned and active crop cycles
+// of a crop in a season
+type CropForecastData struct {
+	CropID              string   `json:"crop_id" example:"CROP00000001"`
+	CropName            string   `json:"crop_name,omitempty" example:"Wheat"`
+	Season              string   `json:"season" example:"RABI"`
+	YieldUnit           string   `json:"yield_unit" example:"kg"`
+	Cycles              int      `json:"cycles" example:"37"`
+	EstimatedCycles     int      `json:"estimated_cycles" example:"34"`
+	AreaHa              float64  `json:"area_ha" example:"61.5"`           // Of all the cycles with an area
+	EstimatedAreaHa     float64  `json:"estimated_area_ha" example:"57.2"` // Of the estimated cycles with an area
+	EstimatedProduction float64  `json:"estimated_production" example:"169614.5"`
+	YieldPerHectare     *float64 `json:"yield_per_hectare,omitempty" example:"2965.26"` // Of the estimated cycles with an area
+}
+
+// NewYieldEstimateResponse creates a new yield estimate response
+func NewYieldEstimateResponse(data *YieldEstimateData, message string) YieldEstimateResponse {
+	return YieldEstimateResponse{
+		BaseResponse: base.NewSuccessResponse(message, data),
+		Data:         data,
+	}
+}
+
+// NewProductionForecastResponse creates a new production forecast response
+func NewProductionForecastResponse(data *ProductionForecastData, message string) ProductionForecastResponse {
+	if data.Crops == nil {
+		data.Crops = []*CropForecastData{}
+	}
+	return ProductionForecastResponse{
+		BaseResponse: base.NewSuccessResponse(message, data),
+		Data:         data,
+	}
+}
+
+// SetRequestID sets the request ID for tracking
+func (r *YieldEstimateResponse) SetRequestID(requestID string) {
+	r.BaseResponse.RequestID = requestID
+}
+
+// SetRequestID sets the request ID for tracking
+func (r *ProductionForecastResponse) SetRequestID(requestID string) {
+	r.BaseResponse.RequestID = requestID
+}
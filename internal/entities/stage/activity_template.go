@@ -0,0 +1,120 @@
+package stage
+
+import (
+	"time"
+
+	"github.com/Kisanlink/farmers-module/internal/entities"
+	"github.com/Kisanlink/farmers-module/pkg/common"
+	"github.com/Kisanlink/kisanlink-db/pkg/base"
+	"github.com/Kisanlink/kisanlink-db/pkg/core/hash"
+)
+
+// OffsetAnchor represents the date an activity template's offset is counted from
+type OffsetAnchor string
+
+const (
+	OffsetAnchorSowing     OffsetAnchor = "SOWING"      // The crop cycle's start date
+	OffsetAnchorStageStart OffsetAnchor = "STAGE_START" // The start of the template's stage
+)
+
+// ActivityTemplate describes an activity that is planned for every crop cycle of a crop,
+// such as the first irrigation 21 days after sowing. Templates belong to a crop stage.
+type ActivityTemplate struct {
+	base.BaseModel
+	CropStageID  string         `json:"crop_stage_id" gorm:"type:varchar(20);not null;index"`
+	Name         string         `json:"name" gorm:"type:varchar(255);not null"`
+	ActivityType string         `json:"activity_type" gorm:"type:varchar(255);not null"`
+	OffsetDays   int            `json:"offset_days" gorm:"type:integer;not null;default:0"`
+	OffsetAnchor OffsetAnchor   `json:"offset_anchor" gorm:"type:varchar(20);not null;default:'SOWING'"`
+	Description  *string        `json:"description" gorm:"type:text"`
+	Metadata     entities.JSONB `json:"metadata" gorm:"type:jsonb;not null;default:'{}';serializer:json"` // Copied to the planned activities
+	IsActive     bool           `json:"is_active" gorm:"type:boolean;not null;default:true"`
+}
+
+// TableName returns the table name for the ActivityTemplate model
+func (t *ActivityTemplate) TableName() string {
+	return "crop_stage_activity_templates"
+}
+
+// GetTableIdentifier returns the table identifier for ID generation
+func (t *ActivityTemplate) GetTableIdentifier() string {
+	return "CSAT"
+}
+
+// GetTableSize returns the table size for ID generation
+func (t *ActivityTemplate) GetTableSize() hash.TableSize {
+	return hash.Medium
+}
+
+// NewActivityTemplate creates a new activity template model with proper initialization
+func NewActivityTemplate() *ActivityTemplate {
+	baseModel := base.NewBaseModel("CSAT", hash.Medium)
+	return &ActivityTemplate{
+		BaseModel:    *baseModel,
+		OffsetAnchor: OffsetAnchorSowing,
+		Metadata:     make(entities.JSONB),
+		IsActive:     true,
+	}
+}
+
+// Validate validates the activity template model
+func (t *ActivityTemplate) Validate() error {
+	if t.CropStageID == "" || t.Name == "" || t.ActivityType == "" {
+		return common.ErrInvalidInput
+	}
+	if t.OffsetDays < 0 {
+		return common.ErrInvalidInput
+	}
+	if t.OffsetAnchor != OffsetAnchorSowing && t.OffsetAnchor != OffsetAnchorStageStart {
+		return common.ErrInvalidInput
+	}
+	return nil
+}
+
+// PlannedAt returns the date the template's activity is planned for in a cycle sown on
+// sowing, given the stage start dates from StageStarts. It is false when the template is
+// counted from the start of a stage whose start is not known.
+func (t *ActivityTemplate) PlannedAt(sowing time.Time, stageStarts map[string]time.Time) (time.Time, bool) {
+	anchor := sowing
+	if t.OffsetAnchor == OffsetAnchorStageStart {
+		start, ok := stageStarts[t.CropStageID]
+		if !ok {
+			return time.Time{}, false
+		}
+		anchor = start
+	}
+	return anchor.AddDate(0, 0, t.OffsetDays), true
+}
+
+// EndFrom returns when the stage ends if it starts on start. It is false when the stage has
+// no duration.
+func (cs *CropStage) EndFrom(start time.Time) (time.Time, bool) {
+	if cs.DurationDays == nil {
+		return time.Time{}, false
+	}
+	switch cs.DurationUnit {
+	case DurationUnitWeeks:
+		return start.AddDate(0, 0, 7**cs.DurationDays), true
+	case DurationUnitMonths:
+		return start.AddDate(0, *cs.DurationDays, 0), true
+	default:
+		return start.AddDate(0, 0, *cs.DurationDays), true
+	}
+}
+
+// StageStarts returns when each of a crop's stages, given in order, starts in a cycle sown on
+// sowing. The first stage starts at sowing and each stage starts when the one before ends;
+// stages after one without a duration are left out.
+func StageStarts(stages []*CropStage, sowing time.Time) map[string]time.Time {
+	starts := make(map[string]time.Time, len(stages))
+	start := sowing
+	for _, cs := range stages {
+		starts[cs.ID] = start
+		end, ok := cs.EndFrom(start)
+		if !ok {
+			break
+		}
+		start = end
+	}
+	return starts
+}
@@ -0,0 +1,93 @@
+package stage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func cropStage(id string, duration *int, unit DurationUnit) *CropStage {
+	cs := NewCropStage()
+	cs.ID = id
+	cs.DurationDays = duration
+	cs.DurationUnit = unit
+	return cs
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func TestStageStarts(t *testing.T) {
+	sowing := time.Date(2024, 6, 20, 0, 0, 0, 0, time.UTC)
+	stages := []*CropStage{
+		cropStage("CSTG1", intPtr(14), DurationUnitDays),
+		cropStage("CSTG2", intPtr(3), DurationUnitWeeks),
+		cropStage("CSTG3", intPtr(1), DurationUnitMonths),
+		cropStage("CSTG4", nil, DurationUnitDays),
+		cropStage("CSTG5", intPtr(10), DurationUnitDays),
+	}
+
+	starts := StageStarts(stages, sowing)
+	assert.Equal(t, sowing, starts["CSTG1"])
+	assert.Equal(t, time.Date(2024, 7, 4, 0, 0, 0, 0, time.UTC), starts["CSTG2"])
+	assert.Equal(t, time.Date(2024, 7, 25, 0, 0, 0, 0, time.UTC), starts["CSTG3"])
+	assert.Equal(t, time.Date(2024, 8, 25, 0, 0, 0, 0, time.UTC), starts["CSTG4"])
+	// The stage before has no duration, so this one's start is unknown
+	assert.NotContains(t, starts, "CSTG5")
+}
+
+func TestActivityTemplatePlannedAt(t *testing.T) {
+	sowing := time.Date(2024, 6, 20, 0, 0, 0, 0, time.UTC)
+	starts := map[string]time.Time{"CSTG2": time.Date(2024, 7, 4, 0, 0, 0, 0, time.UTC)}
+
+	irrigation := NewActivityTemplate()
+	irrigation.CropStageID = "CSTG1"
+	irrigation.OffsetDays = 21
+	plannedAt, ok := irrigation.PlannedAt(sowing, starts)
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2024, 7, 11, 0, 0, 0, 0, time.UTC), plannedAt)
+
+	weeding := NewActivityTemplate()
+	weeding.CropStageID = "CSTG2"
+	weeding.OffsetAnchor = OffsetAnchorStageStart
+	weeding.OffsetDays = 5
+	plannedAt, ok = weeding.PlannedAt(sowing, starts)
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2024, 7, 9, 0, 0, 0, 0, time.UTC), plannedAt)
+
+	weeding.CropStageID = "CSTG5"
+	_, ok = weeding.PlannedAt(sowing, starts)
+	assert.False(t, ok)
+}
+
+func TestActivityTemplateValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(t *ActivityTemplate)
+		wantErr bool
+	}{
+		{"valid template", func(t *ActivityTemplate) {}, false},
+		{"missing crop stage", func(t *ActivityTemplate) { t.CropStageID = "" }, true},
+		{"missing name", func(t *ActivityTemplate) { t.Name = "" }, true},
+		{"missing activity type", func(t *ActivityTemplate) { t.ActivityType = "" }, true},
+		{"negative offset", func(t *ActivityTemplate) { t.OffsetDays = -1 }, true},
+		{"unknown anchor", func(t *ActivityTemplate) { t.OffsetAnchor = "HARVEST" }, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template := NewActivityTemplate()
+			template.CropStageID = "CSTG1"
+			template.Name = "First irrigation"
+			template.ActivityType = "IRRIGATION"
+			template.OffsetDays = 21
+			tt.modify(template)
+			if tt.wantErr {
+				assert.Error(t, template.Validate())
+			} else {
+				assert.NoError(t, template.Validate())
+			}
+		})
+	}
+}
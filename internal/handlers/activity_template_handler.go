@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/requests"
+	"github.com/Kisanlink/kisanlink-db/pkg/base"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// CreateActivityTemplate handles POST /api/v1/crops/:crop_id/stages/:stage_id/activity-templates
+// @Summary Add an activity template to a crop stage
+// @Description Add an activity that is planned for every crop cycle of the crop started with generate_activities, such as the first irrigation 21 days after sowing. The offset is counted from sowing or from the start of the stage; stage starts follow from the durations of the stages before it.
+// @Tags Crop Stages
+// @Accept json
+// @Produce json
+// @Param id path string true "Crop ID"
+// @Param stage_id path string true "Stage ID"
+// @Param template body requests.ActivityTemplateRequest true "Activity template details"
+// @Success 201 {object} responses.ActivityTemplateResponse
+// @Failure 400 {object} github_com_Kisanlink_farmers-module_internal_entities_responses.SwaggerErrorResponse
+// @Failure 401 {object} github_com_Kisanlink_farmers-module_internal_entities_responses.SwaggerErrorResponse
+// @Failure 403 {object} github_com_Kisanlink_farmers-module_internal_entities_responses.SwaggerErrorResponse
+// @Failure 404 {object} github_com_Kisanlink_farmers-module_internal_entities_responses.SwaggerErrorResponse
+// @Failure 500 {object} github_com_Kisanlink_farmers-module_internal_entities_responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/crops/{id}/stages/{stage_id}/activity-templates [post]
+func (h *StageHandler) CreateActivityTemplate(c *gin.Context) {
+	cropID := c.Param("id")
+	stageID := c.Param("stage_id")
+	var req requests.CreateActivityTemplateRequest
+
+	h.logger.Info("Creating activity template",
+		zap.String("crop_id", cropID),
+		zap.String("stage_id", stageID))
+
+	if err := c.ShouldBindJSON(&req.ActivityTemplateRequest); err != nil {
+		h.logger.Error("Failed to bind request", zap.Error(err))
+		errorResp := base.NewErrorResponse("Invalid request format", base.NewValidationError("Invalid request format", err.Error()))
+		c.JSON(http.StatusBadRequest, errorResp)
+		return
+	}
+
+	// Set IDs from path parameters
+	req.CropID = cropID
+	req.StageID = stageID
+	req.UserID, req.OrgID = getUserContext(c)
+	req.RequestID = c.GetString("request_id")
+
+	response, err := h.stageService.CreateActivityTemplate(c.Request.Context(), &req)
+	if err != nil {
+		h.logger.Error("Failed to create activity template", zap.Error(err))
+		h.handleServiceError(c, err)
+		return
+	}
+
+	h.logger.Info("Activity template created successfully",
+		zap.String("crop_id", cropID),
+		zap.String("stage_id", stageID))
+	c.JSON(http.StatusCreated, response)
+}
+
+// ListActivityTemplates handles GET /api/v1/crops/:crop_id/stages/:stage_id/activity-templates
+// @Summary List the activity templates of a crop stage
+// @Tags Crop Stages
+// @Produce json
+// @Param id path string true "Crop ID"
+// @Param stage_id path string true "Stage ID"
+// @Success 200 {object} responses.ActivityTemplatesResponse
+// @Failure 401 {object} github_com_Kisanlink_farmers-module_internal_entities_responses.SwaggerErrorResponse
+// @Failure 403 {object} github_com_Kisanlink_farmers-module_internal_entities_responses.SwaggerErrorResponse
+// @Failure 404 {object} github_com_Kisanlink_farmers-module_internal_entities_responses.SwaggerErrorResponse
+// @Failure 500 {object} github_com_Kisanlink_farmers-module_internal_entities_responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/crops/{id}/stages/{stage_id}/activity-templates [get]
+func (h *StageHandler) ListActivityTemplates(c *gin.Context) {
+	userID, orgID := getUserContext(c)
+	req := &requests.ListActivityTemplatesRequest{
+		BaseRequest: requests.BaseRequest{
+			UserID:    userID,
+			OrgID:     orgID,
+			RequestID: c.GetString("request_id"),
+		},
+		CropID:  c.Param("id"),
+		StageID: c.Param("stage_id"),
+	}
+
+	response, err := h.stageService.ListActivityTemplates(c.Request.Context(), req)
+	if err != nil {
+		h.logger.Error("Failed to list activity templates", zap.Error(err))
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// UpdateActivityTemplate handles PUT /api/v1/crops/:crop_id/stages/:stage_id/activity-templates/:template_id
+// @Summary Update an activity template
+// @Description Replace the fields of an activity template. Activities already planned from it are not changed.
+// @Tags Crop Stages
+// @Accept json
+// @Produce json
+// @Param id path string true "Crop ID"
+// @Param stage_id path string true "Stage ID"
+// @Param template_id path string true "Activity template ID"
+// @Param template body requests.ActivityTemplateRequest true "Activity template details"
+// @Success 200 {object} responses.ActivityTemplateResponse
+// @Failure 400 {object} github_com_Kisanlink_farmers-module_internal_entities_responses.SwaggerErrorResponse
+// @Failure 401 {object} github_com_Kisanlink_farmers-module_internal_entities_responses.SwaggerErrorResponse
+// @Failure 403 {object} github_com_Kisanlink_farmers-module_internal_entities_responses.SwaggerErrorResponse
+// @Failure 404 {object} github_com_Kisanlink_farmers-module_internal_entities_responses.SwaggerErrorResponse
+// @Failure 500 {object} github_com_Kisanlink_farmers-module_internal_entities_responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/crops/{id}/stages/{stage_id}/activity-templates/{template_id} [put]
+func (h *StageHandler) UpdateActivityTemplate(c *gin.Context) {
+	templateID := c.Param("template_id")
+	var req requests.UpdateActivityTemplateRequest
+
+	h.logger.Info("Updating activity template", zap.String("template_id", templateID))
+
+	if err := c.ShouldBindJSON(&req.ActivityTemplateRequest); err != nil {
+		h.logger.Error("Failed to bind request", zap.Error(err))
+		errorResp := base.NewErrorResponse("Invalid request format", base.NewValidationError("Invalid request format", err.Error()))
+		c.JSON(http.StatusBadRequest, errorResp)
+		return
+	}
+
+	// Set IDs from path parameters
+	req.ID = templateID
+	req.CropID = c.Param("id")
+	req.StageID = c.Param("stage_id")
+	req.UserID, req.OrgID = getUserContext(c)
+	req.RequestID = c.GetString("request_id")
+
+	response, err := h.stageService.UpdateActivityTemplate(c.Request.Context(), &req)
+	if err != nil {
+		h.logger.Error("Failed to update activity template", zap.Error(err))
+		h.handleServiceError(c, err)
+		return
+	}
+
+	h.logger.Info("Activity template updated successfully", zap.String("template_id", templateID))
+	c.JSON(http.StatusOK, response)
+}
+
+// DeleteActivityTemplate handles DELETE /api/v1/crops/:crop_id/stages/:stage_id/activity-templates/:template_id
+// @Summary Delete an activity template
+// @Description Remove an activity template from a crop stage (soft delete). Activities already planned from it are kept.
+// @Tags Crop Stages
+// @Produce json
+// @Param id path string true "Crop ID"
+// @Param stage_id path string true "Stage ID"
+// @Param template_id path string true "Activity template ID"
+// @Success 200 {object} github_com_Kisanlink_farmers-module_internal_entities_responses.SwaggerBaseResponse
+// @Failure 401 {object} github_com_Kisanlink_farmers-module_internal_entities_responses.SwaggerErrorResponse
+// @Failure 403 {object} github_com_Kisanlink_farmers-module_internal_entities_responses.SwaggerErrorResponse
+// @Failure 404 {object} github_com_Kisanlink_farmers-module_internal_entities_responses.SwaggerErrorResponse
+// @Failure 500 {object} github_com_Kisanlink_farmers-module_internal_entities_responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /api/v1/crops/{id}/stages/{stage_id}/activity-templates/{template_id} [delete]
+func (h *StageHandler) DeleteActivityTemplate(c *gin.Context) {
+	templateID := c.Param("template_id")
+
+	h.logger.Info("Deleting activity template", zap.String("template_id", templateID))
+
+	userID, orgID := getUserContext(c)
+	req := &requests.DeleteActivityTemplateRequest{
+		BaseRequest: requests.BaseRequest{
+			UserID:    userID,
+			OrgID:     orgID,
+			RequestID: c.GetString("request_id"),
+		},
+		ID:      templateID,
+		CropID:  c.Param("id"),
+		StageID: c.Param("stage_id"),
+	}
+
+	response, err := h.stageService.DeleteActivityTemplate(c.Request.Context(), req)
+	if err != nil {
+		h.logger.Error("Failed to delete activity template", zap.Error(err))
+		h.handleServiceError(c, err)
+		return
+	}
+
+	h.logger.Info("Activity template deleted successfully", zap.String("template_id", templateID))
+	c.JSON(http.StatusOK, response)
+}
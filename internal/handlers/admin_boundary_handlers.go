@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/requests"
+	"github.com/Kisanlink/farmers-module/internal/entities/responses"
+	"github.com/Kisanlink/farmers-module/internal/services"
+	"github.com/Kisanlink/farmers-module/pkg/common"
+	"github.com/gin-gonic/gin"
+)
+
+// ImportAdminBoundaries handles uploads of administrative boundary master data
+// @Summary Import administrative boundaries
+// @Description Import the state, district, block or village polygons of one level from a GeoJSON FeatureCollection or a zipped Shapefile in WGS 84. Each feature's code and name, and optionally its parent's code, are read from the named properties. Boundaries already stored under the same level and code are replaced. Every farm is then tagged again with the codes of the areas its boundary lies in.
+// @Tags admin
+// @Accept multipart/form-data
+// @Produce json
+// @Param level formData string true "Administrative level" Enums(STATE, DISTRICT, BLOCK, VILLAGE)
+// @Param code_property formData string true "Feature property holding the area code"
+// @Param name_property formData string true "Feature property holding the area name"
+// @Param parent_code_property formData string false "Feature property holding the code of the enclosing area"
+// @Param input_format formData string false "Input format (geojson, shapefile); detected from the file extension when omitted"
+// @Param file formData file true "Boundary file; Shapefiles are uploaded as a zip archive with .shp, .dbf and .prj"
+// @Success 200 {object} responses.AdminBoundaryImportResponse
+// @Failure 400 {object} responses.SwaggerErrorResponse
+// @Failure 401 {object} responses.SwaggerErrorResponse
+// @Failure 403 {object} responses.SwaggerErrorResponse
+// @Failure 500 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /admin/boundaries/import [post]
+func ImportAdminBoundaries(service services.AdminBoundaryService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req := requests.NewImportAdminBoundariesRequest()
+		if err := parseAdminBoundaryUpload(c, &req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format: " + err.Error()})
+			return
+		}
+		req.SetUserContext(getUserContext(c))
+		req.RequestID = c.GetString("request_id")
+		if req.RequestID == "" {
+			req.RequestID = generateRequestID()
+		}
+
+		result, err := service.ImportAdminBoundaries(c.Request.Context(), &req)
+		if err != nil {
+			if errors.Is(err, common.ErrInvalidInput) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			handleServiceError(c, err)
+			return
+		}
+
+		response, ok := result.(*responses.AdminBoundaryImportResponse)
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "invalid response type"})
+			return
+		}
+
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// parseAdminBoundaryUpload reads a multipart boundary upload. The input format defaults to
+// the one implied by the file extension.
+func parseAdminBoundaryUpload(c *gin.Context, req *requests.ImportAdminBoundariesRequest) error {
+	if err := c.Request.ParseMultipartForm(100 << 20); // 100 MB max
+	err != nil {
+		return fmt.Errorf("failed to parse multipart form: %w", err)
+	}
+
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		return fmt.Errorf("failed to get file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	req.Level = c.PostForm("level")
+	req.CodeProperty = c.PostForm("code_property")
+	req.NameProperty = c.PostForm("name_property")
+	req.ParentCodeProperty = c.PostForm("parent_code_property")
+	req.InputFormat = c.PostForm("input_format")
+	if req.InputFormat == "" {
+		req.InputFormat = boundaryFormatFromFilename(header.Filename)
+	}
+	req.Data = data
+	return nil
+}
+
+// BackfillFarmAdminCodes handles tagging every farm with its administrative areas
+// @Summary Tag farms with administrative area codes
+// @Description Assign every farm with a boundary the state, district, block and village codes of the imported administrative boundaries containing it. Farms are located by a point guaranteed to lie on the farm. Farms are also tagged when they are created or their boundary changes, and after each boundary import.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} responses.FarmAdminCodesBackfillResponse
+// @Failure 401 {object} responses.SwaggerErrorResponse
+// @Failure 403 {object} responses.SwaggerErrorResponse
+// @Failure 500 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /admin/boundaries/backfill [post]
+func BackfillFarmAdminCodes(service services.AdminBoundaryService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req := requests.NewBackfillFarmAdminCodesRequest()
+		req.SetUserContext(getUserContext(c))
+		req.RequestID = c.GetString("request_id")
+		if req.RequestID == "" {
+			req.RequestID = generateRequestID()
+		}
+
+		result, err := service.BackfillFarmAdminCodes(c.Request.Context(), &req)
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		response, ok := result.(*responses.FarmAdminCodesBackfillResponse)
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "invalid response type"})
+			return
+		}
+
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// ListAdminBoundaries handles listing the administrative areas of a level
+// @Summary List administrative areas
+// @Description List the imported administrative areas of a level by code, optionally those within a parent area. The codes are the values of the state_code, district_code, block_code and village_code filters of farms, farmers and the organization dashboard.
+// @Tags lookups
+// @Produce json
+// @Param level query string true "Administrative level" Enums(STATE, DISTRICT, BLOCK, VILLAGE)
+// @Param parent_code query string false "Code of the enclosing area"
+// @Success 200 {object} responses.AdminBoundaryListResponse
+// @Failure 400 {object} responses.SwaggerErrorResponse
+// @Failure 500 {object} responses.SwaggerErrorResponse
+// @Router /lookups/admin-boundaries [get]
+func ListAdminBoundaries(service services.AdminBoundaryService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req := requests.NewListAdminBoundariesRequest()
+		req.Level = c.Query("level")
+		req.ParentCode = c.Query("parent_code")
+		req.RequestID = c.GetString("request_id")
+
+		result, err := service.ListAdminBoundaries(c.Request.Context(), &req)
+		if err != nil {
+			if errors.Is(err, common.ErrInvalidInput) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			handleServiceError(c, err)
+			return
+		}
+
+		response, ok := result.(*responses.AdminBoundaryListResponse)
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "invalid response type"})
+			return
+		}
+
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// parseAdminAreaQuery reads the administrative area filters from the query string
+func parseAdminAreaQuery(c *gin.Context) requests.AdminAreaFilter {
+	return requests.AdminAreaFilter{
+		StateCode:    c.Query("state_code"),
+		DistrictCode: c.Query("district_code"),
+		BlockCode:    c.Query("block_code"),
+		VillageCode:  c.Query("village_code"),
+	}
+}
@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/requests"
+	"github.com/Kisanlink/farmers-module/internal/entities/responses"
+	"github.com/Kisanlink/farmers-module/internal/interfaces"
+	"github.com/Kisanlink/farmers-module/internal/services"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// BulkCropCycleImportHandler handles bulk crop cycle import HTTP requests
+type BulkCropCycleImportHandler struct {
+	importService services.BulkCropCycleImportService
+	aaaService    services.AAAService
+	logger        interfaces.Logger
+}
+
+// NewBulkCropCycleImportHandler creates a new bulk crop cycle import handler
+func NewBulkCropCycleImportHandler(importService services.BulkCropCycleImportService, aaaService services.AAAService, logger interfaces.Logger) *BulkCropCycleImportHandler {
+	return &BulkCropCycleImportHandler{
+		importService: importService,
+		aaaService:    aaaService,
+		logger:        logger,
+	}
+}
+
+// ImportCropCycles handles bulk creation of crop cycles and farm activities from a spreadsheet
+// @Summary Bulk import crop cycles and activities
+// @Description Create crop cycles, and optionally farm activities, from a CSV or Excel sowing survey with farm_id, crop, variety, season, area_ha and sowing_date columns. Crop and variety names are resolved against the crop master data and every new cycle must fit in its farm's unallocated area. Rows with the same farm, crop, season and sowing date share one crop cycle. Per-row results are available through the bulk operation status and result endpoints, and failed rows can be retried.
+// @Tags Bulk Operations
+// @Accept multipart/form-data
+// @Accept json
+// @Produce json
+// @Param fpo_org_id formData string true "FPO Organization ID"
+// @Param input_format formData string false "Input format (csv, excel); detected from the file extension when omitted"
+// @Param file formData file true "Sowing survey spreadsheet"
+// @Param options formData string false "Import options as JSON string"
+// @Success 202 {object} responses.BulkOperationResponse
+// @Failure 400 {object} responses.SwaggerErrorResponse
+// @Failure 401 {object} responses.SwaggerErrorResponse
+// @Failure 403 {object} responses.SwaggerErrorResponse
+// @Failure 500 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /bulk/crop-cycles/import [post]
+func (h *BulkCropCycleImportHandler) ImportCropCycles(c *gin.Context) {
+	var req requests.BulkCropCycleImportRequest
+	if strings.Contains(c.ContentType(), "multipart/form-data") {
+		if err := h.parseMultipartRequest(c, &req); err != nil {
+			h.logger.Error("Invalid crop cycle import request", zap.Error(err))
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format: " + err.Error()})
+			return
+		}
+	} else if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid crop cycle import request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	req.RequestID = c.GetString("request_id")
+	req.UserID = c.GetString("aaa_subject")
+	req.OrgID = c.GetString("aaa_org")
+	req.Timestamp = time.Now()
+
+	if req.FPOOrgID == "" || req.InputFormat == "" || len(req.Data) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing required fields"})
+		return
+	}
+
+	// Resource: cycle, Action: bulk_create
+	hasPermission, err := h.aaaService.CheckPermission(
+		c.Request.Context(),
+		req.UserID,
+		"cycle",
+		"bulk_create",
+		req.FPOOrgID,
+		req.OrgID,
+	)
+	if err != nil {
+		h.logger.Error("Failed to check permission for crop cycle import",
+			zap.String("request_id", req.RequestID),
+			zap.String("user_id", req.UserID),
+			zap.String("fpo_org_id", req.FPOOrgID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":      "Failed to verify permissions",
+			"request_id": req.RequestID,
+		})
+		return
+	}
+	if !hasPermission {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":      "Insufficient permissions to import crop cycles",
+			"request_id": req.RequestID,
+		})
+		return
+	}
+
+	h.logger.Info("Starting bulk crop cycle import",
+		zap.String("request_id", req.RequestID),
+		zap.String("fpo_org_id", req.FPOOrgID),
+		zap.String("input_format", req.InputFormat),
+		zap.Int("data_size", len(req.Data)),
+	)
+
+	result, err := h.importService.ImportCropCycles(c.Request.Context(), &req)
+	if err != nil {
+		h.logger.Error("Failed to initiate crop cycle import",
+			zap.String("request_id", req.RequestID),
+			zap.Error(err),
+		)
+		if strings.Contains(err.Error(), "failed to parse") || strings.Contains(err.Error(), "unsupported") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		handleServiceError(c, err)
+		return
+	}
+
+	response := responses.NewBulkOperationResponse(result, "Crop cycle import initiated successfully")
+	response.RequestID = req.RequestID
+
+	c.JSON(http.StatusAccepted, response)
+}
+
+// parseMultipartRequest reads a multipart crop cycle import request. The input format
+// defaults to the one implied by the file extension.
+func (h *BulkCropCycleImportHandler) parseMultipartRequest(c *gin.Context, req *requests.BulkCropCycleImportRequest) error {
+	if err := c.Request.ParseMultipartForm(50 << 20); // 50 MB max
+	err != nil {
+		return fmt.Errorf("failed to parse multipart form: %w", err)
+	}
+
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		return fmt.Errorf("failed to get file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	req.FPOOrgID = c.PostForm("fpo_org_id")
+	req.InputFormat = c.PostForm("input_format")
+	if req.InputFormat == "" {
+		req.InputFormat = spreadsheetFormatFromFilename(header.Filename)
+	}
+	req.Data = data
+
+	if optionsStr := c.PostForm("options"); optionsStr != "" {
+		if err := json.Unmarshal([]byte(optionsStr), &req.Options); err != nil {
+			return fmt.Errorf("invalid options: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func spreadsheetFormatFromFilename(filename string) string {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return "csv"
+	case ".xlsx":
+		return "excel"
+	default:
+		return ""
+	}
+}
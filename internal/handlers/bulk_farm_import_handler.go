@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/requests"
+	"github.com/Kisanlink/farmers-module/internal/entities/responses"
+	"github.com/Kisanlink/farmers-module/internal/interfaces"
+	"github.com/Kisanlink/farmers-module/internal/services"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// BulkFarmImportHandler handles bulk farm import HTTP requests
+type BulkFarmImportHandler struct {
+	importService services.BulkFarmImportService
+	aaaService    services.AAAService
+	logger        interfaces.Logger
+}
+
+// NewBulkFarmImportHandler creates a new bulk farm import handler
+func NewBulkFarmImportHandler(importService services.BulkFarmImportService, aaaService services.AAAService, logger interfaces.Logger) *BulkFarmImportHandler {
+	return &BulkFarmImportHandler{
+		importService: importService,
+		aaaService:    aaaService,
+		logger:        logger,
+	}
+}
+
+// ImportFarms handles bulk creation of farms from a boundary file
+// @Summary Bulk import farms with boundaries
+// @Description Create farms from a GeoJSON FeatureCollection, a KML/KMZ file or a zipped Shapefile. Each feature is matched to an FPO farmer by its phone or aaa_user_id property. Progress and per-feature results are available through the bulk operation status and result endpoints.
+// @Tags Bulk Operations
+// @Accept multipart/form-data
+// @Accept json
+// @Produce json
+// @Param fpo_org_id formData string true "FPO Organization ID"
+// @Param input_format formData string false "Input format (geojson, kml, shapefile); detected from the file extension when omitted"
+// @Param file formData file true "Boundary file; Shapefiles are uploaded as a zip archive with .shp, .dbf and .prj"
+// @Param options formData string false "Import options as JSON string"
+// @Success 202 {object} responses.BulkOperationResponse
+// @Failure 400 {object} responses.SwaggerErrorResponse
+// @Failure 401 {object} responses.SwaggerErrorResponse
+// @Failure 403 {object} responses.SwaggerErrorResponse
+// @Failure 500 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /bulk/farms/import [post]
+func (h *BulkFarmImportHandler) ImportFarms(c *gin.Context) {
+	var req requests.BulkFarmImportRequest
+	if strings.Contains(c.ContentType(), "multipart/form-data") {
+		if err := h.parseMultipartRequest(c, &req); err != nil {
+			h.logger.Error("Invalid farm import request", zap.Error(err))
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format: " + err.Error()})
+			return
+		}
+	} else if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid farm import request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	req.RequestID = c.GetString("request_id")
+	req.UserID = c.GetString("aaa_subject")
+	req.OrgID = c.GetString("aaa_org")
+	req.Timestamp = time.Now()
+
+	if req.FPOOrgID == "" || req.InputFormat == "" || len(req.Data) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing required fields"})
+		return
+	}
+
+	// Resource: farm, Action: bulk_create
+	hasPermission, err := h.aaaService.CheckPermission(
+		c.Request.Context(),
+		req.UserID,
+		"farm",
+		"bulk_create",
+		req.FPOOrgID,
+		req.OrgID,
+	)
+	if err != nil {
+		h.logger.Error("Failed to check permission for farm import",
+			zap.String("request_id", req.RequestID),
+			zap.String("user_id", req.UserID),
+			zap.String("fpo_org_id", req.FPOOrgID),
+			zap.Error(err),
+		)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":      "Failed to verify permissions",
+			"request_id": req.RequestID,
+		})
+		return
+	}
+	if !hasPermission {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":      "Insufficient permissions to import farms",
+			"request_id": req.RequestID,
+		})
+		return
+	}
+
+	h.logger.Info("Starting bulk farm import",
+		zap.String("request_id", req.RequestID),
+		zap.String("fpo_org_id", req.FPOOrgID),
+		zap.String("input_format", req.InputFormat),
+		zap.Int("data_size", len(req.Data)),
+	)
+
+	result, err := h.importService.ImportFarms(c.Request.Context(), &req)
+	if err != nil {
+		h.logger.Error("Failed to initiate farm import",
+			zap.String("request_id", req.RequestID),
+			zap.Error(err),
+		)
+		if strings.Contains(err.Error(), "failed to parse") || strings.Contains(err.Error(), "unsupported") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		handleServiceError(c, err)
+		return
+	}
+
+	response := responses.NewBulkOperationResponse(result, "Farm import initiated successfully")
+	response.RequestID = req.RequestID
+
+	c.JSON(http.StatusAccepted, response)
+}
+
+// parseMultipartRequest reads a multipart farm import request. The input format defaults to
+// the one implied by the file extension.
+func (h *BulkFarmImportHandler) parseMultipartRequest(c *gin.Context, req *requests.BulkFarmImportRequest) error {
+	if err := c.Request.ParseMultipartForm(50 << 20); // 50 MB max
+	err != nil {
+		return fmt.Errorf("failed to parse multipart form: %w", err)
+	}
+
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		return fmt.Errorf("failed to get file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	req.FPOOrgID = c.PostForm("fpo_org_id")
+	req.InputFormat = c.PostForm("input_format")
+	if req.InputFormat == "" {
+		req.InputFormat = boundaryFormatFromFilename(header.Filename)
+	}
+	req.Data = data
+
+	if optionsStr := c.PostForm("options"); optionsStr != "" {
+		if err := json.Unmarshal([]byte(optionsStr), &req.Options); err != nil {
+			return fmt.Errorf("invalid options: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func boundaryFormatFromFilename(filename string) string {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".geojson", ".json":
+		return "geojson"
+	case ".kml", ".kmz":
+		return "kml"
+	case ".zip":
+		return "shapefile"
+	default:
+		return ""
+	}
+}
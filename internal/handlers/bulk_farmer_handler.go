@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -43,6 +44,7 @@ func NewBulkFarmerHandler(bulkService services.BulkFarmerService, aaaService ser
 // @Param processing_mode formData string true "Processing mode (sync, async, batch)"
 // @Param file formData file true "File containing farmer data"
 // @Param options formData string false "Processing options as JSON string"
+// @Param mapping_profile_id formData string false "Column mapping profile for files that do not use the template headers"
 // @Success 202 {object} responses.BulkOperationResponse
 // @Success 200 {object} responses.BulkOperationResponse "For synchronous operations"
 // @Failure 400 {object} responses.SwaggerErrorResponse
@@ -127,12 +129,16 @@ func (h *BulkFarmerHandler) BulkAddFarmers(c *gin.Context) {
 		return
 	}
 
+	dataSize := int64(len(req.Data))
+	if req.File != nil {
+		dataSize = req.File.Size
+	}
 	h.logger.Info("Starting bulk farmer addition",
 		zap.String("request_id", req.RequestID),
 		zap.String("fpo_org_id", req.FPOOrgID),
 		zap.String("input_format", req.InputFormat),
 		zap.String("processing_mode", req.ProcessingMode),
-		zap.Int("data_size", len(req.Data)),
+		zap.Int64("data_size", dataSize),
 	)
 
 	// Call service
@@ -280,6 +286,110 @@ func (h *BulkFarmerHandler) CancelBulkOperation(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// PauseBulkOperation pauses a bulk operation
+// @Summary Pause bulk operation
+// @Description Pause a pending or in-progress bulk operation. Processing stops after the record in flight; the remaining records stay pending until the operation is resumed
+// @Tags Bulk Operations
+// @Produce json
+// @Param operation_id path string true "Operation ID"
+// @Success 200 {object} responses.SwaggerBaseResponse
+// @Failure 400 {object} responses.SwaggerErrorResponse
+// @Failure 404 {object} responses.SwaggerErrorResponse
+// @Failure 500 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /bulk/pause/{operation_id} [post]
+func (h *BulkFarmerHandler) PauseBulkOperation(c *gin.Context) {
+	operationID := c.Param("operation_id")
+	if operationID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Operation ID is required"})
+		return
+	}
+
+	h.logger.Info("Pausing bulk operation",
+		zap.String("operation_id", operationID),
+		zap.String("user_id", c.GetString("aaa_subject")),
+	)
+
+	if err := h.bulkService.PauseBulkOperation(c.Request.Context(), operationID); err != nil {
+		h.logger.Error("Failed to pause operation",
+			zap.String("operation_id", operationID),
+			zap.Error(err),
+		)
+
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Operation not found"})
+			return
+		}
+
+		if strings.Contains(err.Error(), "cannot be paused") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		handleServiceError(c, err)
+		return
+	}
+
+	response := &responses.BaseResponse{
+		Success:   true,
+		Message:   "Operation paused successfully",
+		RequestID: c.GetString("request_id"),
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ResumeBulkOperation resumes a paused bulk operation
+// @Summary Resume bulk operation
+// @Description Resume a paused bulk operation from its remaining pending records
+// @Tags Bulk Operations
+// @Produce json
+// @Param operation_id path string true "Operation ID"
+// @Success 202 {object} responses.BulkOperationResponse
+// @Failure 400 {object} responses.SwaggerErrorResponse
+// @Failure 404 {object} responses.SwaggerErrorResponse
+// @Failure 500 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /bulk/resume/{operation_id} [post]
+func (h *BulkFarmerHandler) ResumeBulkOperation(c *gin.Context) {
+	operationID := c.Param("operation_id")
+	if operationID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Operation ID is required"})
+		return
+	}
+
+	h.logger.Info("Resuming bulk operation",
+		zap.String("operation_id", operationID),
+		zap.String("user_id", c.GetString("aaa_subject")),
+	)
+
+	result, err := h.bulkService.ResumeBulkOperation(c.Request.Context(), operationID)
+	if err != nil {
+		h.logger.Error("Failed to resume operation",
+			zap.String("operation_id", operationID),
+			zap.Error(err),
+		)
+
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Operation not found"})
+			return
+		}
+
+		if strings.Contains(err.Error(), "cannot be resumed") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		handleServiceError(c, err)
+		return
+	}
+
+	response := responses.NewBulkOperationResponse(result, "Operation resumed successfully")
+	response.RequestID = c.GetString("request_id")
+
+	c.JSON(http.StatusAccepted, response)
+}
+
 // RetryFailedRecords retries failed records from a bulk operation
 // @Summary Retry failed records
 // @Description Retry processing of failed records from a bulk operation
@@ -404,13 +514,14 @@ func (h *BulkFarmerHandler) GetBulkUploadTemplate(c *gin.Context) {
 
 // DownloadBulkResults downloads the results of a bulk operation
 // @Summary Download bulk operation results
-// @Description Download a file containing the results of a bulk operation
+// @Description Download a file with every input row of a bulk operation and its outcome (status, aaa_user_id, farmer_id, error). The file uses the upload format, so failed rows can be fixed and uploaded again
 // @Tags Bulk Operations
 // @Produce octet-stream
 // @Param operation_id path string true "Operation ID"
-// @Param format query string false "Output format (csv, excel, json)"
-// @Param include_all query bool false "Include all records or just failures"
+// @Param format query string false "Output format (csv, excel, json)" default(csv)
+// @Param include_all query bool false "Include all records (true) or just failures (false)" default(true)
 // @Success 200 {file} file
+// @Failure 400 {object} responses.SwaggerErrorResponse
 // @Failure 404 {object} responses.SwaggerErrorResponse
 // @Failure 500 {object} responses.SwaggerErrorResponse
 // @Router /bulk/results/{operation_id} [get]
@@ -425,14 +536,29 @@ func (h *BulkFarmerHandler) DownloadBulkResults(c *gin.Context) {
 	if format == "" {
 		format = "csv"
 	}
+	if format != "csv" && format != "excel" && format != "json" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Format must be one of: csv, excel, json"})
+		return
+	}
+
+	includeAll := true
+	if raw := c.Query("include_all"); raw != "" {
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "include_all must be a boolean"})
+			return
+		}
+		includeAll = parsed
+	}
 
 	h.logger.Info("Downloading bulk operation results",
 		zap.String("operation_id", operationID),
 		zap.String("format", format),
+		zap.Bool("include_all", includeAll),
 	)
 
 	// Generate result file
-	resultData, err := h.bulkService.GenerateResultFile(c.Request.Context(), operationID, format)
+	resultData, err := h.bulkService.GenerateResultFile(c.Request.Context(), operationID, format, includeAll)
 	if err != nil {
 		h.logger.Error("Failed to generate result file",
 			zap.String("operation_id", operationID),
@@ -469,11 +595,16 @@ func (h *BulkFarmerHandler) DownloadBulkResults(c *gin.Context) {
 
 // ValidateBulkData validates bulk farmer data without processing
 // @Summary Validate bulk data
-// @Description Validate farmer data without actually processing it
+// @Description Dry run of a bulk upload: parses the file, runs validation and duplicate checks (within the file and against the FPO) and returns a per-record report without creating anything
 // @Tags Bulk Operations
-// @Accept json
+// @Accept json,multipart/form-data
 // @Produce json
-// @Param request body requests.ValidateBulkDataRequest true "Validation request"
+// @Param request body requests.ValidateBulkDataRequest false "Validation request (JSON)"
+// @Param fpo_org_id formData string false "FPO Organization ID (multipart)"
+// @Param input_format formData string false "Input format (csv, excel, json) (multipart)"
+// @Param deduplication_mode formData string false "Deduplication mode (skip, update, error) (multipart)"
+// @Param mapping_profile_id formData string false "Column mapping profile ID (multipart)"
+// @Param file formData file false "File containing farmer data (multipart)"
 // @Success 200 {object} responses.BulkValidationResponse
 // @Failure 400 {object} responses.SwaggerErrorResponse
 // @Failure 500 {object} responses.SwaggerErrorResponse
@@ -481,12 +612,29 @@ func (h *BulkFarmerHandler) DownloadBulkResults(c *gin.Context) {
 // @Router /bulk/validate [post]
 func (h *BulkFarmerHandler) ValidateBulkData(c *gin.Context) {
 	var req requests.ValidateBulkDataRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if strings.Contains(c.ContentType(), "multipart/form-data") {
+		data, err := h.readMultipartFile(c)
+		if err != nil {
+			h.logger.Error("Invalid validation request", zap.Error(err))
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format: " + err.Error()})
+			return
+		}
+		req.FPOOrgID = c.PostForm("fpo_org_id")
+		req.InputFormat = c.PostForm("input_format")
+		req.DeduplicationMode = c.PostForm("deduplication_mode")
+		req.MappingProfileID = c.PostForm("mapping_profile_id")
+		req.Data = data
+	} else if err := c.ShouldBindJSON(&req); err != nil {
 		h.logger.Error("Invalid validation request", zap.Error(err))
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
 		return
 	}
 
+	if req.FPOOrgID == "" || (len(req.Data) > 0 && req.InputFormat == "") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing required fields"})
+		return
+	}
+
 	req.RequestID = c.GetString("request_id")
 	req.UserID = c.GetString("aaa_subject")
 	req.OrgID = c.GetString("aaa_org")
@@ -516,12 +664,283 @@ func (h *BulkFarmerHandler) ValidateBulkData(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// CreateMappingProfile saves a column mapping profile for an FPO
+// @Summary Create column mapping profile
+// @Description Save a per-FPO profile that maps the columns of the FPO's own spreadsheets to farmer fields, with optional transforms (split_name, concat, default, lookup)
+// @Tags Bulk Operations
+// @Accept json
+// @Produce json
+// @Param request body requests.ColumnMappingProfileRequest true "Mapping profile"
+// @Success 201 {object} responses.ColumnMappingProfileResponse
+// @Failure 400 {object} responses.SwaggerErrorResponse
+// @Failure 403 {object} responses.SwaggerErrorResponse "FPO is not the caller's organization"
+// @Failure 409 {object} responses.SwaggerErrorResponse "Profile name already used by the FPO"
+// @Failure 500 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /bulk/mapping-profiles [post]
+func (h *BulkFarmerHandler) CreateMappingProfile(c *gin.Context) {
+	var req requests.ColumnMappingProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid mapping profile request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	req.RequestID = c.GetString("request_id")
+	req.UserID = c.GetString("aaa_subject")
+	req.OrgID = c.GetString("aaa_org")
+	req.Timestamp = time.Now()
+
+	result, err := h.bulkService.CreateMappingProfile(c.Request.Context(), &req)
+	if err != nil {
+		h.logger.Error("Failed to create mapping profile",
+			zap.String("request_id", req.RequestID),
+			zap.String("fpo_org_id", req.FPOOrgID),
+			zap.Error(err),
+		)
+		h.handleMappingProfileError(c, err)
+		return
+	}
+
+	response := responses.NewColumnMappingProfileResponse(result, "Mapping profile created successfully")
+	response.RequestID = req.RequestID
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// ListMappingProfiles lists the column mapping profiles of an FPO
+// @Summary List column mapping profiles
+// @Description List the column mapping profiles saved for an FPO
+// @Tags Bulk Operations
+// @Produce json
+// @Param fpo_org_id query string true "FPO Organization ID"
+// @Success 200 {object} responses.ColumnMappingProfileListResponse
+// @Failure 400 {object} responses.SwaggerErrorResponse
+// @Failure 403 {object} responses.SwaggerErrorResponse "FPO is not the caller's organization"
+// @Failure 500 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /bulk/mapping-profiles [get]
+func (h *BulkFarmerHandler) ListMappingProfiles(c *gin.Context) {
+	fpoOrgID := c.Query("fpo_org_id")
+	if fpoOrgID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "fpo_org_id is required"})
+		return
+	}
+
+	result, err := h.bulkService.ListMappingProfiles(c.Request.Context(), fpoOrgID, c.GetString("aaa_org"))
+	if err != nil {
+		h.logger.Error("Failed to list mapping profiles",
+			zap.String("fpo_org_id", fpoOrgID),
+			zap.Error(err),
+		)
+		handleServiceError(c, err)
+		return
+	}
+
+	response := responses.NewColumnMappingProfileListResponse(result)
+	response.RequestID = c.GetString("request_id")
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetMappingProfile retrieves a column mapping profile
+// @Summary Get column mapping profile
+// @Description Get a saved column mapping profile of the caller's organization
+// @Tags Bulk Operations
+// @Produce json
+// @Param profile_id path string true "Mapping profile ID"
+// @Success 200 {object} responses.ColumnMappingProfileResponse
+// @Failure 404 {object} responses.SwaggerErrorResponse
+// @Failure 500 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /bulk/mapping-profiles/{profile_id} [get]
+func (h *BulkFarmerHandler) GetMappingProfile(c *gin.Context) {
+	profileID := c.Param("profile_id")
+	orgID := c.GetString("aaa_org")
+
+	result, err := h.bulkService.GetMappingProfile(c.Request.Context(), profileID, orgID)
+	if err != nil {
+		h.logger.Error("Failed to get mapping profile",
+			zap.String("profile_id", profileID),
+			zap.Error(err),
+		)
+		h.handleMappingProfileError(c, err)
+		return
+	}
+
+	response := responses.NewColumnMappingProfileResponse(result, "Mapping profile retrieved successfully")
+	response.RequestID = c.GetString("request_id")
+
+	c.JSON(http.StatusOK, response)
+}
+
+// UpdateMappingProfile replaces a column mapping profile
+// @Summary Update column mapping profile
+// @Description Replace the name, description and mappings of a saved column mapping profile of the caller's organization
+// @Tags Bulk Operations
+// @Accept json
+// @Produce json
+// @Param profile_id path string true "Mapping profile ID"
+// @Param request body requests.ColumnMappingProfileRequest true "Mapping profile"
+// @Success 200 {object} responses.ColumnMappingProfileResponse
+// @Failure 400 {object} responses.SwaggerErrorResponse
+// @Failure 404 {object} responses.SwaggerErrorResponse
+// @Failure 409 {object} responses.SwaggerErrorResponse "Profile name already used by the FPO"
+// @Failure 500 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /bulk/mapping-profiles/{profile_id} [put]
+func (h *BulkFarmerHandler) UpdateMappingProfile(c *gin.Context) {
+	var req requests.ColumnMappingProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid mapping profile request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	req.ProfileID = c.Param("profile_id")
+	req.RequestID = c.GetString("request_id")
+	req.UserID = c.GetString("aaa_subject")
+	req.OrgID = c.GetString("aaa_org")
+	req.Timestamp = time.Now()
+
+	result, err := h.bulkService.UpdateMappingProfile(c.Request.Context(), &req)
+	if err != nil {
+		h.logger.Error("Failed to update mapping profile",
+			zap.String("profile_id", req.ProfileID),
+			zap.Error(err),
+		)
+		h.handleMappingProfileError(c, err)
+		return
+	}
+
+	response := responses.NewColumnMappingProfileResponse(result, "Mapping profile updated successfully")
+	response.RequestID = req.RequestID
+
+	c.JSON(http.StatusOK, response)
+}
+
+// DeleteMappingProfile deletes a column mapping profile
+// @Summary Delete column mapping profile
+// @Description Delete a saved column mapping profile of the caller's organization
+// @Tags Bulk Operations
+// @Produce json
+// @Param profile_id path string true "Mapping profile ID"
+// @Success 200 {object} responses.SwaggerBaseResponse
+// @Failure 404 {object} responses.SwaggerErrorResponse
+// @Failure 500 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /bulk/mapping-profiles/{profile_id} [delete]
+func (h *BulkFarmerHandler) DeleteMappingProfile(c *gin.Context) {
+	profileID := c.Param("profile_id")
+	orgID := c.GetString("aaa_org")
+
+	if err := h.bulkService.DeleteMappingProfile(c.Request.Context(), profileID, orgID); err != nil {
+		h.logger.Error("Failed to delete mapping profile",
+			zap.String("profile_id", profileID),
+			zap.Error(err),
+		)
+		h.handleMappingProfileError(c, err)
+		return
+	}
+
+	response := &responses.BaseResponse{
+		Success:   true,
+		Message:   "Mapping profile deleted successfully",
+		RequestID: c.GetString("request_id"),
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// PreviewColumnMapping shows how an uploaded file is mapped to farmer records
+// @Summary Preview column mapping
+// @Description Map the first records of a file with a saved profile or with inline mappings and show the resulting farmer records, their validation errors and the source columns left unmapped. Accepts JSON or multipart form data.
+// @Tags Bulk Operations
+// @Accept json
+// @Accept multipart/form-data
+// @Produce json
+// @Param request body requests.PreviewColumnMappingRequest false "Preview request (JSON)"
+// @Param fpo_org_id formData string false "FPO Organization ID (multipart)"
+// @Param input_format formData string false "Input format (csv, excel, json) (multipart)"
+// @Param profile_id formData string false "Mapping profile ID (multipart)"
+// @Param limit formData int false "Number of records to preview (multipart)"
+// @Param file formData file false "File to preview (multipart)"
+// @Success 200 {object} responses.ColumnMappingPreviewResponse
+// @Failure 400 {object} responses.SwaggerErrorResponse
+// @Failure 404 {object} responses.SwaggerErrorResponse
+// @Failure 500 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /bulk/mapping-profiles/preview [post]
+func (h *BulkFarmerHandler) PreviewColumnMapping(c *gin.Context) {
+	var req requests.PreviewColumnMappingRequest
+	if strings.Contains(c.ContentType(), "multipart/form-data") {
+		data, err := h.readMultipartFile(c)
+		if err != nil {
+			h.logger.Error("Invalid preview request", zap.Error(err))
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format: " + err.Error()})
+			return
+		}
+		req.FPOOrgID = c.PostForm("fpo_org_id")
+		req.InputFormat = c.PostForm("input_format")
+		req.ProfileID = c.PostForm("profile_id")
+		req.Limit, _ = strconv.Atoi(c.PostForm("limit"))
+		req.Data = data
+	} else if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid preview request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	if req.FPOOrgID == "" || req.InputFormat == "" || len(req.Data) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing required fields"})
+		return
+	}
+	if req.ProfileID == "" && len(req.Mappings) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Either profile_id or mappings is required"})
+		return
+	}
+
+	req.RequestID = c.GetString("request_id")
+	req.UserID = c.GetString("aaa_subject")
+	req.OrgID = c.GetString("aaa_org")
+	req.Timestamp = time.Now()
+
+	result, err := h.bulkService.PreviewColumnMapping(c.Request.Context(), &req)
+	if err != nil {
+		h.logger.Error("Failed to preview column mapping",
+			zap.String("request_id", req.RequestID),
+			zap.String("profile_id", req.ProfileID),
+			zap.Error(err),
+		)
+		if strings.Contains(err.Error(), "failed to parse") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		h.handleMappingProfileError(c, err)
+		return
+	}
+
+	response := responses.NewColumnMappingPreviewResponse(result)
+	response.RequestID = req.RequestID
+
+	c.JSON(http.StatusOK, response)
+}
+
+// handleMappingProfileError reports invalid profiles as bad requests and falls back to the
+// common service error handling
+func (h *BulkFarmerHandler) handleMappingProfileError(c *gin.Context, err error) {
+	if strings.Contains(err.Error(), "invalid mapping profile") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	handleServiceError(c, err)
+}
+
 // Helper methods
 
 func (h *BulkFarmerHandler) parseMultipartRequest(c *gin.Context) (*requests.BulkFarmerAdditionRequest, error) {
-	// Parse multipart form
-	if err := c.Request.ParseMultipartForm(50 << 20); // 50 MB max
-	err != nil {
+	// Parse multipart form; files above 1 MB are kept on disk rather than in memory
+	if err := c.Request.ParseMultipartForm(1 << 20); err != nil {
 		return nil, fmt.Errorf("failed to parse multipart form: %w", err)
 	}
 
@@ -531,6 +950,7 @@ func (h *BulkFarmerHandler) parseMultipartRequest(c *gin.Context) (*requests.Bul
 	req.FPOOrgID = c.PostForm("fpo_org_id")
 	req.InputFormat = c.PostForm("input_format")
 	req.ProcessingMode = c.PostForm("processing_mode")
+	req.MappingProfileID = c.PostForm("mapping_profile_id")
 
 	// Parse options if provided
 	optionsStr := c.PostForm("options")
@@ -541,6 +961,29 @@ func (h *BulkFarmerHandler) parseMultipartRequest(c *gin.Context) (*requests.Bul
 		}
 	}
 
+	// The file is handed over unread, so that large uploads can be streamed
+	file, err := c.FormFile("file")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file: %w", err)
+	}
+	h.logger.Debug("Parsed multipart request",
+		zap.String("filename", file.Filename),
+		zap.Int64("size", file.Size),
+		zap.String("content_type", file.Header.Get("Content-Type")),
+	)
+
+	req.File = file
+
+	return &req, nil
+}
+
+// readMultipartFile reads the uploaded "file" field of a multipart request
+func (h *BulkFarmerHandler) readMultipartFile(c *gin.Context) ([]byte, error) {
+	if err := c.Request.ParseMultipartForm(50 << 20); // 50 MB max
+	err != nil {
+		return nil, fmt.Errorf("failed to parse multipart form: %w", err)
+	}
+
 	// Get file
 	file, header, err := c.Request.FormFile("file")
 	if err != nil {
@@ -554,13 +997,11 @@ func (h *BulkFarmerHandler) parseMultipartRequest(c *gin.Context) (*requests.Bul
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
 
-	req.Data = data
-
 	h.logger.Debug("Parsed multipart request",
 		zap.String("filename", header.Filename),
 		zap.Int64("size", header.Size),
 		zap.String("content_type", header.Header.Get("Content-Type")),
 	)
 
-	return &req, nil
+	return data, nil
 }
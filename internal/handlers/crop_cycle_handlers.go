@@ -1,17 +1,20 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/Kisanlink/farmers-module/internal/entities/requests"
 	"github.com/Kisanlink/farmers-module/internal/entities/responses"
 	"github.com/Kisanlink/farmers-module/internal/services"
+	"github.com/Kisanlink/farmers-module/pkg/common"
 	"github.com/gin-gonic/gin"
 )
 
 // StartCycle handles starting a new crop cycle
 // @Summary Start a new crop cycle
-// @Description Start a new crop cycle with farm and crop details
+// @Description Start a new crop cycle with farm and crop details. A cycle may be grown on a plot of the farm, taking the whole plot unless area_ha is given; no other planned or active cycle may be grown on the plot or a plot overlapping it. With generate_activities, an activity is planned for every activity template of the crop's stages, dated from start_date. For perennial crops, tree_count and planting_year are used to estimate the cycle's yield.
 // @Tags Crop Cycles
 // @Accept json
 // @Produce json
@@ -20,6 +23,7 @@ import (
 // @Failure 400 {object} responses.SwaggerErrorResponse
 // @Failure 401 {object} responses.SwaggerErrorResponse
 // @Failure 403 {object} responses.SwaggerErrorResponse
+// @Failure 409 {object} responses.SwaggerErrorResponse
 // @Failure 500 {object} responses.SwaggerErrorResponse
 // @Security BearerAuth
 // @Router /crops/cycles [post]
@@ -49,7 +53,7 @@ func StartCycle(service services.CropCycleService) gin.HandlerFunc {
 		// Call service
 		result, err := service.StartCycle(c.Request.Context(), &req)
 		if err != nil {
-			handleServiceError(c, err)
+			handleCropCycleError(c, err)
 			return
 		}
 
@@ -70,6 +74,7 @@ func StartCycle(service services.CropCycleService) gin.HandlerFunc {
 // @Failure 401 {object} responses.SwaggerErrorResponse
 // @Failure 403 {object} responses.SwaggerErrorResponse
 // @Failure 404 {object} responses.SwaggerErrorResponse
+// @Failure 409 {object} responses.SwaggerErrorResponse
 // @Failure 500 {object} responses.SwaggerErrorResponse
 // @Security BearerAuth
 // @Router /crops/cycles/{cycle_id} [put]
@@ -102,7 +107,7 @@ func UpdateCycle(service services.CropCycleService) gin.HandlerFunc {
 		// Call service
 		result, err := service.UpdateCycle(c.Request.Context(), &req)
 		if err != nil {
-			handleServiceError(c, err)
+			handleCropCycleError(c, err)
 			return
 		}
 
@@ -112,7 +117,7 @@ func UpdateCycle(service services.CropCycleService) gin.HandlerFunc {
 
 // EndCycle handles ending a crop cycle
 // @Summary End a crop cycle
-// @Description End a crop cycle and mark it as completed or cancelled. For PERENNIAL crops, provide outcome with age_range_min, age_range_max, yield_per_tree, and yield_unit. For annual crops (RABI/KHARIF/ZAID), provide outcome with yield_per_hectare and yield_unit.
+// @Description End a crop cycle and mark it as completed or cancelled. For PERENNIAL crops, provide outcome with age_range_min, age_range_max, yield_per_tree, and yield_unit. For annual crops (RABI/KHARIF/ZAID), provide outcome with yield_per_hectare and yield_unit, or with yield_per_area in area_unit (e.g. quintals per acre or bigha; local units are sized by the farm's state). yield_unit must be a known unit of mass or a count; the yield is also recorded in kg or numbers under outcome.normalized. A completed cycle also records its expected yield from its variety's benchmarks under outcome.expected_yield, and how the yield compares with it under outcome.yield_variance.
 // @Tags Crop Cycles
 // @Accept json
 // @Produce json
@@ -149,7 +154,54 @@ func EndCycle(service services.CropCycleService) gin.HandlerFunc {
 		// Call service (EndCycleRequest doesn't have Validate method)
 		result, err := service.EndCycle(c.Request.Context(), &req)
 		if err != nil {
-			handleServiceError(c, err)
+			handleCropCycleError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+// ReplanCycle handles moving the sowing date of a crop cycle
+// @Summary Replan a crop cycle
+// @Description Move a crop cycle's start (sowing) date and shift its planned activities by the same number of days. Completed and cancelled activities are not moved.
+// @Tags Crop Cycles
+// @Accept json
+// @Produce json
+// @Param cycle_id path string true "Cycle ID"
+// @Param request body requests.ReplanCycleRequest true "Replan cycle request"
+// @Success 200 {object} responses.ReplanCycleResponse
+// @Failure 400 {object} responses.SwaggerErrorResponse
+// @Failure 401 {object} responses.SwaggerErrorResponse
+// @Failure 403 {object} responses.SwaggerErrorResponse
+// @Failure 404 {object} responses.SwaggerErrorResponse
+// @Failure 500 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /crops/cycles/{cycle_id}/replan [post]
+func ReplanCycle(service services.CropCycleService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req := requests.NewReplanCycleRequest()
+
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, responses.NewValidationError("Invalid request data", err.Error()))
+			return
+		}
+
+		// Get cycle ID from path
+		req.ID = c.Param("cycle_id")
+
+		// Set context information
+		req.RequestID = c.GetString("request_id")
+		if req.RequestID == "" {
+			req.RequestID = generateRequestID()
+		}
+		req.UserID = c.GetString("user_id")
+		req.OrgID = c.GetString("org_id")
+
+		// Call service
+		result, err := service.ReplanCycle(c.Request.Context(), &req)
+		if err != nil {
+			handleCropCycleError(c, err)
 			return
 		}
 
@@ -297,3 +349,250 @@ func GetFarmAreaAllocationSummary(service services.CropCycleService) gin.Handler
 		c.JSON(http.StatusOK, result)
 	}
 }
+
+// GetCycleStageProgress handles getting the stage progress of a crop cycle
+// @Summary Get crop cycle stage progress
+// @Description Get activity completion per stage of a crop cycle together with each stage's expected window, computed from the start date and stage durations, and when the cycle actually entered it. Reports whether the cycle is behind schedule and by how many days.
+// @Tags Crop Cycles
+// @Accept json
+// @Produce json
+// @Param cycle_id path string true "Cycle ID"
+// @Success 200 {object} responses.StageProgressResponse
+// @Failure 400 {object} responses.SwaggerErrorResponse
+// @Failure 401 {object} responses.SwaggerErrorResponse
+// @Failure 403 {object} responses.SwaggerErrorResponse
+// @Failure 404 {object} responses.SwaggerErrorResponse
+// @Failure 500 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /crops/cycles/{cycle_id}/progress [get]
+func GetCycleStageProgress(service services.FarmActivityService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		cycleID := c.Param("cycle_id")
+		if cycleID == "" {
+			c.JSON(http.StatusBadRequest, responses.NewValidationError("Missing cycle ID", "cycle_id is required"))
+			return
+		}
+
+		// Call service
+		result, err := service.GetStageProgress(c.Request.Context(), cycleID)
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		// Set request ID if response supports it
+		if respWithReqID, ok := result.(interface{ SetRequestID(string) }); ok {
+			requestID := c.GetString("request_id")
+			if requestID == "" {
+				requestID = generateRequestID()
+			}
+			respWithReqID.SetRequestID(requestID)
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+// AdvanceCycleStage handles recording that a crop cycle entered a stage
+// @Summary Advance a crop cycle to a stage
+// @Description Record that a crop cycle entered a stage of its crop, by default the stage after its current one. Stages are also entered automatically when their first activity is completed.
+// @Tags Crop Cycles
+// @Accept json
+// @Produce json
+// @Param cycle_id path string true "Cycle ID"
+// @Param request body requests.AdvanceStageRequest true "Advance stage request"
+// @Success 200 {object} responses.StageProgressResponse
+// @Failure 400 {object} responses.SwaggerErrorResponse
+// @Failure 401 {object} responses.SwaggerErrorResponse
+// @Failure 403 {object} responses.SwaggerErrorResponse
+// @Failure 404 {object} responses.SwaggerErrorResponse
+// @Failure 500 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /crops/cycles/{cycle_id}/stages/advance [post]
+func AdvanceCycleStage(service services.FarmActivityService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req := requests.AdvanceStageRequest{BaseRequest: requests.NewBaseRequest()}
+
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, responses.NewValidationError("Invalid request data", err.Error()))
+			return
+		}
+
+		// Get cycle ID from path
+		req.CropCycleID = c.Param("cycle_id")
+
+		// Set context information
+		req.RequestID = c.GetString("request_id")
+		if req.RequestID == "" {
+			req.RequestID = generateRequestID()
+		}
+		req.UserID = c.GetString("user_id")
+		req.OrgID = c.GetString("org_id")
+
+		// Call service
+		result, err := service.AdvanceStage(c.Request.Context(), &req)
+		if err != nil {
+			handleCropCycleError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+// ListDelayedCycles handles listing the crop cycles running behind their stage calendar
+// @Summary List delayed crop cycles
+// @Description Get a paginated list of the organization's planned and active crop cycles that are behind their stage calendar, most delayed first
+// @Tags Crop Cycles
+// @Accept json
+// @Produce json
+// @Param farm_id query string false "Filter by farm ID"
+// @Param min_delay_days query int false "Minimum days behind schedule" default(1)
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} responses.DelayedCyclesResponse
+// @Failure 400 {object} responses.SwaggerErrorResponse
+// @Failure 401 {object} responses.SwaggerErrorResponse
+// @Failure 403 {object} responses.SwaggerErrorResponse
+// @Failure 500 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /crops/cycles/delayed [get]
+func ListDelayedCycles(service services.FarmActivityService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req := requests.NewListDelayedCyclesRequest()
+
+		// Extract query parameters
+		req.FarmID = c.Query("farm_id")
+		req.MinDelayDays = parseIntQuery(c, "min_delay_days", 1)
+		req.Page = parseIntQuery(c, "page", 1)
+		req.PageSize = parseIntQuery(c, "page_size", 20)
+
+		// Set context information
+		req.RequestID = c.GetString("request_id")
+		if req.RequestID == "" {
+			req.RequestID = generateRequestID()
+		}
+		req.UserID = c.GetString("user_id")
+		req.OrgID = c.GetString("org_id")
+
+		// Call service
+		result, err := service.ListDelayedCycles(c.Request.Context(), &req)
+		if err != nil {
+			handleCropCycleError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+// GetCycleYieldEstimate handles getting the expected yield of a crop cycle
+// @Summary Get crop cycle yield estimate
+// @Description Estimate a crop cycle's yield from its variety's benchmarks, taken to be in the crop's unit: for perennial crops with a tree_count, the variety's yield per tree at the trees' age (from planting_year) or its overall yield per tree; otherwise its yield per acre over the cycle's area. Yields are in kg, or numbers for crops counted rather than weighed. Once the outcome records a yield, it is compared with the estimate; the comparison made when the cycle was completed is kept in its outcome under yield_variance.
+// @Tags Crop Cycles
+// @Produce json
+// @Param cycle_id path string true "Cycle ID"
+// @Success 200 {object} responses.YieldEstimateResponse
+// @Failure 401 {object} responses.SwaggerErrorResponse
+// @Failure 403 {object} responses.SwaggerErrorResponse
+// @Failure 404 {object} responses.SwaggerErrorResponse
+// @Failure 500 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /crops/cycles/{cycle_id}/yield-estimate [get]
+func GetCycleYieldEstimate(service services.CropCycleService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req := requests.NewGetYieldEstimateRequest()
+		req.ID = c.Param("cycle_id")
+
+		// Set context information
+		req.RequestID = c.GetString("request_id")
+		if req.RequestID == "" {
+			req.RequestID = generateRequestID()
+		}
+		req.UserID = c.GetString("user_id")
+		req.OrgID = c.GetString("org_id")
+
+		// Call service
+		result, err := service.GetYieldEstimate(c.Request.Context(), &req)
+		if err != nil {
+			handleCropCycleError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+// GetProductionForecast handles estimating the production of the organization's open crop cycles
+// @Summary Get production forecast
+// @Description Estimate the production of the organization's planned and active crop cycles from their varieties' benchmarks, added up by crop and season in kg (or numbers), for aggregation and marketing. Cycles without a benchmark, or without an area or tree count to apply it to, are counted but not estimated.
+// @Tags Crop Cycles
+// @Produce json
+// @Param farm_id query string false "Filter by farm ID"
+// @Param crop_id query string false "Filter by crop ID"
+// @Param season query string false "Filter by season (RABI, KHARIF, ZAID, PERENNIAL, OTHER)"
+// @Param year query int false "Year the cycles start in"
+// @Success 200 {object} responses.ProductionForecastResponse
+// @Failure 400 {object} responses.SwaggerErrorResponse
+// @Failure 401 {object} responses.SwaggerErrorResponse
+// @Failure 403 {object} responses.SwaggerErrorResponse
+// @Failure 500 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /crops/cycles/forecast [get]
+func GetProductionForecast(service services.CropCycleService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req := requests.NewProductionForecastRequest()
+
+		// Extract query parameters
+		req.FarmID = c.Query("farm_id")
+		req.CropID = c.Query("crop_id")
+		req.Season = c.Query("season")
+
+		switch req.Season {
+		case "", "RABI", "KHARIF", "ZAID", "PERENNIAL", "OTHER":
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "season must be one of RABI, KHARIF, ZAID, PERENNIAL, OTHER"})
+			return
+		}
+		if yearStr := c.Query("year"); yearStr != "" {
+			year, err := strconv.Atoi(yearStr)
+			if err != nil || year < 1900 || year > 2200 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "year must be a four-digit year"})
+				return
+			}
+			req.Year = year
+		}
+
+		// Set context information
+		req.RequestID = c.GetString("request_id")
+		if req.RequestID == "" {
+			req.RequestID = generateRequestID()
+		}
+		req.UserID = c.GetString("user_id")
+		req.OrgID = c.GetString("org_id")
+
+		// Call service
+		result, err := service.GetProductionForecast(c.Request.Context(), &req)
+		if err != nil {
+			handleCropCycleError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+// handleCropCycleError maps crop cycle errors to responses: allocations the farm or its
+// plots cannot hold are conflicts
+func handleCropCycleError(c *gin.Context, err error) {
+	var exceeded *common.AreaExceededError
+	var occupied *common.PlotOccupiedError
+	switch {
+	case errors.Is(err, common.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.As(err, &exceeded), errors.As(err, &occupied):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		handleServiceError(c, err)
+	}
+}
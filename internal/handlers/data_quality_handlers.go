@@ -1,7 +1,9 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/Kisanlink/farmers-module/internal/entities/requests"
 	"github.com/Kisanlink/farmers-module/internal/entities/responses"
@@ -56,7 +58,7 @@ func NewDataQualityHandlers(dataQualityService services.DataQualityService) *Dat
 
 // ValidateGeometry validates WKT geometry with PostGIS validation and SRID checks
 // @Summary Validate geometry
-// @Description Validates WKT geometry using PostGIS with SRID enforcement and integrity checks
+// @Description Validates WKT geometry using PostGIS with SRID enforcement and integrity checks. With repair set, also returns a repaired geometry, its area change and the corrections made
 // @Tags Data Quality
 // @Accept json
 // @Produce json
@@ -203,7 +205,7 @@ func (h *DataQualityHandlers) RebuildSpatialIndexes(c *gin.Context) {
 
 // DetectFarmOverlaps detects spatial intersections between farm boundaries
 // @Summary Detect farm overlaps
-// @Description Detects spatial intersections between farm boundaries within an organization
+// @Description Detects spatial intersections between farm boundaries within an organization and records each overlapping pair as an overlap conflict. New pairs open a conflict, resolved conflicts whose overlap is back are reopened, and unresolved conflicts whose overlap is gone are resolved. With incremental set, only farms changed since the last complete run are re-checked. Overlaps under 1 m² are ignored.
 // @Tags Data Quality
 // @Accept json
 // @Produce json
@@ -249,3 +251,138 @@ func (h *DataQualityHandlers) DetectFarmOverlaps(c *gin.Context) {
 
 	c.JSON(http.StatusOK, detectResponse)
 }
+
+// ListOverlapConflicts lists the organization's farm overlap conflicts
+// @Summary List farm overlap conflicts
+// @Description Lists the overlap conflicts recorded by farm overlap detection, largest overlap first. Conflicts can be filtered by status, by either farm of the pair and by assignee.
+// @Tags Data Quality
+// @Produce json
+// @Param status query string false "Conflict status" Enums(OPEN, ACKNOWLEDGED, RESOLVED, FALSE_POSITIVE)
+// @Param farm_id query string false "Either farm of the pair"
+// @Param assignee_id query string false "AAA user ID of the assignee"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} responses.OverlapConflictListResponse
+// @Failure 400 {object} responses.SwaggerErrorResponse
+// @Failure 401 {object} responses.SwaggerErrorResponse
+// @Failure 403 {object} responses.SwaggerErrorResponse
+// @Failure 500 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /data-quality/overlap-conflicts [get]
+func (h *DataQualityHandlers) ListOverlapConflicts(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	req := requests.NewListOverlapConflictsRequest()
+	req.PaginationRequest = requests.NewPaginationRequest(page, pageSize)
+	req.SetUserContext(getUserContext(c))
+	req.Status = c.Query("status")
+	req.FarmID = c.Query("farm_id")
+	req.AssigneeID = c.Query("assignee_id")
+	req.RequestID = c.GetString("request_id")
+
+	response, err := h.dataQualityService.ListOverlapConflicts(c.Request.Context(), &req)
+	if err != nil {
+		handleOverlapConflictError(c, err)
+		return
+	}
+
+	listResponse, ok := response.(*responses.OverlapConflictListResponse)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid response type"})
+		return
+	}
+
+	c.JSON(http.StatusOK, listResponse)
+}
+
+// UpdateOverlapConflict changes the status, assignee or notes of a farm overlap conflict
+// @Summary Update a farm overlap conflict
+// @Description Changes the status, assignee or resolution notes of an overlap conflict. Open conflicts can be acknowledged, resolved without changing either boundary, or dismissed as false positives; resolved and dismissed conflicts can only be reopened. Dismissed conflicts stay dismissed when detected again.
+// @Tags Data Quality
+// @Accept json
+// @Produce json
+// @Param conflict_id path string true "Conflict ID"
+// @Param request body requests.UpdateOverlapConflictRequest true "Conflict changes"
+// @Success 200 {object} responses.OverlapConflictResponse
+// @Failure 400 {object} responses.SwaggerErrorResponse
+// @Failure 401 {object} responses.SwaggerErrorResponse
+// @Failure 403 {object} responses.SwaggerErrorResponse
+// @Failure 404 {object} responses.SwaggerErrorResponse
+// @Failure 500 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /data-quality/overlap-conflicts/{conflict_id} [put]
+func (h *DataQualityHandlers) UpdateOverlapConflict(c *gin.Context) {
+	req := requests.NewUpdateOverlapConflictRequest()
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+	req.ConflictID = c.Param("conflict_id")
+	req.SetUserContext(getUserContext(c))
+	req.RequestID = c.GetString("request_id")
+
+	response, err := h.dataQualityService.UpdateOverlapConflict(c.Request.Context(), &req)
+	if err != nil {
+		handleOverlapConflictError(c, err)
+		return
+	}
+
+	conflictResponse, ok := response.(*responses.OverlapConflictResponse)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid response type"})
+		return
+	}
+
+	c.JSON(http.StatusOK, conflictResponse)
+}
+
+// ResolveOverlapConflict resolves a farm overlap conflict by changing the farm boundaries
+// @Summary Resolve a farm overlap conflict
+// @Description Resolves an open or acknowledged overlap conflict. TRIM removes the shared area from the farm named by trim_farm_id; SPLIT divides the shared area between both farms, each keeping the part nearer its own land. The new boundaries are added to the farms' boundary history and the farms are tagged again with their administrative areas.
+// @Tags Data Quality
+// @Accept json
+// @Produce json
+// @Param conflict_id path string true "Conflict ID"
+// @Param request body requests.ResolveOverlapConflictRequest true "Resolution"
+// @Success 200 {object} responses.OverlapConflictResponse
+// @Failure 400 {object} responses.SwaggerErrorResponse
+// @Failure 401 {object} responses.SwaggerErrorResponse
+// @Failure 403 {object} responses.SwaggerErrorResponse
+// @Failure 404 {object} responses.SwaggerErrorResponse
+// @Failure 500 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /data-quality/overlap-conflicts/{conflict_id}/resolve [post]
+func (h *DataQualityHandlers) ResolveOverlapConflict(c *gin.Context) {
+	req := requests.NewResolveOverlapConflictRequest()
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+	req.ConflictID = c.Param("conflict_id")
+	req.SetUserContext(getUserContext(c))
+	req.RequestID = c.GetString("request_id")
+
+	response, err := h.dataQualityService.ResolveOverlapConflict(c.Request.Context(), &req)
+	if err != nil {
+		handleOverlapConflictError(c, err)
+		return
+	}
+
+	conflictResponse, ok := response.(*responses.OverlapConflictResponse)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid response type"})
+		return
+	}
+
+	c.JSON(http.StatusOK, conflictResponse)
+}
+
+// handleOverlapConflictError maps conflict workflow errors: invalid changes are a bad request
+func handleOverlapConflictError(c *gin.Context, err error) {
+	if errors.Is(err, common.ErrInvalidInput) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	common.HandleServiceError(c, err)
+}
@@ -90,7 +90,7 @@ func CreateFarmActivity(service services.FarmActivityService) gin.HandlerFunc {
 
 // CompleteFarmActivity handles W15: Complete farm activity
 // @Summary Complete a farm activity
-// @Description Mark a farm activity as completed with output data
+// @Description Mark a farm activity as completed with output data. completed_at defaults to now. The first activity of a crop stage to start, or to be completed without being started, records when the cycle entered the stage; that may be neither in the future nor before the cycle's start date.
 // @Tags farm-activities
 // @Accept json
 // @Produce json
@@ -157,6 +157,75 @@ func CompleteFarmActivity(service services.FarmActivityService) gin.HandlerFunc
 	}
 }
 
+// StartFarmActivity handles starting a farm activity
+// @Summary Start a farm activity
+// @Description Mark a planned farm activity as in progress. started_at defaults to now and may be neither in the future nor, for an activity of a crop stage, before the cycle's start date. The first activity of a crop stage to start records when the cycle entered the stage.
+// @Tags farm-activities
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param activity_id path string true "Activity ID"
+// @Param activity body requests.StartActivityRequest true "Start activity data"
+// @Success 200 {object} responses.SwaggerFarmActivityResponse
+// @Failure 400 {object} responses.SwaggerErrorResponse
+// @Failure 401 {object} responses.SwaggerErrorResponse
+// @Failure 403 {object} responses.SwaggerErrorResponse
+// @Failure 404 {object} responses.SwaggerErrorResponse
+// @Failure 500 {object} responses.SwaggerErrorResponse
+// @Router /crops/activities/{activity_id}/start [put]
+func StartFarmActivity(service services.FarmActivityService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req requests.StartActivityRequest
+
+		// Get activity ID from path
+		activityID := c.Param("activity_id")
+		if activityID == "" {
+			c.JSON(http.StatusBadRequest, responses.NewValidationError("Activity ID is required", "Missing activity ID in path"))
+			return
+		}
+
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, responses.NewValidationError("Invalid request data", err.Error()))
+			return
+		}
+
+		// Set user context from middleware
+		if userID, exists := c.Get("aaa_subject"); exists {
+			req.UserID = userID.(string)
+		}
+		if orgID, exists := c.Get("aaa_org"); exists {
+			req.OrgID = orgID.(string)
+		}
+
+		// Set activity ID and request metadata
+		req.ID = activityID
+		req.SetRequestID(c.GetString("request_id"))
+		req.SetRequestType("start_activity")
+
+		// Call service
+		result, err := service.StartActivity(c.Request.Context(), &req)
+		if err != nil {
+			if isValidationError(err) {
+				c.JSON(http.StatusBadRequest, responses.NewValidationError("Validation failed", err.Error()))
+			} else if isPermissionError(err) {
+				c.JSON(http.StatusForbidden, responses.NewForbiddenError("Permission denied"))
+			} else if isNotFoundError(err) {
+				c.JSON(http.StatusNotFound, responses.NewNotFoundError("Resource not found", err.Error()))
+			} else {
+				c.JSON(http.StatusInternalServerError, responses.NewInternalServerError("Internal server error", err.Error()))
+			}
+			return
+		}
+
+		// Set response metadata
+		if response, ok := result.(*responses.FarmActivityResponse); ok {
+			response.SetRequestID(c.GetString("request_id"))
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
 // UpdateFarmActivity handles W16: Update farm activity
 // @Summary Update a farm activity
 // @Description Update farm activity details (only for non-completed activities)
@@ -1,12 +1,17 @@
 package handlers
 
 import (
+	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/Kisanlink/farmers-module/internal/entities/requests"
 	"github.com/Kisanlink/farmers-module/internal/entities/responses"
 	"github.com/Kisanlink/farmers-module/internal/services"
+	"github.com/Kisanlink/farmers-module/pkg/common"
 	"github.com/gin-gonic/gin"
 )
 
@@ -31,7 +36,7 @@ type FarmListResponse struct {
 
 // CreateFarm handles W6: Create farm
 // @Summary Create a new farm
-// @Description Create a new farm with geographic boundaries and metadata
+// @Description Create a new farm with geographic boundaries and metadata. The geometry may be given as WKT, in geometry.geojson, or as a bare RFC 7946 Polygon or Feature in place of the geometry object. Responses carry the geometry as both WKT and GeoJSON. With block_on_overlap set, a boundary overlapping another farm of the organization is refused with 409 instead of opening an overlap conflict.
 // @Tags farms
 // @Accept json
 // @Produce json
@@ -39,6 +44,7 @@ type FarmListResponse struct {
 // @Success 201 {object} responses.SwaggerFarmResponse
 // @Failure 400 {object} responses.SwaggerErrorResponse
 // @Failure 403 {object} responses.SwaggerErrorResponse
+// @Failure 409 {object} responses.SwaggerErrorResponse
 // @Router /farms [post]
 func CreateFarm(service services.FarmService) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -47,6 +53,10 @@ func CreateFarm(service services.FarmService) gin.HandlerFunc {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
+		if err := req.Geometry.ResolveWKT(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 
 		// Set request ID for tracking
 		req.RequestID = c.GetString("request_id")
@@ -57,6 +67,11 @@ func CreateFarm(service services.FarmService) gin.HandlerFunc {
 		// Call service
 		result, err := service.CreateFarm(c.Request.Context(), &req)
 		if err != nil {
+			var overlap *common.FarmOverlapError
+			if errors.As(err, &overlap) {
+				c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "overlapping_farm_ids": overlap.OverlappingFarmIDs})
+				return
+			}
 			handleServiceError(c, err)
 			return
 		}
@@ -73,7 +88,7 @@ func CreateFarm(service services.FarmService) gin.HandlerFunc {
 
 // UpdateFarm handles W7: Update farm
 // @Summary Update an existing farm
-// @Description Update farm details including name, geometry, and location
+// @Description Update farm details including name, geometry, and location. The geometry is accepted as WKT or RFC 7946 GeoJSON, as for farm creation.
 // @Tags farms
 // @Accept json
 // @Produce json
@@ -93,6 +108,10 @@ func UpdateFarm(service services.FarmService) gin.HandlerFunc {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
+		if err := req.Geometry.ResolveWKT(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 
 		// Set farm ID from path parameter
 		req.ID = farmID
@@ -166,6 +185,13 @@ func DeleteFarm(service services.FarmService) gin.HandlerFunc {
 // @Param org_id query string false "Filter by organization ID"
 // @Param min_area query number false "Minimum area in hectares"
 // @Param max_area query number false "Maximum area in hectares"
+// @Param ownership_type query string false "Filter by ownership type (OWN, LEASE, SHARED)"
+// @Param soil_type_id query string false "Filter by soil type ID"
+// @Param irrigation_source_id query string false "Filter by primary irrigation source ID"
+// @Param state_code query string false "Filter by state code"
+// @Param district_code query string false "Filter by district code"
+// @Param block_code query string false "Filter by block code"
+// @Param village_code query string false "Filter by village code"
 // @Success 200 {object} responses.SwaggerFarmListResponse
 // @Failure 400 {object} responses.SwaggerErrorResponse
 // @Failure 403 {object} responses.SwaggerErrorResponse
@@ -173,38 +199,7 @@ func DeleteFarm(service services.FarmService) gin.HandlerFunc {
 // @Router /farms [get]
 func ListFarms(service services.FarmService) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		req := requests.NewListFarmsRequest()
-
-		// Parse query parameters
-		if page := c.Query("page"); page != "" {
-			if p, err := strconv.Atoi(page); err == nil && p > 0 {
-				req.Page = p
-			}
-		}
-		if pageSize := c.Query("page_size"); pageSize != "" {
-			if ps, err := strconv.Atoi(pageSize); err == nil && ps > 0 {
-				req.PageSize = ps
-			}
-		}
-		if farmerID := c.Query("farmer_id"); farmerID != "" {
-			req.FarmerID = farmerID
-		}
-		if aaaUserID := c.Query("aaa_user_id"); aaaUserID != "" {
-			req.AAAUserID = aaaUserID
-		}
-		if orgID := c.Query("org_id"); orgID != "" {
-			req.AAAOrgID = orgID
-		}
-		if minArea := c.Query("min_area"); minArea != "" {
-			if ma, err := strconv.ParseFloat(minArea, 64); err == nil {
-				req.MinArea = &ma
-			}
-		}
-		if maxArea := c.Query("max_area"); maxArea != "" {
-			if ma, err := strconv.ParseFloat(maxArea, 64); err == nil {
-				req.MaxArea = &ma
-			}
-		}
+		req := parseListFarmsQuery(c)
 
 		req.RequestID = c.GetString("request_id")
 		if req.RequestID == "" {
@@ -262,4 +257,310 @@ func GetFarm(service services.FarmService) gin.HandlerFunc {
 	}
 }
 
+// SearchFarms handles spatial farm search
+// @Summary Search farms by location
+// @Description Find farms within radius_m metres of a point (lat, lon), inside a boundary given as WKT or GeoJSON in within (for example a village boundary; set intersects to include farms crossing it), the nearest N farms to a point, or farms in a bbox. Exactly one of radius_m, within, nearest and bbox is given. The ListFarms filters (aaa_org_id, farmer_id, aaa_user_id, ownership_type, soil_type_id, irrigation_source_id, state_code, district_code, block_code, village_code, min_area, max_area) apply as well, and results are paginated. Point searches return farms nearest first with distance_m, the geodesic distance in metres.
+// @Tags farms
+// @Accept json
+// @Produce json
+// @Param search body requests.SearchFarmsRequest true "Search criteria"
+// @Success 200 {object} responses.SwaggerFarmListResponse
+// @Failure 400 {object} responses.SwaggerErrorResponse
+// @Failure 403 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /farms/search [post]
+func SearchFarms(service services.FarmService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req := requests.NewSearchFarmsRequest()
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		// Unset pagination falls back to the list defaults
+		req.PaginationRequest = requests.NewPaginationRequest(req.Page, req.PageSize)
+		if req.AAAOrgID == "" {
+			_, req.AAAOrgID = getUserContext(c)
+		}
+
+		req.RequestID = c.GetString("request_id")
+		if req.RequestID == "" {
+			req.RequestID = generateRequestID()
+		}
+
+		result, err := service.SearchFarms(c.Request.Context(), &req)
+		if err != nil {
+			if errors.Is(err, common.ErrInvalidInput) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			handleServiceError(c, err)
+			return
+		}
+
+		response, ok := result.(*responses.FarmListResponse)
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "invalid response type"})
+			return
+		}
+
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// ExportFarmsGeoJSON handles exporting farms as GeoJSON
+// @Summary Export farms as GeoJSON
+// @Description Download the farms matching the list filters as an RFC 7946 FeatureCollection, without pagination. Exports are limited to 50000 farms; use the tile endpoint to display larger sets.
+// @Tags farms
+// @Produce application/geo+json
+// @Param farmer_id query string false "Filter by internal farmer ID (e.g., FMRR0000000001)"
+// @Param aaa_user_id query string false "Filter by AAA user ID"
+// @Param org_id query string false "Filter by organization ID"
+// @Param min_area query number false "Minimum area in hectares"
+// @Param max_area query number false "Maximum area in hectares"
+// @Success 200 {object} responses.FarmFeatureCollection
+// @Failure 400 {object} responses.SwaggerErrorResponse
+// @Failure 403 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /farms/export.geojson [get]
+func ExportFarmsGeoJSON(service services.FarmService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req := parseListFarmsQuery(c)
+		req.RequestID = c.GetString("request_id")
+		if req.RequestID == "" {
+			req.RequestID = generateRequestID()
+		}
+
+		result, err := service.ExportFarms(c.Request.Context(), &req)
+		if err != nil {
+			if errors.Is(err, common.ErrInvalidInput) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			handleServiceError(c, err)
+			return
+		}
+
+		body, err := json.Marshal(result)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to encode GeoJSON"})
+			return
+		}
+
+		c.Header("Content-Disposition", `attachment; filename="farms.geojson"`)
+		c.Data(http.StatusOK, "application/geo+json", body)
+	}
+}
+
+// GetFarmTile handles vector tiles of farm boundaries
+// @Summary Get a vector tile of farms
+// @Description Render the farms of the caller's organization that fall in an XYZ tile (Web Mercator) as a Mapbox Vector Tile with a "farms" layer carrying id, name, farmer_id, ownership_type and area_ha. Intended for MapLibre and Leaflet vector layers.
+// @Tags farms
+// @Produce application/vnd.mapbox-vector-tile
+// @Param z path int true "Zoom level (0-22)"
+// @Param x path int true "Tile column"
+// @Param y path string true "Tile row, optionally followed by .mvt"
+// @Success 200 {file} binary
+// @Failure 400 {object} responses.SwaggerErrorResponse
+// @Failure 403 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /farms/tiles/{z}/{x}/{y}.mvt [get]
+func GetFarmTile(service services.FarmService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		z, errZ := strconv.Atoi(c.Param("z"))
+		x, errX := strconv.Atoi(c.Param("x"))
+		y, errY := strconv.Atoi(strings.TrimSuffix(c.Param("y"), ".mvt"))
+		if errZ != nil || errX != nil || errY != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "tile coordinates must be integers"})
+			return
+		}
+
+		req := requests.FarmTileRequest{Z: z, X: x, Y: y}
+		req.SetUserContext(getUserContext(c))
+		req.RequestID = c.GetString("request_id")
+		if req.RequestID == "" {
+			req.RequestID = generateRequestID()
+		}
+
+		tile, err := service.GetFarmTile(c.Request.Context(), &req)
+		if err != nil {
+			if errors.Is(err, common.ErrInvalidInput) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			handleServiceError(c, err)
+			return
+		}
+
+		c.Header("Cache-Control", "private, max-age=60")
+		c.Data(http.StatusOK, "application/vnd.mapbox-vector-tile", tile)
+	}
+}
+
+// ListFarmGeometryVersions handles listing the boundary history of a farm
+// @Summary List farm boundary versions
+// @Description List every boundary a farm has had, oldest first, with its author, reason, source, area change and the period it was in force
+// @Tags farms
+// @Produce json
+// @Param farm_id path string true "Farm ID"
+// @Success 200 {object} responses.FarmGeometryVersionListResponse
+// @Failure 403 {object} responses.SwaggerErrorResponse
+// @Failure 404 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /farms/{farm_id}/geometry-versions [get]
+func ListFarmGeometryVersions(service services.FarmService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		result, err := service.ListFarmGeometryVersions(c.Request.Context(), c.Param("farm_id"))
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		response, ok := result.(*responses.FarmGeometryVersionListResponse)
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "invalid response type"})
+			return
+		}
+		response.SetRequestID(c.GetString("request_id"))
+
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// DiffFarmGeometryVersions handles comparing two boundary versions of a farm
+// @Summary Compare farm boundary versions
+// @Description Return the area a farm gained and lost between two boundary versions, as geometries in WKT and GeoJSON with their areas in hectares
+// @Tags farms
+// @Produce json
+// @Param farm_id path string true "Farm ID"
+// @Param from query int true "Version to compare from"
+// @Param to query int true "Version to compare to"
+// @Success 200 {object} responses.FarmGeometryDiffResponse
+// @Failure 400 {object} responses.SwaggerErrorResponse
+// @Failure 403 {object} responses.SwaggerErrorResponse
+// @Failure 404 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /farms/{farm_id}/geometry-diff [get]
+func DiffFarmGeometryVersions(service services.FarmService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		fromVersion, errFrom := strconv.Atoi(c.Query("from"))
+		toVersion, errTo := strconv.Atoi(c.Query("to"))
+		if errFrom != nil || errTo != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "from and to must be version numbers"})
+			return
+		}
+
+		req := requests.FarmGeometryDiffRequest{FarmID: c.Param("farm_id"), FromVersion: fromVersion, ToVersion: toVersion}
+		req.RequestID = c.GetString("request_id")
+		if req.RequestID == "" {
+			req.RequestID = generateRequestID()
+		}
+
+		result, err := service.DiffFarmGeometryVersions(c.Request.Context(), &req)
+		if err != nil {
+			if errors.Is(err, common.ErrInvalidInput) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			handleServiceError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+// GetFarmGeometryAsOf handles reading the boundary a farm had at a point in time
+// @Summary Get a farm's boundary as of a date
+// @Description Return the boundary version in force at the given time. A bare date means the end of that day (UTC), so changes made on the day are included.
+// @Tags farms
+// @Produce json
+// @Param farm_id path string true "Farm ID"
+// @Param as_of query string true "RFC 3339 time or YYYY-MM-DD date"
+// @Success 200 {object} responses.FarmGeometryVersionResponse
+// @Failure 400 {object} responses.SwaggerErrorResponse
+// @Failure 403 {object} responses.SwaggerErrorResponse
+// @Failure 404 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /farms/{farm_id}/geometry [get]
+func GetFarmGeometryAsOf(service services.FarmService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		asOf, err := parseAsOf(c.Query("as_of"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		req := requests.FarmGeometryAsOfRequest{FarmID: c.Param("farm_id"), AsOf: asOf}
+		req.RequestID = c.GetString("request_id")
+		if req.RequestID == "" {
+			req.RequestID = generateRequestID()
+		}
+
+		result, err := service.GetFarmGeometryAsOf(c.Request.Context(), &req)
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+// parseAsOf reads an RFC 3339 time, or a YYYY-MM-DD date as the end of that day in UTC. An
+// empty value means now.
+func parseAsOf(value string) (time.Time, error) {
+	if value == "" {
+		return time.Now(), nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	day, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, errors.New("as_of must be an RFC 3339 time or a YYYY-MM-DD date")
+	}
+	return day.Add(24*time.Hour - time.Nanosecond), nil
+}
+
 // Helper functions
+
+// parseListFarmsQuery reads the ListFarms filters from the query string
+func parseListFarmsQuery(c *gin.Context) requests.ListFarmsRequest {
+	req := requests.NewListFarmsRequest()
+
+	if page := c.Query("page"); page != "" {
+		if p, err := strconv.Atoi(page); err == nil && p > 0 {
+			req.Page = p
+		}
+	}
+	if pageSize := c.Query("page_size"); pageSize != "" {
+		if ps, err := strconv.Atoi(pageSize); err == nil && ps > 0 {
+			req.PageSize = ps
+		}
+	}
+	if farmerID := c.Query("farmer_id"); farmerID != "" {
+		req.FarmerID = farmerID
+	}
+	if aaaUserID := c.Query("aaa_user_id"); aaaUserID != "" {
+		req.AAAUserID = aaaUserID
+	}
+	if orgID := c.Query("org_id"); orgID != "" {
+		req.AAAOrgID = orgID
+	}
+	if minArea := c.Query("min_area"); minArea != "" {
+		if ma, err := strconv.ParseFloat(minArea, 64); err == nil {
+			req.MinArea = &ma
+		}
+	}
+	if maxArea := c.Query("max_area"); maxArea != "" {
+		if ma, err := strconv.ParseFloat(maxArea, 64); err == nil {
+			req.MaxArea = &ma
+		}
+	}
+	req.OwnershipType = c.Query("ownership_type")
+	req.SoilTypeID = c.Query("soil_type_id")
+	req.IrrigationSourceID = c.Query("irrigation_source_id")
+	req.AdminAreaFilter = parseAdminAreaQuery(c)
+
+	return req
+}
@@ -282,6 +282,10 @@ func (h *FarmerHandler) GetFarmerByID(c *gin.Context) {
 // @Param aaa_org_id query string false "AAA Org ID filter"
 // @Param kisan_sathi_user_id query string false "KisanSathi User ID filter"
 // @Param phone_number query string false "Phone number filter"
+// @Param state_code query string false "Farmers with a farm in the state"
+// @Param district_code query string false "Farmers with a farm in the district"
+// @Param block_code query string false "Farmers with a farm in the block"
+// @Param village_code query string false "Farmers with a farm in the village"
 // @Success 200 {object} github_com_Kisanlink_farmers-module_internal_entities_responses.SwaggerFarmerListResponse
 // @Failure 400 {object} github_com_Kisanlink_farmers-module_internal_entities_responses.SwaggerErrorResponse
 // @Failure 500 {object} github_com_Kisanlink_farmers-module_internal_entities_responses.SwaggerErrorResponse
@@ -328,6 +332,7 @@ func (h *FarmerHandler) ListFarmers(c *gin.Context) {
 	if phoneNumber := c.Query("phone_number"); phoneNumber != "" {
 		req.PhoneNumber = phoneNumber
 	}
+	req.AdminAreaFilter = parseAdminAreaQuery(c)
 
 	response, err := h.farmerService.ListFarmers(c.Request.Context(), &req)
 	if err != nil {
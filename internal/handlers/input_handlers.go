@@ -0,0 +1,355 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/requests"
+	"github.com/Kisanlink/farmers-module/internal/entities/responses"
+	"github.com/Kisanlink/farmers-module/internal/services"
+	"github.com/Kisanlink/farmers-module/pkg/common"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateInputProduct handles adding a product to the input master
+// @Summary Create an input product
+// @Description Add a seed, fertiliser or pesticide product, or a labour or machinery service, to the input master with the unit its quantities are recorded in
+// @Tags inputs
+// @Accept json
+// @Produce json
+// @Param product body requests.InputProductRequest true "Input product"
+// @Success 201 {object} responses.InputProductResponse
+// @Failure 400 {object} responses.SwaggerErrorResponse
+// @Failure 403 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /inputs [post]
+func CreateInputProduct(service services.InputProductService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req := requests.NewCreateInputProductRequest()
+		if err := c.ShouldBindJSON(&req.InputProductRequest); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		req.SetUserContext(getUserContext(c))
+		req.RequestID = c.GetString("request_id")
+		if req.RequestID == "" {
+			req.RequestID = generateRequestID()
+		}
+
+		result, err := service.CreateInputProduct(c.Request.Context(), &req)
+		if err != nil {
+			handleInputError(c, err)
+			return
+		}
+
+		response, ok := result.(*responses.InputProductResponse)
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "invalid response type"})
+			return
+		}
+
+		c.JSON(http.StatusCreated, response)
+	}
+}
+
+// ListInputProducts handles listing the input master
+// @Summary List input products
+// @Description List the input master by category and name
+// @Tags inputs
+// @Produce json
+// @Param category query string false "Filter by category (SEED, FERTILIZER, PESTICIDE, LABOUR, MACHINERY, IRRIGATION, OTHER)"
+// @Param search query string false "Search in name or manufacturer"
+// @Param active_only query bool false "Only products in use"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} responses.InputProductListResponse
+// @Failure 400 {object} responses.SwaggerErrorResponse
+// @Failure 403 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /inputs [get]
+func ListInputProducts(service services.InputProductService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req := requests.NewListInputProductsRequest()
+		req.Category = c.Query("category")
+		req.Search = c.Query("search")
+		req.ActiveOnly, _ = strconv.ParseBool(c.Query("active_only"))
+		req.Page = parseIntQuery(c, "page", 1)
+		req.PageSize = parseIntQuery(c, "page_size", 20)
+		req.SetUserContext(getUserContext(c))
+		req.RequestID = c.GetString("request_id")
+		if req.RequestID == "" {
+			req.RequestID = generateRequestID()
+		}
+
+		result, err := service.ListInputProducts(c.Request.Context(), &req)
+		if err != nil {
+			handleInputError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+// GetInputProduct handles getting a product of the input master
+// @Summary Get an input product
+// @Tags inputs
+// @Produce json
+// @Param id path string true "Input product ID"
+// @Success 200 {object} responses.InputProductResponse
+// @Failure 403 {object} responses.SwaggerErrorResponse
+// @Failure 404 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /inputs/{id} [get]
+func GetInputProduct(service services.InputProductService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req := requests.GetInputProductRequest{
+			BaseRequest: requests.NewBaseRequest(),
+			ID:          c.Param("id"),
+		}
+		req.SetUserContext(getUserContext(c))
+		req.RequestID = c.GetString("request_id")
+		if req.RequestID == "" {
+			req.RequestID = generateRequestID()
+		}
+
+		result, err := service.GetInputProduct(c.Request.Context(), &req)
+		if err != nil {
+			handleInputError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+// UpdateInputProduct handles updating a product of the input master
+// @Summary Update an input product
+// @Description Update a product of the input master. Line items already recorded against it keep the unit and category they were recorded with.
+// @Tags inputs
+// @Accept json
+// @Produce json
+// @Param id path string true "Input product ID"
+// @Param product body requests.InputProductRequest true "Input product"
+// @Success 200 {object} responses.InputProductResponse
+// @Failure 400 {object} responses.SwaggerErrorResponse
+// @Failure 403 {object} responses.SwaggerErrorResponse
+// @Failure 404 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /inputs/{id} [put]
+func UpdateInputProduct(service services.InputProductService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req := requests.NewUpdateInputProductRequest()
+		if err := c.ShouldBindJSON(&req.InputProductRequest); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		req.ID = c.Param("id")
+		req.SetUserContext(getUserContext(c))
+		req.RequestID = c.GetString("request_id")
+		if req.RequestID == "" {
+			req.RequestID = generateRequestID()
+		}
+
+		result, err := service.UpdateInputProduct(c.Request.Context(), &req)
+		if err != nil {
+			handleInputError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+// DeleteInputProduct handles removing a product from the input master
+// @Summary Delete an input product
+// @Description Remove a product from the input master. Line items already recorded against it are kept.
+// @Tags inputs
+// @Produce json
+// @Param id path string true "Input product ID"
+// @Success 204 "Input product deleted successfully"
+// @Failure 403 {object} responses.SwaggerErrorResponse
+// @Failure 404 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /inputs/{id} [delete]
+func DeleteInputProduct(service services.InputProductService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req := requests.DeleteInputProductRequest{
+			BaseRequest: requests.NewBaseRequest(),
+			ID:          c.Param("id"),
+		}
+		req.SetUserContext(getUserContext(c))
+		req.RequestID = c.GetString("request_id")
+
+		if err := service.DeleteInputProduct(c.Request.Context(), &req); err != nil {
+			handleInputError(c, err)
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// SetActivityInputs handles recording the input line items of a farm activity
+// @Summary Record the inputs of a farm activity
+// @Description Replace the input line items of a farm activity: the seeds, fertilisers, pesticides, labour and machinery used, with quantity, cost and supplier. Each line item must name an active product of the input master and be recorded in its unit, or in another unit of the same kind (e.g. quintal for a product in kg), which is converted to it; the cost is the total cost when given, otherwise quantity times unit cost. An empty list clears the inputs.
+// @Tags farm-activities
+// @Accept json
+// @Produce json
+// @Param activity_id path string true "Activity ID"
+// @Param inputs body requests.SetActivityInputsRequest true "Input line items"
+// @Success 200 {object} responses.ActivityInputsResponse
+// @Failure 400 {object} responses.SwaggerErrorResponse
+// @Failure 403 {object} responses.SwaggerErrorResponse
+// @Failure 404 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /crops/activities/{activity_id}/inputs [put]
+func SetActivityInputs(service services.CultivationCostService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req := requests.NewSetActivityInputsRequest()
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		req.ActivityID = c.Param("activity_id")
+		req.SetUserContext(getUserContext(c))
+		req.RequestID = c.GetString("request_id")
+		if req.RequestID == "" {
+			req.RequestID = generateRequestID()
+		}
+
+		result, err := service.SetActivityInputs(c.Request.Context(), &req)
+		if err != nil {
+			handleInputError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+// ListActivityInputs handles listing the input line items of a farm activity
+// @Summary List the inputs of a farm activity
+// @Tags farm-activities
+// @Produce json
+// @Param activity_id path string true "Activity ID"
+// @Success 200 {object} responses.ActivityInputsResponse
+// @Failure 403 {object} responses.SwaggerErrorResponse
+// @Failure 404 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /crops/activities/{activity_id}/inputs [get]
+func ListActivityInputs(service services.CultivationCostService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req := requests.ListActivityInputsRequest{
+			BaseRequest: requests.NewBaseRequest(),
+			ActivityID:  c.Param("activity_id"),
+		}
+		req.SetUserContext(getUserContext(c))
+		req.RequestID = c.GetString("request_id")
+		if req.RequestID == "" {
+			req.RequestID = generateRequestID()
+		}
+
+		result, err := service.ListActivityInputs(c.Request.Context(), &req)
+		if err != nil {
+			handleInputError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+// GetCycleCosts handles the cost of cultivation of a crop cycle
+// @Summary Get the cost of cultivation of a crop cycle
+// @Description Total the input costs of a crop cycle's completed activities by category, per hectare of the cycle's area and per unit of the yield recorded in its outcome, both in the yield unit as recorded and in kg (or numbers) under normalized_yield. Inputs of activities still planned are reported as planned cost.
+// @Tags Crop Cycles
+// @Produce json
+// @Param cycle_id path string true "Cycle ID"
+// @Success 200 {object} responses.CycleCostResponse
+// @Failure 403 {object} responses.SwaggerErrorResponse
+// @Failure 404 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /crops/cycles/{cycle_id}/costs [get]
+func GetCycleCosts(service services.CultivationCostService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req := requests.CycleCostsRequest{
+			BaseRequest: requests.NewBaseRequest(),
+			CropCycleID: c.Param("cycle_id"),
+		}
+		req.SetUserContext(getUserContext(c))
+		req.RequestID = c.GetString("request_id")
+		if req.RequestID == "" {
+			req.RequestID = generateRequestID()
+		}
+
+		result, err := service.GetCycleCosts(c.Request.Context(), &req)
+		if err != nil {
+			handleInputError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+// GetFarmCosts handles the cost of cultivation of a farm
+// @Summary Get the cost of cultivation of a farm
+// @Description Total the input costs of a farm's crop cycles by category and per hectare cropped, with the costs of each cycle and the production of each crop in kg (or numbers), optionally for a season and for cycles started in a year. Cancelled cycles are left out.
+// @Tags Farm Area
+// @Produce json
+// @Param farm_id path string true "Farm ID"
+// @Param season query string false "Season (RABI, KHARIF, ZAID, PERENNIAL, OTHER)"
+// @Param year query int false "Year the cycles started in"
+// @Success 200 {object} responses.FarmCostResponse
+// @Failure 400 {object} responses.SwaggerErrorResponse
+// @Failure 403 {object} responses.SwaggerErrorResponse
+// @Failure 404 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /farms/{farm_id}/costs [get]
+func GetFarmCosts(service services.CultivationCostService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req := requests.NewFarmCostsRequest()
+		req.FarmID = c.Param("farm_id")
+		req.Season = c.Query("season")
+		req.SetUserContext(getUserContext(c))
+		req.RequestID = c.GetString("request_id")
+		if req.RequestID == "" {
+			req.RequestID = generateRequestID()
+		}
+
+		switch req.Season {
+		case "", "RABI", "KHARIF", "ZAID", "PERENNIAL", "OTHER":
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "season must be one of RABI, KHARIF, ZAID, PERENNIAL, OTHER"})
+			return
+		}
+		if yearStr := c.Query("year"); yearStr != "" {
+			year, err := strconv.Atoi(yearStr)
+			if err != nil || year < 1900 || year > 2200 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "year must be a four-digit year"})
+				return
+			}
+			req.Year = year
+		}
+
+		result, err := service.GetFarmCosts(c.Request.Context(), &req)
+		if err != nil {
+			handleInputError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+// handleInputError maps input master and input line item errors: unknown products, units
+// other than the product's and negative costs are a bad request
+func handleInputError(c *gin.Context, err error) {
+	if errors.Is(err, common.ErrInvalidInput) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	handleServiceError(c, err)
+}
@@ -0,0 +1,237 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/requests"
+	"github.com/Kisanlink/farmers-module/internal/entities/responses"
+	"github.com/Kisanlink/farmers-module/internal/services"
+	"github.com/Kisanlink/farmers-module/pkg/common"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateLandParcel handles linking a land parcel to a farm
+// @Summary Link a land parcel to a farm
+// @Description Record a parcel from the state land records (survey or khasra number, khata, ownership or lease document) as part of a farm. Owned shares of a survey number may not add up to more than 100%, nor may the shares of leases that run at the same time. The farm's ownership type is derived from its parcels: LEASE while a lease is running, SHARED when an owned parcel is held in part, otherwise OWN.
+// @Tags land-parcels
+// @Accept json
+// @Produce json
+// @Param farm_id path string true "Farm ID"
+// @Param parcel body requests.LandParcelRequest true "Land parcel"
+// @Success 201 {object} responses.LandParcelResponse
+// @Failure 400 {object} responses.SwaggerErrorResponse
+// @Failure 403 {object} responses.SwaggerErrorResponse
+// @Failure 404 {object} responses.SwaggerErrorResponse
+// @Failure 409 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /farms/{farm_id}/parcels [post]
+func CreateLandParcel(service services.LandParcelService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req := requests.NewCreateLandParcelRequest()
+		if err := c.ShouldBindJSON(&req.LandParcelRequest); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		req.FarmID = c.Param("farm_id")
+		req.SetUserContext(getUserContext(c))
+		req.RequestID = c.GetString("request_id")
+		if req.RequestID == "" {
+			req.RequestID = generateRequestID()
+		}
+
+		result, err := service.CreateLandParcel(c.Request.Context(), &req)
+		if err != nil {
+			handleLandParcelError(c, err)
+			return
+		}
+
+		response, ok := result.(*responses.LandParcelResponse)
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "invalid response type"})
+			return
+		}
+
+		c.JSON(http.StatusCreated, response)
+	}
+}
+
+// UpdateLandParcel handles replacing the land record details of a parcel
+// @Summary Update a land parcel
+// @Description Replace the land record details of a parcel of a farm. Its claim on the survey number is checked again, and the farm's ownership type is derived again.
+// @Tags land-parcels
+// @Accept json
+// @Produce json
+// @Param farm_id path string true "Farm ID"
+// @Param parcel_id path string true "Land parcel ID"
+// @Param parcel body requests.LandParcelRequest true "Land parcel"
+// @Success 200 {object} responses.LandParcelResponse
+// @Failure 400 {object} responses.SwaggerErrorResponse
+// @Failure 403 {object} responses.SwaggerErrorResponse
+// @Failure 404 {object} responses.SwaggerErrorResponse
+// @Failure 409 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /farms/{farm_id}/parcels/{parcel_id} [put]
+func UpdateLandParcel(service services.LandParcelService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req := requests.NewUpdateLandParcelRequest()
+		if err := c.ShouldBindJSON(&req.LandParcelRequest); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		req.ID = c.Param("parcel_id")
+		req.FarmID = c.Param("farm_id")
+		req.SetUserContext(getUserContext(c))
+		req.RequestID = c.GetString("request_id")
+		if req.RequestID == "" {
+			req.RequestID = generateRequestID()
+		}
+
+		result, err := service.UpdateLandParcel(c.Request.Context(), &req)
+		if err != nil {
+			handleLandParcelError(c, err)
+			return
+		}
+
+		response, ok := result.(*responses.LandParcelResponse)
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "invalid response type"})
+			return
+		}
+
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// DeleteLandParcel handles unlinking a land parcel from a farm
+// @Summary Delete a land parcel
+// @Description Unlink a parcel from a farm, releasing its share of the survey number
+// @Tags land-parcels
+// @Produce json
+// @Param farm_id path string true "Farm ID"
+// @Param parcel_id path string true "Land parcel ID"
+// @Success 204 "Land parcel deleted successfully"
+// @Failure 403 {object} responses.SwaggerErrorResponse
+// @Failure 404 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /farms/{farm_id}/parcels/{parcel_id} [delete]
+func DeleteLandParcel(service services.LandParcelService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req := requests.DeleteLandParcelRequest{
+			BaseRequest: requests.NewBaseRequest(),
+			ID:          c.Param("parcel_id"),
+			FarmID:      c.Param("farm_id"),
+		}
+		req.SetUserContext(getUserContext(c))
+		req.RequestID = c.GetString("request_id")
+
+		if err := service.DeleteLandParcel(c.Request.Context(), &req); err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// ListFarmLandParcels handles listing the parcels of a farm
+// @Summary List the land parcels of a farm
+// @Tags land-parcels
+// @Produce json
+// @Param farm_id path string true "Farm ID"
+// @Success 200 {object} responses.LandParcelListResponse
+// @Failure 403 {object} responses.SwaggerErrorResponse
+// @Failure 404 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /farms/{farm_id}/parcels [get]
+func ListFarmLandParcels(service services.LandParcelService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		result, err := service.ListFarmLandParcels(c.Request.Context(), c.Param("farm_id"))
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		response, ok := result.(*responses.LandParcelListResponse)
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "invalid response type"})
+			return
+		}
+		response.SetRequestID(c.GetString("request_id"))
+
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// SearchLandParcels handles searching land parcels by their land record identifiers
+// @Summary Search land parcels
+// @Description Search an organization's land parcels by state, district, tehsil and village codes and survey number, for example to find every claim on one survey number. Codes are matched exactly, ignoring case.
+// @Tags land-parcels
+// @Produce json
+// @Param org_id query string false "Organization ID; defaults to the caller's organization"
+// @Param state_code query string false "State code"
+// @Param district_code query string false "District code"
+// @Param tehsil_code query string false "Tehsil code"
+// @Param village_code query string false "Village code"
+// @Param survey_number query string false "Survey or khasra number"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} responses.LandParcelListResponse
+// @Failure 400 {object} responses.SwaggerErrorResponse
+// @Failure 403 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /land-parcels [get]
+func SearchLandParcels(service services.LandParcelService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+		pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+		req := requests.NewSearchLandParcelsRequest()
+		req.PaginationRequest = requests.NewPaginationRequest(page, pageSize)
+		req.SetUserContext(getUserContext(c))
+		req.AAAOrgID = c.DefaultQuery("org_id", req.OrgID)
+		req.StateCode = c.Query("state_code")
+		req.DistrictCode = c.Query("district_code")
+		req.TehsilCode = c.Query("tehsil_code")
+		req.VillageCode = c.Query("village_code")
+		req.SurveyNumber = c.Query("survey_number")
+		req.RequestID = c.GetString("request_id")
+		if req.RequestID == "" {
+			req.RequestID = generateRequestID()
+		}
+
+		if req.AAAOrgID == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "org_id is required"})
+			return
+		}
+
+		result, err := service.SearchLandParcels(c.Request.Context(), &req)
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		response, ok := result.(*responses.LandParcelListResponse)
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "invalid response type"})
+			return
+		}
+
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// handleLandParcelError maps parcel write errors: invalid parcels are a bad request and
+// claims beyond the whole survey number a conflict
+func handleLandParcelError(c *gin.Context, err error) {
+	var exceeded *common.ParcelShareExceededError
+	switch {
+	case errors.Is(err, common.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.As(err, &exceeded):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		handleServiceError(c, err)
+	}
+}
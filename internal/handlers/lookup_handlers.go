@@ -1,9 +1,11 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/Kisanlink/farmers-module/internal/services"
+	"github.com/Kisanlink/farmers-module/pkg/common"
 	"github.com/gin-gonic/gin"
 )
 
@@ -67,6 +69,35 @@ func (h *LookupHandlers) GetIrrigationSources(c *gin.Context) {
 	})
 }
 
+// GetUnits handles GET /api/v1/lookups/units
+// @Summary Get units of measure
+// @Description Retrieve the units of measure that yields, areas and input quantities can be recorded in, optionally of one dimension (MASS, AREA, VOLUME or COUNT). factor is the number of canonical units (kg, ha, l or nos) in one unit; local units whose size differs between states, such as the bigha, have state_factors keyed by state code instead.
+// @Tags lookups
+// @Accept json
+// @Produce json
+// @Param dimension query string false "Dimension" Enums(MASS, AREA, VOLUME, COUNT)
+// @Success 200 {object} UnitsResponse
+// @Failure 400 {object} github_com_Kisanlink_farmers-module_internal_entities_responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /lookups/units [get]
+func (h *LookupHandlers) GetUnits(c *gin.Context) {
+	list, err := h.lookupService.GetUnits(c.Request.Context(), c.Query("dimension"))
+	if err != nil {
+		if errors.Is(err, common.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    list,
+		"success": true,
+		"message": "Units retrieved successfully",
+	})
+}
+
 // Response types for Swagger documentation
 
 // SoilTypesResponse represents the soil types response
@@ -83,6 +114,13 @@ type IrrigationSourcesResponse struct {
 	Data    interface{} `json:"data"`
 }
 
+// UnitsResponse represents the units of measure response
+type UnitsResponse struct {
+	Success bool        `json:"success" example:"true"`
+	Message string      `json:"message" example:"Units retrieved successfully"`
+	Data    interface{} `json:"data"`
+}
+
 // ErrorResponse represents an error response
 type ErrorResponse struct {
 	Error string `json:"error" example:"Failed to retrieve data"`
@@ -0,0 +1,231 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/requests"
+	"github.com/Kisanlink/farmers-module/internal/entities/responses"
+	"github.com/Kisanlink/farmers-module/internal/services"
+	"github.com/Kisanlink/farmers-module/pkg/common"
+	"github.com/gin-gonic/gin"
+)
+
+// CreatePlot handles adding a plot to a farm
+// @Summary Add a plot to a farm
+// @Description Divide a farm into plots (fields) that crop cycles can be grown on. The plot's boundary must lie within the farm's. Plots may overlap, for example when the farm is laid out differently from season to season, but planned or active crop cycles may not be grown on overlapping plots.
+// @Tags plots
+// @Accept json
+// @Produce json
+// @Param farm_id path string true "Farm ID"
+// @Param plot body requests.PlotRequest true "Plot"
+// @Success 201 {object} responses.PlotResponse
+// @Failure 400 {object} responses.SwaggerErrorResponse
+// @Failure 403 {object} responses.SwaggerErrorResponse
+// @Failure 404 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /farms/{farm_id}/plots [post]
+func CreatePlot(service services.PlotService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req := requests.NewCreatePlotRequest()
+		if err := c.ShouldBindJSON(&req.PlotRequest); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		req.FarmID = c.Param("farm_id")
+		req.SetUserContext(getUserContext(c))
+		req.RequestID = c.GetString("request_id")
+		if req.RequestID == "" {
+			req.RequestID = generateRequestID()
+		}
+
+		result, err := service.CreatePlot(c.Request.Context(), &req)
+		if err != nil {
+			handlePlotError(c, err)
+			return
+		}
+
+		response, ok := result.(*responses.PlotResponse)
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "invalid response type"})
+			return
+		}
+
+		c.JSON(http.StatusCreated, response)
+	}
+}
+
+// UpdatePlot handles renaming a plot or redrawing its boundary
+// @Summary Update a plot
+// @Description Rename a plot and, when a geometry is given, redraw its boundary within the farm's. A plot with planned or active crop cycles cannot be redrawn.
+// @Tags plots
+// @Accept json
+// @Produce json
+// @Param farm_id path string true "Farm ID"
+// @Param plot_id path string true "Plot ID"
+// @Param plot body requests.PlotRequest true "Plot"
+// @Success 200 {object} responses.PlotResponse
+// @Failure 400 {object} responses.SwaggerErrorResponse
+// @Failure 403 {object} responses.SwaggerErrorResponse
+// @Failure 404 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /farms/{farm_id}/plots/{plot_id} [put]
+func UpdatePlot(service services.PlotService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req := requests.NewUpdatePlotRequest()
+		if err := c.ShouldBindJSON(&req.PlotRequest); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		req.ID = c.Param("plot_id")
+		req.FarmID = c.Param("farm_id")
+		req.SetUserContext(getUserContext(c))
+		req.RequestID = c.GetString("request_id")
+		if req.RequestID == "" {
+			req.RequestID = generateRequestID()
+		}
+
+		result, err := service.UpdatePlot(c.Request.Context(), &req)
+		if err != nil {
+			handlePlotError(c, err)
+			return
+		}
+
+		response, ok := result.(*responses.PlotResponse)
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "invalid response type"})
+			return
+		}
+
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// DeletePlot handles removing a plot from a farm
+// @Summary Delete a plot
+// @Description Remove a plot from a farm. A plot with planned or active crop cycles cannot be removed.
+// @Tags plots
+// @Produce json
+// @Param farm_id path string true "Farm ID"
+// @Param plot_id path string true "Plot ID"
+// @Success 204 "Plot deleted successfully"
+// @Failure 400 {object} responses.SwaggerErrorResponse
+// @Failure 403 {object} responses.SwaggerErrorResponse
+// @Failure 404 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /farms/{farm_id}/plots/{plot_id} [delete]
+func DeletePlot(service services.PlotService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req := requests.DeletePlotRequest{
+			BaseRequest: requests.NewBaseRequest(),
+			ID:          c.Param("plot_id"),
+			FarmID:      c.Param("farm_id"),
+		}
+		req.SetUserContext(getUserContext(c))
+		req.RequestID = c.GetString("request_id")
+
+		if err := service.DeletePlot(c.Request.Context(), &req); err != nil {
+			handlePlotError(c, err)
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// ListFarmPlots handles listing the plots of a farm
+// @Summary List the plots of a farm
+// @Tags plots
+// @Produce json
+// @Param farm_id path string true "Farm ID"
+// @Success 200 {object} responses.PlotListResponse
+// @Failure 403 {object} responses.SwaggerErrorResponse
+// @Failure 404 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /farms/{farm_id}/plots [get]
+func ListFarmPlots(service services.PlotService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		result, err := service.ListFarmPlots(c.Request.Context(), c.Param("farm_id"))
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		response, ok := result.(*responses.PlotListResponse)
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "invalid response type"})
+			return
+		}
+		response.SetRequestID(c.GetString("request_id"))
+
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// GetPlotMap handles the map of a farm's plots for a season
+// @Summary Map the plots of a farm for a season
+// @Description Return the plots of a farm as an RFC 7946 FeatureCollection. Each plot carries the crop cycles grown on it in the season, optionally only those started in the year; a plot without cycles was left fallow. Without a season the planned and active cycles are given.
+// @Tags plots
+// @Produce application/geo+json
+// @Param farm_id path string true "Farm ID"
+// @Param season query string false "Season (RABI, KHARIF, ZAID, PERENNIAL, OTHER)"
+// @Param year query int false "Year the cycles started in"
+// @Success 200 {object} responses.PlotFeatureCollection
+// @Failure 400 {object} responses.SwaggerErrorResponse
+// @Failure 403 {object} responses.SwaggerErrorResponse
+// @Failure 404 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /farms/{farm_id}/plots/map [get]
+func GetPlotMap(service services.PlotService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req := requests.NewPlotMapRequest()
+		req.FarmID = c.Param("farm_id")
+		req.Season = c.Query("season")
+		req.SetUserContext(getUserContext(c))
+		req.RequestID = c.GetString("request_id")
+		if req.RequestID == "" {
+			req.RequestID = generateRequestID()
+		}
+
+		switch req.Season {
+		case "", "RABI", "KHARIF", "ZAID", "PERENNIAL", "OTHER":
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "season must be one of RABI, KHARIF, ZAID, PERENNIAL, OTHER"})
+			return
+		}
+		if yearStr := c.Query("year"); yearStr != "" {
+			year, err := strconv.Atoi(yearStr)
+			if err != nil || year < 1900 || year > 2200 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "year must be a four-digit year"})
+				return
+			}
+			req.Year = year
+		}
+
+		result, err := service.GetPlotMap(c.Request.Context(), &req)
+		if err != nil {
+			handlePlotError(c, err)
+			return
+		}
+
+		body, err := json.Marshal(result)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to encode GeoJSON"})
+			return
+		}
+
+		c.Data(http.StatusOK, "application/geo+json", body)
+	}
+}
+
+// handlePlotError maps plot errors: boundaries outside the farm and changes to plots in use
+// are a bad request
+func handlePlotError(c *gin.Context, err error) {
+	if errors.Is(err, common.ErrInvalidInput) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	handleServiceError(c, err)
+}
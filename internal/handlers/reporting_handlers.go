@@ -215,6 +215,10 @@ func (h *ReportingHandlers) ExportFarmerPortfolioByID(c *gin.Context) {
 // @Param season query string false "Season filter" Enums(RABI, KHARIF, ZAID, PERENNIAL, OTHER)
 // @Param start_date query string false "Start date filter (RFC3339 format)"
 // @Param end_date query string false "End date filter (RFC3339 format)"
+// @Param state_code query string false "Count only farms in the state"
+// @Param district_code query string false "Count only farms in the district"
+// @Param block_code query string false "Count only farms in the block"
+// @Param village_code query string false "Count only farms in the village"
 // @Success 200 {object} responses.SwaggerOrgDashboardCountersResponse
 // @Failure 400 {object} responses.SwaggerErrorResponse
 // @Failure 401 {object} responses.SwaggerErrorResponse
@@ -230,7 +234,8 @@ func (h *ReportingHandlers) OrgDashboardCountersByID(c *gin.Context) {
 
 	// Build request from query parameters
 	req := requests.OrgDashboardCountersRequest{
-		Season: c.Query("season"),
+		Season:          c.Query("season"),
+		AdminAreaFilter: parseAdminAreaQuery(c),
 	}
 
 	// Parse date filters if provided
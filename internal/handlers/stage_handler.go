@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -540,17 +541,17 @@ func (h *StageHandler) handleServiceError(c *gin.Context, err error) {
 	status := http.StatusInternalServerError
 	var apiError base.ErrorInterface
 
-	switch err {
-	case common.ErrInvalidInput:
+	switch {
+	case errors.Is(err, common.ErrInvalidInput):
 		status = http.StatusBadRequest
 		apiError = base.NewValidationError("Invalid input", err.Error())
-	case common.ErrNotFound:
+	case errors.Is(err, common.ErrNotFound):
 		status = http.StatusNotFound
 		apiError = base.NewNotFoundError("Resource", "")
-	case common.ErrAlreadyExists:
+	case errors.Is(err, common.ErrAlreadyExists):
 		status = http.StatusConflict
 		apiError = base.NewConflictError("Resource", err.Error())
-	case common.ErrForbidden:
+	case errors.Is(err, common.ErrForbidden):
 		status = http.StatusForbidden
 		apiError = base.NewForbiddenError("Insufficient permissions")
 	default:
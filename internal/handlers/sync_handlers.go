@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/requests"
+	"github.com/Kisanlink/farmers-module/internal/services"
+	"github.com/Kisanlink/farmers-module/pkg/common"
+	"github.com/gin-gonic/gin"
+)
+
+// maxSyncPushMutations bounds the number of mutations in one push
+const maxSyncPushMutations = 500
+
+// GetSyncChanges handles the offline sync change feed
+// @Summary Get changes since a sync cursor
+// @Description Return the farmers, farms, crop cycles and farm activities changed since the cursor, limited to the farmers the caller is or is assigned to as KisanSathi. Changes are ordered by change time and deleted records are returned with deleted_at set. Omit the cursor for a full sync, store next_cursor and request again while has_more is set.
+// @Tags sync
+// @Produce json
+// @Param cursor query string false "Cursor returned by the previous call"
+// @Param limit query int false "Maximum number of changes" default(200)
+// @Success 200 {object} responses.SyncChangesResponse
+// @Failure 400 {object} responses.SwaggerErrorResponse
+// @Failure 401 {object} responses.SwaggerErrorResponse
+// @Failure 403 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /sync/changes [get]
+func GetSyncChanges(service services.SyncService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req requests.SyncChangesRequest
+		req.Cursor = c.Query("cursor")
+		req.Limit = parseIntQuery(c, "limit", services.DefaultSyncChangesLimit)
+		if req.Limit < 1 || req.Limit > services.MaxSyncChangesLimit {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 1000"})
+			return
+		}
+
+		req.SetUserContext(getUserContext(c))
+		req.RequestID = c.GetString("request_id")
+		if req.RequestID == "" {
+			req.RequestID = generateRequestID()
+		}
+
+		response, err := service.GetChanges(c.Request.Context(), &req)
+		if err != nil {
+			if errors.Is(err, common.ErrInvalidInput) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			handleServiceError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// PushSyncChanges handles a batch of offline mutations
+// @Summary Push offline changes
+// @Description Apply creates, updates, deletes, crop cycle ends and activity completions made offline, in order. Changes to existing records must carry the updated_at of the copy they were made on; when the record has changed since, the result is a CONFLICT with the server copy. Every mutation gets its own result, so the request succeeds even when some mutations are rejected.
+// @Tags sync
+// @Accept json
+// @Produce json
+// @Param push body requests.SyncPushRequest true "Offline mutations"
+// @Success 200 {object} responses.SyncPushResponse
+// @Failure 400 {object} responses.SwaggerErrorResponse
+// @Failure 401 {object} responses.SwaggerErrorResponse
+// @Failure 403 {object} responses.SwaggerErrorResponse
+// @Security BearerAuth
+// @Router /sync/push [post]
+func PushSyncChanges(service services.SyncService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req requests.SyncPushRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if len(req.Mutations) == 0 || len(req.Mutations) > maxSyncPushMutations {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "mutations must contain between 1 and 500 entries"})
+			return
+		}
+		for _, mutation := range req.Mutations {
+			if mutation.MutationID == "" || mutation.Entity == "" || mutation.Operation == "" {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "every mutation requires mutation_id, entity and operation"})
+				return
+			}
+		}
+
+		req.SetUserContext(getUserContext(c))
+		req.RequestID = c.GetString("request_id")
+		if req.RequestID == "" {
+			req.RequestID = generateRequestID()
+		}
+
+		response, err := service.PushChanges(c.Request.Context(), &req)
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, response)
+	}
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/requests"
+	"github.com/Kisanlink/farmers-module/internal/entities/responses"
+	"github.com/Kisanlink/farmers-module/internal/handlers"
+	"github.com/Kisanlink/farmers-module/internal/testutils"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupCropCycleImportTestRouter(handler *handlers.BulkCropCycleImportHandler) *gin.Engine {
+	router := testutils.SetupTestRouter()
+	router.POST("/api/v1/bulk/crop-cycles/import", handler.ImportCropCycles)
+	return router
+}
+
+func TestBulkCropCycleImportHandler_ImportCropCycles_Multipart(t *testing.T) {
+	mockService := &testutils.MockBulkCropCycleImportService{}
+
+	var received *requests.BulkCropCycleImportRequest
+	mockService.ImportCropCyclesFunc = func(ctx context.Context, req *requests.BulkCropCycleImportRequest) (*responses.BulkOperationData, error) {
+		received = req
+		return &responses.BulkOperationData{
+			OperationID: "BLKO00000002",
+			Status:      "PENDING",
+			StatusURL:   "/api/v1/bulk/status/BLKO00000002",
+			Message:     "Crop cycle import initiated for 2 rows",
+		}, nil
+	}
+
+	handler := handlers.NewBulkCropCycleImportHandler(mockService, &testutils.MockAAAService{}, &testutils.MockLogger{})
+	router := setupCropCycleImportTestRouter(handler)
+
+	// farmImportMultipart builds a generic multipart upload with a "file" part
+	body, contentType := farmImportMultipart(t, map[string]string{
+		"fpo_org_id": "fpo_123",
+		"options":    `{"season":"KHARIF","continue_on_error":true}`,
+	}, "sowing_survey.xlsx", []byte("PK..."))
+
+	req := httptest.NewRequest("POST", "/api/v1/bulk/crop-cycles/import", body)
+	req.Header.Set("Content-Type", contentType)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	require.NotNil(t, received)
+	assert.Equal(t, "fpo_123", received.FPOOrgID)
+	assert.Equal(t, "excel", received.InputFormat, "format is detected from the file extension")
+	assert.Equal(t, "KHARIF", received.Options.Season)
+	assert.True(t, received.Options.ContinueOnError)
+
+	var response responses.BulkOperationResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "BLKO00000002", response.Data.OperationID)
+}
+
+func TestBulkCropCycleImportHandler_ImportCropCycles_PermissionDenied(t *testing.T) {
+	mockService := &testutils.MockBulkCropCycleImportService{}
+	mockService.ImportCropCyclesFunc = func(ctx context.Context, req *requests.BulkCropCycleImportRequest) (*responses.BulkOperationData, error) {
+		t.Fatal("import must not start without permission")
+		return nil, nil
+	}
+
+	var checkedResource, checkedAction string
+	mockAAAService := &testutils.MockAAAService{
+		CheckPermissionFunc: func(ctx context.Context, subject, resource, action, object, orgID string) (bool, error) {
+			checkedResource, checkedAction = resource, action
+			return false, nil
+		},
+	}
+
+	handler := handlers.NewBulkCropCycleImportHandler(mockService, mockAAAService, &testutils.MockLogger{})
+	router := setupCropCycleImportTestRouter(handler)
+
+	jsonBody, _ := json.Marshal(requests.BulkCropCycleImportRequest{
+		FPOOrgID:    "fpo_123",
+		InputFormat: "csv",
+		Data:        []byte("farm_id,crop,sowing_date\nFARM00000001,Paddy,2024-06-20\n"),
+	})
+	req := httptest.NewRequest("POST", "/api/v1/bulk/crop-cycles/import", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Equal(t, "cycle", checkedResource)
+	assert.Equal(t, "bulk_create", checkedAction)
+}
+
+func TestBulkCropCycleImportHandler_ImportCropCycles_ParseError(t *testing.T) {
+	mockService := &testutils.MockBulkCropCycleImportService{}
+	mockService.ImportCropCyclesFunc = func(ctx context.Context, req *requests.BulkCropCycleImportRequest) (*responses.BulkOperationData, error) {
+		return nil, errors.New("failed to parse input data: missing required columns: [sowing_date]")
+	}
+
+	handler := handlers.NewBulkCropCycleImportHandler(mockService, &testutils.MockAAAService{}, &testutils.MockLogger{})
+	router := setupCropCycleImportTestRouter(handler)
+
+	body, contentType := farmImportMultipart(t, map[string]string{"fpo_org_id": "fpo_123"}, "survey.csv", []byte("farm_id,crop\n"))
+	req := httptest.NewRequest("POST", "/api/v1/bulk/crop-cycles/import", body)
+	req.Header.Set("Content-Type", contentType)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "sowing_date")
+}
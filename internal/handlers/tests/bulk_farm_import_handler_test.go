@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/requests"
+	"github.com/Kisanlink/farmers-module/internal/entities/responses"
+	"github.com/Kisanlink/farmers-module/internal/handlers"
+	"github.com/Kisanlink/farmers-module/internal/testutils"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupFarmImportTestRouter(handler *handlers.BulkFarmImportHandler) *gin.Engine {
+	router := testutils.SetupTestRouter()
+	router.POST("/api/v1/bulk/farms/import", handler.ImportFarms)
+	return router
+}
+
+func farmImportMultipart(t *testing.T, fields map[string]string, filename string, content []byte) (*bytes.Buffer, string) {
+	t.Helper()
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for key, value := range fields {
+		require.NoError(t, writer.WriteField(key, value))
+	}
+	part, err := writer.CreateFormFile("file", filename)
+	require.NoError(t, err)
+	_, _ = part.Write(content)
+	require.NoError(t, writer.Close())
+	return body, writer.FormDataContentType()
+}
+
+func TestBulkFarmImportHandler_ImportFarms_Multipart(t *testing.T) {
+	mockService := &testutils.MockBulkFarmImportService{}
+
+	var received *requests.BulkFarmImportRequest
+	mockService.ImportFarmsFunc = func(ctx context.Context, req *requests.BulkFarmImportRequest) (*responses.BulkOperationData, error) {
+		received = req
+		return &responses.BulkOperationData{
+			OperationID: "BLKO00000001",
+			Status:      "PENDING",
+			StatusURL:   "/api/v1/bulk/status/BLKO00000001",
+			Message:     "Farm import initiated for 2 features",
+		}, nil
+	}
+
+	handler := handlers.NewBulkFarmImportHandler(mockService, &testutils.MockAAAService{}, &testutils.MockLogger{})
+	router := setupFarmImportTestRouter(handler)
+
+	body, contentType := farmImportMultipart(t, map[string]string{
+		"fpo_org_id": "fpo_123",
+		"options":    `{"match_by":"phone","allow_overlap":true,"continue_on_error":true}`,
+	}, "village_farms.kmz", []byte("PK..."))
+
+	req := httptest.NewRequest("POST", "/api/v1/bulk/farms/import", body)
+	req.Header.Set("Content-Type", contentType)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	require.NotNil(t, received)
+	assert.Equal(t, "fpo_123", received.FPOOrgID)
+	assert.Equal(t, "kml", received.InputFormat, "format is detected from the file extension")
+	assert.Equal(t, "phone", received.Options.MatchBy)
+	assert.True(t, received.Options.AllowOverlap)
+
+	var response responses.BulkOperationResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "BLKO00000001", response.Data.OperationID)
+}
+
+func TestBulkFarmImportHandler_ImportFarms_MissingFormat(t *testing.T) {
+	handler := handlers.NewBulkFarmImportHandler(&testutils.MockBulkFarmImportService{}, &testutils.MockAAAService{}, &testutils.MockLogger{})
+	router := setupFarmImportTestRouter(handler)
+
+	body, contentType := farmImportMultipart(t, map[string]string{"fpo_org_id": "fpo_123"}, "farms.gpx", []byte("<gpx/>"))
+	req := httptest.NewRequest("POST", "/api/v1/bulk/farms/import", body)
+	req.Header.Set("Content-Type", contentType)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestBulkFarmImportHandler_ImportFarms_PermissionDenied(t *testing.T) {
+	mockService := &testutils.MockBulkFarmImportService{}
+	mockService.ImportFarmsFunc = func(ctx context.Context, req *requests.BulkFarmImportRequest) (*responses.BulkOperationData, error) {
+		t.Fatal("import must not start without permission")
+		return nil, nil
+	}
+
+	var checkedResource, checkedAction string
+	mockAAAService := &testutils.MockAAAService{
+		CheckPermissionFunc: func(ctx context.Context, subject, resource, action, object, orgID string) (bool, error) {
+			checkedResource, checkedAction = resource, action
+			return false, nil
+		},
+	}
+
+	handler := handlers.NewBulkFarmImportHandler(mockService, mockAAAService, &testutils.MockLogger{})
+	router := setupFarmImportTestRouter(handler)
+
+	jsonBody, _ := json.Marshal(requests.BulkFarmImportRequest{
+		FPOOrgID:    "fpo_123",
+		InputFormat: "geojson",
+		Data:        []byte(`{"type":"FeatureCollection","features":[]}`),
+	})
+	req := httptest.NewRequest("POST", "/api/v1/bulk/farms/import", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Equal(t, "farm", checkedResource)
+	assert.Equal(t, "bulk_create", checkedAction)
+}
+
+func TestBulkFarmImportHandler_ImportFarms_ParseError(t *testing.T) {
+	mockService := &testutils.MockBulkFarmImportService{}
+	mockService.ImportFarmsFunc = func(ctx context.Context, req *requests.BulkFarmImportRequest) (*responses.BulkOperationData, error) {
+		return nil, errors.New("failed to parse input data: shapefile projection must be WGS 84 (EPSG:4326)")
+	}
+
+	handler := handlers.NewBulkFarmImportHandler(mockService, &testutils.MockAAAService{}, &testutils.MockLogger{})
+	router := setupFarmImportTestRouter(handler)
+
+	body, contentType := farmImportMultipart(t, map[string]string{"fpo_org_id": "fpo_123"}, "farms.zip", []byte("PK..."))
+	req := httptest.NewRequest("POST", "/api/v1/bulk/farms/import", body)
+	req.Header.Set("Content-Type", contentType)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "WGS 84")
+}
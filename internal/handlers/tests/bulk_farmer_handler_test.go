@@ -28,10 +28,18 @@ func setupBulkTestRouter(handler *handlers.BulkFarmerHandler) *gin.Engine {
 		bulk.POST("/farmers/add", handler.BulkAddFarmers)
 		bulk.GET("/status/:operation_id", handler.GetBulkOperationStatus)
 		bulk.POST("/cancel/:operation_id", handler.CancelBulkOperation)
+		bulk.POST("/pause/:operation_id", handler.PauseBulkOperation)
+		bulk.POST("/resume/:operation_id", handler.ResumeBulkOperation)
 		bulk.POST("/retry/:operation_id", handler.RetryFailedRecords)
 		bulk.GET("/results/:operation_id", handler.DownloadBulkResults)
 		bulk.GET("/template", handler.GetBulkUploadTemplate)
 		bulk.POST("/validate", handler.ValidateBulkData)
+		bulk.POST("/mapping-profiles", handler.CreateMappingProfile)
+		bulk.GET("/mapping-profiles", handler.ListMappingProfiles)
+		bulk.POST("/mapping-profiles/preview", handler.PreviewColumnMapping)
+		bulk.GET("/mapping-profiles/:profile_id", handler.GetMappingProfile)
+		bulk.PUT("/mapping-profiles/:profile_id", handler.UpdateMappingProfile)
+		bulk.DELETE("/mapping-profiles/:profile_id", handler.DeleteMappingProfile)
 	}
 	return router
 }
@@ -262,6 +270,83 @@ func TestBulkFarmerHandler_CancelBulkOperation(t *testing.T) {
 
 }
 
+func TestBulkFarmerHandler_PauseBulkOperation(t *testing.T) {
+	mockService := &testutils.MockBulkFarmerService{}
+	mockLogger := &testutils.MockLogger{}
+
+	mockAAAService := &testutils.MockAAAService{}
+	handler := handlers.NewBulkFarmerHandler(mockService, mockAAAService, mockLogger)
+	router := setupBulkTestRouter(handler)
+
+	var pausedID string
+	mockService.PauseBulkOperationFunc = func(ctx context.Context, operationID string) error {
+		pausedID = operationID
+		return nil
+	}
+
+	req := httptest.NewRequest("POST", "/api/v1/bulk/pause/bulk_op_123", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "bulk_op_123", pausedID)
+
+	var response responses.BaseResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Contains(t, response.Message, "paused successfully")
+
+	// Completed operations cannot be paused
+	mockService.PauseBulkOperationFunc = func(ctx context.Context, operationID string) error {
+		return errors.New("operation cannot be paused: status=COMPLETED")
+	}
+
+	req = httptest.NewRequest("POST", "/api/v1/bulk/pause/bulk_op_123", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestBulkFarmerHandler_ResumeBulkOperation(t *testing.T) {
+	mockService := &testutils.MockBulkFarmerService{}
+	mockLogger := &testutils.MockLogger{}
+
+	mockAAAService := &testutils.MockAAAService{}
+	handler := handlers.NewBulkFarmerHandler(mockService, mockAAAService, mockLogger)
+	router := setupBulkTestRouter(handler)
+
+	mockService.ResumeBulkOperationFunc = func(ctx context.Context, operationID string) (*responses.BulkOperationData, error) {
+		return &responses.BulkOperationData{
+			OperationID: operationID,
+			Status:      "PROCESSING",
+			Message:     "Bulk operation resumed for 40 remaining farmers",
+		}, nil
+	}
+
+	req := httptest.NewRequest("POST", "/api/v1/bulk/resume/bulk_op_123", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusAccepted, w.Code)
+
+	var response responses.BulkOperationResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "bulk_op_123", response.Data.OperationID)
+	assert.Equal(t, "PROCESSING", response.Data.Status)
+
+	mockService.ResumeBulkOperationFunc = func(ctx context.Context, operationID string) (*responses.BulkOperationData, error) {
+		return nil, errors.New("operation cannot be resumed: status=PROCESSING")
+	}
+
+	req = httptest.NewRequest("POST", "/api/v1/bulk/resume/bulk_op_123", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
 func TestBulkFarmerHandler_GetBulkUploadTemplate(t *testing.T) {
 	mockService := &testutils.MockBulkFarmerService{}
 	mockLogger := &testutils.MockLogger{}
@@ -292,6 +377,77 @@ func TestBulkFarmerHandler_GetBulkUploadTemplate(t *testing.T) {
 
 }
 
+func TestBulkFarmerHandler_DownloadBulkResults(t *testing.T) {
+	mockService := &testutils.MockBulkFarmerService{}
+	mockLogger := &testutils.MockLogger{}
+
+	mockAAAService := &testutils.MockAAAService{}
+	handler := handlers.NewBulkFarmerHandler(mockService, mockAAAService, mockLogger)
+	router := setupBulkTestRouter(handler)
+
+	var gotFormat string
+	var gotIncludeAll bool
+	mockService.GenerateResultFileFunc = func(ctx context.Context, operationID string, format string, includeAll bool) ([]byte, error) {
+		gotFormat = format
+		gotIncludeAll = includeAll
+		return []byte("first_name,result_status\nJohn,failed\n"), nil
+	}
+
+	req := httptest.NewRequest("GET", "/api/v1/bulk/results/bulk_op_123?format=csv&include_all=false", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "bulk_operation_bulk_op_123_results.csv")
+	assert.Equal(t, "first_name,result_status\nJohn,failed\n", w.Body.String())
+	assert.Equal(t, "csv", gotFormat)
+	assert.False(t, gotIncludeAll)
+
+	// All records are included by default
+	req = httptest.NewRequest("GET", "/api/v1/bulk/results/bulk_op_123?format=json", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.True(t, gotIncludeAll)
+}
+
+func TestBulkFarmerHandler_DownloadBulkResults_InvalidFormat(t *testing.T) {
+	mockService := &testutils.MockBulkFarmerService{}
+	mockLogger := &testutils.MockLogger{}
+
+	mockAAAService := &testutils.MockAAAService{}
+	handler := handlers.NewBulkFarmerHandler(mockService, mockAAAService, mockLogger)
+	router := setupBulkTestRouter(handler)
+
+	req := httptest.NewRequest("GET", "/api/v1/bulk/results/bulk_op_123?format=pdf", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestBulkFarmerHandler_DownloadBulkResults_NotFound(t *testing.T) {
+	mockService := &testutils.MockBulkFarmerService{}
+	mockLogger := &testutils.MockLogger{}
+
+	mockAAAService := &testutils.MockAAAService{}
+	handler := handlers.NewBulkFarmerHandler(mockService, mockAAAService, mockLogger)
+	router := setupBulkTestRouter(handler)
+
+	mockService.GenerateResultFileFunc = func(ctx context.Context, operationID string, format string, includeAll bool) ([]byte, error) {
+		return nil, errors.New("failed to get bulk operation: bulk operation not found: bulk_op_404")
+	}
+
+	req := httptest.NewRequest("GET", "/api/v1/bulk/results/bulk_op_404", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
 func TestBulkFarmerHandler_ValidateBulkData(t *testing.T) {
 	mockService := &testutils.MockBulkFarmerService{}
 	mockLogger := &testutils.MockLogger{}
@@ -366,3 +522,181 @@ func TestBulkFarmerHandler_UnsupportedContentType(t *testing.T) {
 
 	assert.Contains(t, response["error"], "Unsupported content type")
 }
+
+func TestBulkFarmerHandler_CreateMappingProfile(t *testing.T) {
+	mockService := &testutils.MockBulkFarmerService{}
+	mockLogger := &testutils.MockLogger{}
+
+	var received *requests.ColumnMappingProfileRequest
+	mockService.CreateMappingProfileFunc = func(ctx context.Context, req *requests.ColumnMappingProfileRequest) (*responses.ColumnMappingProfileData, error) {
+		received = req
+		return &responses.ColumnMappingProfileData{ID: "BLKM00000001", FPOOrgID: req.FPOOrgID, Name: req.Name}, nil
+	}
+
+	handler := handlers.NewBulkFarmerHandler(mockService, &testutils.MockAAAService{}, mockLogger)
+	router := setupBulkTestRouter(handler)
+
+	reqBody := requests.ColumnMappingProfileRequest{
+		FPOOrgID: "fpo_123",
+		Name:     "Register",
+		Mappings: []requests.ColumnMappingRule{
+			{Target: "first_name", Source: "Farmer Name", Transform: "split_name", Part: "first"},
+			{Target: "gender", Source: "Sex", Transform: "lookup", Lookup: map[string]string{"M": "male"}},
+		},
+	}
+
+	jsonBody, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("POST", "/api/v1/bulk/mapping-profiles", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	require.NotNil(t, received)
+	assert.Len(t, received.Mappings, 2)
+	assert.Equal(t, "male", received.Mappings[1].Lookup["M"])
+
+	var response responses.ColumnMappingProfileResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "BLKM00000001", response.Data.ID)
+}
+
+func TestBulkFarmerHandler_CreateMappingProfile_Invalid(t *testing.T) {
+	mockService := &testutils.MockBulkFarmerService{}
+	mockLogger := &testutils.MockLogger{}
+
+	mockService.CreateMappingProfileFunc = func(ctx context.Context, req *requests.ColumnMappingProfileRequest) (*responses.ColumnMappingProfileData, error) {
+		return nil, errors.New("invalid mapping profile: mapping 0: unknown target field \"aadhaar\"")
+	}
+
+	handler := handlers.NewBulkFarmerHandler(mockService, &testutils.MockAAAService{}, mockLogger)
+	router := setupBulkTestRouter(handler)
+
+	body := `{"fpo_org_id":"fpo_123","name":"Register","mappings":[{"target":"aadhaar","source":"Aadhaar"}]}`
+	req := httptest.NewRequest("POST", "/api/v1/bulk/mapping-profiles", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "unknown target field")
+}
+
+func TestBulkFarmerHandler_ListMappingProfiles(t *testing.T) {
+	mockService := &testutils.MockBulkFarmerService{}
+	mockLogger := &testutils.MockLogger{}
+
+	mockService.ListMappingProfilesFunc = func(ctx context.Context, fpoOrgID, orgID string) ([]*responses.ColumnMappingProfileData, error) {
+		assert.Equal(t, "fpo_123", fpoOrgID)
+		assert.Equal(t, "test-org-id", orgID)
+		return []*responses.ColumnMappingProfileData{{ID: "BLKM00000001"}, {ID: "BLKM00000002"}}, nil
+	}
+
+	handler := handlers.NewBulkFarmerHandler(mockService, &testutils.MockAAAService{}, mockLogger)
+	router := setupBulkTestRouter(handler)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/bulk/mapping-profiles?fpo_org_id=fpo_123", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response responses.ColumnMappingProfileListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response.Data, 2)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/bulk/mapping-profiles", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestBulkFarmerHandler_GetMappingProfile_NotFound(t *testing.T) {
+	mockService := &testutils.MockBulkFarmerService{}
+	mockLogger := &testutils.MockLogger{}
+
+	var scopedTo string
+	mockService.GetMappingProfileFunc = func(ctx context.Context, profileID, orgID string) (*responses.ColumnMappingProfileData, error) {
+		scopedTo = orgID
+		return nil, errors.New("mapping profile not found: " + profileID)
+	}
+
+	handler := handlers.NewBulkFarmerHandler(mockService, &testutils.MockAAAService{}, mockLogger)
+	router := setupBulkTestRouter(handler)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/bulk/mapping-profiles/BLKM00000009", nil))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, "test-org-id", scopedTo)
+}
+
+func TestBulkFarmerHandler_PreviewColumnMapping_Multipart(t *testing.T) {
+	mockService := &testutils.MockBulkFarmerService{}
+	mockLogger := &testutils.MockLogger{}
+
+	var received *requests.PreviewColumnMappingRequest
+	mockService.PreviewColumnMappingFunc = func(ctx context.Context, req *requests.PreviewColumnMappingRequest) (*responses.ColumnMappingPreviewData, error) {
+		received = req
+		return &responses.ColumnMappingPreviewData{
+			SourceHeaders: []string{"Farmer Name", "Mobile No"},
+			TotalRecords:  1,
+			ValidRecords:  1,
+			Records: []responses.MappedRecordPreview{
+				{RowNumber: 2, Mapped: map[string]interface{}{"first_name": "Ramesh"}},
+			},
+		}, nil
+	}
+
+	handler := handlers.NewBulkFarmerHandler(mockService, &testutils.MockAAAService{}, mockLogger)
+	router := setupBulkTestRouter(handler)
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	_ = writer.WriteField("fpo_org_id", "fpo_123")
+	_ = writer.WriteField("input_format", "csv")
+	_ = writer.WriteField("profile_id", "BLKM00000001")
+	_ = writer.WriteField("limit", "5")
+	part, _ := writer.CreateFormFile("file", "register.csv")
+	_, _ = part.Write([]byte("Farmer Name,Mobile No\nRamesh Kumar,9876543210"))
+	_ = writer.Close()
+
+	req := httptest.NewRequest("POST", "/api/v1/bulk/mapping-profiles/preview", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	require.NotNil(t, received)
+	assert.Equal(t, "BLKM00000001", received.ProfileID)
+	assert.Equal(t, 5, received.Limit)
+	assert.NotEmpty(t, received.Data)
+
+	var response responses.ColumnMappingPreviewResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "Ramesh", response.Data.Records[0].Mapped["first_name"])
+}
+
+func TestBulkFarmerHandler_PreviewColumnMapping_RequiresMappings(t *testing.T) {
+	mockService := &testutils.MockBulkFarmerService{}
+	mockLogger := &testutils.MockLogger{}
+
+	handler := handlers.NewBulkFarmerHandler(mockService, &testutils.MockAAAService{}, mockLogger)
+	router := setupBulkTestRouter(handler)
+
+	reqBody := requests.PreviewColumnMappingRequest{
+		FPOOrgID:    "fpo_123",
+		InputFormat: "csv",
+		Data:        []byte("Farmer Name\nRamesh Kumar"),
+	}
+
+	jsonBody, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest("POST", "/api/v1/bulk/mapping-profiles/preview", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
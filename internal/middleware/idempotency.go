@@ -0,0 +1,202 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/Kisanlink/farmers-module/internal/auth"
+	"github.com/Kisanlink/farmers-module/internal/interfaces"
+	"github.com/Kisanlink/farmers-module/internal/services"
+	"github.com/Kisanlink/farmers-module/pkg/common"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	// IdempotencyKeyHeader carries the client-chosen key of a mutating request
+	IdempotencyKeyHeader = "Idempotency-Key"
+
+	// IdempotentReplayedHeader is set on responses replayed from an earlier request
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+)
+
+// IdempotencyMiddleware honours the Idempotency-Key header on mutating requests. The first
+// request with a key runs normally and its response is stored per key and tenant; retries
+// with the same key and body are answered with the stored response, and reusing the key for
+// a different request is rejected. Server errors are not stored, so such requests can be
+// retried with the same key. It must run after authentication, which provides the tenant.
+func IdempotencyMiddleware(idempotencyService services.IdempotencyService, logger interfaces.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := strings.TrimSpace(c.GetHeader(IdempotencyKeyHeader))
+		if key == "" || !isMutatingMethod(c.Request.Method) || idempotencyService == nil {
+			c.Next()
+			return
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			c.JSON(http.StatusBadRequest, common.ErrorResponse{
+				Error:         "bad_request",
+				Message:       "Idempotency-Key must be at most 255 characters",
+				Code:          "IDEMPOTENCY_KEY_INVALID",
+				CorrelationID: getRequestIDFromGin(c),
+			})
+			c.Abort()
+			return
+		}
+
+		tenantID := idempotencyTenant(c)
+		if tenantID == "" {
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, common.ErrorResponse{
+				Error:         "bad_request",
+				Message:       "Failed to read request body",
+				Code:          "INVALID_REQUEST_BODY",
+				CorrelationID: getRequestIDFromGin(c),
+			})
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		// Storing the outcome must not depend on the client still waiting for it
+		ctx := context.WithoutCancel(c.Request.Context())
+		path := c.Request.URL.RequestURI()
+
+		record, replay, err := idempotencyService.Begin(ctx, tenantID, key, c.Request.Method, path, requestHash(c.Request.Method, path, body))
+		switch {
+		case errors.Is(err, common.ErrIdempotencyKeyReused):
+			c.JSON(http.StatusUnprocessableEntity, common.ErrorResponse{
+				Error:         "idempotency_key_reused",
+				Message:       err.Error(),
+				Code:          "IDEMPOTENCY_KEY_REUSED",
+				CorrelationID: getRequestIDFromGin(c),
+			})
+			c.Abort()
+			return
+		case errors.Is(err, common.ErrIdempotencyKeyInProgress):
+			c.JSON(http.StatusConflict, common.ErrorResponse{
+				Error:         "idempotency_key_in_progress",
+				Message:       err.Error(),
+				Code:          "IDEMPOTENCY_KEY_IN_PROGRESS",
+				CorrelationID: getRequestIDFromGin(c),
+			})
+			c.Abort()
+			return
+		case err != nil:
+			// Serve the request without idempotency rather than failing it
+			logger.Error("Failed to claim idempotency key",
+				zap.String("path", path),
+				zap.String("request_id", getRequestIDFromGin(c)),
+				zap.Error(err),
+			)
+			c.Next()
+			return
+		}
+
+		if replay {
+			c.Header(IdempotentReplayedHeader, "true")
+			c.Data(record.ResponseStatus, record.ResponseContentType, record.ResponseBody)
+			c.Abort()
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+
+		// Release the key when the handler panics or fails with a server error
+		stored := false
+		defer func() {
+			if stored {
+				return
+			}
+			if err := idempotencyService.Release(ctx, record); err != nil {
+				logger.Error("Failed to release idempotency key",
+					zap.String("path", path),
+					zap.String("request_id", getRequestIDFromGin(c)),
+					zap.Error(err),
+				)
+			}
+		}()
+
+		c.Next()
+
+		status := recorder.Status()
+		if status >= http.StatusInternalServerError {
+			return
+		}
+
+		if err := idempotencyService.Complete(ctx, record, status, recorder.Header().Get("Content-Type"), recorder.body.Bytes()); err != nil {
+			logger.Error("Failed to store idempotent response",
+				zap.String("path", path),
+				zap.String("request_id", getRequestIDFromGin(c)),
+				zap.Error(err),
+			)
+			return
+		}
+		stored = true
+	}
+}
+
+// responseRecorder keeps a copy of the response body written by a handler
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// idempotencyTenant returns the organization of the caller, or the caller itself when it
+// acts outside an organization
+func idempotencyTenant(c *gin.Context) string {
+	if orgContextInterface, exists := c.Get("org_context"); exists {
+		if orgContext, ok := orgContextInterface.(*auth.OrgContext); ok && orgContext != nil && orgContext.AAAOrgID != "" {
+			return orgContext.AAAOrgID
+		}
+	}
+	if userContextInterface, exists := c.Get("user_context"); exists {
+		if userContext, ok := userContextInterface.(*auth.UserContext); ok && userContext != nil && userContext.AAAUserID != "" {
+			return "user:" + userContext.AAAUserID
+		}
+	}
+	return ""
+}
+
+// requestHash fingerprints a request so that a key reused for a different request is detected
+func requestHash(method, path string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method))
+	h.Write([]byte{' '})
+	h.Write([]byte(path))
+	h.Write([]byte{'\n'})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,193 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Kisanlink/farmers-module/internal/auth"
+	"github.com/Kisanlink/farmers-module/internal/entities/idempotency"
+	"github.com/Kisanlink/farmers-module/internal/services"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryIdempotencyRepo implements IdempotencyKeyRepository in memory
+type memoryIdempotencyRepo struct {
+	mu   sync.Mutex
+	keys map[string]*idempotency.IdempotencyKey
+}
+
+func newMemoryIdempotencyRepo() *memoryIdempotencyRepo {
+	return &memoryIdempotencyRepo{keys: make(map[string]*idempotency.IdempotencyKey)}
+}
+
+func (r *memoryIdempotencyRepo) Claim(ctx context.Context, key *idempotency.IdempotencyKey) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.keys[key.TenantID+"/"+key.Key]; exists {
+		return false, nil
+	}
+	stored := *key
+	r.keys[key.TenantID+"/"+key.Key] = &stored
+	return true, nil
+}
+
+func (r *memoryIdempotencyRepo) Get(ctx context.Context, tenantID, key string) (*idempotency.IdempotencyKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stored, exists := r.keys[tenantID+"/"+key]; exists {
+		copied := *stored
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+func (r *memoryIdempotencyRepo) Complete(ctx context.Context, id string, responseStatus int, contentType string, body []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, stored := range r.keys {
+		if stored.ID == id {
+			stored.Status = idempotency.KeyStatusCompleted
+			stored.ResponseStatus = responseStatus
+			stored.ResponseContentType = contentType
+			stored.ResponseBody = append([]byte(nil), body...)
+		}
+	}
+	return nil
+}
+
+func (r *memoryIdempotencyRepo) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for name, stored := range r.keys {
+		if stored.ID == id {
+			delete(r.keys, name)
+		}
+	}
+	return nil
+}
+
+func (r *memoryIdempotencyRepo) DeleteIfExpired(ctx context.Context, id string, now time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for name, stored := range r.keys {
+		if stored.ID == id && stored.IsExpired(now) {
+			delete(r.keys, name)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// newIdempotentRouter serves POST /farms, counting handler executions. The handler fails
+// with a server error while failing is set.
+func newIdempotentRouter(repo *memoryIdempotencyRepo, orgID string, executions *int, failing *bool) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	logger := &MockLogger{}
+	logger.On("Error", mock.Anything, mock.Anything).Return()
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user_context", &auth.UserContext{AAAUserID: "user123"})
+		c.Set("org_context", &auth.OrgContext{AAAOrgID: orgID})
+		c.Next()
+	})
+	router.Use(IdempotencyMiddleware(services.NewIdempotencyService(repo, time.Hour, logger), logger))
+	router.POST("/farms", func(c *gin.Context) {
+		*executions++
+		if *failing {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "database unavailable"})
+			return
+		}
+		c.JSON(http.StatusCreated, gin.H{"farm_id": "FARM0000000" + string(rune('0'+*executions))})
+	})
+	return router
+}
+
+func postFarm(router *gin.Engine, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/farms", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestIdempotencyMiddleware_ReplaysStoredResponse(t *testing.T) {
+	executions, failing := 0, false
+	router := newIdempotentRouter(newMemoryIdempotencyRepo(), "org123", &executions, &failing)
+
+	first := postFarm(router, "key-1", `{"name":"North field"}`)
+	require.Equal(t, http.StatusCreated, first.Code)
+
+	retry := postFarm(router, "key-1", `{"name":"North field"}`)
+	assert.Equal(t, http.StatusCreated, retry.Code)
+	assert.Equal(t, first.Body.String(), retry.Body.String())
+	assert.Equal(t, "true", retry.Header().Get(IdempotentReplayedHeader))
+	assert.Contains(t, retry.Header().Get("Content-Type"), "application/json")
+	assert.Equal(t, 1, executions, "the retry is not executed again")
+
+	// Requests without a key are not deduplicated
+	postFarm(router, "", `{"name":"North field"}`)
+	postFarm(router, "", `{"name":"North field"}`)
+	assert.Equal(t, 3, executions)
+}
+
+func TestIdempotencyMiddleware_RejectsKeyReusedWithDifferentBody(t *testing.T) {
+	executions, failing := 0, false
+	router := newIdempotentRouter(newMemoryIdempotencyRepo(), "org123", &executions, &failing)
+
+	require.Equal(t, http.StatusCreated, postFarm(router, "key-1", `{"name":"North field"}`).Code)
+
+	w := postFarm(router, "key-1", `{"name":"South field"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Contains(t, w.Body.String(), "IDEMPOTENCY_KEY_REUSED")
+	assert.Equal(t, 1, executions)
+}
+
+func TestIdempotencyMiddleware_KeysAreScopedToTenant(t *testing.T) {
+	repo := newMemoryIdempotencyRepo()
+	executions, failing := 0, false
+
+	require.Equal(t, http.StatusCreated, postFarm(newIdempotentRouter(repo, "org123", &executions, &failing), "key-1", `{}`).Code)
+	require.Equal(t, http.StatusCreated, postFarm(newIdempotentRouter(repo, "org456", &executions, &failing), "key-1", `{"other":true}`).Code)
+	assert.Equal(t, 2, executions)
+}
+
+func TestIdempotencyMiddleware_ServerErrorsCanBeRetried(t *testing.T) {
+	executions, failing := 0, true
+	router := newIdempotentRouter(newMemoryIdempotencyRepo(), "org123", &executions, &failing)
+
+	require.Equal(t, http.StatusInternalServerError, postFarm(router, "key-1", `{}`).Code)
+
+	failing = false
+	assert.Equal(t, http.StatusCreated, postFarm(router, "key-1", `{}`).Code)
+	assert.Equal(t, 2, executions)
+}
+
+func TestIdempotencyMiddleware_InProgressKey(t *testing.T) {
+	repo := newMemoryIdempotencyRepo()
+	service := services.NewIdempotencyService(repo, time.Hour, &MockLogger{})
+
+	_, replay, err := service.Begin(context.Background(), "org123", "key-1", http.MethodPost, "/farms", "hash")
+	require.NoError(t, err)
+	require.False(t, replay)
+
+	executions, failing := 0, false
+	router := newIdempotentRouter(repo, "org123", &executions, &failing)
+	// The middleware hashes the real request, so use a matching hash for the claimed key
+	repo.keys["org123/key-1"].RequestHash = requestHash(http.MethodPost, "/farms", []byte(`{}`))
+
+	w := postFarm(router, "key-1", `{}`)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, 0, executions)
+}
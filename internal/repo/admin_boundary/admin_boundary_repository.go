@@ -0,0 +1,79 @@
+package admin_boundary
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/admin_boundary"
+	"gorm.io/gorm"
+)
+
+// AdminBoundaryRepository defines the storage of administrative boundary master data
+type AdminBoundaryRepository interface {
+	Upsert(ctx context.Context, boundaries []*admin_boundary.AdminBoundary) error
+	List(ctx context.Context, level admin_boundary.AdminLevel, parentCode string) ([]*admin_boundary.AdminBoundary, error)
+}
+
+// AdminBoundaryRepositoryImpl implements AdminBoundaryRepository on PostGIS
+type AdminBoundaryRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewAdminBoundaryRepository creates a new admin boundary repository
+func NewAdminBoundaryRepository(db *gorm.DB) AdminBoundaryRepository {
+	return &AdminBoundaryRepositoryImpl{
+		db: db,
+	}
+}
+
+// Upsert stores boundaries in one transaction, replacing the name, parent and geometry of a
+// boundary already stored under the same level and code. Geometries are made valid and kept
+// as polygons only, so that containment tests on them do not fail.
+func (r *AdminBoundaryRepositoryImpl) Upsert(ctx context.Context, boundaries []*admin_boundary.AdminBoundary) error {
+	if r.db == nil {
+		return fmt.Errorf("database connection not available")
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, b := range boundaries {
+			if err := tx.Exec(`
+				INSERT INTO admin_boundaries
+					(id, created_at, updated_at, created_by, updated_by, level, code, name, parent_code, geometry)
+				VALUES (?, NOW(), NOW(), ?, ?, ?, ?, ?, ?,
+					ST_Multi(ST_CollectionExtract(ST_MakeValid(ST_GeomFromText(?, 4326)), 3)))
+				ON CONFLICT (level, code) DO UPDATE SET
+					name = EXCLUDED.name,
+					parent_code = EXCLUDED.parent_code,
+					geometry = EXCLUDED.geometry,
+					updated_at = NOW(),
+					updated_by = EXCLUDED.updated_by,
+					deleted_at = NULL,
+					deleted_by = NULL`,
+				b.ID, b.CreatedBy, b.UpdatedBy, b.Level, b.Code, b.Name, b.ParentCode, b.Geometry).Error; err != nil {
+				return fmt.Errorf("failed to store %s boundary %s: %w", b.Level, b.Code, err)
+			}
+		}
+		return nil
+	})
+}
+
+// List lists the boundaries of a level by code, without their geometry. A non-empty
+// parentCode keeps only the areas within that parent.
+func (r *AdminBoundaryRepositoryImpl) List(ctx context.Context, level admin_boundary.AdminLevel, parentCode string) ([]*admin_boundary.AdminBoundary, error) {
+	if r.db == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+
+	query := r.db.WithContext(ctx).
+		Select("id, created_at, updated_at, level, code, name, parent_code").
+		Where("level = ? AND deleted_at IS NULL", level)
+	if parentCode != "" {
+		query = query.Where("parent_code = ?", parentCode)
+	}
+
+	var boundaries []*admin_boundary.AdminBoundary
+	if err := query.Order("code").Find(&boundaries).Error; err != nil {
+		return nil, fmt.Errorf("failed to list admin boundaries: %w", err)
+	}
+	return boundaries, nil
+}
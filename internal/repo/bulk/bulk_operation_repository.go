@@ -18,6 +18,7 @@ type BulkOperationRepository interface {
 	Update(ctx context.Context, operation *bulk.BulkOperation) error
 	UpdateStatus(ctx context.Context, id string, status bulk.OperationStatus) error
 	UpdateProgress(ctx context.Context, id string, processed, successful, failed, skipped int) error
+	SetResultFileURL(ctx context.Context, id string, url string) error
 	List(ctx context.Context, filter *base.Filter) ([]*bulk.BulkOperation, error)
 	ListByFPO(ctx context.Context, fpoOrgID string, filter *base.Filter) ([]*bulk.BulkOperation, error)
 	Delete(ctx context.Context, id string) error
@@ -113,6 +114,16 @@ func (r *BulkOperationRepositoryImpl) UpdateProgress(ctx context.Context, id str
 	return nil
 }
 
+// SetResultFileURL records where the result file of a bulk operation can be downloaded
+func (r *BulkOperationRepositoryImpl) SetResultFileURL(ctx context.Context, id string, url string) error {
+	if err := r.db.WithContext(ctx).Model(&bulk.BulkOperation{}).
+		Where("id = ?", id).
+		Update("result_file_url", url).Error; err != nil {
+		return fmt.Errorf("failed to set bulk operation result file URL: %w", err)
+	}
+	return nil
+}
+
 // List retrieves bulk operations based on filter
 func (r *BulkOperationRepositoryImpl) List(ctx context.Context, filter *base.Filter) ([]*bulk.BulkOperation, error) {
 	var operations []*bulk.BulkOperation
@@ -183,6 +194,7 @@ type ProcessingDetailRepository interface {
 	UpdateBatch(ctx context.Context, details []*bulk.ProcessingDetail) error
 	GetFailedRecords(ctx context.Context, operationID string) ([]*bulk.ProcessingDetail, error)
 	GetRetryableRecords(ctx context.Context, operationID string) ([]*bulk.ProcessingDetail, error)
+	DeleteByOperationID(ctx context.Context, operationID string) error
 }
 
 // ProcessingDetailRepositoryImpl implements ProcessingDetailRepository
@@ -332,6 +344,14 @@ func (r *ProcessingDetailRepositoryImpl) GetRetryableRecords(ctx context.Context
 	return details, nil
 }
 
+// DeleteByOperationID deletes every processing detail of a bulk operation
+func (r *ProcessingDetailRepositoryImpl) DeleteByOperationID(ctx context.Context, operationID string) error {
+	if err := r.db.WithContext(ctx).Where("bulk_operation_id = ?", operationID).Delete(&bulk.ProcessingDetail{}).Error; err != nil {
+		return fmt.Errorf("failed to delete processing details: %w", err)
+	}
+	return nil
+}
+
 // applyFilter applies filter conditions to the query
 func applyFilter(query *gorm.DB, filter *base.Filter) *gorm.DB {
 	if filter == nil {
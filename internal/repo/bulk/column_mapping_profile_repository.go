@@ -0,0 +1,94 @@
+package bulk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/bulk"
+	"gorm.io/gorm"
+)
+
+// ColumnMappingProfileRepository defines the interface for column mapping profile repository
+type ColumnMappingProfileRepository interface {
+	Create(ctx context.Context, profile *bulk.ColumnMappingProfile) error
+	GetByID(ctx context.Context, id string) (*bulk.ColumnMappingProfile, error)
+	Update(ctx context.Context, profile *bulk.ColumnMappingProfile) error
+	Delete(ctx context.Context, id string) error
+	ListByFPO(ctx context.Context, fpoOrgID string) ([]*bulk.ColumnMappingProfile, error)
+}
+
+// ColumnMappingProfileRepositoryImpl implements ColumnMappingProfileRepository
+type ColumnMappingProfileRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewColumnMappingProfileRepository creates a new column mapping profile repository
+func NewColumnMappingProfileRepository(db *gorm.DB) ColumnMappingProfileRepository {
+	return &ColumnMappingProfileRepositoryImpl{
+		db: db,
+	}
+}
+
+// Create creates a new column mapping profile
+func (r *ColumnMappingProfileRepositoryImpl) Create(ctx context.Context, profile *bulk.ColumnMappingProfile) error {
+	if err := r.db.WithContext(ctx).Create(profile).Error; err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("mapping profile %q already exists for this FPO", profile.Name)
+		}
+		return fmt.Errorf("failed to create mapping profile: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a column mapping profile by ID
+func (r *ColumnMappingProfileRepositoryImpl) GetByID(ctx context.Context, id string) (*bulk.ColumnMappingProfile, error) {
+	var profile bulk.ColumnMappingProfile
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&profile).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("mapping profile not found: %s", id)
+		}
+		return nil, fmt.Errorf("failed to get mapping profile: %w", err)
+	}
+	return &profile, nil
+}
+
+// Update updates a column mapping profile
+func (r *ColumnMappingProfileRepositoryImpl) Update(ctx context.Context, profile *bulk.ColumnMappingProfile) error {
+	if err := r.db.WithContext(ctx).Save(profile).Error; err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("mapping profile %q already exists for this FPO", profile.Name)
+		}
+		return fmt.Errorf("failed to update mapping profile: %w", err)
+	}
+	return nil
+}
+
+// Delete deletes a column mapping profile
+func (r *ColumnMappingProfileRepositoryImpl) Delete(ctx context.Context, id string) error {
+	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&bulk.ColumnMappingProfile{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete mapping profile: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("mapping profile not found: %s", id)
+	}
+	return nil
+}
+
+// ListByFPO retrieves the column mapping profiles of an FPO ordered by name
+func (r *ColumnMappingProfileRepositoryImpl) ListByFPO(ctx context.Context, fpoOrgID string) ([]*bulk.ColumnMappingProfile, error) {
+	var profiles []*bulk.ColumnMappingProfile
+	if err := r.db.WithContext(ctx).
+		Where("fpo_org_id = ?", fpoOrgID).
+		Order("name ASC").
+		Find(&profiles).Error; err != nil {
+		return nil, fmt.Errorf("failed to list mapping profiles: %w", err)
+	}
+	return profiles, nil
+}
+
+func isUniqueViolation(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "duplicate key") || strings.Contains(msg, "UNIQUE constraint")
+}
@@ -0,0 +1,285 @@
+package bulk
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/bulk"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// JobQueueRepository defines the database-backed queue used to process bulk operations
+// across replicas. Records of queued operations are claimed in chunks under a lease, so a
+// chunk abandoned by a crashed worker becomes claimable again once its lease expires.
+type JobQueueRepository interface {
+	Enqueue(ctx context.Context, operationID string) error
+	UpdateLoading(ctx context.Context, operationID string, totalRecords int, loading bool) error
+	ClaimChunk(ctx context.Context, workerID string, size int, leaseTTL time.Duration) ([]*bulk.ProcessingDetail, error)
+	ExtendLease(ctx context.Context, workerID string, operationID string, leaseTTL time.Duration) error
+	ReleaseLease(ctx context.Context, workerID string, detailIDs []string) error
+	Heartbeat(ctx context.Context, operationID string, owner string) error
+	RefreshProgress(ctx context.Context, operationID string) error
+	CompleteIfDone(ctx context.Context, operationID string) (bool, error)
+	RecoverOrphaned(ctx context.Context, staleBefore time.Time) ([]string, error)
+}
+
+// JobQueueRepositoryImpl implements JobQueueRepository on PostgreSQL
+type JobQueueRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewJobQueueRepository creates a new job queue repository
+func NewJobQueueRepository(db *gorm.DB) JobQueueRepository {
+	return &JobQueueRepositoryImpl{
+		db: db,
+	}
+}
+
+// activeStatuses are the operation statuses whose records may be claimed
+var activeStatuses = []string{string(bulk.StatusPending), string(bulk.StatusProcessing)}
+
+// Enqueue hands an operation to the job workers
+func (r *JobQueueRepositoryImpl) Enqueue(ctx context.Context, operationID string) error {
+	if err := r.db.WithContext(ctx).Model(&bulk.BulkOperation{}).
+		Where("id = ?", operationID).
+		Updates(map[string]interface{}{
+			"queued":       true,
+			"lease_owner":  nil,
+			"heartbeat_at": gorm.Expr("NOW()"),
+		}).Error; err != nil {
+		return fmt.Errorf("failed to enqueue bulk operation: %w", err)
+	}
+	return nil
+}
+
+// UpdateLoading records how many records of a streamed upload have been stored and whether
+// more are still being read
+func (r *JobQueueRepositoryImpl) UpdateLoading(ctx context.Context, operationID string, totalRecords int, loading bool) error {
+	if err := r.db.WithContext(ctx).Model(&bulk.BulkOperation{}).
+		Where("id = ?", operationID).
+		Updates(map[string]interface{}{
+			"total_records": totalRecords,
+			"loading":       loading,
+		}).Error; err != nil {
+		return fmt.Errorf("failed to update bulk operation loading state: %w", err)
+	}
+	return nil
+}
+
+// ClaimChunk leases up to size pending records of the oldest queued operation that has
+// claimable work. Rows locked by a concurrent claim are skipped rather than waited for.
+func (r *JobQueueRepositoryImpl) ClaimChunk(ctx context.Context, workerID string, size int, leaseTTL time.Duration) ([]*bulk.ProcessingDetail, error) {
+	var claimed []*bulk.ProcessingDetail
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var operationIDs []string
+		if err := tx.Model(&bulk.BulkOperation{}).
+			Select("bulk_operations.id").
+			Where("bulk_operations.queued AND bulk_operations.status IN ?", activeStatuses).
+			Where(`EXISTS (SELECT 1 FROM bulk_processing_details d
+				WHERE d.bulk_operation_id = bulk_operations.id AND d.status = ?
+				AND (d.lease_expires_at IS NULL OR d.lease_expires_at < NOW()))`, bulk.RecordStatusPending).
+			Order("bulk_operations.created_at ASC").
+			Limit(1).
+			Pluck("bulk_operations.id", &operationIDs).Error; err != nil {
+			return fmt.Errorf("failed to find queued operation: %w", err)
+		}
+		if len(operationIDs) == 0 {
+			return nil
+		}
+		operationID := operationIDs[0]
+
+		var ids []string
+		if err := tx.Model(&bulk.ProcessingDetail{}).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("bulk_operation_id = ? AND status = ?", operationID, bulk.RecordStatusPending).
+			Where("lease_expires_at IS NULL OR lease_expires_at < NOW()").
+			Order("record_index ASC").
+			Limit(size).
+			Pluck("id", &ids).Error; err != nil {
+			return fmt.Errorf("failed to lock pending records: %w", err)
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		if err := tx.Model(&bulk.ProcessingDetail{}).
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{
+				"lease_owner":      workerID,
+				"lease_expires_at": leaseExpiry(leaseTTL),
+			}).Error; err != nil {
+			return fmt.Errorf("failed to lease records: %w", err)
+		}
+
+		if err := tx.Model(&bulk.BulkOperation{}).
+			Where("id = ?", operationID).
+			Updates(map[string]interface{}{
+				"status":       bulk.StatusProcessing,
+				"start_time":   gorm.Expr("COALESCE(start_time, NOW())"),
+				"lease_owner":  workerID,
+				"heartbeat_at": gorm.Expr("NOW()"),
+			}).Error; err != nil {
+			return fmt.Errorf("failed to mark operation processing: %w", err)
+		}
+
+		return tx.Where("id IN ?", ids).Order("record_index ASC").Find(&claimed).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim chunk: %w", err)
+	}
+
+	return claimed, nil
+}
+
+// ExtendLease renews the worker's leases on the unprocessed records of an operation and
+// refreshes the operation heartbeat
+func (r *JobQueueRepositoryImpl) ExtendLease(ctx context.Context, workerID string, operationID string, leaseTTL time.Duration) error {
+	if err := r.db.WithContext(ctx).Model(&bulk.ProcessingDetail{}).
+		Where("bulk_operation_id = ? AND lease_owner = ? AND status = ?", operationID, workerID, bulk.RecordStatusPending).
+		Update("lease_expires_at", leaseExpiry(leaseTTL)).Error; err != nil {
+		return fmt.Errorf("failed to extend record leases: %w", err)
+	}
+	return r.Heartbeat(ctx, operationID, workerID)
+}
+
+// ReleaseLease drops the worker's leases on records it will not process, making them
+// immediately claimable by other workers
+func (r *JobQueueRepositoryImpl) ReleaseLease(ctx context.Context, workerID string, detailIDs []string) error {
+	if len(detailIDs) == 0 {
+		return nil
+	}
+
+	if err := r.db.WithContext(ctx).Model(&bulk.ProcessingDetail{}).
+		Where("id IN ? AND lease_owner = ?", detailIDs, workerID).
+		Updates(map[string]interface{}{
+			"lease_owner":      nil,
+			"lease_expires_at": nil,
+		}).Error; err != nil {
+		return fmt.Errorf("failed to release record leases: %w", err)
+	}
+	return nil
+}
+
+// Heartbeat records that owner is still working on the operation
+func (r *JobQueueRepositoryImpl) Heartbeat(ctx context.Context, operationID string, owner string) error {
+	if err := r.db.WithContext(ctx).Model(&bulk.BulkOperation{}).
+		Where("id = ?", operationID).
+		Updates(map[string]interface{}{
+			"lease_owner":  owner,
+			"heartbeat_at": gorm.Expr("NOW()"),
+		}).Error; err != nil {
+		return fmt.Errorf("failed to record heartbeat: %w", err)
+	}
+	return nil
+}
+
+// RefreshProgress recomputes the operation counts from its processing details. Several
+// workers finish records of the same operation, so counts are derived rather than added up.
+func (r *JobQueueRepositoryImpl) RefreshProgress(ctx context.Context, operationID string) error {
+	if err := r.db.WithContext(ctx).Exec(`
+		UPDATE bulk_operations SET
+			successful_records = c.successful,
+			failed_records = c.failed,
+			skipped_records = c.skipped,
+			processed_records = c.successful + c.failed + c.skipped,
+			updated_at = NOW()
+		FROM (
+			SELECT
+				COUNT(*) FILTER (WHERE status = ?) AS successful,
+				COUNT(*) FILTER (WHERE status = ?) AS failed,
+				COUNT(*) FILTER (WHERE status = ?) AS skipped
+			FROM bulk_processing_details
+			WHERE bulk_operation_id = ?
+		) c
+		WHERE bulk_operations.id = ?`,
+		bulk.RecordStatusSuccess, bulk.RecordStatusFailed, bulk.RecordStatusSkipped,
+		operationID, operationID).Error; err != nil {
+		return fmt.Errorf("failed to refresh bulk operation progress: %w", err)
+	}
+	return nil
+}
+
+// CompleteIfDone finishes a processing operation that has no pending records left and is
+// not still loading records. It reports whether this call finished the operation, so only
+// one worker publishes results.
+func (r *JobQueueRepositoryImpl) CompleteIfDone(ctx context.Context, operationID string) (bool, error) {
+	result := r.db.WithContext(ctx).Exec(`
+		UPDATE bulk_operations SET
+			status = CASE WHEN successful_records = 0 AND failed_records > 0 THEN ? ELSE ? END,
+			end_time = NOW(),
+			processing_time = EXTRACT(EPOCH FROM (NOW() - COALESCE(start_time, created_at))) * 1000,
+			queued = false,
+			lease_owner = NULL,
+			updated_at = NOW()
+		WHERE id = ? AND status = ? AND NOT loading
+		AND NOT EXISTS (
+			SELECT 1 FROM bulk_processing_details
+			WHERE bulk_operation_id = ? AND status = ?
+		)`,
+		bulk.StatusFailed, bulk.StatusCompleted,
+		operationID, bulk.StatusProcessing,
+		operationID, bulk.RecordStatusPending)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to complete bulk operation: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// RecoverOrphaned queues active operations whose last heartbeat is older than staleBefore,
+// such as in-process runs of a replica that crashed or restarted, and returns their IDs.
+// Queued operations still waiting for their first claim are not orphaned. An upload whose
+// reader went away stops loading; the records stored before that are processed.
+func (r *JobQueueRepositoryImpl) RecoverOrphaned(ctx context.Context, staleBefore time.Time) ([]string, error) {
+	var ids []string
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&bulk.BulkOperation{}).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status IN ?", activeStatuses).
+			Where("NOT queued OR status = ?", bulk.StatusProcessing).
+			Where("COALESCE(heartbeat_at, updated_at) < ?", staleBefore).
+			Pluck("id", &ids).Error; err != nil {
+			return fmt.Errorf("failed to find orphaned operations: %w", err)
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		if err := tx.Model(&bulk.ProcessingDetail{}).
+			Where("bulk_operation_id IN ? AND status = ? AND lease_expires_at < NOW()", ids, bulk.RecordStatusPending).
+			Updates(map[string]interface{}{
+				"lease_owner":      nil,
+				"lease_expires_at": nil,
+			}).Error; err != nil {
+			return fmt.Errorf("failed to clear expired leases: %w", err)
+		}
+
+		if err := tx.Exec(`
+			UPDATE bulk_operations SET
+				loading = false,
+				total_records = (SELECT COUNT(*) FROM bulk_processing_details d WHERE d.bulk_operation_id = bulk_operations.id)
+			WHERE id IN ? AND loading`, ids).Error; err != nil {
+			return fmt.Errorf("failed to end loading of orphaned operations: %w", err)
+		}
+
+		return tx.Model(&bulk.BulkOperation{}).
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{
+				"queued":       true,
+				"lease_owner":  nil,
+				"heartbeat_at": gorm.Expr("NOW()"),
+			}).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to recover orphaned operations: %w", err)
+	}
+
+	return ids, nil
+}
+
+func leaseExpiry(leaseTTL time.Duration) clause.Expr {
+	return gorm.Expr("NOW() + make_interval(secs => ?)", leaseTTL.Seconds())
+}
@@ -0,0 +1,233 @@
+package changefeed
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/changefeed"
+	"github.com/Kisanlink/farmers-module/internal/entities/crop_cycle"
+	farmEntity "github.com/Kisanlink/farmers-module/internal/entities/farm"
+	"github.com/Kisanlink/farmers-module/internal/entities/farm_activity"
+	"github.com/Kisanlink/farmers-module/internal/entities/farmer"
+	"github.com/Kisanlink/farmers-module/pkg/geo"
+	"gorm.io/gorm"
+)
+
+// ChangeFeedRepository reads the records offered to offline clients. A user's scope is the
+// farmers they are, or are assigned to as KisanSathi, together with the farms, crop cycles
+// and farm activities of those farmers. Soft-deleted records are included.
+type ChangeFeedRepository interface {
+	// ListChanges returns up to limit records of an entity in the user's scope that changed
+	// after the cursor and no later than until, in feed order
+	ListChanges(ctx context.Context, userID string, entity changefeed.Entity, after changefeed.Cursor, until time.Time, limit int) ([]*changefeed.Change, error)
+
+	// GetChange returns the current state of a record, or nil when it does not exist
+	GetChange(ctx context.Context, entity changefeed.Entity, id string) (*changefeed.Change, error)
+
+	// FarmerInScope reports whether a farmer is in the user's scope
+	FarmerInScope(ctx context.Context, userID, farmerID string) (bool, error)
+}
+
+// ChangeFeedRepositoryImpl implements ChangeFeedRepository on PostgreSQL
+type ChangeFeedRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewChangeFeedRepository creates a new change feed repository
+func NewChangeFeedRepository(db *gorm.DB) ChangeFeedRepository {
+	return &ChangeFeedRepositoryImpl{
+		db: db,
+	}
+}
+
+// feedTables maps each entity to its table and the column holding its farmer
+var feedTables = map[changefeed.Entity]struct {
+	table        string
+	farmerColumn string
+}{
+	changefeed.EntityFarmer:       {table: "farmers", farmerColumn: "id"},
+	changefeed.EntityFarm:         {table: "farms", farmerColumn: "farmer_id"},
+	changefeed.EntityCropCycle:    {table: "crop_cycles", farmerColumn: "farmer_id"},
+	changefeed.EntityFarmActivity: {table: "farm_activities", farmerColumn: "farmer_id"},
+}
+
+// ListChanges returns the changed records of an entity in the user's scope
+func (r *ChangeFeedRepositoryImpl) ListChanges(ctx context.Context, userID string, entity changefeed.Entity, after changefeed.Cursor, until time.Time, limit int) ([]*changefeed.Change, error) {
+	meta, ok := feedTables[entity]
+	if !ok {
+		return nil, fmt.Errorf("unknown sync entity %q", entity)
+	}
+
+	// A deletion does not necessarily touch updated_at, so a record changed when it was last
+	// updated or deleted, whichever is later
+	changedAt := fmt.Sprintf("GREATEST(%[1]s.updated_at, COALESCE(%[1]s.deleted_at, %[1]s.updated_at))", meta.table)
+
+	query := r.db.WithContext(ctx).Table(meta.table).
+		Where(fmt.Sprintf("%s.%s IN (?)", meta.table, meta.farmerColumn), r.scopedFarmerIDs(ctx, userID)).
+		Where(changedAt+" <= ?", until)
+
+	if !after.IsZero() {
+		// Records of the cursor's timestamp come after the cursor when their entity ranks
+		// later, or when they are of the same entity with a greater ID
+		switch rank, cursorRank := entity.Rank(), after.Entity.Rank(); {
+		case rank > cursorRank:
+			query = query.Where(changedAt+" >= ?", after.ChangedAt)
+		case rank == cursorRank:
+			query = query.Where(fmt.Sprintf("(%[1]s > ? OR (%[1]s = ? AND %[2]s.id > ?))", changedAt, meta.table), after.ChangedAt, after.ChangedAt, after.ID)
+		default:
+			query = query.Where(changedAt+" > ?", after.ChangedAt)
+		}
+	}
+
+	query = query.Order(changedAt).Order(meta.table + ".id").Limit(limit)
+
+	changes, err := r.find(ctx, query, entity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s changes: %w", entity, err)
+	}
+	return changes, nil
+}
+
+// GetChange returns the current state of a record, including soft-deleted records
+func (r *ChangeFeedRepositoryImpl) GetChange(ctx context.Context, entity changefeed.Entity, id string) (*changefeed.Change, error) {
+	meta, ok := feedTables[entity]
+	if !ok {
+		return nil, fmt.Errorf("unknown sync entity %q", entity)
+	}
+
+	changes, err := r.find(ctx, r.db.WithContext(ctx).Table(meta.table).Where(meta.table+".id = ?", id).Limit(1), entity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s %s: %w", entity, id, err)
+	}
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	return changes[0], nil
+}
+
+// FarmerInScope reports whether a farmer is in the user's scope
+func (r *ChangeFeedRepositoryImpl) FarmerInScope(ctx context.Context, userID, farmerID string) (bool, error) {
+	var count int64
+	if err := r.scopedFarmerIDs(ctx, userID).Where("farmers.id = ?", farmerID).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check farmer scope: %w", err)
+	}
+	return count > 0, nil
+}
+
+// scopedFarmerIDs selects the IDs of the farmers in the user's scope: the user's own farmer
+// record and the farmers assigned to the user as KisanSathi, either directly or through an
+// active FPO linkage
+func (r *ChangeFeedRepositoryImpl) scopedFarmerIDs(ctx context.Context, userID string) *gorm.DB {
+	return r.db.WithContext(ctx).Table("farmers").Select("farmers.id").
+		Where(`farmers.aaa_user_id = @user
+			OR farmers.kisan_sathi_user_id = @user
+			OR EXISTS (
+				SELECT 1 FROM farmer_links
+				WHERE farmer_links.aaa_user_id = farmers.aaa_user_id
+				AND farmer_links.kisan_sathi_user_id = @user
+				AND farmer_links.status = 'ACTIVE'
+				AND farmer_links.deleted_at IS NULL
+			)`, sql.Named("user", userID))
+}
+
+// find runs a query on the table of an entity and wraps the records as changes
+func (r *ChangeFeedRepositoryImpl) find(ctx context.Context, query *gorm.DB, entity changefeed.Entity) ([]*changefeed.Change, error) {
+	var changes []*changefeed.Change
+	switch entity {
+	case changefeed.EntityFarmer:
+		var records []*farmer.Farmer
+		if err := query.Find(&records).Error; err != nil {
+			return nil, err
+		}
+		for _, record := range records {
+			changes = append(changes, newChange(entity, record.ID, record.ID, record.UpdatedAt, record.DeletedAt, record))
+		}
+
+	case changefeed.EntityFarm:
+		var records []*farmEntity.Farm
+		if err := query.Find(&records).Error; err != nil {
+			return nil, err
+		}
+		if err := r.loadGeometryWKT(ctx, records); err != nil {
+			return nil, err
+		}
+		for _, record := range records {
+			changes = append(changes, newChange(entity, record.ID, record.FarmerID, record.UpdatedAt, record.DeletedAt, record))
+		}
+
+	case changefeed.EntityCropCycle:
+		var records []*crop_cycle.CropCycle
+		if err := query.Find(&records).Error; err != nil {
+			return nil, err
+		}
+		for _, record := range records {
+			changes = append(changes, newChange(entity, record.ID, record.FarmerID, record.UpdatedAt, record.DeletedAt, record))
+		}
+
+	case changefeed.EntityFarmActivity:
+		var records []*farm_activity.FarmActivity
+		if err := query.Find(&records).Error; err != nil {
+			return nil, err
+		}
+		for _, record := range records {
+			changes = append(changes, newChange(entity, record.ID, record.FarmerID, record.UpdatedAt, record.DeletedAt, record))
+		}
+
+	default:
+		return nil, fmt.Errorf("unknown sync entity %q", entity)
+	}
+	return changes, nil
+}
+
+func newChange(entity changefeed.Entity, id string, farmerID string, updatedAt time.Time, deletedAt *time.Time, record interface{}) *changefeed.Change {
+	return &changefeed.Change{
+		Entity:    entity,
+		ID:        id,
+		FarmerID:  farmerID,
+		UpdatedAt: updatedAt,
+		DeletedAt: deletedAt,
+		Record:    record,
+	}
+}
+
+// loadGeometryWKT replaces the stored geometry of farms with its WKT, the format clients
+// send back when they edit a boundary. Single-part boundaries, which are stored as
+// MULTIPOLYGONs, are returned as POLYGONs.
+func (r *ChangeFeedRepositoryImpl) loadGeometryWKT(ctx context.Context, farms []*farmEntity.Farm) error {
+	var ids []string
+	for _, farm := range farms {
+		if farm.Geometry != "" {
+			ids = append(ids, farm.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var rows []struct {
+		ID  string
+		WKT string
+	}
+	if err := r.db.WithContext(ctx).Table("farms").
+		Select("id, ST_AsText(geometry) AS wkt").
+		Where("id IN ?", ids).
+		Scan(&rows).Error; err != nil {
+		return fmt.Errorf("failed to read farm boundaries: %w", err)
+	}
+
+	wkt := make(map[string]string, len(rows))
+	for _, row := range rows {
+		wkt[row.ID] = row.WKT
+		if geometry, err := geo.ParseWKT(row.WKT); err == nil {
+			wkt[row.ID] = geometry.WKT()
+		}
+	}
+	for _, farm := range farms {
+		if value, ok := wkt[farm.ID]; ok {
+			farm.Geometry = value
+		}
+	}
+	return nil
+}
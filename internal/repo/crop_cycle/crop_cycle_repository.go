@@ -4,9 +4,12 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/Kisanlink/farmers-module/internal/entities/crop_cycle"
 	farmEntity "github.com/Kisanlink/farmers-module/internal/entities/farm"
+	farmActivityEntity "github.com/Kisanlink/farmers-module/internal/entities/farm_activity"
+	"github.com/Kisanlink/farmers-module/internal/entities/plot"
 	"github.com/Kisanlink/farmers-module/pkg/common"
 	"github.com/Kisanlink/kisanlink-db/pkg/base"
 	"gorm.io/gorm"
@@ -70,6 +73,17 @@ type AreaAllocationSummary struct {
 	AvailableAreaHa    float64
 	ActiveCyclesCount  int64
 	PlannedCyclesCount int64
+	PlottedAreaHa      float64        // Area of the farm divided into plots
+	PlotConflicts      []PlotConflict // Planned or active cycles grown on the same ground
+}
+
+// PlotConflict is a pair of planned or active crop cycles of a farm whose plots overlap
+type PlotConflict struct {
+	CycleID       string
+	PlotID        string
+	OtherCycleID  string
+	OtherPlotID   string
+	OverlapAreaHa float64
 }
 
 // GetTotalAllocatedArea calculates the total allocated area for a farm
@@ -205,6 +219,35 @@ func (r *CropCycleRepository) GetAreaAllocationSummary(ctx context.Context, farm
 		totalFarmArea = farm.AreaHa
 	}
 
+	var plottedArea float64
+	if err := r.db.WithContext(ctx).
+		Model(&plot.Plot{}).
+		Where("farm_id = ? AND deleted_at IS NULL", farmID).
+		Select("COALESCE(SUM(area_ha), 0)").
+		Scan(&plottedArea).Error; err != nil {
+		return nil, err
+	}
+
+	// Summed areas can fit the farm while two cycles are still grown on the same ground
+	cycles, plots, err := loadPlotCycles(r.db.WithContext(ctx), farmID, "")
+	if err != nil {
+		return nil, err
+	}
+	var conflicts []PlotConflict
+	for i, cycle := range cycles {
+		for _, other := range cycles[i+1:] {
+			if overlap := plotOverlapHa(plots, *cycle.PlotID, *other.PlotID); overlap >= plot.ToleranceHa {
+				conflicts = append(conflicts, PlotConflict{
+					CycleID:       cycle.ID,
+					PlotID:        *cycle.PlotID,
+					OtherCycleID:  other.ID,
+					OtherPlotID:   *other.PlotID,
+					OverlapAreaHa: overlap,
+				})
+			}
+		}
+	}
+
 	return &AreaAllocationSummary{
 		FarmID:             farmID,
 		TotalAreaHa:        totalFarmArea,
@@ -212,5 +255,302 @@ func (r *CropCycleRepository) GetAreaAllocationSummary(ctx context.Context, farm
 		AvailableAreaHa:    totalFarmArea - allocatedArea,
 		ActiveCyclesCount:  activeCycles,
 		PlannedCyclesCount: plannedCycles,
+		PlottedAreaHa:      plottedArea,
+		PlotConflicts:      conflicts,
 	}, nil
 }
+
+// ValidatePlotAllocation validates that a crop cycle can be grown on a plot: the plot must
+// belong to the farm, and no other planned or active cycle of the farm may be grown on the
+// plot or on a plot overlapping it. It returns the plot. Like ValidateAreaAllocation it
+// locks the farm record while checking.
+func (r *CropCycleRepository) ValidatePlotAllocation(ctx context.Context, farmID, cycleID, plotID string) (*plot.Plot, error) {
+	if r.db == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+
+	var target *plot.Plot
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var farm farmEntity.Farm
+		if err := tx.Set("gorm:query_option", "FOR UPDATE").
+			Where("id = ? AND deleted_at IS NULL", farmID).
+			First(&farm).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return common.ErrNotFound
+			}
+			return err
+		}
+
+		var found []*plot.Plot
+		if err := tx.Where("id = ? AND farm_id = ? AND deleted_at IS NULL", plotID, farmID).
+			Limit(1).
+			Find(&found).Error; err != nil {
+			return err
+		}
+		if len(found) == 0 {
+			return fmt.Errorf("%w: plot %s is not a plot of farm %s", common.ErrInvalidInput, plotID, farmID)
+		}
+		target = found[0]
+
+		cycles, plots, err := loadPlotCycles(tx, farmID, cycleID)
+		if err != nil {
+			return err
+		}
+		plots[target.ID] = target
+		for _, cycle := range cycles {
+			if overlap := plotOverlapHa(plots, plotID, *cycle.PlotID); overlap >= plot.ToleranceHa {
+				return &common.PlotOccupiedError{
+					PlotID:         plotID,
+					OccupiedByPlot: *cycle.PlotID,
+					OccupiedBy:     cycle.ID,
+					OverlapAreaHa:  overlap,
+				}
+			}
+		}
+		return nil
+	}, &sql.TxOptions{
+		Isolation: sql.LevelSerializable,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return target, nil
+}
+
+// loadPlotCycles loads the planned and active cycles of a farm grown on plots, other than
+// excludeCycleID, with their plots by ID
+func loadPlotCycles(tx *gorm.DB, farmID, excludeCycleID string) ([]*crop_cycle.CropCycle, map[string]*plot.Plot, error) {
+	var cycles []*crop_cycle.CropCycle
+	if err := tx.Where("farm_id = ? AND plot_id IS NOT NULL AND status IN (?) AND deleted_at IS NULL",
+		farmID, []string{"PLANNED", "ACTIVE"}).
+		Where("id != ?", excludeCycleID).
+		Order("id").
+		Find(&cycles).Error; err != nil {
+		return nil, nil, err
+	}
+
+	plots := make(map[string]*plot.Plot)
+	if len(cycles) == 0 {
+		return cycles, plots, nil
+	}
+	plotIDs := make([]string, len(cycles))
+	for i, cycle := range cycles {
+		plotIDs[i] = *cycle.PlotID
+	}
+	var found []*plot.Plot
+	if err := tx.Where("id IN ? AND deleted_at IS NULL", plotIDs).Find(&found).Error; err != nil {
+		return nil, nil, err
+	}
+	for _, p := range found {
+		plots[p.ID] = p
+	}
+	return cycles, plots, nil
+}
+
+// plotOverlapHa returns the area two plots share; a plot shares all of itself
+func plotOverlapHa(plots map[string]*plot.Plot, plotID, otherPlotID string) float64 {
+	a, b := plots[plotID], plots[otherPlotID]
+	if a == nil || b == nil {
+		return 0
+	}
+	if plotID == otherPlotID {
+		return a.AreaHa
+	}
+	return plot.OverlapAreaHa(a, b)
+}
+
+// FindOpenCycle finds the planned or active cycle of a crop on a farm for a season and start
+// date. It returns nil when there is no such cycle.
+func (r *CropCycleRepository) FindOpenCycle(ctx context.Context, farmID, cropID, season string, startDate time.Time) (*crop_cycle.CropCycle, error) {
+	if r.db == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+
+	var cycles []*crop_cycle.CropCycle
+	if err := r.db.WithContext(ctx).
+		Where("farm_id = ? AND crop_id = ? AND season = ? AND start_date = ? AND status IN (?) AND deleted_at IS NULL",
+			farmID, cropID, season, startDate.Format("2006-01-02"), []string{"PLANNED", "ACTIVE"}).
+		Order("created_at ASC").
+		Limit(1).
+		Find(&cycles).Error; err != nil {
+		return nil, err
+	}
+
+	if len(cycles) == 0 {
+		return nil, nil
+	}
+	return cycles[0], nil
+}
+
+// CreateWithActivities creates a crop cycle together with the activities planned for it, in
+// one transaction so that a cycle is never kept with only part of its plan
+func (r *CropCycleRepository) CreateWithActivities(ctx context.Context, cycle *crop_cycle.CropCycle, activities []*farmActivityEntity.FarmActivity) error {
+	if r.db == nil {
+		return fmt.Errorf("database connection not available")
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(cycle).Error; err != nil {
+			return err
+		}
+		if len(activities) == 0 {
+			return nil
+		}
+		if err := tx.Create(&activities).Error; err != nil {
+			return fmt.Errorf("failed to create planned activities: %w", err)
+		}
+		return nil
+	})
+}
+
+// ShiftSchedule moves a crop cycle's start date and, by the same number of days, the planned
+// activities of the cycle that have a date. Completed and cancelled activities are left as
+// they are. It returns the number of activities moved.
+func (r *CropCycleRepository) ShiftSchedule(ctx context.Context, cycleID string, startDate time.Time, shiftDays int, updatedBy string) (int64, error) {
+	if r.db == nil {
+		return 0, fmt.Errorf("database connection not available")
+	}
+
+	var shifted int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&crop_cycle.CropCycle{}).
+			Where("id = ? AND deleted_at IS NULL", cycleID).
+			Updates(map[string]interface{}{
+				"start_date": startDate,
+				"updated_by": updatedBy,
+				"updated_at": now,
+			})
+		if result.Error != nil {
+			return fmt.Errorf("failed to update crop cycle start date: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return common.ErrNotFound
+		}
+
+		if shiftDays == 0 {
+			return nil
+		}
+		result = tx.Table("farm_activities").
+			Where("crop_cycle_id = ? AND status = ? AND planned_at IS NOT NULL AND deleted_at IS NULL", cycleID, "PLANNED").
+			Updates(map[string]interface{}{
+				"planned_at": gorm.Expr("planned_at + make_interval(days => ?)", shiftDays),
+				"updated_by": updatedBy,
+				"updated_at": now,
+			})
+		if result.Error != nil {
+			return fmt.Errorf("failed to shift planned activities: %w", result.Error)
+		}
+		shifted = result.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return shifted, nil
+}
+
+// ListSownOpenCycles lists the planned and active crop cycles with a start date on the farms
+// of an organisation, optionally of a single farm, with their crops preloaded
+func (r *CropCycleRepository) ListSownOpenCycles(ctx context.Context, orgID, farmID string) ([]*crop_cycle.CropCycle, error) {
+	if r.db == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+
+	query := r.db.WithContext(ctx).Preload("Crop").
+		Joins("JOIN farms ON farms.id = crop_cycles.farm_id AND farms.deleted_at IS NULL").
+		Where("farms.aaa_org_id = ? AND crop_cycles.status IN (?) AND crop_cycles.start_date IS NOT NULL AND crop_cycles.deleted_at IS NULL",
+			orgID, []string{"PLANNED", "ACTIVE"})
+	if farmID != "" {
+		query = query.Where("crop_cycles.farm_id = ?", farmID)
+	}
+
+	var cycles []*crop_cycle.CropCycle
+	if err := query.Order("crop_cycles.start_date, crop_cycles.id").Find(&cycles).Error; err != nil {
+		return nil, fmt.Errorf("failed to list open crop cycles: %w", err)
+	}
+	return cycles, nil
+}
+
+// ListFarmCycles lists the crop cycles of a farm that were not cancelled, optionally of a
+// season and of cycles started in a year, with their crops preloaded
+func (r *CropCycleRepository) ListFarmCycles(ctx context.Context, farmID, season string, year int) ([]*crop_cycle.CropCycle, error) {
+	if r.db == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+
+	query := r.db.WithContext(ctx).Preload("Crop").
+		Where("farm_id = ? AND status <> ? AND deleted_at IS NULL", farmID, "CANCELLED")
+	if season != "" {
+		query = query.Where("season = ?", season)
+	}
+	if year > 0 {
+		query = query.Where("EXTRACT(YEAR FROM start_date) = ?", year)
+	}
+
+	var cycles []*crop_cycle.CropCycle
+	if err := query.Order("start_date, id").Find(&cycles).Error; err != nil {
+		return nil, fmt.Errorf("failed to list farm crop cycles: %w", err)
+	}
+	return cycles, nil
+}
+
+// GetWithBenchmarks gets a crop cycle with its crop and variety preloaded, for estimating its
+// yield from the variety's benchmarks
+func (r *CropCycleRepository) GetWithBenchmarks(ctx context.Context, cycleID string) (*crop_cycle.CropCycle, error) {
+	if r.db == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+
+	var cycle crop_cycle.CropCycle
+	err := r.db.WithContext(ctx).Preload("Crop").Preload("Variety").
+		Where("id = ? AND deleted_at IS NULL", cycleID).
+		First(&cycle).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, common.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get crop cycle: %w", err)
+	}
+	return &cycle, nil
+}
+
+// OpenCycleFilter selects the planned and active crop cycles of an organisation
+type OpenCycleFilter struct {
+	OrgID  string
+	FarmID string
+	CropID string
+	Season string
+	Year   int // Of the start date
+}
+
+// ListOpenCycles lists the planned and active crop cycles on the farms of an organisation
+// with their crops and varieties preloaded
+func (r *CropCycleRepository) ListOpenCycles(ctx context.Context, filter OpenCycleFilter) ([]*crop_cycle.CropCycle, error) {
+	if r.db == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+
+	query := r.db.WithContext(ctx).Preload("Crop").Preload("Variety").
+		Joins("JOIN farms ON farms.id = crop_cycles.farm_id AND farms.deleted_at IS NULL").
+		Where("farms.aaa_org_id = ? AND crop_cycles.status IN (?) AND crop_cycles.deleted_at IS NULL",
+			filter.OrgID, []string{"PLANNED", "ACTIVE"})
+	if filter.FarmID != "" {
+		query = query.Where("crop_cycles.farm_id = ?", filter.FarmID)
+	}
+	if filter.CropID != "" {
+		query = query.Where("crop_cycles.crop_id = ?", filter.CropID)
+	}
+	if filter.Season != "" {
+		query = query.Where("crop_cycles.season = ?", filter.Season)
+	}
+	if filter.Year > 0 {
+		query = query.Where("EXTRACT(YEAR FROM crop_cycles.start_date) = ?", filter.Year)
+	}
+
+	var cycles []*crop_cycle.CropCycle
+	if err := query.Order("crop_cycles.start_date, crop_cycles.id").Find(&cycles).Error; err != nil {
+		return nil, fmt.Errorf("failed to list open crop cycles: %w", err)
+	}
+	return cycles, nil
+}
@@ -0,0 +1,98 @@
+package crop_cycle
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/crop_cycle"
+	"gorm.io/gorm"
+)
+
+// StageTransitionRepository defines the storage of the stage transitions of crop cycles
+type StageTransitionRepository interface {
+	Create(ctx context.Context, t *crop_cycle.StageTransition) error
+	CreateIfFirst(ctx context.Context, t *crop_cycle.StageTransition) (bool, error)
+	ListByCycle(ctx context.Context, cycleID string) ([]*crop_cycle.StageTransition, error)
+	ListByCycles(ctx context.Context, cycleIDs []string) (map[string][]*crop_cycle.StageTransition, error)
+}
+
+// StageTransitionRepositoryImpl implements StageTransitionRepository on PostgreSQL
+type StageTransitionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewStageTransitionRepository creates a new stage transition repository
+func NewStageTransitionRepository(db *gorm.DB) StageTransitionRepository {
+	return &StageTransitionRepositoryImpl{
+		db: db,
+	}
+}
+
+// Create stores a new stage transition
+func (r *StageTransitionRepositoryImpl) Create(ctx context.Context, t *crop_cycle.StageTransition) error {
+	if err := r.db.WithContext(ctx).Create(t).Error; err != nil {
+		return fmt.Errorf("failed to create stage transition: %w", err)
+	}
+	return nil
+}
+
+// CreateIfFirst stores a stage transition unless the cycle's entry into the stage is already
+// recorded. It reports whether the transition was stored.
+func (r *StageTransitionRepositoryImpl) CreateIfFirst(ctx context.Context, t *crop_cycle.StageTransition) (bool, error) {
+	created := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Serialise recordings for the cycle so that two activities cannot both start a stage
+		if err := tx.Exec("SELECT 1 FROM crop_cycles WHERE id = ? FOR UPDATE", t.CropCycleID).Error; err != nil {
+			return fmt.Errorf("failed to lock crop cycle: %w", err)
+		}
+
+		var count int64
+		if err := tx.Model(&crop_cycle.StageTransition{}).
+			Where("crop_cycle_id = ? AND crop_stage_id = ? AND deleted_at IS NULL", t.CropCycleID, t.CropStageID).
+			Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to check stage transitions: %w", err)
+		}
+		if count > 0 {
+			return nil
+		}
+
+		if err := tx.Create(t).Error; err != nil {
+			return fmt.Errorf("failed to create stage transition: %w", err)
+		}
+		created = true
+		return nil
+	})
+	return created, err
+}
+
+// ListByCycle lists the stage transitions of a crop cycle by start
+func (r *StageTransitionRepositoryImpl) ListByCycle(ctx context.Context, cycleID string) ([]*crop_cycle.StageTransition, error) {
+	var transitions []*crop_cycle.StageTransition
+	if err := r.db.WithContext(ctx).
+		Where("crop_cycle_id = ? AND deleted_at IS NULL", cycleID).
+		Order("started_at, id").
+		Find(&transitions).Error; err != nil {
+		return nil, fmt.Errorf("failed to list stage transitions: %w", err)
+	}
+	return transitions, nil
+}
+
+// ListByCycles lists the stage transitions of several crop cycles, keyed by cycle ID
+func (r *StageTransitionRepositoryImpl) ListByCycles(ctx context.Context, cycleIDs []string) (map[string][]*crop_cycle.StageTransition, error) {
+	byCycle := make(map[string][]*crop_cycle.StageTransition)
+	if len(cycleIDs) == 0 {
+		return byCycle, nil
+	}
+
+	var transitions []*crop_cycle.StageTransition
+	if err := r.db.WithContext(ctx).
+		Where("crop_cycle_id IN (?) AND deleted_at IS NULL", cycleIDs).
+		Order("started_at, id").
+		Find(&transitions).Error; err != nil {
+		return nil, fmt.Errorf("failed to list stage transitions: %w", err)
+	}
+	for _, t := range transitions {
+		byCycle[t.CropCycleID] = append(byCycle[t.CropCycleID], t)
+	}
+	return byCycle, nil
+}
@@ -0,0 +1,84 @@
+package farm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/admin_boundary"
+	"gorm.io/gorm"
+)
+
+// AdminCodesBackfillBatchSize is the number of farms tagged per statement by BackfillAdminCodes
+const AdminCodesBackfillBatchSize = 1000
+
+// adminCodesAssignment sets each admin code column of farms to the code of the boundary of
+// that level containing the farm, or NULL when no boundary does. The farm is located by
+// ST_PointOnSurface rather than ST_Centroid: the centroid of a crescent or L-shaped farm can
+// fall outside it, and so in the neighbouring village.
+func adminCodesAssignment() string {
+	assignments := make([]string, len(admin_boundary.AdminLevels))
+	for i, level := range admin_boundary.AdminLevels {
+		assignments[i] = fmt.Sprintf(`%s = (SELECT b.code FROM admin_boundaries b
+			WHERE b.level = '%s' AND b.deleted_at IS NULL
+			AND ST_Covers(b.geometry, ST_PointOnSurface(farms.geometry))
+			ORDER BY b.code LIMIT 1)`, level.FarmColumn(), level)
+	}
+	return strings.Join(assignments, ",\n")
+}
+
+// AssignAdminCodes tags farms with the state, district, block and village codes of the
+// administrative boundaries containing them. Farms without a boundary are left as they are.
+func (r *FarmRepository) AssignAdminCodes(ctx context.Context, farmIDs ...string) error {
+	if r.db == nil {
+		return fmt.Errorf("database connection not available")
+	}
+	return assignAdminCodes(r.db.WithContext(ctx), farmIDs...)
+}
+
+// assignAdminCodes is AssignAdminCodes run on tx
+func assignAdminCodes(tx *gorm.DB, farmIDs ...string) error {
+	if len(farmIDs) == 0 {
+		return nil
+	}
+
+	if err := tx.Exec(`UPDATE farms SET `+adminCodesAssignment()+`
+		WHERE id IN ? AND geometry IS NOT NULL`, farmIDs).Error; err != nil {
+		return fmt.Errorf("failed to assign admin codes: %w", err)
+	}
+	return nil
+}
+
+// BackfillAdminCodes tags every farm with a boundary again, in batches of
+// AdminCodesBackfillBatchSize farms taken in id order, and returns the number of farms tagged.
+// It is run after boundaries are imported, since new boundaries can change any farm's codes.
+func (r *FarmRepository) BackfillAdminCodes(ctx context.Context) (int64, error) {
+	if r.db == nil {
+		return 0, fmt.Errorf("database connection not available")
+	}
+
+	var tagged int64
+	lastID := ""
+	for {
+		var ids []string
+		if err := r.db.WithContext(ctx).Table("farms").
+			Where("id > ? AND deleted_at IS NULL AND geometry IS NOT NULL", lastID).
+			Order("id").Limit(AdminCodesBackfillBatchSize).
+			Pluck("id", &ids).Error; err != nil {
+			return tagged, fmt.Errorf("failed to list farms to tag: %w", err)
+		}
+		if len(ids) == 0 {
+			return tagged, nil
+		}
+
+		if err := r.AssignAdminCodes(ctx, ids...); err != nil {
+			return tagged, err
+		}
+		tagged += int64(len(ids))
+		lastID = ids[len(ids)-1]
+
+		if err := ctx.Err(); err != nil {
+			return tagged, err
+		}
+	}
+}
@@ -3,9 +3,11 @@ package farm
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/Kisanlink/farmers-module/internal/entities/farm"
 	"github.com/Kisanlink/farmers-module/internal/entities/requests"
+	"github.com/Kisanlink/farmers-module/pkg/geo"
 	"github.com/Kisanlink/kisanlink-db/pkg/base"
 	"gorm.io/gorm"
 )
@@ -116,6 +118,75 @@ func (r *FarmRepository) ValidateGeometry(ctx context.Context, wkt string) error
 	return nil
 }
 
+// GeometryRepair is a boundary repaired by RepairGeometry, for the user to accept or reject
+type GeometryRepair struct {
+	WKT            string
+	OriginalAreaHa float64
+	RepairedAreaHa float64
+	Corrections    []string
+}
+
+// RepairGeometry repairs a captured boundary: duplicate vertices and spikes are removed, the
+// boundary is simplified within simplifyToleranceM metres, self-intersections are resolved
+// with ST_MakeValid and rings are wound counter-clockwise, holes clockwise. Without PostGIS
+// self-intersections are left in place.
+func (r *FarmRepository) RepairGeometry(ctx context.Context, wkt string, simplifyToleranceM float64) (*GeometryRepair, error) {
+	original, err := geo.ParseWKT(wkt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid geometry: %w", err)
+	}
+	repaired, corrections, err := geo.Repair(original, geo.RepairOptions{SimplifyToleranceM: simplifyToleranceM})
+	if err != nil {
+		return nil, fmt.Errorf("geometry cannot be repaired: %w", err)
+	}
+
+	var postgisAvailable bool
+	if r.db != nil {
+		if err := r.db.WithContext(ctx).Raw(`SELECT EXISTS(SELECT 1 FROM pg_extension WHERE extname = 'postgis')`).Scan(&postgisAvailable).Error; err != nil {
+			return nil, fmt.Errorf("failed to check PostGIS availability: %w", err)
+		}
+	}
+	if postgisAvailable {
+		var validity struct {
+			IsValid bool
+			Reason  string
+		}
+		if err := r.db.WithContext(ctx).Raw(`SELECT ST_IsValid(g) AS is_valid, ST_IsValidReason(g) AS reason
+			FROM ST_GeomFromText(?, 4326) AS g`, repaired.WKT()).Scan(&validity).Error; err != nil {
+			return nil, fmt.Errorf("failed to validate repaired geometry: %w", err)
+		}
+		if !validity.IsValid {
+			// Keep only the polygonal parts ST_MakeValid returns, dropping collapsed lines and points
+			var made string
+			if err := r.db.WithContext(ctx).Raw(`SELECT ST_AsText(ST_Multi(ST_CollectionExtract(ST_MakeValid(ST_GeomFromText(?, 4326)), 3)))`,
+				repaired.WKT()).Scan(&made).Error; err != nil {
+				return nil, fmt.Errorf("failed to make geometry valid: %w", err)
+			}
+			valid, err := geo.ParseWKT(made)
+			if err != nil {
+				return nil, fmt.Errorf("geometry cannot be repaired: %w", err)
+			}
+			// ST_MakeValid does not keep the winding order
+			if repaired, _, err = geo.Repair(valid, geo.RepairOptions{}); err != nil {
+				return nil, fmt.Errorf("geometry cannot be repaired: %w", err)
+			}
+			// The reason names the first problem and its location, e.g. "Self-intersection[78 17]"
+			reason := validity.Reason
+			if i := strings.Index(reason, "["); i > 0 {
+				reason = reason[:i]
+			}
+			corrections = append(corrections, "resolved "+strings.ToLower(reason))
+		}
+	}
+
+	return &GeometryRepair{
+		WKT:            repaired.WKT(),
+		OriginalAreaHa: geo.AreaHa(original),
+		RepairedAreaHa: geo.AreaHa(repaired),
+		Corrections:    corrections,
+	}, nil
+}
+
 // Note: Count method is inherited from BaseFilterableRepository
 // which properly delegates to PostgresManager.Count()
 // No need to override it here
@@ -158,13 +229,13 @@ func (r *FarmRepository) CheckOverlap(ctx context.Context, wkt string, excludeFa
 	}
 
 	if !postgisAvailable {
-		// Cannot check overlap without PostGIS
-		return false, nil, 0, nil
+		return r.checkOverlapWithoutPostGIS(ctx, wkt, excludeFarmID, orgID)
 	}
 
-	// Query for overlapping farms
+	// Query for overlapping farms. The intersection is cast to geography so that its area is
+	// in square metres rather than square degrees.
 	query := `
-		SELECT id, ST_Area(ST_Intersection(geometry, ST_GeomFromText(?, 4326)))/10000.0 as overlap_area
+		SELECT id, ST_Area(ST_Intersection(geometry, ST_GeomFromText(?, 4326))::geography)/10000.0 as overlap_area
 		FROM farms
 		WHERE aaa_org_id = ?
 		AND ST_Intersects(geometry, ST_GeomFromText(?, 4326))
@@ -203,3 +274,40 @@ func (r *FarmRepository) CheckOverlap(ctx context.Context, wkt string, excludeFa
 	hasOverlap := len(overlappingFarms) > 0
 	return hasOverlap, overlappingFarms, totalOverlapArea, nil
 }
+
+// checkOverlapWithoutPostGIS computes CheckOverlap in Go from the stored WKT of the
+// organization's farms
+func (r *FarmRepository) checkOverlapWithoutPostGIS(ctx context.Context, wkt string, excludeFarmID string, orgID string) (bool, []string, float64, error) {
+	candidate, err := geo.ParseWKT(wkt)
+	if err != nil {
+		return false, nil, 0, fmt.Errorf("invalid WKT format: %w", err)
+	}
+
+	query := r.db.WithContext(ctx).Model(&farm.Farm{}).
+		Select("id, geometry").
+		Where("aaa_org_id = ? AND deleted_at IS NULL", orgID)
+	if excludeFarmID != "" {
+		query = query.Where("id != ?", excludeFarmID)
+	}
+
+	var farms []struct {
+		ID       string
+		Geometry string
+	}
+	if err := query.Scan(&farms).Error; err != nil {
+		return false, nil, 0, fmt.Errorf("failed to check overlap: %w", err)
+	}
+
+	var overlappingFarms []string
+	var totalOverlapArea float64
+	for _, existing := range farms {
+		geometry, err := geo.ParseGeometry(existing.Geometry)
+		if err != nil || !geo.Intersects(candidate, geometry) {
+			continue
+		}
+		overlappingFarms = append(overlappingFarms, existing.ID)
+		totalOverlapArea += geo.IntersectionAreaHa(candidate, geometry)
+	}
+
+	return len(overlappingFarms) > 0, overlappingFarms, totalOverlapArea, nil
+}
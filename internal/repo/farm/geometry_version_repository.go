@@ -0,0 +1,225 @@
+package farm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/farm"
+	"github.com/Kisanlink/farmers-module/pkg/geo"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GeometryDiff is the area gained and lost between two boundary versions of a farm, as WKT
+// MULTIPOLYGONs that are empty when nothing changed on that side
+type GeometryDiff struct {
+	AddedWKT      string
+	AddedAreaHa   float64
+	RemovedWKT    string
+	RemovedAreaHa float64
+}
+
+// BoundaryChange is a new boundary given to a farm, with the version recording it in the
+// farm's boundary history
+type BoundaryChange struct {
+	Farm    *farm.Farm
+	Version *farm.FarmGeometryVersion
+}
+
+// CreateWithGeometryVersion creates a farm with a boundary in one transaction with the first
+// version of its boundary history, valid from the farm's creation, and tags it with its
+// administrative areas
+func (r *FarmRepository) CreateWithGeometryVersion(ctx context.Context, f *farm.Farm, version *farm.FarmGeometryVersion) error {
+	if r.db == nil {
+		return fmt.Errorf("database connection not available")
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(f).Error; err != nil {
+			return fmt.Errorf("failed to create farm: %w", err)
+		}
+		if err := assignAdminCodes(tx, f.ID); err != nil {
+			return err
+		}
+		version.FarmID = f.ID
+		version.ValidFrom = f.CreatedAt
+		return addGeometryVersion(tx, version)
+	})
+}
+
+// ReplaceBoundary stores a farm whose geometry has been changed in one transaction with the
+// version recording the new boundary. It is common.ErrInvalidInput when the new boundary
+// cuts through a plot of the farm.
+func (r *FarmRepository) ReplaceBoundary(ctx context.Context, change BoundaryChange) error {
+	if r.db == nil {
+		return fmt.Errorf("database connection not available")
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return replaceBoundary(tx, change)
+	})
+}
+
+// replaceBoundary stores a farm whose geometry has been changed on tx once its plots are
+// found to lie within the new boundary: the boundary it had before is kept as a baseline, the
+// farm is tagged again with its administrative areas and the new boundary is versioned, valid
+// from the farm's update
+func replaceBoundary(tx *gorm.DB, change BoundaryChange) error {
+	if err := checkPlotsWithin(tx, change.Farm.ID, change.Version.Geometry); err != nil {
+		return err
+	}
+	if err := ensureGeometryBaseline(tx, change.Farm.ID); err != nil {
+		return err
+	}
+	if err := tx.Save(change.Farm).Error; err != nil {
+		return fmt.Errorf("failed to update farm: %w", err)
+	}
+	if err := assignAdminCodes(tx, change.Farm.ID); err != nil {
+		return err
+	}
+	change.Version.FarmID = change.Farm.ID
+	change.Version.ValidFrom = change.Farm.UpdatedAt
+	return addGeometryVersion(tx, change.Version)
+}
+
+// EnsureGeometryBaseline records the current boundary of a farm as its first version when the
+// farm has no boundary history yet, so that the boundary it had before versioning began is
+// kept when it is changed. It must be called before the farm's geometry is overwritten.
+func (r *FarmRepository) EnsureGeometryBaseline(ctx context.Context, farmID string) error {
+	if r.db == nil {
+		return fmt.Errorf("database connection not available")
+	}
+
+	return ensureGeometryBaseline(r.db.WithContext(ctx), farmID)
+}
+
+// ensureGeometryBaseline is EnsureGeometryBaseline run on tx
+func ensureGeometryBaseline(tx *gorm.DB, farmID string) error {
+	baseline := farm.NewFarmGeometryVersion()
+	if err := tx.Exec(`
+		INSERT INTO farm_geometry_versions
+			(id, created_at, updated_at, created_by, updated_by, farm_id, version, geometry, area_ha, area_delta_ha, source, author_id, valid_from)
+		SELECT ?, NOW(), NOW(), created_by, created_by, id, 1, ST_Multi(geometry), COALESCE(area_ha_computed, 0), 0, ?, created_by, created_at
+		FROM farms
+		WHERE id = ? AND geometry IS NOT NULL AND deleted_at IS NULL
+		AND NOT EXISTS (SELECT 1 FROM farm_geometry_versions WHERE farm_id = ?)
+		ON CONFLICT DO NOTHING`,
+		baseline.ID, farm.GeometrySourceUnknown, farmID, farmID).Error; err != nil {
+		return fmt.Errorf("failed to record baseline geometry version: %w", err)
+	}
+	return nil
+}
+
+// AddGeometryVersion records a new boundary of a farm: it closes the current version at the
+// new version's valid_from and numbers the new version after it. The area and the change in
+// area are computed from the geometry.
+func (r *FarmRepository) AddGeometryVersion(ctx context.Context, version *farm.FarmGeometryVersion) error {
+	if r.db == nil {
+		return fmt.Errorf("database connection not available")
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return addGeometryVersion(tx, version)
+	})
+}
+
+// addGeometryVersion is AddGeometryVersion run inside the transaction tx
+func addGeometryVersion(tx *gorm.DB, version *farm.FarmGeometryVersion) error {
+	geometry, err := geo.ParseGeometry(version.Geometry)
+	if err != nil {
+		return fmt.Errorf("invalid geometry: %w", err)
+	}
+	version.AreaHa = geo.AreaHa(geometry)
+
+	var latest struct {
+		Version int
+		AreaHa  float64
+	}
+	result := tx.Model(&farm.FarmGeometryVersion{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("version, area_ha").
+		Where("farm_id = ?", version.FarmID).
+		Order("version DESC").
+		Limit(1).
+		Scan(&latest)
+	if result.Error != nil {
+		return fmt.Errorf("failed to read latest geometry version: %w", result.Error)
+	}
+
+	version.Version = latest.Version + 1
+	version.AreaDeltaHa = 0
+	if result.RowsAffected > 0 {
+		version.AreaDeltaHa = version.AreaHa - latest.AreaHa
+	}
+
+	if err := tx.Model(&farm.FarmGeometryVersion{}).
+		Where("farm_id = ? AND valid_to IS NULL", version.FarmID).
+		Updates(map[string]interface{}{"valid_to": version.ValidFrom, "updated_at": time.Now()}).Error; err != nil {
+		return fmt.Errorf("failed to close current geometry version: %w", err)
+	}
+	if err := tx.Create(version).Error; err != nil {
+		return fmt.Errorf("failed to create geometry version: %w", err)
+	}
+	return nil
+}
+
+// ListGeometryVersions lists the boundary versions of a farm, oldest first
+func (r *FarmRepository) ListGeometryVersions(ctx context.Context, farmID string) ([]*farm.FarmGeometryVersion, error) {
+	if r.db == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+
+	var versions []*farm.FarmGeometryVersion
+	if err := r.db.WithContext(ctx).
+		Where("farm_id = ? AND deleted_at IS NULL", farmID).
+		Order("version ASC").
+		Find(&versions).Error; err != nil {
+		return nil, fmt.Errorf("failed to list geometry versions: %w", err)
+	}
+	return versions, nil
+}
+
+// GetGeometryVersionAt returns the boundary version of a farm in force at the given time, or
+// gorm.ErrRecordNotFound when the farm had no recorded boundary then
+func (r *FarmRepository) GetGeometryVersionAt(ctx context.Context, farmID string, at time.Time) (*farm.FarmGeometryVersion, error) {
+	if r.db == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+
+	var version farm.FarmGeometryVersion
+	if err := r.db.WithContext(ctx).
+		Where("farm_id = ? AND deleted_at IS NULL AND valid_from <= ? AND (valid_to IS NULL OR valid_to > ?)", farmID, at, at).
+		Order("version DESC").
+		First(&version).Error; err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
+
+// DiffGeometryVersions returns the area a farm gained and lost going from one boundary
+// version to another, or gorm.ErrRecordNotFound when either version does not exist
+func (r *FarmRepository) DiffGeometryVersions(ctx context.Context, farmID string, fromVersion, toVersion int) (*GeometryDiff, error) {
+	if r.db == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+
+	var rows []GeometryDiff
+	if err := r.db.WithContext(ctx).Raw(`
+		SELECT ST_AsText(added) AS added_wkt, ST_Area(added::geography)/10000.0 AS added_area_ha,
+			ST_AsText(removed) AS removed_wkt, ST_Area(removed::geography)/10000.0 AS removed_area_ha
+		FROM (
+			SELECT ST_Multi(ST_CollectionExtract(ST_Difference(b.geometry, a.geometry), 3)) AS added,
+				ST_Multi(ST_CollectionExtract(ST_Difference(a.geometry, b.geometry), 3)) AS removed
+			FROM farm_geometry_versions a, farm_geometry_versions b
+			WHERE a.farm_id = ? AND a.version = ? AND a.deleted_at IS NULL
+			AND b.farm_id = ? AND b.version = ? AND b.deleted_at IS NULL
+		) AS diff`,
+		farmID, fromVersion, farmID, toVersion).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to diff geometry versions: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &rows[0], nil
+}
@@ -0,0 +1,110 @@
+package farm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/farm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func newGeometryVersionTestRepository(t *testing.T) *FarmRepository {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.Exec(`
+		CREATE TABLE farm_geometry_versions (
+			id VARCHAR(255) PRIMARY KEY,
+			created_at DATETIME NOT NULL,
+			updated_at DATETIME NOT NULL,
+			created_by VARCHAR(255),
+			updated_by VARCHAR(255),
+			deleted_at DATETIME,
+			deleted_by VARCHAR(255),
+			farm_id VARCHAR(255) NOT NULL,
+			version INTEGER NOT NULL,
+			geometry TEXT NOT NULL,
+			area_ha REAL NOT NULL DEFAULT 0,
+			area_delta_ha REAL NOT NULL DEFAULT 0,
+			source VARCHAR(20) NOT NULL DEFAULT 'UNKNOWN',
+			reason TEXT,
+			author_id VARCHAR(255),
+			valid_from DATETIME NOT NULL,
+			valid_to DATETIME,
+			UNIQUE (farm_id, version)
+		);
+	`).Error)
+	return &FarmRepository{db: db}
+}
+
+func addTestGeometryVersion(t *testing.T, r *FarmRepository, farmID, wkt string, validFrom time.Time) *farm.FarmGeometryVersion {
+	version := farm.NewFarmGeometryVersion()
+	version.FarmID, version.Geometry, version.ValidFrom = farmID, wkt, validFrom
+	require.NoError(t, r.AddGeometryVersion(context.Background(), version))
+	return version
+}
+
+func TestAddGeometryVersion(t *testing.T) {
+	r := newGeometryVersionTestRepository(t)
+	ctx := context.Background()
+	created := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	redrawn := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	// A 0.002° square farm, later cut back to its west half
+	first := addTestGeometryVersion(t, r, "FARM00000001", "POLYGON((78 17,78.002 17,78.002 17.002,78 17.002,78 17))", created)
+	second := addTestGeometryVersion(t, r, "FARM00000001", "POLYGON((78 17,78.001 17,78.001 17.002,78 17.002,78 17))", redrawn)
+	addTestGeometryVersion(t, r, "FARM00000002", "POLYGON((78 17,78.001 17,78.001 17.001,78 17.001,78 17))", redrawn)
+
+	assert.Equal(t, 1, first.Version)
+	assert.Zero(t, first.AreaDeltaHa)
+	assert.InDelta(t, 4.7, first.AreaHa, 0.1)
+	assert.Equal(t, 2, second.Version)
+	assert.InDelta(t, -first.AreaHa/2, second.AreaDeltaHa, 0.01)
+	assert.Contains(t, second.Geometry, "MULTIPOLYGON")
+
+	// The first boundary is closed when the second takes over; the second stays open
+	versions, err := r.ListGeometryVersions(ctx, "FARM00000001")
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	assert.Equal(t, first.ID, versions[0].ID)
+	require.NotNil(t, versions[0].ValidTo)
+	assert.True(t, redrawn.Equal(*versions[0].ValidTo))
+	assert.Nil(t, versions[1].ValidTo)
+}
+
+func TestGetGeometryVersionAt(t *testing.T) {
+	r := newGeometryVersionTestRepository(t)
+	ctx := context.Background()
+	created := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	redrawn := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	first := addTestGeometryVersion(t, r, "FARM00000001", "POLYGON((78 17,78.002 17,78.002 17.002,78 17.002,78 17))", created)
+	second := addTestGeometryVersion(t, r, "FARM00000001", "POLYGON((78 17,78.001 17,78.001 17.002,78 17.002,78 17))", redrawn)
+
+	tests := []struct {
+		name string
+		at   time.Time
+		want string
+	}{
+		{"when created", created, first.ID},
+		{"during the first boundary", time.Date(2023, time.July, 15, 0, 0, 0, 0, time.UTC), first.ID},
+		{"when redrawn", redrawn, second.ID},
+		{"after the redraw", time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC), second.ID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, err := r.GetGeometryVersionAt(ctx, "FARM00000001", tt.at)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, version.ID)
+		})
+	}
+
+	// Before the farm had a boundary there is nothing to return
+	_, err := r.GetGeometryVersionAt(ctx, "FARM00000001", created.Add(-time.Hour))
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	_, err = r.GetGeometryVersionAt(ctx, "FARM00000002", redrawn)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
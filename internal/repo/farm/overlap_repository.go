@@ -0,0 +1,120 @@
+package farm
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/farm_overlap"
+	"github.com/Kisanlink/farmers-module/pkg/common"
+	"gorm.io/gorm"
+)
+
+// ChangedFarmIDs lists an organization's farms created, updated or deleted after since
+func (r *FarmRepository) ChangedFarmIDs(ctx context.Context, orgID string, since time.Time) ([]string, error) {
+	if r.db == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+
+	var ids []string
+	if err := r.db.WithContext(ctx).Table("farms").
+		Where("aaa_org_id = ? AND (updated_at > ? OR deleted_at > ?)", orgID, since, since).
+		Pluck("id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("failed to list changed farms: %w", err)
+	}
+	return ids, nil
+}
+
+// TrimmedGeometry returns the boundary of a farm with the area it shares with another farm
+// removed, as a WKT MULTIPOLYGON. It is common.ErrInvalidInput when nothing of the farm
+// would be left.
+func (r *FarmRepository) TrimmedGeometry(ctx context.Context, farmID, otherFarmID string) (string, error) {
+	if r.db == nil {
+		return "", fmt.Errorf("database connection not available")
+	}
+
+	var trimmed sql.NullString
+	if err := r.db.WithContext(ctx).Raw(`
+		SELECT ST_AsText(ST_Multi(ST_CollectionExtract(ST_MakeValid(ST_Difference(a.geometry, b.geometry)), 3)))
+		FROM farms a, farms b
+		WHERE a.id = ? AND b.id = ? AND a.deleted_at IS NULL AND b.deleted_at IS NULL`,
+		farmID, otherFarmID).Scan(&trimmed).Error; err != nil {
+		return "", fmt.Errorf("failed to trim farm boundary: %w", err)
+	}
+	if !trimmed.Valid {
+		return "", common.ErrNotFound
+	}
+	if trimmed.String == "MULTIPOLYGON EMPTY" {
+		return "", fmt.Errorf("%w: farm %s lies entirely within farm %s and cannot be trimmed to it", common.ErrInvalidInput, farmID, otherFarmID)
+	}
+	return trimmed.String, nil
+}
+
+// SplitOverlapGeometries divides the area two farms share between them and returns both
+// new boundaries as WKT MULTIPOLYGONs. Each part of the shared area goes to the farm whose
+// unshared land is nearer: the shared area is cut along the perpendicular bisector of the
+// centroids of the two farms' unshared land. It is common.ErrInvalidInput when either farm
+// lies entirely within the other.
+func (r *FarmRepository) SplitOverlapGeometries(ctx context.Context, farm1ID, farm2ID string) (string, string, error) {
+	if r.db == nil {
+		return "", "", fmt.Errorf("database connection not available")
+	}
+
+	var split struct {
+		Found    bool
+		Farm1WKT sql.NullString
+		Farm2WKT sql.NullString
+	}
+	if err := r.db.WithContext(ctx).Raw(`
+		WITH pair AS (
+			SELECT a.geometry AS a, b.geometry AS b,
+				ST_Intersection(a.geometry, b.geometry) AS shared,
+				ST_Centroid(ST_Difference(a.geometry, b.geometry)) AS site_a,
+				ST_Centroid(ST_Difference(b.geometry, a.geometry)) AS site_b
+			FROM farms a, farms b
+			WHERE a.id = ? AND b.id = ? AND a.deleted_at IS NULL AND b.deleted_at IS NULL
+		), cells AS (
+			SELECT pair.*,
+				(SELECT d.geom FROM ST_Dump(ST_VoronoiPolygons(ST_Collect(site_a, site_b), 0,
+					ST_Expand(ST_Envelope(ST_Collect(a, b)), 0.01))) d
+				WHERE ST_Contains(d.geom, site_a)) AS cell_a
+			FROM pair
+			WHERE NOT ST_IsEmpty(site_a) AND NOT ST_IsEmpty(site_b) AND NOT ST_Equals(site_a, site_b)
+		)
+		SELECT TRUE AS found,
+			(SELECT ST_AsText(ST_Multi(ST_CollectionExtract(ST_MakeValid(ST_Difference(a, ST_Difference(shared, cell_a))), 3))) FROM cells) AS farm1_wkt,
+			(SELECT ST_AsText(ST_Multi(ST_CollectionExtract(ST_MakeValid(ST_Difference(b, ST_Intersection(shared, cell_a))), 3))) FROM cells) AS farm2_wkt
+		FROM pair`,
+		farm1ID, farm2ID).Scan(&split).Error; err != nil {
+		return "", "", fmt.Errorf("failed to split shared area: %w", err)
+	}
+	if !split.Found {
+		return "", "", common.ErrNotFound
+	}
+	if !split.Farm1WKT.Valid || !split.Farm2WKT.Valid {
+		return "", "", fmt.Errorf("%w: the shared area of farms %s and %s cannot be split because one lies entirely within the other", common.ErrInvalidInput, farm1ID, farm2ID)
+	}
+	return split.Farm1WKT.String, split.Farm2WKT.String, nil
+}
+
+// ResolveOverlapConflict stores the new boundaries of the farms of an overlap conflict and the
+// resolved conflict in one transaction, so that a conflict is never left open with only one
+// of its farms changed
+func (r *FarmRepository) ResolveOverlapConflict(ctx context.Context, conflict *farm_overlap.FarmOverlapConflict, changes []BoundaryChange) error {
+	if r.db == nil {
+		return fmt.Errorf("database connection not available")
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, change := range changes {
+			if err := replaceBoundary(tx, change); err != nil {
+				return err
+			}
+		}
+		if err := tx.Save(conflict).Error; err != nil {
+			return fmt.Errorf("failed to update overlap conflict: %w", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,39 @@
+package farm
+
+import (
+	"fmt"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/plot"
+	"github.com/Kisanlink/farmers-module/pkg/common"
+	"github.com/Kisanlink/farmers-module/pkg/geo"
+	"gorm.io/gorm"
+)
+
+// checkPlotsWithin checks that a new boundary of a farm still contains every plot of the
+// farm, so that a boundary change never leaves part of a plot outside its farm. It is
+// common.ErrInvalidInput naming the first plot cut by the boundary.
+func checkPlotsWithin(tx *gorm.DB, farmID, wkt string) error {
+	var plots []*plot.Plot
+	if err := tx.Where("farm_id = ? AND deleted_at IS NULL", farmID).Order("name, id").Find(&plots).Error; err != nil {
+		return fmt.Errorf("failed to list plots: %w", err)
+	}
+	if len(plots) == 0 {
+		return nil
+	}
+
+	farmShape, err := geo.ParseGeometry(wkt)
+	if err != nil {
+		return fmt.Errorf("%w: %v", common.ErrInvalidInput, err)
+	}
+	for _, p := range plots {
+		plotShape, err := p.Shape()
+		if err != nil {
+			continue
+		}
+		if outside := plot.OutsideAreaHa(plotShape, farmShape); outside >= plot.ToleranceHa {
+			return fmt.Errorf("%w: the new boundary of farm %s leaves %.4f ha of plot %s outside it; redraw or delete the plot first",
+				common.ErrInvalidInput, farmID, outside, p.ID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package farm
+
+import (
+	"testing"
+
+	"github.com/Kisanlink/farmers-module/pkg/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestCheckPlotsWithin(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.Exec(`
+		CREATE TABLE plots (
+			id VARCHAR(255) PRIMARY KEY,
+			created_at DATETIME NOT NULL,
+			updated_at DATETIME NOT NULL,
+			created_by VARCHAR(255),
+			updated_by VARCHAR(255),
+			deleted_at DATETIME,
+			deleted_by VARCHAR(255),
+			farm_id VARCHAR(255) NOT NULL,
+			aaa_org_id VARCHAR(255) NOT NULL,
+			name VARCHAR(255) NOT NULL,
+			geometry TEXT NOT NULL,
+			area_ha REAL NOT NULL DEFAULT 0
+		);
+	`).Error)
+
+	// The west half of a 0.002° farm
+	require.NoError(t, db.Exec(`INSERT INTO plots (id, created_at, updated_at, farm_id, aaa_org_id, name, geometry)
+		VALUES ('PLOT00000001', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, 'FARM00000001', 'org-1', 'West',
+		'MULTIPOLYGON(((78 17,78.001 17,78.001 17.002,78 17.002,78 17)))')`).Error)
+
+	// Shrinking the farm to its west half keeps the plot
+	assert.NoError(t, checkPlotsWithin(db, "FARM00000001", "POLYGON((78 17,78.001 17,78.001 17.002,78 17.002,78 17))"))
+
+	// Shrinking it to its east half cuts the plot off
+	err = checkPlotsWithin(db, "FARM00000001", "POLYGON((78.001 17,78.002 17,78.002 17.002,78.001 17.002,78.001 17))")
+	assert.ErrorIs(t, err, common.ErrInvalidInput)
+	assert.Contains(t, err.Error(), "PLOT00000001")
+
+	// Other farms' plots are not checked
+	assert.NoError(t, checkPlotsWithin(db, "FARM00000002", "POLYGON((0 0,0 1,1 1,1 0,0 0))"))
+}
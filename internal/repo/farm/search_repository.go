@@ -0,0 +1,173 @@
+package farm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/farm"
+	"github.com/Kisanlink/farmers-module/internal/entities/requests"
+	"gorm.io/gorm"
+)
+
+// SpatialFilter selects farms by location. Exactly one criterion is set: RadiusM around the
+// point, a WithinWKT boundary, the Nearest farms to the point, or a BBox.
+type SpatialFilter struct {
+	Lat        float64
+	Lon        float64
+	RadiusM    float64
+	WithinWKT  string
+	Intersects bool // With WithinWKT, also match farms crossing the boundary
+	Nearest    int
+	BBox       *requests.BoundingBox
+}
+
+// FarmSearchFilter holds the attribute filters of a farm search; empty fields match any farm
+type FarmSearchFilter struct {
+	AAAOrgID           string
+	FarmerID           string
+	AAAUserID          string
+	OwnershipType      string
+	SoilTypeID         string
+	IrrigationSourceID string
+	MinAreaHa          *float64
+	MaxAreaHa          *float64
+	AdminArea          requests.AdminAreaFilter
+}
+
+// FarmSearchResult is a farm found by a spatial search with its distance from the search
+// point, which is nil for boundary and bounding box searches
+type FarmSearchResult struct {
+	Farm      *farm.Farm
+	DistanceM *float64
+}
+
+// SearchSpatial finds the farms matching a spatial filter and attribute filters, nearest first
+// for point searches. Distances are geodesic. Nearest searches match at most Nearest farms, of
+// which limit and offset select a page.
+func (r *FarmRepository) SearchSpatial(ctx context.Context, spatial SpatialFilter, filter FarmSearchFilter, limit, offset int) ([]FarmSearchResult, int64, error) {
+	if r.db == nil {
+		return nil, 0, fmt.Errorf("database connection not available")
+	}
+
+	const point = "ST_SetSRID(ST_MakePoint(?, ?), 4326)::geography"
+	query := r.db.WithContext(ctx).Model(&farm.Farm{}).Where("deleted_at IS NULL AND geometry IS NOT NULL")
+	query = ApplyFarmSearchFilter(query, filter)
+
+	distance := "NULL::float8"
+	var distanceArgs []interface{}
+	switch {
+	case spatial.RadiusM > 0:
+		query = query.Where("ST_DWithin(geometry::geography, "+point+", ?)", spatial.Lon, spatial.Lat, spatial.RadiusM)
+		distance, distanceArgs = "ST_Distance(geometry::geography, "+point+")", []interface{}{spatial.Lon, spatial.Lat}
+	case spatial.Nearest > 0:
+		distance, distanceArgs = "ST_Distance(geometry::geography, "+point+")", []interface{}{spatial.Lon, spatial.Lat}
+	case spatial.WithinWKT != "" && spatial.Intersects:
+		query = query.Where("ST_Intersects(geometry, ST_GeomFromText(?, 4326))", spatial.WithinWKT)
+	case spatial.WithinWKT != "":
+		query = query.Where("ST_CoveredBy(geometry, ST_GeomFromText(?, 4326))", spatial.WithinWKT)
+	case spatial.BBox != nil:
+		query = query.Where("ST_Intersects(geometry, ST_MakeEnvelope(?, ?, ?, ?, 4326))",
+			spatial.BBox.MinLon, spatial.BBox.MinLat, spatial.BBox.MaxLon, spatial.BBox.MaxLat)
+	default:
+		return nil, 0, fmt.Errorf("no spatial criterion given")
+	}
+
+	// Share the conditions between the count and the page
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count farms: %w", err)
+	}
+
+	// Nearest searches rank every matching farm and keep the first N
+	if spatial.Nearest > 0 {
+		var inRange bool
+		if limit, total, inRange = nearestPage(spatial.Nearest, total, limit, offset); !inRange {
+			return []FarmSearchResult{}, total, nil
+		}
+	}
+
+	var rows []struct {
+		ID        string
+		DistanceM *float64
+	}
+	order := "id"
+	if distanceArgs != nil {
+		order = "distance_m, id"
+	}
+	if err := query.
+		Select("id, "+distance+" AS distance_m", distanceArgs...).
+		Order(order).
+		Limit(limit).Offset(offset).
+		Scan(&rows).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to search farms: %w", err)
+	}
+	if len(rows) == 0 {
+		return []FarmSearchResult{}, total, nil
+	}
+
+	ids := make([]string, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID
+	}
+	var farms []*farm.Farm
+	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&farms).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to load farms: %w", err)
+	}
+	byID := make(map[string]*farm.Farm, len(farms))
+	for _, f := range farms {
+		byID[f.ID] = f
+	}
+
+	results := make([]FarmSearchResult, 0, len(rows))
+	for _, row := range rows {
+		if f, ok := byID[row.ID]; ok {
+			results = append(results, FarmSearchResult{Farm: f, DistanceM: row.DistanceM})
+		}
+	}
+	return results, total, nil
+}
+
+// nearestPage cuts a page of a nearest-N search down to the nearest farms: the total matching
+// counts at most nearest farms and the page stops at the last of them. It is false when the
+// page starts past them.
+func nearestPage(nearest int, total int64, limit, offset int) (int, int64, bool) {
+	if total > int64(nearest) {
+		total = int64(nearest)
+	}
+	if offset >= nearest {
+		return 0, total, false
+	}
+	if offset+limit > nearest {
+		limit = nearest - offset
+	}
+	return limit, total, true
+}
+
+// ApplyFarmSearchFilter adds the attribute filters of a farm search to a query on farms, for
+// listings that build their own query
+func ApplyFarmSearchFilter(query *gorm.DB, filter FarmSearchFilter) *gorm.DB {
+	for column, value := range map[string]string{
+		"aaa_org_id":                   filter.AAAOrgID,
+		"farmer_id":                    filter.FarmerID,
+		"aaa_user_id":                  filter.AAAUserID,
+		"ownership_type":               filter.OwnershipType,
+		"soil_type_id":                 filter.SoilTypeID,
+		"primary_irrigation_source_id": filter.IrrigationSourceID,
+	} {
+		if value != "" {
+			query = query.Where(column+" = ?", value)
+		}
+	}
+	for column, code := range filter.AdminArea.Columns() {
+		query = query.Where(column+" = ?", code)
+	}
+	if filter.MinAreaHa != nil {
+		query = query.Where("area_ha >= ?", *filter.MinAreaHa)
+	}
+	if filter.MaxAreaHa != nil {
+		query = query.Where("area_ha <= ?", *filter.MaxAreaHa)
+	}
+	return query
+}
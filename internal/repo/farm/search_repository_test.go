@@ -0,0 +1,36 @@
+package farm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNearestPage(t *testing.T) {
+	tests := []struct {
+		name      string
+		total     int64
+		limit     int
+		offset    int
+		wantLimit int
+		wantTotal int64
+		wantOK    bool
+	}{
+		{"first page", 40, 5, 0, 5, 10, true},
+		{"page ending at the last farm", 40, 5, 5, 5, 10, true},
+		{"page running past the last farm", 40, 5, 8, 2, 10, true},
+		{"page starting at the last farm", 40, 5, 9, 1, 10, true},
+		{"page starting past the last farm", 40, 5, 10, 0, 10, false},
+		{"far past the last farm", 40, 5, 25, 0, 10, false},
+		{"page larger than the nearest farms", 40, 20, 0, 10, 10, true},
+		{"fewer farms matching than asked for", 3, 5, 0, 5, 3, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limit, total, ok := nearestPage(10, tt.total, tt.limit, tt.offset)
+			assert.Equal(t, tt.wantLimit, limit)
+			assert.Equal(t, tt.wantTotal, total)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+}
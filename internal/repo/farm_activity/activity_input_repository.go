@@ -0,0 +1,98 @@
+package farm_activity
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/farm_activity"
+	"gorm.io/gorm"
+)
+
+// InputCostTotal holds the cost of the input line items of a crop cycle in one category,
+// either of its completed activities or of its planned ones
+type InputCostTotal struct {
+	CropCycleID string
+	Category    string
+	Completed   bool
+	TotalCost   float64
+	LineItems   int64
+}
+
+// ActivityInputRepository defines the storage of the input line items of farm activities
+type ActivityInputRepository interface {
+	ReplaceForActivity(ctx context.Context, activityID string, inputs []*farm_activity.ActivityInput, deletedBy string) error
+	ListByActivity(ctx context.Context, activityID string) ([]*farm_activity.ActivityInput, error)
+	SumCostsByCycles(ctx context.Context, cycleIDs []string) ([]*InputCostTotal, error)
+}
+
+// ActivityInputRepositoryImpl implements ActivityInputRepository on PostgreSQL
+type ActivityInputRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewActivityInputRepository creates a new activity input repository
+func NewActivityInputRepository(db *gorm.DB) ActivityInputRepository {
+	return &ActivityInputRepositoryImpl{
+		db: db,
+	}
+}
+
+// ReplaceForActivity soft-deletes the input line items of an activity and stores the given
+// ones in their place
+func (r *ActivityInputRepositoryImpl) ReplaceForActivity(ctx context.Context, activityID string, inputs []*farm_activity.ActivityInput, deletedBy string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		if err := tx.Model(&farm_activity.ActivityInput{}).
+			Where("farm_activity_id = ? AND deleted_at IS NULL", activityID).
+			Updates(map[string]interface{}{
+				"deleted_at": now,
+				"deleted_by": deletedBy,
+				"updated_at": now,
+			}).Error; err != nil {
+			return fmt.Errorf("failed to remove activity inputs: %w", err)
+		}
+
+		if len(inputs) == 0 {
+			return nil
+		}
+		if err := tx.Omit("InputProduct").Create(&inputs).Error; err != nil {
+			return fmt.Errorf("failed to create activity inputs: %w", err)
+		}
+		return nil
+	})
+}
+
+// ListByActivity lists the input line items of an activity with their products
+func (r *ActivityInputRepositoryImpl) ListByActivity(ctx context.Context, activityID string) ([]*farm_activity.ActivityInput, error) {
+	var inputs []*farm_activity.ActivityInput
+	if err := r.db.WithContext(ctx).
+		Preload("InputProduct").
+		Where("farm_activity_id = ? AND deleted_at IS NULL", activityID).
+		Order("created_at, id").
+		Find(&inputs).Error; err != nil {
+		return nil, fmt.Errorf("failed to list activity inputs: %w", err)
+	}
+	return inputs, nil
+}
+
+// SumCostsByCycles totals the input costs of crop cycles by category, separately for
+// completed and planned activities. Inputs of cancelled and deleted activities are left out.
+func (r *ActivityInputRepositoryImpl) SumCostsByCycles(ctx context.Context, cycleIDs []string) ([]*InputCostTotal, error) {
+	var totals []*InputCostTotal
+	if len(cycleIDs) == 0 {
+		return totals, nil
+	}
+
+	if err := r.db.WithContext(ctx).
+		Table("farm_activity_inputs AS fai").
+		Select(`fai.crop_cycle_id, fai.category, fa.status = 'COMPLETED' AS completed,
+			COALESCE(SUM(fai.total_cost), 0) AS total_cost, COUNT(*) AS line_items`).
+		Joins("JOIN farm_activities fa ON fa.id = fai.farm_activity_id AND fa.deleted_at IS NULL").
+		Where("fai.crop_cycle_id IN (?) AND fai.deleted_at IS NULL AND fa.status <> ?", cycleIDs, "CANCELLED").
+		Group("fai.crop_cycle_id, fai.category, fa.status = 'COMPLETED'").
+		Scan(&totals).Error; err != nil {
+		return nil, fmt.Errorf("failed to sum input costs: %w", err)
+	}
+	return totals, nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/Kisanlink/farmers-module/internal/entities/farm_activity"
+	"github.com/Kisanlink/farmers-module/internal/entities/stage"
 	"github.com/Kisanlink/kisanlink-db/pkg/base"
 	"gorm.io/gorm"
 )
@@ -126,11 +127,9 @@ type StageCompletionStat struct {
 	CompletionPercent    float64
 }
 
-// GetStageCompletionStats retrieves stage-wise activity completion statistics for a crop cycle
-// This method is meant to be called from the service layer which will pass crop stages
-// The service layer should use CropStageRepository to get the stages first
-func (r *FarmActivityRepository) GetStageCompletionStats(ctx context.Context, cropCycleID string, cropStages interface{}) ([]*StageCompletionStat, error) {
-	// Cast the interface to the expected type
+// GetStageCompletionStats retrieves stage-wise activity completion statistics for a crop cycle.
+// The service layer passes the crop's stages, in order, from CropStageRepository.
+func (r *FarmActivityRepository) GetStageCompletionStats(ctx context.Context, cropCycleID string, cropStages []*stage.CropStage) ([]*StageCompletionStat, error) {
 	type CropStageInfo struct {
 		ID           string
 		StageID      string
@@ -139,14 +138,18 @@ func (r *FarmActivityRepository) GetStageCompletionStats(ctx context.Context, cr
 		DurationDays *int
 	}
 
-	var stageInfos []*CropStageInfo
-
-	// Type assertion to extract stage info
-	switch v := cropStages.(type) {
-	case []*CropStageInfo:
-		stageInfos = v
-	default:
-		return nil, fmt.Errorf("invalid crop stages type")
+	stageInfos := make([]*CropStageInfo, len(cropStages))
+	for i, cropStage := range cropStages {
+		stageInfos[i] = &CropStageInfo{
+			ID:           cropStage.ID,
+			StageID:      cropStage.StageID,
+			StageOrder:   cropStage.StageOrder,
+			DurationDays: cropStage.DurationDays,
+		}
+		// Get stage name from preloaded Stage relationship
+		if cropStage.Stage != nil {
+			stageInfos[i].StageName = cropStage.Stage.StageName
+		}
 	}
 
 	var stats []*StageCompletionStat
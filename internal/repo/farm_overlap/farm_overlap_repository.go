@@ -0,0 +1,185 @@
+package farm_overlap
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/farm_overlap"
+	"github.com/Kisanlink/farmers-module/pkg/common"
+	"gorm.io/gorm"
+)
+
+// ConflictFilter selects overlap conflicts. Empty fields match any value.
+type ConflictFilter struct {
+	AAAOrgID   string
+	Status     farm_overlap.ConflictStatus
+	FarmID     string // Either farm of the pair
+	AssigneeID string
+	Limit      int
+	Offset     int
+}
+
+// FarmOverlapRepository defines the storage of farm overlap conflicts and of the detection
+// runs that find them
+type FarmOverlapRepository interface {
+	Create(ctx context.Context, conflict *farm_overlap.FarmOverlapConflict) error
+	Update(ctx context.Context, conflict *farm_overlap.FarmOverlapConflict) error
+	GetByID(ctx context.Context, id string) (*farm_overlap.FarmOverlapConflict, error)
+	ListByFarms(ctx context.Context, orgID string, farmIDs []string) ([]*farm_overlap.FarmOverlapConflict, error)
+	Search(ctx context.Context, filter ConflictFilter) ([]*farm_overlap.FarmOverlapConflict, int64, error)
+	GetCheckedThrough(ctx context.Context, orgID string) (*time.Time, error)
+	RecordRun(ctx context.Context, orgID string, checkedThrough time.Time) error
+}
+
+// FarmOverlapRepositoryImpl implements FarmOverlapRepository on PostgreSQL
+type FarmOverlapRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewFarmOverlapRepository creates a new farm overlap repository
+func NewFarmOverlapRepository(db *gorm.DB) FarmOverlapRepository {
+	return &FarmOverlapRepositoryImpl{
+		db: db,
+	}
+}
+
+// Create stores a new conflict
+func (r *FarmOverlapRepositoryImpl) Create(ctx context.Context, conflict *farm_overlap.FarmOverlapConflict) error {
+	if r.db == nil {
+		return fmt.Errorf("database connection not available")
+	}
+	if err := r.db.WithContext(ctx).Create(conflict).Error; err != nil {
+		return fmt.Errorf("failed to create overlap conflict: %w", err)
+	}
+	return nil
+}
+
+// Update stores every field of a conflict
+func (r *FarmOverlapRepositoryImpl) Update(ctx context.Context, conflict *farm_overlap.FarmOverlapConflict) error {
+	if r.db == nil {
+		return fmt.Errorf("database connection not available")
+	}
+	if err := r.db.WithContext(ctx).Save(conflict).Error; err != nil {
+		return fmt.Errorf("failed to update overlap conflict: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a conflict, or common.ErrNotFound
+func (r *FarmOverlapRepositoryImpl) GetByID(ctx context.Context, id string) (*farm_overlap.FarmOverlapConflict, error) {
+	if r.db == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+
+	var conflict farm_overlap.FarmOverlapConflict
+	if err := r.db.WithContext(ctx).
+		Where("id = ? AND deleted_at IS NULL", id).
+		First(&conflict).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, common.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get overlap conflict: %w", err)
+	}
+	return &conflict, nil
+}
+
+// ListByFarms lists an organization's conflicts, whatever their status, that involve any of
+// the farms. A nil farmIDs lists all of the organization's conflicts.
+func (r *FarmOverlapRepositoryImpl) ListByFarms(ctx context.Context, orgID string, farmIDs []string) ([]*farm_overlap.FarmOverlapConflict, error) {
+	if r.db == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+	if farmIDs != nil && len(farmIDs) == 0 {
+		return nil, nil
+	}
+
+	query := r.db.WithContext(ctx).Where("aaa_org_id = ? AND deleted_at IS NULL", orgID)
+	if farmIDs != nil {
+		query = query.Where("farm1_id IN ? OR farm2_id IN ?", farmIDs, farmIDs)
+	}
+
+	var conflicts []*farm_overlap.FarmOverlapConflict
+	if err := query.Find(&conflicts).Error; err != nil {
+		return nil, fmt.Errorf("failed to list overlap conflicts: %w", err)
+	}
+	return conflicts, nil
+}
+
+// Search lists the conflicts matching the filter, largest overlap first, with the total
+// number of matches
+func (r *FarmOverlapRepositoryImpl) Search(ctx context.Context, filter ConflictFilter) ([]*farm_overlap.FarmOverlapConflict, int64, error) {
+	if r.db == nil {
+		return nil, 0, fmt.Errorf("database connection not available")
+	}
+
+	query := r.db.WithContext(ctx).Model(&farm_overlap.FarmOverlapConflict{}).
+		Where("aaa_org_id = ? AND deleted_at IS NULL", filter.AAAOrgID)
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.FarmID != "" {
+		query = query.Where("farm1_id = ? OR farm2_id = ?", filter.FarmID, filter.FarmID)
+	}
+	if filter.AssigneeID != "" {
+		query = query.Where("assignee_id = ?", filter.AssigneeID)
+	}
+
+	// Share the conditions between the count and the page
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count overlap conflicts: %w", err)
+	}
+
+	var conflicts []*farm_overlap.FarmOverlapConflict
+	if err := query.
+		Order("overlap_area_ha DESC, id").
+		Limit(filter.Limit).Offset(filter.Offset).
+		Find(&conflicts).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to search overlap conflicts: %w", err)
+	}
+	return conflicts, total, nil
+}
+
+// GetCheckedThrough returns the time up to which an organization's farm changes have been
+// checked for overlaps, or nil when detection has never run for it
+func (r *FarmOverlapRepositoryImpl) GetCheckedThrough(ctx context.Context, orgID string) (*time.Time, error) {
+	if r.db == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+
+	var runs []farm_overlap.FarmOverlapDetectionRun
+	if err := r.db.WithContext(ctx).
+		Where("aaa_org_id = ? AND deleted_at IS NULL", orgID).
+		Limit(1).
+		Find(&runs).Error; err != nil {
+		return nil, fmt.Errorf("failed to get overlap detection run: %w", err)
+	}
+	if len(runs) == 0 {
+		return nil, nil
+	}
+	return &runs[0].CheckedThrough, nil
+}
+
+// RecordRun records that an organization's farm changes up to checkedThrough have been
+// checked for overlaps
+func (r *FarmOverlapRepositoryImpl) RecordRun(ctx context.Context, orgID string, checkedThrough time.Time) error {
+	if r.db == nil {
+		return fmt.Errorf("database connection not available")
+	}
+
+	run := farm_overlap.NewFarmOverlapDetectionRun()
+	if err := r.db.WithContext(ctx).Exec(`
+		INSERT INTO farm_overlap_detection_runs (id, created_at, updated_at, aaa_org_id, checked_through)
+		VALUES (?, NOW(), NOW(), ?, ?)
+		ON CONFLICT (aaa_org_id) DO UPDATE SET
+			checked_through = EXCLUDED.checked_through,
+			updated_at = NOW(),
+			deleted_at = NULL`,
+		run.ID, orgID, checkedThrough).Error; err != nil {
+		return fmt.Errorf("failed to record overlap detection run: %w", err)
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"log"
 
 	farmerentity "github.com/Kisanlink/farmers-module/internal/entities/farmer"
+	"github.com/Kisanlink/farmers-module/internal/entities/requests"
 	"github.com/Kisanlink/kisanlink-db/pkg/base"
 	"gorm.io/gorm"
 )
@@ -86,65 +87,7 @@ func (r *FarmerRepository) FindByOrgID(ctx context.Context, aaaOrgID string, fil
 		Where("farmers.deleted_at IS NULL")
 
 	// Apply additional filters if provided
-	if filter != nil {
-		// Apply filter conditions
-		if filter.Group.Conditions != nil {
-			for _, condition := range filter.Group.Conditions {
-				// Skip aaa_org_id filter as it's already applied via the join
-				if condition.Field == "aaa_org_id" {
-					continue
-				}
-
-				// Apply farmer table filters with proper table prefix
-				switch condition.Operator {
-				case base.OpEqual:
-					query = query.Where("farmers."+condition.Field+" = ?", condition.Value)
-				case base.OpNotEqual:
-					query = query.Where("farmers."+condition.Field+" != ?", condition.Value)
-				case base.OpIn:
-					query = query.Where("farmers."+condition.Field+" IN ?", condition.Value)
-				case base.OpContains:
-					query = query.Where("farmers."+condition.Field+" LIKE ?", "%"+fmt.Sprint(condition.Value)+"%")
-				case base.OpStartsWith:
-					query = query.Where("farmers."+condition.Field+" LIKE ?", fmt.Sprint(condition.Value)+"%")
-				case base.OpEndsWith:
-					query = query.Where("farmers."+condition.Field+" LIKE ?", "%"+fmt.Sprint(condition.Value))
-				case base.OpIsNull:
-					query = query.Where("farmers." + condition.Field + " IS NULL")
-				case base.OpIsNotNull:
-					query = query.Where("farmers." + condition.Field + " IS NOT NULL")
-				default:
-					// For other operators, apply without table prefix (GORM will handle it)
-					query = query.Where(condition.Field+" = ?", condition.Value)
-				}
-			}
-		}
-
-		// Apply sorting
-		if filter.Sort != nil {
-			for _, sortField := range filter.Sort {
-				order := "farmers." + sortField.Field + " " + sortField.Direction
-				query = query.Order(order)
-			}
-		}
-
-		// Apply pagination
-		if filter.Page > 0 && filter.PageSize > 0 {
-			offset := (filter.Page - 1) * filter.PageSize
-			query = query.Limit(filter.PageSize).Offset(offset)
-		}
-
-		// Apply preloads (relationships)
-		if filter.Preloads != nil {
-			for _, preload := range filter.Preloads {
-				if len(preload.Conditions) > 0 {
-					query = query.Preload(preload.Relation, preload.Conditions...)
-				} else {
-					query = query.Preload(preload.Relation)
-				}
-			}
-		}
-	}
+	query = applyFarmerFilter(query, filter)
 
 	// Execute the query
 	var farmers []*farmerentity.Farmer
@@ -236,6 +179,133 @@ func (r *FarmerRepository) CountByOrgID(ctx context.Context, aaaOrgID string, fi
 		Where("farmers.deleted_at IS NULL")
 
 	// Apply additional filters if provided
+	query = applyFarmerConditions(query, filter)
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count farmers by org_id: %w", err)
+	}
+
+	return count, nil
+}
+
+// FindInAdminArea retrieves the farmers with at least one farm in the administrative area,
+// by the admin codes their farms are tagged with. When aaaOrgID is set only farmers linked
+// to that organization are returned, as in FindByOrgID.
+func (r *FarmerRepository) FindInAdminArea(ctx context.Context, aaaOrgID string, area requests.AdminAreaFilter, filter *base.Filter) ([]*farmerentity.Farmer, error) {
+	if r.db == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+
+	var farmers []*farmerentity.Farmer
+	if err := applyFarmerFilter(r.adminAreaQuery(ctx, aaaOrgID, area), filter).Find(&farmers).Error; err != nil {
+		return nil, fmt.Errorf("failed to find farmers in area: %w", err)
+	}
+	return farmers, nil
+}
+
+// CountInAdminArea counts the farmers FindInAdminArea returns, without pagination
+func (r *FarmerRepository) CountInAdminArea(ctx context.Context, aaaOrgID string, area requests.AdminAreaFilter, filter *base.Filter) (int64, error) {
+	if r.db == nil {
+		return 0, fmt.Errorf("database connection not available")
+	}
+
+	var count int64
+	if err := applyFarmerConditions(r.adminAreaQuery(ctx, aaaOrgID, area), filter).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count farmers in area: %w", err)
+	}
+	return count, nil
+}
+
+// adminAreaQuery selects the farmers with a farm in the administrative area. The farms are
+// matched with a correlated subquery, so the query does not grow with the number of farmers.
+func (r *FarmerRepository) adminAreaQuery(ctx context.Context, aaaOrgID string, area requests.AdminAreaFilter) *gorm.DB {
+	query := r.db.WithContext(ctx).
+		Model(&farmerentity.Farmer{}).
+		Where("farmers.deleted_at IS NULL")
+	if aaaOrgID != "" {
+		query = query.
+			Joins("INNER JOIN farmer_links ON farmers.aaa_user_id = farmer_links.aaa_user_id AND farmer_links.deleted_at IS NULL").
+			Where("farmer_links.aaa_org_id = ?", aaaOrgID)
+	}
+
+	farms := r.db.Table("farms AS f").
+		Select("1").
+		Where("f.farmer_id = farmers.id AND f.deleted_at IS NULL")
+	for column, code := range area.Columns() {
+		farms = farms.Where("f."+column+" = ?", code)
+	}
+	return query.Where("EXISTS (?)", farms)
+}
+
+// applyFarmerFilter applies the conditions, sorting, pagination and preloads of a filter to
+// a query on the farmers table
+func applyFarmerFilter(query *gorm.DB, filter *base.Filter) *gorm.DB {
+	if filter != nil {
+		// Apply filter conditions
+		if filter.Group.Conditions != nil {
+			for _, condition := range filter.Group.Conditions {
+				// Skip aaa_org_id filter as it's already applied via the join
+				if condition.Field == "aaa_org_id" {
+					continue
+				}
+
+				// Apply farmer table filters with proper table prefix
+				switch condition.Operator {
+				case base.OpEqual:
+					query = query.Where("farmers."+condition.Field+" = ?", condition.Value)
+				case base.OpNotEqual:
+					query = query.Where("farmers."+condition.Field+" != ?", condition.Value)
+				case base.OpIn:
+					query = query.Where("farmers."+condition.Field+" IN ?", condition.Value)
+				case base.OpContains:
+					query = query.Where("farmers."+condition.Field+" LIKE ?", "%"+fmt.Sprint(condition.Value)+"%")
+				case base.OpStartsWith:
+					query = query.Where("farmers."+condition.Field+" LIKE ?", fmt.Sprint(condition.Value)+"%")
+				case base.OpEndsWith:
+					query = query.Where("farmers."+condition.Field+" LIKE ?", "%"+fmt.Sprint(condition.Value))
+				case base.OpIsNull:
+					query = query.Where("farmers." + condition.Field + " IS NULL")
+				case base.OpIsNotNull:
+					query = query.Where("farmers." + condition.Field + " IS NOT NULL")
+				default:
+					// For other operators, apply without table prefix (GORM will handle it)
+					query = query.Where(condition.Field+" = ?", condition.Value)
+				}
+			}
+		}
+
+		// Apply sorting
+		if filter.Sort != nil {
+			for _, sortField := range filter.Sort {
+				order := "farmers." + sortField.Field + " " + sortField.Direction
+				query = query.Order(order)
+			}
+		}
+
+		// Apply pagination
+		if filter.Page > 0 && filter.PageSize > 0 {
+			offset := (filter.Page - 1) * filter.PageSize
+			query = query.Limit(filter.PageSize).Offset(offset)
+		}
+
+		// Apply preloads (relationships)
+		if filter.Preloads != nil {
+			for _, preload := range filter.Preloads {
+				if len(preload.Conditions) > 0 {
+					query = query.Preload(preload.Relation, preload.Conditions...)
+				} else {
+					query = query.Preload(preload.Relation)
+				}
+			}
+		}
+	}
+
+	return query
+}
+
+// applyFarmerConditions applies the conditions of a filter to a count of the farmers table
+func applyFarmerConditions(query *gorm.DB, filter *base.Filter) *gorm.DB {
 	if filter != nil && filter.Group.Conditions != nil {
 		for _, condition := range filter.Group.Conditions {
 			// Skip aaa_org_id filter as it's already applied via the join
@@ -259,12 +329,7 @@ func (r *FarmerRepository) CountByOrgID(ctx context.Context, aaaOrgID string, fi
 		}
 	}
 
-	var count int64
-	if err := query.Count(&count).Error; err != nil {
-		return 0, fmt.Errorf("failed to count farmers by org_id: %w", err)
-	}
-
-	return count, nil
+	return query
 }
 
 // NewFarmerLinkRepository creates a new farmer link repository using BaseFilterableRepository
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	farmerentity "github.com/Kisanlink/farmers-module/internal/entities/farmer"
+	"github.com/Kisanlink/farmers-module/internal/entities/requests"
 	"github.com/Kisanlink/kisanlink-db/pkg/base"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -421,3 +422,101 @@ func TestFarmerRepository_FindByOrgID_MultiOrgScenario(t *testing.T) {
 	// Verify it's the same farmer in both queries
 	assert.Equal(t, farmersOrg1[0].ID, farmersOrg2[0].ID)
 }
+
+func TestFarmerRepository_FindInAdminArea(t *testing.T) {
+	db := setupTestDB(t)
+	_, _ = seedTestData(t, db)
+
+	// Farms tagged with admin codes; only the columns the area filter reads
+	require.NoError(t, db.Exec(`
+		CREATE TABLE farms (
+			id VARCHAR(255) PRIMARY KEY,
+			farmer_id VARCHAR(255),
+			deleted_at DATETIME,
+			state_code VARCHAR(20),
+			district_code VARCHAR(20),
+			block_code VARCHAR(20),
+			village_code VARCHAR(20)
+		);
+	`).Error)
+	require.NoError(t, db.Exec(`
+		INSERT INTO farms (id, farmer_id, deleted_at, state_code, district_code, village_code) VALUES
+			('FARM-001', 'FMRR-001', NULL, '27', '523', '563214'),
+			('FARM-002', 'FMRR-001', NULL, '27', '523', '563215'),
+			('FARM-003', 'FMRR-002', NULL, '27', '524', '563300'),
+			('FARM-004', 'FMRR-003', NULL, '27', '523', '563214'),
+			('FARM-005', 'FMRR-002', CURRENT_TIMESTAMP, '27', '523', '563214');
+	`).Error)
+
+	repo := &FarmerRepository{
+		db: db,
+	}
+
+	tests := []struct {
+		name            string
+		aaaOrgID        string
+		area            requests.AdminAreaFilter
+		filter          *base.Filter
+		expectedUserIDs []string
+	}{
+		{
+			name:            "District across organizations",
+			area:            requests.AdminAreaFilter{DistrictCode: "523"},
+			filter:          base.NewFilterBuilder().Build(),
+			expectedUserIDs: []string{"user-001", "user-003"},
+		},
+		{
+			name:            "District within organization",
+			aaaOrgID:        "org-001",
+			area:            requests.AdminAreaFilter{DistrictCode: "523"},
+			filter:          base.NewFilterBuilder().Build(),
+			expectedUserIDs: []string{"user-001"},
+		},
+		{
+			name:            "Codes must match the same farm",
+			area:            requests.AdminAreaFilter{DistrictCode: "524", VillageCode: "563214"},
+			filter:          base.NewFilterBuilder().Build(),
+			expectedUserIDs: []string{},
+		},
+		{
+			name:     "Area with phone number filter",
+			aaaOrgID: "org-002",
+			area:     requests.AdminAreaFilter{StateCode: "27"},
+			filter: base.NewFilterBuilder().
+				Where("phone_number", base.OpEqual, "5555555555").
+				Build(),
+			expectedUserIDs: []string{"user-003"},
+		},
+		{
+			name:            "Deleted farms are ignored",
+			area:            requests.AdminAreaFilter{VillageCode: "563214"},
+			filter:          base.NewFilterBuilder().Build(),
+			expectedUserIDs: []string{"user-001", "user-003"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			farmers, err := repo.FindInAdminArea(ctx, tt.aaaOrgID, tt.area, tt.filter)
+			require.NoError(t, err)
+
+			userIDs := make([]string, len(farmers))
+			for i, farmer := range farmers {
+				userIDs[i] = farmer.AAAUserID
+			}
+			assert.ElementsMatch(t, tt.expectedUserIDs, userIDs)
+
+			count, err := repo.CountInAdminArea(ctx, tt.aaaOrgID, tt.area, tt.filter)
+			require.NoError(t, err)
+			assert.Equal(t, int64(len(tt.expectedUserIDs)), count)
+		})
+	}
+
+	// Farmers with several farms in the area are listed once, and pages hold distinct farmers
+	farmers, err := repo.FindInAdminArea(context.Background(), "", requests.AdminAreaFilter{StateCode: "27"},
+		base.NewFilterBuilder().Page(1, 2).Build())
+	require.NoError(t, err)
+	assert.Len(t, farmers, 2)
+	assert.NotEqual(t, farmers[0].ID, farmers[1].ID)
+}
@@ -0,0 +1,91 @@
+package idempotency
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/idempotency"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IdempotencyKeyRepository defines the storage of idempotency keys and stored responses
+type IdempotencyKeyRepository interface {
+	Claim(ctx context.Context, key *idempotency.IdempotencyKey) (bool, error)
+	Get(ctx context.Context, tenantID, key string) (*idempotency.IdempotencyKey, error)
+	Complete(ctx context.Context, id string, responseStatus int, contentType string, body []byte) error
+	Delete(ctx context.Context, id string) error
+	DeleteIfExpired(ctx context.Context, id string, now time.Time) (bool, error)
+}
+
+// IdempotencyKeyRepositoryImpl implements IdempotencyKeyRepository on PostgreSQL
+type IdempotencyKeyRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewIdempotencyKeyRepository creates a new idempotency key repository
+func NewIdempotencyKeyRepository(db *gorm.DB) IdempotencyKeyRepository {
+	return &IdempotencyKeyRepositoryImpl{
+		db: db,
+	}
+}
+
+// Claim stores a new key. It reports false, without an error, when the tenant already
+// holds the key, so concurrent requests with the same key cannot both claim it.
+func (r *IdempotencyKeyRepositoryImpl) Claim(ctx context.Context, key *idempotency.IdempotencyKey) (bool, error) {
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(key)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to claim idempotency key: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Get retrieves the key of a tenant, or nil when the tenant does not hold it
+func (r *IdempotencyKeyRepositoryImpl) Get(ctx context.Context, tenantID, key string) (*idempotency.IdempotencyKey, error) {
+	var record idempotency.IdempotencyKey
+	if err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND idempotency_key = ?", tenantID, key).
+		First(&record).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+	return &record, nil
+}
+
+// Complete stores the response of the request made with a key
+func (r *IdempotencyKeyRepositoryImpl) Complete(ctx context.Context, id string, responseStatus int, contentType string, body []byte) error {
+	if err := r.db.WithContext(ctx).Model(&idempotency.IdempotencyKey{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":                idempotency.KeyStatusCompleted,
+			"response_status":       responseStatus,
+			"response_content_type": contentType,
+			"response_body":         body,
+			"updated_at":            time.Now(),
+		}).Error; err != nil {
+		return fmt.Errorf("failed to store idempotent response: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a key so that it can be used again
+func (r *IdempotencyKeyRepositoryImpl) Delete(ctx context.Context, id string) error {
+	if err := r.db.WithContext(ctx).Where("id = ?", id).Delete(&idempotency.IdempotencyKey{}).Error; err != nil {
+		return fmt.Errorf("failed to delete idempotency key: %w", err)
+	}
+	return nil
+}
+
+// DeleteIfExpired removes a key whose TTL has passed and reports whether it did
+func (r *IdempotencyKeyRepositoryImpl) DeleteIfExpired(ctx context.Context, id string, now time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND expires_at <= ?", id, now).
+		Delete(&idempotency.IdempotencyKey{})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to delete expired idempotency key: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,145 @@
+package input_product
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/input_product"
+	"github.com/Kisanlink/farmers-module/pkg/common"
+	"gorm.io/gorm"
+)
+
+// InputProductFilter holds the filters for listing input products
+type InputProductFilter struct {
+	Category   string
+	Search     string // Matched against name and manufacturer
+	ActiveOnly bool
+	Limit      int
+	Offset     int
+}
+
+// InputProductRepository defines the storage of the input master
+type InputProductRepository interface {
+	Create(ctx context.Context, p *input_product.InputProduct) error
+	Update(ctx context.Context, p *input_product.InputProduct) error
+	Delete(ctx context.Context, id, deletedBy string) error
+	GetByID(ctx context.Context, id string) (*input_product.InputProduct, error)
+	GetByIDs(ctx context.Context, ids []string) (map[string]*input_product.InputProduct, error)
+	List(ctx context.Context, filter InputProductFilter) ([]*input_product.InputProduct, int64, error)
+}
+
+// InputProductRepositoryImpl implements InputProductRepository on PostgreSQL
+type InputProductRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewInputProductRepository creates a new input product repository
+func NewInputProductRepository(db *gorm.DB) InputProductRepository {
+	return &InputProductRepositoryImpl{
+		db: db,
+	}
+}
+
+// Create stores a new input product
+func (r *InputProductRepositoryImpl) Create(ctx context.Context, p *input_product.InputProduct) error {
+	if err := r.db.WithContext(ctx).Create(p).Error; err != nil {
+		return fmt.Errorf("failed to create input product: %w", err)
+	}
+	return nil
+}
+
+// Update stores every field of an input product
+func (r *InputProductRepositoryImpl) Update(ctx context.Context, p *input_product.InputProduct) error {
+	p.UpdatedAt = time.Now()
+	if err := r.db.WithContext(ctx).Save(p).Error; err != nil {
+		return fmt.Errorf("failed to update input product: %w", err)
+	}
+	return nil
+}
+
+// Delete soft-deletes an input product. Line items recorded against it keep their product ID.
+func (r *InputProductRepositoryImpl) Delete(ctx context.Context, id, deletedBy string) error {
+	now := time.Now()
+	result := r.db.WithContext(ctx).Model(&input_product.InputProduct{}).
+		Where("id = ? AND deleted_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"deleted_at": now,
+			"deleted_by": deletedBy,
+			"updated_at": now,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete input product: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return common.ErrNotFound
+	}
+	return nil
+}
+
+// GetByID retrieves an input product, or common.ErrNotFound
+func (r *InputProductRepositoryImpl) GetByID(ctx context.Context, id string) (*input_product.InputProduct, error) {
+	var p input_product.InputProduct
+	if err := r.db.WithContext(ctx).
+		Where("id = ? AND deleted_at IS NULL", id).
+		First(&p).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, common.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get input product: %w", err)
+	}
+	return &p, nil
+}
+
+// GetByIDs retrieves the input products with the given IDs, keyed by ID. Unknown IDs are
+// left out.
+func (r *InputProductRepositoryImpl) GetByIDs(ctx context.Context, ids []string) (map[string]*input_product.InputProduct, error) {
+	byID := make(map[string]*input_product.InputProduct, len(ids))
+	if len(ids) == 0 {
+		return byID, nil
+	}
+
+	var products []*input_product.InputProduct
+	if err := r.db.WithContext(ctx).
+		Where("id IN (?) AND deleted_at IS NULL", ids).
+		Find(&products).Error; err != nil {
+		return nil, fmt.Errorf("failed to get input products: %w", err)
+	}
+	for _, p := range products {
+		byID[p.ID] = p
+	}
+	return byID, nil
+}
+
+// List lists input products by category and name with the total matching the filter
+func (r *InputProductRepositoryImpl) List(ctx context.Context, filter InputProductFilter) ([]*input_product.InputProduct, int64, error) {
+	query := r.db.WithContext(ctx).Model(&input_product.InputProduct{}).Where("deleted_at IS NULL")
+	if filter.Category != "" {
+		query = query.Where("category = ?", filter.Category)
+	}
+	if filter.Search != "" {
+		pattern := "%" + filter.Search + "%"
+		query = query.Where("name ILIKE ? OR manufacturer ILIKE ?", pattern, pattern)
+	}
+	if filter.ActiveOnly {
+		query = query.Where("is_active = ?", true)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count input products: %w", err)
+	}
+
+	var products []*input_product.InputProduct
+	query = query.Order("category, name, id")
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+	if err := query.Find(&products).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list input products: %w", err)
+	}
+	return products, total, nil
+}
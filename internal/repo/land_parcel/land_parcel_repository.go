@@ -0,0 +1,213 @@
+package land_parcel
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/land_parcel"
+	"github.com/Kisanlink/farmers-module/pkg/common"
+	"gorm.io/gorm"
+)
+
+// SearchFilter selects land parcels by their land record identifiers. Empty fields match
+// any value.
+type SearchFilter struct {
+	AAAOrgID     string
+	StateCode    string
+	DistrictCode string
+	TehsilCode   string
+	VillageCode  string
+	SurveyNumber string
+	Limit        int
+	Offset       int
+}
+
+// LandParcelRepository defines the storage of the land record parcels of farms
+type LandParcelRepository interface {
+	Create(ctx context.Context, parcel *land_parcel.LandParcel) error
+	Update(ctx context.Context, parcel *land_parcel.LandParcel) error
+	Delete(ctx context.Context, id, deletedBy string) error
+	GetByID(ctx context.Context, id string) (*land_parcel.LandParcel, error)
+	ListByFarm(ctx context.Context, farmID string) ([]*land_parcel.LandParcel, error)
+	Search(ctx context.Context, filter SearchFilter) ([]*land_parcel.LandParcel, int64, error)
+}
+
+// LandParcelRepositoryImpl implements LandParcelRepository on PostgreSQL
+type LandParcelRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewLandParcelRepository creates a new land parcel repository
+func NewLandParcelRepository(db *gorm.DB) LandParcelRepository {
+	return &LandParcelRepositoryImpl{
+		db: db,
+	}
+}
+
+// serializableAttempts is how often a claim is tried before a serialization failure is
+// returned. Concurrent claims on the same survey number make PostgreSQL abort all but one
+// of their SERIALIZABLE transactions.
+const serializableAttempts = 3
+
+// Create stores a parcel after checking that the claims on its survey number, this one
+// included, do not exceed 100%
+func (r *LandParcelRepositoryImpl) Create(ctx context.Context, parcel *land_parcel.LandParcel) error {
+	return r.claim(ctx, func(tx *gorm.DB) error {
+		if err := checkShareAvailable(tx, parcel); err != nil {
+			return err
+		}
+		if err := tx.Create(parcel).Error; err != nil {
+			return fmt.Errorf("failed to create land parcel: %w", err)
+		}
+		return nil
+	})
+}
+
+// Update saves a parcel after checking its claim again, since its survey number, share or
+// lease period may have changed
+func (r *LandParcelRepositoryImpl) Update(ctx context.Context, parcel *land_parcel.LandParcel) error {
+	return r.claim(ctx, func(tx *gorm.DB) error {
+		if err := checkShareAvailable(tx, parcel); err != nil {
+			return err
+		}
+		parcel.UpdatedAt = time.Now()
+		if err := tx.Save(parcel).Error; err != nil {
+			return fmt.Errorf("failed to update land parcel: %w", err)
+		}
+		return nil
+	})
+}
+
+// claim runs fn in a SERIALIZABLE transaction, so that two claims on the same survey number
+// cannot both pass the share check, and runs it again when the transaction is aborted by a
+// serialization failure
+func (r *LandParcelRepositoryImpl) claim(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	var err error
+	for attempt := 0; attempt < serializableAttempts; attempt++ {
+		err = r.db.WithContext(ctx).Transaction(fn, &sql.TxOptions{Isolation: sql.LevelSerializable})
+		if !isSerializationFailure(err) {
+			return err
+		}
+	}
+	return err
+}
+
+// isSerializationFailure reports whether err is a PostgreSQL serialization failure
+// (SQLSTATE 40001), after which the transaction can be retried
+func isSerializationFailure(err error) bool {
+	var pgErr interface{ SQLState() string }
+	return errors.As(err, &pgErr) && pgErr.SQLState() == "40001"
+}
+
+// checkShareAvailable sums the shares other parcels claim in the same survey number. Owned
+// shares may not add up to more than 100%, and neither may the shares of leases that run
+// at the same time.
+func checkShareAvailable(tx *gorm.DB, parcel *land_parcel.LandParcel) error {
+	var others []*land_parcel.LandParcel
+	if err := tx.
+		Where("state_code = ? AND district_code = ? AND tehsil_code = ? AND village_code = ? AND survey_number = ?",
+			parcel.StateCode, parcel.DistrictCode, parcel.TehsilCode, parcel.VillageCode, parcel.SurveyNumber).
+		Where("tenure = ? AND id != ? AND deleted_at IS NULL", parcel.Tenure, parcel.ID).
+		Find(&others).Error; err != nil {
+		return fmt.Errorf("failed to check land parcel claims: %w", err)
+	}
+
+	var claimed float64
+	for _, other := range others {
+		if parcel.SharesClaimWith(other) {
+			claimed += other.SharePct
+		}
+	}
+	// Shares are stored to two decimals; allow for rounding
+	if claimed+parcel.SharePct > 100.005 {
+		return &common.ParcelShareExceededError{
+			RecordKey:    parcel.RecordKey(),
+			Tenure:       string(parcel.Tenure),
+			ClaimedPct:   claimed,
+			RequestedPct: parcel.SharePct,
+		}
+	}
+	return nil
+}
+
+// Delete soft-deletes a parcel
+func (r *LandParcelRepositoryImpl) Delete(ctx context.Context, id, deletedBy string) error {
+	now := time.Now()
+	result := r.db.WithContext(ctx).Model(&land_parcel.LandParcel{}).
+		Where("id = ? AND deleted_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"deleted_at": now,
+			"deleted_by": deletedBy,
+			"updated_at": now,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete land parcel: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return common.ErrNotFound
+	}
+	return nil
+}
+
+// GetByID retrieves a parcel, or common.ErrNotFound
+func (r *LandParcelRepositoryImpl) GetByID(ctx context.Context, id string) (*land_parcel.LandParcel, error) {
+	var parcel land_parcel.LandParcel
+	if err := r.db.WithContext(ctx).
+		Where("id = ? AND deleted_at IS NULL", id).
+		First(&parcel).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, common.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get land parcel: %w", err)
+	}
+	return &parcel, nil
+}
+
+// ListByFarm lists the parcels of a farm
+func (r *LandParcelRepositoryImpl) ListByFarm(ctx context.Context, farmID string) ([]*land_parcel.LandParcel, error) {
+	var parcels []*land_parcel.LandParcel
+	if err := r.db.WithContext(ctx).
+		Where("farm_id = ? AND deleted_at IS NULL", farmID).
+		Order("state_code, district_code, tehsil_code, village_code, survey_number").
+		Find(&parcels).Error; err != nil {
+		return nil, fmt.Errorf("failed to list land parcels: %w", err)
+	}
+	return parcels, nil
+}
+
+// Search lists the parcels matching the filter, with the total number of matches
+func (r *LandParcelRepositoryImpl) Search(ctx context.Context, filter SearchFilter) ([]*land_parcel.LandParcel, int64, error) {
+	query := r.db.WithContext(ctx).Model(&land_parcel.LandParcel{}).Where("deleted_at IS NULL")
+	for column, value := range map[string]string{
+		"aaa_org_id":    filter.AAAOrgID,
+		"state_code":    filter.StateCode,
+		"district_code": filter.DistrictCode,
+		"tehsil_code":   filter.TehsilCode,
+		"village_code":  filter.VillageCode,
+		"survey_number": filter.SurveyNumber,
+	} {
+		if value != "" {
+			query = query.Where(column+" = ?", value)
+		}
+	}
+
+	// Share the conditions between the count and the page
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count land parcels: %w", err)
+	}
+
+	var parcels []*land_parcel.LandParcel
+	if err := query.
+		Order("state_code, district_code, tehsil_code, village_code, survey_number, id").
+		Limit(filter.Limit).Offset(filter.Offset).
+		Find(&parcels).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to search land parcels: %w", err)
+	}
+	return parcels, total, nil
+}
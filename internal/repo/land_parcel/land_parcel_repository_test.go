@@ -0,0 +1,186 @@
+package land_parcel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/land_parcel"
+	"github.com/Kisanlink/farmers-module/pkg/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// setupTestDB creates an in-memory SQLite database with a land_parcels table
+func setupTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	require.NoError(t, db.Exec(`
+		CREATE TABLE land_parcels (
+			id VARCHAR(255) PRIMARY KEY,
+			created_at DATETIME NOT NULL,
+			updated_at DATETIME NOT NULL,
+			created_by VARCHAR(255),
+			updated_by VARCHAR(255),
+			deleted_at DATETIME,
+			deleted_by VARCHAR(255),
+			farm_id VARCHAR(255) NOT NULL,
+			farmer_id VARCHAR(255) NOT NULL,
+			aaa_org_id VARCHAR(255) NOT NULL,
+			state_code VARCHAR(20) NOT NULL,
+			district_code VARCHAR(20) NOT NULL,
+			tehsil_code VARCHAR(20) NOT NULL,
+			village_code VARCHAR(20) NOT NULL,
+			survey_number VARCHAR(50) NOT NULL,
+			khata_number VARCHAR(50),
+			area_ha REAL,
+			share_pct REAL NOT NULL DEFAULT 100,
+			tenure VARCHAR(20) NOT NULL DEFAULT 'OWNED',
+			lease_start_date DATE,
+			lease_end_date DATE,
+			lessor_name VARCHAR(255),
+			document_type VARCHAR(50),
+			document_reference TEXT
+		);
+	`).Error)
+
+	return db
+}
+
+func date(year int, month time.Month, day int) *time.Time {
+	d := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	return &d
+}
+
+func newParcel(farmID, surveyNumber string, sharePct float64) *land_parcel.LandParcel {
+	parcel := land_parcel.NewLandParcel()
+	parcel.FarmID = farmID
+	parcel.FarmerID = "FMRR" + farmID
+	parcel.AAAOrgID = "org-1"
+	parcel.StateCode, parcel.DistrictCode, parcel.TehsilCode, parcel.VillageCode = "27", "521", "4213", "563214"
+	parcel.SurveyNumber = surveyNumber
+	parcel.SharePct = sharePct
+	return parcel
+}
+
+func newLease(farmID string, sharePct float64, start, end *time.Time) *land_parcel.LandParcel {
+	parcel := newParcel(farmID, "123/2A", sharePct)
+	parcel.Tenure = land_parcel.TenureLeased
+	parcel.LeaseStartDate, parcel.LeaseEndDate = start, end
+	return parcel
+}
+
+func assertShareExceeded(t *testing.T, err error, claimed float64) {
+	t.Helper()
+	var shareErr *common.ParcelShareExceededError
+	require.True(t, errors.As(err, &shareErr), "got %v", err)
+	assert.InDelta(t, claimed, shareErr.ClaimedPct, 0.001)
+}
+
+func TestLandParcelRepository_OwnedSharesUpToWhole(t *testing.T) {
+	ctx := context.Background()
+	repo := NewLandParcelRepository(setupTestDB(t))
+
+	require.NoError(t, repo.Create(ctx, newParcel("FARM1", "123/2A", 60)))
+	second := newParcel("FARM2", "123/2A", 40)
+	require.NoError(t, repo.Create(ctx, second))
+
+	// The survey number is fully claimed
+	assertShareExceeded(t, repo.Create(ctx, newParcel("FARM3", "123/2A", 1)), 100)
+
+	// Other survey numbers and leases of the same one are separate claims
+	require.NoError(t, repo.Create(ctx, newParcel("FARM3", "124", 100)))
+	require.NoError(t, repo.Create(ctx, newLease("FARM3", 100, date(2024, 1, 1), nil)))
+
+	// A claim is not counted against itself when it is updated
+	second.SharePct = 40
+	require.NoError(t, repo.Update(ctx, second))
+	second.SharePct = 45
+	assertShareExceeded(t, repo.Update(ctx, second), 60)
+
+	// Deleted claims release their share
+	require.NoError(t, repo.Delete(ctx, second.ID, "user-1"))
+	require.NoError(t, repo.Create(ctx, newParcel("FARM3", "123/2A", 40)))
+}
+
+func TestLandParcelRepository_LeasesOnlyCountWhileTheyOverlap(t *testing.T) {
+	ctx := context.Background()
+	repo := NewLandParcelRepository(setupTestDB(t))
+
+	require.NoError(t, repo.Create(ctx, newLease("FARM1", 100, date(2024, 1, 1), date(2025, 1, 1))))
+
+	tests := []struct {
+		name       string
+		start, end *time.Time
+		wantErr    bool
+	}{
+		{"inside the lease", date(2024, 6, 1), date(2024, 12, 1), true},
+		{"overlapping its end", date(2024, 12, 1), date(2025, 6, 1), true},
+		{"open-ended from before its end", date(2024, 12, 31), nil, true},
+		{"ending on its start", date(2023, 1, 1), date(2024, 1, 1), false},
+		{"starting on its end", date(2025, 1, 1), date(2026, 1, 1), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parcel := newLease("FARM2", 100, tt.start, tt.end)
+			err := repo.Create(ctx, parcel)
+			if tt.wantErr {
+				assertShareExceeded(t, err, 100)
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, repo.Delete(ctx, parcel.ID, "user-1"))
+		})
+	}
+
+	// Shares of overlapping leases add up
+	require.NoError(t, repo.Create(ctx, newLease("FARM2", 100, date(2025, 1, 1), nil)))
+	assertShareExceeded(t, repo.Create(ctx, newLease("FARM3", 10, date(2030, 1, 1), date(2031, 1, 1))), 100)
+}
+
+// sqlStateError is an error carrying a PostgreSQL SQLSTATE, like pgconn.PgError
+type sqlStateError struct {
+	code string
+}
+
+func (e *sqlStateError) Error() string    { return "ERROR (SQLSTATE " + e.code + ")" }
+func (e *sqlStateError) SQLState() string { return e.code }
+
+func TestLandParcelRepository_RetriesSerializationFailures(t *testing.T) {
+	repo := &LandParcelRepositoryImpl{db: setupTestDB(t)}
+	serializationFailure := fmt.Errorf("failed to create land parcel: %w", &sqlStateError{code: "40001"})
+
+	attempts := 0
+	err := repo.claim(context.Background(), func(tx *gorm.DB) error {
+		attempts++
+		if attempts < serializableAttempts {
+			return serializationFailure
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, serializableAttempts, attempts)
+
+	// The failure is returned once the attempts are used up
+	attempts = 0
+	err = repo.claim(context.Background(), func(tx *gorm.DB) error {
+		attempts++
+		return serializationFailure
+	})
+	assert.ErrorIs(t, err, serializationFailure)
+	assert.Equal(t, serializableAttempts, attempts)
+
+	// Other errors are not retried
+	attempts = 0
+	err = repo.claim(context.Background(), func(tx *gorm.DB) error {
+		attempts++
+		return &sqlStateError{code: "23505"}
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, attempts)
+}
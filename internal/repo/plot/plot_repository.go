@@ -0,0 +1,106 @@
+package plot
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/plot"
+	"github.com/Kisanlink/farmers-module/pkg/common"
+	"gorm.io/gorm"
+)
+
+// PlotRepository defines the storage of the plots farms are divided into
+type PlotRepository interface {
+	Create(ctx context.Context, p *plot.Plot) error
+	Update(ctx context.Context, p *plot.Plot) error
+	Delete(ctx context.Context, id, deletedBy string) error
+	GetByID(ctx context.Context, id string) (*plot.Plot, error)
+	ListByFarm(ctx context.Context, farmID string) ([]*plot.Plot, error)
+	CountOpenCycles(ctx context.Context, plotID string) (int64, error)
+}
+
+// PlotRepositoryImpl implements PlotRepository on PostgreSQL
+type PlotRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewPlotRepository creates a new plot repository
+func NewPlotRepository(db *gorm.DB) PlotRepository {
+	return &PlotRepositoryImpl{
+		db: db,
+	}
+}
+
+// Create stores a new plot
+func (r *PlotRepositoryImpl) Create(ctx context.Context, p *plot.Plot) error {
+	if err := r.db.WithContext(ctx).Create(p).Error; err != nil {
+		return fmt.Errorf("failed to create plot: %w", err)
+	}
+	return nil
+}
+
+// Update stores every field of a plot
+func (r *PlotRepositoryImpl) Update(ctx context.Context, p *plot.Plot) error {
+	p.UpdatedAt = time.Now()
+	if err := r.db.WithContext(ctx).Save(p).Error; err != nil {
+		return fmt.Errorf("failed to update plot: %w", err)
+	}
+	return nil
+}
+
+// Delete soft-deletes a plot
+func (r *PlotRepositoryImpl) Delete(ctx context.Context, id, deletedBy string) error {
+	now := time.Now()
+	result := r.db.WithContext(ctx).Model(&plot.Plot{}).
+		Where("id = ? AND deleted_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"deleted_at": now,
+			"deleted_by": deletedBy,
+			"updated_at": now,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete plot: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return common.ErrNotFound
+	}
+	return nil
+}
+
+// GetByID retrieves a plot, or common.ErrNotFound
+func (r *PlotRepositoryImpl) GetByID(ctx context.Context, id string) (*plot.Plot, error) {
+	var p plot.Plot
+	if err := r.db.WithContext(ctx).
+		Where("id = ? AND deleted_at IS NULL", id).
+		First(&p).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, common.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get plot: %w", err)
+	}
+	return &p, nil
+}
+
+// ListByFarm lists the plots of a farm by name
+func (r *PlotRepositoryImpl) ListByFarm(ctx context.Context, farmID string) ([]*plot.Plot, error) {
+	var plots []*plot.Plot
+	if err := r.db.WithContext(ctx).
+		Where("farm_id = ? AND deleted_at IS NULL", farmID).
+		Order("name, id").
+		Find(&plots).Error; err != nil {
+		return nil, fmt.Errorf("failed to list plots: %w", err)
+	}
+	return plots, nil
+}
+
+// CountOpenCycles counts the planned and active crop cycles grown on a plot
+func (r *PlotRepositoryImpl) CountOpenCycles(ctx context.Context, plotID string) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Table("crop_cycles").
+		Where("plot_id = ? AND status IN (?) AND deleted_at IS NULL", plotID, []string{"PLANNED", "ACTIVE"}).
+		Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count crop cycles of plot: %w", err)
+	}
+	return count, nil
+}
@@ -4,15 +4,22 @@ import (
 	"context"
 
 	fpoConfigEntity "github.com/Kisanlink/farmers-module/internal/entities/fpo_config"
+	"github.com/Kisanlink/farmers-module/internal/repo/admin_boundary"
 	"github.com/Kisanlink/farmers-module/internal/repo/bulk"
+	"github.com/Kisanlink/farmers-module/internal/repo/changefeed"
 	"github.com/Kisanlink/farmers-module/internal/repo/crop"
 	"github.com/Kisanlink/farmers-module/internal/repo/crop_cycle"
 	"github.com/Kisanlink/farmers-module/internal/repo/farm"
 	"github.com/Kisanlink/farmers-module/internal/repo/farm_activity"
+	"github.com/Kisanlink/farmers-module/internal/repo/farm_overlap"
 	"github.com/Kisanlink/farmers-module/internal/repo/farmer"
 	"github.com/Kisanlink/farmers-module/internal/repo/fpo"
 	"github.com/Kisanlink/farmers-module/internal/repo/fpo_config"
+	"github.com/Kisanlink/farmers-module/internal/repo/idempotency"
+	"github.com/Kisanlink/farmers-module/internal/repo/input_product"
 	"github.com/Kisanlink/farmers-module/internal/repo/irrigation_source"
+	"github.com/Kisanlink/farmers-module/internal/repo/land_parcel"
+	"github.com/Kisanlink/farmers-module/internal/repo/plot"
 	"github.com/Kisanlink/farmers-module/internal/repo/soil_type"
 	"github.com/Kisanlink/farmers-module/internal/repo/stage"
 	"github.com/Kisanlink/kisanlink-db/pkg/base"
@@ -21,21 +28,33 @@ import (
 
 // RepositoryFactory provides access to all domain repositories
 type RepositoryFactory struct {
-	FarmerRepo           *farmer.FarmerRepository
-	FarmerLinkageRepo    *farmer.FarmerLinkRepository
-	FPORefRepo           *fpo.FPORepository
-	FPOConfigRepo        *base.BaseFilterableRepository[*fpoConfigEntity.FPOConfig]
-	FarmRepo             *farm.FarmRepository
-	CropRepo             *crop.CropRepository
-	CropVarietyRepo      *crop.CropVarietyRepository
-	CropCycleRepo        *crop_cycle.CropCycleRepository
-	FarmActivityRepo     *farm_activity.FarmActivityRepository
-	BulkOperationRepo    bulk.BulkOperationRepository
-	ProcessingDetailRepo bulk.ProcessingDetailRepository
-	StageRepo            *stage.StageRepository
-	CropStageRepo        *stage.CropStageRepository
-	SoilTypeRepo         *soil_type.SoilTypeRepository
-	IrrigationSourceRepo *irrigation_source.IrrigationSourceRepository
+	FarmerRepo               *farmer.FarmerRepository
+	FarmerLinkageRepo        *farmer.FarmerLinkRepository
+	FPORefRepo               *fpo.FPORepository
+	FPOConfigRepo            *base.BaseFilterableRepository[*fpoConfigEntity.FPOConfig]
+	FarmRepo                 *farm.FarmRepository
+	CropRepo                 *crop.CropRepository
+	CropVarietyRepo          *crop.CropVarietyRepository
+	CropCycleRepo            *crop_cycle.CropCycleRepository
+	StageTransitionRepo      crop_cycle.StageTransitionRepository
+	FarmActivityRepo         *farm_activity.FarmActivityRepository
+	ActivityInputRepo        farm_activity.ActivityInputRepository
+	InputProductRepo         input_product.InputProductRepository
+	BulkOperationRepo        bulk.BulkOperationRepository
+	ProcessingDetailRepo     bulk.ProcessingDetailRepository
+	JobQueueRepo             bulk.JobQueueRepository
+	ColumnMappingProfileRepo bulk.ColumnMappingProfileRepository
+	IdempotencyKeyRepo       idempotency.IdempotencyKeyRepository
+	ChangeFeedRepo           changefeed.ChangeFeedRepository
+	StageRepo                *stage.StageRepository
+	CropStageRepo            *stage.CropStageRepository
+	ActivityTemplateRepo     stage.ActivityTemplateRepository
+	SoilTypeRepo             *soil_type.SoilTypeRepository
+	IrrigationSourceRepo     *irrigation_source.IrrigationSourceRepository
+	LandParcelRepo           land_parcel.LandParcelRepository
+	AdminBoundaryRepo        admin_boundary.AdminBoundaryRepository
+	FarmOverlapRepo          farm_overlap.FarmOverlapRepository
+	PlotRepo                 plot.PlotRepository
 }
 
 // NewRepositoryFactory creates a new repository factory
@@ -47,20 +66,32 @@ func NewRepositoryFactory(dbManager *db.PostgresManager) *RepositoryFactory {
 	}
 
 	return &RepositoryFactory{
-		FarmerRepo:           farmer.NewFarmerRepository(dbManager),
-		FarmerLinkageRepo:    farmer.NewFarmerLinkRepository(dbManager),
-		FPORefRepo:           fpo.NewFPORepository(dbManager),
-		FPOConfigRepo:        fpo_config.NewFPOConfigRepository(dbManager),
-		FarmRepo:             farm.NewFarmRepository(dbManager),
-		CropRepo:             crop.NewCropRepository(dbManager),
-		CropVarietyRepo:      crop.NewCropVarietyRepository(dbManager),
-		CropCycleRepo:        crop_cycle.NewRepository(dbManager),
-		FarmActivityRepo:     farm_activity.NewFarmActivityRepository(dbManager),
-		BulkOperationRepo:    bulk.NewBulkOperationRepository(gormDB),
-		ProcessingDetailRepo: bulk.NewProcessingDetailRepository(gormDB),
-		StageRepo:            stage.NewStageRepository(dbManager),
-		CropStageRepo:        stage.NewCropStageRepository(dbManager),
-		SoilTypeRepo:         soil_type.NewSoilTypeRepository(dbManager),
-		IrrigationSourceRepo: irrigation_source.NewIrrigationSourceRepository(dbManager),
+		FarmerRepo:               farmer.NewFarmerRepository(dbManager),
+		FarmerLinkageRepo:        farmer.NewFarmerLinkRepository(dbManager),
+		FPORefRepo:               fpo.NewFPORepository(dbManager),
+		FPOConfigRepo:            fpo_config.NewFPOConfigRepository(dbManager),
+		FarmRepo:                 farm.NewFarmRepository(dbManager),
+		CropRepo:                 crop.NewCropRepository(dbManager),
+		CropVarietyRepo:          crop.NewCropVarietyRepository(dbManager),
+		CropCycleRepo:            crop_cycle.NewRepository(dbManager),
+		StageTransitionRepo:      crop_cycle.NewStageTransitionRepository(gormDB),
+		FarmActivityRepo:         farm_activity.NewFarmActivityRepository(dbManager),
+		ActivityInputRepo:        farm_activity.NewActivityInputRepository(gormDB),
+		InputProductRepo:         input_product.NewInputProductRepository(gormDB),
+		BulkOperationRepo:        bulk.NewBulkOperationRepository(gormDB),
+		ProcessingDetailRepo:     bulk.NewProcessingDetailRepository(gormDB),
+		JobQueueRepo:             bulk.NewJobQueueRepository(gormDB),
+		ColumnMappingProfileRepo: bulk.NewColumnMappingProfileRepository(gormDB),
+		IdempotencyKeyRepo:       idempotency.NewIdempotencyKeyRepository(gormDB),
+		ChangeFeedRepo:           changefeed.NewChangeFeedRepository(gormDB),
+		StageRepo:                stage.NewStageRepository(dbManager),
+		CropStageRepo:            stage.NewCropStageRepository(dbManager),
+		ActivityTemplateRepo:     stage.NewActivityTemplateRepository(gormDB),
+		SoilTypeRepo:             soil_type.NewSoilTypeRepository(dbManager),
+		IrrigationSourceRepo:     irrigation_source.NewIrrigationSourceRepository(dbManager),
+		LandParcelRepo:           land_parcel.NewLandParcelRepository(gormDB),
+		AdminBoundaryRepo:        admin_boundary.NewAdminBoundaryRepository(gormDB),
+		FarmOverlapRepo:          farm_overlap.NewFarmOverlapRepository(gormDB),
+		PlotRepo:                 plot.NewPlotRepository(gormDB),
 	}
 }
@@ -0,0 +1,110 @@
+package stage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/stage"
+	"github.com/Kisanlink/farmers-module/pkg/common"
+	"gorm.io/gorm"
+)
+
+// ActivityTemplateRepository defines the storage of the activity templates of crop stages
+type ActivityTemplateRepository interface {
+	Create(ctx context.Context, t *stage.ActivityTemplate) error
+	Update(ctx context.Context, t *stage.ActivityTemplate) error
+	Delete(ctx context.Context, id, deletedBy string) error
+	GetByID(ctx context.Context, id string) (*stage.ActivityTemplate, error)
+	ListByCropStage(ctx context.Context, cropStageID string) ([]*stage.ActivityTemplate, error)
+	ListActiveByCropStages(ctx context.Context, cropStageIDs []string) ([]*stage.ActivityTemplate, error)
+}
+
+// ActivityTemplateRepositoryImpl implements ActivityTemplateRepository on PostgreSQL
+type ActivityTemplateRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewActivityTemplateRepository creates a new activity template repository
+func NewActivityTemplateRepository(db *gorm.DB) ActivityTemplateRepository {
+	return &ActivityTemplateRepositoryImpl{
+		db: db,
+	}
+}
+
+// Create stores a new activity template
+func (r *ActivityTemplateRepositoryImpl) Create(ctx context.Context, t *stage.ActivityTemplate) error {
+	if err := r.db.WithContext(ctx).Create(t).Error; err != nil {
+		return fmt.Errorf("failed to create activity template: %w", err)
+	}
+	return nil
+}
+
+// Update stores every field of an activity template
+func (r *ActivityTemplateRepositoryImpl) Update(ctx context.Context, t *stage.ActivityTemplate) error {
+	t.UpdatedAt = time.Now()
+	if err := r.db.WithContext(ctx).Save(t).Error; err != nil {
+		return fmt.Errorf("failed to update activity template: %w", err)
+	}
+	return nil
+}
+
+// Delete soft-deletes an activity template. Activities already planned from it are kept.
+func (r *ActivityTemplateRepositoryImpl) Delete(ctx context.Context, id, deletedBy string) error {
+	now := time.Now()
+	result := r.db.WithContext(ctx).Model(&stage.ActivityTemplate{}).
+		Where("id = ? AND deleted_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"deleted_at": now,
+			"deleted_by": deletedBy,
+			"updated_at": now,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete activity template: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return common.ErrNotFound
+	}
+	return nil
+}
+
+// GetByID retrieves an activity template, or common.ErrNotFound
+func (r *ActivityTemplateRepositoryImpl) GetByID(ctx context.Context, id string) (*stage.ActivityTemplate, error) {
+	var t stage.ActivityTemplate
+	if err := r.db.WithContext(ctx).
+		Where("id = ? AND deleted_at IS NULL", id).
+		First(&t).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, common.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get activity template: %w", err)
+	}
+	return &t, nil
+}
+
+// ListByCropStage lists the activity templates of a crop stage by offset
+func (r *ActivityTemplateRepositoryImpl) ListByCropStage(ctx context.Context, cropStageID string) ([]*stage.ActivityTemplate, error) {
+	var templates []*stage.ActivityTemplate
+	if err := r.db.WithContext(ctx).
+		Where("crop_stage_id = ? AND deleted_at IS NULL", cropStageID).
+		Order("offset_anchor, offset_days, name, id").
+		Find(&templates).Error; err != nil {
+		return nil, fmt.Errorf("failed to list activity templates: %w", err)
+	}
+	return templates, nil
+}
+
+// ListActiveByCropStages lists the active activity templates of the given crop stages
+func (r *ActivityTemplateRepositoryImpl) ListActiveByCropStages(ctx context.Context, cropStageIDs []string) ([]*stage.ActivityTemplate, error) {
+	var templates []*stage.ActivityTemplate
+	if len(cropStageIDs) == 0 {
+		return templates, nil
+	}
+	if err := r.db.WithContext(ctx).
+		Where("crop_stage_id IN (?) AND is_active = ? AND deleted_at IS NULL", cropStageIDs, true).
+		Order("crop_stage_id, offset_anchor, offset_days, name, id").
+		Find(&templates).Error; err != nil {
+		return nil, fmt.Errorf("failed to list activity templates: %w", err)
+	}
+	return templates, nil
+}
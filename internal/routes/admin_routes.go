@@ -36,6 +36,10 @@ func RegisterAdminRoutes(router *gin.RouterGroup, services *services.ServiceFact
 		admin.POST("/reconcile", handlers.TriggerReconciliation(services.ReconciliationJob))
 		admin.GET("/reconcile/status", handlers.GetReconciliationStatus(services.ReconciliationJob))
 
+		// Administrative boundary master data and farm geo-tagging
+		admin.POST("/boundaries/import", handlers.ImportAdminBoundaries(services.AdminBoundaryService))
+		admin.POST("/boundaries/backfill", handlers.BackfillFarmAdminCodes(services.AdminBoundaryService))
+
 		// Permanent delete endpoints (super admin only)
 		admin.POST("/permanent-delete", handlers.PermanentDelete(services.PermanentDeleteService))
 		admin.POST("/permanent-delete/org", handlers.PermanentDeleteByOrg(services.PermanentDeleteService))
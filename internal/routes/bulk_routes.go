@@ -18,6 +18,8 @@ func RegisterBulkOperationsRoutes(router *gin.RouterGroup, services *services.Se
 
 	// Initialize bulk farmer handler with AAA service for permission checks
 	bulkFarmerHandler := handlers.NewBulkFarmerHandler(services.BulkFarmerService, services.AAAService, logger)
+	bulkFarmImportHandler := handlers.NewBulkFarmImportHandler(services.BulkFarmImportService, services.AAAService, logger)
+	bulkCropCycleImportHandler := handlers.NewBulkCropCycleImportHandler(services.BulkCropCycleImportService, services.AAAService, logger)
 
 	// Create bulk routes group with authentication and authorization
 	bulk := router.Group("/bulk")
@@ -26,9 +28,17 @@ func RegisterBulkOperationsRoutes(router *gin.RouterGroup, services *services.Se
 		// Farmer operations
 		bulk.POST("/farmers/add", bulkFarmerHandler.BulkAddFarmers)
 
+		// Farm operations
+		bulk.POST("/farms/import", bulkFarmImportHandler.ImportFarms)
+
+		// Crop cycle operations
+		bulk.POST("/crop-cycles/import", bulkCropCycleImportHandler.ImportCropCycles)
+
 		// Operation management
 		bulk.GET("/status/:operation_id", bulkFarmerHandler.GetBulkOperationStatus)
 		bulk.POST("/cancel/:operation_id", bulkFarmerHandler.CancelBulkOperation)
+		bulk.POST("/pause/:operation_id", bulkFarmerHandler.PauseBulkOperation)
+		bulk.POST("/resume/:operation_id", bulkFarmerHandler.ResumeBulkOperation)
 		bulk.POST("/retry/:operation_id", bulkFarmerHandler.RetryFailedRecords)
 
 		// Results and templates
@@ -37,5 +47,13 @@ func RegisterBulkOperationsRoutes(router *gin.RouterGroup, services *services.Se
 
 		// Validation
 		bulk.POST("/validate", bulkFarmerHandler.ValidateBulkData)
+
+		// Column mapping profiles
+		bulk.POST("/mapping-profiles", bulkFarmerHandler.CreateMappingProfile)
+		bulk.GET("/mapping-profiles", bulkFarmerHandler.ListMappingProfiles)
+		bulk.POST("/mapping-profiles/preview", bulkFarmerHandler.PreviewColumnMapping)
+		bulk.GET("/mapping-profiles/:profile_id", bulkFarmerHandler.GetMappingProfile)
+		bulk.PUT("/mapping-profiles/:profile_id", bulkFarmerHandler.UpdateMappingProfile)
+		bulk.DELETE("/mapping-profiles/:profile_id", bulkFarmerHandler.DeleteMappingProfile)
 	}
 }
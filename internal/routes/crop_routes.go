@@ -14,9 +14,10 @@ func RegisterCropRoutes(router *gin.RouterGroup, services *services.ServiceFacto
 	// Initialize authentication and authorization middleware
 	authenticationMW := middleware.AuthenticationMiddleware(services.AAAService, logger)
 	authorizationMW := middleware.AuthorizationMiddleware(services.AAAService, logger)
+	idempotencyMW := middleware.IdempotencyMiddleware(services.IdempotencyService, logger)
 
 	crops := router.Group("/crops")
-	crops.Use(authenticationMW, authorizationMW) // Apply auth middleware to all crop routes
+	crops.Use(authenticationMW, authorizationMW, idempotencyMW) // Apply auth and Idempotency-Key handling to crop, cycle and activity routes
 	{
 		// Crop Master Data (CRUD operations)
 		crops.POST("", handlers.CreateCrop(services.CropService))
@@ -37,11 +38,32 @@ func RegisterCropRoutes(router *gin.RouterGroup, services *services.ServiceFacto
 			// W12: End crop cycle
 			cycles.PUT("/:cycle_id/end", handlers.EndCycle(services.CropCycleService))
 
+			// Move the sowing date, shifting the planned activities
+			cycles.POST("/:cycle_id/replan", handlers.ReplanCycle(services.CropCycleService))
+
+			// Record that a cycle entered a stage
+			cycles.POST("/:cycle_id/stages/advance", handlers.AdvanceCycleStage(services.FarmActivityService))
+
 			// W13: List crop cycles
 			cycles.GET("", handlers.ListCycles(services.CropCycleService))
 
+			// List cycles behind their stage calendar
+			cycles.GET("/delayed", handlers.ListDelayedCycles(services.FarmActivityService))
+
+			// Production forecast of open cycles from variety benchmarks
+			cycles.GET("/forecast", handlers.GetProductionForecast(services.CropCycleService))
+
 			// Get crop cycle by ID
 			cycles.GET("/:cycle_id", handlers.GetCropCycle(services.CropCycleService))
+
+			// Stage progress against the stage calendar
+			cycles.GET("/:cycle_id/progress", handlers.GetCycleStageProgress(services.FarmActivityService))
+
+			// Cost of cultivation from the inputs of the cycle's activities
+			cycles.GET("/:cycle_id/costs", handlers.GetCycleCosts(services.CultivationCostService))
+
+			// Expected yield from variety benchmarks, with the variance of the actual yield
+			cycles.GET("/:cycle_id/yield-estimate", handlers.GetCycleYieldEstimate(services.CropCycleService))
 		}
 
 		// Farm Activities (W14-W17)
@@ -53,6 +75,9 @@ func RegisterCropRoutes(router *gin.RouterGroup, services *services.ServiceFacto
 			// W15: Complete farm activity
 			activities.PUT("/:activity_id/complete", handlers.CompleteFarmActivity(services.FarmActivityService))
 
+			// Start farm activity
+			activities.PUT("/:activity_id/start", handlers.StartFarmActivity(services.FarmActivityService))
+
 			// W16: Update farm activity
 			activities.PUT("/:activity_id", handlers.UpdateFarmActivity(services.FarmActivityService))
 
@@ -64,6 +89,10 @@ func RegisterCropRoutes(router *gin.RouterGroup, services *services.ServiceFacto
 
 			// Delete farm activity
 			activities.DELETE("/:activity_id", handlers.DeleteFarmActivity(services.FarmActivityService))
+
+			// Input line items of farm activity
+			activities.PUT("/:activity_id/inputs", handlers.SetActivityInputs(services.CultivationCostService))
+			activities.GET("/:activity_id/inputs", handlers.ListActivityInputs(services.CultivationCostService))
 		}
 	}
 
@@ -78,6 +107,17 @@ func RegisterCropRoutes(router *gin.RouterGroup, services *services.ServiceFacto
 		varieties.DELETE("/:id", handlers.DeleteCropVariety(services.CropService))
 	}
 
+	// Input master of seeds, fertilisers, pesticides, labour and machinery
+	inputs := router.Group("/inputs")
+	inputs.Use(authenticationMW, authorizationMW) // Apply auth middleware to input master routes
+	{
+		inputs.POST("", handlers.CreateInputProduct(services.InputProductService))
+		inputs.GET("", handlers.ListInputProducts(services.InputProductService))
+		inputs.GET("/:id", handlers.GetInputProduct(services.InputProductService))
+		inputs.PUT("/:id", handlers.UpdateInputProduct(services.InputProductService))
+		inputs.DELETE("/:id", handlers.DeleteInputProduct(services.InputProductService))
+	}
+
 	// Get varieties for a specific crop using nested route under /crop-varieties
 	cropVarieties := router.Group("/crop-varieties")
 	cropVarieties.Use(authenticationMW, authorizationMW)
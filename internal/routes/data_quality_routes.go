@@ -27,5 +27,10 @@ func RegisterDataQualityRoutes(api *gin.RouterGroup, services *services.ServiceF
 
 		// Farm overlaps detection
 		dataQuality.POST("/detect-farm-overlaps", dataQualityHandlers.DetectFarmOverlaps)
+
+		// Farm overlap conflict workflow
+		dataQuality.GET("/overlap-conflicts", dataQualityHandlers.ListOverlapConflicts)
+		dataQuality.PUT("/overlap-conflicts/:conflict_id", dataQualityHandlers.UpdateOverlapConflict)
+		dataQuality.POST("/overlap-conflicts/:conflict_id/resolve", dataQualityHandlers.ResolveOverlapConflict)
 	}
 }
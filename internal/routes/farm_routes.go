@@ -14,9 +14,10 @@ func RegisterFarmRoutes(router *gin.RouterGroup, services *services.ServiceFacto
 	// Initialize authentication and authorization middleware
 	authenticationMW := middleware.AuthenticationMiddleware(services.AAAService, logger)
 	authorizationMW := middleware.AuthorizationMiddleware(services.AAAService, logger)
+	idempotencyMW := middleware.IdempotencyMiddleware(services.IdempotencyService, logger)
 
 	farms := router.Group("/farms")
-	farms.Use(authenticationMW, authorizationMW) // Apply auth middleware to all farm routes
+	farms.Use(authenticationMW, authorizationMW, idempotencyMW) // Apply auth and Idempotency-Key handling to all farm routes
 	{
 		// W6: Create farm
 		farms.POST("", handlers.CreateFarm(services.FarmService))
@@ -30,10 +31,47 @@ func RegisterFarmRoutes(router *gin.RouterGroup, services *services.ServiceFacto
 		// W9: List farms
 		farms.GET("", handlers.ListFarms(services.FarmService))
 
+		// Search farms by radius, boundary, nearest-N or bounding box
+		farms.POST("/search", handlers.SearchFarms(services.FarmService))
+
+		// Export farms as a GeoJSON FeatureCollection
+		farms.GET("/export.geojson", handlers.ExportFarmsGeoJSON(services.FarmService))
+
+		// Vector tiles of the organization's farms for map clients ({y} may end in .mvt)
+		farms.GET("/tiles/:z/:x/:y", handlers.GetFarmTile(services.FarmService))
+
 		// Get farm by ID
 		farms.GET("/:farm_id", handlers.GetFarm(services.FarmService))
 
+		// Boundary history: versions, the difference between two versions and the boundary as of a date
+		farms.GET("/:farm_id/geometry-versions", handlers.ListFarmGeometryVersions(services.FarmService))
+		farms.GET("/:farm_id/geometry-diff", handlers.DiffFarmGeometryVersions(services.FarmService))
+		farms.GET("/:farm_id/geometry", handlers.GetFarmGeometryAsOf(services.FarmService))
+
 		// Get farm area allocation summary
 		farms.GET("/:farm_id/area-allocation", handlers.GetFarmAreaAllocationSummary(services.CropCycleService))
+
+		// Cost of cultivation of a farm's crop cycles
+		farms.GET("/:farm_id/costs", handlers.GetFarmCosts(services.CultivationCostService))
+
+		// Land record parcels of a farm
+		farms.POST("/:farm_id/parcels", handlers.CreateLandParcel(services.LandParcelService))
+		farms.GET("/:farm_id/parcels", handlers.ListFarmLandParcels(services.LandParcelService))
+		farms.PUT("/:farm_id/parcels/:parcel_id", handlers.UpdateLandParcel(services.LandParcelService))
+		farms.DELETE("/:farm_id/parcels/:parcel_id", handlers.DeleteLandParcel(services.LandParcelService))
+
+		// Plots a farm is divided into, and their map for a season
+		farms.POST("/:farm_id/plots", handlers.CreatePlot(services.PlotService))
+		farms.GET("/:farm_id/plots", handlers.ListFarmPlots(services.PlotService))
+		farms.GET("/:farm_id/plots/map", handlers.GetPlotMap(services.PlotService))
+		farms.PUT("/:farm_id/plots/:plot_id", handlers.UpdatePlot(services.PlotService))
+		farms.DELETE("/:farm_id/plots/:plot_id", handlers.DeletePlot(services.PlotService))
+	}
+
+	// Search land parcels by village and survey number
+	landParcels := router.Group("/land-parcels")
+	landParcels.Use(authenticationMW, authorizationMW)
+	{
+		landParcels.GET("", handlers.SearchLandParcels(services.LandParcelService))
 	}
 }
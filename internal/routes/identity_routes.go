@@ -24,6 +24,7 @@ func RegisterIdentityRoutes(router *gin.RouterGroup, services *services.ServiceF
 	{
 		// Farmer management endpoints
 		farmers := identity.Group("/farmers")
+		farmers.Use(middleware.IdempotencyMiddleware(services.IdempotencyService, logger))
 		{
 			// Create a new farmer with validation
 			farmers.POST("", validation.ValidateFarmerCreation(), handlers.CreateFarmer(services.FarmerService, logger))
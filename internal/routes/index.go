@@ -44,6 +44,9 @@ func RegisterAllRoutes(router *gin.Engine, services *services.ServiceFactory, cf
 		// Bulk Operations
 		RegisterBulkOperationsRoutes(api, services, cfg, logger)
 
+		// Offline Sync
+		RegisterSyncRoutes(api, services, cfg, logger)
+
 		// Admin & Access Control (W18-W19)
 		RegisterAdminRoutes(api, services, cfg, logger)
 	}
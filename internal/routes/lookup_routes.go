@@ -21,5 +21,11 @@ func RegisterLookupRoutes(router *gin.RouterGroup, services *services.ServiceFac
 
 		// Get all irrigation sources
 		lookups.GET("/irrigation-sources", lookupHandlers.GetIrrigationSources)
+
+		// Get units of measure
+		lookups.GET("/units", lookupHandlers.GetUnits)
+
+		// Get administrative areas of a level
+		lookups.GET("/admin-boundaries", handlers.ListAdminBoundaries(services.AdminBoundaryService))
 	}
 }
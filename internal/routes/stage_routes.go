@@ -42,6 +42,12 @@ func RegisterStageRoutes(router *gin.RouterGroup, services *services.ServiceFact
 			cropStages.POST("/reorder", stageHandler.ReorderCropStages) // Reorder endpoint before :stage_id to avoid conflicts
 			cropStages.PUT("/:stage_id", stageHandler.UpdateCropStage)
 			cropStages.DELETE("/:stage_id", stageHandler.RemoveStageFromCrop)
+
+			// Activity templates the crop calendar of new cycles is planned from
+			cropStages.POST("/:stage_id/activity-templates", stageHandler.CreateActivityTemplate)
+			cropStages.GET("/:stage_id/activity-templates", stageHandler.ListActivityTemplates)
+			cropStages.PUT("/:stage_id/activity-templates/:template_id", stageHandler.UpdateActivityTemplate)
+			cropStages.DELETE("/:stage_id/activity-templates/:template_id", stageHandler.DeleteActivityTemplate)
 		}
 	}
 }
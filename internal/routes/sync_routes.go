@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"github.com/Kisanlink/farmers-module/internal/config"
+	"github.com/Kisanlink/farmers-module/internal/handlers"
+	"github.com/Kisanlink/farmers-module/internal/interfaces"
+	"github.com/Kisanlink/farmers-module/internal/middleware"
+	"github.com/Kisanlink/farmers-module/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterSyncRoutes registers routes for offline sync of mobile clients
+func RegisterSyncRoutes(router *gin.RouterGroup, services *services.ServiceFactory, cfg *config.Config, logger interfaces.Logger) {
+	// Initialize authentication and authorization middleware
+	authenticationMW := middleware.AuthenticationMiddleware(services.AAAService, logger)
+	authorizationMW := middleware.AuthorizationMiddleware(services.AAAService, logger)
+	idempotencyMW := middleware.IdempotencyMiddleware(services.IdempotencyService, logger)
+
+	sync := router.Group("/sync")
+	sync.Use(authenticationMW, authorizationMW, idempotencyMW) // Pushes retried after a lost response are replayed, not applied twice
+	{
+		// Changes since a cursor
+		sync.GET("/changes", handlers.GetSyncChanges(services.SyncService))
+
+		// Batch of offline mutations
+		sync.POST("/push", handlers.PushSyncChanges(services.SyncService))
+	}
+}
@@ -0,0 +1,241 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Kisanlink/farmers-module/internal/entities"
+	"github.com/Kisanlink/farmers-module/internal/entities/requests"
+	"github.com/Kisanlink/farmers-module/internal/entities/responses"
+	stageEntity "github.com/Kisanlink/farmers-module/internal/entities/stage"
+	"github.com/Kisanlink/farmers-module/pkg/common"
+)
+
+// CreateActivityTemplate adds an activity template to a crop stage. Crop cycles of the crop
+// started with generate_activities get a planned activity for every active template.
+func (s *StageServiceImpl) CreateActivityTemplate(ctx context.Context, req interface{}) (interface{}, error) {
+	createReq, ok := req.(*requests.CreateActivityTemplateRequest)
+	if !ok {
+		return nil, common.ErrInvalidInput
+	}
+
+	// Check permission
+	hasPermission, err := s.aaaService.CheckPermission(ctx, createReq.UserID, "crop_stage", "create", "", createReq.OrgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !hasPermission {
+		return nil, common.ErrForbidden
+	}
+
+	cropStage, err := s.getCropStage(ctx, createReq.CropID, createReq.StageID)
+	if err != nil {
+		return nil, err
+	}
+
+	template := stageEntity.NewActivityTemplate()
+	template.CropStageID = cropStage.ID
+	template.CreatedBy = createReq.UserID
+	applyActivityTemplateRequest(template, &createReq.ActivityTemplateRequest)
+	if err := validateActivityTemplate(template); err != nil {
+		return nil, err
+	}
+
+	if err := s.templateRepo.Create(ctx, template); err != nil {
+		return nil, err
+	}
+
+	return &responses.ActivityTemplateResponse{
+		BaseResponse: &responses.BaseResponse{
+			Success:   true,
+			Message:   "Activity template created successfully",
+			RequestID: createReq.RequestID,
+		},
+		Data: convertActivityTemplateToResponse(template),
+	}, nil
+}
+
+// UpdateActivityTemplate replaces the fields of an activity template. Activities already
+// planned from it are left as they are.
+func (s *StageServiceImpl) UpdateActivityTemplate(ctx context.Context, req interface{}) (interface{}, error) {
+	updateReq, ok := req.(*requests.UpdateActivityTemplateRequest)
+	if !ok {
+		return nil, common.ErrInvalidInput
+	}
+
+	// Check permission
+	hasPermission, err := s.aaaService.CheckPermission(ctx, updateReq.UserID, "crop_stage", "update", "", updateReq.OrgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !hasPermission {
+		return nil, common.ErrForbidden
+	}
+
+	template, err := s.getActivityTemplate(ctx, updateReq.CropID, updateReq.StageID, updateReq.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	template.UpdatedBy = updateReq.UserID
+	applyActivityTemplateRequest(template, &updateReq.ActivityTemplateRequest)
+	if err := validateActivityTemplate(template); err != nil {
+		return nil, err
+	}
+
+	if err := s.templateRepo.Update(ctx, template); err != nil {
+		return nil, err
+	}
+
+	return &responses.ActivityTemplateResponse{
+		BaseResponse: &responses.BaseResponse{
+			Success:   true,
+			Message:   "Activity template updated successfully",
+			RequestID: updateReq.RequestID,
+		},
+		Data: convertActivityTemplateToResponse(template),
+	}, nil
+}
+
+// DeleteActivityTemplate removes an activity template from a crop stage. Activities already
+// planned from it are kept.
+func (s *StageServiceImpl) DeleteActivityTemplate(ctx context.Context, req interface{}) (interface{}, error) {
+	deleteReq, ok := req.(*requests.DeleteActivityTemplateRequest)
+	if !ok {
+		return nil, common.ErrInvalidInput
+	}
+
+	// Check permission
+	hasPermission, err := s.aaaService.CheckPermission(ctx, deleteReq.UserID, "crop_stage", "delete", "", deleteReq.OrgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !hasPermission {
+		return nil, common.ErrForbidden
+	}
+
+	template, err := s.getActivityTemplate(ctx, deleteReq.CropID, deleteReq.StageID, deleteReq.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.templateRepo.Delete(ctx, template.ID, deleteReq.UserID); err != nil {
+		return nil, err
+	}
+
+	return &responses.BaseResponse{
+		Success:   true,
+		Message:   "Activity template deleted successfully",
+		RequestID: deleteReq.RequestID,
+	}, nil
+}
+
+// ListActivityTemplates lists the activity templates of a crop stage
+func (s *StageServiceImpl) ListActivityTemplates(ctx context.Context, req interface{}) (interface{}, error) {
+	listReq, ok := req.(*requests.ListActivityTemplatesRequest)
+	if !ok {
+		return nil, common.ErrInvalidInput
+	}
+
+	// Check permission
+	hasPermission, err := s.aaaService.CheckPermission(ctx, listReq.UserID, "crop_stage", "list", "", listReq.OrgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !hasPermission {
+		return nil, common.ErrForbidden
+	}
+
+	cropStage, err := s.getCropStage(ctx, listReq.CropID, listReq.StageID)
+	if err != nil {
+		return nil, err
+	}
+
+	templates, err := s.templateRepo.ListByCropStage(ctx, cropStage.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]*responses.ActivityTemplateData, len(templates))
+	for i, template := range templates {
+		data[i] = convertActivityTemplateToResponse(template)
+	}
+
+	return &responses.ActivityTemplatesResponse{
+		BaseResponse: &responses.BaseResponse{
+			Success:   true,
+			Message:   "Activity templates retrieved successfully",
+			RequestID: listReq.RequestID,
+		},
+		Data: data,
+	}, nil
+}
+
+// getCropStage returns the stage of a crop, or common.ErrNotFound
+func (s *StageServiceImpl) getCropStage(ctx context.Context, cropID, stageID string) (*stageEntity.CropStage, error) {
+	exists, err := s.cropStageRepo.CheckCropStageExists(ctx, cropID, stageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check crop stage: %w", err)
+	}
+	if !exists {
+		return nil, common.ErrNotFound
+	}
+	return s.cropStageRepo.GetCropStageByCropAndStage(ctx, cropID, stageID)
+}
+
+// getActivityTemplate returns an activity template of the stage of a crop, or common.ErrNotFound
+func (s *StageServiceImpl) getActivityTemplate(ctx context.Context, cropID, stageID, templateID string) (*stageEntity.ActivityTemplate, error) {
+	cropStage, err := s.getCropStage(ctx, cropID, stageID)
+	if err != nil {
+		return nil, err
+	}
+	template, err := s.templateRepo.GetByID(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+	if template.CropStageID != cropStage.ID {
+		return nil, common.ErrNotFound
+	}
+	return template, nil
+}
+
+func validateActivityTemplate(template *stageEntity.ActivityTemplate) error {
+	if err := template.Validate(); err != nil {
+		return fmt.Errorf("%w: activity template needs a name, an activity type, a non-negative offset_days and an offset_anchor of SOWING or STAGE_START", err)
+	}
+	return nil
+}
+
+func applyActivityTemplateRequest(template *stageEntity.ActivityTemplate, req *requests.ActivityTemplateRequest) {
+	template.Name = req.Name
+	template.ActivityType = req.ActivityType
+	template.OffsetDays = req.OffsetDays
+	template.OffsetAnchor = stageEntity.OffsetAnchorSowing
+	if req.OffsetAnchor != "" {
+		template.OffsetAnchor = stageEntity.OffsetAnchor(req.OffsetAnchor)
+	}
+	template.Description = req.Description
+	template.Metadata = req.Metadata
+	if template.Metadata == nil {
+		template.Metadata = make(entities.JSONB)
+	}
+	if req.IsActive != nil {
+		template.IsActive = *req.IsActive
+	}
+}
+
+func convertActivityTemplateToResponse(template *stageEntity.ActivityTemplate) *responses.ActivityTemplateData {
+	return &responses.ActivityTemplateData{
+		ID:           template.ID,
+		CropStageID:  template.CropStageID,
+		Name:         template.Name,
+		ActivityType: template.ActivityType,
+		OffsetDays:   template.OffsetDays,
+		OffsetAnchor: string(template.OffsetAnchor),
+		Description:  template.Description,
+		Metadata:     template.Metadata,
+		IsActive:     template.IsActive,
+		CreatedAt:    template.CreatedAt,
+		UpdatedAt:    template.UpdatedAt,
+	}
+}
@@ -0,0 +1,200 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Kisanlink/farmers-module/internal/auth"
+	"github.com/Kisanlink/farmers-module/internal/entities/admin_boundary"
+	"github.com/Kisanlink/farmers-module/internal/entities/requests"
+	"github.com/Kisanlink/farmers-module/internal/entities/responses"
+	adminBoundaryRepo "github.com/Kisanlink/farmers-module/internal/repo/admin_boundary"
+	farmRepo "github.com/Kisanlink/farmers-module/internal/repo/farm"
+	"github.com/Kisanlink/farmers-module/internal/services/parsers"
+	"github.com/Kisanlink/farmers-module/pkg/common"
+)
+
+// AdminBoundaryServiceImpl implements AdminBoundaryService
+type AdminBoundaryServiceImpl struct {
+	boundaryRepo adminBoundaryRepo.AdminBoundaryRepository
+	farmRepo     *farmRepo.FarmRepository
+	aaaService   AAAService
+}
+
+// NewAdminBoundaryService creates a new admin boundary service
+func NewAdminBoundaryService(boundaryRepo adminBoundaryRepo.AdminBoundaryRepository, farmRepo *farmRepo.FarmRepository, aaaService AAAService) AdminBoundaryService {
+	return &AdminBoundaryServiceImpl{
+		boundaryRepo: boundaryRepo,
+		farmRepo:     farmRepo,
+		aaaService:   aaaService,
+	}
+}
+
+// ImportAdminBoundaries stores the boundaries of one administrative level from a GeoJSON
+// FeatureCollection or a zipped Shapefile, replacing those already stored under the same codes,
+// and then tags every farm again. Features without a usable geometry, code or name are skipped
+// and reported; the others are stored together or not at all.
+func (s *AdminBoundaryServiceImpl) ImportAdminBoundaries(ctx context.Context, req interface{}) (interface{}, error) {
+	importReq, ok := req.(*requests.ImportAdminBoundariesRequest)
+	if !ok {
+		return nil, fmt.Errorf("invalid request type for ImportAdminBoundaries")
+	}
+
+	if err := s.checkMaintainPermission(ctx, importReq.OrgID); err != nil {
+		return nil, err
+	}
+
+	level, ok := admin_boundary.ParseAdminLevel(importReq.Level)
+	if !ok {
+		return nil, fmt.Errorf("%w: level must be STATE, DISTRICT, BLOCK or VILLAGE", common.ErrInvalidInput)
+	}
+	if importReq.CodeProperty == "" || importReq.NameProperty == "" {
+		return nil, fmt.Errorf("%w: code_property and name_property are required", common.ErrInvalidInput)
+	}
+	if importReq.InputFormat != parsers.BoundaryFormatGeoJSON && importReq.InputFormat != parsers.BoundaryFormatShapefile {
+		return nil, fmt.Errorf("%w: input_format must be geojson or shapefile", common.ErrInvalidInput)
+	}
+
+	features, err := parsers.ParseBoundaryFile(importReq.InputFormat, importReq.Data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", common.ErrInvalidInput, err)
+	}
+
+	data := &responses.AdminBoundaryImportData{Level: string(level)}
+	boundaries := make([]*admin_boundary.AdminBoundary, 0, len(features))
+	seen := make(map[string]bool, len(features))
+	for _, feature := range features {
+		boundary, err := adminBoundaryFromFeature(feature, level, importReq)
+		if err == nil && seen[boundary.Code] {
+			err = fmt.Errorf("code %s appears more than once", boundary.Code)
+		}
+		if err != nil {
+			data.Skipped = append(data.Skipped, responses.AdminBoundaryImportSkipData{
+				Index: feature.Index,
+				Code:  feature.Property(importReq.CodeProperty),
+				Error: err.Error(),
+			})
+			continue
+		}
+		seen[boundary.Code] = true
+		boundaries = append(boundaries, boundary)
+	}
+
+	if len(boundaries) > 0 {
+		if err := s.boundaryRepo.Upsert(ctx, boundaries); err != nil {
+			return nil, err
+		}
+		data.Imported = len(boundaries)
+
+		if data.FarmsTagged, err = s.farmRepo.BackfillAdminCodes(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	response := responses.NewAdminBoundaryImportResponse(data, "Administrative boundaries imported successfully")
+	response.SetRequestID(importReq.RequestID)
+	return &response, nil
+}
+
+// adminBoundaryFromFeature reads a boundary from an uploaded feature
+func adminBoundaryFromFeature(feature *parsers.BoundaryFeature, level admin_boundary.AdminLevel, req *requests.ImportAdminBoundariesRequest) (*admin_boundary.AdminBoundary, error) {
+	if feature.Error != "" {
+		return nil, errors.New(feature.Error)
+	}
+
+	boundary := admin_boundary.NewAdminBoundary()
+	boundary.Level = level
+	boundary.Code = feature.Property(req.CodeProperty)
+	boundary.Name = feature.Property(req.NameProperty)
+	boundary.Geometry = feature.WKT()
+	boundary.CreatedBy = req.UserID
+	boundary.UpdatedBy = req.UserID
+	if boundary.Code == "" {
+		return nil, fmt.Errorf("feature has no code property %s", req.CodeProperty)
+	}
+	if boundary.Name == "" {
+		return nil, fmt.Errorf("feature has no name property %s", req.NameProperty)
+	}
+	if req.ParentCodeProperty != "" {
+		if parentCode := feature.Property(req.ParentCodeProperty); parentCode != "" {
+			boundary.ParentCode = &parentCode
+		}
+	}
+	if err := boundary.Validate(); err != nil {
+		return nil, err
+	}
+	return boundary, nil
+}
+
+// BackfillFarmAdminCodes tags every farm with a boundary with the codes of the administrative
+// areas it lies in
+func (s *AdminBoundaryServiceImpl) BackfillFarmAdminCodes(ctx context.Context, req interface{}) (interface{}, error) {
+	backfillReq, ok := req.(*requests.BackfillFarmAdminCodesRequest)
+	if !ok {
+		return nil, fmt.Errorf("invalid request type for BackfillFarmAdminCodes")
+	}
+
+	if err := s.checkMaintainPermission(ctx, backfillReq.OrgID); err != nil {
+		return nil, err
+	}
+
+	tagged, err := s.farmRepo.BackfillAdminCodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := responses.NewFarmAdminCodesBackfillResponse(tagged, "Farm admin codes assigned successfully")
+	response.SetRequestID(backfillReq.RequestID)
+	return &response, nil
+}
+
+// ListAdminBoundaries lists the administrative areas of a level, for pickers and for the
+// admin code filters of farm, farmer and dashboard queries
+func (s *AdminBoundaryServiceImpl) ListAdminBoundaries(ctx context.Context, req interface{}) (interface{}, error) {
+	listReq, ok := req.(*requests.ListAdminBoundariesRequest)
+	if !ok {
+		return nil, fmt.Errorf("invalid request type for ListAdminBoundaries")
+	}
+
+	level, ok := admin_boundary.ParseAdminLevel(listReq.Level)
+	if !ok {
+		return nil, fmt.Errorf("%w: level must be STATE, DISTRICT, BLOCK or VILLAGE", common.ErrInvalidInput)
+	}
+
+	boundaries, err := s.boundaryRepo.List(ctx, level, listReq.ParentCode)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]*responses.AdminBoundaryData, len(boundaries))
+	for i, b := range boundaries {
+		data[i] = &responses.AdminBoundaryData{
+			Level:      string(b.Level),
+			Code:       b.Code,
+			Name:       b.Name,
+			ParentCode: b.ParentCode,
+		}
+	}
+
+	response := responses.NewAdminBoundaryListResponse(data, "Administrative boundaries retrieved successfully")
+	response.SetRequestID(listReq.RequestID)
+	return &response, nil
+}
+
+// checkMaintainPermission checks that the caller may maintain master data
+func (s *AdminBoundaryServiceImpl) checkMaintainPermission(ctx context.Context, orgID string) error {
+	userCtx, err := auth.GetUserFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get user context: %w", err)
+	}
+
+	hasPermission, err := s.aaaService.CheckPermission(ctx, userCtx.AAAUserID, "admin", "maintain", "", orgID)
+	if err != nil {
+		return fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !hasPermission {
+		return common.ErrForbidden
+	}
+	return nil
+}
@@ -0,0 +1,246 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/bulk"
+	"github.com/Kisanlink/farmers-module/internal/entities/requests"
+	"github.com/Kisanlink/farmers-module/internal/entities/responses"
+	"github.com/Kisanlink/farmers-module/pkg/common"
+)
+
+// defaultPreviewLimit is the number of mapped records a preview returns when no limit is given
+const defaultPreviewLimit = 20
+
+// CreateMappingProfile saves a new column mapping profile for the caller's FPO
+func (s *BulkFarmerServiceImpl) CreateMappingProfile(ctx context.Context, req *requests.ColumnMappingProfileRequest) (*responses.ColumnMappingProfileData, error) {
+	if err := checkProfileScope(req.FPOOrgID, req.OrgID); err != nil {
+		return nil, err
+	}
+
+	profile := bulk.NewColumnMappingProfile()
+	profile.FPOOrgID = req.FPOOrgID
+	profile.Name = strings.TrimSpace(req.Name)
+	profile.Description = req.Description
+	profile.Mappings = mappingsFromRules(req.Mappings)
+	profile.KeepUnmapped = req.KeepUnmapped
+	profile.CreatedBy = req.UserID
+
+	if err := profile.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid mapping profile: %w", err)
+	}
+
+	if err := s.profileRepo.Create(ctx, profile); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info(fmt.Sprintf("Created column mapping profile: profile_id=%s, fpo_org_id=%s, name=%s",
+		profile.ID, profile.FPOOrgID, profile.Name))
+
+	return mappingProfileData(profile), nil
+}
+
+// UpdateMappingProfile replaces the name, description and mappings of a saved profile of the
+// caller's organisation. A profile cannot be moved to another FPO.
+func (s *BulkFarmerServiceImpl) UpdateMappingProfile(ctx context.Context, req *requests.ColumnMappingProfileRequest) (*responses.ColumnMappingProfileData, error) {
+	profile, err := s.mappingProfileFor(ctx, req.ProfileID, req.FPOOrgID, req.OrgID)
+	if err != nil {
+		return nil, err
+	}
+
+	profile.Name = strings.TrimSpace(req.Name)
+	profile.Description = req.Description
+	profile.Mappings = mappingsFromRules(req.Mappings)
+	profile.KeepUnmapped = req.KeepUnmapped
+	profile.UpdatedBy = req.UserID
+
+	if err := profile.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid mapping profile: %w", err)
+	}
+
+	if err := s.profileRepo.Update(ctx, profile); err != nil {
+		return nil, err
+	}
+
+	return mappingProfileData(profile), nil
+}
+
+// GetMappingProfile retrieves a saved column mapping profile of the caller's organisation
+func (s *BulkFarmerServiceImpl) GetMappingProfile(ctx context.Context, profileID, orgID string) (*responses.ColumnMappingProfileData, error) {
+	profile, err := s.mappingProfileFor(ctx, profileID, "", orgID)
+	if err != nil {
+		return nil, err
+	}
+	return mappingProfileData(profile), nil
+}
+
+// ListMappingProfiles lists the column mapping profiles saved for the caller's FPO
+func (s *BulkFarmerServiceImpl) ListMappingProfiles(ctx context.Context, fpoOrgID, orgID string) ([]*responses.ColumnMappingProfileData, error) {
+	if fpoOrgID == "" {
+		return nil, common.ErrInvalidInput
+	}
+	if err := checkProfileScope(fpoOrgID, orgID); err != nil {
+		return nil, err
+	}
+
+	profiles, err := s.profileRepo.ListByFPO(ctx, fpoOrgID)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]*responses.ColumnMappingProfileData, 0, len(profiles))
+	for _, profile := range profiles {
+		data = append(data, mappingProfileData(profile))
+	}
+	return data, nil
+}
+
+// DeleteMappingProfile deletes a saved column mapping profile of the caller's organisation.
+// Operations that already used the profile keep its ID in their metadata.
+func (s *BulkFarmerServiceImpl) DeleteMappingProfile(ctx context.Context, profileID, orgID string) error {
+	if _, err := s.mappingProfileFor(ctx, profileID, "", orgID); err != nil {
+		return err
+	}
+	return s.profileRepo.Delete(ctx, profileID)
+}
+
+// PreviewColumnMapping maps the first records of an uploaded file with a saved profile, or
+// with inline mappings, so a profile can be checked before any farmers are created
+func (s *BulkFarmerServiceImpl) PreviewColumnMapping(ctx context.Context, req *requests.PreviewColumnMappingRequest) (*responses.ColumnMappingPreviewData, error) {
+	if req.FPOOrgID == "" || len(req.Data) == 0 {
+		return nil, common.ErrInvalidInput
+	}
+
+	var profile *bulk.ColumnMappingProfile
+	if req.ProfileID != "" {
+		var err error
+		if profile, err = s.mappingProfileFor(ctx, req.ProfileID, req.FPOOrgID, req.OrgID); err != nil {
+			return nil, err
+		}
+	} else {
+		profile = bulk.NewColumnMappingProfile()
+		profile.FPOOrgID = req.FPOOrgID
+		profile.Name = "preview"
+		profile.Mappings = mappingsFromRules(req.Mappings)
+		profile.KeepUnmapped = req.KeepUnmapped
+		if err := profile.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid mapping profile: %w", err)
+		}
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultPreviewLimit
+	}
+
+	preview, err := s.fileParser.PreviewMapping(strings.ToLower(req.InputFormat), req.Data, profile, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse input data: %w", err)
+	}
+
+	data := &responses.ColumnMappingPreviewData{
+		SourceHeaders:   preview.SourceHeaders,
+		UnmappedHeaders: preview.UnmappedHeaders,
+		MissingSources:  preview.MissingSources,
+		MissingRequired: preview.MissingRequired,
+		TotalRecords:    preview.TotalRows,
+		Records:         make([]responses.MappedRecordPreview, 0, len(preview.Records)),
+	}
+	for _, record := range preview.Records {
+		if record.Error == "" {
+			data.ValidRecords++
+		}
+		data.Records = append(data.Records, responses.MappedRecordPreview{
+			RowNumber: record.RowNumber,
+			Source:    record.Source,
+			Mapped:    mappedFarmerFields(record.Farmer),
+			Error:     record.Error,
+		})
+	}
+
+	return data, nil
+}
+
+// mappingProfileFor loads a mapping profile of the caller's organisation, such as the one
+// selected for an upload. Profiles belong to one FPO and are only visible from that FPO's
+// organisation; a profile of another FPO, or one applied to another FPO's records, is
+// reported as not found. An empty fpoOrgID skips the second check.
+func (s *BulkFarmerServiceImpl) mappingProfileFor(ctx context.Context, profileID, fpoOrgID, orgID string) (*bulk.ColumnMappingProfile, error) {
+	if profileID == "" {
+		return nil, nil
+	}
+
+	profile, err := s.profileRepo.GetByID(ctx, profileID)
+	if err != nil {
+		return nil, err
+	}
+	if orgID == "" || profile.FPOOrgID != orgID || (fpoOrgID != "" && profile.FPOOrgID != fpoOrgID) {
+		return nil, fmt.Errorf("mapping profile not found: %s", profileID)
+	}
+	return profile, nil
+}
+
+// checkProfileScope rejects creating or listing the mapping profiles of an FPO other than
+// the caller's organisation
+func checkProfileScope(fpoOrgID, orgID string) error {
+	if orgID == "" || fpoOrgID != orgID {
+		return fmt.Errorf("%w: mapping profiles can only be managed by the FPO's own organisation", common.ErrForbidden)
+	}
+	return nil
+}
+
+func mappingsFromRules(rules []requests.ColumnMappingRule) []bulk.ColumnMapping {
+	mappings := make([]bulk.ColumnMapping, 0, len(rules))
+	for _, rule := range rules {
+		mappings = append(mappings, bulk.ColumnMapping{
+			Target:    strings.TrimSpace(rule.Target),
+			Source:    rule.Source,
+			Sources:   rule.Sources,
+			Transform: bulk.MappingTransform(strings.ToLower(rule.Transform)),
+			Part:      strings.ToLower(rule.Part),
+			Separator: rule.Separator,
+			Lookup:    rule.Lookup,
+			Default:   rule.Default,
+		})
+	}
+	return mappings
+}
+
+func mappingProfileData(profile *bulk.ColumnMappingProfile) *responses.ColumnMappingProfileData {
+	return &responses.ColumnMappingProfileData{
+		ID:           profile.ID,
+		FPOOrgID:     profile.FPOOrgID,
+		Name:         profile.Name,
+		Description:  profile.Description,
+		Mappings:     profile.Mappings,
+		KeepUnmapped: profile.KeepUnmapped,
+		CreatedAt:    profile.CreatedAt,
+		UpdatedAt:    profile.UpdatedAt,
+	}
+}
+
+// mappedFarmerFields returns the non-empty fields of a mapped farmer record. Passwords are
+// never echoed back.
+func mappedFarmerFields(farmer *requests.FarmerBulkData) map[string]interface{} {
+	fields := make(map[string]interface{})
+	if farmer == nil {
+		return fields
+	}
+
+	raw, err := json.Marshal(farmer)
+	if err != nil {
+		return fields
+	}
+	_ = json.Unmarshal(raw, &fields)
+	delete(fields, "password")
+
+	for key, value := range fields {
+		if value == "" {
+			delete(fields, key)
+		}
+	}
+	return fields
+}
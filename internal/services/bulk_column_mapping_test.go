@@ -0,0 +1,182 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/bulk"
+	"github.com/Kisanlink/farmers-module/internal/entities/requests"
+	"github.com/Kisanlink/farmers-module/internal/services/parsers"
+	"github.com/Kisanlink/farmers-module/internal/testutils"
+	"github.com/Kisanlink/farmers-module/pkg/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeProfileRepo keeps column mapping profiles in memory
+type fakeProfileRepo struct {
+	profiles map[string]*bulk.ColumnMappingProfile
+	deleted  []string
+}
+
+func newFakeProfileRepo(profiles ...*bulk.ColumnMappingProfile) *fakeProfileRepo {
+	repo := &fakeProfileRepo{profiles: make(map[string]*bulk.ColumnMappingProfile)}
+	for _, profile := range profiles {
+		repo.profiles[profile.ID] = profile
+	}
+	return repo
+}
+
+func (r *fakeProfileRepo) Create(ctx context.Context, profile *bulk.ColumnMappingProfile) error {
+	r.profiles[profile.ID] = profile
+	return nil
+}
+
+func (r *fakeProfileRepo) GetByID(ctx context.Context, id string) (*bulk.ColumnMappingProfile, error) {
+	profile, ok := r.profiles[id]
+	if !ok {
+		return nil, fmt.Errorf("mapping profile not found: %s", id)
+	}
+	return profile, nil
+}
+
+func (r *fakeProfileRepo) Update(ctx context.Context, profile *bulk.ColumnMappingProfile) error {
+	r.profiles[profile.ID] = profile
+	return nil
+}
+
+func (r *fakeProfileRepo) Delete(ctx context.Context, id string) error {
+	r.deleted = append(r.deleted, id)
+	delete(r.profiles, id)
+	return nil
+}
+
+func (r *fakeProfileRepo) ListByFPO(ctx context.Context, fpoOrgID string) ([]*bulk.ColumnMappingProfile, error) {
+	var profiles []*bulk.ColumnMappingProfile
+	for _, profile := range r.profiles {
+		if profile.FPOOrgID == fpoOrgID {
+			profiles = append(profiles, profile)
+		}
+	}
+	return profiles, nil
+}
+
+func newProfileTestService(repo *fakeProfileRepo) *BulkFarmerServiceImpl {
+	return &BulkFarmerServiceImpl{
+		profileRepo:      repo,
+		fileParser:       parsers.NewFileParser(),
+		validationParser: parsers.NewFileParser(),
+		logger:           &testutils.MockLogger{},
+	}
+}
+
+func testMappingProfile(id, fpoOrgID string) *bulk.ColumnMappingProfile {
+	profile := bulk.NewColumnMappingProfile()
+	profile.ID = id
+	profile.FPOOrgID = fpoOrgID
+	profile.Name = "Register " + id
+	profile.Mappings = []bulk.ColumnMapping{
+		{Target: "first_name", Source: "First"},
+		{Target: "last_name", Source: "Last"},
+		{Target: "phone_number", Source: "Mobile"},
+	}
+	return profile
+}
+
+func testProfileRequest(fpoOrgID, orgID string) *requests.ColumnMappingProfileRequest {
+	req := &requests.ColumnMappingProfileRequest{
+		FPOOrgID: fpoOrgID,
+		Name:     "Register",
+		Mappings: []requests.ColumnMappingRule{
+			{Target: "first_name", Source: "First"},
+			{Target: "last_name", Source: "Last"},
+			{Target: "phone_number", Source: "Mobile"},
+		},
+	}
+	req.OrgID = orgID
+	req.UserID = "user_1"
+	return req
+}
+
+func TestMappingProfiles_CreateAndListScopedToCallerOrg(t *testing.T) {
+	ctx := context.Background()
+	repo := newFakeProfileRepo(testMappingProfile("BLKM1", "fpo_a"))
+	service := newProfileTestService(repo)
+
+	_, err := service.CreateMappingProfile(ctx, testProfileRequest("fpo_b", "fpo_a"))
+	assert.True(t, errors.Is(err, common.ErrForbidden), "got %v", err)
+	_, err = service.CreateMappingProfile(ctx, testProfileRequest("fpo_a", ""))
+	assert.True(t, errors.Is(err, common.ErrForbidden), "got %v", err)
+	assert.Len(t, repo.profiles, 1)
+
+	created, err := service.CreateMappingProfile(ctx, testProfileRequest("fpo_a", "fpo_a"))
+	require.NoError(t, err)
+	assert.Equal(t, "fpo_a", created.FPOOrgID)
+
+	_, err = service.ListMappingProfiles(ctx, "fpo_a", "fpo_b")
+	assert.True(t, errors.Is(err, common.ErrForbidden), "got %v", err)
+
+	profiles, err := service.ListMappingProfiles(ctx, "fpo_a", "fpo_a")
+	require.NoError(t, err)
+	assert.Len(t, profiles, 2)
+}
+
+func TestMappingProfiles_OtherOrgProfileNotFound(t *testing.T) {
+	ctx := context.Background()
+	repo := newFakeProfileRepo(testMappingProfile("BLKM1", "fpo_a"))
+	service := newProfileTestService(repo)
+
+	_, err := service.GetMappingProfile(ctx, "BLKM1", "fpo_b")
+	assert.ErrorContains(t, err, "mapping profile not found")
+
+	update := testProfileRequest("", "fpo_b")
+	update.ProfileID = "BLKM1"
+	_, err = service.UpdateMappingProfile(ctx, update)
+	assert.ErrorContains(t, err, "mapping profile not found")
+
+	// A profile cannot be moved to another FPO
+	update = testProfileRequest("fpo_b", "fpo_a")
+	update.ProfileID = "BLKM1"
+	_, err = service.UpdateMappingProfile(ctx, update)
+	assert.ErrorContains(t, err, "mapping profile not found")
+
+	assert.ErrorContains(t, service.DeleteMappingProfile(ctx, "BLKM1", "fpo_b"), "mapping profile not found")
+	assert.Empty(t, repo.deleted)
+
+	got, err := service.GetMappingProfile(ctx, "BLKM1", "fpo_a")
+	require.NoError(t, err)
+	assert.Equal(t, "BLKM1", got.ID)
+	require.NoError(t, service.DeleteMappingProfile(ctx, "BLKM1", "fpo_a"))
+	assert.Equal(t, []string{"BLKM1"}, repo.deleted)
+}
+
+func TestMappingProfiles_UploadsUseCallerOrgProfiles(t *testing.T) {
+	ctx := context.Background()
+	service := newProfileTestService(newFakeProfileRepo(testMappingProfile("BLKM1", "fpo_a")))
+	data := []byte("First,Last,Mobile\nRam,Kumar,9876543210\n")
+
+	preview := func(fpoOrgID, orgID string) error {
+		req := &requests.PreviewColumnMappingRequest{FPOOrgID: fpoOrgID, InputFormat: "csv", Data: data, ProfileID: "BLKM1"}
+		req.OrgID = orgID
+		_, err := service.PreviewColumnMapping(ctx, req)
+		return err
+	}
+	validate := func(fpoOrgID, orgID string) error {
+		req := &requests.ValidateBulkDataRequest{FPOOrgID: fpoOrgID, InputFormat: "csv", Data: data, MappingProfileID: "BLKM1"}
+		req.OrgID = orgID
+		_, err := service.ValidateBulkData(ctx, req)
+		return err
+	}
+
+	for name, run := range map[string]func(fpoOrgID, orgID string) error{"preview": preview, "validate": validate} {
+		t.Run(name, func(t *testing.T) {
+			assert.ErrorContains(t, run("fpo_a", "fpo_b"), "mapping profile not found")
+			assert.ErrorContains(t, run("fpo_b", "fpo_a"), "mapping profile not found")
+			assert.ErrorContains(t, run("fpo_a", ""), "mapping profile not found")
+		})
+	}
+
+	require.NoError(t, preview("fpo_a", "fpo_a"))
+}
@@ -0,0 +1,291 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/bulk"
+	"github.com/Kisanlink/farmers-module/internal/entities/crop"
+	"github.com/Kisanlink/farmers-module/internal/entities/crop_cycle"
+	"github.com/Kisanlink/farmers-module/internal/entities/crop_variety"
+	farmEntity "github.com/Kisanlink/farmers-module/internal/entities/farm"
+	"github.com/Kisanlink/farmers-module/internal/entities/farm_activity"
+	"github.com/Kisanlink/farmers-module/internal/entities/requests"
+	"github.com/Kisanlink/farmers-module/internal/entities/responses"
+	"github.com/Kisanlink/farmers-module/internal/interfaces"
+	bulkRepo "github.com/Kisanlink/farmers-module/internal/repo/bulk"
+	cropRepo "github.com/Kisanlink/farmers-module/internal/repo/crop"
+	cropCycleRepo "github.com/Kisanlink/farmers-module/internal/repo/crop_cycle"
+	farmRepo "github.com/Kisanlink/farmers-module/internal/repo/farm"
+	farmActivityRepo "github.com/Kisanlink/farmers-module/internal/repo/farm_activity"
+	"github.com/Kisanlink/farmers-module/internal/services/parsers"
+	"github.com/Kisanlink/farmers-module/internal/services/pipeline"
+	"github.com/Kisanlink/farmers-module/pkg/common"
+	"github.com/Kisanlink/kisanlink-db/pkg/base"
+)
+
+// BulkCropCycleImportService defines the interface for bulk crop cycle imports
+type BulkCropCycleImportService interface {
+	ImportCropCycles(ctx context.Context, req *requests.BulkCropCycleImportRequest) (*responses.BulkOperationData, error)
+}
+
+// BulkCropCycleImportServiceImpl implements BulkCropCycleImportService. Imports are bulk
+// operations of type CROP_CYCLE_IMPORT: every spreadsheet row is a processing detail run
+// through the crop cycle pipeline stages by the bulk job workers.
+type BulkCropCycleImportServiceImpl struct {
+	bulkOpRepo     bulkRepo.BulkOperationRepository
+	processingRepo bulkRepo.ProcessingDetailRepository
+	queueRepo      bulkRepo.JobQueueRepository
+	store          *cropCycleImportStore
+	logger         interfaces.Logger
+}
+
+// NewBulkCropCycleImportService creates a new bulk crop cycle import service
+func NewBulkCropCycleImportService(
+	bulkOpRepo bulkRepo.BulkOperationRepository,
+	processingRepo bulkRepo.ProcessingDetailRepository,
+	queueRepo bulkRepo.JobQueueRepository,
+	cropRepository *cropRepo.CropRepository,
+	varietyRepository *cropRepo.CropVarietyRepository,
+	cycleRepository *cropCycleRepo.CropCycleRepository,
+	activityRepository *farmActivityRepo.FarmActivityRepository,
+	farmRepository *farmRepo.FarmRepository,
+	logger interfaces.Logger,
+) *BulkCropCycleImportServiceImpl {
+	return &BulkCropCycleImportServiceImpl{
+		bulkOpRepo:     bulkOpRepo,
+		processingRepo: processingRepo,
+		queueRepo:      queueRepo,
+		store: &cropCycleImportStore{
+			cropRepo:     cropRepository,
+			varietyRepo:  varietyRepository,
+			cycleRepo:    cycleRepository,
+			activityRepo: activityRepository,
+			farmRepo:     farmRepository,
+		},
+		logger: logger,
+	}
+}
+
+// ImportCropCycles parses a sowing survey spreadsheet and queues one record per row
+func (s *BulkCropCycleImportServiceImpl) ImportCropCycles(ctx context.Context, req *requests.BulkCropCycleImportRequest) (*responses.BulkOperationData, error) {
+	if req.FPOOrgID == "" || len(req.Data) == 0 {
+		return nil, common.ErrInvalidInput
+	}
+
+	format := strings.ToLower(req.InputFormat)
+	switch format {
+	case "csv", "excel":
+	default:
+		return nil, fmt.Errorf("unsupported crop cycle import format: %s", req.InputFormat)
+	}
+
+	if req.Options.Season != "" {
+		req.Options.Season = strings.ToUpper(req.Options.Season)
+		switch req.Options.Season {
+		case "RABI", "KHARIF", "ZAID", "PERENNIAL", "OTHER":
+		default:
+			return nil, fmt.Errorf("unsupported season: %s", req.Options.Season)
+		}
+	}
+
+	s.logger.Info(fmt.Sprintf("Starting bulk crop cycle import: fpo_org_id=%s, input_format=%s", req.FPOOrgID, format))
+
+	rows, err := parsers.ParseCropCycleFile(format, req.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse input data: %w", err)
+	}
+
+	bulkOp := bulk.NewBulkOperation()
+	bulkOp.OperationType = bulk.OperationCropCycleImport
+	bulkOp.FPOOrgID = req.FPOOrgID
+	bulkOp.InitiatedBy = req.UserID
+	bulkOp.InputFormat = bulk.InputFormat(strings.ToUpper(format))
+	bulkOp.ProcessingMode = bulk.ModeAsync
+	bulkOp.TotalRecords = len(rows)
+	bulkOp.Status = bulk.StatusPending
+
+	optionsJSON, _ := json.Marshal(req.Options)
+	_ = json.Unmarshal(optionsJSON, &bulkOp.Options)
+
+	if err := s.bulkOpRepo.Create(ctx, bulkOp); err != nil {
+		return nil, fmt.Errorf("failed to create bulk operation: %w", err)
+	}
+
+	details := make([]*bulk.ProcessingDetail, len(rows))
+	for i, row := range rows {
+		detail := bulk.NewProcessingDetail(bulkOp.ID, i)
+		detail.ExternalID = row.ExternalID
+
+		rowJSON, _ := json.Marshal(row)
+		var rowMap map[string]interface{}
+		_ = json.Unmarshal(rowJSON, &rowMap)
+		detail.InputData = rowMap
+
+		details[i] = detail
+	}
+	if err := s.processingRepo.CreateBatch(ctx, details); err != nil {
+		return nil, fmt.Errorf("failed to create processing details: %w", err)
+	}
+
+	if err := s.queueRepo.Enqueue(ctx, bulkOp.ID); err != nil {
+		return nil, fmt.Errorf("failed to queue bulk operation: %w", err)
+	}
+
+	s.logger.Info(fmt.Sprintf("Queued bulk crop cycle import: operation_id=%s, rows=%d", bulkOp.ID, len(rows)))
+
+	return &responses.BulkOperationData{
+		OperationID: bulkOp.ID,
+		Status:      string(bulkOp.Status),
+		StatusURL:   fmt.Sprintf("/api/v1/bulk/status/%s", bulkOp.ID),
+		ResultURL:   resultFileURL(bulkOp.ID),
+		Message:     fmt.Sprintf("Crop cycle import initiated for %d rows", len(rows)),
+	}, nil
+}
+
+// processDetail runs one imported row through the crop cycle pipeline
+func (s *BulkCropCycleImportServiceImpl) processDetail(ctx context.Context, bulkOp *bulk.BulkOperation, detail *bulk.ProcessingDetail, _ requests.BulkProcessingOptions) (string, string, error) {
+	row, err := cropCycleRowFromDetail(detail)
+	if err != nil {
+		return "", "", &recordError{code: "INVALID_INPUT", err: err}
+	}
+
+	options := cropCycleImportOptionsFrom(bulkOp)
+	procCtx := pipeline.NewProcessingContext(bulkOp.ID, bulkOp.FPOOrgID, bulkOp.InitiatedBy, detail.RecordIndex, row)
+
+	if _, err := s.buildPipeline(options).Execute(ctx, procCtx); err != nil {
+		return "", "", fmt.Errorf("pipeline execution failed: %w", err)
+	}
+
+	record, ok := pipeline.GetCropCycleRecord(procCtx)
+	if !ok || record.Cycle == nil {
+		return "", "", fmt.Errorf("crop cycle not found in pipeline result")
+	}
+
+	if detail.Metadata == nil {
+		detail.Metadata = make(map[string]interface{})
+	}
+	detail.Metadata["crop_cycle_id"] = record.Cycle.ID
+	detail.Metadata["crop_cycle_created"] = record.CycleCreated
+	if record.Activity != nil {
+		detail.Metadata["activity_id"] = record.Activity.ID
+	}
+
+	return record.Cycle.FarmerID, "", nil
+}
+
+// buildPipeline builds the crop cycle import pipeline
+func (s *BulkCropCycleImportServiceImpl) buildPipeline(options requests.CropCycleImportOptions) pipeline.ProcessingPipeline {
+	return pipeline.NewPipeline(s.logger).
+		AddStage(pipeline.NewCropCycleValidationStage(options.Season, s.logger)).
+		AddStage(pipeline.NewCropResolutionStage(s.store, s.logger)).
+		AddStage(pipeline.NewAreaAllocationStage(s.store, s.logger)).
+		AddStage(pipeline.NewCropCycleCreationStage(s.store, s.logger)).
+		AddStage(pipeline.NewFarmActivityCreationStage(s.store, s.logger))
+}
+
+func cropCycleRowFromDetail(detail *bulk.ProcessingDetail) (*requests.CropCycleBulkData, error) {
+	inputJSON, err := json.Marshal(detail.InputData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal input data: %w", err)
+	}
+
+	var row requests.CropCycleBulkData
+	if err := json.Unmarshal(inputJSON, &row); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal crop cycle data: %w", err)
+	}
+
+	return &row, nil
+}
+
+// cropCycleImportOptionsFrom restores the import options stored with a bulk operation
+func cropCycleImportOptionsFrom(bulkOp *bulk.BulkOperation) requests.CropCycleImportOptions {
+	var options requests.CropCycleImportOptions
+	if optionsJSON, err := json.Marshal(bulkOp.Options); err == nil {
+		_ = json.Unmarshal(optionsJSON, &options)
+	}
+	return options
+}
+
+// cropCycleImportStore gives the crop cycle pipeline stages access to the crop master data,
+// farms, crop cycles and activities
+type cropCycleImportStore struct {
+	cropRepo     *cropRepo.CropRepository
+	varietyRepo  *cropRepo.CropVarietyRepository
+	cycleRepo    *cropCycleRepo.CropCycleRepository
+	activityRepo *farmActivityRepo.FarmActivityRepository
+	farmRepo     *farmRepo.FarmRepository
+}
+
+// FindCropByName finds an active crop by name. Survey spreadsheets rarely match the master
+// data's capitalisation, so names are compared case-insensitively.
+func (st *cropCycleImportStore) FindCropByName(ctx context.Context, name string) (*crop.Crop, error) {
+	if cropEntity, err := st.cropRepo.FindByName(ctx, name); err == nil && cropEntity != nil {
+		return cropEntity, nil
+	}
+
+	crops, err := st.cropRepo.GetActiveCropsForLookup(ctx, "", "")
+	if err != nil {
+		return nil, err
+	}
+	for _, cropEntity := range crops {
+		if strings.EqualFold(strings.TrimSpace(cropEntity.Name), name) {
+			return cropEntity, nil
+		}
+	}
+	return nil, nil
+}
+
+// FindVarietyByName finds an active variety of a crop by name, ignoring case
+func (st *cropCycleImportStore) FindVarietyByName(ctx context.Context, cropID, name string) (*crop_variety.CropVariety, error) {
+	varieties, err := st.varietyRepo.GetActiveVarietiesForLookup(ctx, cropID)
+	if err != nil {
+		return nil, err
+	}
+	for _, variety := range varieties {
+		if strings.EqualFold(strings.TrimSpace(variety.Name), name) {
+			return variety, nil
+		}
+	}
+	return nil, nil
+}
+
+// GetFarm returns a farm that has not been deleted, or nil
+func (st *cropCycleImportStore) GetFarm(ctx context.Context, farmID string) (*farmEntity.Farm, error) {
+	filter := base.NewFilterBuilder().
+		Where("id", base.OpEqual, farmID).
+		Where("deleted_at", base.OpIsNull, nil).
+		Build()
+
+	farms, err := st.farmRepo.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(farms) == 0 {
+		return nil, nil
+	}
+	return farms[0], nil
+}
+
+// FindCropCycle returns the open cycle a row belongs to, or nil
+func (st *cropCycleImportStore) FindCropCycle(ctx context.Context, farmID, cropID, season string, startDate time.Time) (*crop_cycle.CropCycle, error) {
+	return st.cycleRepo.FindOpenCycle(ctx, farmID, cropID, season, startDate)
+}
+
+// ValidateAreaAllocation applies the farm area allocation rules of GetAreaAllocationSummary
+func (st *cropCycleImportStore) ValidateAreaAllocation(ctx context.Context, farmID, cycleID string, areaHa float64) error {
+	return st.cycleRepo.ValidateAreaAllocation(ctx, farmID, cycleID, areaHa)
+}
+
+// CreateCropCycle creates a crop cycle
+func (st *cropCycleImportStore) CreateCropCycle(ctx context.Context, cycle *crop_cycle.CropCycle) error {
+	return st.cycleRepo.Create(ctx, cycle)
+}
+
+// CreateFarmActivity creates a farm activity
+func (st *cropCycleImportStore) CreateFarmActivity(ctx context.Context, activity *farm_activity.FarmActivity) error {
+	return st.activityRepo.Create(ctx, activity)
+}
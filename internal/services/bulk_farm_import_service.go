@@ -0,0 +1,309 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/bulk"
+	farmEntity "github.com/Kisanlink/farmers-module/internal/entities/farm"
+	farmerentity "github.com/Kisanlink/farmers-module/internal/entities/farmer"
+	"github.com/Kisanlink/farmers-module/internal/entities/requests"
+	"github.com/Kisanlink/farmers-module/internal/entities/responses"
+	"github.com/Kisanlink/farmers-module/internal/interfaces"
+	bulkRepo "github.com/Kisanlink/farmers-module/internal/repo/bulk"
+	farmRepo "github.com/Kisanlink/farmers-module/internal/repo/farm"
+	farmerRepo "github.com/Kisanlink/farmers-module/internal/repo/farmer"
+	"github.com/Kisanlink/farmers-module/internal/services/parsers"
+	"github.com/Kisanlink/farmers-module/pkg/common"
+	"github.com/Kisanlink/kisanlink-db/pkg/base"
+)
+
+// Feature properties read by a farm import, matched case-insensitively
+var (
+	farmImportPhoneKeys     = []string{"phone_number", "phone", "mobile", "mobile_no", "mobile_number", "farmer_phone"}
+	farmImportAAAUserIDKeys = []string{"aaa_user_id", "user_id"}
+	farmImportNameKeys      = []string{"name", "farm_name"}
+	farmImportOwnershipKeys = []string{"ownership_type", "ownership"}
+	farmImportExternalKeys  = []string{"external_id", "id"}
+)
+
+var nonDigits = regexp.MustCompile(`\D`)
+
+// BulkFarmImportService defines the interface for bulk farm imports
+type BulkFarmImportService interface {
+	ImportFarms(ctx context.Context, req *requests.BulkFarmImportRequest) (*responses.BulkOperationData, error)
+}
+
+// BulkFarmImportServiceImpl implements BulkFarmImportService. Imports are bulk operations of
+// type FARM_IMPORT: every feature of the file is a processing detail, processed by the bulk
+// job workers, so status, pause, resume, retry and result files work as for farmer uploads.
+type BulkFarmImportServiceImpl struct {
+	bulkOpRepo     bulkRepo.BulkOperationRepository
+	processingRepo bulkRepo.ProcessingDetailRepository
+	queueRepo      bulkRepo.JobQueueRepository
+	farmRepo       *farmRepo.FarmRepository
+	farmerRepo     *farmerRepo.FarmerRepository
+	farmService    FarmService
+	logger         interfaces.Logger
+}
+
+// NewBulkFarmImportService creates a new bulk farm import service. Boundaries are validated
+// by farmService with the same rules as farms created through it, so it is required.
+func NewBulkFarmImportService(
+	bulkOpRepo bulkRepo.BulkOperationRepository,
+	processingRepo bulkRepo.ProcessingDetailRepository,
+	queueRepo bulkRepo.JobQueueRepository,
+	farmRepo *farmRepo.FarmRepository,
+	farmerRepo *farmerRepo.FarmerRepository,
+	farmService FarmService,
+	logger interfaces.Logger,
+) *BulkFarmImportServiceImpl {
+	if farmService == nil {
+		panic("bulk farm import service requires a farm service to validate boundaries")
+	}
+	return &BulkFarmImportServiceImpl{
+		bulkOpRepo:     bulkOpRepo,
+		processingRepo: processingRepo,
+		queueRepo:      queueRepo,
+		farmRepo:       farmRepo,
+		farmerRepo:     farmerRepo,
+		farmService:    farmService,
+		logger:         logger,
+	}
+}
+
+// ImportFarms parses a boundary file and queues one record per feature. Features that
+// cannot be used are recorded as failed records rather than rejecting the whole file.
+func (s *BulkFarmImportServiceImpl) ImportFarms(ctx context.Context, req *requests.BulkFarmImportRequest) (*responses.BulkOperationData, error) {
+	if req.FPOOrgID == "" || len(req.Data) == 0 {
+		return nil, common.ErrInvalidInput
+	}
+
+	format := strings.ToLower(req.InputFormat)
+	switch format {
+	case parsers.BoundaryFormatGeoJSON, parsers.BoundaryFormatKML, parsers.BoundaryFormatShapefile:
+	default:
+		return nil, fmt.Errorf("unsupported boundary format: %s", req.InputFormat)
+	}
+
+	switch req.Options.MatchBy {
+	case "", requests.FarmImportMatchByPhone, requests.FarmImportMatchByAAAUserID:
+	default:
+		return nil, fmt.Errorf("unsupported match_by: %s", req.Options.MatchBy)
+	}
+
+	s.logger.Info(fmt.Sprintf("Starting bulk farm import: fpo_org_id=%s, input_format=%s", req.FPOOrgID, format))
+
+	features, err := parsers.ParseBoundaryFile(format, req.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse input data: %w", err)
+	}
+
+	bulkOp := bulk.NewBulkOperation()
+	bulkOp.OperationType = bulk.OperationFarmImport
+	bulkOp.FPOOrgID = req.FPOOrgID
+	bulkOp.InitiatedBy = req.UserID
+	bulkOp.InputFormat = bulk.InputFormat(strings.ToUpper(format))
+	bulkOp.ProcessingMode = bulk.ModeAsync
+	bulkOp.TotalRecords = len(features)
+	bulkOp.Status = bulk.StatusPending
+
+	optionsJSON, _ := json.Marshal(req.Options)
+	_ = json.Unmarshal(optionsJSON, &bulkOp.Options)
+
+	if err := s.bulkOpRepo.Create(ctx, bulkOp); err != nil {
+		return nil, fmt.Errorf("failed to create bulk operation: %w", err)
+	}
+
+	details := make([]*bulk.ProcessingDetail, len(features))
+	for i, feature := range features {
+		detail := bulk.NewProcessingDetail(bulkOp.ID, i)
+		detail.ExternalID = feature.Property(farmImportExternalKeys...)
+		detail.InputData = featureInput(feature)
+		details[i] = detail
+	}
+	if err := s.processingRepo.CreateBatch(ctx, details); err != nil {
+		return nil, fmt.Errorf("failed to create processing details: %w", err)
+	}
+
+	if err := s.queueRepo.Enqueue(ctx, bulkOp.ID); err != nil {
+		return nil, fmt.Errorf("failed to queue bulk operation: %w", err)
+	}
+
+	s.logger.Info(fmt.Sprintf("Queued bulk farm import: operation_id=%s, features=%d", bulkOp.ID, len(features)))
+
+	return &responses.BulkOperationData{
+		OperationID: bulkOp.ID,
+		Status:      string(bulkOp.Status),
+		StatusURL:   fmt.Sprintf("/api/v1/bulk/status/%s", bulkOp.ID),
+		ResultURL:   resultFileURL(bulkOp.ID),
+		Message:     fmt.Sprintf("Farm import initiated for %d features", len(features)),
+	}, nil
+}
+
+// processDetail creates the farm of one imported feature
+func (s *BulkFarmImportServiceImpl) processDetail(ctx context.Context, bulkOp *bulk.BulkOperation, detail *bulk.ProcessingDetail, _ requests.BulkProcessingOptions) (string, string, error) {
+	input := detail.InputData
+	options := farmImportOptionsFrom(bulkOp)
+
+	if parseError := inputString(input, "parse_error"); parseError != "" {
+		return "", "", &recordError{code: "INVALID_GEOMETRY", err: fmt.Errorf("invalid geometry: %s", parseError)}
+	}
+
+	farmer, err := s.matchFarmer(ctx, bulkOp.FPOOrgID, inputString(input, "phone_number"), inputString(input, "aaa_user_id"), options.MatchBy)
+	if err != nil {
+		return "", "", err
+	}
+
+	wkt := inputString(input, "wkt")
+	if err := s.farmService.ValidateGeometry(ctx, wkt); err != nil {
+		return "", "", &recordError{code: "INVALID_GEOMETRY", err: fmt.Errorf("geometry validation failed: %w", err)}
+	}
+
+	ownership := farmEntity.OwnershipType(strings.ToUpper(inputString(input, "ownership_type")))
+	if ownership == "" {
+		ownership = farmEntity.OwnershipType(strings.ToUpper(options.OwnershipType))
+	}
+	switch ownership {
+	case "":
+		ownership = farmEntity.OwnershipOwn
+	case farmEntity.OwnershipOwn, farmEntity.OwnershipLease, farmEntity.OwnershipShared:
+	default:
+		return "", "", &recordError{code: "INVALID_INPUT", err: fmt.Errorf("invalid ownership type: %s", ownership)}
+	}
+
+	overlaps, overlappingIDs, overlapArea, err := s.farmRepo.CheckOverlap(ctx, wkt, "", bulkOp.FPOOrgID)
+	if err != nil {
+		return "", "", err
+	}
+	if overlaps {
+		if !options.AllowOverlap {
+			return "", "", &recordError{code: "OVERLAP", err: fmt.Errorf("boundary overlaps existing farms: %s", strings.Join(overlappingIDs, ", "))}
+		}
+		if detail.Metadata == nil {
+			detail.Metadata = make(map[string]interface{})
+		}
+		detail.Metadata["overlapping_farm_ids"] = overlappingIDs
+		detail.Metadata["overlap_area_ha"] = overlapArea
+	}
+
+	farm := farmEntity.NewFarm()
+	farm.FarmerID = farmer.ID
+	farm.AAAUserID = farmer.AAAUserID
+	farm.AAAOrgID = bulkOp.FPOOrgID
+	farm.OwnershipType = ownership
+	farm.Geometry = wkt
+	if name := inputString(input, "name"); name != "" {
+		farm.Name = &name
+	}
+	farm.Metadata["import_operation_id"] = bulkOp.ID
+	farm.Metadata["import_feature_index"] = detail.RecordIndex
+	if properties, ok := input["properties"].(map[string]interface{}); ok && len(properties) > 0 {
+		farm.Metadata["import_properties"] = properties
+	}
+
+	// The farm, its administrative areas and the start of its boundary history are stored
+	// together, so a failed record leaves no farm behind to be imported twice on retry
+	reason := fmt.Sprintf("Imported by bulk operation %s", bulkOp.ID)
+	version := farmEntity.NewFarmGeometryVersion()
+	version.Geometry = wkt
+	version.Reason = &reason
+	version.AuthorID = bulkOp.InitiatedBy
+	version.CreatedBy = bulkOp.InitiatedBy
+	version.UpdatedBy = bulkOp.InitiatedBy
+	if err := s.farmRepo.CreateWithGeometryVersion(ctx, farm, version); err != nil {
+		return "", "", err
+	}
+
+	if detail.Metadata == nil {
+		detail.Metadata = make(map[string]interface{})
+	}
+	detail.Metadata["farm_id"] = farm.ID
+
+	return farmer.ID, farmer.AAAUserID, nil
+}
+
+// matchFarmer finds the FPO farmer a feature belongs to. Phone numbers are compared on
+// their last ten digits, with or without the +91 country code.
+func (s *BulkFarmImportServiceImpl) matchFarmer(ctx context.Context, fpoOrgID, phone, aaaUserID, matchBy string) (*farmerentity.Farmer, error) {
+	if matchBy == "" {
+		matchBy = requests.FarmImportMatchByPhone
+		if aaaUserID != "" {
+			matchBy = requests.FarmImportMatchByAAAUserID
+		}
+	}
+
+	var filter *base.Filter
+	var key string
+	switch matchBy {
+	case requests.FarmImportMatchByAAAUserID:
+		if aaaUserID == "" {
+			return nil, &recordError{code: "FARMER_NOT_FOUND", err: fmt.Errorf("feature has no aaa_user_id property")}
+		}
+		key = "aaa_user_id " + aaaUserID
+		filter = base.NewFilterBuilder().Where("aaa_user_id", base.OpEqual, aaaUserID).Build()
+	default:
+		digits := nonDigits.ReplaceAllString(phone, "")
+		if len(digits) < 10 {
+			return nil, &recordError{code: "FARMER_NOT_FOUND", err: fmt.Errorf("feature has no valid phone number")}
+		}
+		digits = digits[len(digits)-10:]
+		key = "phone " + digits
+		filter = base.NewFilterBuilder().
+			Where("phone_number", base.OpIn, []string{digits, "+91" + digits, "91" + digits, "0" + digits}).
+			Build()
+	}
+
+	farmers, err := s.farmerRepo.FindByOrgID(ctx, fpoOrgID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup farmer: %w", err)
+	}
+	switch len(farmers) {
+	case 0:
+		return nil, &recordError{code: "FARMER_NOT_FOUND", err: fmt.Errorf("no farmer of the FPO found for %s", key)}
+	case 1:
+		return farmers[0], nil
+	default:
+		return nil, &recordError{code: "AMBIGUOUS_FARMER", err: fmt.Errorf("%d farmers of the FPO match %s", len(farmers), key)}
+	}
+}
+
+// featureInput returns the input data recorded for a feature
+func featureInput(feature *parsers.BoundaryFeature) map[string]interface{} {
+	properties := make(map[string]interface{}, len(feature.Properties))
+	for key, value := range feature.Properties {
+		properties[key] = value
+	}
+
+	input := map[string]interface{}{
+		"feature_index":  feature.Index,
+		"name":           feature.Property(farmImportNameKeys...),
+		"phone_number":   feature.Property(farmImportPhoneKeys...),
+		"aaa_user_id":    feature.Property(farmImportAAAUserIDKeys...),
+		"ownership_type": feature.Property(farmImportOwnershipKeys...),
+		"external_id":    feature.Property(farmImportExternalKeys...),
+		"wkt":            feature.WKT(),
+		"properties":     properties,
+	}
+	if feature.Error != "" {
+		input["parse_error"] = feature.Error
+	}
+	return input
+}
+
+// farmImportOptionsFrom restores the import options stored with a bulk operation
+func farmImportOptionsFrom(bulkOp *bulk.BulkOperation) requests.FarmImportOptions {
+	var options requests.FarmImportOptions
+	if optionsJSON, err := json.Marshal(bulkOp.Options); err == nil {
+		_ = json.Unmarshal(optionsJSON, &options)
+	}
+	return options
+}
+
+func inputString(input map[string]interface{}, key string) string {
+	value, _ := input[key].(string)
+	return strings.TrimSpace(value)
+}
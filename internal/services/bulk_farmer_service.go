@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
 	"strings"
 	"sync"
 	"time"
@@ -17,6 +18,8 @@ import (
 	"github.com/Kisanlink/farmers-module/internal/services/parsers"
 	"github.com/Kisanlink/farmers-module/internal/services/pipeline"
 	"github.com/Kisanlink/farmers-module/internal/utils"
+	"github.com/Kisanlink/farmers-module/pkg/common"
+	"github.com/google/uuid"
 )
 
 // BulkFarmerService defines the interface for bulk farmer operations
@@ -25,12 +28,22 @@ type BulkFarmerService interface {
 	BulkAddFarmersToFPO(ctx context.Context, req *requests.BulkFarmerAdditionRequest) (*responses.BulkOperationData, error)
 	GetBulkOperationStatus(ctx context.Context, operationID string) (*responses.BulkOperationStatusData, error)
 	CancelBulkOperation(ctx context.Context, operationID string) error
+	PauseBulkOperation(ctx context.Context, operationID string) error
+	ResumeBulkOperation(ctx context.Context, operationID string) (*responses.BulkOperationData, error)
 	RetryFailedRecords(ctx context.Context, req *requests.RetryBulkOperationRequest) (*responses.BulkOperationData, error)
 
 	// File operations
 	ValidateBulkData(ctx context.Context, req *requests.ValidateBulkDataRequest) (*responses.BulkValidationData, error)
 	ParseBulkFile(ctx context.Context, format string, data []byte) ([]*requests.FarmerBulkData, error)
-	GenerateResultFile(ctx context.Context, operationID string, format string) ([]byte, error)
+	GenerateResultFile(ctx context.Context, operationID string, format string, includeAll bool) ([]byte, error)
+
+	// Column mapping profiles
+	CreateMappingProfile(ctx context.Context, req *requests.ColumnMappingProfileRequest) (*responses.ColumnMappingProfileData, error)
+	UpdateMappingProfile(ctx context.Context, req *requests.ColumnMappingProfileRequest) (*responses.ColumnMappingProfileData, error)
+	GetMappingProfile(ctx context.Context, profileID, orgID string) (*responses.ColumnMappingProfileData, error)
+	ListMappingProfiles(ctx context.Context, fpoOrgID, orgID string) ([]*responses.ColumnMappingProfileData, error)
+	DeleteMappingProfile(ctx context.Context, profileID, orgID string) error
+	PreviewColumnMapping(ctx context.Context, req *requests.PreviewColumnMappingRequest) (*responses.ColumnMappingPreviewData, error)
 
 	// Template operations
 	GetBulkUploadTemplate(ctx context.Context, format string, includeExample bool) (*responses.BulkTemplateData, error)
@@ -40,14 +53,24 @@ type BulkFarmerService interface {
 type BulkFarmerServiceImpl struct {
 	bulkOpRepo         bulkRepo.BulkOperationRepository
 	processingRepo     bulkRepo.ProcessingDetailRepository
+	queueRepo          bulkRepo.JobQueueRepository
+	profileRepo        bulkRepo.ColumnMappingProfileRepository
 	farmerService      FarmerService
 	linkageService     FarmerLinkageService
 	aaaService         AAAService
 	fileParser         parsers.FileParser
+	validationParser   parsers.FileParser
+	streamingParser    parsers.StreamingFileParser
 	processingPipeline pipeline.ProcessingPipeline
 	logger             interfaces.Logger
 	config             *BulkServiceConfig
 	defaultPassword    string
+
+	// instanceID identifies this replica in heartbeats and record leases
+	instanceID string
+
+	// controls holds the *operationControl of every operation processed by this instance
+	controls sync.Map
 }
 
 // BulkServiceConfig contains configuration for bulk service
@@ -58,19 +81,25 @@ type BulkServiceConfig struct {
 	MaxRetries        int
 	ProcessingTimeout time.Duration
 	EnableAsync       bool
+	LeaseTTL          time.Duration // operations without a heartbeat for this long are recovered
+	HeartbeatInterval time.Duration
+	StreamThreshold   int // async uploads larger than this many bytes are streamed into the queue
 }
 
 // NewBulkFarmerService creates a new bulk farmer service
 // defaultPassword should come from config (AAA_DEFAULT_PASSWORD env with fallback "Welcome@123")
+// Large operations are handed to queueRepo and processed by BulkJobWorker.
 func NewBulkFarmerService(
 	bulkOpRepo bulkRepo.BulkOperationRepository,
 	processingRepo bulkRepo.ProcessingDetailRepository,
+	queueRepo bulkRepo.JobQueueRepository,
+	profileRepo bulkRepo.ColumnMappingProfileRepository,
 	farmerService FarmerService,
 	linkageService FarmerLinkageService,
 	aaaService AAAService,
 	logger interfaces.Logger,
 	defaultPassword string,
-) BulkFarmerService {
+) *BulkFarmerServiceImpl {
 	config := &BulkServiceConfig{
 		MaxSyncRecords:    100,
 		DefaultChunkSize:  100,
@@ -78,26 +107,48 @@ func NewBulkFarmerService(
 		MaxRetries:        3,
 		ProcessingTimeout: 30 * time.Minute,
 		EnableAsync:       true,
+		LeaseTTL:          2 * time.Minute,
+		HeartbeatInterval: 30 * time.Second,
+		StreamThreshold:   1 << 20,
 	}
 
 	// Create file parser
 	fileParser := parsers.NewFileParser()
 
+	// Validation keeps rows that fail record checks so they can be reported individually
+	validationConfig := parsers.DefaultParserConfig()
+	validationConfig.SkipRecordValidation = true
+	validationParser := parsers.NewFileParserWithConfig(validationConfig)
+
 	// Create processing pipeline
 	processingPipeline := pipeline.NewPipeline(logger)
 
 	return &BulkFarmerServiceImpl{
 		bulkOpRepo:         bulkOpRepo,
 		processingRepo:     processingRepo,
+		queueRepo:          queueRepo,
+		profileRepo:        profileRepo,
 		farmerService:      farmerService,
 		linkageService:     linkageService,
 		aaaService:         aaaService,
 		fileParser:         fileParser,
+		validationParser:   validationParser,
+		streamingParser:    parsers.NewStreamingFileParser(parsers.DefaultStreamingParserConfig()),
 		processingPipeline: processingPipeline,
 		logger:             logger,
 		config:             config,
 		defaultPassword:    defaultPassword,
+		instanceID:         newInstanceID(),
+	}
+}
+
+// newInstanceID returns an identifier that is unique across replicas and restarts
+func newInstanceID() string {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "farmers-module"
 	}
+	return fmt.Sprintf("%s-%s", hostname, uuid.NewString()[:8])
 }
 
 // BulkAddFarmersToFPO adds multiple farmers to an FPO
@@ -108,29 +159,49 @@ func (s *BulkFarmerServiceImpl) BulkAddFarmersToFPO(ctx context.Context, req *re
 	// Set default options
 	req.Options.SetDefaults()
 
-	// Parse input data
-	farmers, err := s.parseInputData(ctx, req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse input data: %w", err)
-	}
-
-	if len(farmers) == 0 {
-		return nil, fmt.Errorf("no valid farmer records found in input")
+	// Uploads that are not streamed are parsed whole
+	if req.File != nil && (req.Options.ValidateOnly || !s.shouldStream(req)) {
+		if err := readUpload(req); err != nil {
+			return nil, err
+		}
 	}
 
 	// Validate data if requested
 	if req.Options.ValidateOnly {
-		validationResult, err := s.validateFarmers(ctx, req.FPOOrgID, farmers)
+		validationResult, err := s.ValidateBulkData(ctx, &requests.ValidateBulkDataRequest{
+			BaseRequest:       req.BaseRequest,
+			FPOOrgID:          req.FPOOrgID,
+			InputFormat:       req.InputFormat,
+			Data:              req.Data,
+			DeduplicationMode: req.Options.DeduplicationMode,
+			MappingProfileID:  req.MappingProfileID,
+		})
 		if err != nil {
 			return nil, fmt.Errorf("validation failed: %w", err)
 		}
 
 		return &responses.BulkOperationData{
-			Status:  "VALIDATED",
-			Message: fmt.Sprintf("Validation completed. Valid: %d, Invalid: %d", validationResult.ValidRecords, len(validationResult.Errors)),
+			Status: "VALIDATED",
+			Message: fmt.Sprintf("Validation completed. Valid: %d, Invalid: %d, Duplicates: %d",
+				validationResult.ValidRecords, validationResult.InvalidRecords, validationResult.DuplicateRecords),
 		}, nil
 	}
 
+	// Large uploads are read record by record instead of being parsed whole
+	if s.shouldStream(req) {
+		return s.streamBulkAddition(ctx, req)
+	}
+
+	// Parse input data
+	farmers, err := s.parseInputData(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse input data: %w", err)
+	}
+
+	if len(farmers) == 0 {
+		return nil, fmt.Errorf("no valid farmer records found in input")
+	}
+
 	// Create bulk operation record
 	bulkOp := s.createBulkOperation(req, len(farmers))
 	if err := s.bulkOpRepo.Create(ctx, bulkOp); err != nil {
@@ -143,25 +214,13 @@ func (s *BulkFarmerServiceImpl) BulkAddFarmersToFPO(ctx context.Context, req *re
 		s.logger.Error(fmt.Sprintf("Failed to create processing details: %v", err))
 	}
 
-	// Extract user context to propagate to background goroutine
-	// This ensures FPO linkage and other operations have access to authenticated user
-	bgCtx := context.Background()
-	if userCtx, err := auth.GetUserFromContext(ctx); err == nil {
-		bgCtx = auth.SetUserInContext(bgCtx, userCtx)
-	}
-
-	// Determine processing strategy
-	if req.ProcessingMode == "sync" || len(farmers) <= s.config.MaxSyncRecords {
-		// Process synchronously
-		go s.processSynchronously(bgCtx, bulkOp, farmers, req.Options)
+	// Determine processing strategy and start processing in the background
+	records := newBulkRecords(farmers)
+	s.startProcessing(backgroundContext(ctx), bulkOp, records, req.ProcessingMode, req.Options)
 
-		// For sync mode with small batches, wait a bit to get initial progress
-		if req.ProcessingMode == "sync" && len(farmers) <= 10 {
-			time.Sleep(100 * time.Millisecond)
-		}
-	} else {
-		// Process asynchronously
-		go s.processAsynchronously(bgCtx, bulkOp, farmers, req.Options)
+	// For sync mode with small batches, wait a bit to get initial progress
+	if req.ProcessingMode == "sync" && len(farmers) <= 10 {
+		time.Sleep(100 * time.Millisecond)
 	}
 
 	// Return operation info
@@ -169,33 +228,108 @@ func (s *BulkFarmerServiceImpl) BulkAddFarmersToFPO(ctx context.Context, req *re
 		OperationID: bulkOp.ID,
 		Status:      string(bulkOp.Status),
 		StatusURL:   fmt.Sprintf("/api/v1/bulk/status/%s", bulkOp.ID),
-		ResultURL:   fmt.Sprintf("/api/v1/bulk/results/%s", bulkOp.ID),
+		ResultURL:   resultFileURL(bulkOp.ID),
 		Message:     fmt.Sprintf("Bulk operation initiated for %d farmers", len(farmers)),
 	}, nil
 }
 
+// backgroundContext returns a context for background processing that carries the
+// authenticated user of ctx, so FPO linkage and other operations can use it
+func backgroundContext(ctx context.Context) context.Context {
+	bgCtx := context.Background()
+	if userCtx, err := auth.GetUserFromContext(ctx); err == nil {
+		bgCtx = auth.SetUserInContext(bgCtx, userCtx)
+	}
+	return bgCtx
+}
+
+// startProcessing processes the records of an operation. Small and sync operations run in
+// a background goroutine of this instance; larger ones are queued for the job workers of
+// any replica. The stop control is registered before the goroutine starts so an early
+// pause is not lost.
+func (s *BulkFarmerServiceImpl) startProcessing(ctx context.Context, bulkOp *bulk.BulkOperation, records []bulkRecord, processingMode string, options requests.BulkProcessingOptions) {
+	if !strings.EqualFold(processingMode, "sync") && len(records) > s.config.MaxSyncRecords {
+		err := s.enqueue(ctx, bulkOp)
+		if err == nil {
+			return
+		}
+		s.logger.Error(fmt.Sprintf("Failed to queue bulk operation, processing in-process: operation_id=%s, error=%v", bulkOp.ID, err))
+	}
+
+	control := s.registerControl(bulkOp.ID)
+	go s.processSynchronously(ctx, bulkOp, records, options, control)
+}
+
+// enqueue hands an operation to the job workers, reactivating it when it was paused
+func (s *BulkFarmerServiceImpl) enqueue(ctx context.Context, bulkOp *bulk.BulkOperation) error {
+	if err := s.queueRepo.Enqueue(ctx, bulkOp.ID); err != nil {
+		return err
+	}
+
+	if bulkOp.Status == bulk.StatusPaused {
+		return s.bulkOpRepo.UpdateStatus(ctx, bulkOp.ID, bulk.StatusProcessing)
+	}
+
+	s.logger.Info(fmt.Sprintf("Queued bulk operation: operation_id=%s, total_records=%d", bulkOp.ID, bulkOp.TotalRecords))
+	return nil
+}
+
+// startHeartbeat keeps an in-process run from being recovered as orphaned. The returned
+// function stops the heartbeat and must be called when the run ends.
+func (s *BulkFarmerServiceImpl) startHeartbeat(ctx context.Context, operationID string) func() {
+	_ = s.queueRepo.Heartbeat(ctx, operationID, s.instanceID)
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(s.config.HeartbeatInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := s.queueRepo.Heartbeat(ctx, operationID, s.instanceID); err != nil {
+					s.logger.Error(fmt.Sprintf("Failed to record heartbeat: operation_id=%s, error=%v", operationID, err))
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
+
 // processSynchronously processes farmers synchronously
-func (s *BulkFarmerServiceImpl) processSynchronously(ctx context.Context, bulkOp *bulk.BulkOperation, farmers []*requests.FarmerBulkData, options requests.BulkProcessingOptions) {
+func (s *BulkFarmerServiceImpl) processSynchronously(ctx context.Context, bulkOp *bulk.BulkOperation, records []bulkRecord, options requests.BulkProcessingOptions, control *operationControl) {
+	defer s.releaseControl(bulkOp.ID)
+	defer s.startHeartbeat(ctx, bulkOp.ID)()
+
 	s.logger.Info(fmt.Sprintf("Starting synchronous processing: operation_id=%s, total_farmers=%d",
-		bulkOp.ID, len(farmers)))
+		bulkOp.ID, len(records)))
 
 	// Update status to processing
 	_ = s.bulkOpRepo.UpdateStatus(ctx, bulkOp.ID, bulk.StatusProcessing)
 
-	var processed, successful, failed, skipped int
+	// Counts continue from earlier runs when a paused operation is resumed
+	processed, successful, failed, skipped := bulkOp.ProcessedRecords, bulkOp.SuccessfulRecords, bulkOp.FailedRecords, bulkOp.SkippedRecords
+
+	for i, record := range records {
+		// Stop between rows when the operation was paused or cancelled
+		if control.stopped() {
+			break
+		}
 
-	for i, farmer := range farmers {
 		// Process individual farmer
-		procCtx, err := s.processSingleFarmer(ctx, bulkOp, farmer, i, options)
+		procCtx, err := s.processSingleFarmer(ctx, bulkOp, record.farmer, record.index, options)
 
 		processed++
 		if err != nil {
 			failed++
 			s.logger.Error(fmt.Sprintf("Failed to process farmer: index=%d, phone=%s, error=%v",
-				i, farmer.PhoneNumber, err))
+				record.index, record.farmer.PhoneNumber, err))
 
 			// Update processing detail with error
-			detail, _ := s.getProcessingDetailByIndex(ctx, bulkOp.ID, i)
+			detail, _ := s.getProcessingDetailByIndex(ctx, bulkOp.ID, record.index)
 			if detail != nil {
 				detail.SetFailed(err.Error(), "PROCESSING_ERROR")
 				_ = s.processingRepo.Update(ctx, detail)
@@ -208,7 +342,7 @@ func (s *BulkFarmerServiceImpl) processSynchronously(ctx context.Context, bulkOp
 			successful++
 
 			// Update processing detail with success
-			detail, _ := s.getProcessingDetailByIndex(ctx, bulkOp.ID, i)
+			detail, _ := s.getProcessingDetailByIndex(ctx, bulkOp.ID, record.index)
 			if detail != nil {
 				// Extract farmer ID and AAA user ID from processing context
 				farmerID, aaaUserID := s.extractIDsFromContext(procCtx)
@@ -217,23 +351,34 @@ func (s *BulkFarmerServiceImpl) processSynchronously(ctx context.Context, bulkOp
 			}
 		}
 
-		// Update progress periodically (but not on last iteration to avoid race)
-		if processed%10 == 0 && processed != len(farmers) {
+		// Update progress periodically (but not on last iteration to avoid race) and pick up
+		// pause or cancel requests made through another instance
+		if (i+1)%10 == 0 && i+1 != len(records) {
 			_ = s.bulkOpRepo.UpdateProgress(ctx, bulkOp.ID, processed, successful, failed, skipped)
+			s.stopRequested(ctx, bulkOp.ID, control)
 		}
 	}
 
-	// Determine final status FIRST before any DB updates
+	// Update progress with final counts FIRST
+	_ = s.bulkOpRepo.UpdateProgress(ctx, bulkOp.ID, processed, successful, failed, skipped)
+
+	// A stopped operation keeps its remaining records PENDING so it can be resumed
+	if status, stopped := control.stopStatus(); stopped {
+		_ = s.bulkOpRepo.UpdateStatus(ctx, bulkOp.ID, status)
+		s.logger.Info(fmt.Sprintf("Synchronous processing stopped: operation_id=%s, status=%s, processed=%d",
+			bulkOp.ID, status, processed))
+		return
+	}
+
+	// Determine final status
 	finalStatus := bulk.StatusCompleted
 	if failed > 0 && successful == 0 {
 		finalStatus = bulk.StatusFailed
 	}
 
-	// Update progress with final counts FIRST
-	_ = s.bulkOpRepo.UpdateProgress(ctx, bulkOp.ID, processed, successful, failed, skipped)
-
 	// Then update status (this will set end_time and processing_time correctly)
 	_ = s.bulkOpRepo.UpdateStatus(ctx, bulkOp.ID, finalStatus)
+	s.publishResultFile(ctx, bulkOp.ID)
 
 	s.logger.Info("Synchronous processing completed",
 		bulkOp.ID,
@@ -242,105 +387,6 @@ func (s *BulkFarmerServiceImpl) processSynchronously(ctx context.Context, bulkOp
 	)
 }
 
-// processAsynchronously processes farmers asynchronously using worker pool
-func (s *BulkFarmerServiceImpl) processAsynchronously(ctx context.Context, bulkOp *bulk.BulkOperation, farmers []*requests.FarmerBulkData, options requests.BulkProcessingOptions) {
-	s.logger.Info("Starting asynchronous processing",
-		bulkOp.ID,
-		len(farmers),
-		options.ChunkSize,
-		options.MaxConcurrency,
-	)
-
-	// Update status to processing
-	_ = s.bulkOpRepo.UpdateStatus(ctx, bulkOp.ID, bulk.StatusProcessing)
-
-	// Create chunks
-	chunks := s.createChunks(farmers, options.ChunkSize)
-
-	// Process chunks with worker pool
-	var wg sync.WaitGroup
-	semaphore := make(chan struct{}, options.MaxConcurrency)
-
-	progressChan := make(chan progressUpdate, len(farmers))
-	go s.aggregateProgress(ctx, bulkOp.ID, progressChan, len(farmers))
-
-	for chunkIndex, chunk := range chunks {
-		wg.Add(1)
-		semaphore <- struct{}{} // Acquire semaphore
-
-		go func(chunkIdx int, chunkData []*requests.FarmerBulkData) {
-			defer func() {
-				<-semaphore // Release semaphore
-				wg.Done()
-			}()
-
-			s.processChunk(ctx, bulkOp, chunkIdx, chunkData, options, progressChan)
-		}(chunkIndex, chunk)
-	}
-
-	wg.Wait()
-	close(progressChan)
-
-	// Update final status
-	_ = s.bulkOpRepo.UpdateStatus(ctx, bulkOp.ID, bulk.StatusCompleted)
-
-	s.logger.Info("Asynchronous processing completed",
-		bulkOp.ID,
-	)
-}
-
-// processChunk processes a chunk of farmers
-func (s *BulkFarmerServiceImpl) processChunk(ctx context.Context, bulkOp *bulk.BulkOperation, chunkIndex int, farmers []*requests.FarmerBulkData, options requests.BulkProcessingOptions, progressChan chan<- progressUpdate) {
-	s.logger.Debug("Processing chunk",
-		bulkOp.ID,
-		chunkIndex,
-		len(farmers),
-	)
-
-	for i, farmer := range farmers {
-		globalIndex := chunkIndex*options.ChunkSize + i
-		procCtx, err := s.processSingleFarmer(ctx, bulkOp, farmer, globalIndex, options)
-
-		update := progressUpdate{
-			processed: 1,
-		}
-
-		if err != nil {
-			update.failed = 1
-			s.logger.Error("Failed to process farmer in chunk",
-				chunkIndex,
-				i,
-				err,
-			)
-
-			// Update processing detail with error
-			detail, _ := s.getProcessingDetailByIndex(ctx, bulkOp.ID, globalIndex)
-			if detail != nil {
-				detail.SetFailed(err.Error(), "PROCESSING_ERROR")
-				_ = s.processingRepo.Update(ctx, detail)
-			}
-
-			if !options.ContinueOnError {
-				progressChan <- update
-				return
-			}
-		} else {
-			update.successful = 1
-
-			// Update processing detail with success
-			detail, _ := s.getProcessingDetailByIndex(ctx, bulkOp.ID, globalIndex)
-			if detail != nil {
-				// Extract farmer ID and AAA user ID from processing context
-				farmerID, aaaUserID := s.extractIDsFromContext(procCtx)
-				detail.SetSuccess(farmerID, aaaUserID)
-				_ = s.processingRepo.Update(ctx, detail)
-			}
-		}
-
-		progressChan <- update
-	}
-}
-
 // extractIDsFromContext extracts farmer ID and AAA user ID from processing context
 func (s *BulkFarmerServiceImpl) extractIDsFromContext(procCtx *pipeline.ProcessingContext) (string, string) {
 	farmerID := ""
@@ -427,6 +473,15 @@ func (s *BulkFarmerServiceImpl) buildProcessingPipeline(options requests.BulkPro
 	return pipe
 }
 
+// buildValidationPipeline builds the side-effect free part of the processing pipeline
+// used for dry-run validation. Deduplication is always included so duplicates are reported
+// regardless of the deduplication mode.
+func (s *BulkFarmerServiceImpl) buildValidationPipeline() pipeline.ProcessingPipeline {
+	return pipeline.NewPipeline(s.logger).
+		AddStage(pipeline.NewValidationStage(s.logger)).
+		AddStage(pipeline.NewDeduplicationStage(s.farmerService, s.logger))
+}
+
 // GetBulkOperationStatus retrieves the status of a bulk operation
 func (s *BulkFarmerServiceImpl) GetBulkOperationStatus(ctx context.Context, operationID string) (*responses.BulkOperationStatusData, error) {
 	bulkOp, err := s.bulkOpRepo.GetByID(ctx, operationID)
@@ -451,9 +506,10 @@ func (s *BulkFarmerServiceImpl) GetBulkOperationStatus(ctx context.Context, oper
 
 	// Build status response
 	status := &responses.BulkOperationStatusData{
-		OperationID: bulkOp.ID,
-		FPOOrgID:    bulkOp.FPOOrgID,
-		Status:      string(bulkOp.Status),
+		OperationID:   bulkOp.ID,
+		OperationType: string(bulkOp.OperationType),
+		FPOOrgID:      bulkOp.FPOOrgID,
+		Status:        string(bulkOp.Status),
 		Progress: responses.ProgressInfo{
 			Total:      bulkOp.TotalRecords,
 			Processed:  bulkOp.ProcessedRecords,
@@ -478,7 +534,8 @@ func (s *BulkFarmerServiceImpl) GetBulkOperationStatus(ctx context.Context, oper
 	return status, nil
 }
 
-// CancelBulkOperation cancels a bulk operation
+// CancelBulkOperation cancels a bulk operation. Running processing stops after the record in
+// flight; records that were not reached stay PENDING.
 func (s *BulkFarmerServiceImpl) CancelBulkOperation(ctx context.Context, operationID string) error {
 	bulkOp, err := s.bulkOpRepo.GetByID(ctx, operationID)
 	if err != nil {
@@ -489,9 +546,108 @@ func (s *BulkFarmerServiceImpl) CancelBulkOperation(ctx context.Context, operati
 		return fmt.Errorf("operation is already complete")
 	}
 
+	s.signalStop(operationID, bulk.StatusCancelled)
 	return s.bulkOpRepo.UpdateStatus(ctx, operationID, bulk.StatusCancelled)
 }
 
+// PauseBulkOperation pauses a pending or processing bulk operation. Processing stops after
+// the record in flight and can be continued with ResumeBulkOperation.
+func (s *BulkFarmerServiceImpl) PauseBulkOperation(ctx context.Context, operationID string) error {
+	bulkOp, err := s.bulkOpRepo.GetByID(ctx, operationID)
+	if err != nil {
+		return fmt.Errorf("failed to get bulk operation: %w", err)
+	}
+
+	if !bulkOp.CanPause() {
+		return fmt.Errorf("operation cannot be paused: status=%s", bulkOp.Status)
+	}
+
+	s.signalStop(operationID, bulk.StatusPaused)
+	return s.bulkOpRepo.UpdateStatus(ctx, operationID, bulk.StatusPaused)
+}
+
+// ResumeBulkOperation continues a paused bulk operation with the records that are still PENDING
+func (s *BulkFarmerServiceImpl) ResumeBulkOperation(ctx context.Context, operationID string) (*responses.BulkOperationData, error) {
+	s.logger.Info(fmt.Sprintf("Resuming bulk operation: operation_id=%s", operationID))
+
+	bulkOp, err := s.bulkOpRepo.GetByID(ctx, operationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bulk operation: %w", err)
+	}
+
+	if !bulkOp.CanResume() {
+		return nil, fmt.Errorf("operation cannot be resumed: status=%s", bulkOp.Status)
+	}
+
+	// The paused run finishes its record in flight before releasing the operation
+	if _, running := s.controls.Load(operationID); running {
+		return nil, fmt.Errorf("operation cannot be resumed: still stopping, try again shortly")
+	}
+
+	pendingDetails, err := s.processingRepo.GetByStatus(ctx, operationID, bulk.RecordStatusPending)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending records: %w", err)
+	}
+
+	// Only farmer additions run in-process; other operation types are always queued
+	if len(pendingDetails) > 0 && !bulkOp.IsFarmerAddition() {
+		if err := s.enqueue(ctx, bulkOp); err != nil {
+			return nil, fmt.Errorf("failed to queue bulk operation: %w", err)
+		}
+
+		return &responses.BulkOperationData{
+			OperationID: operationID,
+			Status:      string(bulk.StatusProcessing),
+			StatusURL:   fmt.Sprintf("/api/v1/bulk/status/%s", operationID),
+			ResultURL:   resultFileURL(operationID),
+			Message:     fmt.Sprintf("Bulk operation resumed for %d remaining records", len(pendingDetails)),
+		}, nil
+	}
+
+	records := make([]bulkRecord, 0, len(pendingDetails))
+	for _, detail := range pendingDetails {
+		farmer, err := farmerFromDetail(detail)
+		if err != nil {
+			s.logger.Error(fmt.Sprintf("Failed to restore farmer data: detail_id=%s, error=%v", detail.ID, err))
+			continue
+		}
+		records = append(records, bulkRecord{index: detail.RecordIndex, farmer: farmer})
+	}
+
+	if len(records) == 0 {
+		// Everything was processed before the pause took effect
+		finalStatus := bulk.StatusCompleted
+		if bulkOp.FailedRecords > 0 && bulkOp.SuccessfulRecords == 0 {
+			finalStatus = bulk.StatusFailed
+		}
+		if err := s.bulkOpRepo.UpdateStatus(ctx, operationID, finalStatus); err != nil {
+			return nil, err
+		}
+		s.publishResultFile(ctx, operationID)
+
+		return &responses.BulkOperationData{
+			OperationID: operationID,
+			Status:      string(finalStatus),
+			StatusURL:   fmt.Sprintf("/api/v1/bulk/status/%s", operationID),
+			ResultURL:   resultFileURL(operationID),
+			Message:     "No remaining records to process",
+		}, nil
+	}
+
+	s.startProcessing(backgroundContext(ctx), bulkOp, records, string(bulkOp.ProcessingMode), s.optionsFromOperation(bulkOp))
+
+	s.logger.Info(fmt.Sprintf("Resumed bulk operation: operation_id=%s, remaining=%d, next_index=%d",
+		operationID, len(records), records[0].index))
+
+	return &responses.BulkOperationData{
+		OperationID: operationID,
+		Status:      string(bulk.StatusProcessing),
+		StatusURL:   fmt.Sprintf("/api/v1/bulk/status/%s", operationID),
+		ResultURL:   resultFileURL(operationID),
+		Message:     fmt.Sprintf("Bulk operation resumed for %d remaining farmers", len(records)),
+	}, nil
+}
+
 // RetryFailedRecords retries failed records from a bulk operation
 // Uses exponential backoff retry logic with circuit breaker pattern
 func (s *BulkFarmerServiceImpl) RetryFailedRecords(ctx context.Context, req *requests.RetryBulkOperationRequest) (*responses.BulkOperationData, error) {
@@ -519,6 +675,7 @@ func (s *BulkFarmerServiceImpl) RetryFailedRecords(ctx context.Context, req *req
 
 	// Create new bulk operation for retry
 	retryOp := bulk.NewBulkOperation()
+	retryOp.OperationType = originalOp.OperationType
 	retryOp.FPOOrgID = originalOp.FPOOrgID
 	retryOp.InitiatedBy = originalOp.InitiatedBy
 	retryOp.TotalRecords = len(failedDetails)
@@ -529,6 +686,12 @@ func (s *BulkFarmerServiceImpl) RetryFailedRecords(ctx context.Context, req *req
 		"retry_of":      originalOp.ID,
 		"retry_attempt": "1",
 	}
+	if !originalOp.IsFarmerAddition() {
+		for key, value := range originalOp.Options {
+			retryOp.Options[key] = value
+		}
+		retryOp.Options["continue_on_error"] = true
+	}
 	now := time.Now()
 	retryOp.StartTime = &now
 
@@ -539,21 +702,25 @@ func (s *BulkFarmerServiceImpl) RetryFailedRecords(ctx context.Context, req *req
 	s.logger.Info(fmt.Sprintf("Created retry operation: retry_id=%s, original_id=%s, retrying=%d records",
 		retryOp.ID, originalOp.ID, len(failedDetails)))
 
+	if !originalOp.IsFarmerAddition() {
+		return s.retryQueued(ctx, originalOp, retryOp, failedDetails)
+	}
+
 	// Reconstruct farmer data from failed details
 	farmers := make([]*requests.FarmerBulkData, 0, len(failedDetails))
 	for _, detail := range failedDetails {
-		var farmerData requests.FarmerBulkData
-		// Convert InputData map to FarmerBulkData
-		inputJSON, err := json.Marshal(detail.InputData)
+		farmerData, err := farmerFromDetail(detail)
 		if err != nil {
-			s.logger.Error(fmt.Sprintf("Failed to marshal input data: detail_id=%s, error=%v", detail.ID, err))
-			continue
-		}
-		if err := json.Unmarshal(inputJSON, &farmerData); err != nil {
-			s.logger.Error(fmt.Sprintf("Failed to unmarshal farmer data: detail_id=%s, error=%v", detail.ID, err))
+			s.logger.Error(fmt.Sprintf("Failed to restore farmer data: detail_id=%s, error=%v", detail.ID, err))
 			continue
 		}
-		farmers = append(farmers, &farmerData)
+		farmers = append(farmers, farmerData)
+	}
+
+	// Track retried records under the retry operation so it gets its own result file
+	retryDetails := s.createProcessingDetails(retryOp.ID, farmers)
+	if err := s.processingRepo.CreateBatch(ctx, retryDetails); err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to create retry processing details: %v", err))
 	}
 
 	// Prepare retry configuration with exponential backoff
@@ -573,10 +740,12 @@ func (s *BulkFarmerServiceImpl) RetryFailedRecords(ctx context.Context, req *req
 	}
 
 	// Process retries asynchronously with exponential backoff
+	records := newBulkRecords(farmers)
+	control := s.registerControl(retryOp.ID)
 	if s.config.EnableAsync && len(farmers) > s.config.MaxSyncRecords {
-		go s.processRetriesAsynchronously(context.Background(), retryOp, farmers, options, retryConfig)
+		go s.processRetriesAsynchronously(context.Background(), retryOp, records, options, retryConfig, control)
 	} else {
-		go s.processRetriesSynchronously(context.Background(), retryOp, farmers, options, retryConfig)
+		go s.processRetriesSynchronously(context.Background(), retryOp, records, options, retryConfig, control)
 	}
 
 	// Return operation info
@@ -584,25 +753,61 @@ func (s *BulkFarmerServiceImpl) RetryFailedRecords(ctx context.Context, req *req
 		OperationID: retryOp.ID,
 		Status:      string(retryOp.Status),
 		StatusURL:   fmt.Sprintf("/api/v1/bulk/status/%s", retryOp.ID),
-		ResultURL:   fmt.Sprintf("/api/v1/bulk/results/%s", retryOp.ID),
+		ResultURL:   resultFileURL(retryOp.ID),
 		Message:     fmt.Sprintf("Retry operation initiated for %d failed records from operation %s", len(farmers), originalOp.ID),
 	}, nil
 }
 
+// retryQueued queues the failed records of an operation that is processed by the job
+// workers. The records are copied unchanged; the workers' own lease recovery takes the
+// place of the in-process backoff used for farmer additions.
+func (s *BulkFarmerServiceImpl) retryQueued(ctx context.Context, originalOp, retryOp *bulk.BulkOperation, failedDetails []*bulk.ProcessingDetail) (*responses.BulkOperationData, error) {
+	retryDetails := make([]*bulk.ProcessingDetail, 0, len(failedDetails))
+	for i, failed := range failedDetails {
+		detail := bulk.NewProcessingDetail(retryOp.ID, i)
+		detail.ExternalID = failed.ExternalID
+		detail.InputData = failed.InputData
+		retryDetails = append(retryDetails, detail)
+	}
+
+	if err := s.processingRepo.CreateBatch(ctx, retryDetails); err != nil {
+		return nil, fmt.Errorf("failed to create retry processing details: %w", err)
+	}
+	if err := s.enqueue(ctx, retryOp); err != nil {
+		return nil, fmt.Errorf("failed to queue retry operation: %w", err)
+	}
+
+	return &responses.BulkOperationData{
+		OperationID: retryOp.ID,
+		Status:      string(retryOp.Status),
+		StatusURL:   fmt.Sprintf("/api/v1/bulk/status/%s", retryOp.ID),
+		ResultURL:   resultFileURL(retryOp.ID),
+		Message:     fmt.Sprintf("Retry operation queued for %d failed records from operation %s", len(retryDetails), originalOp.ID),
+	}, nil
+}
+
 // processRetriesSynchronously processes retry records with exponential backoff
-func (s *BulkFarmerServiceImpl) processRetriesSynchronously(ctx context.Context, retryOp *bulk.BulkOperation, farmers []*requests.FarmerBulkData, options requests.BulkProcessingOptions, retryConfig utils.RetryConfig) {
-	s.logger.Info(fmt.Sprintf("Starting synchronous retry processing: retry_id=%s, total=%d", retryOp.ID, len(farmers)))
+func (s *BulkFarmerServiceImpl) processRetriesSynchronously(ctx context.Context, retryOp *bulk.BulkOperation, records []bulkRecord, options requests.BulkProcessingOptions, retryConfig utils.RetryConfig, control *operationControl) {
+	defer s.releaseControl(retryOp.ID)
+	defer s.startHeartbeat(ctx, retryOp.ID)()
+
+	s.logger.Info(fmt.Sprintf("Starting synchronous retry processing: retry_id=%s, total=%d", retryOp.ID, len(records)))
 
 	_ = s.bulkOpRepo.UpdateStatus(ctx, retryOp.ID, bulk.StatusProcessing)
 
 	var processed, successful, failed int
 
-	for i, farmer := range farmers {
+	for _, record := range records {
+		// Stop between rows when the operation was paused or cancelled
+		if control.stopped() {
+			break
+		}
+
 		// Process with retry logic and exponential backoff
 		var procCtx *pipeline.ProcessingContext
 		err := utils.RetryWithBackoff(ctx, retryConfig, func() error {
 			var err error
-			procCtx, err = s.processSingleFarmer(ctx, retryOp, farmer, i, options)
+			procCtx, err = s.processSingleFarmer(ctx, retryOp, record.farmer, record.index, options)
 			return err
 		})
 
@@ -610,20 +815,20 @@ func (s *BulkFarmerServiceImpl) processRetriesSynchronously(ctx context.Context,
 		if err != nil {
 			failed++
 			s.logger.Error(fmt.Sprintf("Retry failed after %d attempts: index=%d, phone=%s, error=%v",
-				retryConfig.MaxAttempts, i, farmer.PhoneNumber, err))
+				retryConfig.MaxAttempts, record.index, record.farmer.PhoneNumber, err))
 
 			// Update processing detail with error
-			detail, _ := s.getProcessingDetailByIndex(ctx, retryOp.ID, i)
+			detail, _ := s.getProcessingDetailByIndex(ctx, retryOp.ID, record.index)
 			if detail != nil {
 				detail.SetFailed(err.Error(), "RETRY_FAILED")
 				_ = s.processingRepo.Update(ctx, detail)
 			}
 		} else {
 			successful++
-			s.logger.Info(fmt.Sprintf("Retry succeeded: index=%d, phone=%s", i, farmer.PhoneNumber))
+			s.logger.Info(fmt.Sprintf("Retry succeeded: index=%d, phone=%s", record.index, record.farmer.PhoneNumber))
 
 			// Update processing detail with success
-			detail, _ := s.getProcessingDetailByIndex(ctx, retryOp.ID, i)
+			detail, _ := s.getProcessingDetailByIndex(ctx, retryOp.ID, record.index)
 			if detail != nil {
 				// Extract farmer ID and AAA user ID from processing context
 				farmerID, aaaUserID := s.extractIDsFromContext(procCtx)
@@ -633,11 +838,20 @@ func (s *BulkFarmerServiceImpl) processRetriesSynchronously(ctx context.Context,
 		}
 
 		// Update progress
-		if processed%10 == 0 || processed == len(farmers) {
+		if processed%10 == 0 || processed == len(records) {
 			_ = s.bulkOpRepo.UpdateProgress(ctx, retryOp.ID, processed, successful, failed, 0)
+			s.stopRequested(ctx, retryOp.ID, control)
 		}
 	}
 
+	if status, stopped := control.stopStatus(); stopped {
+		_ = s.bulkOpRepo.UpdateProgress(ctx, retryOp.ID, processed, successful, failed, 0)
+		_ = s.bulkOpRepo.UpdateStatus(ctx, retryOp.ID, status)
+		s.logger.Info(fmt.Sprintf("Retry processing stopped: retry_id=%s, status=%s, processed=%d",
+			retryOp.ID, status, processed))
+		return
+	}
+
 	// Final status
 	finalStatus := bulk.StatusCompleted
 	if failed > 0 && successful == 0 {
@@ -645,45 +859,62 @@ func (s *BulkFarmerServiceImpl) processRetriesSynchronously(ctx context.Context,
 	}
 	_ = s.bulkOpRepo.UpdateStatus(ctx, retryOp.ID, finalStatus)
 	_ = s.bulkOpRepo.UpdateProgress(ctx, retryOp.ID, processed, successful, failed, 0)
+	s.publishResultFile(ctx, retryOp.ID)
 
 	s.logger.Info(fmt.Sprintf("Retry processing completed: retry_id=%s, successful=%d, failed=%d",
 		retryOp.ID, successful, failed))
 }
 
 // processRetriesAsynchronously processes retry records asynchronously with exponential backoff
-func (s *BulkFarmerServiceImpl) processRetriesAsynchronously(ctx context.Context, retryOp *bulk.BulkOperation, farmers []*requests.FarmerBulkData, options requests.BulkProcessingOptions, retryConfig utils.RetryConfig) {
-	s.logger.Info(fmt.Sprintf("Starting asynchronous retry processing: retry_id=%s, total=%d", retryOp.ID, len(farmers)))
+func (s *BulkFarmerServiceImpl) processRetriesAsynchronously(ctx context.Context, retryOp *bulk.BulkOperation, records []bulkRecord, options requests.BulkProcessingOptions, retryConfig utils.RetryConfig, control *operationControl) {
+	defer s.releaseControl(retryOp.ID)
+	defer s.startHeartbeat(ctx, retryOp.ID)()
+
+	s.logger.Info(fmt.Sprintf("Starting asynchronous retry processing: retry_id=%s, total=%d", retryOp.ID, len(records)))
 
 	_ = s.bulkOpRepo.UpdateStatus(ctx, retryOp.ID, bulk.StatusProcessing)
 
 	// Process with worker pool and retry logic
-	chunks := s.createChunks(farmers, options.ChunkSize)
+	chunks := s.createChunks(records, options.ChunkSize)
 	var wg sync.WaitGroup
 	semaphore := make(chan struct{}, options.MaxConcurrency)
 
-	progressChan := make(chan progressUpdate, len(farmers))
-	go s.aggregateProgress(ctx, retryOp.ID, progressChan, len(farmers))
+	progressChan := make(chan progressUpdate, len(records))
+	progressDone := make(chan struct{})
+	go func() {
+		defer close(progressDone)
+		s.aggregateProgress(ctx, retryOp.ID, progressChan, progressUpdate{})
+	}()
 
-	for chunkIdx, chunk := range chunks {
+	for _, chunk := range chunks {
 		wg.Add(1)
-		go func(idx int, farmerChunk []*requests.FarmerBulkData) {
+		go func(recordChunk []bulkRecord) {
 			defer wg.Done()
 			semaphore <- struct{}{}        // Acquire
 			defer func() { <-semaphore }() // Release
 
-			for i, farmer := range farmerChunk {
-				globalIdx := idx*options.ChunkSize + i
+			// Stop between chunks when the operation was paused or cancelled
+			if s.stopRequested(ctx, retryOp.ID, control) {
+				return
+			}
+
+			for _, record := range recordChunk {
+				// Stop between rows when the operation was paused or cancelled
+				if control.stopped() {
+					return
+				}
+				globalIdx := record.index
 
 				// Process with retry logic and exponential backoff
 				var procCtx *pipeline.ProcessingContext
 				err := utils.RetryWithBackoff(ctx, retryConfig, func() error {
 					var err error
-					procCtx, err = s.processSingleFarmer(ctx, retryOp, farmer, globalIdx, options)
+					procCtx, err = s.processSingleFarmer(ctx, retryOp, record.farmer, globalIdx, options)
 					return err
 				})
 
 				if err != nil {
-					progressChan <- progressUpdate{failed: 1}
+					progressChan <- progressUpdate{processed: 1, failed: 1}
 					s.logger.Error(fmt.Sprintf("Retry failed: index=%d, error=%v", globalIdx, err))
 
 					// Update processing detail with error
@@ -693,7 +924,7 @@ func (s *BulkFarmerServiceImpl) processRetriesAsynchronously(ctx context.Context
 						_ = s.processingRepo.Update(ctx, detail)
 					}
 				} else {
-					progressChan <- progressUpdate{successful: 1}
+					progressChan <- progressUpdate{processed: 1, successful: 1}
 
 					// Update processing detail with success
 					detail, _ := s.getProcessingDetailByIndex(ctx, retryOp.ID, globalIdx)
@@ -705,14 +936,23 @@ func (s *BulkFarmerServiceImpl) processRetriesAsynchronously(ctx context.Context
 					}
 				}
 			}
-		}(chunkIdx, chunk)
+		}(chunk)
 	}
 
 	wg.Wait()
 	close(progressChan)
 
 	// Wait for progress aggregation to complete
-	time.Sleep(1 * time.Second)
+	<-progressDone
+
+	if status, stopped := control.stopStatus(); stopped {
+		_ = s.bulkOpRepo.UpdateStatus(ctx, retryOp.ID, status)
+		s.logger.Info(fmt.Sprintf("Asynchronous retry processing stopped: retry_id=%s, status=%s", retryOp.ID, status))
+		return
+	}
+
+	_ = s.bulkOpRepo.UpdateStatus(ctx, retryOp.ID, bulk.StatusCompleted)
+	s.publishResultFile(ctx, retryOp.ID)
 
 	s.logger.Info(fmt.Sprintf("Asynchronous retry processing completed: retry_id=%s", retryOp.ID))
 }
@@ -721,7 +961,11 @@ func (s *BulkFarmerServiceImpl) processRetriesAsynchronously(ctx context.Context
 
 func (s *BulkFarmerServiceImpl) parseInputData(ctx context.Context, req *requests.BulkFarmerAdditionRequest) ([]*requests.FarmerBulkData, error) {
 	if len(req.Data) > 0 {
-		return s.ParseBulkFile(ctx, req.InputFormat, req.Data)
+		profile, err := s.mappingProfileFor(ctx, req.MappingProfileID, req.FPOOrgID, req.OrgID)
+		if err != nil {
+			return nil, err
+		}
+		return s.parseFile(s.fileParser, req.InputFormat, req.Data, profile)
 	}
 
 	if req.FileURL != "" {
@@ -747,38 +991,174 @@ func (s *BulkFarmerServiceImpl) createBulkOperation(req *requests.BulkFarmerAddi
 	_ = json.Unmarshal(optionsJSON, &optionsMap)
 	bulkOp.Options = optionsMap
 
+	if req.MappingProfileID != "" {
+		bulkOp.Metadata["mapping_profile_id"] = req.MappingProfileID
+	}
+
 	return bulkOp
 }
 
 func (s *BulkFarmerServiceImpl) createProcessingDetails(bulkOperationID string, farmers []*requests.FarmerBulkData) []*bulk.ProcessingDetail {
 	details := make([]*bulk.ProcessingDetail, len(farmers))
 	for i, farmer := range farmers {
-		detail := bulk.NewProcessingDetail(bulkOperationID, i)
-		detail.ExternalID = farmer.ExternalID
-
-		// Store input data
-		farmerJSON, _ := json.Marshal(farmer)
-		var farmerMap map[string]interface{}
-		_ = json.Unmarshal(farmerJSON, &farmerMap)
-		detail.InputData = farmerMap
-
-		details[i] = detail
+		details[i] = newFarmerProcessingDetail(bulkOperationID, i, farmer)
 	}
 	return details
 }
 
-func (s *BulkFarmerServiceImpl) createChunks(farmers []*requests.FarmerBulkData, chunkSize int) [][]*requests.FarmerBulkData {
-	var chunks [][]*requests.FarmerBulkData
-	for i := 0; i < len(farmers); i += chunkSize {
+// newFarmerProcessingDetail creates the processing detail of a farmer record, storing the
+// record as its input data
+func newFarmerProcessingDetail(bulkOperationID string, index int, farmer *requests.FarmerBulkData) *bulk.ProcessingDetail {
+	detail := bulk.NewProcessingDetail(bulkOperationID, index)
+	detail.ExternalID = farmer.ExternalID
+
+	// Store input data
+	farmerJSON, _ := json.Marshal(farmer)
+	var farmerMap map[string]interface{}
+	_ = json.Unmarshal(farmerJSON, &farmerMap)
+	detail.InputData = farmerMap
+
+	return detail
+}
+
+func (s *BulkFarmerServiceImpl) createChunks(records []bulkRecord, chunkSize int) [][]bulkRecord {
+	var chunks [][]bulkRecord
+	for i := 0; i < len(records); i += chunkSize {
 		end := i + chunkSize
-		if end > len(farmers) {
-			end = len(farmers)
+		if end > len(records) {
+			end = len(records)
 		}
-		chunks = append(chunks, farmers[i:end])
+		chunks = append(chunks, records[i:end])
 	}
 	return chunks
 }
 
+// bulkRecord is a farmer record together with its index in the operation's processing details
+type bulkRecord struct {
+	index  int
+	farmer *requests.FarmerBulkData
+}
+
+func newBulkRecords(farmers []*requests.FarmerBulkData) []bulkRecord {
+	records := make([]bulkRecord, len(farmers))
+	for i, farmer := range farmers {
+		records[i] = bulkRecord{index: i, farmer: farmer}
+	}
+	return records
+}
+
+// farmerFromDetail restores the farmer record stored in a processing detail
+func farmerFromDetail(detail *bulk.ProcessingDetail) (*requests.FarmerBulkData, error) {
+	inputJSON, err := json.Marshal(detail.InputData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal input data: %w", err)
+	}
+
+	var farmerData requests.FarmerBulkData
+	if err := json.Unmarshal(inputJSON, &farmerData); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal farmer data: %w", err)
+	}
+
+	return &farmerData, nil
+}
+
+// processDetail adds the farmer stored in a queued processing detail
+func (s *BulkFarmerServiceImpl) processDetail(ctx context.Context, bulkOp *bulk.BulkOperation, detail *bulk.ProcessingDetail, options requests.BulkProcessingOptions) (string, string, error) {
+	farmer, err := farmerFromDetail(detail)
+	if err != nil {
+		return "", "", &recordError{code: "INVALID_INPUT", err: err}
+	}
+
+	procCtx, err := s.processSingleFarmer(ctx, bulkOp, farmer, detail.RecordIndex, options)
+	if err != nil {
+		return "", "", err
+	}
+
+	farmerID, aaaUserID := s.extractIDsFromContext(procCtx)
+	return farmerID, aaaUserID, nil
+}
+
+// optionsFromOperation restores the processing options stored with a bulk operation
+func (s *BulkFarmerServiceImpl) optionsFromOperation(bulkOp *bulk.BulkOperation) requests.BulkProcessingOptions {
+	var options requests.BulkProcessingOptions
+	if optionsJSON, err := json.Marshal(bulkOp.Options); err == nil {
+		_ = json.Unmarshal(optionsJSON, &options)
+	}
+	options.SetDefaults()
+	return options
+}
+
+// operationControl carries a pause or cancel request to the goroutines processing an
+// operation. Processing checks it between records, so the record in flight always completes
+// and its processing detail stays consistent with the operation counts.
+type operationControl struct {
+	stop     chan struct{}
+	stopOnce sync.Once
+	status   bulk.OperationStatus
+}
+
+// requestStop asks processing to stop and leave the operation in the given status
+func (c *operationControl) requestStop(status bulk.OperationStatus) {
+	c.stopOnce.Do(func() {
+		c.status = status
+		close(c.stop)
+	})
+}
+
+func (c *operationControl) stopped() bool {
+	select {
+	case <-c.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// stopStatus returns the status requested for a stopped operation
+func (c *operationControl) stopStatus() (bulk.OperationStatus, bool) {
+	if !c.stopped() {
+		return "", false
+	}
+	return c.status, true
+}
+
+func (s *BulkFarmerServiceImpl) registerControl(operationID string) *operationControl {
+	control := &operationControl{stop: make(chan struct{})}
+	s.controls.Store(operationID, control)
+	return control
+}
+
+func (s *BulkFarmerServiceImpl) releaseControl(operationID string) {
+	s.controls.Delete(operationID)
+}
+
+// signalStop stops processing of the operation if it is running on this instance
+func (s *BulkFarmerServiceImpl) signalStop(operationID string, status bulk.OperationStatus) {
+	if control, ok := s.controls.Load(operationID); ok {
+		control.(*operationControl).requestStop(status)
+	}
+}
+
+// stopRequested reports whether processing should stop, also picking up pause and cancel
+// requests that were handled by another instance and are only visible in the database
+func (s *BulkFarmerServiceImpl) stopRequested(ctx context.Context, operationID string, control *operationControl) bool {
+	if control.stopped() {
+		return true
+	}
+
+	bulkOp, err := s.bulkOpRepo.GetByID(ctx, operationID)
+	if err != nil {
+		return false
+	}
+
+	if bulkOp.Status == bulk.StatusPaused || bulkOp.Status == bulk.StatusCancelled {
+		control.requestStop(bulkOp.Status)
+		return true
+	}
+
+	return false
+}
+
 func (s *BulkFarmerServiceImpl) getProcessingDetailByIndex(ctx context.Context, bulkOperationID string, index int) (*bulk.ProcessingDetail, error) {
 	details, err := s.processingRepo.GetByOperationID(ctx, bulkOperationID)
 	if err != nil {
@@ -801,8 +1181,20 @@ type progressUpdate struct {
 	skipped    int
 }
 
-func (s *BulkFarmerServiceImpl) aggregateProgress(ctx context.Context, operationID string, progressChan <-chan progressUpdate, total int) {
-	var processed, successful, failed, skipped int
+// progressUpdateFrom returns the progress already recorded for an operation
+func progressUpdateFrom(bulkOp *bulk.BulkOperation) progressUpdate {
+	return progressUpdate{
+		processed:  bulkOp.ProcessedRecords,
+		successful: bulkOp.SuccessfulRecords,
+		failed:     bulkOp.FailedRecords,
+		skipped:    bulkOp.SkippedRecords,
+	}
+}
+
+// aggregateProgress collects progress updates on top of the initial counts and writes the
+// totals periodically and once more when the channel is closed
+func (s *BulkFarmerServiceImpl) aggregateProgress(ctx context.Context, operationID string, progressChan <-chan progressUpdate, initial progressUpdate) {
+	processed, successful, failed, skipped := initial.processed, initial.successful, initial.failed, initial.skipped
 
 	updateTicker := time.NewTicker(1 * time.Second)
 	defer updateTicker.Stop()
@@ -831,14 +1223,44 @@ func (s *BulkFarmerServiceImpl) aggregateProgress(ctx context.Context, operation
 	}
 }
 
-// Placeholder methods for unimplemented features
-
+// ValidateBulkData runs the pipeline's validation and deduplication rules over the input
+// as a dry run and returns a per-record report. Nothing is written to AAA or the database.
 func (s *BulkFarmerServiceImpl) ValidateBulkData(ctx context.Context, req *requests.ValidateBulkDataRequest) (*responses.BulkValidationData, error) {
-	// TODO: Implement validation logic
-	return nil, fmt.Errorf("not yet implemented")
+	if req.FPOOrgID == "" {
+		return nil, common.ErrInvalidInput
+	}
+
+	var farmers []*requests.FarmerBulkData
+	if len(req.Data) > 0 {
+		profile, err := s.mappingProfileFor(ctx, req.MappingProfileID, req.FPOOrgID, req.OrgID)
+		if err != nil {
+			return nil, err
+		}
+		parsed, err := s.parseFile(s.validationParser, req.InputFormat, req.Data, profile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse input data: %w", err)
+		}
+		farmers = parsed
+	} else {
+		for i := range req.Farmers {
+			farmers = append(farmers, &req.Farmers[i])
+		}
+	}
+
+	if len(farmers) == 0 {
+		return nil, fmt.Errorf("no farmer records provided")
+	}
+
+	return s.validateFarmers(ctx, req.FPOOrgID, req.DeduplicationMode, farmers)
 }
 
 func (s *BulkFarmerServiceImpl) ParseBulkFile(ctx context.Context, format string, data []byte) ([]*requests.FarmerBulkData, error) {
+	return s.parseFile(s.fileParser, format, data, nil)
+}
+
+// parseFile parses data in the given format with the given parser. Files are read with the
+// column mapping profile when one is given, otherwise they must use the template headers.
+func (s *BulkFarmerServiceImpl) parseFile(parser parsers.FileParser, format string, data []byte, profile *bulk.ColumnMappingProfile) ([]*requests.FarmerBulkData, error) {
 	s.logger.Debug("Parsing bulk file",
 		format,
 		len(data),
@@ -847,32 +1269,135 @@ func (s *BulkFarmerServiceImpl) ParseBulkFile(ctx context.Context, format string
 	var farmers []*requests.FarmerBulkData
 	var err error
 
+	if profile != nil {
+		farmers, err = parser.ParseWithMapping(strings.ToLower(format), data, profile)
+	} else {
+		farmers, err = s.parseTemplateFile(parser, format, data)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s file: %w", format, err)
+	}
+
+	s.logger.Debug("File parsed successfully",
+		format,
+		len(farmers),
+	)
+
+	return farmers, nil
+}
+
+// parseTemplateFile parses a file that uses the upload template headers
+func (s *BulkFarmerServiceImpl) parseTemplateFile(parser parsers.FileParser, format string, data []byte) ([]*requests.FarmerBulkData, error) {
+	var farmers []*requests.FarmerBulkData
+	var err error
+
 	switch strings.ToLower(format) {
 	case "csv":
-		farmers, err = s.fileParser.ParseCSV(data)
+		farmers, err = parser.ParseCSV(data)
 	case "excel", "xlsx", "xls":
-		farmers, err = s.fileParser.ParseExcel(data)
+		farmers, err = parser.ParseExcel(data)
 	case "json":
-		farmers, err = s.fileParser.ParseJSON(data)
+		farmers, err = parser.ParseJSON(data)
 	default:
 		return nil, fmt.Errorf("unsupported file format: %s", format)
 	}
 
+	return farmers, err
+}
+
+// GenerateResultFile builds a result file for a bulk operation from its processing details.
+// Every row repeats the original input followed by its outcome, in a format the file parser
+// accepts, so failed rows can be corrected and uploaded again. When includeAll is false only
+// failed rows are included.
+func (s *BulkFarmerServiceImpl) GenerateResultFile(ctx context.Context, operationID string, format string, includeAll bool) ([]byte, error) {
+	bulkOp, err := s.bulkOpRepo.GetByID(ctx, operationID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse %s file: %w", format, err)
+		return nil, fmt.Errorf("failed to get bulk operation: %w", err)
 	}
 
-	s.logger.Debug("File parsed successfully",
+	details, err := s.processingRepo.GetByOperationID(ctx, operationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get processing details: %w", err)
+	}
+
+	rows := make([]parsers.ResultRow, 0, len(details))
+	for _, detail := range details {
+		if !includeAll && detail.Status != bulk.RecordStatusFailed {
+			continue
+		}
+
+		row := parsers.ResultRow{
+			RecordIndex: detail.RecordIndex,
+			Input:       detail.InputData,
+			Status:      strings.ToLower(string(detail.Status)),
+		}
+		if detail.AAAUserID != nil {
+			row.AAAUserID = *detail.AAAUserID
+		}
+		if detail.FarmerID != nil {
+			row.FarmerID = *detail.FarmerID
+		}
+		if detail.Error != nil {
+			row.Error = *detail.Error
+		}
+		if detail.ErrorCode != nil {
+			row.ErrorCode = *detail.ErrorCode
+		}
+		if farmID, ok := detail.Metadata["farm_id"].(string); ok {
+			row.FarmID = farmID
+		}
+		if cycleID, ok := detail.Metadata["crop_cycle_id"].(string); ok {
+			row.CropCycleID = cycleID
+		}
+		if activityID, ok := detail.Metadata["activity_id"].(string); ok {
+			row.ActivityID = activityID
+		}
+		rows = append(rows, row)
+	}
+
+	s.logger.Debug("Generating bulk result file",
+		operationID,
 		format,
-		len(farmers),
+		len(rows),
 	)
 
-	return farmers, nil
+	writeCSV, writeExcel, writeJSON := parsers.WriteResultCSV, parsers.WriteResultExcel, parsers.WriteResultJSON
+	switch bulkOp.OperationType {
+	case bulk.OperationFarmImport:
+		writeCSV, writeExcel, writeJSON = parsers.WriteFarmResultCSV, parsers.WriteFarmResultExcel, parsers.WriteFarmResultJSON
+	case bulk.OperationCropCycleImport:
+		writeCSV, writeExcel, writeJSON = parsers.WriteCropCycleResultCSV, parsers.WriteCropCycleResultExcel, parsers.WriteCropCycleResultJSON
+	}
+
+	var content []byte
+	switch strings.ToLower(format) {
+	case "csv":
+		content, err = writeCSV(rows)
+	case "excel", "xlsx":
+		content, err = writeExcel(rows)
+	case "json":
+		content, err = writeJSON(rows)
+	default:
+		return nil, fmt.Errorf("unsupported result format: %s", format)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate %s result file: %w", format, err)
+	}
+
+	return content, nil
+}
+
+// publishResultFile records the result file URL once an operation has finished processing
+func (s *BulkFarmerServiceImpl) publishResultFile(ctx context.Context, operationID string) {
+	if err := s.bulkOpRepo.SetResultFileURL(ctx, operationID, resultFileURL(operationID)); err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to set result file URL: operation_id=%s, error=%v", operationID, err))
+	}
 }
 
-func (s *BulkFarmerServiceImpl) GenerateResultFile(ctx context.Context, operationID string, format string) ([]byte, error) {
-	// TODO: Implement result file generation
-	return nil, fmt.Errorf("not yet implemented")
+func resultFileURL(operationID string) string {
+	return fmt.Sprintf("/api/v1/bulk/results/%s", operationID)
 }
 
 func (s *BulkFarmerServiceImpl) GetBulkUploadTemplate(ctx context.Context, format string, includeExample bool) (*responses.BulkTemplateData, error) {
@@ -952,11 +1477,142 @@ Tips:
 	return template, nil
 }
 
-func (s *BulkFarmerServiceImpl) validateFarmers(ctx context.Context, fpoOrgID string, farmers []*requests.FarmerBulkData) (*responses.BulkValidationData, error) {
-	// TODO: Implement validation
-	return &responses.BulkValidationData{
-		IsValid:      true,
+// validateFarmers checks every record with the stages of the validation pipeline and
+// builds the per-record report. Duplicates only count as errors in "error" deduplication
+// mode; in "skip" and "update" modes the real run would not fail on them.
+func (s *BulkFarmerServiceImpl) validateFarmers(ctx context.Context, fpoOrgID, deduplicationMode string, farmers []*requests.FarmerBulkData) (*responses.BulkValidationData, error) {
+	if deduplicationMode == "" {
+		deduplicationMode = "skip"
+	}
+
+	pipe := s.buildValidationPipeline()
+
+	result := &responses.BulkValidationData{
 		TotalRecords: len(farmers),
-		ValidRecords: len(farmers),
-	}, nil
+		Records:      make([]responses.RecordValidationResult, 0, len(farmers)),
+	}
+	errorCodes := make(map[string]int)
+	warningCodes := make(map[string]int)
+	duplicateSources := make(map[string]int)
+
+	for i, farmer := range farmers {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		procCtx := pipeline.NewProcessingContextWithOptions("", fpoOrgID, "", i, farmer, deduplicationMode)
+
+		check := &pipeline.CheckResult{}
+		for _, stage := range pipe.GetStages() {
+			checker, ok := stage.(pipeline.RecordChecker)
+			if !ok {
+				continue
+			}
+			stageResult, err := checker.Check(ctx, procCtx)
+			if err != nil {
+				return nil, fmt.Errorf("%s check failed for record %d: %w", stage.GetName(), i, err)
+			}
+			check.Merge(stageResult)
+		}
+
+		record := responses.RecordValidationResult{
+			RecordIndex: i,
+			ExternalID:  farmer.ExternalID,
+			PhoneNumber: farmer.PhoneNumber,
+			Status:      "VALID",
+		}
+
+		for _, issue := range check.Errors {
+			record.Errors = append(record.Errors, responses.ValidationError{
+				RecordIndex: i,
+				Field:       issue.Field,
+				Value:       issue.Value,
+				Message:     issue.Message,
+				Code:        issue.Code,
+			})
+		}
+		for _, issue := range check.Warnings {
+			record.Warnings = append(record.Warnings, responses.ValidationWarning{
+				RecordIndex: i,
+				Field:       issue.Field,
+				Message:     issue.Message,
+				Code:        issue.Code,
+			})
+		}
+
+		for _, dup := range check.Duplicates {
+			duplicateSources[dup.Source]++
+			record.Duplicates = append(record.Duplicates, responses.DuplicateInfo{
+				Source:            dup.Source,
+				Field:             dup.Field,
+				Value:             dup.Value,
+				DuplicateOfIndex:  dup.RecordIndex,
+				ExistingFarmerID:  dup.FarmerID,
+				ExistingAAAUserID: dup.AAAUserID,
+			})
+
+			message := fmt.Sprintf("duplicate %s %s", dup.Field, dup.Value)
+			if dup.Source == pipeline.DuplicateSourceFile && dup.RecordIndex != nil {
+				message = fmt.Sprintf("%s (first seen in record %d)", message, *dup.RecordIndex)
+			} else if dup.Source == pipeline.DuplicateSourceFPO {
+				message = fmt.Sprintf("%s (farmer %s already belongs to the FPO)", message, dup.FarmerID)
+			}
+
+			if deduplicationMode == "error" {
+				record.Errors = append(record.Errors, responses.ValidationError{
+					RecordIndex: i,
+					Field:       dup.Field,
+					Value:       dup.Value,
+					Message:     message,
+					Code:        "DUPLICATE_RECORD",
+				})
+			} else {
+				record.Warnings = append(record.Warnings, responses.ValidationWarning{
+					RecordIndex: i,
+					Field:       dup.Field,
+					Message:     fmt.Sprintf("%s, record will be handled with deduplication_mode=%s", message, deduplicationMode),
+					Code:        "DUPLICATE_RECORD",
+				})
+			}
+		}
+
+		switch {
+		case len(record.Errors) > 0:
+			record.Status = "INVALID"
+			result.InvalidRecords++
+		case len(record.Duplicates) > 0:
+			record.Status = "DUPLICATE"
+			result.ValidRecords++
+		default:
+			result.ValidRecords++
+		}
+		if len(record.Duplicates) > 0 {
+			result.DuplicateRecords++
+		}
+
+		for _, e := range record.Errors {
+			errorCodes[e.Code]++
+		}
+		for _, w := range record.Warnings {
+			warningCodes[w.Code]++
+		}
+
+		result.Errors = append(result.Errors, record.Errors...)
+		result.Warnings = append(result.Warnings, record.Warnings...)
+		result.Records = append(result.Records, record)
+	}
+
+	result.IsValid = result.InvalidRecords == 0
+	result.Summary = map[string]interface{}{
+		"deduplication_mode": deduplicationMode,
+		"error_codes":        errorCodes,
+		"warning_codes":      warningCodes,
+		"duplicates_in_file": duplicateSources[pipeline.DuplicateSourceFile],
+		"duplicates_in_fpo":  duplicateSources[pipeline.DuplicateSourceFPO],
+	}
+
+	s.logger.Info(fmt.Sprintf("Bulk validation completed: fpo_org_id=%s, total=%d, valid=%d, invalid=%d, duplicates=%d",
+		fpoOrgID, result.TotalRecords, result.ValidRecords, result.InvalidRecords, result.DuplicateRecords))
+
+	return result, nil
 }
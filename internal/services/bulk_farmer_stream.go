@@ -0,0 +1,244 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/bulk"
+	"github.com/Kisanlink/farmers-module/internal/entities/requests"
+	"github.com/Kisanlink/farmers-module/internal/entities/responses"
+	"github.com/Kisanlink/farmers-module/internal/services/parsers"
+)
+
+// shouldStream reports whether an upload is read record by record. Only queued operations
+// are streamed; sync operations and URL uploads are parsed whole.
+func (s *BulkFarmerServiceImpl) shouldStream(req *requests.BulkFarmerAdditionRequest) bool {
+	return s.streamingParser != nil &&
+		!strings.EqualFold(req.ProcessingMode, "sync") &&
+		uploadSize(req) > int64(s.config.StreamThreshold)
+}
+
+// uploadSize returns the size in bytes of the file uploaded with a request
+func uploadSize(req *requests.BulkFarmerAdditionRequest) int64 {
+	if req.File != nil {
+		return req.File.Size
+	}
+	return int64(len(req.Data))
+}
+
+// readUpload reads the uploaded file of a multipart request into Data, for uploads that are
+// parsed whole
+func readUpload(req *requests.BulkFarmerAdditionRequest) error {
+	file, err := req.File.Open()
+	if err != nil {
+		return fmt.Errorf("failed to open upload: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	if req.Data, err = io.ReadAll(file); err != nil {
+		return fmt.Errorf("failed to read upload: %w", err)
+	}
+	req.File = nil
+	return nil
+}
+
+// spoolUpload copies a streamed upload to a temporary file and returns its path. The upload
+// is read from that file after the request has ended, so it is never held in memory whole.
+func spoolUpload(req *requests.BulkFarmerAdditionRequest) (string, error) {
+	var src io.Reader = bytes.NewReader(req.Data)
+	if req.File != nil {
+		file, err := req.File.Open()
+		if err != nil {
+			return "", fmt.Errorf("failed to open upload: %w", err)
+		}
+		defer func() { _ = file.Close() }()
+		src = file
+	}
+
+	tmp, err := os.CreateTemp("", "bulk-upload-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create upload file: %w", err)
+	}
+	if _, err := io.Copy(tmp, src); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return "", fmt.Errorf("failed to copy upload: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return "", fmt.Errorf("failed to copy upload: %w", err)
+	}
+	return tmp.Name(), nil
+}
+
+// streamBulkAddition creates a bulk operation for a large upload and reads its records in
+// the background. It returns once the first chunk of records is queued, so file errors
+// found before any record is read are still reported to the caller.
+func (s *BulkFarmerServiceImpl) streamBulkAddition(ctx context.Context, req *requests.BulkFarmerAdditionRequest) (*responses.BulkOperationData, error) {
+	profile, err := s.mappingProfileFor(ctx, req.MappingProfileID, req.FPOOrgID, req.OrgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse input data: %w", err)
+	}
+
+	upload, err := spoolUpload(req)
+	if err != nil {
+		return nil, err
+	}
+
+	bulkOp := s.createBulkOperation(req, 0)
+	bulkOp.Loading = true
+	if err := s.bulkOpRepo.Create(ctx, bulkOp); err != nil {
+		_ = os.Remove(upload)
+		return nil, fmt.Errorf("failed to create bulk operation: %w", err)
+	}
+
+	ready := make(chan error, 1)
+	go s.loadStream(backgroundContext(ctx), bulkOp, req.InputFormat, upload, profile, ready)
+	if err := <-ready; err != nil {
+		return nil, fmt.Errorf("failed to parse input data: %w", err)
+	}
+
+	return &responses.BulkOperationData{
+		OperationID: bulkOp.ID,
+		Status:      string(bulkOp.Status),
+		StatusURL:   fmt.Sprintf("/api/v1/bulk/status/%s", bulkOp.ID),
+		ResultURL:   resultFileURL(bulkOp.ID),
+		Message:     "Bulk operation initiated, farmers are queued as the file is read",
+	}, nil
+}
+
+// loadStream reads the records of an upload spooled to a file and stores them as processing
+// details, one chunk at a time. The operation is queued after the first chunk, so job workers
+// process records while the rest of the file is read; only one chunk of records is held in
+// memory. Rows failing validation are stored as failed records, so they are counted and
+// listed in the result file. The file is removed once read.
+//
+// ready receives the outcome of the first chunk. When reading fails before the operation is
+// queued the operation and any records stored are deleted; a later failure fails the
+// operation, leaving the records already processed as they are.
+func (s *BulkFarmerServiceImpl) loadStream(ctx context.Context, bulkOp *bulk.BulkOperation, format, upload string, profile *bulk.ColumnMappingProfile, ready chan<- error) {
+	defer s.startHeartbeat(ctx, bulkOp.ID)()
+	defer func() { _ = os.Remove(upload) }()
+
+	chunkSize := s.config.DefaultChunkSize
+	chunk := make([]*bulk.ProcessingDetail, 0, chunkSize)
+	total := 0
+
+	flush := func() error {
+		if len(chunk) == 0 {
+			return nil
+		}
+		if err := s.processingRepo.CreateBatch(ctx, chunk); err != nil {
+			return fmt.Errorf("failed to create processing details: %w", err)
+		}
+		chunk = make([]*bulk.ProcessingDetail, 0, chunkSize)
+
+		if err := s.queueRepo.UpdateLoading(ctx, bulkOp.ID, total, true); err != nil {
+			return err
+		}
+		if ready != nil {
+			if err := s.enqueue(ctx, bulkOp); err != nil {
+				return err
+			}
+			ready <- nil
+			ready = nil
+		}
+		return nil
+	}
+
+	add := func(detail *bulk.ProcessingDetail) error {
+		chunk = append(chunk, detail)
+		total++
+		if len(chunk) < chunkSize {
+			return nil
+		}
+		return flush()
+	}
+
+	err := s.streamFile(format, upload, profile, func(farmer *requests.FarmerBulkData) error {
+		return add(newFarmerProcessingDetail(bulkOp.ID, total, farmer))
+	}, func(rowNumber int, values map[string]string, rowErr error) error {
+		s.logger.Warn(fmt.Sprintf("Invalid row in bulk upload: operation_id=%s, row=%d, error=%v", bulkOp.ID, rowNumber, rowErr))
+		return add(newInvalidRowDetail(bulkOp.ID, total, rowNumber, values, rowErr))
+	})
+	if err == nil {
+		err = flush()
+	}
+
+	if ready != nil {
+		if err == nil {
+			err = fmt.Errorf("no farmer records found in input")
+		}
+		if delErr := s.processingRepo.DeleteByOperationID(ctx, bulkOp.ID); delErr != nil {
+			s.logger.Error(fmt.Sprintf("Failed to delete records of abandoned upload: operation_id=%s, error=%v", bulkOp.ID, delErr))
+		}
+		if delErr := s.bulkOpRepo.Delete(ctx, bulkOp.ID); delErr != nil {
+			s.logger.Error(fmt.Sprintf("Failed to delete abandoned bulk operation: operation_id=%s, error=%v", bulkOp.ID, delErr))
+		}
+		ready <- err
+		return
+	}
+
+	if loadErr := s.queueRepo.UpdateLoading(ctx, bulkOp.ID, total, false); loadErr != nil {
+		s.logger.Error(fmt.Sprintf("Failed to end loading: operation_id=%s, error=%v", bulkOp.ID, loadErr))
+	}
+
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to read bulk upload: operation_id=%s, records_read=%d, error=%v", bulkOp.ID, total, err))
+		_ = s.queueRepo.RefreshProgress(ctx, bulkOp.ID)
+		_ = s.bulkOpRepo.UpdateStatus(ctx, bulkOp.ID, bulk.StatusFailed)
+		s.publishResultFile(ctx, bulkOp.ID)
+		return
+	}
+
+	s.logger.Info(fmt.Sprintf("Bulk upload loaded: operation_id=%s, total_records=%d", bulkOp.ID, total))
+
+	// Workers may have processed every record before loading ended
+	_ = s.queueRepo.RefreshProgress(ctx, bulkOp.ID)
+	if completed, _ := s.queueRepo.CompleteIfDone(ctx, bulkOp.ID); completed {
+		s.publishResultFile(ctx, bulkOp.ID)
+	}
+}
+
+// newInvalidRowDetail records an upload row that failed validation as a failed record, with
+// the row's values as its input and its row number in the file
+func newInvalidRowDetail(bulkOperationID string, index, rowNumber int, values map[string]string, err error) *bulk.ProcessingDetail {
+	detail := bulk.NewProcessingDetail(bulkOperationID, index)
+	for column, value := range values {
+		detail.InputData[column] = value
+	}
+	detail.Metadata["row_number"] = rowNumber
+	detail.SetFailed(fmt.Sprintf("row %d: %v", rowNumber, err), "VALIDATION_ERROR")
+	return detail
+}
+
+// streamFile reads an upload spooled to a file with the streaming parser, with the column
+// mapping profile when one is given, otherwise with the template headers
+func (s *BulkFarmerServiceImpl) streamFile(format, upload string, profile *bulk.ColumnMappingProfile, fn parsers.FarmerRecordFunc, onInvalid parsers.InvalidRowFunc) error {
+	reader, err := os.Open(upload)
+	if err != nil {
+		return fmt.Errorf("failed to open upload: %w", err)
+	}
+	defer func() { _ = reader.Close() }()
+
+	if profile != nil {
+		_, err = s.streamingParser.StreamWithMapping(strings.ToLower(format), reader, profile, fn, onInvalid)
+		return err
+	}
+
+	switch strings.ToLower(format) {
+	case "csv":
+		_, err = s.streamingParser.StreamCSV(reader, fn, onInvalid)
+	case "excel", "xlsx", "xls":
+		_, err = s.streamingParser.StreamExcel(reader, fn, onInvalid)
+	case "json":
+		_, err = s.streamingParser.StreamJSON(reader, fn)
+	default:
+		err = fmt.Errorf("unsupported file format: %s", format)
+	}
+	return err
+}
@@ -0,0 +1,381 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Kisanlink/farmers-module/internal/auth"
+	"github.com/Kisanlink/farmers-module/internal/entities/bulk"
+	"github.com/Kisanlink/farmers-module/internal/entities/requests"
+	"github.com/Kisanlink/farmers-module/internal/interfaces"
+	bulkRepo "github.com/Kisanlink/farmers-module/internal/repo/bulk"
+	"github.com/Kisanlink/farmers-module/internal/services/pipeline"
+)
+
+// Writes recording the outcome of a record are retried before the operation is stopped
+const (
+	outcomeWriteAttempts = 3
+	outcomeWriteBackoff  = 200 * time.Millisecond
+)
+
+// bulkRecordProcessor processes one queued record of a bulk operation. It returns the IDs
+// recorded on the processing detail; errors may carry an error code as a *recordError or,
+// when raised by a pipeline stage, as a *pipeline.PipelineError.
+type bulkRecordProcessor interface {
+	processDetail(ctx context.Context, bulkOp *bulk.BulkOperation, detail *bulk.ProcessingDetail, options requests.BulkProcessingOptions) (farmerID, aaaUserID string, err error)
+}
+
+// recordError is a record failure with the error code reported for the record
+type recordError struct {
+	code string
+	err  error
+}
+
+func (e *recordError) Error() string {
+	return e.err.Error()
+}
+
+func (e *recordError) Unwrap() error {
+	return e.err
+}
+
+// recordErrorCode returns the error code of a record failure
+func recordErrorCode(err error) string {
+	var recErr *recordError
+	if errors.As(err, &recErr) {
+		return recErr.code
+	}
+	var stageErr *pipeline.PipelineError
+	if errors.As(err, &stageErr) && stageErr.ErrorCode != "" {
+		return stageErr.ErrorCode
+	}
+	return "PROCESSING_ERROR"
+}
+
+// BulkJobWorker processes queued bulk operations. Every replica runs a worker; workers
+// claim chunks of pending records under a lease, so an operation is shared by all replicas
+// and a chunk abandoned by a crashed replica is picked up again once its lease expires.
+type BulkJobWorker struct {
+	service      *BulkFarmerServiceImpl
+	queue        bulkRepo.JobQueueRepository
+	processors   map[bulk.OperationType]bulkRecordProcessor
+	logger       interfaces.Logger
+	workerID     string
+	pollInterval time.Duration
+	chunkSize    int
+	slots        chan struct{}
+	lastRecovery time.Time
+	stopCh       chan struct{}
+	wg           sync.WaitGroup
+	running      bool
+	mu           sync.Mutex
+}
+
+// NewBulkJobWorker creates a job worker for the bulk farmer service
+func NewBulkJobWorker(service *BulkFarmerServiceImpl, queue bulkRepo.JobQueueRepository, logger interfaces.Logger, pollInterval time.Duration) *BulkJobWorker {
+	if pollInterval == 0 {
+		pollInterval = 2 * time.Second
+	}
+	return &BulkJobWorker{
+		service:      service,
+		queue:        queue,
+		processors:   map[bulk.OperationType]bulkRecordProcessor{bulk.OperationFarmerAddition: service},
+		logger:       logger,
+		workerID:     service.instanceID,
+		pollInterval: pollInterval,
+		chunkSize:    service.config.DefaultChunkSize,
+		slots:        make(chan struct{}, service.config.MaxConcurrency),
+		stopCh:       make(chan struct{}),
+	}
+}
+
+// registerProcessor sets the processor for the records of an operation type. Processors
+// must be registered before the worker is started.
+func (w *BulkJobWorker) registerProcessor(operationType bulk.OperationType, processor bulkRecordProcessor) {
+	w.processors[operationType] = processor
+}
+
+// processorFor returns the processor of an operation; operations without a registered
+// type are farmer additions
+func (w *BulkJobWorker) processorFor(bulkOp *bulk.BulkOperation) bulkRecordProcessor {
+	if processor, ok := w.processors[bulkOp.OperationType]; ok {
+		return processor
+	}
+	return w.service
+}
+
+// Start recovers operations orphaned by earlier runs and begins polling for work
+func (w *BulkJobWorker) Start() {
+	w.mu.Lock()
+	if w.running {
+		w.mu.Unlock()
+		return
+	}
+	w.running = true
+	w.mu.Unlock()
+
+	w.wg.Add(1)
+	go w.run()
+	log.Printf("Bulk job worker started (worker_id: %s, poll interval: %s)", w.workerID, w.pollInterval)
+}
+
+// Stop stops claiming work and waits for chunks in flight. Records a chunk has not reached
+// yet are released so other replicas can claim them right away.
+func (w *BulkJobWorker) Stop() {
+	w.mu.Lock()
+	if !w.running {
+		w.mu.Unlock()
+		return
+	}
+	w.running = false
+	w.mu.Unlock()
+
+	close(w.stopCh)
+	w.wg.Wait()
+	log.Println("Bulk job worker stopped")
+}
+
+func (w *BulkJobWorker) run() {
+	defer w.wg.Done()
+
+	w.recoverOrphaned()
+
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if time.Since(w.lastRecovery) >= w.service.config.LeaseTTL {
+				w.recoverOrphaned()
+			}
+			w.poll()
+		case <-w.stopCh:
+			return
+		}
+	}
+}
+
+// poll claims chunks until the queue is empty or every slot is busy
+func (w *BulkJobWorker) poll() {
+	for {
+		select {
+		case w.slots <- struct{}{}:
+		case <-w.stopCh:
+			return
+		default:
+			return
+		}
+
+		details, err := w.queue.ClaimChunk(context.Background(), w.workerID, w.chunkSize, w.service.config.LeaseTTL)
+		if err != nil || len(details) == 0 {
+			<-w.slots
+			if err != nil {
+				w.logger.Error(fmt.Sprintf("Failed to claim bulk chunk: worker_id=%s, error=%v", w.workerID, err))
+			}
+			return
+		}
+
+		w.wg.Add(1)
+		go func() {
+			defer func() {
+				<-w.slots
+				w.wg.Done()
+			}()
+			w.processChunk(details)
+		}()
+	}
+}
+
+// processChunk processes the leased records of one operation. The operation status is
+// checked before every record so pause and cancel requests made on any replica apply.
+func (w *BulkJobWorker) processChunk(details []*bulk.ProcessingDetail) {
+	operationID := details[0].BulkOperationID
+	ctx := context.Background()
+
+	bulkOp, err := w.service.bulkOpRepo.GetByID(ctx, operationID)
+	if err != nil {
+		w.logger.Error(fmt.Sprintf("Failed to load queued operation: operation_id=%s, error=%v", operationID, err))
+		w.release(ctx, details)
+		return
+	}
+
+	// The requester's identity is not available across replicas; act as the initiating user
+	ctx = auth.SetUserInContext(ctx, &auth.UserContext{AAAUserID: bulkOp.InitiatedBy})
+	options := w.service.optionsFromOperation(bulkOp)
+	processor := w.processorFor(bulkOp)
+
+	stopLease := w.keepLease(ctx, operationID)
+	defer stopLease()
+
+	w.logger.Debug(fmt.Sprintf("Processing claimed chunk: operation_id=%s, records=%d, first_index=%d",
+		operationID, len(details), details[0].RecordIndex))
+
+	for i, detail := range details {
+		if w.stopping() || w.operationStopped(ctx, operationID) {
+			w.release(ctx, details[i:])
+			break
+		}
+
+		farmerID, aaaUserID, err := processor.processDetail(ctx, bulkOp, detail, options)
+		if err != nil {
+			w.logger.Error(fmt.Sprintf("Failed to process record: operation_id=%s, index=%d, error=%v",
+				operationID, detail.RecordIndex, err))
+			detail.SetFailed(err.Error(), recordErrorCode(err))
+		} else {
+			detail.SetSuccess(farmerID, aaaUserID)
+		}
+
+		// A record whose outcome is not stored stays pending and would be processed again,
+		// creating its farmer a second time, so the operation is stopped instead
+		if saveErr := w.saveOutcome(ctx, detail); saveErr != nil {
+			w.logger.Error(fmt.Sprintf("Failed to store record outcome, stopping operation: operation_id=%s, index=%d, status=%s, farmer_id=%s, aaa_user_id=%s, error=%v",
+				operationID, detail.RecordIndex, detail.Status, farmerID, aaaUserID, saveErr))
+			w.failOperation(ctx, operationID, details[i+1:])
+			return
+		}
+
+		if err != nil && !options.ContinueOnError {
+			// Stop the whole operation, including chunks leased by other workers
+			w.failOperation(ctx, operationID, details[i+1:])
+			return
+		}
+	}
+
+	w.finish(ctx, operationID)
+}
+
+// saveOutcome stores the outcome of a processed record, retrying failed writes
+func (w *BulkJobWorker) saveOutcome(ctx context.Context, detail *bulk.ProcessingDetail) error {
+	return w.retry(func() error {
+		return w.service.processingRepo.Update(ctx, detail)
+	})
+}
+
+// failOperation fails an operation that cannot go on: the rest of the chunk is released and
+// the operation marked failed, so that no worker claims its records again
+func (w *BulkJobWorker) failOperation(ctx context.Context, operationID string, rest []*bulk.ProcessingDetail) {
+	w.release(ctx, rest)
+
+	if err := w.retry(func() error { return w.queue.RefreshProgress(ctx, operationID) }); err != nil {
+		w.logger.Error(fmt.Sprintf("Failed to refresh progress: operation_id=%s, error=%v", operationID, err))
+	}
+	if err := w.retry(func() error {
+		return w.service.bulkOpRepo.UpdateStatus(ctx, operationID, bulk.StatusFailed)
+	}); err != nil {
+		w.logger.Error(fmt.Sprintf("Failed to mark operation failed: operation_id=%s, error=%v", operationID, err))
+		return
+	}
+	w.service.publishResultFile(ctx, operationID)
+}
+
+// retry runs a write up to outcomeWriteAttempts times, waiting a little longer after each
+// failure, and returns the last error
+func (w *BulkJobWorker) retry(write func() error) error {
+	var err error
+	for attempt := 1; attempt <= outcomeWriteAttempts; attempt++ {
+		if err = write(); err == nil {
+			return nil
+		}
+		if attempt < outcomeWriteAttempts {
+			time.Sleep(time.Duration(attempt) * outcomeWriteBackoff)
+		}
+	}
+	return err
+}
+
+// finish refreshes the operation counts and completes the operation once no records are
+// pending. Only the worker whose update completes the operation publishes the result file.
+func (w *BulkJobWorker) finish(ctx context.Context, operationID string) {
+	if err := w.queue.RefreshProgress(ctx, operationID); err != nil {
+		w.logger.Error(fmt.Sprintf("Failed to refresh progress: operation_id=%s, error=%v", operationID, err))
+		return
+	}
+
+	completed, err := w.queue.CompleteIfDone(ctx, operationID)
+	if err != nil {
+		w.logger.Error(fmt.Sprintf("Failed to complete operation: operation_id=%s, error=%v", operationID, err))
+		return
+	}
+	if completed {
+		w.service.publishResultFile(ctx, operationID)
+		w.logger.Info(fmt.Sprintf("Queued bulk operation completed: operation_id=%s", operationID))
+	}
+}
+
+// recoverOrphaned queues operations whose owner stopped sending heartbeats and finishes
+// those whose records were all processed before the owner went away
+func (w *BulkJobWorker) recoverOrphaned() {
+	w.lastRecovery = time.Now()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	operationIDs, err := w.queue.RecoverOrphaned(ctx, time.Now().Add(-w.service.config.LeaseTTL))
+	if err != nil {
+		log.Printf("Bulk job recovery failed: %v", err)
+		return
+	}
+	if len(operationIDs) == 0 {
+		return
+	}
+
+	log.Printf("Recovered %d orphaned bulk operations", len(operationIDs))
+	for _, operationID := range operationIDs {
+		w.finish(ctx, operationID)
+	}
+}
+
+// keepLease renews the worker's leases on the operation while a chunk is processed
+func (w *BulkJobWorker) keepLease(ctx context.Context, operationID string) func() {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(w.service.config.HeartbeatInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := w.queue.ExtendLease(ctx, w.workerID, operationID, w.service.config.LeaseTTL); err != nil {
+					w.logger.Error(fmt.Sprintf("Failed to extend lease: operation_id=%s, error=%v", operationID, err))
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
+
+// operationStopped reports whether the operation was paused, cancelled or failed
+func (w *BulkJobWorker) operationStopped(ctx context.Context, operationID string) bool {
+	bulkOp, err := w.service.bulkOpRepo.GetByID(ctx, operationID)
+	if err != nil {
+		return false
+	}
+	return bulkOp.Status != bulk.StatusPending && bulkOp.Status != bulk.StatusProcessing
+}
+
+func (w *BulkJobWorker) stopping() bool {
+	select {
+	case <-w.stopCh:
+		return true
+	default:
+		return false
+	}
+}
+
+func (w *BulkJobWorker) release(ctx context.Context, details []*bulk.ProcessingDetail) {
+	ids := make([]string, 0, len(details))
+	for _, detail := range details {
+		ids = append(ids, detail.ID)
+	}
+	if err := w.queue.ReleaseLease(ctx, w.workerID, ids); err != nil {
+		w.logger.Error(fmt.Sprintf("Failed to release leases: worker_id=%s, error=%v", w.workerID, err))
+	}
+}
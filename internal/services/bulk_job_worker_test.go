@@ -0,0 +1,631 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/bulk"
+	"github.com/Kisanlink/farmers-module/internal/entities/requests"
+	bulkRepo "github.com/Kisanlink/farmers-module/internal/repo/bulk"
+	"github.com/Kisanlink/farmers-module/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// bulkStore holds the operations and processing details shared by the fake bulk
+// repositories. Lease expiry is checked against a clock the tests move forward; it starts
+// at the real time because orphan recovery compares heartbeats with time.Now.
+type bulkStore struct {
+	mu          sync.Mutex
+	now         time.Time
+	operations  map[string]*bulk.BulkOperation
+	details     map[string]*bulk.ProcessingDetail
+	resultFiles map[string]int
+
+	updateFailures int // detail updates left to fail; negative fails every update
+	updateCalls    int
+	extendCalls    int
+}
+
+func newBulkStore() *bulkStore {
+	return &bulkStore{
+		now:         time.Now(),
+		operations:  make(map[string]*bulk.BulkOperation),
+		details:     make(map[string]*bulk.ProcessingDetail),
+		resultFiles: make(map[string]int),
+	}
+}
+
+// addOperation stores a queued operation with records pending processing
+func (s *bulkStore) addOperation(id string, records int, options map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	op := bulk.NewBulkOperation()
+	op.ID = id
+	op.Queued = true
+	op.InitiatedBy = "user_1"
+	op.TotalRecords = records
+	op.CreatedAt = s.now
+	if options != nil {
+		op.Options = options
+	}
+	s.operations[id] = op
+
+	for i := 0; i < records; i++ {
+		detail := bulk.NewProcessingDetail(id, i)
+		detail.ID = fmt.Sprintf("%s-%d", id, i)
+		s.details[detail.ID] = detail
+	}
+}
+
+func (s *bulkStore) advance(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.now = s.now.Add(d)
+}
+
+func (s *bulkStore) setStatus(operationID string, status bulk.OperationStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.operations[operationID].Status = status
+}
+
+func (s *bulkStore) operation(operationID string) bulk.BulkOperation {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return *s.operations[operationID]
+}
+
+// records returns copies of the details of an operation in record order
+func (s *bulkStore) records(operationID string) []bulk.ProcessingDetail {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.recordsLocked(operationID)
+}
+
+func (s *bulkStore) recordsLocked(operationID string) []bulk.ProcessingDetail {
+	var records []bulk.ProcessingDetail
+	for _, detail := range s.details {
+		if detail.BulkOperationID == operationID {
+			records = append(records, *detail)
+		}
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].RecordIndex < records[j].RecordIndex })
+	return records
+}
+
+func (s *bulkStore) claimable(detail *bulk.ProcessingDetail) bool {
+	return detail.Status == bulk.RecordStatusPending &&
+		(detail.LeaseExpiresAt == nil || detail.LeaseExpiresAt.Before(s.now))
+}
+
+// fakeBulkOpRepo implements the parts of BulkOperationRepository the worker uses
+type fakeBulkOpRepo struct {
+	bulkRepo.BulkOperationRepository
+	store *bulkStore
+}
+
+func (r *fakeBulkOpRepo) GetByID(ctx context.Context, id string) (*bulk.BulkOperation, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	op, ok := r.store.operations[id]
+	if !ok {
+		return nil, fmt.Errorf("bulk operation not found: %s", id)
+	}
+	copied := *op
+	return &copied, nil
+}
+
+func (r *fakeBulkOpRepo) UpdateStatus(ctx context.Context, id string, status bulk.OperationStatus) error {
+	r.store.setStatus(id, status)
+	return nil
+}
+
+func (r *fakeBulkOpRepo) SetResultFileURL(ctx context.Context, id string, url string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	r.store.resultFiles[id]++
+	r.store.operations[id].ResultFileURL = url
+	return nil
+}
+
+// fakeProcessingRepo implements the parts of ProcessingDetailRepository the worker uses
+type fakeProcessingRepo struct {
+	bulkRepo.ProcessingDetailRepository
+	store *bulkStore
+}
+
+func (r *fakeProcessingRepo) Update(ctx context.Context, detail *bulk.ProcessingDetail) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	r.store.updateCalls++
+	if r.store.updateFailures != 0 {
+		r.store.updateFailures--
+		return errors.New("connection reset")
+	}
+	copied := *detail
+	r.store.details[detail.ID] = &copied
+	return nil
+}
+
+// fakeJobQueue is an in-memory JobQueueRepository with the lease semantics of the
+// PostgreSQL implementation
+type fakeJobQueue struct {
+	store *bulkStore
+}
+
+func (q *fakeJobQueue) Enqueue(ctx context.Context, operationID string) error {
+	q.store.mu.Lock()
+	defer q.store.mu.Unlock()
+	q.store.operations[operationID].Queued = true
+	return nil
+}
+
+func (q *fakeJobQueue) UpdateLoading(ctx context.Context, operationID string, totalRecords int, loading bool) error {
+	q.store.mu.Lock()
+	defer q.store.mu.Unlock()
+	op := q.store.operations[operationID]
+	op.TotalRecords = totalRecords
+	op.Loading = loading
+	return nil
+}
+
+func (q *fakeJobQueue) ClaimChunk(ctx context.Context, workerID string, size int, leaseTTL time.Duration) ([]*bulk.ProcessingDetail, error) {
+	s := q.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var ops []*bulk.BulkOperation
+	for _, op := range s.operations {
+		if op.Queued && (op.Status == bulk.StatusPending || op.Status == bulk.StatusProcessing) {
+			ops = append(ops, op)
+		}
+	}
+	sort.Slice(ops, func(i, j int) bool { return ops[i].CreatedAt.Before(ops[j].CreatedAt) })
+
+	for _, op := range ops {
+		var claimed []*bulk.ProcessingDetail
+		for _, record := range s.recordsLocked(op.ID) {
+			detail := s.details[record.ID]
+			if !s.claimable(detail) || len(claimed) == size {
+				continue
+			}
+			owner, expires := workerID, s.now.Add(leaseTTL)
+			detail.LeaseOwner, detail.LeaseExpiresAt = &owner, &expires
+			copied := *detail
+			claimed = append(claimed, &copied)
+		}
+		if len(claimed) > 0 {
+			op.Status = bulk.StatusProcessing
+			return claimed, nil
+		}
+	}
+	return nil, nil
+}
+
+func (q *fakeJobQueue) ExtendLease(ctx context.Context, workerID string, operationID string, leaseTTL time.Duration) error {
+	s := q.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.extendCalls++
+	for _, detail := range s.details {
+		if detail.BulkOperationID == operationID && detail.Status == bulk.RecordStatusPending &&
+			detail.LeaseOwner != nil && *detail.LeaseOwner == workerID {
+			expires := s.now.Add(leaseTTL)
+			detail.LeaseExpiresAt = &expires
+		}
+	}
+	heartbeat := s.now
+	s.operations[operationID].HeartbeatAt = &heartbeat
+	return nil
+}
+
+func (q *fakeJobQueue) ReleaseLease(ctx context.Context, workerID string, detailIDs []string) error {
+	s := q.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, id := range detailIDs {
+		if detail := s.details[id]; detail.LeaseOwner != nil && *detail.LeaseOwner == workerID {
+			detail.LeaseOwner, detail.LeaseExpiresAt = nil, nil
+		}
+	}
+	return nil
+}
+
+func (q *fakeJobQueue) Heartbeat(ctx context.Context, operationID string, owner string) error {
+	s := q.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	heartbeat := s.now
+	s.operations[operationID].HeartbeatAt = &heartbeat
+	return nil
+}
+
+func (q *fakeJobQueue) RefreshProgress(ctx context.Context, operationID string) error {
+	s := q.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var successful, failed, skipped int
+	for _, record := range s.recordsLocked(operationID) {
+		switch record.Status {
+		case bulk.RecordStatusSuccess:
+			successful++
+		case bulk.RecordStatusFailed:
+			failed++
+		case bulk.RecordStatusSkipped:
+			skipped++
+		}
+	}
+	op := s.operations[operationID]
+	op.SuccessfulRecords, op.FailedRecords, op.SkippedRecords = successful, failed, skipped
+	op.ProcessedRecords = successful + failed + skipped
+	return nil
+}
+
+func (q *fakeJobQueue) CompleteIfDone(ctx context.Context, operationID string) (bool, error) {
+	s := q.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	op := s.operations[operationID]
+	if op.Status != bulk.StatusProcessing || op.Loading {
+		return false, nil
+	}
+	for _, record := range s.recordsLocked(operationID) {
+		if record.Status == bulk.RecordStatusPending {
+			return false, nil
+		}
+	}
+	op.Status = bulk.StatusCompleted
+	if op.SuccessfulRecords == 0 && op.FailedRecords > 0 {
+		op.Status = bulk.StatusFailed
+	}
+	op.Queued = false
+	return true, nil
+}
+
+func (q *fakeJobQueue) RecoverOrphaned(ctx context.Context, staleBefore time.Time) ([]string, error) {
+	s := q.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var ids []string
+	for _, op := range s.operations {
+		if op.Status != bulk.StatusPending && op.Status != bulk.StatusProcessing {
+			continue
+		}
+		if op.Queued && op.Status != bulk.StatusProcessing {
+			continue
+		}
+		if op.HeartbeatAt == nil || !op.HeartbeatAt.Before(staleBefore) {
+			continue
+		}
+		op.Queued = true
+		op.Loading = false
+		ids = append(ids, op.ID)
+	}
+	return ids, nil
+}
+
+// fakeRecordProcessor records the processed records and runs an optional hook before each
+type fakeRecordProcessor struct {
+	mu        sync.Mutex
+	processed []int
+	before    func(detail *bulk.ProcessingDetail)
+	fail      map[int]bool
+}
+
+func (p *fakeRecordProcessor) processDetail(ctx context.Context, bulkOp *bulk.BulkOperation, detail *bulk.ProcessingDetail, options requests.BulkProcessingOptions) (string, string, error) {
+	if p.before != nil {
+		p.before(detail)
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.processed = append(p.processed, detail.RecordIndex)
+	if p.fail[detail.RecordIndex] {
+		return "", "", &recordError{code: "AAA_ERROR", err: errors.New("aaa unavailable")}
+	}
+	return fmt.Sprintf("FMRR%d", detail.RecordIndex), fmt.Sprintf("USR%d", detail.RecordIndex), nil
+}
+
+func (p *fakeRecordProcessor) processedRecords() []int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	records := append([]int(nil), p.processed...)
+	sort.Ints(records)
+	return records
+}
+
+// newTestWorker creates a worker of one replica over the shared store
+func newTestWorker(store *bulkStore, workerID string, processor bulkRecordProcessor) *BulkJobWorker {
+	service := &BulkFarmerServiceImpl{
+		bulkOpRepo:     &fakeBulkOpRepo{store: store},
+		processingRepo: &fakeProcessingRepo{store: store},
+		queueRepo:      &fakeJobQueue{store: store},
+		logger:         &testutils.MockLogger{},
+		config: &BulkServiceConfig{
+			DefaultChunkSize:  2,
+			MaxConcurrency:    2,
+			LeaseTTL:          time.Minute,
+			HeartbeatInterval: time.Hour,
+		},
+		instanceID: workerID,
+	}
+	worker := NewBulkJobWorker(service, service.queueRepo, service.logger, time.Hour)
+	worker.registerProcessor(bulk.OperationFarmerAddition, processor)
+	return worker
+}
+
+func statuses(records []bulk.ProcessingDetail) []bulk.RecordStatus {
+	result := make([]bulk.RecordStatus, 0, len(records))
+	for _, record := range records {
+		result = append(result, record.Status)
+	}
+	return result
+}
+
+func TestBulkJobWorker_ReclaimsChunkAfterLeaseExpires(t *testing.T) {
+	store := newBulkStore()
+	store.addOperation("BLKO1", 4, nil)
+	ctx := context.Background()
+
+	// Replica A claims the first chunk and goes away without processing it
+	crashed := newTestWorker(store, "worker-a", &fakeRecordProcessor{})
+	abandoned, err := crashed.queue.ClaimChunk(ctx, "worker-a", 2, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, abandoned, 2)
+
+	processor := &fakeRecordProcessor{}
+	worker := newTestWorker(store, "worker-b", processor)
+
+	// While the lease is valid only the other chunk can be claimed
+	details, err := worker.queue.ClaimChunk(ctx, "worker-b", 2, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, details, 2)
+	assert.Equal(t, []int{2, 3}, []int{details[0].RecordIndex, details[1].RecordIndex})
+	worker.processChunk(details)
+
+	details, err = worker.queue.ClaimChunk(ctx, "worker-b", 2, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, details)
+	assert.Equal(t, bulk.StatusProcessing, store.operation("BLKO1").Status)
+
+	// Once it expires the abandoned chunk is claimed and the operation completes
+	store.advance(time.Minute + time.Second)
+	details, err = worker.queue.ClaimChunk(ctx, "worker-b", 2, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, details, 2)
+	assert.Equal(t, "worker-b", *details[0].LeaseOwner)
+	worker.processChunk(details)
+
+	assert.Equal(t, []int{0, 1, 2, 3}, processor.processedRecords())
+	op := store.operation("BLKO1")
+	assert.Equal(t, bulk.StatusCompleted, op.Status)
+	assert.Equal(t, 4, op.SuccessfulRecords)
+	assert.Equal(t, 1, store.resultFiles["BLKO1"])
+}
+
+func TestBulkJobWorker_StopsMidChunk(t *testing.T) {
+	for _, status := range []bulk.OperationStatus{bulk.StatusPaused, bulk.StatusCancelled} {
+		t.Run(string(status), func(t *testing.T) {
+			store := newBulkStore()
+			store.addOperation("BLKO1", 4, nil)
+
+			// The operation is paused or cancelled on another replica while record 1 is processed
+			processor := &fakeRecordProcessor{before: func(detail *bulk.ProcessingDetail) {
+				if detail.RecordIndex == 1 {
+					store.setStatus("BLKO1", status)
+				}
+			}}
+			worker := newTestWorker(store, "worker-a", processor)
+
+			details, err := worker.queue.ClaimChunk(context.Background(), "worker-a", 4, time.Minute)
+			require.NoError(t, err)
+			worker.processChunk(details)
+
+			// The record in flight completes; the rest stay pending and are released
+			assert.Equal(t, []int{0, 1}, processor.processedRecords())
+			records := store.records("BLKO1")
+			assert.Equal(t, []bulk.RecordStatus{
+				bulk.RecordStatusSuccess, bulk.RecordStatusSuccess, bulk.RecordStatusPending, bulk.RecordStatusPending,
+			}, statuses(records))
+			assert.Nil(t, records[2].LeaseOwner)
+			assert.Nil(t, records[3].LeaseOwner)
+
+			assert.Equal(t, status, store.operation("BLKO1").Status)
+			assert.Zero(t, store.resultFiles["BLKO1"])
+
+			details, err = worker.queue.ClaimChunk(context.Background(), "worker-a", 4, time.Minute)
+			require.NoError(t, err)
+			assert.Empty(t, details)
+		})
+	}
+}
+
+func TestBulkJobWorker_CompletesOnceWhenWorkersFinishTogether(t *testing.T) {
+	store := newBulkStore()
+	store.addOperation("BLKO1", 4, nil)
+
+	// Both workers are inside their last record at the same time
+	var started sync.WaitGroup
+	started.Add(2)
+	release := make(chan struct{})
+	hold := func(detail *bulk.ProcessingDetail) {
+		if detail.RecordIndex%2 == 1 {
+			started.Done()
+			<-release
+		}
+	}
+	workerA := newTestWorker(store, "worker-a", &fakeRecordProcessor{before: hold})
+	workerB := newTestWorker(store, "worker-b", &fakeRecordProcessor{before: hold})
+
+	chunkA, err := workerA.queue.ClaimChunk(context.Background(), "worker-a", 2, time.Minute)
+	require.NoError(t, err)
+	chunkB, err := workerB.queue.ClaimChunk(context.Background(), "worker-b", 2, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, chunkA, 2)
+	require.Len(t, chunkB, 2)
+
+	var done sync.WaitGroup
+	done.Add(2)
+	go func() { defer done.Done(); workerA.processChunk(chunkA) }()
+	go func() { defer done.Done(); workerB.processChunk(chunkB) }()
+	started.Wait()
+	close(release)
+	done.Wait()
+
+	op := store.operation("BLKO1")
+	assert.Equal(t, bulk.StatusCompleted, op.Status)
+	assert.Equal(t, 4, op.ProcessedRecords)
+	assert.Equal(t, 1, store.resultFiles["BLKO1"], "the result file is published by one worker only")
+}
+
+func TestBulkJobWorker_RetriesOutcomeWrites(t *testing.T) {
+	store := newBulkStore()
+	store.addOperation("BLKO1", 1, nil)
+	store.updateFailures = outcomeWriteAttempts - 1
+
+	processor := &fakeRecordProcessor{}
+	worker := newTestWorker(store, "worker-a", processor)
+
+	details, err := worker.queue.ClaimChunk(context.Background(), "worker-a", 2, time.Minute)
+	require.NoError(t, err)
+	worker.processChunk(details)
+
+	assert.Equal(t, outcomeWriteAttempts, store.updateCalls)
+	assert.Equal(t, []bulk.RecordStatus{bulk.RecordStatusSuccess}, statuses(store.records("BLKO1")))
+	assert.Equal(t, bulk.StatusCompleted, store.operation("BLKO1").Status)
+}
+
+func TestBulkJobWorker_FailsOperationWhenOutcomeCannotBeStored(t *testing.T) {
+	store := newBulkStore()
+	store.addOperation("BLKO1", 3, nil)
+	store.updateFailures = -1
+
+	processor := &fakeRecordProcessor{}
+	worker := newTestWorker(store, "worker-a", processor)
+
+	details, err := worker.queue.ClaimChunk(context.Background(), "worker-a", 3, time.Minute)
+	require.NoError(t, err)
+	worker.processChunk(details)
+
+	// The first record is not processed again, and no further record is started
+	assert.Equal(t, []int{0}, processor.processedRecords())
+	assert.Equal(t, outcomeWriteAttempts, store.updateCalls)
+
+	op := store.operation("BLKO1")
+	assert.Equal(t, bulk.StatusFailed, op.Status)
+	assert.Equal(t, 1, store.resultFiles["BLKO1"])
+
+	records := store.records("BLKO1")
+	assert.Nil(t, records[1].LeaseOwner)
+	assert.Nil(t, records[2].LeaseOwner)
+
+	details, err = worker.queue.ClaimChunk(context.Background(), "worker-a", 3, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, details, "a failed operation is not claimed again")
+}
+
+func TestBulkJobWorker_FailsOperationOnRecordErrorWithoutContinueOnError(t *testing.T) {
+	store := newBulkStore()
+	store.addOperation("BLKO1", 3, map[string]interface{}{"continue_on_error": false})
+
+	processor := &fakeRecordProcessor{fail: map[int]bool{1: true}}
+	worker := newTestWorker(store, "worker-a", processor)
+
+	details, err := worker.queue.ClaimChunk(context.Background(), "worker-a", 3, time.Minute)
+	require.NoError(t, err)
+	worker.processChunk(details)
+
+	assert.Equal(t, []int{0, 1}, processor.processedRecords())
+	records := store.records("BLKO1")
+	assert.Equal(t, []bulk.RecordStatus{
+		bulk.RecordStatusSuccess, bulk.RecordStatusFailed, bulk.RecordStatusPending,
+	}, statuses(records))
+	assert.Equal(t, "AAA_ERROR", *records[1].ErrorCode)
+
+	op := store.operation("BLKO1")
+	assert.Equal(t, bulk.StatusFailed, op.Status)
+	assert.Equal(t, 1, op.SuccessfulRecords)
+	assert.Equal(t, 1, op.FailedRecords)
+}
+
+func TestBulkJobWorker_KeepLeaseExtendsUntilStopped(t *testing.T) {
+	store := newBulkStore()
+	store.addOperation("BLKO1", 2, nil)
+
+	worker := newTestWorker(store, "worker-a", &fakeRecordProcessor{})
+	worker.service.config.HeartbeatInterval = 5 * time.Millisecond
+
+	_, err := worker.queue.ClaimChunk(context.Background(), "worker-a", 2, time.Minute)
+	require.NoError(t, err)
+	store.advance(45 * time.Second)
+
+	stop := worker.keepLease(context.Background(), "BLKO1")
+	require.Eventually(t, func() bool {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		return store.extendCalls > 0
+	}, time.Second, time.Millisecond)
+	stop()
+
+	store.mu.Lock()
+	calls := store.extendCalls
+	store.mu.Unlock()
+
+	// The renewed lease outlives the original one
+	store.advance(30 * time.Second)
+	details, err := worker.queue.ClaimChunk(context.Background(), "worker-b", 2, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, details)
+
+	time.Sleep(20 * time.Millisecond)
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	assert.Equal(t, calls, store.extendCalls, "leases are not extended after the chunk is done")
+}
+
+func TestBulkJobWorker_RecoverOrphanedFinishesProcessedOperations(t *testing.T) {
+	store := newBulkStore()
+	store.addOperation("BLKO1", 2, nil)
+	store.addOperation("BLKO2", 2, nil)
+	ctx := context.Background()
+
+	// Every record of BLKO1 was processed, but its owner stopped before completing it
+	worker := newTestWorker(store, "worker-a", &fakeRecordProcessor{})
+	for _, record := range store.records("BLKO1") {
+		detail := record
+		detail.SetSuccess("FMRR", "USR")
+		require.NoError(t, worker.service.processingRepo.Update(ctx, &detail))
+	}
+	require.NoError(t, worker.queue.Heartbeat(ctx, "BLKO1", "worker-a"))
+	require.NoError(t, worker.queue.Heartbeat(ctx, "BLKO2", "worker-a"))
+	store.setStatus("BLKO1", bulk.StatusProcessing)
+	store.setStatus("BLKO2", bulk.StatusProcessing)
+
+	// Heartbeats within the lease TTL are not orphaned
+	worker.recoverOrphaned()
+	assert.Equal(t, bulk.StatusProcessing, store.operation("BLKO1").Status)
+
+	store.mu.Lock()
+	stale := time.Now().Add(-2 * time.Minute)
+	store.operations["BLKO1"].HeartbeatAt = &stale
+	store.operations["BLKO2"].HeartbeatAt = &stale
+	store.mu.Unlock()
+	worker.recoverOrphaned()
+
+	op := store.operation("BLKO1")
+	assert.Equal(t, bulk.StatusCompleted, op.Status)
+	assert.Equal(t, 2, op.SuccessfulRecords)
+	assert.Equal(t, 1, store.resultFiles["BLKO1"])
+
+	// An operation with pending records is queued again for any worker to claim
+	op = store.operation("BLKO2")
+	assert.Equal(t, bulk.StatusProcessing, op.Status)
+	assert.True(t, op.Queued)
+	assert.Zero(t, store.resultFiles["BLKO2"])
+}
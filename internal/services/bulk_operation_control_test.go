@@ -0,0 +1,320 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/bulk"
+	"github.com/Kisanlink/farmers-module/internal/entities/requests"
+	"github.com/Kisanlink/farmers-module/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (r *fakeBulkOpRepo) UpdateProgress(ctx context.Context, id string, processed, successful, failed, skipped int) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	op := r.store.operations[id]
+	op.ProcessedRecords, op.SuccessfulRecords, op.FailedRecords, op.SkippedRecords = processed, successful, failed, skipped
+	return nil
+}
+
+func (r *fakeProcessingRepo) GetByOperationID(ctx context.Context, operationID string) ([]*bulk.ProcessingDetail, error) {
+	return r.GetByStatus(ctx, operationID, "")
+}
+
+// GetByStatus returns copies of the details of an operation; an empty status returns all
+func (r *fakeProcessingRepo) GetByStatus(ctx context.Context, operationID string, status bulk.RecordStatus) ([]*bulk.ProcessingDetail, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	var details []*bulk.ProcessingDetail
+	for _, record := range r.store.recordsLocked(operationID) {
+		if status == "" || record.Status == status {
+			copied := record
+			details = append(details, &copied)
+		}
+	}
+	return details, nil
+}
+
+// observedProcessingRepo records the rows written by in-process runs and runs a hook after each
+type observedProcessingRepo struct {
+	*fakeProcessingRepo
+	mu      sync.Mutex
+	written []int
+	after   func(detail *bulk.ProcessingDetail)
+}
+
+func (r *observedProcessingRepo) Update(ctx context.Context, detail *bulk.ProcessingDetail) error {
+	if err := r.fakeProcessingRepo.Update(ctx, detail); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	r.written = append(r.written, detail.RecordIndex)
+	r.mu.Unlock()
+	if r.after != nil {
+		r.after(detail)
+	}
+	return nil
+}
+
+func (r *observedProcessingRepo) writtenRecords() []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	records := append([]int(nil), r.written...)
+	sort.Ints(records)
+	return records
+}
+
+// newControlTestService creates a service that processes operations of the store in-process.
+// The records are empty, so every row fails validation before reaching AAA or the database.
+func newControlTestService(store *bulkStore) (*BulkFarmerServiceImpl, *observedProcessingRepo) {
+	processingRepo := &observedProcessingRepo{fakeProcessingRepo: &fakeProcessingRepo{store: store}}
+	service := &BulkFarmerServiceImpl{
+		bulkOpRepo:     &fakeBulkOpRepo{store: store},
+		processingRepo: processingRepo,
+		queueRepo:      &fakeJobQueue{store: store},
+		logger:         &testutils.MockLogger{},
+		config: &BulkServiceConfig{
+			MaxSyncRecords:    100,
+			HeartbeatInterval: time.Hour,
+		},
+		instanceID: "instance-a",
+	}
+	return service, processingRepo
+}
+
+func emptyRecords(n int) []bulkRecord {
+	records := make([]bulkRecord, n)
+	for i := range records {
+		records[i] = bulkRecord{index: i, farmer: &requests.FarmerBulkData{}}
+	}
+	return records
+}
+
+func continueOnError() map[string]interface{} {
+	return map[string]interface{}{"continue_on_error": true}
+}
+
+// runInProcess processes all records of an operation in-process until it finishes or stops
+func runInProcess(t *testing.T, service *BulkFarmerServiceImpl, store *bulkStore, operationID string, records int) {
+	t.Helper()
+	op := store.operation(operationID)
+	control := service.registerControl(operationID)
+	service.processSynchronously(context.Background(), &op, emptyRecords(records), service.optionsFromOperation(&op), control)
+}
+
+func TestOperationControl_FirstStopWins(t *testing.T) {
+	control := &operationControl{stop: make(chan struct{})}
+	_, stopped := control.stopStatus()
+	assert.False(t, stopped)
+
+	control.requestStop(bulk.StatusPaused)
+	control.requestStop(bulk.StatusCancelled)
+
+	status, stopped := control.stopStatus()
+	assert.True(t, stopped)
+	assert.Equal(t, bulk.StatusPaused, status)
+}
+
+func TestSignalStop_OnlyStopsRegisteredOperations(t *testing.T) {
+	service, _ := newControlTestService(newBulkStore())
+	control := service.registerControl("BLKO1")
+
+	// Operations running on another instance are not affected
+	service.signalStop("BLKO2", bulk.StatusPaused)
+	assert.False(t, control.stopped())
+
+	service.signalStop("BLKO1", bulk.StatusCancelled)
+	status, stopped := control.stopStatus()
+	assert.True(t, stopped)
+	assert.Equal(t, bulk.StatusCancelled, status)
+
+	service.releaseControl("BLKO1")
+	_, running := service.controls.Load("BLKO1")
+	assert.False(t, running)
+}
+
+func TestStopRequested_PicksUpStatusFromDatabase(t *testing.T) {
+	ctx := context.Background()
+	store := newBulkStore()
+	store.addOperation("BLKO1", 1, nil)
+	service, _ := newControlTestService(store)
+
+	control := service.registerControl("BLKO1")
+	store.setStatus("BLKO1", bulk.StatusProcessing)
+	assert.False(t, service.stopRequested(ctx, "BLKO1", control))
+	assert.False(t, control.stopped())
+
+	// Paused through another instance
+	store.setStatus("BLKO1", bulk.StatusPaused)
+	assert.True(t, service.stopRequested(ctx, "BLKO1", control))
+	status, stopped := control.stopStatus()
+	assert.True(t, stopped)
+	assert.Equal(t, bulk.StatusPaused, status)
+}
+
+func TestProcessSynchronously_StopsBetweenRows(t *testing.T) {
+	stops := map[bulk.OperationStatus]func(s *BulkFarmerServiceImpl, ctx context.Context, id string) error{
+		bulk.StatusPaused:    (*BulkFarmerServiceImpl).PauseBulkOperation,
+		bulk.StatusCancelled: (*BulkFarmerServiceImpl).CancelBulkOperation,
+	}
+
+	for status, stop := range stops {
+		t.Run(string(status), func(t *testing.T) {
+			ctx := context.Background()
+			store := newBulkStore()
+			store.addOperation("BLKO1", 5, continueOnError())
+			service, processingRepo := newControlTestService(store)
+
+			// The operation is stopped while record 1 is being written
+			processingRepo.after = func(detail *bulk.ProcessingDetail) {
+				if detail.RecordIndex == 1 {
+					require.NoError(t, stop(service, ctx, "BLKO1"))
+				}
+			}
+			runInProcess(t, service, store, "BLKO1", 5)
+
+			assert.Equal(t, []int{0, 1}, processingRepo.writtenRecords())
+			assert.Equal(t, []bulk.RecordStatus{
+				bulk.RecordStatusFailed, bulk.RecordStatusFailed,
+				bulk.RecordStatusPending, bulk.RecordStatusPending, bulk.RecordStatusPending,
+			}, statuses(store.records("BLKO1")))
+
+			op := store.operation("BLKO1")
+			assert.Equal(t, status, op.Status)
+			assert.Equal(t, 2, op.ProcessedRecords)
+			assert.Zero(t, store.resultFiles["BLKO1"])
+
+			_, running := service.controls.Load("BLKO1")
+			assert.False(t, running)
+		})
+	}
+}
+
+func TestProcessSynchronously_StopsOnPauseFromAnotherInstance(t *testing.T) {
+	store := newBulkStore()
+	store.addOperation("BLKO1", 25, continueOnError())
+	service, processingRepo := newControlTestService(store)
+
+	// Only the status in the database changes; it is checked every 10 rows
+	processingRepo.after = func(detail *bulk.ProcessingDetail) {
+		if detail.RecordIndex == 3 {
+			store.setStatus("BLKO1", bulk.StatusPaused)
+		}
+	}
+	runInProcess(t, service, store, "BLKO1", 25)
+
+	records := store.records("BLKO1")
+	for _, record := range records[:10] {
+		assert.Equal(t, bulk.RecordStatusFailed, record.Status, "record %d", record.RecordIndex)
+	}
+	for _, record := range records[10:] {
+		assert.Equal(t, bulk.RecordStatusPending, record.Status, "record %d", record.RecordIndex)
+	}
+	op := store.operation("BLKO1")
+	assert.Equal(t, bulk.StatusPaused, op.Status)
+	assert.Equal(t, 10, op.ProcessedRecords)
+}
+
+func TestResumeBulkOperation_ProcessesOnlyPendingRecords(t *testing.T) {
+	ctx := context.Background()
+	store := newBulkStore()
+	store.addOperation("BLKO1", 5, continueOnError())
+	service, processingRepo := newControlTestService(store)
+
+	processingRepo.after = func(detail *bulk.ProcessingDetail) {
+		if detail.RecordIndex == 1 {
+			require.NoError(t, service.PauseBulkOperation(ctx, "BLKO1"))
+		}
+	}
+	runInProcess(t, service, store, "BLKO1", 5)
+	require.Equal(t, bulk.StatusPaused, store.operation("BLKO1").Status)
+
+	processingRepo.mu.Lock()
+	processingRepo.written, processingRepo.after = nil, nil
+	processingRepo.mu.Unlock()
+
+	result, err := service.ResumeBulkOperation(ctx, "BLKO1")
+	require.NoError(t, err)
+	assert.Equal(t, string(bulk.StatusProcessing), result.Status)
+
+	require.Eventually(t, func() bool {
+		op := store.operation("BLKO1")
+		return op.IsComplete() && store.resultFiles["BLKO1"] == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, []int{2, 3, 4}, processingRepo.writtenRecords())
+	op := store.operation("BLKO1")
+	assert.Equal(t, bulk.StatusFailed, op.Status)
+	assert.Equal(t, 5, op.ProcessedRecords)
+	assert.Equal(t, 5, op.FailedRecords)
+}
+
+func TestResumeBulkOperation_WhileStillStopping(t *testing.T) {
+	store := newBulkStore()
+	store.addOperation("BLKO1", 2, nil)
+	store.setStatus("BLKO1", bulk.StatusPaused)
+	service, processingRepo := newControlTestService(store)
+
+	// The paused run has not finished its record in flight yet
+	service.registerControl("BLKO1")
+	_, err := service.ResumeBulkOperation(context.Background(), "BLKO1")
+	assert.ErrorContains(t, err, "still stopping")
+	assert.Empty(t, processingRepo.writtenRecords())
+	assert.Equal(t, bulk.StatusPaused, store.operation("BLKO1").Status)
+}
+
+func TestResumeBulkOperation_NothingPending(t *testing.T) {
+	store := newBulkStore()
+	store.addOperation("BLKO1", 2, nil)
+	for _, record := range store.records("BLKO1") {
+		store.details[record.ID].SetSuccess("FMRR1", "USR1")
+	}
+	store.setStatus("BLKO1", bulk.StatusPaused)
+	store.operations["BLKO1"].SuccessfulRecords = 2
+	service, _ := newControlTestService(store)
+
+	result, err := service.ResumeBulkOperation(context.Background(), "BLKO1")
+	require.NoError(t, err)
+	assert.Equal(t, string(bulk.StatusCompleted), result.Status)
+	assert.Equal(t, bulk.StatusCompleted, store.operation("BLKO1").Status)
+	assert.Equal(t, 1, store.resultFiles["BLKO1"])
+}
+
+func TestResumeBulkOperation_QueuesOtherOperationTypes(t *testing.T) {
+	store := newBulkStore()
+	store.addOperation("BLKO1", 2, nil)
+	store.operations["BLKO1"].OperationType = bulk.OperationFarmImport
+	store.operations["BLKO1"].Queued = false
+	store.setStatus("BLKO1", bulk.StatusPaused)
+	service, processingRepo := newControlTestService(store)
+
+	result, err := service.ResumeBulkOperation(context.Background(), "BLKO1")
+	require.NoError(t, err)
+	assert.Equal(t, string(bulk.StatusProcessing), result.Status)
+
+	op := store.operation("BLKO1")
+	assert.True(t, op.Queued)
+	assert.Equal(t, bulk.StatusProcessing, op.Status)
+	assert.Empty(t, processingRepo.writtenRecords())
+	_, running := service.controls.Load("BLKO1")
+	assert.False(t, running)
+}
+
+func TestPauseAndCancel_RejectFinishedOperations(t *testing.T) {
+	ctx := context.Background()
+	store := newBulkStore()
+	store.addOperation("BLKO1", 1, nil)
+	store.setStatus("BLKO1", bulk.StatusCompleted)
+	service, _ := newControlTestService(store)
+
+	assert.ErrorContains(t, service.PauseBulkOperation(ctx, "BLKO1"), "cannot be paused")
+	assert.ErrorContains(t, service.CancelBulkOperation(ctx, "BLKO1"), "already complete")
+	_, err := service.ResumeBulkOperation(ctx, "BLKO1")
+	assert.ErrorContains(t, err, "cannot be resumed")
+	assert.Equal(t, bulk.StatusCompleted, store.operation("BLKO1").Status)
+}
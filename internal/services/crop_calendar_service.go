@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/Kisanlink/farmers-module/internal/auth"
+	"github.com/Kisanlink/farmers-module/internal/entities"
+	cropCycleEntity "github.com/Kisanlink/farmers-module/internal/entities/crop_cycle"
+	farmActivityEntity "github.com/Kisanlink/farmers-module/internal/entities/farm_activity"
+	"github.com/Kisanlink/farmers-module/internal/entities/requests"
+	"github.com/Kisanlink/farmers-module/internal/entities/responses"
+	stageEntity "github.com/Kisanlink/farmers-module/internal/entities/stage"
+	"github.com/Kisanlink/farmers-module/pkg/common"
+	"github.com/Kisanlink/kisanlink-db/pkg/base"
+	"github.com/Kisanlink/kisanlink-db/pkg/core/hash"
+)
+
+// planCycleActivities plans an activity for every active activity template of the stages of a
+// cycle's crop, dated from the cycle's start date, for the activities to be created with the
+// cycle. Templates counted from the start of a stage that follows a stage without a duration
+// cannot be dated and are reported in the calendar instead; the planned activities are added
+// to it once they are created.
+func (s *CropCycleServiceImpl) planCycleActivities(ctx context.Context, cycle *cropCycleEntity.CropCycle, userID string) ([]*farmActivityEntity.FarmActivity, *responses.CropCalendarData, error) {
+	calendar := &responses.CropCalendarData{PlannedActivities: []*responses.FarmActivityData{}}
+	if cycle.StartDate == nil {
+		return nil, calendar, nil
+	}
+
+	cropStages, err := s.cropStageRepo.GetCropStages(ctx, cycle.CropID)
+	if err != nil {
+		return nil, nil, err
+	}
+	cropStageIDs := make([]string, len(cropStages))
+	for i, cs := range cropStages {
+		cropStageIDs[i] = cs.ID
+	}
+	templates, err := s.templateRepo.ListActiveByCropStages(ctx, cropStageIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var activities []*farmActivityEntity.FarmActivity
+	stageStarts := stageEntity.StageStarts(cropStages, *cycle.StartDate)
+	for _, template := range templates {
+		plannedAt, ok := template.PlannedAt(*cycle.StartDate, stageStarts)
+		if !ok {
+			calendar.UnplannedTemplates = append(calendar.UnplannedTemplates, &responses.UnplannedTemplateData{
+				TemplateID:  template.ID,
+				CropStageID: template.CropStageID,
+				Name:        template.Name,
+				Reason:      "an earlier stage of the crop has no duration, so the stage's start date is unknown",
+			})
+			continue
+		}
+
+		metadata := make(entities.JSONB, len(template.Metadata)+1)
+		for k, v := range template.Metadata {
+			metadata[k] = v
+		}
+		metadata["activity_template_name"] = template.Name
+
+		cropStageID, templateID := template.CropStageID, template.ID
+		activity := &farmActivityEntity.FarmActivity{
+			BaseModel:          *base.NewBaseModel("FACT", hash.Medium),
+			CropCycleID:        cycle.ID,
+			CropStageID:        &cropStageID,
+			FarmerID:           cycle.FarmerID,
+			ActivityTemplateID: &templateID,
+			ActivityType:       template.ActivityType,
+			PlannedAt:          &plannedAt,
+			CreatedBy:          userID,
+			Status:             "PLANNED",
+			Output:             make(entities.JSONB),
+			Metadata:           metadata,
+		}
+		if err := activity.Validate(); err != nil {
+			return nil, nil, fmt.Errorf("invalid activity from template %s: %w", template.ID, err)
+		}
+		activities = append(activities, activity)
+	}
+
+	return activities, calendar, nil
+}
+
+// addPlannedActivities adds the activities created from a cycle's activity templates to its
+// crop calendar
+func addPlannedActivities(calendar *responses.CropCalendarData, activities []*farmActivityEntity.FarmActivity) {
+	for _, activity := range activities {
+		calendar.PlannedActivities = append(calendar.PlannedActivities, &responses.FarmActivityData{
+			ID:                 activity.ID,
+			CropCycleID:        activity.CropCycleID,
+			CropStageID:        activity.CropStageID,
+			ActivityTemplateID: activity.ActivityTemplateID,
+			ActivityType:       activity.ActivityType,
+			PlannedAt:          activity.PlannedAt,
+			CreatedBy:          activity.CreatedBy,
+			Status:             activity.Status,
+			Output:             activity.Output,
+			Metadata:           activity.Metadata,
+			CreatedAt:          activity.CreatedAt,
+			UpdatedAt:          activity.UpdatedAt,
+		})
+	}
+}
+
+// ReplanCycle moves a crop cycle's sowing date and shifts its planned activities by the same
+// number of days, so that a late or early sowing keeps the crop calendar in step. Completed
+// and cancelled activities are not moved.
+func (s *CropCycleServiceImpl) ReplanCycle(ctx context.Context, req interface{}) (interface{}, error) {
+	replanReq, ok := req.(*requests.ReplanCycleRequest)
+	if !ok {
+		return nil, common.ErrInvalidInput
+	}
+
+	// Extract authenticated user from context
+	userCtx, err := auth.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user context: %w", err)
+	}
+
+	// Check if authenticated user can update crop cycle
+	hasPermission, err := s.aaaService.CheckPermission(ctx, userCtx.AAAUserID, "cycle", "update", replanReq.ID, replanReq.OrgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !hasPermission {
+		return nil, common.ErrForbidden
+	}
+
+	if replanReq.StartDate.IsZero() {
+		return nil, fmt.Errorf("%w: start_date is required", common.ErrInvalidInput)
+	}
+
+	// Get existing cycle
+	cycle := &cropCycleEntity.CropCycle{}
+	_, err = s.cropCycleRepo.GetByID(ctx, replanReq.ID, cycle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get crop cycle: %w", err)
+	}
+	if cycle.Status == "COMPLETED" || cycle.Status == "CANCELLED" {
+		return nil, fmt.Errorf("%w: cannot replan cycle in terminal state: %s", common.ErrInvalidInput, cycle.Status)
+	}
+
+	shiftDays := replanShiftDays(cycle.StartDate, replanReq.StartDate)
+	shifted, err := s.cropCycleRepo.ShiftSchedule(ctx, cycle.ID, replanReq.StartDate, shiftDays, userCtx.AAAUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := responses.NewReplanCycleResponse(&responses.ReplanCycleData{
+		CropCycleID:       cycle.ID,
+		PreviousStartDate: cycle.StartDate,
+		StartDate:         replanReq.StartDate,
+		ShiftDays:         shiftDays,
+		ShiftedActivities: shifted,
+	}, "Crop cycle replanned successfully")
+	response.SetRequestID(replanReq.RequestID)
+	return &response, nil
+}
+
+// replanShiftDays returns the whole days between a cycle's start date and its new one. A cycle
+// without a start date has no activities dated from it to move.
+func replanShiftDays(previous *time.Time, startDate time.Time) int {
+	if previous == nil {
+		return 0
+	}
+	return int(math.Round(startDate.Sub(*previous).Hours() / 24))
+}
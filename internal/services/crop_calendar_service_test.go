@@ -0,0 +1,190 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Kisanlink/farmers-module/internal/auth"
+	"github.com/Kisanlink/farmers-module/internal/entities"
+	cropCycleEntity "github.com/Kisanlink/farmers-module/internal/entities/crop_cycle"
+	"github.com/Kisanlink/farmers-module/internal/entities/requests"
+	"github.com/Kisanlink/farmers-module/internal/entities/responses"
+	stageEntity "github.com/Kisanlink/farmers-module/internal/entities/stage"
+	"github.com/Kisanlink/farmers-module/internal/repo/crop_cycle"
+	stageRepo "github.com/Kisanlink/farmers-module/internal/repo/stage"
+	"github.com/Kisanlink/farmers-module/pkg/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func days(n int) *int {
+	return &n
+}
+
+func createCropStage(t *testing.T, db *gorm.DB, order int, duration *int, unit stageEntity.DurationUnit) *stageEntity.CropStage {
+	s := stageEntity.NewStage()
+	s.StageName = "Stage " + string(rune('A'+order))
+	require.NoError(t, db.Create(s).Error)
+
+	cs := stageEntity.NewCropStage()
+	cs.CropID, cs.StageID, cs.StageOrder = "CROP1", s.ID, order
+	cs.DurationDays, cs.DurationUnit = duration, unit
+	require.NoError(t, db.Create(cs).Error)
+	return cs
+}
+
+func createTemplate(t *testing.T, db *gorm.DB, cs *stageEntity.CropStage, name string, anchor stageEntity.OffsetAnchor, offsetDays int) *stageEntity.ActivityTemplate {
+	template := stageEntity.NewActivityTemplate()
+	template.CropStageID, template.Name, template.ActivityType = cs.ID, name, "FIELD_WORK"
+	template.OffsetAnchor, template.OffsetDays = anchor, offsetDays
+	require.NoError(t, db.Create(template).Error)
+	return template
+}
+
+func newCalendarTestService(t *testing.T) (*CropCycleServiceImpl, *gorm.DB) {
+	db := newSQLiteDB(t, cropCyclesTable)
+	require.NoError(t, db.AutoMigrate(&stageEntity.Stage{}, &stageEntity.CropStage{}, &stageEntity.ActivityTemplate{}))
+	return &CropCycleServiceImpl{
+		cropCycleRepo: crop_cycle.NewRepository(&sqliteManager{db: db}),
+		cropStageRepo: stageRepo.NewCropStageRepository(&sqliteManager{db: db}),
+		templateRepo:  stageRepo.NewActivityTemplateRepository(db),
+		aaaService:    &MockAAAService{},
+	}, db
+}
+
+func TestPlanCycleActivities(t *testing.T) {
+	service, db := newCalendarTestService(t)
+	ctx := context.Background()
+
+	// Sowing lasts 10 days and vegetative growth two weeks; flowering has no duration, so
+	// maturity's start is unknown
+	sowing := createCropStage(t, db, 1, days(10), stageEntity.DurationUnitDays)
+	vegetative := createCropStage(t, db, 2, days(2), stageEntity.DurationUnitWeeks)
+	flowering := createCropStage(t, db, 3, nil, stageEntity.DurationUnitDays)
+	maturity := createCropStage(t, db, 4, days(30), stageEntity.DurationUnitDays)
+
+	seed := createTemplate(t, db, sowing, "Seed treatment", stageEntity.OffsetAnchorSowing, 0)
+	seed.Metadata = entities.JSONB{"input": "Trichoderma"}
+	require.NoError(t, db.Save(seed).Error)
+	weeding := createTemplate(t, db, sowing, "Weeding", stageEntity.OffsetAnchorSowing, 20)
+	topDressing := createTemplate(t, db, vegetative, "Top dressing", stageEntity.OffsetAnchorStageStart, 3)
+	spraying := createTemplate(t, db, flowering, "Spraying", stageEntity.OffsetAnchorStageStart, 1)
+	harvest := createTemplate(t, db, maturity, "Harvest", stageEntity.OffsetAnchorStageStart, 0)
+	retired := createTemplate(t, db, vegetative, "Retired", stageEntity.OffsetAnchorSowing, 5)
+	require.NoError(t, db.Model(retired).Update("is_active", false).Error)
+
+	start := time.Date(2024, time.June, 10, 0, 0, 0, 0, time.UTC)
+	cycle := &cropCycleEntity.CropCycle{FarmerID: "FMRR1", CropID: "CROP1", StartDate: &start}
+	cycle.ID = "CRCY1"
+
+	activities, calendar, err := service.planCycleActivities(ctx, cycle, "user-1")
+	require.NoError(t, err)
+
+	planned := make(map[string]time.Time)
+	for _, activity := range activities {
+		assert.Equal(t, "CRCY1", activity.CropCycleID)
+		assert.Equal(t, "FMRR1", activity.FarmerID)
+		assert.Equal(t, "PLANNED", activity.Status)
+		assert.Equal(t, "user-1", activity.CreatedBy)
+		planned[*activity.ActivityTemplateID] = *activity.PlannedAt
+	}
+	assert.Equal(t, map[string]time.Time{
+		seed.ID:        start,
+		weeding.ID:     start.AddDate(0, 0, 20),
+		topDressing.ID: start.AddDate(0, 0, 10+3),
+		spraying.ID:    start.AddDate(0, 0, 10+14+1),
+	}, planned)
+
+	// The template's metadata is copied with its name
+	for _, activity := range activities {
+		if *activity.ActivityTemplateID == seed.ID {
+			assert.Equal(t, "Trichoderma", activity.Metadata["input"])
+			assert.Equal(t, "Seed treatment", activity.Metadata["activity_template_name"])
+		}
+	}
+
+	require.Len(t, calendar.UnplannedTemplates, 1)
+	assert.Equal(t, harvest.ID, calendar.UnplannedTemplates[0].TemplateID)
+	assert.Equal(t, maturity.ID, calendar.UnplannedTemplates[0].CropStageID)
+
+	// The calendar lists the activities once they are created
+	assert.Empty(t, calendar.PlannedActivities)
+	addPlannedActivities(calendar, activities)
+	require.Len(t, calendar.PlannedActivities, len(activities))
+	for i, activity := range activities {
+		assert.Equal(t, activity.ID, calendar.PlannedActivities[i].ID)
+		assert.Equal(t, activity.PlannedAt, calendar.PlannedActivities[i].PlannedAt)
+	}
+}
+
+func TestPlanCycleActivities_WithoutStartDate(t *testing.T) {
+	service, db := newCalendarTestService(t)
+	createTemplate(t, db, createCropStage(t, db, 1, days(10), stageEntity.DurationUnitDays), "Sowing", stageEntity.OffsetAnchorSowing, 0)
+
+	activities, calendar, err := service.planCycleActivities(context.Background(), &cropCycleEntity.CropCycle{CropID: "CROP1"}, "user-1")
+	require.NoError(t, err)
+	assert.Empty(t, activities)
+	assert.Equal(t, &responses.CropCalendarData{PlannedActivities: []*responses.FarmActivityData{}}, calendar)
+}
+
+func TestReplanShiftDays(t *testing.T) {
+	sown := time.Date(2024, time.June, 10, 0, 0, 0, 0, time.UTC)
+	ist := time.FixedZone("IST", 5*60*60+30*60)
+
+	tests := []struct {
+		name      string
+		previous  *time.Time
+		startDate time.Time
+		want      int
+	}{
+		{"no previous start date", nil, sown, 0},
+		{"same day", &sown, sown, 0},
+		{"later", &sown, time.Date(2024, time.June, 24, 0, 0, 0, 0, time.UTC), 14},
+		{"earlier", &sown, time.Date(2024, time.June, 3, 0, 0, 0, 0, time.UTC), -7},
+		{"midnight in another zone", &sown, time.Date(2024, time.June, 15, 0, 0, 0, 0, ist), 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, replanShiftDays(tt.previous, tt.startDate))
+		})
+	}
+}
+
+func TestReplanCycle(t *testing.T) {
+	service, db := newCalendarTestService(t)
+	aaa := service.aaaService.(*MockAAAService)
+	aaa.On("CheckPermission", mock.Anything, "user-1", "cycle", "update", mock.Anything, "org-1").Return(true, nil)
+	ctx := auth.SetUserInContext(context.Background(), &auth.UserContext{AAAUserID: "user-1"})
+
+	unsown := createTestCycle(t, db, "PLANNED", nil, 1)
+	ended := createTestCycle(t, db, "COMPLETED", nil, 1)
+	replan := func(cycleID string, startDate time.Time) (*responses.ReplanCycleData, error) {
+		result, err := service.ReplanCycle(ctx, &requests.ReplanCycleRequest{BaseRequest: requests.BaseRequest{OrgID: "org-1"}, ID: cycleID, StartDate: startDate})
+		if err != nil {
+			return nil, err
+		}
+		return result.(*responses.ReplanCycleResponse).Data, nil
+	}
+
+	// A cycle without a start date is given one without moving anything
+	sown := time.Date(2024, time.June, 10, 0, 0, 0, 0, time.UTC)
+	data, err := replan(unsown.ID, sown)
+	require.NoError(t, err)
+	assert.Nil(t, data.PreviousStartDate)
+	assert.Zero(t, data.ShiftDays)
+	assert.Zero(t, data.ShiftedActivities)
+	stored := &cropCycleEntity.CropCycle{}
+	require.NoError(t, db.First(stored, "id = ?", unsown.ID).Error)
+	require.NotNil(t, stored.StartDate)
+	assert.True(t, sown.Equal(*stored.StartDate))
+
+	_, err = replan(unsown.ID, time.Time{})
+	assert.ErrorIs(t, err, common.ErrInvalidInput)
+
+	_, err = replan(ended.ID, sown)
+	assert.ErrorIs(t, err, common.ErrInvalidInput)
+	assert.Contains(t, err.Error(), "terminal state")
+}
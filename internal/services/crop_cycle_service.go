@@ -6,26 +6,44 @@ import (
 
 	"github.com/Kisanlink/farmers-module/internal/auth"
 	cropCycleEntity "github.com/Kisanlink/farmers-module/internal/entities/crop_cycle"
+	farmActivityEntity "github.com/Kisanlink/farmers-module/internal/entities/farm_activity"
+	"github.com/Kisanlink/farmers-module/internal/entities/plot"
 	"github.com/Kisanlink/farmers-module/internal/entities/requests"
 	"github.com/Kisanlink/farmers-module/internal/entities/responses"
 	"github.com/Kisanlink/farmers-module/internal/repo/crop_cycle"
+	"github.com/Kisanlink/farmers-module/internal/repo/farm_activity"
+	"github.com/Kisanlink/farmers-module/internal/repo/stage"
 	"github.com/Kisanlink/farmers-module/pkg/common"
 	"github.com/Kisanlink/kisanlink-db/pkg/base"
+	"github.com/Kisanlink/kisanlink-db/pkg/core/hash"
 )
 
 // CropCycleServiceImpl implements CropCycleService
 type CropCycleServiceImpl struct {
-	cropCycleRepo *crop_cycle.CropCycleRepository
-	farmService   FarmService
-	aaaService    AAAService
+	cropCycleRepo    *crop_cycle.CropCycleRepository
+	cropStageRepo    *stage.CropStageRepository
+	templateRepo     stage.ActivityTemplateRepository
+	farmActivityRepo *farm_activity.FarmActivityRepository
+	farmService      FarmService
+	aaaService       AAAService
 }
 
 // NewCropCycleService creates a new crop cycle service
-func NewCropCycleService(cropCycleRepo *crop_cycle.CropCycleRepository, farmService FarmService, aaaService AAAService) CropCycleService {
+func NewCropCycleService(
+	cropCycleRepo *crop_cycle.CropCycleRepository,
+	cropStageRepo *stage.CropStageRepository,
+	templateRepo stage.ActivityTemplateRepository,
+	farmActivityRepo *farm_activity.FarmActivityRepository,
+	farmService FarmService,
+	aaaService AAAService,
+) CropCycleService {
 	return &CropCycleServiceImpl{
-		cropCycleRepo: cropCycleRepo,
-		farmService:   farmService,
-		aaaService:    aaaService,
+		cropCycleRepo:    cropCycleRepo,
+		cropStageRepo:    cropStageRepo,
+		templateRepo:     templateRepo,
+		farmActivityRepo: farmActivityRepo,
+		farmService:      farmService,
+		aaaService:       aaaService,
 	}
 }
 
@@ -63,6 +81,15 @@ func (s *CropCycleServiceImpl) StartCycle(ctx context.Context, req interface{})
 		return nil, fmt.Errorf("unexpected response type from farm service")
 	}
 
+	// A cycle on a plot must have the plot to itself and fit within it
+	if startReq.PlotID != nil {
+		areaHa, err := s.resolvePlotArea(ctx, startReq.FarmID, "", *startReq.PlotID, startReq.AreaHa)
+		if err != nil {
+			return nil, err
+		}
+		startReq.AreaHa = areaHa
+	}
+
 	// Validate area allocation if provided
 	if startReq.AreaHa != nil {
 		if err := s.cropCycleRepo.ValidateAreaAllocation(ctx, startReq.FarmID, "", *startReq.AreaHa); err != nil {
@@ -72,14 +99,19 @@ func (s *CropCycleServiceImpl) StartCycle(ctx context.Context, req interface{})
 
 	// Create crop cycle entity
 	cycle := &cropCycleEntity.CropCycle{
+		BaseModel: *base.NewBaseModel("CRCY", hash.Medium),
 		FarmID:    startReq.FarmID,
 		FarmerID:  farmData.Data.FarmerID,
+		PlotID:    startReq.PlotID,
 		AreaHa:    startReq.AreaHa,
 		Season:    startReq.Season,
 		Status:    "PLANNED",
 		StartDate: &startReq.StartDate,
 		CropID:    startReq.CropID,
 		VarietyID: startReq.VarietyID,
+
+		TreeCount:    startReq.TreeCount,
+		PlantingYear: startReq.PlantingYear,
 	}
 
 	// Validate the cycle
@@ -87,8 +119,18 @@ func (s *CropCycleServiceImpl) StartCycle(ctx context.Context, req interface{})
 		return nil, err
 	}
 
-	// Create the cycle in database
-	if err := s.cropCycleRepo.Create(ctx, cycle); err != nil {
+	// Plan the activities of the crop's activity templates from the sowing date
+	var activities []*farmActivityEntity.FarmActivity
+	var calendar *responses.CropCalendarData
+	if startReq.GenerateActivities {
+		if activities, calendar, err = s.planCycleActivities(ctx, cycle, userCtx.AAAUserID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Create the cycle and its planned activities in database, so that neither is kept
+	// without the other
+	if err := s.cropCycleRepo.CreateWithActivities(ctx, cycle, activities); err != nil {
 		return nil, fmt.Errorf("failed to create crop cycle: %w", err)
 	}
 
@@ -97,6 +139,7 @@ func (s *CropCycleServiceImpl) StartCycle(ctx context.Context, req interface{})
 		ID:        cycle.GetID(),
 		FarmID:    cycle.FarmID,
 		FarmerID:  cycle.FarmerID,
+		PlotID:    cycle.PlotID,
 		AreaHa:    cycle.AreaHa,
 		Season:    cycle.Season,
 		Status:    cycle.Status,
@@ -111,12 +154,20 @@ func (s *CropCycleServiceImpl) StartCycle(ctx context.Context, req interface{})
 			}
 			return nil
 		}(),
-		Outcome:   cycle.Outcome,
-		CreatedAt: cycle.CreatedAt,
-		UpdatedAt: cycle.UpdatedAt,
+		TreeCount:    cycle.TreeCount,
+		PlantingYear: cycle.PlantingYear,
+		Outcome:      cycle.Outcome,
+		CreatedAt:    cycle.CreatedAt,
+		UpdatedAt:    cycle.UpdatedAt,
+	}
+
+	response := responses.NewCropCycleResponse(cycleData, "Crop cycle started successfully")
+	if calendar != nil {
+		addPlannedActivities(calendar, activities)
+		response.Calendar = calendar
 	}
 
-	return responses.NewCropCycleResponse(cycleData, "Crop cycle started successfully"), nil
+	return response, nil
 }
 
 // UpdateCycle implements W11: Update crop cycle
@@ -159,6 +210,29 @@ func (s *CropCycleServiceImpl) UpdateCycle(ctx context.Context, req interface{})
 		return nil, fmt.Errorf("cannot change crop_id for an existing cycle - create a new cycle instead")
 	}
 
+	// Moving the cycle off its plot or to another one, or resizing it on its plot, is checked
+	// against the cycle's status and the plot
+	if updateReq.PlotID != nil && *updateReq.PlotID == "" {
+		if cycle.PlotID != nil && !cycle.CanModifyArea() {
+			return nil, common.ErrStatusNotModifiable
+		}
+		cycle.PlotID = nil
+	} else if updateReq.PlotID != nil || (updateReq.AreaHa != nil && cycle.PlotID != nil) {
+		if !cycle.CanModifyArea() {
+			return nil, common.ErrStatusNotModifiable
+		}
+		plotID := cycle.PlotID
+		if updateReq.PlotID != nil {
+			plotID = updateReq.PlotID
+		}
+		areaHa, err := s.resolvePlotArea(ctx, cycle.FarmID, cycle.ID, *plotID, updateReq.AreaHa)
+		if err != nil {
+			return nil, err
+		}
+		updateReq.AreaHa = areaHa
+		cycle.PlotID = plotID
+	}
+
 	// Validate area allocation if area is being updated
 	if updateReq.AreaHa != nil {
 		if !cycle.CanModifyArea() {
@@ -183,6 +257,12 @@ func (s *CropCycleServiceImpl) UpdateCycle(ctx context.Context, req interface{})
 	if updateReq.VarietyID != nil {
 		cycle.VarietyID = updateReq.VarietyID
 	}
+	if updateReq.TreeCount != nil {
+		cycle.TreeCount = updateReq.TreeCount
+	}
+	if updateReq.PlantingYear != nil {
+		cycle.PlantingYear = updateReq.PlantingYear
+	}
 
 	// Validate the updated cycle
 	if err := cycle.Validate(); err != nil {
@@ -199,6 +279,7 @@ func (s *CropCycleServiceImpl) UpdateCycle(ctx context.Context, req interface{})
 		ID:        cycle.GetID(),
 		FarmID:    cycle.FarmID,
 		FarmerID:  cycle.FarmerID,
+		PlotID:    cycle.PlotID,
 		AreaHa:    cycle.AreaHa,
 		Season:    cycle.Season,
 		Status:    cycle.Status,
@@ -213,14 +294,33 @@ func (s *CropCycleServiceImpl) UpdateCycle(ctx context.Context, req interface{})
 			}
 			return nil
 		}(),
-		Outcome:   cycle.Outcome,
-		CreatedAt: cycle.CreatedAt,
-		UpdatedAt: cycle.UpdatedAt,
+		TreeCount:    cycle.TreeCount,
+		PlantingYear: cycle.PlantingYear,
+		Outcome:      cycle.Outcome,
+		CreatedAt:    cycle.CreatedAt,
+		UpdatedAt:    cycle.UpdatedAt,
 	}
 
 	return responses.NewCropCycleResponse(cycleData, "Crop cycle updated successfully"), nil
 }
 
+// resolvePlotArea checks that a cycle can be grown on a plot and returns the area it will
+// take: the requested area, which may not exceed the plot's, or the whole plot
+func (s *CropCycleServiceImpl) resolvePlotArea(ctx context.Context, farmID, cycleID, plotID string, areaHa *float64) (*float64, error) {
+	p, err := s.cropCycleRepo.ValidatePlotAllocation(ctx, farmID, cycleID, plotID)
+	if err != nil {
+		return nil, err
+	}
+	if areaHa == nil {
+		plotArea := p.AreaHa
+		return &plotArea, nil
+	}
+	if *areaHa > p.AreaHa+plot.ToleranceHa {
+		return nil, fmt.Errorf("%w: area_ha %.4f exceeds the %.4f ha of plot %s", common.ErrInvalidInput, *areaHa, p.AreaHa, p.ID)
+	}
+	return areaHa, nil
+}
+
 // EndCycle implements W12: End crop cycle
 func (s *CropCycleServiceImpl) EndCycle(ctx context.Context, req interface{}) (interface{}, error) {
 	endReq, ok := req.(*requests.EndCycleRequest)
@@ -269,7 +369,33 @@ func (s *CropCycleServiceImpl) EndCycle(ctx context.Context, req interface{}) (i
 
 	// Validate outcome data based on season type
 	if err := cycle.ValidateOutcome(); err != nil {
-		return nil, fmt.Errorf("invalid outcome data: %w", err)
+		return nil, fmt.Errorf("%w: invalid outcome data: %v", common.ErrInvalidInput, err)
+	}
+
+	// Check the outcome's units and record its yield in canonical units. Local area units
+	// are sized by the farm's state.
+	stateCode := ""
+	if _, hasAreaUnit := cycle.Outcome["area_unit"]; hasAreaUnit {
+		farmResponse, err := s.farmService.GetFarm(ctx, cycle.FarmID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get farm: %w", err)
+		}
+		if farmData, ok := farmResponse.(*responses.FarmResponse); ok && farmData.Data != nil && farmData.Data.StateCode != nil {
+			stateCode = *farmData.Data.StateCode
+		}
+	}
+	if err := cycle.NormalizeOutcome(stateCode); err != nil {
+		return nil, fmt.Errorf("%w: invalid outcome data: %v", common.ErrInvalidInput, err)
+	}
+
+	// Compare the yield with the one expected of the variety, keeping the benchmarks as
+	// they are when the cycle ends
+	if cycle.Status == "COMPLETED" && len(cycle.Outcome) > 0 {
+		benchmarked, err := s.cropCycleRepo.GetWithBenchmarks(ctx, cycle.ID)
+		if err != nil {
+			return nil, err
+		}
+		cycle.RecordYieldVariance(benchmarked.EstimateYield())
 	}
 
 	// Update the cycle in database
@@ -282,6 +408,7 @@ func (s *CropCycleServiceImpl) EndCycle(ctx context.Context, req interface{}) (i
 		ID:        cycle.GetID(),
 		FarmID:    cycle.FarmID,
 		FarmerID:  cycle.FarmerID,
+		PlotID:    cycle.PlotID,
 		AreaHa:    cycle.AreaHa,
 		Season:    cycle.Season,
 		Status:    cycle.Status,
@@ -296,9 +423,11 @@ func (s *CropCycleServiceImpl) EndCycle(ctx context.Context, req interface{}) (i
 			}
 			return nil
 		}(),
-		Outcome:   cycle.Outcome,
-		CreatedAt: cycle.CreatedAt,
-		UpdatedAt: cycle.UpdatedAt,
+		TreeCount:    cycle.TreeCount,
+		PlantingYear: cycle.PlantingYear,
+		Outcome:      cycle.Outcome,
+		CreatedAt:    cycle.CreatedAt,
+		UpdatedAt:    cycle.UpdatedAt,
 	}
 
 	return responses.NewCropCycleResponse(cycleData, "Crop cycle ended successfully"), nil
@@ -378,6 +507,7 @@ func (s *CropCycleServiceImpl) ListCycles(ctx context.Context, req interface{})
 			ID:        cycle.GetID(),
 			FarmID:    cycle.FarmID,
 			FarmerID:  cycle.FarmerID,
+			PlotID:    cycle.PlotID,
 			AreaHa:    cycle.AreaHa,
 			Season:    cycle.Season,
 			Status:    cycle.Status,
@@ -392,9 +522,11 @@ func (s *CropCycleServiceImpl) ListCycles(ctx context.Context, req interface{})
 				}
 				return nil
 			}(),
-			Outcome:   cycle.Outcome,
-			CreatedAt: cycle.CreatedAt,
-			UpdatedAt: cycle.UpdatedAt,
+			TreeCount:    cycle.TreeCount,
+			PlantingYear: cycle.PlantingYear,
+			Outcome:      cycle.Outcome,
+			CreatedAt:    cycle.CreatedAt,
+			UpdatedAt:    cycle.UpdatedAt,
 		}
 		cycleDataList = append(cycleDataList, cycleData)
 	}
@@ -416,6 +548,7 @@ func (s *CropCycleServiceImpl) GetCropCycle(ctx context.Context, cycleID string)
 		ID:        cycle.GetID(),
 		FarmID:    cycle.FarmID,
 		FarmerID:  cycle.FarmerID,
+		PlotID:    cycle.PlotID,
 		AreaHa:    cycle.AreaHa,
 		Season:    cycle.Season,
 		Status:    cycle.Status,
@@ -430,9 +563,11 @@ func (s *CropCycleServiceImpl) GetCropCycle(ctx context.Context, cycleID string)
 			}
 			return nil
 		}(),
-		Outcome:   cycle.Outcome,
-		CreatedAt: cycle.CreatedAt,
-		UpdatedAt: cycle.UpdatedAt,
+		TreeCount:    cycle.TreeCount,
+		PlantingYear: cycle.PlantingYear,
+		Outcome:      cycle.Outcome,
+		CreatedAt:    cycle.CreatedAt,
+		UpdatedAt:    cycle.UpdatedAt,
 	}
 
 	return responses.NewCropCycleResponse(cycleData, "Crop cycle retrieved successfully"), nil
@@ -461,6 +596,17 @@ func (s *CropCycleServiceImpl) GetAreaAllocationSummary(ctx context.Context, far
 		UtilizationPercent: utilizationPercent,
 		ActiveCyclesCount:  summary.ActiveCyclesCount,
 		PlannedCyclesCount: summary.PlannedCyclesCount,
+		PlottedAreaHa:      summary.PlottedAreaHa,
+		PlotsDisjoint:      len(summary.PlotConflicts) == 0,
+	}
+	for _, conflict := range summary.PlotConflicts {
+		summaryData.PlotConflicts = append(summaryData.PlotConflicts, &responses.PlotConflict{
+			CropCycleID:      conflict.CycleID,
+			PlotID:           conflict.PlotID,
+			OtherCropCycleID: conflict.OtherCycleID,
+			OtherPlotID:      conflict.OtherPlotID,
+			OverlapAreaHa:    conflict.OverlapAreaHa,
+		})
 	}
 
 	return responses.NewAreaAllocationSummaryResponse(summaryData, "Area allocation summary retrieved successfully"), nil
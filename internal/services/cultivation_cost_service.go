@@ -0,0 +1,424 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/Kisanlink/farmers-module/internal/auth"
+	cropCycleEntity "github.com/Kisanlink/farmers-module/internal/entities/crop_cycle"
+	farmActivityEntity "github.com/Kisanlink/farmers-module/internal/entities/farm_activity"
+	"github.com/Kisanlink/farmers-module/internal/entities/input_product"
+	"github.com/Kisanlink/farmers-module/internal/entities/requests"
+	"github.com/Kisanlink/farmers-module/internal/entities/responses"
+	"github.com/Kisanlink/farmers-module/internal/repo/crop_cycle"
+	farmRepo "github.com/Kisanlink/farmers-module/internal/repo/farm"
+	"github.com/Kisanlink/farmers-module/internal/repo/farm_activity"
+	inputProductRepo "github.com/Kisanlink/farmers-module/internal/repo/input_product"
+	"github.com/Kisanlink/farmers-module/pkg/common"
+	"github.com/Kisanlink/farmers-module/pkg/units"
+)
+
+// CultivationCostServiceImpl implements CultivationCostService
+type CultivationCostServiceImpl struct {
+	inputRepo        farm_activity.ActivityInputRepository
+	productRepo      inputProductRepo.InputProductRepository
+	farmActivityRepo *farm_activity.FarmActivityRepository
+	cropCycleRepo    *crop_cycle.CropCycleRepository
+	farmRepo         *farmRepo.FarmRepository
+	aaaService       AAAService
+}
+
+// NewCultivationCostService creates a new cultivation cost service
+func NewCultivationCostService(
+	inputRepo farm_activity.ActivityInputRepository,
+	productRepo inputProductRepo.InputProductRepository,
+	farmActivityRepo *farm_activity.FarmActivityRepository,
+	cropCycleRepo *crop_cycle.CropCycleRepository,
+	farmRepo *farmRepo.FarmRepository,
+	aaaService AAAService,
+) CultivationCostService {
+	return &CultivationCostServiceImpl{
+		inputRepo:        inputRepo,
+		productRepo:      productRepo,
+		farmActivityRepo: farmActivityRepo,
+		cropCycleRepo:    cropCycleRepo,
+		farmRepo:         farmRepo,
+		aaaService:       aaaService,
+	}
+}
+
+// SetActivityInputs replaces the input line items of a farm activity. Each line item must
+// name an active product of the input master and be recorded in the product's unit or one
+// convertible to it.
+func (s *CultivationCostServiceImpl) SetActivityInputs(ctx context.Context, req interface{}) (interface{}, error) {
+	setReq, ok := req.(*requests.SetActivityInputsRequest)
+	if !ok {
+		return nil, common.ErrInvalidInput
+	}
+
+	// Extract authenticated user from context
+	userCtx, err := auth.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user context: %w", err)
+	}
+
+	// Check if authenticated user can update activity
+	hasPermission, err := s.aaaService.CheckPermission(ctx, userCtx.AAAUserID, "activity", "update", setReq.ActivityID, setReq.OrgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !hasPermission {
+		return nil, common.ErrForbidden
+	}
+
+	activity := &farmActivityEntity.FarmActivity{}
+	_, err = s.farmActivityRepo.GetByID(ctx, setReq.ActivityID, activity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get farm activity: %w", err)
+	}
+	if activity.Status == "CANCELLED" {
+		return nil, fmt.Errorf("%w: cannot record inputs of a cancelled activity", common.ErrInvalidInput)
+	}
+
+	productIDs := make([]string, len(setReq.Inputs))
+	for i, item := range setReq.Inputs {
+		productIDs[i] = item.InputProductID
+	}
+	products, err := s.productRepo.GetByIDs(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	inputs := make([]*farmActivityEntity.ActivityInput, len(setReq.Inputs))
+	for i, item := range setReq.Inputs {
+		product, ok := products[item.InputProductID]
+		if !ok {
+			return nil, fmt.Errorf("%w: input %d: unknown input product %s", common.ErrInvalidInput, i+1, item.InputProductID)
+		}
+		if !product.IsActive {
+			return nil, fmt.Errorf("%w: input %d: input product %s is no longer in use", common.ErrInvalidInput, i+1, product.Name)
+		}
+		// Quantities in another unit of the same dimension as the product's are converted,
+		// along with their unit cost
+		quantity, unitCost := item.Quantity, item.UnitCost
+		unit := strings.TrimSpace(item.Unit)
+		if unit != "" && !strings.EqualFold(unit, product.Unit) {
+			perUnit, err := units.Convert(1, unit, product.Unit, "")
+			if err != nil {
+				return nil, fmt.Errorf("%w: input %d: %s is recorded in %s, not %s", common.ErrInvalidInput, i+1, product.Name, product.Unit, unit)
+			}
+			quantity *= perUnit
+			if unitCost != nil {
+				converted := *unitCost / perUnit
+				unitCost = &converted
+			}
+		}
+
+		input := farmActivityEntity.NewActivityInput()
+		input.FarmActivityID = activity.ID
+		input.CropCycleID = activity.CropCycleID
+		input.InputProductID = product.ID
+		input.Category = product.Category
+		input.Quantity = quantity
+		input.Unit = product.Unit
+		input.SetCost(unitCost, item.TotalCost)
+		input.Supplier = item.Supplier
+		input.Notes = item.Notes
+		input.CreatedBy = userCtx.AAAUserID
+		if err := input.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: input %d: quantity must be positive and costs must not be negative", err, i+1)
+		}
+		input.InputProduct = product
+		inputs[i] = input
+	}
+
+	if err := s.inputRepo.ReplaceForActivity(ctx, activity.ID, inputs, userCtx.AAAUserID); err != nil {
+		return nil, err
+	}
+
+	response := responses.NewActivityInputsResponse(convertActivityInputsToData(activity, inputs), "Activity inputs recorded successfully")
+	response.SetRequestID(setReq.RequestID)
+	return &response, nil
+}
+
+// ListActivityInputs gets the input line items of a farm activity
+func (s *CultivationCostServiceImpl) ListActivityInputs(ctx context.Context, req interface{}) (interface{}, error) {
+	listReq, ok := req.(*requests.ListActivityInputsRequest)
+	if !ok {
+		return nil, common.ErrInvalidInput
+	}
+
+	// Extract authenticated user from context
+	userCtx, err := auth.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user context: %w", err)
+	}
+
+	// Check if authenticated user can read activity
+	hasPermission, err := s.aaaService.CheckPermission(ctx, userCtx.AAAUserID, "activity", "read", listReq.ActivityID, listReq.OrgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !hasPermission {
+		return nil, common.ErrForbidden
+	}
+
+	activity := &farmActivityEntity.FarmActivity{}
+	_, err = s.farmActivityRepo.GetByID(ctx, listReq.ActivityID, activity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get farm activity: %w", err)
+	}
+
+	inputs, err := s.inputRepo.ListByActivity(ctx, activity.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := responses.NewActivityInputsResponse(convertActivityInputsToData(activity, inputs), "Activity inputs retrieved successfully")
+	response.SetRequestID(listReq.RequestID)
+	return &response, nil
+}
+
+// GetCycleCosts gets the cost of cultivation of a crop cycle: the cost of the inputs of its
+// completed activities by category, per hectare of the cycle's area and per unit of the
+// yield recorded in its outcome. Inputs of activities still planned are reported apart.
+func (s *CultivationCostServiceImpl) GetCycleCosts(ctx context.Context, req interface{}) (interface{}, error) {
+	costReq, ok := req.(*requests.CycleCostsRequest)
+	if !ok {
+		return nil, common.ErrInvalidInput
+	}
+
+	// Extract authenticated user from context
+	userCtx, err := auth.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user context: %w", err)
+	}
+
+	// Check if authenticated user can read crop cycle
+	hasPermission, err := s.aaaService.CheckPermission(ctx, userCtx.AAAUserID, "cycle", "read", costReq.CropCycleID, costReq.OrgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !hasPermission {
+		return nil, common.ErrForbidden
+	}
+
+	cycle := &cropCycleEntity.CropCycle{}
+	_, err = s.cropCycleRepo.GetByID(ctx, costReq.CropCycleID, cycle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get crop cycle: %w", err)
+	}
+
+	totals, err := s.inputRepo.SumCostsByCycles(ctx, []string{cycle.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	response := responses.NewCycleCostResponse(buildCycleCost(cycle, totals), "Crop cycle costs retrieved successfully")
+	response.SetRequestID(costReq.RequestID)
+	return &response, nil
+}
+
+// GetFarmCosts gets the cost of cultivation of a farm's crop cycles, optionally of a season
+// and of cycles started in a year, in total and per hectare cropped. Cancelled cycles are
+// left out.
+func (s *CultivationCostServiceImpl) GetFarmCosts(ctx context.Context, req interface{}) (interface{}, error) {
+	costReq, ok := req.(*requests.FarmCostsRequest)
+	if !ok {
+		return nil, common.ErrInvalidInput
+	}
+
+	farm, err := getFarmForAction(ctx, s.farmRepo, s.aaaService, costReq.FarmID, "read")
+	if err != nil {
+		return nil, err
+	}
+
+	cycles, err := s.cropCycleRepo.ListFarmCycles(ctx, farm.ID, costReq.Season, costReq.Year)
+	if err != nil {
+		return nil, err
+	}
+	cycleIDs := make([]string, len(cycles))
+	for i, cycle := range cycles {
+		cycleIDs[i] = cycle.ID
+	}
+	totals, err := s.inputRepo.SumCostsByCycles(ctx, cycleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &responses.FarmCostData{
+		FarmID: farm.ID,
+		Season: costReq.Season,
+		Year:   costReq.Year,
+		Cycles: make([]*responses.CycleCostData, len(cycles)),
+	}
+	for i, cycle := range cycles {
+		cycleCost := buildCycleCost(cycle, totals)
+		data.Cycles[i] = cycleCost
+		data.TotalCost += cycleCost.TotalCost
+		data.PlannedCost += cycleCost.PlannedCost
+		if cycle.AreaHa != nil {
+			data.CroppedAreaHa += *cycle.AreaHa
+		}
+	}
+	data.Categories = categoryCosts(totals)
+	data.Production = cropProduction(cycles)
+	if data.CroppedAreaHa > 0 {
+		perHectare := roundCost(data.TotalCost / data.CroppedAreaHa)
+		data.CostPerHectare = &perHectare
+	}
+
+	response := responses.NewFarmCostResponse(data, "Farm costs retrieved successfully")
+	response.SetRequestID(costReq.RequestID)
+	return &response, nil
+}
+
+// buildCycleCost builds the cost of cultivation of a crop cycle from the input cost totals,
+// which may include other cycles
+func buildCycleCost(cycle *cropCycleEntity.CropCycle, totals []*farm_activity.InputCostTotal) *responses.CycleCostData {
+	var cycleTotals []*farm_activity.InputCostTotal
+	for _, total := range totals {
+		if total.CropCycleID == cycle.ID {
+			cycleTotals = append(cycleTotals, total)
+		}
+	}
+
+	data := &responses.CycleCostData{
+		CropCycleID: cycle.ID,
+		FarmID:      cycle.FarmID,
+		CropID:      cycle.CropID,
+		CropName:    cycle.GetCropName(),
+		Season:      cycle.Season,
+		Status:      cycle.Status,
+		StartDate:   cycle.StartDate,
+		AreaHa:      cycle.AreaHa,
+		Categories:  categoryCosts(cycleTotals),
+	}
+	for _, category := range data.Categories {
+		data.TotalCost += category.Cost
+		data.PlannedCost += category.PlannedCost
+	}
+	if cycle.AreaHa != nil && *cycle.AreaHa > 0 {
+		perHectare := roundCost(data.TotalCost / *cycle.AreaHa)
+		data.CostPerHectare = &perHectare
+	}
+
+	data.YieldPerHectare, data.TotalYield, data.YieldUnit = cycle.OutcomeYield()
+	data.CostPerYieldUnit = costPerYieldUnit(data.TotalCost, data.CostPerHectare, data.YieldPerHectare, data.TotalYield)
+
+	if perHectare, total, unit := cycle.CanonicalYield(); unit != "" {
+		data.NormalizedYield = &responses.NormalizedYieldData{
+			YieldUnit:        unit,
+			YieldPerHectare:  perHectare,
+			TotalYield:       total,
+			CostPerYieldUnit: costPerYieldUnit(data.TotalCost, data.CostPerHectare, perHectare, total),
+		}
+	}
+	return data
+}
+
+// costPerYieldUnit returns the cost of a unit of yield from the total yield or, when that is
+// not known, from the yield per hectare, or nil when neither is known
+func costPerYieldUnit(totalCost float64, costPerHectare, yieldPerHectare, totalYield *float64) *float64 {
+	switch {
+	case totalYield != nil && *totalYield > 0:
+		perUnit := roundCost(totalCost / *totalYield)
+		return &perUnit
+	case costPerHectare != nil && yieldPerHectare != nil && *yieldPerHectare > 0:
+		perUnit := roundCost(*costPerHectare / *yieldPerHectare)
+		return &perUnit
+	}
+	return nil
+}
+
+// cropProduction adds up the yields of crop cycles by crop in canonical units. A cycle
+// without a total yield counts its yield per hectare over its area; cycles whose yield is
+// not known are left out.
+func cropProduction(cycles []*cropCycleEntity.CropCycle) []*responses.CropProductionData {
+	var production []*responses.CropProductionData
+	byCrop := make(map[string]*responses.CropProductionData)
+	for _, cycle := range cycles {
+		perHectare, total, unit := cycle.CanonicalYield()
+		if total == nil && perHectare != nil && cycle.AreaHa != nil {
+			value := *perHectare * *cycle.AreaHa
+			total = &value
+		}
+		if total == nil {
+			continue
+		}
+		key := cycle.CropID + "|" + unit
+		crop, ok := byCrop[key]
+		if !ok {
+			crop = &responses.CropProductionData{CropID: cycle.CropID, CropName: cycle.GetCropName(), YieldUnit: unit}
+			byCrop[key] = crop
+			production = append(production, crop)
+		}
+		crop.TotalYield += *total
+		crop.Cycles++
+	}
+	return production
+}
+
+// categoryCosts sums input cost totals by category, in the order of the input categories.
+// Categories without line items are left out.
+func categoryCosts(totals []*farm_activity.InputCostTotal) []*responses.CategoryCostData {
+	byCategory := make(map[string]*responses.CategoryCostData)
+	for _, total := range totals {
+		category, ok := byCategory[total.Category]
+		if !ok {
+			category = &responses.CategoryCostData{Category: total.Category}
+			byCategory[total.Category] = category
+		}
+		if total.Completed {
+			category.Cost += total.TotalCost
+		} else {
+			category.PlannedCost += total.TotalCost
+		}
+		category.LineItems += total.LineItems
+	}
+
+	categories := make([]*responses.CategoryCostData, 0, len(byCategory))
+	for _, c := range input_product.InputCategories {
+		if category, ok := byCategory[string(c)]; ok {
+			categories = append(categories, category)
+		}
+	}
+	return categories
+}
+
+// convertActivityInputsToData converts the input line items of a farm activity to their
+// response data
+func convertActivityInputsToData(activity *farmActivityEntity.FarmActivity, inputs []*farmActivityEntity.ActivityInput) *responses.ActivityInputsData {
+	data := &responses.ActivityInputsData{
+		FarmActivityID: activity.ID,
+		CropCycleID:    activity.CropCycleID,
+		Inputs:         make([]*responses.ActivityInputData, len(inputs)),
+	}
+	for i, input := range inputs {
+		inputData := &responses.ActivityInputData{
+			ID:             input.ID,
+			InputProductID: input.InputProductID,
+			Category:       string(input.Category),
+			Quantity:       input.Quantity,
+			Unit:           input.Unit,
+			UnitCost:       input.UnitCost,
+			TotalCost:      input.TotalCost,
+			Supplier:       input.Supplier,
+			Notes:          input.Notes,
+			CreatedAt:      input.CreatedAt,
+		}
+		if input.InputProduct != nil {
+			inputData.ProductName = input.InputProduct.Name
+		}
+		data.Inputs[i] = inputData
+		data.TotalCost += input.TotalCost
+	}
+	return data
+}
+
+// roundCost rounds an amount in rupees to paise
+func roundCost(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
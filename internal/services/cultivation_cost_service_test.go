@@ -0,0 +1,198 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Kisanlink/farmers-module/internal/auth"
+	"github.com/Kisanlink/farmers-module/internal/entities"
+	"github.com/Kisanlink/farmers-module/internal/entities/crop"
+	cropCycleEntity "github.com/Kisanlink/farmers-module/internal/entities/crop_cycle"
+	"github.com/Kisanlink/farmers-module/internal/entities/requests"
+	"github.com/Kisanlink/farmers-module/internal/entities/responses"
+	"github.com/Kisanlink/farmers-module/internal/repo/crop_cycle"
+	farmRepo "github.com/Kisanlink/farmers-module/internal/repo/farm"
+	"github.com/Kisanlink/farmers-module/internal/repo/farm_activity"
+	"github.com/Kisanlink/kisanlink-db/pkg/base"
+	"github.com/Kisanlink/kisanlink-db/pkg/core/hash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeInputRepo returns fixed input cost totals
+type fakeInputRepo struct {
+	farm_activity.ActivityInputRepository
+	totals []*farm_activity.InputCostTotal
+}
+
+func (r *fakeInputRepo) SumCostsByCycles(ctx context.Context, cycleIDs []string) ([]*farm_activity.InputCostTotal, error) {
+	var totals []*farm_activity.InputCostTotal
+	for _, total := range r.totals {
+		for _, id := range cycleIDs {
+			if total.CropCycleID == id {
+				totals = append(totals, total)
+			}
+		}
+	}
+	return totals, nil
+}
+
+func costTotal(cycleID, category string, completed bool, cost float64) *farm_activity.InputCostTotal {
+	return &farm_activity.InputCostTotal{CropCycleID: cycleID, Category: category, Completed: completed, TotalCost: cost, LineItems: 1}
+}
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+func TestBuildCycleCost(t *testing.T) {
+	cycle := &cropCycleEntity.CropCycle{
+		AreaHa:  floatPtr(2),
+		Season:  "KHARIF",
+		CropID:  "CROP1",
+		Outcome: entities.JSONB{"yield_per_hectare": 20.0, "total_yield": 40.0, "yield_unit": "quintal"},
+	}
+	cycle.ID = "CRCY1"
+	totals := []*farm_activity.InputCostTotal{
+		costTotal("CRCY1", "LABOUR", true, 1000.333),
+		costTotal("CRCY1", "SEED", true, 3000),
+		costTotal("CRCY1", "SEED", false, 500),
+		costTotal("CRCY1", "FERTILIZER", true, 4000),
+		costTotal("CRCY2", "SEED", true, 9999),
+	}
+
+	data := buildCycleCost(cycle, totals)
+
+	// Categories come in reporting order, with planned inputs kept apart
+	require.Len(t, data.Categories, 3)
+	assert.Equal(t, "SEED", data.Categories[0].Category)
+	assert.Equal(t, 3000.0, data.Categories[0].Cost)
+	assert.Equal(t, 500.0, data.Categories[0].PlannedCost)
+	assert.Equal(t, int64(2), data.Categories[0].LineItems)
+	assert.Equal(t, "FERTILIZER", data.Categories[1].Category)
+	assert.Equal(t, "LABOUR", data.Categories[2].Category)
+
+	assert.InDelta(t, 8000.333, data.TotalCost, 0.0001)
+	assert.Equal(t, 500.0, data.PlannedCost)
+	assert.Equal(t, floatPtr(4000.17), data.CostPerHectare)
+
+	// The cost of a unit of yield is taken from the total yield
+	assert.Equal(t, "quintal", data.YieldUnit)
+	assert.Equal(t, floatPtr(200.01), data.CostPerYieldUnit)
+	require.NotNil(t, data.NormalizedYield)
+	assert.Equal(t, "kg", data.NormalizedYield.YieldUnit)
+	assert.Equal(t, floatPtr(4000), data.NormalizedYield.TotalYield)
+	assert.Equal(t, floatPtr(2), data.NormalizedYield.CostPerYieldUnit)
+}
+
+func TestBuildCycleCost_WithoutAreaOrYield(t *testing.T) {
+	cycle := &cropCycleEntity.CropCycle{Season: "KHARIF", CropID: "CROP1"}
+	cycle.ID = "CRCY1"
+
+	data := buildCycleCost(cycle, []*farm_activity.InputCostTotal{costTotal("CRCY1", "SEED", true, 3000)})
+	assert.Equal(t, 3000.0, data.TotalCost)
+	assert.Nil(t, data.CostPerHectare)
+	assert.Nil(t, data.CostPerYieldUnit)
+	assert.Nil(t, data.NormalizedYield)
+
+	// Without any inputs a cycle costs nothing
+	data = buildCycleCost(cycle, nil)
+	assert.Empty(t, data.Categories)
+	assert.Zero(t, data.TotalCost)
+}
+
+func TestCostPerYieldUnit(t *testing.T) {
+	tests := []struct {
+		name            string
+		totalCost       float64
+		costPerHectare  *float64
+		yieldPerHectare *float64
+		totalYield      *float64
+		want            *float64
+	}{
+		{"from the total yield", 9000, floatPtr(3000), floatPtr(20), floatPtr(60), floatPtr(150)},
+		{"from the yield per hectare", 9000, floatPtr(3000), floatPtr(20), nil, floatPtr(150)},
+		{"zero total yield", 9000, floatPtr(3000), floatPtr(20), floatPtr(0), floatPtr(150)},
+		{"rounded to paise", 1000, nil, nil, floatPtr(3), floatPtr(333.33)},
+		{"no area", 9000, nil, floatPtr(20), nil, nil},
+		{"no yield", 9000, floatPtr(3000), nil, nil, nil},
+		{"zero yield per hectare", 9000, floatPtr(3000), floatPtr(0), nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, costPerYieldUnit(tt.totalCost, tt.costPerHectare, tt.yieldPerHectare, tt.totalYield))
+		})
+	}
+}
+
+func TestGetFarmCosts(t *testing.T) {
+	db := newSQLiteDB(t, farmsTable, cropCyclesTable)
+	require.NoError(t, db.AutoMigrate(&crop.Crop{}))
+	insertFarm(t, db, "FARM1", "org-1", "")
+
+	aaa := &MockAAAService{}
+	aaa.On("CheckPermission", mock.Anything, "user-1", "farm", "read", "FARM1", "org-1").Return(true, nil)
+	ctx := auth.SetUserInContext(context.Background(), &auth.UserContext{AAAUserID: "user-1"})
+
+	createCycle := func(season, status string, areaHa float64, outcome entities.JSONB) *cropCycleEntity.CropCycle {
+		cycle := &cropCycleEntity.CropCycle{
+			BaseModel: *base.NewBaseModel("CRCY", hash.Medium),
+			FarmID:    "FARM1",
+			FarmerID:  "FMRRFARM1",
+			AreaHa:    &areaHa,
+			Season:    season,
+			Status:    status,
+			CropID:    "CROP1",
+			Outcome:   outcome,
+		}
+		require.NoError(t, db.Create(cycle).Error)
+		return cycle
+	}
+	kharif := createCycle("KHARIF", "COMPLETED", 2, entities.JSONB{"total_yield": 40.0, "yield_unit": "quintal"})
+	rabi := createCycle("RABI", "ACTIVE", 1.5, entities.JSONB{"yield_per_hectare": 10.0, "yield_unit": "quintal"})
+	cancelled := createCycle("RABI", "CANCELLED", 1, entities.JSONB{})
+
+	service := &CultivationCostServiceImpl{
+		inputRepo: &fakeInputRepo{totals: []*farm_activity.InputCostTotal{
+			costTotal(kharif.ID, "SEED", true, 2000),
+			costTotal(kharif.ID, "FERTILIZER", true, 4000),
+			costTotal(kharif.ID, "PESTICIDE", false, 1000),
+			costTotal(rabi.ID, "SEED", true, 1000),
+			costTotal(cancelled.ID, "SEED", true, 5000),
+		}},
+		cropCycleRepo: crop_cycle.NewRepository(&sqliteManager{db: db}),
+		farmRepo:      farmRepo.NewFarmRepository(&sqliteManager{db: db}),
+		aaaService:    aaa,
+	}
+	farmCosts := func(season string) *responses.FarmCostData {
+		result, err := service.GetFarmCosts(ctx, &requests.FarmCostsRequest{FarmID: "FARM1", Season: season})
+		require.NoError(t, err)
+		return result.(*responses.FarmCostResponse).Data
+	}
+
+	// Cancelled cycles are left out of the totals and the cropped area
+	data := farmCosts("")
+	require.Len(t, data.Cycles, 2)
+	assert.Equal(t, 7000.0, data.TotalCost)
+	assert.Equal(t, 1000.0, data.PlannedCost)
+	assert.Equal(t, 3.5, data.CroppedAreaHa)
+	assert.Equal(t, floatPtr(2000), data.CostPerHectare)
+	require.Len(t, data.Categories, 3)
+	assert.Equal(t, 3000.0, data.Categories[0].Cost)
+
+	// The rabi yield is only known per hectare, so it is counted over the cycle's area
+	require.Len(t, data.Production, 1)
+	assert.Equal(t, "kg", data.Production[0].YieldUnit)
+	assert.Equal(t, 5500.0, data.Production[0].TotalYield)
+	assert.Equal(t, 2, data.Production[0].Cycles)
+
+	data = farmCosts("KHARIF")
+	require.Len(t, data.Cycles, 1)
+	assert.Equal(t, 6000.0, data.TotalCost)
+	assert.Equal(t, floatPtr(3000), data.CostPerHectare)
+	assert.Equal(t, floatPtr(3000), data.Cycles[0].CostPerHectare)
+
+	_, err := service.GetFarmCosts(ctx, &requests.FarmCostsRequest{FarmID: "FARM9"})
+	assert.Error(t, err)
+}
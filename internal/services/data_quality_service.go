@@ -4,14 +4,18 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/Kisanlink/farmers-module/internal/auth"
+	"github.com/Kisanlink/farmers-module/internal/entities/farm_overlap"
 	farmerentity "github.com/Kisanlink/farmers-module/internal/entities/farmer"
 	"github.com/Kisanlink/farmers-module/internal/entities/requests"
 	"github.com/Kisanlink/farmers-module/internal/entities/responses"
 	farmRepo "github.com/Kisanlink/farmers-module/internal/repo/farm"
+	farmOverlapRepo "github.com/Kisanlink/farmers-module/internal/repo/farm_overlap"
 	farmerRepo "github.com/Kisanlink/farmers-module/internal/repo/farmer"
 	"github.com/Kisanlink/farmers-module/pkg/common"
+	"github.com/Kisanlink/farmers-module/pkg/geo"
 	"github.com/Kisanlink/kisanlink-db/pkg/base"
 	"gorm.io/gorm"
 )
@@ -21,6 +25,7 @@ type DataQualityServiceImpl struct {
 	db                  *gorm.DB
 	farmRepo            *farmRepo.FarmRepository
 	farmerLinkageRepo   *farmerRepo.FarmerLinkRepository
+	overlapRepo         farmOverlapRepo.FarmOverlapRepository
 	aaaService          AAAService
 	notificationService NotificationService
 }
@@ -30,6 +35,7 @@ func NewDataQualityService(
 	db *gorm.DB,
 	farmRepo *farmRepo.FarmRepository,
 	farmerLinkageRepo *farmerRepo.FarmerLinkRepository,
+	overlapRepo farmOverlapRepo.FarmOverlapRepository,
 	aaaService AAAService,
 	notificationService NotificationService,
 ) DataQualityService {
@@ -37,6 +43,7 @@ func NewDataQualityService(
 		db:                  db,
 		farmRepo:            farmRepo,
 		farmerLinkageRepo:   farmerLinkageRepo,
+		overlapRepo:         overlapRepo,
 		aaaService:          aaaService,
 		notificationService: notificationService,
 	}
@@ -82,10 +89,27 @@ func (s *DataQualityServiceImpl) ValidateGeometry(ctx context.Context, req inter
 		return response, nil
 	}
 
+	// Offer a repaired geometry alongside the validation of the one submitted
+	if validateReq.Repair {
+		switch {
+		case validateReq.SimplifyToleranceM < 0 || validateReq.SimplifyToleranceM > requests.MaxSimplifyToleranceM:
+			response.Errors = append(response.Errors, fmt.Sprintf("simplify_tolerance_m must be between 0 and %g", requests.MaxSimplifyToleranceM))
+		case s.farmRepo == nil:
+			response.Warnings = append(response.Warnings, "geometry repair not available")
+		default:
+			repair, err := s.farmRepo.RepairGeometry(ctx, validateReq.WKT, validateReq.SimplifyToleranceM)
+			if err != nil {
+				response.Errors = append(response.Errors, fmt.Sprintf("geometry repair failed: %v", err))
+			} else {
+				response.Repair = geometryRepairData(repair)
+			}
+		}
+	}
+
 	// If no database connection, do basic validation
 	if s.db == nil {
-		if !strings.HasPrefix(strings.ToUpper(validateReq.WKT), "POLYGON") {
-			response.Errors = append(response.Errors, "only POLYGON geometries are supported")
+		if !isPolygonalWKT(validateReq.WKT) {
+			response.Errors = append(response.Errors, "only POLYGON and MULTIPOLYGON geometries are supported")
 		} else {
 			response.IsValid = true
 		}
@@ -102,10 +126,15 @@ func (s *DataQualityServiceImpl) ValidateGeometry(ctx context.Context, req inter
 
 	if !postgisAvailable {
 		response.Warnings = append(response.Warnings, "PostGIS extension not available, performing basic validation only")
-		if !strings.HasPrefix(strings.ToUpper(validateReq.WKT), "POLYGON") {
-			response.Errors = append(response.Errors, "only POLYGON geometries are supported")
+		if !isPolygonalWKT(validateReq.WKT) {
+			response.Errors = append(response.Errors, "only POLYGON and MULTIPOLYGON geometries are supported")
 		} else {
 			response.IsValid = true
+			// Report the same geodesic area PostGIS would
+			if geometry, err := geo.ParseWKT(validateReq.WKT); err == nil {
+				areaHa := geo.AreaHa(geometry)
+				response.AreaHa = &areaHa
+			}
 		}
 		return response, nil
 	}
@@ -130,12 +159,12 @@ func (s *DataQualityServiceImpl) ValidateGeometry(ctx context.Context, req inter
 		return response, nil
 	}
 
-	// Check geometry type (must be POLYGON)
+	// Check geometry type (must be POLYGON or MULTIPOLYGON)
 	var geomType string
 	if err := s.db.Raw("SELECT ST_GeometryType(ST_GeomFromText(?, 4326))", validateReq.WKT).Scan(&geomType).Error; err != nil {
 		response.Warnings = append(response.Warnings, fmt.Sprintf("failed to get geometry type: %v", err))
-	} else if geomType != "ST_Polygon" {
-		response.Errors = append(response.Errors, fmt.Sprintf("only POLYGON geometries are supported, got %s", geomType))
+	} else if geomType != "ST_Polygon" && geomType != "ST_MultiPolygon" {
+		response.Errors = append(response.Errors, fmt.Sprintf("only POLYGON and MULTIPOLYGON geometries are supported, got %s", geomType))
 		return response, nil
 	}
 
@@ -182,6 +211,16 @@ func (s *DataQualityServiceImpl) ValidateGeometry(ctx context.Context, req inter
 	return response, nil
 }
 
+// isPolygonalWKT reports whether WKT names a POLYGON or MULTIPOLYGON, optionally with an
+// EWKT SRID prefix
+func isPolygonalWKT(wkt string) bool {
+	text := strings.ToUpper(strings.TrimSpace(wkt))
+	if i := strings.Index(text, ";"); strings.HasPrefix(text, "SRID=") && i >= 0 {
+		text = strings.TrimSpace(text[i+1:])
+	}
+	return strings.HasPrefix(text, "POLYGON") || strings.HasPrefix(text, "MULTIPOLYGON")
+}
+
 // ReconcileAAALinks heals broken AAA references in farmer_links
 // Business Rule 6.1: AAA-Local State Invariants
 // - Every farmer_links with status='ACTIVE' MUST reference valid AAA user and org
@@ -425,7 +464,8 @@ func (s *DataQualityServiceImpl) RebuildSpatialIndexes(ctx context.Context, req
 	return response, nil
 }
 
-// DetectFarmOverlaps detects spatial intersections between farm boundaries
+// DetectFarmOverlaps detects spatial intersections between farm boundaries and keeps the
+// organization's overlap conflicts up to date with them
 func (s *DataQualityServiceImpl) DetectFarmOverlaps(ctx context.Context, req interface{}) (interface{}, error) {
 	detectReq, ok := req.(*requests.DetectFarmOverlapsRequest)
 	if !ok {
@@ -466,11 +506,85 @@ func (s *DataQualityServiceImpl) DetectFarmOverlaps(ctx context.Context, req int
 		return nil, fmt.Errorf("failed to check PostGIS availability: %w", err)
 	}
 
-	if !postgisAvailable {
-		return nil, fmt.Errorf("PostGIS extension not available for overlap detection")
+	if s.overlapRepo == nil {
+		return nil, fmt.Errorf("overlap conflict storage not available")
+	}
+
+	// Overlaps below a square metre are slivers rather than conflicts
+	minAreaHa := farm_overlap.MinConflictAreaHa
+	if detectReq.MinOverlapAreaHa != nil && *detectReq.MinOverlapAreaHa > minAreaHa {
+		minAreaHa = *detectReq.MinOverlapAreaHa
+	}
+
+	// An incremental run re-checks only the pairs involving a farm changed since the last
+	// complete run
+	runStartedAt := time.Now()
+	var since *time.Time
+	if detectReq.Incremental {
+		if since, err = s.overlapRepo.GetCheckedThrough(ctx, detectReq.OrgID); err != nil {
+			return nil, err
+		}
+	}
+	response.Incremental = since != nil
+	response.CheckedSince = since
+
+	var overlaps []detectedOverlap
+	if postgisAvailable {
+		overlaps, err = s.queryFarmOverlaps(ctx, detectReq, minAreaHa, since)
+	} else {
+		overlaps, err = s.computeFarmOverlaps(ctx, detectReq, minAreaHa, since)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Only a run that saw every overlap of the farms it checked can tell which conflicts are gone
+	complete := (detectReq.Limit == nil || *detectReq.Limit <= 0 || len(overlaps) < *detectReq.Limit) &&
+		minAreaHa == farm_overlap.MinConflictAreaHa
+	if err := s.recordOverlapConflicts(ctx, detectReq.OrgID, userCtx.AAAUserID, overlaps, since, complete, response); err != nil {
+		return nil, err
+	}
+	if complete {
+		if err := s.overlapRepo.RecordRun(ctx, detectReq.OrgID, runStartedAt); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, detected := range overlaps {
+		overlap := detected.FarmOverlap
+		// Calculate overlap percentages
+		if overlap.Farm1AreaHa > 0 {
+			overlap.OverlapPercentageFarm1 = (overlap.OverlapAreaHa / overlap.Farm1AreaHa) * 100
+		}
+		if overlap.Farm2AreaHa > 0 {
+			overlap.OverlapPercentageFarm2 = (overlap.OverlapAreaHa / overlap.Farm2AreaHa) * 100
+		}
+
+		response.Overlaps = append(response.Overlaps, overlap)
+	}
+
+	response.TotalOverlaps = len(response.Overlaps)
+
+	if response.TotalOverlaps == 0 {
+		response.Message = "No farm overlaps detected"
+	} else {
+		response.Message = fmt.Sprintf("Detected %d farm overlaps", response.TotalOverlaps)
 	}
 
-	// Build query to find overlapping farms
+	return response, nil
+}
+
+// detectedOverlap is an overlap found by detection with the shared area as WKT, which is
+// empty when PostGIS is not available
+type detectedOverlap struct {
+	responses.FarmOverlap
+	wkt string
+}
+
+// queryFarmOverlaps finds overlapping farm pairs with PostGIS. Areas are computed on
+// geography so that they are in square metres rather than square degrees. A non-nil since
+// keeps only the pairs in which a farm changed after it.
+func (s *DataQualityServiceImpl) queryFarmOverlaps(ctx context.Context, detectReq *requests.DetectFarmOverlapsRequest, minAreaHa float64, since *time.Time) ([]detectedOverlap, error) {
 	query := `
 		SELECT
 			f1.id as farm1_id,
@@ -479,9 +593,10 @@ func (s *DataQualityServiceImpl) DetectFarmOverlaps(ctx context.Context, req int
 			f2.id as farm2_id,
 			f2.name as farm2_name,
 			f2.aaa_user_id as farm2_farmer_id,
-			ST_Area(ST_Intersection(f1.geometry, f2.geometry))/10000.0 as overlap_area_ha,
-			ST_Area(f1.geometry)/10000.0 as farm1_area_ha,
-			ST_Area(f2.geometry)/10000.0 as farm2_area_ha
+			ST_Area(ST_Intersection(f1.geometry, f2.geometry)::geography)/10000.0 as overlap_area_ha,
+			ST_Area(f1.geometry::geography)/10000.0 as farm1_area_ha,
+			ST_Area(f2.geometry::geography)/10000.0 as farm2_area_ha,
+			ST_AsText(ST_Multi(ST_CollectionExtract(ST_Intersection(f1.geometry, f2.geometry), 3))) as overlap_wkt
 		FROM farms f1
 		JOIN farms f2 ON f1.id < f2.id
 		WHERE f1.aaa_org_id = ?
@@ -489,14 +604,13 @@ func (s *DataQualityServiceImpl) DetectFarmOverlaps(ctx context.Context, req int
 		AND f1.deleted_at IS NULL
 		AND f2.deleted_at IS NULL
 		AND ST_Intersects(f1.geometry, f2.geometry)
-		AND ST_Area(ST_Intersection(f1.geometry, f2.geometry)) > 0`
+		AND ST_Area(ST_Intersection(f1.geometry, f2.geometry)::geography)/10000.0 >= ?`
 
-	args := []interface{}{detectReq.OrgID, detectReq.OrgID}
+	args := []interface{}{detectReq.OrgID, detectReq.OrgID, minAreaHa}
 
-	// Add minimum overlap area filter if specified
-	if detectReq.MinOverlapAreaHa != nil && *detectReq.MinOverlapAreaHa > 0 {
-		query += " AND ST_Area(ST_Intersection(f1.geometry, f2.geometry))/10000.0 >= ?"
-		args = append(args, *detectReq.MinOverlapAreaHa)
+	if since != nil {
+		query += " AND (f1.updated_at > ? OR f2.updated_at > ?)"
+		args = append(args, *since, *since)
 	}
 
 	// Add limit if specified
@@ -505,7 +619,7 @@ func (s *DataQualityServiceImpl) DetectFarmOverlaps(ctx context.Context, req int
 		args = append(args, *detectReq.Limit)
 	}
 
-	rows, err := s.db.Raw(query, args...).Rows()
+	rows, err := s.db.WithContext(ctx).Raw(query, args...).Rows()
 	if err != nil {
 		return nil, fmt.Errorf("failed to detect overlaps: %w", err)
 	}
@@ -515,8 +629,9 @@ func (s *DataQualityServiceImpl) DetectFarmOverlaps(ctx context.Context, req int
 		}
 	}()
 
+	var overlaps []detectedOverlap
 	for rows.Next() {
-		var overlap responses.FarmOverlap
+		var overlap detectedOverlap
 		if err := rows.Scan(
 			&overlap.Farm1ID,
 			&overlap.Farm1Name,
@@ -527,28 +642,82 @@ func (s *DataQualityServiceImpl) DetectFarmOverlaps(ctx context.Context, req int
 			&overlap.OverlapAreaHa,
 			&overlap.Farm1AreaHa,
 			&overlap.Farm2AreaHa,
+			&overlap.wkt,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan overlap result: %w", err)
 		}
+		overlaps = append(overlaps, overlap)
+	}
 
-		// Calculate overlap percentages
-		if overlap.Farm1AreaHa > 0 {
-			overlap.OverlapPercentageFarm1 = (overlap.OverlapAreaHa / overlap.Farm1AreaHa) * 100
-		}
-		if overlap.Farm2AreaHa > 0 {
-			overlap.OverlapPercentageFarm2 = (overlap.OverlapAreaHa / overlap.Farm2AreaHa) * 100
-		}
+	return overlaps, nil
+}
 
-		response.Overlaps = append(response.Overlaps, overlap)
+// computeFarmOverlaps finds overlapping farm pairs in Go when PostGIS is not available,
+// producing the same geodesic areas as queryFarmOverlaps
+func (s *DataQualityServiceImpl) computeFarmOverlaps(ctx context.Context, detectReq *requests.DetectFarmOverlapsRequest, minAreaHa float64, since *time.Time) ([]detectedOverlap, error) {
+	var rows []struct {
+		ID        string
+		Name      *string
+		AAAUserID string
+		Geometry  string
+		UpdatedAt time.Time
+	}
+	if err := s.db.WithContext(ctx).Table("farms").
+		Select("id, name, aaa_user_id, geometry, updated_at").
+		Where("aaa_org_id = ? AND deleted_at IS NULL", detectReq.OrgID).
+		Order("id").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to detect overlaps: %w", err)
 	}
 
-	response.TotalOverlaps = len(response.Overlaps)
+	type farmShape struct {
+		id, name, farmerID string
+		geometry           geo.MultiPolygon
+		bounds             geo.Bounds
+		areaHa             float64
+		changed            bool
+	}
+	shapes := make([]farmShape, 0, len(rows))
+	for _, row := range rows {
+		geometry, err := geo.ParseWKT(row.Geometry)
+		if err != nil {
+			continue
+		}
+		shape := farmShape{id: row.ID, farmerID: row.AAAUserID, geometry: geometry, bounds: geometry.Bounds(), areaHa: geo.AreaHa(geometry)}
+		shape.changed = since == nil || row.UpdatedAt.After(*since)
+		if row.Name != nil {
+			shape.name = *row.Name
+		}
+		shapes = append(shapes, shape)
+	}
 
-	if response.TotalOverlaps == 0 {
-		response.Message = "No farm overlaps detected"
-	} else {
-		response.Message = fmt.Sprintf("Detected %d farm overlaps", response.TotalOverlaps)
+	var overlaps []detectedOverlap
+	for i := range shapes {
+		for j := i + 1; j < len(shapes); j++ {
+			f1, f2 := shapes[i], shapes[j]
+			if !(f1.changed || f2.changed) || !f1.bounds.Intersects(f2.bounds) {
+				continue
+			}
+			overlapAreaHa := geo.IntersectionAreaHa(f1.geometry, f2.geometry)
+			if overlapAreaHa <= 0 || overlapAreaHa < minAreaHa {
+				continue
+			}
+			overlaps = append(overlaps, detectedOverlap{FarmOverlap: responses.FarmOverlap{
+				Farm1ID:       f1.id,
+				Farm1Name:     f1.name,
+				Farm1FarmerID: f1.farmerID,
+				Farm2ID:       f2.id,
+				Farm2Name:     f2.name,
+				Farm2FarmerID: f2.farmerID,
+				OverlapAreaHa: overlapAreaHa,
+				Farm1AreaHa:   f1.areaHa,
+				Farm2AreaHa:   f2.areaHa,
+			}})
+			if detectReq.Limit != nil && *detectReq.Limit > 0 && len(overlaps) >= *detectReq.Limit {
+				return overlaps, nil
+			}
+		}
 	}
 
-	return response, nil
+	return overlaps, nil
 }
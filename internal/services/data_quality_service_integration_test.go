@@ -9,6 +9,7 @@ import (
 	"github.com/Kisanlink/farmers-module/internal/entities/requests"
 	"github.com/Kisanlink/farmers-module/internal/entities/responses"
 	farmRepo "github.com/Kisanlink/farmers-module/internal/repo/farm"
+	farmOverlapRepo "github.com/Kisanlink/farmers-module/internal/repo/farm_overlap"
 	farmerRepo "github.com/Kisanlink/farmers-module/internal/repo/farmer"
 	"github.com/Kisanlink/kisanlink-db/pkg/base"
 	"github.com/stretchr/testify/mock"
@@ -52,7 +53,7 @@ func (suite *DataQualityServiceIntegrationTestSuite) SetupSuite() {
 	suite.mockNotificationService = &MockNotificationService{}
 
 	// Create service
-	suite.service = NewDataQualityService(db, suite.farmRepo, suite.farmerLinkageRepo, suite.mockAAAService, suite.mockNotificationService)
+	suite.service = NewDataQualityService(db, suite.farmRepo, suite.farmerLinkageRepo, farmOverlapRepo.NewFarmOverlapRepository(db), suite.mockAAAService, suite.mockNotificationService)
 }
 
 // TearDownSuite tears down the test suite
@@ -26,7 +26,7 @@ func TestDataQualityService_ValidateGeometry_Minimal(t *testing.T) {
 	mockAAAService.On("CheckPermission", mock.Anything, "user123", "farm", "audit", "", "org123").Return(true, nil)
 
 	// Create service with nil repositories (will use basic validation)
-	service := NewDataQualityService(nil, nil, nil, mockAAAService, mockNotificationService)
+	service := NewDataQualityService(nil, nil, nil, nil, mockAAAService, mockNotificationService)
 
 	t.Run("Valid polygon geometry without PostGIS", func(t *testing.T) {
 		req := &requests.ValidateGeometryRequest{
@@ -120,7 +120,7 @@ func TestDataQualityService_ValidateGeometry_Minimal(t *testing.T) {
 		validateResponse, ok := response.(*responses.ValidateGeometryResponse)
 		assert.True(t, ok)
 		assert.False(t, validateResponse.IsValid)
-		assert.Contains(t, validateResponse.Errors, "only POLYGON geometries are supported")
+		assert.Contains(t, validateResponse.Errors, "only POLYGON and MULTIPOLYGON geometries are supported")
 	})
 
 	// Verify mocks
@@ -151,7 +151,7 @@ func TestDataQualityService_ReconcileAAALinks_Minimal(t *testing.T) {
 	farmerLinkageRepo.SetDBManager(&mockDBManager{db: db})
 
 	// Create service with real repository
-	service := NewDataQualityService(db, nil, farmerLinkageRepo, mockAAAService, mockNotificationService)
+	service := NewDataQualityService(db, nil, farmerLinkageRepo, nil, mockAAAService, mockNotificationService)
 
 	t.Run("Successful reconciliation with no links", func(t *testing.T) {
 		req := &requests.ReconcileAAALinksRequest{
@@ -199,7 +199,7 @@ func TestDataQualityService_RebuildSpatialIndexes_Minimal(t *testing.T) {
 	mockAAAService.On("CheckPermission", mock.Anything, "admin123", "admin", "maintain", "", "org123").Return(true, nil)
 
 	// Create service with nil repositories (will report no PostGIS)
-	service := NewDataQualityService(nil, nil, nil, mockAAAService, mockNotificationService)
+	service := NewDataQualityService(nil, nil, nil, nil, mockAAAService, mockNotificationService)
 
 	t.Run("Rebuild without database connection", func(t *testing.T) {
 		req := &requests.RebuildSpatialIndexesRequest{
@@ -245,7 +245,7 @@ func TestDataQualityService_DetectFarmOverlaps_Minimal(t *testing.T) {
 	mockAAAService.On("CheckPermission", mock.Anything, "user123", "farm", "audit", "", "org123").Return(true, nil)
 
 	// Create service with nil repositories (will fail without PostGIS)
-	service := NewDataQualityService(nil, nil, nil, mockAAAService, mockNotificationService)
+	service := NewDataQualityService(nil, nil, nil, nil, mockAAAService, mockNotificationService)
 
 	t.Run("Detect overlaps without database connection", func(t *testing.T) {
 		req := &requests.DetectFarmOverlapsRequest{
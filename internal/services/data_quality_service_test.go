@@ -19,7 +19,7 @@ func TestDataQualityService_ValidateGeometry(t *testing.T) {
 	mockAAAService.On("CheckPermission", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
 
 	// Create service with nil repositories for testing
-	service := NewDataQualityService(nil, nil, nil, mockAAAService, mockNotificationService)
+	service := NewDataQualityService(nil, nil, nil, nil, mockAAAService, mockNotificationService)
 
 	req := &requests.ValidateGeometryRequest{
 		BaseRequest: requests.BaseRequest{
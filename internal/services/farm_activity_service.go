@@ -23,6 +23,7 @@ type FarmActivityServiceImpl struct {
 	farmActivityRepo *farm_activity.FarmActivityRepository
 	cropCycleRepo    *crop_cycle.CropCycleRepository
 	cropStageRepo    *stage.CropStageRepository
+	transitionRepo   crop_cycle.StageTransitionRepository
 	farmerLinkRepo   FarmerLinkRepository
 	aaaService       AAAService
 }
@@ -32,6 +33,7 @@ func NewFarmActivityService(
 	farmActivityRepo *farm_activity.FarmActivityRepository,
 	cropCycleRepo *crop_cycle.CropCycleRepository,
 	cropStageRepo *stage.CropStageRepository,
+	transitionRepo crop_cycle.StageTransitionRepository,
 	farmerLinkRepo FarmerLinkRepository,
 	aaaService AAAService,
 ) FarmActivityService {
@@ -39,6 +41,7 @@ func NewFarmActivityService(
 		farmActivityRepo: farmActivityRepo,
 		cropCycleRepo:    cropCycleRepo,
 		cropStageRepo:    cropStageRepo,
+		transitionRepo:   transitionRepo,
 		farmerLinkRepo:   farmerLinkRepo,
 		aaaService:       aaaService,
 	}
@@ -146,18 +149,20 @@ func (s *FarmActivityServiceImpl) CreateActivity(ctx context.Context, req interf
 
 	// Convert to response data
 	activityData := &responses.FarmActivityData{
-		ID:           activity.ID,
-		CropCycleID:  activity.CropCycleID,
-		CropStageID:  activity.CropStageID,
-		ActivityType: activity.ActivityType,
-		PlannedAt:    activity.PlannedAt,
-		CompletedAt:  activity.CompletedAt,
-		CreatedBy:    activity.CreatedBy,
-		Status:       activity.Status,
-		Output:       activity.Output,
-		Metadata:     activity.Metadata,
-		CreatedAt:    activity.CreatedAt,
-		UpdatedAt:    activity.UpdatedAt,
+		ID:                 activity.ID,
+		CropCycleID:        activity.CropCycleID,
+		CropStageID:        activity.CropStageID,
+		ActivityTemplateID: activity.ActivityTemplateID,
+		ActivityType:       activity.ActivityType,
+		PlannedAt:          activity.PlannedAt,
+		StartedAt:          activity.StartedAt,
+		CompletedAt:        activity.CompletedAt,
+		CreatedBy:          activity.CreatedBy,
+		Status:             activity.Status,
+		Output:             activity.Output,
+		Metadata:           activity.Metadata,
+		CreatedAt:          activity.CreatedAt,
+		UpdatedAt:          activity.UpdatedAt,
 	}
 
 	response := responses.NewFarmActivityResponse(activityData, "Farm activity created successfully")
@@ -198,9 +203,24 @@ func (s *FarmActivityServiceImpl) CompleteActivity(ctx context.Context, req inte
 		return nil, fmt.Errorf("activity is already completed")
 	}
 
+	completedAt := completeReq.CompletedAt
+	if completedAt.IsZero() {
+		completedAt = time.Now()
+	}
+
+	// An activity completed without being started started its stage when it was completed
+	stageStartedAt := completedAt
+	if activity.StartedAt != nil {
+		stageStartedAt = *activity.StartedAt
+	}
+	transition, err := s.stageTransitionFor(ctx, activity, stageStartedAt, userCtx.AAAUserID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Update activity with completion details
 	activity.Status = "COMPLETED"
-	activity.CompletedAt = &completeReq.CompletedAt
+	activity.CompletedAt = &completedAt
 	if completeReq.Output != nil {
 		activity.Output = completeReq.Output
 	}
@@ -210,26 +230,147 @@ func (s *FarmActivityServiceImpl) CompleteActivity(ctx context.Context, req inte
 		return nil, fmt.Errorf("failed to complete farm activity: %w", err)
 	}
 
+	if transition != nil {
+		if _, err := s.transitionRepo.CreateIfFirst(ctx, transition); err != nil {
+			return nil, fmt.Errorf("failed to record stage start: %w", err)
+		}
+	}
+
 	// Convert to response data
 	activityData := &responses.FarmActivityData{
-		ID:           activity.ID,
-		CropCycleID:  activity.CropCycleID,
-		CropStageID:  activity.CropStageID,
-		ActivityType: activity.ActivityType,
-		PlannedAt:    activity.PlannedAt,
-		CompletedAt:  activity.CompletedAt,
-		CreatedBy:    activity.CreatedBy,
-		Status:       activity.Status,
-		Output:       activity.Output,
-		Metadata:     activity.Metadata,
-		CreatedAt:    activity.CreatedAt,
-		UpdatedAt:    activity.UpdatedAt,
+		ID:                 activity.ID,
+		CropCycleID:        activity.CropCycleID,
+		CropStageID:        activity.CropStageID,
+		ActivityTemplateID: activity.ActivityTemplateID,
+		ActivityType:       activity.ActivityType,
+		PlannedAt:          activity.PlannedAt,
+		StartedAt:          activity.StartedAt,
+		CompletedAt:        activity.CompletedAt,
+		CreatedBy:          activity.CreatedBy,
+		Status:             activity.Status,
+		Output:             activity.Output,
+		Metadata:           activity.Metadata,
+		CreatedAt:          activity.CreatedAt,
+		UpdatedAt:          activity.UpdatedAt,
 	}
 
 	response := responses.NewFarmActivityResponse(activityData, "Farm activity completed successfully")
 	return &response, nil
 }
 
+// StartActivity records that a planned farm activity has started. The first activity of a
+// stage to start marks when the cycle entered the stage.
+func (s *FarmActivityServiceImpl) StartActivity(ctx context.Context, req interface{}) (interface{}, error) {
+	startReq, ok := req.(*requests.StartActivityRequest)
+	if !ok {
+		return nil, common.ErrInvalidInput
+	}
+
+	// Extract authenticated user from context
+	userCtx, err := auth.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user context: %w", err)
+	}
+
+	// Check if authenticated user can update activity
+	hasPermission, err := s.aaaService.CheckPermission(ctx, userCtx.AAAUserID, "activity", "update", startReq.ID, startReq.OrgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !hasPermission {
+		return nil, common.ErrForbidden
+	}
+
+	// Get existing activity
+	activity := &farmActivityEntity.FarmActivity{}
+	_, err = s.farmActivityRepo.GetByID(ctx, startReq.ID, activity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get farm activity: %w", err)
+	}
+	if activity.Status != "PLANNED" {
+		return nil, fmt.Errorf("%w: only planned activities can be started, activity is %s", common.ErrInvalidInput, activity.Status)
+	}
+
+	now := time.Now()
+	startedAt := now
+	if startReq.StartedAt != nil {
+		startedAt = *startReq.StartedAt
+	}
+	if startedAt.After(now) {
+		return nil, fmt.Errorf("%w: started_at cannot be in the future", common.ErrInvalidInput)
+	}
+	transition, err := s.stageTransitionFor(ctx, activity, startedAt, userCtx.AAAUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	activity.Status = "IN_PROGRESS"
+	activity.StartedAt = &startedAt
+	if err := s.farmActivityRepo.Update(ctx, activity); err != nil {
+		return nil, fmt.Errorf("failed to start farm activity: %w", err)
+	}
+
+	if transition != nil {
+		if _, err := s.transitionRepo.CreateIfFirst(ctx, transition); err != nil {
+			return nil, fmt.Errorf("failed to record stage start: %w", err)
+		}
+	}
+
+	// Convert to response data
+	activityData := &responses.FarmActivityData{
+		ID:                 activity.ID,
+		CropCycleID:        activity.CropCycleID,
+		CropStageID:        activity.CropStageID,
+		ActivityTemplateID: activity.ActivityTemplateID,
+		ActivityType:       activity.ActivityType,
+		PlannedAt:          activity.PlannedAt,
+		StartedAt:          activity.StartedAt,
+		CompletedAt:        activity.CompletedAt,
+		CreatedBy:          activity.CreatedBy,
+		Status:             activity.Status,
+		Output:             activity.Output,
+		Metadata:           activity.Metadata,
+		CreatedAt:          activity.CreatedAt,
+		UpdatedAt:          activity.UpdatedAt,
+	}
+
+	response := responses.NewFarmActivityResponse(activityData, "Farm activity started successfully")
+	return &response, nil
+}
+
+// stageTransitionFor builds the transition recording that an activity's stage started at the
+// given time, for the first activity of a stage marks when the cycle entered it. The time may
+// be neither in the future nor before the cycle started. It returns nil when the activity has
+// no stage.
+func (s *FarmActivityServiceImpl) stageTransitionFor(ctx context.Context, activity *farmActivityEntity.FarmActivity, startedAt time.Time, userID string) (*cropCycleEntity.StageTransition, error) {
+	if activity.CropStageID == nil || *activity.CropStageID == "" {
+		return nil, nil
+	}
+
+	cropCycle := &cropCycleEntity.CropCycle{}
+	if _, err := s.cropCycleRepo.GetByID(ctx, activity.CropCycleID, cropCycle); err != nil {
+		return nil, fmt.Errorf("failed to get crop cycle: %w", err)
+	}
+	if startedAt.After(time.Now()) {
+		return nil, fmt.Errorf("%w: the stage cannot start in the future", common.ErrInvalidInput)
+	}
+	if cropCycle.StartDate != nil && startedAt.Before(*cropCycle.StartDate) {
+		return nil, fmt.Errorf("%w: the stage cannot start before the cycle's start date", common.ErrInvalidInput)
+	}
+
+	transition := cropCycleEntity.NewStageTransition()
+	transition.CropCycleID = activity.CropCycleID
+	transition.CropStageID = *activity.CropStageID
+	transition.StartedAt = startedAt
+	transition.Source = cropCycleEntity.StageTransitionSourceActivity
+	transition.ActivityID = &activity.ID
+	transition.CreatedBy = userID
+	if err := transition.Validate(); err != nil {
+		return nil, err
+	}
+	return transition, nil
+}
+
 // UpdateActivity implements W16: Update farm activity
 func (s *FarmActivityServiceImpl) UpdateActivity(ctx context.Context, req interface{}) (interface{}, error) {
 	updateReq, ok := req.(*requests.UpdateActivityRequest)
@@ -314,18 +455,20 @@ func (s *FarmActivityServiceImpl) UpdateActivity(ctx context.Context, req interf
 
 	// Convert to response data
 	activityData := &responses.FarmActivityData{
-		ID:           activity.ID,
-		CropCycleID:  activity.CropCycleID,
-		CropStageID:  activity.CropStageID,
-		ActivityType: activity.ActivityType,
-		PlannedAt:    activity.PlannedAt,
-		CompletedAt:  activity.CompletedAt,
-		CreatedBy:    activity.CreatedBy,
-		Status:       activity.Status,
-		Output:       activity.Output,
-		Metadata:     activity.Metadata,
-		CreatedAt:    activity.CreatedAt,
-		UpdatedAt:    activity.UpdatedAt,
+		ID:                 activity.ID,
+		CropCycleID:        activity.CropCycleID,
+		CropStageID:        activity.CropStageID,
+		ActivityTemplateID: activity.ActivityTemplateID,
+		ActivityType:       activity.ActivityType,
+		PlannedAt:          activity.PlannedAt,
+		StartedAt:          activity.StartedAt,
+		CompletedAt:        activity.CompletedAt,
+		CreatedBy:          activity.CreatedBy,
+		Status:             activity.Status,
+		Output:             activity.Output,
+		Metadata:           activity.Metadata,
+		CreatedAt:          activity.CreatedAt,
+		UpdatedAt:          activity.UpdatedAt,
 	}
 
 	response := responses.NewFarmActivityResponse(activityData, "Farm activity updated successfully")
@@ -413,18 +556,20 @@ func (s *FarmActivityServiceImpl) ListActivities(ctx context.Context, req interf
 	var activityDataList []*responses.FarmActivityData
 	for _, activity := range activities {
 		activityData := &responses.FarmActivityData{
-			ID:           activity.ID,
-			CropCycleID:  activity.CropCycleID,
-			CropStageID:  activity.CropStageID,
-			ActivityType: activity.ActivityType,
-			PlannedAt:    activity.PlannedAt,
-			CompletedAt:  activity.CompletedAt,
-			CreatedBy:    activity.CreatedBy,
-			Status:       activity.Status,
-			Output:       activity.Output,
-			Metadata:     activity.Metadata,
-			CreatedAt:    activity.CreatedAt,
-			UpdatedAt:    activity.UpdatedAt,
+			ID:                 activity.ID,
+			CropCycleID:        activity.CropCycleID,
+			CropStageID:        activity.CropStageID,
+			ActivityTemplateID: activity.ActivityTemplateID,
+			ActivityType:       activity.ActivityType,
+			PlannedAt:          activity.PlannedAt,
+			StartedAt:          activity.StartedAt,
+			CompletedAt:        activity.CompletedAt,
+			CreatedBy:          activity.CreatedBy,
+			Status:             activity.Status,
+			Output:             activity.Output,
+			Metadata:           activity.Metadata,
+			CreatedAt:          activity.CreatedAt,
+			UpdatedAt:          activity.UpdatedAt,
 		}
 		activityDataList = append(activityDataList, activityData)
 	}
@@ -444,18 +589,20 @@ func (s *FarmActivityServiceImpl) GetFarmActivity(ctx context.Context, activityI
 
 	// Convert to response data
 	activityData := &responses.FarmActivityData{
-		ID:           activity.ID,
-		CropCycleID:  activity.CropCycleID,
-		CropStageID:  activity.CropStageID,
-		ActivityType: activity.ActivityType,
-		PlannedAt:    activity.PlannedAt,
-		CompletedAt:  activity.CompletedAt,
-		CreatedBy:    activity.CreatedBy,
-		Status:       activity.Status,
-		Output:       activity.Output,
-		Metadata:     activity.Metadata,
-		CreatedAt:    activity.CreatedAt,
-		UpdatedAt:    activity.UpdatedAt,
+		ID:                 activity.ID,
+		CropCycleID:        activity.CropCycleID,
+		CropStageID:        activity.CropStageID,
+		ActivityTemplateID: activity.ActivityTemplateID,
+		ActivityType:       activity.ActivityType,
+		PlannedAt:          activity.PlannedAt,
+		StartedAt:          activity.StartedAt,
+		CompletedAt:        activity.CompletedAt,
+		CreatedBy:          activity.CreatedBy,
+		Status:             activity.Status,
+		Output:             activity.Output,
+		Metadata:           activity.Metadata,
+		CreatedAt:          activity.CreatedAt,
+		UpdatedAt:          activity.UpdatedAt,
 	}
 
 	response := responses.NewFarmActivityResponse(activityData, "Farm activity retrieved successfully")
@@ -484,109 +631,3 @@ func (s *FarmActivityServiceImpl) DeleteActivity(ctx context.Context, activityID
 
 	return nil
 }
-
-// GetStageProgress gets stage-wise activity completion statistics for a crop cycle
-func (s *FarmActivityServiceImpl) GetStageProgress(ctx context.Context, cropCycleID string) (interface{}, error) {
-	// Get crop cycle to verify it exists and get crop info
-	cropCycle := &cropCycleEntity.CropCycle{}
-	_, err := s.cropCycleRepo.GetByID(ctx, cropCycleID, cropCycle)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get crop cycle: %w", err)
-	}
-
-	// Get all crop stages for this crop using filter
-	cropStageFilter := base.NewFilterBuilder().
-		Where("crop_id", base.OpEqual, cropCycle.CropID).
-		Build()
-
-	cropStages, err := s.cropStageRepo.Find(ctx, cropStageFilter)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get crop stages: %w", err)
-	}
-
-	// Prepare stage info for repository method
-	type CropStageInfo struct {
-		ID           string
-		StageID      string
-		StageName    string
-		StageOrder   int
-		DurationDays *int
-	}
-
-	var stageInfos []*CropStageInfo
-	for _, cropStage := range cropStages {
-		stageInfo := &CropStageInfo{
-			ID:           cropStage.ID,
-			StageID:      cropStage.StageID,
-			StageOrder:   cropStage.StageOrder,
-			DurationDays: cropStage.DurationDays,
-		}
-		// Get stage name from preloaded Stage relationship
-		if cropStage.Stage != nil {
-			stageInfo.StageName = cropStage.Stage.StageName
-		}
-		stageInfos = append(stageInfos, stageInfo)
-	}
-
-	// Get completion statistics from repository
-	stats, err := s.farmActivityRepo.GetStageCompletionStats(ctx, cropCycleID, stageInfos)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get stage completion stats: %w", err)
-	}
-
-	// Convert stats to response format
-	var stageStats []*responses.StageCompletionStat
-	totalCompletedActivities := 0
-	totalActivities := 0
-
-	for _, stat := range stats {
-		responseStat := &responses.StageCompletionStat{
-			CropStageID:          stat.CropStageID,
-			StageID:              stat.StageID,
-			StageName:            stat.StageName,
-			StageOrder:           stat.StageOrder,
-			DurationDays:         stat.DurationDays,
-			TotalActivities:      stat.TotalActivities,
-			CompletedActivities:  stat.CompletedActivities,
-			InProgressActivities: stat.InProgressActivities,
-			PlannedActivities:    stat.PlannedActivities,
-			CompletionPercent:    stat.CompletionPercent,
-		}
-		stageStats = append(stageStats, responseStat)
-		totalCompletedActivities += stat.CompletedActivities
-		totalActivities += stat.TotalActivities
-	}
-
-	// Calculate overall completion percentage
-	overallPercent := 0.0
-	if totalActivities > 0 {
-		overallPercent = float64(totalCompletedActivities) / float64(totalActivities) * 100
-	}
-
-	// Determine current stage (first incomplete stage in order, or last completed stage)
-	var currentStage *responses.StageCompletionStat
-	for _, stat := range stageStats {
-		if stat.CompletionPercent < 100 {
-			currentStage = stat
-			break
-		}
-	}
-	// If all stages completed, current stage is the last one
-	if currentStage == nil && len(stageStats) > 0 {
-		currentStage = stageStats[len(stageStats)-1]
-	}
-
-	// Build response data
-	progressData := &responses.StageProgressData{
-		CropCycleID:    cropCycleID,
-		CropID:         cropCycle.CropID,
-		CropName:       "", // TODO: Get crop name from crop service if needed
-		CurrentStage:   currentStage,
-		Stages:         stageStats,
-		OverallPercent: overallPercent,
-		TotalStages:    len(stageStats),
-	}
-
-	response := responses.NewStageProgressResponse(progressData, "Stage progress retrieved successfully")
-	return &response, nil
-}
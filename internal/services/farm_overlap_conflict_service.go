@@ -0,0 +1,363 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Kisanlink/farmers-module/internal/auth"
+	farmEntity "github.com/Kisanlink/farmers-module/internal/entities/farm"
+	"github.com/Kisanlink/farmers-module/internal/entities/farm_overlap"
+	"github.com/Kisanlink/farmers-module/internal/entities/requests"
+	"github.com/Kisanlink/farmers-module/internal/entities/responses"
+	farmRepo "github.com/Kisanlink/farmers-module/internal/repo/farm"
+	farmOverlapRepo "github.com/Kisanlink/farmers-module/internal/repo/farm_overlap"
+	"github.com/Kisanlink/farmers-module/pkg/common"
+	"github.com/Kisanlink/kisanlink-db/pkg/base"
+	"gorm.io/gorm"
+)
+
+// recordOverlapConflicts stores the overlaps found by a detection run as conflicts: new pairs
+// open a conflict, resolved conflicts whose overlap is back are reopened, and false positives
+// stay dismissed. A complete run also resolves the unresolved conflicts of the farms it
+// checked that no longer overlap; a nil since means every farm was checked.
+func (s *DataQualityServiceImpl) recordOverlapConflicts(ctx context.Context, orgID, userID string, overlaps []detectedOverlap, since *time.Time, complete bool, response *responses.DetectFarmOverlapsResponse) error {
+	var checkedFarmIDs []string
+	if since != nil {
+		var err error
+		if checkedFarmIDs, err = s.farmRepo.ChangedFarmIDs(ctx, orgID, *since); err != nil {
+			return err
+		}
+		if checkedFarmIDs == nil {
+			checkedFarmIDs = []string{}
+		}
+	}
+
+	existing, err := s.overlapRepo.ListByFarms(ctx, orgID, checkedFarmIDs)
+	if err != nil {
+		return err
+	}
+	byPair := make(map[[2]string]*farm_overlap.FarmOverlapConflict, len(existing))
+	for _, conflict := range existing {
+		byPair[[2]string{conflict.Farm1ID, conflict.Farm2ID}] = conflict
+	}
+
+	now := time.Now()
+	detected := make(map[string]bool, len(overlaps))
+	for i := range overlaps {
+		overlap := &overlaps[i]
+		farm1ID, farm2ID := farm_overlap.OrderedPair(overlap.Farm1ID, overlap.Farm2ID)
+		conflict, found := byPair[[2]string{farm1ID, farm2ID}]
+		if !found {
+			conflict = farm_overlap.NewFarmOverlapConflict(orgID, farm1ID, farm2ID)
+			conflict.DetectedAt = now
+			conflict.CreatedBy = userID
+			response.NewConflicts++
+		} else if conflict.Status == farm_overlap.ConflictStatusResolved {
+			reopenConflict(conflict)
+			response.ReopenedConflicts++
+		}
+
+		conflict.OverlapAreaHa = overlap.OverlapAreaHa
+		if overlap.wkt != "" {
+			conflict.OverlapGeometry = &overlap.wkt
+		}
+		conflict.LastDetectedAt = now
+		conflict.UpdatedBy = userID
+
+		if found {
+			err = s.overlapRepo.Update(ctx, conflict)
+		} else {
+			err = s.overlapRepo.Create(ctx, conflict)
+		}
+		if err != nil {
+			return err
+		}
+		detected[conflict.ID] = true
+		overlap.ConflictID = conflict.ID
+		overlap.ConflictStatus = string(conflict.Status)
+	}
+
+	if !complete {
+		return nil
+	}
+	for _, conflict := range existing {
+		if detected[conflict.ID] || !conflict.Status.IsUnresolved() {
+			continue
+		}
+		closeConflict(conflict, farm_overlap.ConflictStatusResolved, farm_overlap.ResolutionNoLongerOverlapping, userID)
+		if err := s.overlapRepo.Update(ctx, conflict); err != nil {
+			return err
+		}
+		response.ResolvedConflicts++
+	}
+	return nil
+}
+
+// reopenConflict returns a conflict to OPEN, clearing how it was closed
+func reopenConflict(conflict *farm_overlap.FarmOverlapConflict) {
+	conflict.Status = farm_overlap.ConflictStatusOpen
+	conflict.ResolutionAction = nil
+	conflict.ResolvedAt = nil
+	conflict.ResolvedBy = nil
+}
+
+// closeConflict marks a conflict resolved or a false positive
+func closeConflict(conflict *farm_overlap.FarmOverlapConflict, status farm_overlap.ConflictStatus, action farm_overlap.ResolutionAction, userID string) {
+	now := time.Now()
+	conflict.Status = status
+	conflict.ResolutionAction = nil
+	if action != "" {
+		conflict.ResolutionAction = &action
+	}
+	conflict.ResolvedAt = &now
+	conflict.ResolvedBy = &userID
+	conflict.UpdatedBy = userID
+}
+
+// ListOverlapConflicts lists an organization's farm overlap conflicts, largest overlap first
+func (s *DataQualityServiceImpl) ListOverlapConflicts(ctx context.Context, req interface{}) (interface{}, error) {
+	listReq, ok := req.(*requests.ListOverlapConflictsRequest)
+	if !ok {
+		return nil, fmt.Errorf("invalid request type for ListOverlapConflicts")
+	}
+
+	if _, err := s.checkAuditPermission(ctx, listReq.OrgID); err != nil {
+		return nil, err
+	}
+
+	status := farm_overlap.ConflictStatus(listReq.Status)
+	if status != "" && !status.IsValid() {
+		return nil, fmt.Errorf("%w: status must be OPEN, ACKNOWLEDGED, RESOLVED or FALSE_POSITIVE", common.ErrInvalidInput)
+	}
+
+	conflicts, total, err := s.overlapRepo.Search(ctx, farmOverlapRepo.ConflictFilter{
+		AAAOrgID:   listReq.OrgID,
+		Status:     status,
+		FarmID:     listReq.FarmID,
+		AssigneeID: listReq.AssigneeID,
+		Limit:      listReq.PageSize,
+		Offset:     (listReq.Page - 1) * listReq.PageSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]*responses.OverlapConflictData, len(conflicts))
+	for i, conflict := range conflicts {
+		data[i] = convertOverlapConflictToData(conflict)
+	}
+	response := responses.NewOverlapConflictListResponse(data, listReq.Page, listReq.PageSize, total)
+	response.SetRequestID(listReq.RequestID)
+	return &response, nil
+}
+
+// UpdateOverlapConflict changes the status, assignee or notes of a conflict. Marking a
+// conflict RESOLVED here records a manual resolution that leaves both boundaries as they are.
+func (s *DataQualityServiceImpl) UpdateOverlapConflict(ctx context.Context, req interface{}) (interface{}, error) {
+	updateReq, ok := req.(*requests.UpdateOverlapConflictRequest)
+	if !ok {
+		return nil, fmt.Errorf("invalid request type for UpdateOverlapConflict")
+	}
+
+	conflict, err := s.overlapRepo.GetByID(ctx, updateReq.ConflictID)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := s.checkAuditPermission(ctx, conflict.AAAOrgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if updateReq.Status != nil {
+		status := farm_overlap.ConflictStatus(*updateReq.Status)
+		if !conflict.Status.CanTransitionTo(status) {
+			return nil, fmt.Errorf("%w: a %s conflict cannot be moved to %s", common.ErrInvalidInput, conflict.Status, *updateReq.Status)
+		}
+		if status != conflict.Status {
+			switch status {
+			case farm_overlap.ConflictStatusResolved:
+				closeConflict(conflict, status, farm_overlap.ResolutionManual, userID)
+			case farm_overlap.ConflictStatusFalsePositive:
+				closeConflict(conflict, status, "", userID)
+			default:
+				reopenConflict(conflict)
+				conflict.Status = status
+			}
+		}
+	}
+	if updateReq.AssigneeID != nil {
+		conflict.AssigneeID = nil
+		if *updateReq.AssigneeID != "" {
+			conflict.AssigneeID = updateReq.AssigneeID
+		}
+	}
+	if updateReq.ResolutionNotes != nil {
+		conflict.ResolutionNotes = updateReq.ResolutionNotes
+	}
+	conflict.UpdatedBy = userID
+
+	if err := s.overlapRepo.Update(ctx, conflict); err != nil {
+		return nil, err
+	}
+
+	response := responses.NewOverlapConflictResponse(convertOverlapConflictToData(conflict), "Overlap conflict updated successfully")
+	response.SetRequestID(updateReq.RequestID)
+	return &response, nil
+}
+
+// ResolveOverlapConflict resolves an unresolved conflict by changing the farm boundaries:
+// TRIM removes the shared area from one farm, SPLIT divides it between both. Each changed
+// boundary is recorded in the farm's boundary history and the farm is tagged again with its
+// administrative areas. The caller must be allowed to update every changed farm, a new
+// boundary may not cut through the farm's plots, and the boundaries and the conflict are
+// stored together or not at all.
+func (s *DataQualityServiceImpl) ResolveOverlapConflict(ctx context.Context, req interface{}) (interface{}, error) {
+	resolveReq, ok := req.(*requests.ResolveOverlapConflictRequest)
+	if !ok {
+		return nil, fmt.Errorf("invalid request type for ResolveOverlapConflict")
+	}
+
+	conflict, err := s.overlapRepo.GetByID(ctx, resolveReq.ConflictID)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := s.checkAuditPermission(ctx, conflict.AAAOrgID)
+	if err != nil {
+		return nil, err
+	}
+	if !conflict.Status.IsUnresolved() {
+		return nil, fmt.Errorf("%w: conflict is already %s", common.ErrInvalidInput, conflict.Status)
+	}
+
+	// Work out every new boundary before changing any
+	boundaries := make(map[string]string, 2)
+	action := farm_overlap.ResolutionAction(resolveReq.Action)
+	switch action {
+	case farm_overlap.ResolutionTrim:
+		if resolveReq.TrimFarmID != conflict.Farm1ID && resolveReq.TrimFarmID != conflict.Farm2ID {
+			return nil, fmt.Errorf("%w: trim_farm_id must be %s or %s", common.ErrInvalidInput, conflict.Farm1ID, conflict.Farm2ID)
+		}
+		trimmed, err := s.farmRepo.TrimmedGeometry(ctx, resolveReq.TrimFarmID, conflict.OtherFarmID(resolveReq.TrimFarmID))
+		if err != nil {
+			return nil, err
+		}
+		boundaries[resolveReq.TrimFarmID] = trimmed
+	case farm_overlap.ResolutionSplit:
+		farm1WKT, farm2WKT, err := s.farmRepo.SplitOverlapGeometries(ctx, conflict.Farm1ID, conflict.Farm2ID)
+		if err != nil {
+			return nil, err
+		}
+		boundaries[conflict.Farm1ID] = farm1WKT
+		boundaries[conflict.Farm2ID] = farm2WKT
+	default:
+		return nil, fmt.Errorf("%w: action must be TRIM or SPLIT", common.ErrInvalidInput)
+	}
+
+	reason := fmt.Sprintf("Overlap conflict %s resolved by %s", conflict.ID, action)
+	changes, err := s.boundaryChanges(ctx, conflict, boundaries, reason, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	closeConflict(conflict, farm_overlap.ConflictStatusResolved, action, userID)
+	if resolveReq.ResolutionNotes != nil {
+		conflict.ResolutionNotes = resolveReq.ResolutionNotes
+	}
+	if err := s.farmRepo.ResolveOverlapConflict(ctx, conflict, changes); err != nil {
+		return nil, err
+	}
+
+	response := responses.NewOverlapConflictResponse(convertOverlapConflictToData(conflict), "Overlap conflict resolved successfully")
+	response.SetRequestID(resolveReq.RequestID)
+	return &response, nil
+}
+
+// boundaryChanges prepares the new boundaries of the farms of a conflict, in the order of the
+// pair, after checking that the caller may update every one of them
+func (s *DataQualityServiceImpl) boundaryChanges(ctx context.Context, conflict *farm_overlap.FarmOverlapConflict, boundaries map[string]string, reason, userID string) ([]farmRepo.BoundaryChange, error) {
+	var changes []farmRepo.BoundaryChange
+	for _, farmID := range []string{conflict.Farm1ID, conflict.Farm2ID} {
+		if wkt, ok := boundaries[farmID]; ok {
+			change, err := s.boundaryChange(ctx, farmID, wkt, reason, userID)
+			if err != nil {
+				return nil, err
+			}
+			changes = append(changes, change)
+		}
+	}
+	return changes, nil
+}
+
+// boundaryChange prepares a farm's new boundary after checking that the caller may update
+// the farm. Nothing is stored.
+func (s *DataQualityServiceImpl) boundaryChange(ctx context.Context, farmID, wkt, reason, userID string) (farmRepo.BoundaryChange, error) {
+	filter := base.NewFilterBuilder().Where("id", base.OpEqual, farmID).Build()
+	farm, err := s.farmRepo.FindOne(ctx, filter)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return farmRepo.BoundaryChange{}, common.ErrNotFound
+		}
+		return farmRepo.BoundaryChange{}, fmt.Errorf("failed to get farm: %w", err)
+	}
+
+	hasPermission, err := s.aaaService.CheckPermission(ctx, userID, "farm", "update", farm.ID, farm.AAAOrgID)
+	if err != nil {
+		return farmRepo.BoundaryChange{}, fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !hasPermission {
+		return farmRepo.BoundaryChange{}, common.ErrForbidden
+	}
+
+	farm.Geometry = wkt
+	farm.UpdatedBy = userID
+
+	version := farmEntity.NewFarmGeometryVersion()
+	version.Geometry = wkt
+	version.Reason = &reason
+	version.AuthorID = userID
+	version.CreatedBy = userID
+	version.UpdatedBy = userID
+	return farmRepo.BoundaryChange{Farm: farm, Version: version}, nil
+}
+
+// checkAuditPermission checks that the caller may audit the organization's farms and returns
+// the caller's AAA user ID
+func (s *DataQualityServiceImpl) checkAuditPermission(ctx context.Context, orgID string) (string, error) {
+	userCtx, err := auth.GetUserFromContext(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get user context: %w", err)
+	}
+
+	hasPermission, err := s.aaaService.CheckPermission(ctx, userCtx.AAAUserID, "farm", "audit", "", orgID)
+	if err != nil {
+		return "", fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !hasPermission {
+		return "", common.ErrForbidden
+	}
+	return userCtx.AAAUserID, nil
+}
+
+// convertOverlapConflictToData converts a conflict to its response representation
+func convertOverlapConflictToData(conflict *farm_overlap.FarmOverlapConflict) *responses.OverlapConflictData {
+	data := &responses.OverlapConflictData{
+		ID:              conflict.ID,
+		AAAOrgID:        conflict.AAAOrgID,
+		Farm1ID:         conflict.Farm1ID,
+		Farm2ID:         conflict.Farm2ID,
+		OverlapAreaHa:   conflict.OverlapAreaHa,
+		Status:          string(conflict.Status),
+		AssigneeID:      conflict.AssigneeID,
+		ResolutionNotes: conflict.ResolutionNotes,
+		DetectedAt:      conflict.DetectedAt,
+		LastDetectedAt:  conflict.LastDetectedAt,
+		ResolvedAt:      conflict.ResolvedAt,
+		ResolvedBy:      conflict.ResolvedBy,
+	}
+	if conflict.ResolutionAction != nil {
+		action := string(*conflict.ResolutionAction)
+		data.ResolutionAction = &action
+	}
+	return data
+}
@@ -0,0 +1,305 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Kisanlink/farmers-module/internal/auth"
+	"github.com/Kisanlink/farmers-module/internal/entities/farm_overlap"
+	"github.com/Kisanlink/farmers-module/internal/entities/requests"
+	"github.com/Kisanlink/farmers-module/internal/entities/responses"
+	farmRepo "github.com/Kisanlink/farmers-module/internal/repo/farm"
+	farmOverlapRepo "github.com/Kisanlink/farmers-module/internal/repo/farm_overlap"
+	"github.com/Kisanlink/farmers-module/pkg/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeOverlapRepo keeps overlap conflicts in memory
+type fakeOverlapRepo struct {
+	farmOverlapRepo.FarmOverlapRepository
+	conflicts map[string]*farm_overlap.FarmOverlapConflict
+	updates   int
+}
+
+func newFakeOverlapRepo(conflicts ...*farm_overlap.FarmOverlapConflict) *fakeOverlapRepo {
+	repo := &fakeOverlapRepo{conflicts: make(map[string]*farm_overlap.FarmOverlapConflict)}
+	for _, conflict := range conflicts {
+		repo.conflicts[conflict.ID] = conflict
+	}
+	return repo
+}
+
+func (r *fakeOverlapRepo) Create(ctx context.Context, conflict *farm_overlap.FarmOverlapConflict) error {
+	r.conflicts[conflict.ID] = conflict
+	return nil
+}
+
+func (r *fakeOverlapRepo) Update(ctx context.Context, conflict *farm_overlap.FarmOverlapConflict) error {
+	r.updates++
+	r.conflicts[conflict.ID] = conflict
+	return nil
+}
+
+func (r *fakeOverlapRepo) GetByID(ctx context.Context, id string) (*farm_overlap.FarmOverlapConflict, error) {
+	conflict, ok := r.conflicts[id]
+	if !ok {
+		return nil, common.ErrNotFound
+	}
+	copied := *conflict
+	return &copied, nil
+}
+
+func (r *fakeOverlapRepo) ListByFarms(ctx context.Context, orgID string, farmIDs []string) ([]*farm_overlap.FarmOverlapConflict, error) {
+	var conflicts []*farm_overlap.FarmOverlapConflict
+	for _, conflict := range r.conflicts {
+		if conflict.AAAOrgID == orgID {
+			copied := *conflict
+			conflicts = append(conflicts, &copied)
+		}
+	}
+	return conflicts, nil
+}
+
+// byPair returns the stored conflict of two farms
+func (r *fakeOverlapRepo) byPair(farmID, otherFarmID string) *farm_overlap.FarmOverlapConflict {
+	farm1ID, farm2ID := farm_overlap.OrderedPair(farmID, otherFarmID)
+	for _, conflict := range r.conflicts {
+		if conflict.Farm1ID == farm1ID && conflict.Farm2ID == farm2ID {
+			return conflict
+		}
+	}
+	return nil
+}
+
+func testConflict(id, farmID, otherFarmID string, status farm_overlap.ConflictStatus) *farm_overlap.FarmOverlapConflict {
+	conflict := farm_overlap.NewFarmOverlapConflict("org-1", farmID, otherFarmID)
+	conflict.ID = id
+	conflict.Status = status
+	conflict.OverlapAreaHa = 0.5
+	if !status.IsUnresolved() {
+		action, resolvedAt, resolvedBy := farm_overlap.ResolutionManual, time.Now(), "user-0"
+		conflict.ResolutionAction, conflict.ResolvedAt, conflict.ResolvedBy = &action, &resolvedAt, &resolvedBy
+	}
+	return conflict
+}
+
+// auditorContext authenticates user-1, who may audit org-1
+func auditorContext(aaa *MockAAAService) context.Context {
+	aaa.On("CheckPermission", mock.Anything, "user-1", "farm", "audit", "", "org-1").Return(true, nil)
+	return auth.SetUserInContext(context.Background(), &auth.UserContext{AAAUserID: "user-1"})
+}
+
+func newConflictTestService(t *testing.T, overlapRepo *fakeOverlapRepo, aaa *MockAAAService) (*DataQualityServiceImpl, *farmRepo.FarmRepository) {
+	db := newSQLiteDB(t, farmsTable)
+	farms := farmRepo.NewFarmRepository(&sqliteManager{db: db})
+	insertFarm(t, db, "FARM1", "org-1", "POLYGON((0 0,0 1,1 1,1 0,0 0))")
+	insertFarm(t, db, "FARM2", "org-1", "POLYGON((0.5 0,0.5 1,1.5 1,1.5 0,0.5 0))")
+	return &DataQualityServiceImpl{db: db, farmRepo: farms, overlapRepo: overlapRepo, aaaService: aaa}, farms
+}
+
+func TestUpdateOverlapConflict_StatusTransitions(t *testing.T) {
+	tests := []struct {
+		from       farm_overlap.ConflictStatus
+		to         string
+		wantErr    bool
+		wantAction *farm_overlap.ResolutionAction
+		resolved   bool
+	}{
+		{from: farm_overlap.ConflictStatusOpen, to: "ACKNOWLEDGED"},
+		{from: farm_overlap.ConflictStatusAcknowledged, to: "OPEN"},
+		{from: farm_overlap.ConflictStatusAcknowledged, to: "RESOLVED", wantAction: actionPtr(farm_overlap.ResolutionManual), resolved: true},
+		{from: farm_overlap.ConflictStatusOpen, to: "FALSE_POSITIVE", resolved: true},
+		{from: farm_overlap.ConflictStatusResolved, to: "OPEN"},
+		{from: farm_overlap.ConflictStatusFalsePositive, to: "OPEN"},
+		{from: farm_overlap.ConflictStatusResolved, to: "ACKNOWLEDGED", wantErr: true},
+		{from: farm_overlap.ConflictStatusFalsePositive, to: "RESOLVED", wantErr: true},
+		{from: farm_overlap.ConflictStatusOpen, to: "CLOSED", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s to %s", tt.from, tt.to), func(t *testing.T) {
+			aaa := &MockAAAService{}
+			ctx := auditorContext(aaa)
+			repo := newFakeOverlapRepo(testConflict("FOVC1", "FARM1", "FARM2", tt.from))
+			service, _ := newConflictTestService(t, repo, aaa)
+
+			_, err := service.UpdateOverlapConflict(ctx, &requests.UpdateOverlapConflictRequest{ConflictID: "FOVC1", Status: &tt.to})
+			stored := repo.conflicts["FOVC1"]
+			if tt.wantErr {
+				assert.ErrorIs(t, err, common.ErrInvalidInput)
+				assert.Equal(t, tt.from, stored.Status)
+				assert.Zero(t, repo.updates)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, farm_overlap.ConflictStatus(tt.to), stored.Status)
+			assert.Equal(t, tt.wantAction, stored.ResolutionAction)
+			if tt.resolved {
+				require.NotNil(t, stored.ResolvedBy)
+				assert.Equal(t, "user-1", *stored.ResolvedBy)
+				assert.NotNil(t, stored.ResolvedAt)
+			} else {
+				assert.Nil(t, stored.ResolvedBy)
+				assert.Nil(t, stored.ResolvedAt)
+			}
+		})
+	}
+}
+
+func actionPtr(action farm_overlap.ResolutionAction) *farm_overlap.ResolutionAction {
+	return &action
+}
+
+func TestUpdateOverlapConflict_AssigneeAndNotes(t *testing.T) {
+	aaa := &MockAAAService{}
+	ctx := auditorContext(aaa)
+	repo := newFakeOverlapRepo(testConflict("FOVC1", "FARM1", "FARM2", farm_overlap.ConflictStatusOpen))
+	service, _ := newConflictTestService(t, repo, aaa)
+
+	assignee, notes := "user-2", "Field visit booked"
+	_, err := service.UpdateOverlapConflict(ctx, &requests.UpdateOverlapConflictRequest{ConflictID: "FOVC1", AssigneeID: &assignee, ResolutionNotes: &notes})
+	require.NoError(t, err)
+	stored := repo.conflicts["FOVC1"]
+	assert.Equal(t, farm_overlap.ConflictStatusOpen, stored.Status)
+	assert.Equal(t, &assignee, stored.AssigneeID)
+	assert.Equal(t, &notes, stored.ResolutionNotes)
+
+	// An empty assignee unassigns the conflict
+	unassigned := ""
+	_, err = service.UpdateOverlapConflict(ctx, &requests.UpdateOverlapConflictRequest{ConflictID: "FOVC1", AssigneeID: &unassigned})
+	require.NoError(t, err)
+	assert.Nil(t, repo.conflicts["FOVC1"].AssigneeID)
+	assert.Equal(t, &notes, repo.conflicts["FOVC1"].ResolutionNotes)
+}
+
+func TestUpdateOverlapConflict_RequiresAuditPermission(t *testing.T) {
+	aaa := &MockAAAService{}
+	aaa.On("CheckPermission", mock.Anything, "user-1", "farm", "audit", "", "org-1").Return(false, nil)
+	ctx := auth.SetUserInContext(context.Background(), &auth.UserContext{AAAUserID: "user-1"})
+	repo := newFakeOverlapRepo(testConflict("FOVC1", "FARM1", "FARM2", farm_overlap.ConflictStatusOpen))
+	service, _ := newConflictTestService(t, repo, aaa)
+
+	status := "RESOLVED"
+	_, err := service.UpdateOverlapConflict(ctx, &requests.UpdateOverlapConflictRequest{ConflictID: "FOVC1", Status: &status})
+	assert.ErrorIs(t, err, common.ErrForbidden)
+	assert.Equal(t, farm_overlap.ConflictStatusOpen, repo.conflicts["FOVC1"].Status)
+}
+
+func TestRecordOverlapConflicts(t *testing.T) {
+	ctx := context.Background()
+	repo := newFakeOverlapRepo(
+		testConflict("FOVC1", "FARM1", "FARM2", farm_overlap.ConflictStatusResolved),
+		testConflict("FOVC2", "FARM1", "FARM3", farm_overlap.ConflictStatusFalsePositive),
+		testConflict("FOVC3", "FARM2", "FARM3", farm_overlap.ConflictStatusAcknowledged),
+		testConflict("FOVC4", "FARM3", "FARM4", farm_overlap.ConflictStatusResolved),
+	)
+	service := &DataQualityServiceImpl{overlapRepo: repo}
+
+	overlap := func(farmID, otherFarmID string, areaHa float64) detectedOverlap {
+		return detectedOverlap{FarmOverlap: responses.FarmOverlap{Farm1ID: farmID, Farm2ID: otherFarmID, OverlapAreaHa: areaHa}}
+	}
+	overlaps := []detectedOverlap{
+		overlap("FARM2", "FARM1", 0.7), // resolved, but the overlap is back
+		overlap("FARM1", "FARM3", 0.2), // dismissed as a false positive
+		overlap("FARM5", "FARM4", 0.3), // new, reported in the other order
+	}
+	response := &responses.DetectFarmOverlapsResponse{}
+	require.NoError(t, service.recordOverlapConflicts(ctx, "org-1", "user-1", overlaps, nil, true, response))
+
+	reopened := repo.byPair("FARM1", "FARM2")
+	assert.Equal(t, farm_overlap.ConflictStatusOpen, reopened.Status)
+	assert.Nil(t, reopened.ResolutionAction)
+	assert.Nil(t, reopened.ResolvedAt)
+	assert.Equal(t, 0.7, reopened.OverlapAreaHa)
+
+	dismissed := repo.byPair("FARM1", "FARM3")
+	assert.Equal(t, farm_overlap.ConflictStatusFalsePositive, dismissed.Status)
+	assert.Equal(t, 0.2, dismissed.OverlapAreaHa)
+
+	// The acknowledged overlap was not found again
+	gone := repo.byPair("FARM2", "FARM3")
+	assert.Equal(t, farm_overlap.ConflictStatusResolved, gone.Status)
+	assert.Equal(t, actionPtr(farm_overlap.ResolutionNoLongerOverlapping), gone.ResolutionAction)
+
+	// Closed conflicts that were not found again are left alone
+	assert.Equal(t, actionPtr(farm_overlap.ResolutionManual), repo.byPair("FARM3", "FARM4").ResolutionAction)
+
+	created := repo.byPair("FARM4", "FARM5")
+	require.NotNil(t, created)
+	assert.Equal(t, "FARM4", created.Farm1ID)
+	assert.Equal(t, farm_overlap.ConflictStatusOpen, created.Status)
+	assert.Equal(t, created.ID, overlaps[2].ConflictID)
+
+	assert.Equal(t, 1, response.NewConflicts)
+	assert.Equal(t, 1, response.ReopenedConflicts)
+	assert.Equal(t, 1, response.ResolvedConflicts)
+}
+
+func TestRecordOverlapConflicts_IncompleteRunResolvesNothing(t *testing.T) {
+	repo := newFakeOverlapRepo(testConflict("FOVC1", "FARM1", "FARM2", farm_overlap.ConflictStatusOpen))
+	service := &DataQualityServiceImpl{overlapRepo: repo}
+
+	// A run cut short by its limit has not seen every overlap
+	response := &responses.DetectFarmOverlapsResponse{}
+	require.NoError(t, service.recordOverlapConflicts(context.Background(), "org-1", "user-1", nil, nil, false, response))
+	assert.Equal(t, farm_overlap.ConflictStatusOpen, repo.conflicts["FOVC1"].Status)
+	assert.Zero(t, response.ResolvedConflicts)
+}
+
+func TestResolveOverlapConflict_RejectsInvalidResolutions(t *testing.T) {
+	aaa := &MockAAAService{}
+	ctx := auditorContext(aaa)
+	repo := newFakeOverlapRepo(
+		testConflict("FOVC1", "FARM1", "FARM2", farm_overlap.ConflictStatusOpen),
+		testConflict("FOVC2", "FARM1", "FARM3", farm_overlap.ConflictStatusResolved),
+	)
+	service, _ := newConflictTestService(t, repo, aaa)
+
+	tests := []struct {
+		name string
+		req  *requests.ResolveOverlapConflictRequest
+	}{
+		{"already resolved", &requests.ResolveOverlapConflictRequest{ConflictID: "FOVC2", Action: "SPLIT"}},
+		{"trimming a farm outside the pair", &requests.ResolveOverlapConflictRequest{ConflictID: "FOVC1", Action: "TRIM", TrimFarmID: "FARM3"}},
+		{"unknown action", &requests.ResolveOverlapConflictRequest{ConflictID: "FOVC1", Action: "MERGE"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.ResolveOverlapConflict(ctx, tt.req)
+			assert.ErrorIs(t, err, common.ErrInvalidInput)
+		})
+	}
+	assert.Zero(t, repo.updates)
+}
+
+func TestBoundaryChanges_ChecksEveryFarm(t *testing.T) {
+	aaa := &MockAAAService{}
+	aaa.On("CheckPermission", mock.Anything, "user-1", "farm", "update", "FARM1", "org-1").Return(true, nil)
+	aaa.On("CheckPermission", mock.Anything, "user-1", "farm", "update", "FARM2", "org-1").Return(false, nil)
+	conflict := testConflict("FOVC1", "FARM1", "FARM2", farm_overlap.ConflictStatusOpen)
+	service, _ := newConflictTestService(t, newFakeOverlapRepo(conflict), aaa)
+	ctx := context.Background()
+
+	// A split changes both farms, and the caller may only update one of them
+	split := map[string]string{
+		"FARM1": "MULTIPOLYGON(((0 0,0 1,0.75 1,0.75 0,0 0)))",
+		"FARM2": "MULTIPOLYGON(((0.75 0,0.75 1,1.5 1,1.5 0,0.75 0)))",
+	}
+	_, err := service.boundaryChanges(ctx, conflict, split, "split", "user-1")
+	assert.ErrorIs(t, err, common.ErrForbidden)
+
+	// Trimming the farm the caller may update is allowed
+	changes, err := service.boundaryChanges(ctx, conflict, map[string]string{"FARM1": split["FARM1"]}, "trim", "user-1")
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "FARM1", changes[0].Farm.ID)
+	assert.Equal(t, split["FARM1"], changes[0].Farm.Geometry)
+	assert.Equal(t, split["FARM1"], changes[0].Version.Geometry)
+	assert.Equal(t, "trim", *changes[0].Version.Reason)
+	assert.Equal(t, "user-1", changes[0].Version.AuthorID)
+}
@@ -2,20 +2,25 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
-	"strings"
 
 	"github.com/Kisanlink/farmers-module/internal/auth"
 	farmEntity "github.com/Kisanlink/farmers-module/internal/entities/farm"
+	"github.com/Kisanlink/farmers-module/internal/entities/farm_overlap"
 	"github.com/Kisanlink/farmers-module/internal/entities/requests"
 	"github.com/Kisanlink/farmers-module/internal/entities/responses"
 	farmRepo "github.com/Kisanlink/farmers-module/internal/repo/farm"
 	farmerRepo "github.com/Kisanlink/farmers-module/internal/repo/farmer"
 	"github.com/Kisanlink/farmers-module/pkg/common"
+	"github.com/Kisanlink/farmers-module/pkg/geo"
 	"github.com/Kisanlink/kisanlink-db/pkg/base"
 	"gorm.io/gorm"
 )
 
+// MaxFarmExportFeatures bounds the number of farms in one GeoJSON export
+const MaxFarmExportFeatures = 50000
+
 // FarmServiceImpl implements FarmService
 type FarmServiceImpl struct {
 	farmRepo   *farmRepo.FarmRepository
@@ -41,6 +46,11 @@ func (s *FarmServiceImpl) CreateFarm(ctx context.Context, req interface{}) (inte
 		return nil, fmt.Errorf("invalid request type for CreateFarm")
 	}
 
+	// Accept GeoJSON geometries by converting them to WKT
+	if err := createReq.Geometry.ResolveWKT(); err != nil {
+		return nil, err
+	}
+
 	// Validate request
 	if err := s.validateCreateFarmRequest(createReq); err != nil {
 		return nil, err
@@ -80,8 +90,15 @@ func (s *FarmServiceImpl) CreateFarm(ctx context.Context, req interface{}) (inte
 		farmerID = farmer.ID
 	}
 
-	// Validate geometry if provided
+	// Repair the captured boundary if asked to, then validate it
 	var geometryWKT string
+	var repair *farmRepo.GeometryRepair
+	if createReq.Geometry.WKT != "" && createReq.Geometry.Repair {
+		if repair, err = s.farmRepo.RepairGeometry(ctx, createReq.Geometry.WKT, createReq.Geometry.SimplifyToleranceM); err != nil {
+			return nil, fmt.Errorf("geometry repair failed: %w", err)
+		}
+		createReq.Geometry.WKT = repair.WKT
+	}
 	if createReq.Geometry.WKT != "" {
 		if err := s.validateGeometry(ctx, createReq.Geometry.WKT); err != nil {
 			return nil, fmt.Errorf("geometry validation failed: %w", err)
@@ -89,6 +106,17 @@ func (s *FarmServiceImpl) CreateFarm(ctx context.Context, req interface{}) (inte
 		geometryWKT = createReq.Geometry.WKT
 	}
 
+	// Refuse a boundary that would open an overlap conflict when asked to
+	if geometryWKT != "" && createReq.BlockOnOverlap {
+		overlaps, overlappingIDs, overlapAreaHa, err := s.farmRepo.CheckOverlap(ctx, geometryWKT, "", createReq.AAAOrgID)
+		if err != nil {
+			return nil, err
+		}
+		if overlaps && overlapAreaHa >= farm_overlap.MinConflictAreaHa {
+			return nil, &common.FarmOverlapError{OverlappingFarmIDs: overlappingIDs, OverlapAreaHa: overlapAreaHa}
+		}
+	}
+
 	// Create farm entity with proper BaseModel initialization
 	farm := farmEntity.NewFarm()
 	farm.FarmerID = farmerID
@@ -110,8 +138,13 @@ func (s *FarmServiceImpl) CreateFarm(ctx context.Context, req interface{}) (inte
 		}
 	}
 
-	// Create farm in database
-	if err := s.farmRepo.Create(ctx, farm); err != nil {
+	// Create farm in database, starting its boundary history when it has a boundary
+	if geometryWKT != "" {
+		version := newGeometryVersion(geometryWKT, &createReq.Geometry, userCtx.AAAUserID)
+		if err := s.farmRepo.CreateWithGeometryVersion(ctx, farm, version); err != nil {
+			return nil, err
+		}
+	} else if err := s.farmRepo.Create(ctx, farm); err != nil {
 		return nil, fmt.Errorf("failed to create farm: %w", err)
 	}
 
@@ -124,6 +157,7 @@ func (s *FarmServiceImpl) CreateFarm(ctx context.Context, req interface{}) (inte
 
 	// Convert to response
 	farmData := s.convertFarmToData(createdFarm)
+	farmData.GeometryRepair = geometryRepairData(repair)
 	response := responses.NewFarmResponse(farmData, "Farm created successfully")
 	response.SetRequestID(createReq.RequestID)
 
@@ -163,6 +197,16 @@ func (s *FarmServiceImpl) UpdateFarm(ctx context.Context, req interface{}) (inte
 	}
 
 	// Update fields
+	if err := updateReq.Geometry.ResolveWKT(); err != nil {
+		return nil, err
+	}
+	var repair *farmRepo.GeometryRepair
+	if updateReq.Geometry != nil && updateReq.Geometry.WKT != "" && updateReq.Geometry.Repair {
+		if repair, err = s.farmRepo.RepairGeometry(ctx, updateReq.Geometry.WKT, updateReq.Geometry.SimplifyToleranceM); err != nil {
+			return nil, fmt.Errorf("geometry repair failed: %w", err)
+		}
+		updateReq.Geometry.WKT = repair.WKT
+	}
 	if updateReq.Geometry != nil && updateReq.Geometry.WKT != "" {
 		if err := s.validateGeometry(ctx, updateReq.Geometry.WKT); err != nil {
 			return nil, fmt.Errorf("geometry validation failed: %w", err)
@@ -210,8 +254,17 @@ func (s *FarmServiceImpl) UpdateFarm(ctx context.Context, req interface{}) (inte
 		}
 	}
 
-	// Update farm in database
-	if err := s.farmRepo.Update(ctx, existingFarm); err != nil {
+	// Update farm in database; a new boundary is versioned with it and may lie in other
+	// administrative areas
+	if updateReq.Geometry != nil && updateReq.Geometry.WKT != "" {
+		change := farmRepo.BoundaryChange{
+			Farm:    existingFarm,
+			Version: newGeometryVersion(updateReq.Geometry.WKT, updateReq.Geometry, userCtx.AAAUserID),
+		}
+		if err := s.farmRepo.ReplaceBoundary(ctx, change); err != nil {
+			return nil, err
+		}
+	} else if err := s.farmRepo.Update(ctx, existingFarm); err != nil {
 		return nil, fmt.Errorf("failed to update farm: %w", err)
 	}
 
@@ -224,6 +277,7 @@ func (s *FarmServiceImpl) UpdateFarm(ctx context.Context, req interface{}) (inte
 
 	// Convert to response
 	farmData := s.convertFarmToData(updatedFarm)
+	farmData.GeometryRepair = geometryRepairData(repair)
 	response := responses.NewFarmResponse(farmData, "Farm updated successfully")
 	response.SetRequestID(updateReq.RequestID)
 
@@ -317,6 +371,18 @@ func (s *FarmServiceImpl) ListFarms(ctx context.Context, req interface{}) (inter
 	if listReq.AAAOrgID != "" {
 		filterBuilder = filterBuilder.Where("aaa_org_id", base.OpEqual, listReq.AAAOrgID)
 	}
+	if listReq.OwnershipType != "" {
+		filterBuilder = filterBuilder.Where("ownership_type", base.OpEqual, listReq.OwnershipType)
+	}
+	if listReq.SoilTypeID != "" {
+		filterBuilder = filterBuilder.Where("soil_type_id", base.OpEqual, listReq.SoilTypeID)
+	}
+	if listReq.IrrigationSourceID != "" {
+		filterBuilder = filterBuilder.Where("primary_irrigation_source_id", base.OpEqual, listReq.IrrigationSourceID)
+	}
+	for column, code := range listReq.AdminAreaFilter.Columns() {
+		filterBuilder = filterBuilder.Where(column, base.OpEqual, code)
+	}
 
 	// Get farms
 	farms, err := s.farmRepo.Find(ctx, filterBuilder.Build())
@@ -436,6 +502,217 @@ func (s *FarmServiceImpl) ListFarmsByBoundingBox(ctx context.Context, bbox reque
 	return &response, nil
 }
 
+// ExportFarms returns the farms matching the ListFarms filters as a GeoJSON FeatureCollection
+func (s *FarmServiceImpl) ExportFarms(ctx context.Context, req interface{}) (interface{}, error) {
+	listReq, ok := req.(*requests.ListFarmsRequest)
+	if !ok {
+		return nil, fmt.Errorf("invalid request type for ExportFarms")
+	}
+
+	// Extract authenticated user from context
+	userCtx, err := auth.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user context: %w", err)
+	}
+
+	// Exporting is listing in another format
+	hasPermission, err := s.aaaService.CheckPermission(ctx, userCtx.AAAUserID, "farm", "list", "", listReq.AAAOrgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !hasPermission {
+		return nil, common.ErrForbidden
+	}
+
+	if s.db == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+
+	query := s.db.WithContext(ctx).Model(&farmEntity.Farm{}).Where("deleted_at IS NULL")
+	query = farmRepo.ApplyFarmSearchFilter(query, farmSearchFilter(listReq))
+
+	var farms []*farmEntity.Farm
+	if err := query.Order("id").Limit(MaxFarmExportFeatures + 1).Find(&farms).Error; err != nil {
+		return nil, fmt.Errorf("failed to export farms: %w", err)
+	}
+	if len(farms) > MaxFarmExportFeatures {
+		return nil, fmt.Errorf("%w: more than %d farms match, narrow the filters or use the tile endpoint", common.ErrInvalidInput, MaxFarmExportFeatures)
+	}
+
+	features := make([]*responses.FarmFeature, len(farms))
+	for i, farm := range farms {
+		data := s.convertFarmToData(farm)
+		geometry := data.GeoJSON
+		if geometry == nil {
+			geometry = json.RawMessage("null")
+		}
+		features[i] = &responses.FarmFeature{
+			Type:     "Feature",
+			ID:       farm.ID,
+			Geometry: geometry,
+			Properties: &responses.FarmFeatureProperties{
+				FarmerID:       data.FarmerID,
+				AAAUserID:      data.AAAUserID,
+				AAAOrgID:       data.AAAOrgID,
+				Name:           data.Name,
+				OwnershipType:  data.OwnershipType,
+				AreaHa:         data.AreaHa,
+				AreaHaComputed: data.AreaHaComputed,
+				BoreWellCount:  data.BoreWellCount,
+				UpdatedAt:      data.UpdatedAt,
+			},
+		}
+	}
+
+	return responses.NewFarmFeatureCollection(features), nil
+}
+
+// SearchFarms finds farms by location: within a radius of a point, inside a boundary, nearest
+// to a point or in a bounding box, combined with the ListFarms filters. Point searches return
+// farms nearest first with their geodesic distance.
+func (s *FarmServiceImpl) SearchFarms(ctx context.Context, req interface{}) (interface{}, error) {
+	searchReq, ok := req.(*requests.SearchFarmsRequest)
+	if !ok {
+		return nil, fmt.Errorf("invalid request type for SearchFarms")
+	}
+	if err := searchReq.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", common.ErrInvalidInput, err)
+	}
+
+	// Extract authenticated user from context
+	userCtx, err := auth.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user context: %w", err)
+	}
+
+	// Searching is listing by location
+	hasPermission, err := s.aaaService.CheckPermission(ctx, userCtx.AAAUserID, "farm", "list", "", searchReq.AAAOrgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !hasPermission {
+		return nil, common.ErrForbidden
+	}
+
+	spatial := farmRepo.SpatialFilter{
+		Intersects: searchReq.Intersects,
+		BBox:       searchReq.BBox,
+	}
+	if searchReq.Lat != nil && searchReq.Lon != nil {
+		spatial.Lat, spatial.Lon = *searchReq.Lat, *searchReq.Lon
+	}
+	if searchReq.RadiusM != nil {
+		spatial.RadiusM = *searchReq.RadiusM
+	}
+	if searchReq.Nearest != nil {
+		spatial.Nearest = *searchReq.Nearest
+	}
+	if searchReq.Within != nil {
+		spatial.WithinWKT = searchReq.Within.WKT
+	}
+
+	results, total, err := s.farmRepo.SearchSpatial(ctx, spatial, farmSearchFilter(&searchReq.ListFarmsRequest),
+		searchReq.PageSize, (searchReq.Page-1)*searchReq.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	farmDataList := make([]*responses.FarmData, len(results))
+	for i, result := range results {
+		farmDataList[i] = s.convertFarmToData(result.Farm)
+		farmDataList[i].DistanceM = result.DistanceM
+	}
+
+	response := responses.NewFarmListResponse(farmDataList, searchReq.Page, searchReq.PageSize, total)
+	response.SetRequestID(searchReq.RequestID)
+
+	return &response, nil
+}
+
+// farmSearchFilter returns the attribute filters of a list request
+func farmSearchFilter(listReq *requests.ListFarmsRequest) farmRepo.FarmSearchFilter {
+	return farmRepo.FarmSearchFilter{
+		AAAOrgID:           listReq.AAAOrgID,
+		FarmerID:           listReq.FarmerID,
+		AAAUserID:          listReq.AAAUserID,
+		OwnershipType:      listReq.OwnershipType,
+		SoilTypeID:         listReq.SoilTypeID,
+		IrrigationSourceID: listReq.IrrigationSourceID,
+		MinAreaHa:          listReq.MinArea,
+		MaxAreaHa:          listReq.MaxArea,
+		AdminArea:          listReq.AdminAreaFilter,
+	}
+}
+
+// GetFarmTile renders the farms of the caller's organization in one XYZ tile as a Mapbox
+// Vector Tile with a single "farms" layer. Geometries are clipped to the tile by PostGIS, so
+// map clients can draw every farm of an FPO without downloading all of them.
+func (s *FarmServiceImpl) GetFarmTile(ctx context.Context, req interface{}) ([]byte, error) {
+	tileReq, ok := req.(*requests.FarmTileRequest)
+	if !ok {
+		return nil, fmt.Errorf("invalid request type for GetFarmTile")
+	}
+	if err := tileReq.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", common.ErrInvalidInput, err)
+	}
+	if tileReq.OrgID == "" {
+		return nil, fmt.Errorf("%w: an organization context is required for farm tiles", common.ErrInvalidInput)
+	}
+
+	// Extract authenticated user from context
+	userCtx, err := auth.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user context: %w", err)
+	}
+
+	hasPermission, err := s.aaaService.CheckPermission(ctx, userCtx.AAAUserID, "farm", "list", "", tileReq.OrgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !hasPermission {
+		return nil, common.ErrForbidden
+	}
+
+	if s.db == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+
+	var postgisAvailable bool
+	if err := s.db.Raw(`SELECT EXISTS(SELECT 1 FROM pg_extension WHERE extname = 'postgis')`).Scan(&postgisAvailable).Error; err != nil {
+		return nil, fmt.Errorf("failed to check PostGIS availability: %w", err)
+	}
+	if !postgisAvailable {
+		return nil, fmt.Errorf("PostGIS extension not available for vector tiles")
+	}
+
+	// The bounding box test runs in EPSG:4326 so that it can use the geometry index
+	query := `
+		WITH bounds AS (
+			SELECT ST_TileEnvelope(?, ?, ?) AS geom
+		),
+		tile AS (
+			SELECT
+				f.id,
+				f.name,
+				f.farmer_id,
+				f.ownership_type,
+				f.area_ha_computed::float8 AS area_ha,
+				ST_AsMVTGeom(ST_Transform(f.geometry, 3857), bounds.geom) AS geom
+			FROM farms f, bounds
+			WHERE f.aaa_org_id = ?
+			AND f.deleted_at IS NULL
+			AND f.geometry && ST_Transform(bounds.geom, 4326)
+		)
+		SELECT ST_AsMVT(tile.*, 'farms', 4096, 'geom') FROM tile`
+
+	var tile []byte
+	if err := s.db.WithContext(ctx).Raw(query, tileReq.Z, tileReq.X, tileReq.Y, tileReq.OrgID).Row().Scan(&tile); err != nil {
+		return nil, fmt.Errorf("failed to render farm tile: %w", err)
+	}
+
+	return tile, nil
+}
+
 // ValidateGeometry validates WKT geometry with SRID enforcement and integrity checks
 func (s *FarmServiceImpl) ValidateGeometry(ctx context.Context, wkt string) error {
 	return s.validateGeometry(ctx, wkt)
@@ -467,11 +744,7 @@ func (s *FarmServiceImpl) validateGeometry(ctx context.Context, wkt string) erro
 
 	// If no database connection, do basic validation
 	if s.db == nil {
-		// Basic WKT format validation without PostGIS
-		if !strings.HasPrefix(strings.ToUpper(wkt), "POLYGON") {
-			return fmt.Errorf("only POLYGON geometries are supported")
-		}
-		return nil
+		return validateGeometryStructure(wkt)
 	}
 
 	// Check if PostGIS is available
@@ -481,14 +754,11 @@ func (s *FarmServiceImpl) validateGeometry(ctx context.Context, wkt string) erro
 	}
 
 	if !postgisAvailable {
-		// Basic WKT format validation without PostGIS
-		if !strings.HasPrefix(strings.ToUpper(wkt), "POLYGON") {
-			return fmt.Errorf("only POLYGON geometries are supported")
-		}
-		return nil
+		return validateGeometryStructure(wkt)
 	}
 
-	// Validate WKT format using PostGIS
+	// Validate WKT format using PostGIS. Validity covers holes outside their exterior and
+	// overlapping parts of a multipolygon.
 	var isValid bool
 	if err := s.db.Raw("SELECT ST_IsValid(ST_GeomFromText(?, 4326))", wkt).Scan(&isValid).Error; err != nil {
 		return fmt.Errorf("invalid WKT format: %w", err)
@@ -497,13 +767,13 @@ func (s *FarmServiceImpl) validateGeometry(ctx context.Context, wkt string) erro
 		return fmt.Errorf("geometry is not valid")
 	}
 
-	// Check geometry type (must be POLYGON)
+	// Check geometry type: a single parcel, possibly with holes, or a fragmented holding
 	var geomType string
 	if err := s.db.Raw("SELECT ST_GeometryType(ST_GeomFromText(?, 4326))", wkt).Scan(&geomType).Error; err != nil {
 		return fmt.Errorf("failed to get geometry type: %w", err)
 	}
-	if geomType != "ST_Polygon" {
-		return fmt.Errorf("only POLYGON geometries are supported, got %s", geomType)
+	if geomType != "ST_Polygon" && geomType != "ST_MultiPolygon" {
+		return fmt.Errorf("only POLYGON and MULTIPOLYGON geometries are supported, got %s", geomType)
 	}
 
 	// Check for self-intersections
@@ -546,6 +816,15 @@ func (s *FarmServiceImpl) validateGeometry(ctx context.Context, wkt string) erro
 	return nil
 }
 
+// validateGeometryStructure checks a geometry without PostGIS: it must be a well-formed
+// POLYGON or MULTIPOLYGON in WGS 84
+func validateGeometryStructure(wkt string) error {
+	if _, err := geo.ParseWKT(wkt); err != nil {
+		return fmt.Errorf("invalid geometry: %w", err)
+	}
+	return nil
+}
+
 func (s *FarmServiceImpl) filterFarmsByArea(farms []*farmEntity.Farm, minArea, maxArea *float64) []*farmEntity.Farm {
 	if minArea == nil && maxArea == nil {
 		return farms
@@ -564,11 +843,206 @@ func (s *FarmServiceImpl) filterFarmsByArea(farms []*farmEntity.Farm, minArea, m
 	return filtered
 }
 
+// newGeometryVersion describes a farm's new boundary for its history
+func newGeometryVersion(wkt string, geometry *requests.GeometryData, authorID string) *farmEntity.FarmGeometryVersion {
+	version := farmEntity.NewFarmGeometryVersion()
+	version.Geometry = wkt
+	version.Reason = geometry.ChangeReason
+	version.AuthorID = authorID
+	version.CreatedBy = authorID
+	version.UpdatedBy = authorID
+	if geometry.Source != "" {
+		version.Source = farmEntity.GeometrySource(geometry.Source)
+	}
+	return version
+}
+
+// getReadableFarm returns a farm after checking that the authenticated user may read it
+func (s *FarmServiceImpl) getReadableFarm(ctx context.Context, farmID string) (*farmEntity.Farm, error) {
+	filter := base.NewFilterBuilder().Where("id", base.OpEqual, farmID).Build()
+	farm, err := s.farmRepo.FindOne(ctx, filter)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, common.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get farm: %w", err)
+	}
+
+	userCtx, err := auth.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user context: %w", err)
+	}
+	hasPermission, err := s.aaaService.CheckPermission(ctx, userCtx.AAAUserID, "farm", "read", farm.ID, farm.AAAOrgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !hasPermission {
+		return nil, common.ErrForbidden
+	}
+	return farm, nil
+}
+
+// ListFarmGeometryVersions lists the boundary history of a farm, oldest first
+func (s *FarmServiceImpl) ListFarmGeometryVersions(ctx context.Context, farmID string) (interface{}, error) {
+	if _, err := s.getReadableFarm(ctx, farmID); err != nil {
+		return nil, err
+	}
+
+	versions, err := s.farmRepo.ListGeometryVersions(ctx, farmID)
+	if err != nil {
+		return nil, err
+	}
+	data := make([]*responses.FarmGeometryVersionData, len(versions))
+	for i, version := range versions {
+		data[i] = convertGeometryVersionToData(version)
+	}
+
+	response := responses.NewFarmGeometryVersionListResponse(data, "Farm geometry versions retrieved successfully")
+	return &response, nil
+}
+
+// DiffFarmGeometryVersions returns the area a farm gained and lost between two boundary
+// versions, as geometries
+func (s *FarmServiceImpl) DiffFarmGeometryVersions(ctx context.Context, req interface{}) (interface{}, error) {
+	diffReq, ok := req.(*requests.FarmGeometryDiffRequest)
+	if !ok {
+		return nil, fmt.Errorf("invalid request type for DiffFarmGeometryVersions")
+	}
+	if err := diffReq.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", common.ErrInvalidInput, err)
+	}
+	if _, err := s.getReadableFarm(ctx, diffReq.FarmID); err != nil {
+		return nil, err
+	}
+
+	diff, err := s.farmRepo.DiffGeometryVersions(ctx, diffReq.FarmID, diffReq.FromVersion, diffReq.ToVersion)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, common.ErrNotFound
+		}
+		return nil, err
+	}
+
+	data := &responses.FarmGeometryDiffData{
+		FarmID:          diffReq.FarmID,
+		FromVersion:     diffReq.FromVersion,
+		ToVersion:       diffReq.ToVersion,
+		AddedAreaHa:     diff.AddedAreaHa,
+		RemovedAreaHa:   diff.RemovedAreaHa,
+		NetAreaChangeHa: diff.AddedAreaHa - diff.RemovedAreaHa,
+	}
+	// An empty difference does not parse and is left out
+	if added, err := geo.ParseWKT(diff.AddedWKT); err == nil {
+		data.Added, data.AddedGeoJSON = added.WKT(), added.GeoJSON()
+	}
+	if removed, err := geo.ParseWKT(diff.RemovedWKT); err == nil {
+		data.Removed, data.RemovedGeoJSON = removed.WKT(), removed.GeoJSON()
+	}
+
+	response := responses.NewFarmGeometryDiffResponse(data, "Farm geometry versions compared successfully")
+	response.SetRequestID(diffReq.RequestID)
+	return &response, nil
+}
+
+// GetFarmGeometryAsOf returns the boundary version a farm had at a point in time, such as the
+// boundary during a past season. Farms whose boundary never changed since before boundary
+// history began are answered with their current boundary.
+func (s *FarmServiceImpl) GetFarmGeometryAsOf(ctx context.Context, req interface{}) (interface{}, error) {
+	asOfReq, ok := req.(*requests.FarmGeometryAsOfRequest)
+	if !ok {
+		return nil, fmt.Errorf("invalid request type for GetFarmGeometryAsOf")
+	}
+	farm, err := s.getReadableFarm(ctx, asOfReq.FarmID)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := s.farmRepo.GetGeometryVersionAt(ctx, asOfReq.FarmID, asOfReq.AsOf)
+	if err == gorm.ErrRecordNotFound {
+		versions, listErr := s.farmRepo.ListGeometryVersions(ctx, asOfReq.FarmID)
+		if listErr != nil {
+			return nil, listErr
+		}
+		if len(versions) > 0 || farm.Geometry == "" || farm.CreatedAt.After(asOfReq.AsOf) {
+			return nil, common.ErrNotFound
+		}
+		version = &farmEntity.FarmGeometryVersion{
+			FarmID:    farm.ID,
+			Version:   1,
+			Geometry:  farm.Geometry,
+			AreaHa:    farm.AreaHaComputed,
+			Source:    farmEntity.GeometrySourceUnknown,
+			AuthorID:  farm.CreatedBy,
+			ValidFrom: farm.CreatedAt,
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get farm geometry: %w", err)
+	}
+
+	response := responses.NewFarmGeometryVersionResponse(convertGeometryVersionToData(version), "Farm geometry retrieved successfully")
+	response.SetRequestID(asOfReq.RequestID)
+	return &response, nil
+}
+
+// convertGeometryVersionToData converts a farm geometry version to its response form
+func convertGeometryVersionToData(version *farmEntity.FarmGeometryVersion) *responses.FarmGeometryVersionData {
+	geometryWKT, geometryGeoJSON := farmGeometry(version.Geometry)
+	return &responses.FarmGeometryVersionData{
+		ID:          version.ID,
+		FarmID:      version.FarmID,
+		Version:     version.Version,
+		Geometry:    geometryWKT,
+		GeoJSON:     geometryGeoJSON,
+		AreaHa:      version.AreaHa,
+		AreaDeltaHa: version.AreaDeltaHa,
+		Source:      string(version.Source),
+		Reason:      version.Reason,
+		AuthorID:    version.AuthorID,
+		ValidFrom:   version.ValidFrom,
+		ValidTo:     version.ValidTo,
+	}
+}
+
+// geometryRepairData converts a geometry repair to its response form
+func geometryRepairData(repair *farmRepo.GeometryRepair) *responses.GeometryRepairData {
+	if repair == nil {
+		return nil
+	}
+	data := &responses.GeometryRepairData{
+		RepairedWKT:    repair.WKT,
+		OriginalAreaHa: repair.OriginalAreaHa,
+		RepairedAreaHa: repair.RepairedAreaHa,
+		AreaChangeHa:   repair.RepairedAreaHa - repair.OriginalAreaHa,
+		Corrections:    repair.Corrections,
+	}
+	if data.Corrections == nil {
+		data.Corrections = []string{}
+	}
+	if repair.OriginalAreaHa > 0 {
+		data.AreaChangePct = data.AreaChangeHa / repair.OriginalAreaHa * 100
+	}
+	return data
+}
+
+// farmGeometry returns a stored farm geometry, which PostGIS returns as hex EWKB, as WKT and
+// as GeoJSON. A geometry that cannot be read is returned unchanged without GeoJSON.
+func farmGeometry(stored string) (string, json.RawMessage) {
+	if stored == "" {
+		return "", nil
+	}
+	geometry, err := geo.ParseGeometry(stored)
+	if err != nil {
+		return stored, nil
+	}
+	return geometry.WKT(), geometry.GeoJSON()
+}
+
 func (s *FarmServiceImpl) convertFarmToData(farm *farmEntity.Farm) *responses.FarmData {
 	farmName := ""
 	if farm.Name != nil {
 		farmName = *farm.Name
 	}
+	geometryWKT, geometryGeoJSON := farmGeometry(farm.Geometry)
 	return &responses.FarmData{
 		ID:                        farm.ID,
 		FarmerID:                  farm.FarmerID,
@@ -576,7 +1050,8 @@ func (s *FarmServiceImpl) convertFarmToData(farm *farmEntity.Farm) *responses.Fa
 		AAAOrgID:                  farm.AAAOrgID,
 		Name:                      farmName,
 		OwnershipType:             string(farm.OwnershipType),
-		Geometry:                  farm.Geometry,
+		Geometry:                  geometryWKT,
+		GeoJSON:                   geometryGeoJSON,
 		AreaHa:                    farm.AreaHa,
 		AreaHaComputed:            farm.AreaHaComputed,
 		SoilTypeID:                farm.SoilTypeID,
@@ -584,6 +1059,10 @@ func (s *FarmServiceImpl) convertFarmToData(farm *farmEntity.Farm) *responses.Fa
 		BoreWellCount:             farm.BoreWellCount,
 		OtherIrrigationDetails:    farm.OtherIrrigationDetails,
 		Metadata:                  farm.Metadata,
+		StateCode:                 farm.StateCode,
+		DistrictCode:              farm.DistrictCode,
+		BlockCode:                 farm.BlockCode,
+		VillageCode:               farm.VillageCode,
 		CreatedAt:                 farm.CreatedAt,
 		UpdatedAt:                 farm.UpdatedAt,
 	}
@@ -594,6 +1073,7 @@ func (s *FarmServiceImpl) convertFarmToDataWithRelations(farm *farmEntity.Farm)
 	if farm.Name != nil {
 		farmName = *farm.Name
 	}
+	geometryWKT, geometryGeoJSON := farmGeometry(farm.Geometry)
 
 	farmData := &responses.FarmData{
 		ID:                        farm.ID,
@@ -602,7 +1082,8 @@ func (s *FarmServiceImpl) convertFarmToDataWithRelations(farm *farmEntity.Farm)
 		AAAOrgID:                  farm.AAAOrgID,
 		Name:                      farmName,
 		OwnershipType:             string(farm.OwnershipType),
-		Geometry:                  farm.Geometry,
+		Geometry:                  geometryWKT,
+		GeoJSON:                   geometryGeoJSON,
 		AreaHa:                    farm.AreaHa,
 		AreaHaComputed:            farm.AreaHaComputed,
 		SoilTypeID:                farm.SoilTypeID,
@@ -610,6 +1091,10 @@ func (s *FarmServiceImpl) convertFarmToDataWithRelations(farm *farmEntity.Farm)
 		BoreWellCount:             farm.BoreWellCount,
 		OtherIrrigationDetails:    farm.OtherIrrigationDetails,
 		Metadata:                  farm.Metadata,
+		StateCode:                 farm.StateCode,
+		DistrictCode:              farm.DistrictCode,
+		BlockCode:                 farm.BlockCode,
+		VillageCode:               farm.VillageCode,
 		CreatedAt:                 farm.CreatedAt,
 		UpdatedAt:                 farm.UpdatedAt,
 	}
@@ -827,8 +827,28 @@ func (s *FarmerServiceImpl) ListFarmers(ctx context.Context, req *requests.ListF
 	var totalCount int64
 	var err error
 
-	// If organization filter is specified, use the FPO linkage-based filtering
-	if req.AAAOrgID != "" {
+	// Get total count for pagination
+	countFilter := base.NewFilterBuilder()
+	if req.KisanSathiUserID != "" {
+		countFilter = countFilter.Where("kisan_sathi_user_id", base.OpEqual, req.KisanSathiUserID)
+	}
+	if req.PhoneNumber != "" {
+		countFilter = countFilter.Where("phone_number", base.OpEqual, req.PhoneNumber)
+	}
+
+	if !req.AdminAreaFilter.IsEmpty() {
+		// Keep farmers with a farm in the administrative area, linked to the organization if specified
+		farmers, err = s.repository.FindInAdminArea(ctx, req.AAAOrgID, req.AdminAreaFilter, filter.Build())
+		if err != nil {
+			return nil, fmt.Errorf("failed to find farmers in area: %w", err)
+		}
+
+		totalCount, err = s.repository.CountInAdminArea(ctx, req.AAAOrgID, req.AdminAreaFilter, countFilter.Build())
+		if err != nil {
+			return nil, fmt.Errorf("failed to count farmers in area: %w", err)
+		}
+	} else if req.AAAOrgID != "" {
+		// If organization filter is specified, use the FPO linkage-based filtering
 		// Use the custom FindByOrgID method that joins with farmer_links table
 		farmers, err = s.repository.FindByOrgID(ctx, req.AAAOrgID, filter.Build())
 		if err != nil {
@@ -836,14 +856,6 @@ func (s *FarmerServiceImpl) ListFarmers(ctx context.Context, req *requests.ListF
 		}
 
 		// Get count using the same join-based approach
-		countFilter := base.NewFilterBuilder()
-		if req.KisanSathiUserID != "" {
-			countFilter = countFilter.Where("kisan_sathi_user_id", base.OpEqual, req.KisanSathiUserID)
-		}
-		if req.PhoneNumber != "" {
-			countFilter = countFilter.Where("phone_number", base.OpEqual, req.PhoneNumber)
-		}
-
 		totalCount, err = s.repository.CountByOrgID(ctx, req.AAAOrgID, countFilter.Build())
 		if err != nil {
 			return nil, fmt.Errorf("failed to count farmers by org_id: %w", err)
@@ -855,15 +867,6 @@ func (s *FarmerServiceImpl) ListFarmers(ctx context.Context, req *requests.ListF
 			return nil, fmt.Errorf("failed to list farmers: %w", err)
 		}
 
-		// Get total count for pagination
-		countFilter := base.NewFilterBuilder()
-		if req.KisanSathiUserID != "" {
-			countFilter = countFilter.Where("kisan_sathi_user_id", base.OpEqual, req.KisanSathiUserID)
-		}
-		if req.PhoneNumber != "" {
-			countFilter = countFilter.Where("phone_number", base.OpEqual, req.PhoneNumber)
-		}
-
 		// Count without pagination
 		allResults, err := s.repository.Find(ctx, countFilter.Build())
 		if err != nil {
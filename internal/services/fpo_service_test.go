@@ -43,7 +43,7 @@ func TestFPOService_CreateFPO_Success(t *testing.T) {
 	mockAAA := &MockAAAService{}
 
 	// Create service
-	service := NewFPOService(mockRepo, mockAAA)
+	service := NewFPOService(mockRepo, mockAAA, nil)
 
 	// Test data
 	ctx := context.Background()
@@ -85,6 +85,7 @@ func TestFPOService_CreateFPO_Success(t *testing.T) {
 		}, nil)
 
 	mockAAA.On("AssignRole", ctx, "user123", "org123", "CEO").Return(nil)
+	mockAAA.On("AddUserToGroup", ctx, "user123", "group_directors").Return(nil)
 
 	// Mock user group creation
 	groupNames := []string{"directors", "shareholders", "store_staff", "store_managers"}
@@ -148,7 +149,7 @@ func TestFPOService_CreateFPO_ValidationErrors(t *testing.T) {
 	t.Run("Missing FPO name", func(t *testing.T) {
 		mockRepo := &MockFPORefRepository{}
 		mockAAA := &MockAAAService{}
-		service := NewFPOService(mockRepo, mockAAA)
+		service := NewFPOService(mockRepo, mockAAA, nil)
 
 		result, err := service.CreateFPO(ctx, &requests.CreateFPORequest{
 			RegistrationNo: "FPO123456",
@@ -166,7 +167,7 @@ func TestFPOService_CreateFPO_ValidationErrors(t *testing.T) {
 	t.Run("Missing registration number", func(t *testing.T) {
 		mockRepo := &MockFPORefRepository{}
 		mockAAA := &MockAAAService{}
-		service := NewFPOService(mockRepo, mockAAA)
+		service := NewFPOService(mockRepo, mockAAA, nil)
 
 		result, err := service.CreateFPO(ctx, &requests.CreateFPORequest{
 			Name: "Test FPO",
@@ -184,7 +185,7 @@ func TestFPOService_CreateFPO_ValidationErrors(t *testing.T) {
 	t.Run("Missing CEO phone number", func(t *testing.T) {
 		mockRepo := &MockFPORefRepository{}
 		mockAAA := &MockAAAService{}
-		service := NewFPOService(mockRepo, mockAAA)
+		service := NewFPOService(mockRepo, mockAAA, nil)
 
 		result, err := service.CreateFPO(ctx, &requests.CreateFPORequest{
 			Name:           "Test FPO",
@@ -203,7 +204,7 @@ func TestFPOService_CreateFPO_ValidationErrors(t *testing.T) {
 	t.Run("Missing CEO name when creating new user", func(t *testing.T) {
 		mockRepo := &MockFPORefRepository{}
 		mockAAA := &MockAAAService{}
-		service := NewFPOService(mockRepo, mockAAA)
+		service := NewFPOService(mockRepo, mockAAA, nil)
 
 		// Mock AAA returns user not found
 		mockAAA.On("GetUserByMobile", ctx, "+919876543210").Return(nil, errors.New("user not found"))
@@ -225,7 +226,7 @@ func TestFPOService_CreateFPO_ValidationErrors(t *testing.T) {
 	t.Run("Existing CEO user - no name required", func(t *testing.T) {
 		mockRepo := &MockFPORefRepository{}
 		mockAAA := &MockAAAService{}
-		service := NewFPOService(mockRepo, mockAAA)
+		service := NewFPOService(mockRepo, mockAAA, nil)
 
 		// Mock AAA returns existing user
 		mockAAA.On("GetUserByMobile", ctx, "+919876543210").Return(
@@ -249,6 +250,7 @@ func TestFPOService_CreateFPO_ValidationErrors(t *testing.T) {
 
 		// Mock role assignment
 		mockAAA.On("AssignRole", ctx, "user123", "org123", "CEO").Return(nil)
+		mockAAA.On("AddUserToGroup", ctx, "user123", "group_directors").Return(nil)
 
 		// Mock user groups
 		groupNames := []string{"directors", "shareholders", "store_staff", "store_managers"}
@@ -288,7 +290,7 @@ func TestFPOService_RegisterFPORef_Success(t *testing.T) {
 	mockAAA := &MockAAAService{}
 
 	// Create service
-	service := NewFPOService(mockRepo, mockAAA)
+	service := NewFPOService(mockRepo, mockAAA, nil)
 
 	// Test data
 	ctx := context.Background()
@@ -337,7 +339,7 @@ func TestFPOService_RegisterFPORef_AlreadyExists(t *testing.T) {
 	mockAAA := &MockAAAService{}
 
 	// Create service
-	service := NewFPOService(mockRepo, mockAAA)
+	service := NewFPOService(mockRepo, mockAAA, nil)
 
 	// Test data
 	ctx := context.Background()
@@ -381,7 +383,7 @@ func TestFPOService_GetFPORef_Success(t *testing.T) {
 	mockAAA := &MockAAAService{}
 
 	// Create service
-	service := NewFPOService(mockRepo, mockAAA)
+	service := NewFPOService(mockRepo, mockAAA, nil)
 
 	// Test data
 	ctx := context.Background()
@@ -426,7 +428,7 @@ func TestFPOService_GetFPORef_NotFound(t *testing.T) {
 	mockAAA := &MockAAAService{}
 
 	// Create service
-	service := NewFPOService(mockRepo, mockAAA)
+	service := NewFPOService(mockRepo, mockAAA, nil)
 
 	// Test data
 	ctx := context.Background()
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/idempotency"
+	"github.com/Kisanlink/farmers-module/internal/interfaces"
+	idempotencyRepo "github.com/Kisanlink/farmers-module/internal/repo/idempotency"
+	"github.com/Kisanlink/farmers-module/pkg/common"
+)
+
+// DefaultIdempotencyKeyTTL is how long a stored response is replayed when no TTL is configured
+const DefaultIdempotencyKeyTTL = 24 * time.Hour
+
+// IdempotencyService tracks requests made with an Idempotency-Key header so that retried
+// requests are answered with the response of the first attempt
+type IdempotencyService interface {
+	// Begin claims a key for a request. When the key was already used for the same request
+	// and that request completed, the stored key is returned with replay set. Using a key for
+	// a different request returns common.ErrIdempotencyKeyReused; retrying while the first
+	// attempt is still running returns common.ErrIdempotencyKeyInProgress.
+	Begin(ctx context.Context, tenantID, key, method, path, requestHash string) (record *idempotency.IdempotencyKey, replay bool, err error)
+
+	// Complete stores the response of a claimed key for replay
+	Complete(ctx context.Context, record *idempotency.IdempotencyKey, responseStatus int, contentType string, body []byte) error
+
+	// Release gives up a claimed key, so the request can be retried with it
+	Release(ctx context.Context, record *idempotency.IdempotencyKey) error
+}
+
+// IdempotencyServiceImpl implements IdempotencyService
+type IdempotencyServiceImpl struct {
+	repo   idempotencyRepo.IdempotencyKeyRepository
+	ttl    time.Duration
+	logger interfaces.Logger
+	now    func() time.Time
+}
+
+// NewIdempotencyService creates a new idempotency service. Stored responses are replayed
+// for ttl after the first request.
+func NewIdempotencyService(repo idempotencyRepo.IdempotencyKeyRepository, ttl time.Duration, logger interfaces.Logger) IdempotencyService {
+	if ttl <= 0 {
+		ttl = DefaultIdempotencyKeyTTL
+	}
+	return &IdempotencyServiceImpl{
+		repo:   repo,
+		ttl:    ttl,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// Begin claims a key for a request or returns the stored outcome of an earlier request
+func (s *IdempotencyServiceImpl) Begin(ctx context.Context, tenantID, key, method, path, requestHash string) (*idempotency.IdempotencyKey, bool, error) {
+	record := idempotency.NewIdempotencyKey()
+	record.TenantID = tenantID
+	record.Key = key
+	record.Method = method
+	record.Path = path
+	record.RequestHash = requestHash
+
+	// An expired key is removed and claimed afresh; one retry covers that case
+	for attempt := 0; attempt < 2; attempt++ {
+		now := s.now()
+		record.ExpiresAt = now.Add(s.ttl)
+
+		claimed, err := s.repo.Claim(ctx, record)
+		if err != nil {
+			return nil, false, err
+		}
+		if claimed {
+			return record, false, nil
+		}
+
+		existing, err := s.repo.Get(ctx, tenantID, key)
+		if err != nil {
+			return nil, false, err
+		}
+		if existing == nil {
+			// Released between the claim and the lookup
+			continue
+		}
+
+		if existing.IsExpired(now) {
+			if _, err := s.repo.DeleteIfExpired(ctx, existing.ID, now); err != nil {
+				return nil, false, err
+			}
+			continue
+		}
+
+		if existing.RequestHash != requestHash {
+			return nil, false, common.ErrIdempotencyKeyReused
+		}
+		if existing.Status != idempotency.KeyStatusCompleted {
+			return nil, false, common.ErrIdempotencyKeyInProgress
+		}
+		return existing, true, nil
+	}
+
+	return nil, false, fmt.Errorf("failed to claim idempotency key %q", key)
+}
+
+// Complete stores the response of a claimed key
+func (s *IdempotencyServiceImpl) Complete(ctx context.Context, record *idempotency.IdempotencyKey, responseStatus int, contentType string, body []byte) error {
+	if err := s.repo.Complete(ctx, record.ID, responseStatus, contentType, body); err != nil {
+		return err
+	}
+	record.Status = idempotency.KeyStatusCompleted
+	record.ResponseStatus = responseStatus
+	record.ResponseContentType = contentType
+	record.ResponseBody = body
+	return nil
+}
+
+// Release removes a claimed key
+func (s *IdempotencyServiceImpl) Release(ctx context.Context, record *idempotency.IdempotencyKey) error {
+	return s.repo.Delete(ctx, record.ID)
+}
@@ -0,0 +1,202 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/input_product"
+	"github.com/Kisanlink/farmers-module/internal/entities/requests"
+	"github.com/Kisanlink/farmers-module/internal/entities/responses"
+	inputProductRepo "github.com/Kisanlink/farmers-module/internal/repo/input_product"
+	"github.com/Kisanlink/farmers-module/pkg/common"
+)
+
+// InputProductServiceImpl implements InputProductService
+type InputProductServiceImpl struct {
+	productRepo inputProductRepo.InputProductRepository
+	aaaService  AAAService
+}
+
+// NewInputProductService creates a new input product service
+func NewInputProductService(productRepo inputProductRepo.InputProductRepository, aaaService AAAService) InputProductService {
+	return &InputProductServiceImpl{
+		productRepo: productRepo,
+		aaaService:  aaaService,
+	}
+}
+
+// CreateInputProduct adds a product to the input master
+func (s *InputProductServiceImpl) CreateInputProduct(ctx context.Context, req interface{}) (interface{}, error) {
+	createReq, ok := req.(*requests.CreateInputProductRequest)
+	if !ok {
+		return nil, common.ErrInvalidInput
+	}
+
+	if err := s.checkPermission(ctx, createReq.UserID, "create", "", createReq.OrgID); err != nil {
+		return nil, err
+	}
+
+	product := input_product.NewInputProduct()
+	setInputProductFields(product, &createReq.InputProductRequest)
+	product.CreatedBy = createReq.UserID
+	if err := product.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: name, unit and a known category are required", err)
+	}
+
+	if err := s.productRepo.Create(ctx, product); err != nil {
+		return nil, err
+	}
+
+	response := responses.NewInputProductResponse(convertInputProductToData(product), "Input product created successfully")
+	response.SetRequestID(createReq.RequestID)
+	return &response, nil
+}
+
+// GetInputProduct gets a product of the input master
+func (s *InputProductServiceImpl) GetInputProduct(ctx context.Context, req interface{}) (interface{}, error) {
+	getReq, ok := req.(*requests.GetInputProductRequest)
+	if !ok {
+		return nil, common.ErrInvalidInput
+	}
+
+	if err := s.checkPermission(ctx, getReq.UserID, "read", getReq.ID, getReq.OrgID); err != nil {
+		return nil, err
+	}
+
+	product, err := s.productRepo.GetByID(ctx, getReq.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := responses.NewInputProductResponse(convertInputProductToData(product), "Input product retrieved successfully")
+	response.SetRequestID(getReq.RequestID)
+	return &response, nil
+}
+
+// UpdateInputProduct updates a product of the input master. Line items already recorded
+// against the product keep the unit and category they were recorded with.
+func (s *InputProductServiceImpl) UpdateInputProduct(ctx context.Context, req interface{}) (interface{}, error) {
+	updateReq, ok := req.(*requests.UpdateInputProductRequest)
+	if !ok {
+		return nil, common.ErrInvalidInput
+	}
+
+	if err := s.checkPermission(ctx, updateReq.UserID, "update", updateReq.ID, updateReq.OrgID); err != nil {
+		return nil, err
+	}
+
+	product, err := s.productRepo.GetByID(ctx, updateReq.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	setInputProductFields(product, &updateReq.InputProductRequest)
+	product.UpdatedBy = updateReq.UserID
+	if err := product.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: name, unit and a known category are required", err)
+	}
+
+	if err := s.productRepo.Update(ctx, product); err != nil {
+		return nil, err
+	}
+
+	response := responses.NewInputProductResponse(convertInputProductToData(product), "Input product updated successfully")
+	response.SetRequestID(updateReq.RequestID)
+	return &response, nil
+}
+
+// DeleteInputProduct removes a product from the input master
+func (s *InputProductServiceImpl) DeleteInputProduct(ctx context.Context, req interface{}) error {
+	deleteReq, ok := req.(*requests.DeleteInputProductRequest)
+	if !ok {
+		return common.ErrInvalidInput
+	}
+
+	if err := s.checkPermission(ctx, deleteReq.UserID, "delete", deleteReq.ID, deleteReq.OrgID); err != nil {
+		return err
+	}
+
+	return s.productRepo.Delete(ctx, deleteReq.ID, deleteReq.UserID)
+}
+
+// ListInputProducts lists the input master by category and name
+func (s *InputProductServiceImpl) ListInputProducts(ctx context.Context, req interface{}) (interface{}, error) {
+	listReq, ok := req.(*requests.ListInputProductsRequest)
+	if !ok {
+		return nil, common.ErrInvalidInput
+	}
+
+	if err := s.checkPermission(ctx, listReq.UserID, "list", "", listReq.OrgID); err != nil {
+		return nil, err
+	}
+
+	if listReq.Category != "" && !input_product.InputCategory(listReq.Category).IsValid() {
+		return nil, fmt.Errorf("%w: unknown input category %s", common.ErrInvalidInput, listReq.Category)
+	}
+	if listReq.Page < 1 {
+		listReq.Page = 1
+	}
+	if listReq.PageSize < 1 || listReq.PageSize > 100 {
+		listReq.PageSize = 20
+	}
+
+	products, total, err := s.productRepo.List(ctx, inputProductRepo.InputProductFilter{
+		Category:   listReq.Category,
+		Search:     strings.TrimSpace(listReq.Search),
+		ActiveOnly: listReq.ActiveOnly,
+		Limit:      listReq.PageSize,
+		Offset:     (listReq.Page - 1) * listReq.PageSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]*responses.InputProductData, len(products))
+	for i, p := range products {
+		data[i] = convertInputProductToData(p)
+	}
+
+	response := responses.NewInputProductListResponse(data, listReq.Page, listReq.PageSize, total)
+	response.SetRequestID(listReq.RequestID)
+	return &response, nil
+}
+
+// checkPermission checks that a user may act on the input master
+func (s *InputProductServiceImpl) checkPermission(ctx context.Context, userID, action, productID, orgID string) error {
+	hasPermission, err := s.aaaService.CheckPermission(ctx, userID, "input", action, productID, orgID)
+	if err != nil {
+		return fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !hasPermission {
+		return common.ErrForbidden
+	}
+	return nil
+}
+
+// setInputProductFields copies the fields of a request onto an input product
+func setInputProductFields(product *input_product.InputProduct, req *requests.InputProductRequest) {
+	product.Name = strings.TrimSpace(req.Name)
+	product.Category = input_product.InputCategory(strings.ToUpper(req.Category))
+	product.Unit = strings.TrimSpace(req.Unit)
+	product.Manufacturer = req.Manufacturer
+	product.Description = req.Description
+	if req.IsActive != nil {
+		product.IsActive = *req.IsActive
+	}
+}
+
+// convertInputProductToData converts an input product to its response data
+func convertInputProductToData(p *input_product.InputProduct) *responses.InputProductData {
+	return &responses.InputProductData{
+		ID:           p.ID,
+		Name:         p.Name,
+		Category:     string(p.Category),
+		Unit:         p.Unit,
+		Manufacturer: p.Manufacturer,
+		Description:  p.Description,
+		IsActive:     p.IsActive,
+		CreatedAt:    p.CreatedAt,
+		UpdatedAt:    p.UpdatedAt,
+	}
+}
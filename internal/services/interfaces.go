@@ -67,6 +67,81 @@ type FarmService interface {
 	ListFarms(ctx context.Context, req interface{}) (interface{}, error)
 	// Get farm by ID
 	GetFarm(ctx context.Context, farmID string) (interface{}, error)
+	// Search farms by radius, boundary, nearest-N or bounding box
+	SearchFarms(ctx context.Context, req interface{}) (interface{}, error)
+	// Export farms matching the list filters as a GeoJSON FeatureCollection
+	ExportFarms(ctx context.Context, req interface{}) (interface{}, error)
+	// Render the organization's farms in one XYZ tile as a Mapbox Vector Tile
+	GetFarmTile(ctx context.Context, req interface{}) ([]byte, error)
+	// List the boundary history of a farm
+	ListFarmGeometryVersions(ctx context.Context, farmID string) (interface{}, error)
+	// Compare two boundary versions of a farm
+	DiffFarmGeometryVersions(ctx context.Context, req interface{}) (interface{}, error)
+	// Get the boundary a farm had at a point in time
+	GetFarmGeometryAsOf(ctx context.Context, req interface{}) (interface{}, error)
+	// Validate a farm boundary with the rules farms are created with
+	ValidateGeometry(ctx context.Context, wkt string) error
+}
+
+// LandParcelService handles the land record parcels of farms
+type LandParcelService interface {
+	// Link a land parcel to a farm
+	CreateLandParcel(ctx context.Context, req interface{}) (interface{}, error)
+	// Replace the land record details of a parcel
+	UpdateLandParcel(ctx context.Context, req interface{}) (interface{}, error)
+	// Unlink a land parcel from a farm
+	DeleteLandParcel(ctx context.Context, req interface{}) error
+	// List the parcels of a farm
+	ListFarmLandParcels(ctx context.Context, farmID string) (interface{}, error)
+	// Search parcels by village and survey number
+	SearchLandParcels(ctx context.Context, req interface{}) (interface{}, error)
+}
+
+// PlotService handles the plots farms are divided into
+type PlotService interface {
+	// Add a plot to a farm
+	CreatePlot(ctx context.Context, req interface{}) (interface{}, error)
+	// Rename a plot or redraw its boundary
+	UpdatePlot(ctx context.Context, req interface{}) (interface{}, error)
+	// Remove a plot from a farm
+	DeletePlot(ctx context.Context, req interface{}) error
+	// List the plots of a farm
+	ListFarmPlots(ctx context.Context, farmID string) (interface{}, error)
+	// Map the plots of a farm with the crop cycles grown on them in a season
+	GetPlotMap(ctx context.Context, req interface{}) (interface{}, error)
+}
+
+// InputProductService handles the input master of seeds, fertilisers, pesticides, labour
+// and machinery
+type InputProductService interface {
+	CreateInputProduct(ctx context.Context, req interface{}) (interface{}, error)
+	GetInputProduct(ctx context.Context, req interface{}) (interface{}, error)
+	UpdateInputProduct(ctx context.Context, req interface{}) (interface{}, error)
+	DeleteInputProduct(ctx context.Context, req interface{}) error
+	ListInputProducts(ctx context.Context, req interface{}) (interface{}, error)
+}
+
+// CultivationCostService handles the input line items of farm activities and the cost of
+// cultivation rolled up from them
+type CultivationCostService interface {
+	// Replace the input line items of a farm activity
+	SetActivityInputs(ctx context.Context, req interface{}) (interface{}, error)
+	// List the input line items of a farm activity
+	ListActivityInputs(ctx context.Context, req interface{}) (interface{}, error)
+	// Get the cost of cultivation of a crop cycle
+	GetCycleCosts(ctx context.Context, req interface{}) (interface{}, error)
+	// Get the cost of cultivation of a farm's crop cycles
+	GetFarmCosts(ctx context.Context, req interface{}) (interface{}, error)
+}
+
+// AdminBoundaryService handles administrative boundary master data and farm geo-tagging
+type AdminBoundaryService interface {
+	// Import the boundaries of one administrative level and tag farms again
+	ImportAdminBoundaries(ctx context.Context, req interface{}) (interface{}, error)
+	// Tag every farm with the administrative areas it lies in
+	BackfillFarmAdminCodes(ctx context.Context, req interface{}) (interface{}, error)
+	// List the administrative areas of a level
+	ListAdminBoundaries(ctx context.Context, req interface{}) (interface{}, error)
 }
 
 // CropCycleService handles crop cycle workflows
@@ -83,6 +158,12 @@ type CropCycleService interface {
 	GetCropCycle(ctx context.Context, cycleID string) (interface{}, error)
 	// Get area allocation summary for a farm
 	GetAreaAllocationSummary(ctx context.Context, farmID string) (interface{}, error)
+	// Move a crop cycle's sowing date, shifting its planned activities
+	ReplanCycle(ctx context.Context, req interface{}) (interface{}, error)
+	// Get a crop cycle's expected yield from its variety's benchmarks
+	GetYieldEstimate(ctx context.Context, req interface{}) (interface{}, error)
+	// Estimate the production of an organization's open crop cycles by crop and season
+	GetProductionForecast(ctx context.Context, req interface{}) (interface{}, error)
 }
 
 // FarmActivityService handles farm activity workflows
@@ -91,6 +172,8 @@ type FarmActivityService interface {
 	CreateActivity(ctx context.Context, req interface{}) (interface{}, error)
 	// W15: Complete farm activity
 	CompleteActivity(ctx context.Context, req interface{}) (interface{}, error)
+	// Start farm activity
+	StartActivity(ctx context.Context, req interface{}) (interface{}, error)
 	// W16: Update farm activity
 	UpdateActivity(ctx context.Context, req interface{}) (interface{}, error)
 	// W17: List farm activities
@@ -101,6 +184,10 @@ type FarmActivityService interface {
 	DeleteActivity(ctx context.Context, activityID string) error
 	// Get stage-wise progress for a crop cycle
 	GetStageProgress(ctx context.Context, cropCycleID string) (interface{}, error)
+	// Record that a crop cycle entered a stage
+	AdvanceStage(ctx context.Context, req interface{}) (interface{}, error)
+	// List the crop cycles of an organisation running behind their stage calendar
+	ListDelayedCycles(ctx context.Context, req interface{}) (interface{}, error)
 }
 
 // CropService handles crop master data operations
@@ -173,8 +260,17 @@ type DataQualityService interface {
 	// RebuildSpatialIndexes rebuilds GIST indexes for database maintenance
 	RebuildSpatialIndexes(ctx context.Context, req interface{}) (interface{}, error)
 
-	// DetectFarmOverlaps detects spatial intersections between farm boundaries
+	// DetectFarmOverlaps detects spatial intersections between farm boundaries and records them as conflicts
 	DetectFarmOverlaps(ctx context.Context, req interface{}) (interface{}, error)
+
+	// ListOverlapConflicts lists an organization's farm overlap conflicts
+	ListOverlapConflicts(ctx context.Context, req interface{}) (interface{}, error)
+
+	// UpdateOverlapConflict changes the status, assignee or notes of an overlap conflict
+	UpdateOverlapConflict(ctx context.Context, req interface{}) (interface{}, error)
+
+	// ResolveOverlapConflict resolves an overlap conflict by trimming or splitting the farm boundaries
+	ResolveOverlapConflict(ctx context.Context, req interface{}) (interface{}, error)
 }
 
 // ReportingService handles reporting and analytics workflows
@@ -214,6 +310,12 @@ type StageService interface {
 	GetCropStages(ctx context.Context, req interface{}) (interface{}, error)
 	ReorderCropStages(ctx context.Context, req interface{}) (interface{}, error)
 
+	// Activity templates of crop stages
+	CreateActivityTemplate(ctx context.Context, req interface{}) (interface{}, error)
+	UpdateActivityTemplate(ctx context.Context, req interface{}) (interface{}, error)
+	DeleteActivityTemplate(ctx context.Context, req interface{}) (interface{}, error)
+	ListActivityTemplates(ctx context.Context, req interface{}) (interface{}, error)
+
 	// Lookup operations
 	GetStageLookup(ctx context.Context, req interface{}) (interface{}, error)
 }
@@ -0,0 +1,322 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Kisanlink/farmers-module/internal/auth"
+	farmEntity "github.com/Kisanlink/farmers-module/internal/entities/farm"
+	"github.com/Kisanlink/farmers-module/internal/entities/land_parcel"
+	"github.com/Kisanlink/farmers-module/internal/entities/requests"
+	"github.com/Kisanlink/farmers-module/internal/entities/responses"
+	farmRepo "github.com/Kisanlink/farmers-module/internal/repo/farm"
+	landParcelRepo "github.com/Kisanlink/farmers-module/internal/repo/land_parcel"
+	"github.com/Kisanlink/farmers-module/pkg/common"
+	"github.com/Kisanlink/kisanlink-db/pkg/base"
+	"gorm.io/gorm"
+)
+
+// LandParcelServiceImpl implements LandParcelService
+type LandParcelServiceImpl struct {
+	parcelRepo landParcelRepo.LandParcelRepository
+	farmRepo   *farmRepo.FarmRepository
+	aaaService AAAService
+	db         *gorm.DB
+}
+
+// NewLandParcelService creates a new land parcel service
+func NewLandParcelService(parcelRepo landParcelRepo.LandParcelRepository, farmRepo *farmRepo.FarmRepository, aaaService AAAService, db *gorm.DB) LandParcelService {
+	return &LandParcelServiceImpl{
+		parcelRepo: parcelRepo,
+		farmRepo:   farmRepo,
+		aaaService: aaaService,
+		db:         db,
+	}
+}
+
+// CreateLandParcel links a land parcel to a farm. The claim is rejected with a
+// ParcelShareExceededError when it would take the shares claimed in the survey number
+// above 100%.
+func (s *LandParcelServiceImpl) CreateLandParcel(ctx context.Context, req interface{}) (interface{}, error) {
+	createReq, ok := req.(*requests.CreateLandParcelRequest)
+	if !ok {
+		return nil, fmt.Errorf("invalid request type for CreateLandParcel")
+	}
+
+	farm, err := getFarmForAction(ctx, s.farmRepo, s.aaaService, createReq.FarmID, "update")
+	if err != nil {
+		return nil, err
+	}
+
+	parcel := land_parcel.NewLandParcel()
+	parcel.FarmID = farm.ID
+	parcel.FarmerID = farm.FarmerID
+	parcel.AAAOrgID = farm.AAAOrgID
+	parcel.CreatedBy = createReq.UserID
+	parcel.UpdatedBy = createReq.UserID
+	applyLandParcelRequest(parcel, &createReq.LandParcelRequest)
+	if err := parcel.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", common.ErrInvalidInput, err)
+	}
+
+	if err := s.parcelRepo.Create(ctx, parcel); err != nil {
+		return nil, err
+	}
+	ownership, err := refreshFarmOwnershipType(ctx, s.db, farm, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	data := convertLandParcelToData(parcel)
+	data.FarmOwnershipType = string(ownership)
+	response := responses.NewLandParcelResponse(data, "Land parcel created successfully")
+	response.SetRequestID(createReq.RequestID)
+	return &response, nil
+}
+
+// UpdateLandParcel replaces the land record details of a parcel, checking its claim again
+func (s *LandParcelServiceImpl) UpdateLandParcel(ctx context.Context, req interface{}) (interface{}, error) {
+	updateReq, ok := req.(*requests.UpdateLandParcelRequest)
+	if !ok {
+		return nil, fmt.Errorf("invalid request type for UpdateLandParcel")
+	}
+
+	farm, err := getFarmForAction(ctx, s.farmRepo, s.aaaService, updateReq.FarmID, "update")
+	if err != nil {
+		return nil, err
+	}
+	parcel, err := s.getFarmParcel(ctx, farm.ID, updateReq.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The request replaces the parcel's details; omitted optional fields are cleared
+	parcel.KhataNumber, parcel.AreaHa, parcel.LeaseStartDate, parcel.LeaseEndDate = nil, nil, nil, nil
+	parcel.LessorName, parcel.DocumentType, parcel.DocumentReference = nil, nil, nil
+	parcel.SharePct, parcel.Tenure = 100, land_parcel.TenureOwned
+	applyLandParcelRequest(parcel, &updateReq.LandParcelRequest)
+	parcel.UpdatedBy = updateReq.UserID
+	if err := parcel.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", common.ErrInvalidInput, err)
+	}
+
+	if err := s.parcelRepo.Update(ctx, parcel); err != nil {
+		return nil, err
+	}
+	ownership, err := refreshFarmOwnershipType(ctx, s.db, farm, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	data := convertLandParcelToData(parcel)
+	data.FarmOwnershipType = string(ownership)
+	response := responses.NewLandParcelResponse(data, "Land parcel updated successfully")
+	response.SetRequestID(updateReq.RequestID)
+	return &response, nil
+}
+
+// DeleteLandParcel unlinks a land parcel from a farm, releasing its share of the survey number
+func (s *LandParcelServiceImpl) DeleteLandParcel(ctx context.Context, req interface{}) error {
+	deleteReq, ok := req.(*requests.DeleteLandParcelRequest)
+	if !ok {
+		return fmt.Errorf("invalid request type for DeleteLandParcel")
+	}
+
+	farm, err := getFarmForAction(ctx, s.farmRepo, s.aaaService, deleteReq.FarmID, "update")
+	if err != nil {
+		return err
+	}
+	if _, err := s.getFarmParcel(ctx, farm.ID, deleteReq.ID); err != nil {
+		return err
+	}
+
+	if err := s.parcelRepo.Delete(ctx, deleteReq.ID, deleteReq.UserID); err != nil {
+		return err
+	}
+	_, err = refreshFarmOwnershipType(ctx, s.db, farm, time.Now())
+	return err
+}
+
+// ListFarmLandParcels lists the parcels of a farm
+func (s *LandParcelServiceImpl) ListFarmLandParcels(ctx context.Context, farmID string) (interface{}, error) {
+	if _, err := getFarmForAction(ctx, s.farmRepo, s.aaaService, farmID, "read"); err != nil {
+		return nil, err
+	}
+
+	parcels, err := s.parcelRepo.ListByFarm(ctx, farmID)
+	if err != nil {
+		return nil, err
+	}
+	data := make([]*responses.LandParcelData, len(parcels))
+	for i, parcel := range parcels {
+		data[i] = convertLandParcelToData(parcel)
+	}
+
+	response := responses.NewLandParcelListResponse(data, 1, len(data), int64(len(data)))
+	return &response, nil
+}
+
+// SearchLandParcels searches an organization's parcels by their land record identifiers
+func (s *LandParcelServiceImpl) SearchLandParcels(ctx context.Context, req interface{}) (interface{}, error) {
+	searchReq, ok := req.(*requests.SearchLandParcelsRequest)
+	if !ok {
+		return nil, fmt.Errorf("invalid request type for SearchLandParcels")
+	}
+
+	userCtx, err := auth.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user context: %w", err)
+	}
+	hasPermission, err := s.aaaService.CheckPermission(ctx, userCtx.AAAUserID, "farm", "list", "", searchReq.AAAOrgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !hasPermission {
+		return nil, common.ErrForbidden
+	}
+
+	// Search on the identifiers as they are stored
+	query := land_parcel.LandParcel{
+		StateCode:    searchReq.StateCode,
+		DistrictCode: searchReq.DistrictCode,
+		TehsilCode:   searchReq.TehsilCode,
+		VillageCode:  searchReq.VillageCode,
+		SurveyNumber: searchReq.SurveyNumber,
+	}
+	query.Normalize()
+	parcels, total, err := s.parcelRepo.Search(ctx, landParcelRepo.SearchFilter{
+		AAAOrgID:     searchReq.AAAOrgID,
+		StateCode:    query.StateCode,
+		DistrictCode: query.DistrictCode,
+		TehsilCode:   query.TehsilCode,
+		VillageCode:  query.VillageCode,
+		SurveyNumber: query.SurveyNumber,
+		Limit:        searchReq.PageSize,
+		Offset:       (searchReq.Page - 1) * searchReq.PageSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]*responses.LandParcelData, len(parcels))
+	for i, parcel := range parcels {
+		data[i] = convertLandParcelToData(parcel)
+	}
+	response := responses.NewLandParcelListResponse(data, searchReq.Page, searchReq.PageSize, total)
+	response.SetRequestID(searchReq.RequestID)
+	return &response, nil
+}
+
+// getFarmForAction returns a farm after checking that the authenticated user may perform the
+// action on it. Services of the parts of a farm, such as its land parcels and plots, use it.
+func getFarmForAction(ctx context.Context, farms *farmRepo.FarmRepository, aaaService AAAService, farmID, action string) (*farmEntity.Farm, error) {
+	filter := base.NewFilterBuilder().Where("id", base.OpEqual, farmID).Build()
+	farm, err := farms.FindOne(ctx, filter)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, common.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get farm: %w", err)
+	}
+
+	userCtx, err := auth.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user context: %w", err)
+	}
+	hasPermission, err := aaaService.CheckPermission(ctx, userCtx.AAAUserID, "farm", action, farm.ID, farm.AAAOrgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !hasPermission {
+		return nil, common.ErrForbidden
+	}
+	return farm, nil
+}
+
+// getFarmParcel returns a parcel of the given farm, or common.ErrNotFound
+func (s *LandParcelServiceImpl) getFarmParcel(ctx context.Context, farmID, parcelID string) (*land_parcel.LandParcel, error) {
+	parcel, err := s.parcelRepo.GetByID(ctx, parcelID)
+	if err != nil {
+		return nil, err
+	}
+	if parcel.FarmID != farmID {
+		return nil, common.ErrNotFound
+	}
+	return parcel, nil
+}
+
+// refreshFarmOwnershipType sets a farm's ownership type to the one its parcels give it on the
+// given day and returns the farm's ownership type. A farm whose parcels say nothing keeps the
+// type it was given.
+func refreshFarmOwnershipType(ctx context.Context, db *gorm.DB, farm *farmEntity.Farm, now time.Time) (farmEntity.OwnershipType, error) {
+	var parcels []*land_parcel.LandParcel
+	if err := db.WithContext(ctx).
+		Where("farm_id = ? AND deleted_at IS NULL", farm.ID).
+		Find(&parcels).Error; err != nil {
+		return "", fmt.Errorf("failed to load land parcels: %w", err)
+	}
+
+	ownership, ok := land_parcel.DeriveOwnershipType(parcels, now)
+	if !ok || ownership == farm.OwnershipType {
+		return farm.OwnershipType, nil
+	}
+	if err := db.WithContext(ctx).Table("farms").
+		Where("id = ?", farm.ID).
+		Updates(map[string]interface{}{
+			"ownership_type": ownership,
+			"updated_at":     now,
+		}).Error; err != nil {
+		return "", fmt.Errorf("failed to update farm ownership type: %w", err)
+	}
+	farm.OwnershipType = ownership
+	return ownership, nil
+}
+
+// applyLandParcelRequest copies the land record details of a request onto a parcel
+func applyLandParcelRequest(parcel *land_parcel.LandParcel, req *requests.LandParcelRequest) {
+	parcel.StateCode = req.StateCode
+	parcel.DistrictCode = req.DistrictCode
+	parcel.TehsilCode = req.TehsilCode
+	parcel.VillageCode = req.VillageCode
+	parcel.SurveyNumber = req.SurveyNumber
+	parcel.KhataNumber = req.KhataNumber
+	parcel.AreaHa = req.AreaHa
+	if req.SharePct != nil {
+		parcel.SharePct = *req.SharePct
+	}
+	if req.Tenure != "" {
+		parcel.Tenure = land_parcel.TenureType(req.Tenure)
+	}
+	parcel.LeaseStartDate = req.LeaseStartDate
+	parcel.LeaseEndDate = req.LeaseEndDate
+	parcel.LessorName = req.LessorName
+	parcel.DocumentType = req.DocumentType
+	parcel.DocumentReference = req.DocumentReference
+	parcel.Normalize()
+}
+
+func convertLandParcelToData(parcel *land_parcel.LandParcel) *responses.LandParcelData {
+	return &responses.LandParcelData{
+		ID:                parcel.ID,
+		FarmID:            parcel.FarmID,
+		FarmerID:          parcel.FarmerID,
+		AAAOrgID:          parcel.AAAOrgID,
+		StateCode:         parcel.StateCode,
+		DistrictCode:      parcel.DistrictCode,
+		TehsilCode:        parcel.TehsilCode,
+		VillageCode:       parcel.VillageCode,
+		SurveyNumber:      parcel.SurveyNumber,
+		KhataNumber:       parcel.KhataNumber,
+		AreaHa:            parcel.AreaHa,
+		SharePct:          parcel.SharePct,
+		Tenure:            string(parcel.Tenure),
+		LeaseStartDate:    parcel.LeaseStartDate,
+		LeaseEndDate:      parcel.LeaseEndDate,
+		LessorName:        parcel.LessorName,
+		DocumentType:      parcel.DocumentType,
+		DocumentReference: parcel.DocumentReference,
+		CreatedAt:         parcel.CreatedAt,
+		UpdatedAt:         parcel.UpdatedAt,
+	}
+}
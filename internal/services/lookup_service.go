@@ -2,9 +2,13 @@ package services
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/Kisanlink/farmers-module/internal/entities/irrigation_source"
 	"github.com/Kisanlink/farmers-module/internal/entities/soil_type"
+	"github.com/Kisanlink/farmers-module/pkg/common"
+	"github.com/Kisanlink/farmers-module/pkg/units"
 	"gorm.io/gorm"
 )
 
@@ -13,6 +17,7 @@ type LookupService interface {
 	InitializeLookupData(ctx context.Context) error
 	GetSoilTypes(ctx context.Context) ([]soil_type.SoilType, error)
 	GetIrrigationSources(ctx context.Context) ([]irrigation_source.IrrigationSource, error)
+	GetUnits(ctx context.Context, dimension string) ([]*units.Unit, error)
 }
 
 // LookupServiceImpl implements LookupService
@@ -77,3 +82,12 @@ func (s *LookupServiceImpl) GetIrrigationSources(ctx context.Context) ([]irrigat
 	}
 	return irrigationSources, nil
 }
+
+// GetUnits lists the units of measure of a dimension, or of all dimensions when it is empty
+func (s *LookupServiceImpl) GetUnits(ctx context.Context, dimension string) ([]*units.Unit, error) {
+	d := units.Dimension(strings.ToUpper(strings.TrimSpace(dimension)))
+	if d != "" && !d.IsValid() {
+		return nil, fmt.Errorf("%w: unknown dimension %s", common.ErrInvalidInput, dimension)
+	}
+	return units.List(d), nil
+}
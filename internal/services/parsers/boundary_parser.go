@@ -0,0 +1,278 @@
+package parsers
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Boundary file formats accepted by ParseBoundaryFile
+const (
+	BoundaryFormatGeoJSON   = "geojson"
+	BoundaryFormatKML       = "kml"
+	BoundaryFormatShapefile = "shapefile"
+)
+
+// MaxBoundaryFeatures is the maximum number of features read from one boundary file
+const MaxBoundaryFeatures = 10000
+
+// Position is a longitude/latitude pair
+type Position [2]float64
+
+// Ring is a closed linear ring of positions
+type Ring []Position
+
+// Polygon is an outer ring followed by its holes
+type Polygon []Ring
+
+// BoundaryFeature is one boundary read from a GeoJSON, KML or Shapefile upload. Features
+// whose geometry cannot be used keep their properties and carry an Error instead, so the
+// import can report them alongside the others.
+type BoundaryFeature struct {
+	Index      int
+	Properties map[string]string
+	Polygons   []Polygon
+	Error      string
+}
+
+// ParseBoundaryFile reads the features of a boundary file. Coordinates must be WGS 84
+// longitude/latitude (EPSG:4326).
+func ParseBoundaryFile(format string, data []byte) ([]*BoundaryFeature, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("empty %s data", format)
+	}
+
+	var features []*BoundaryFeature
+	var err error
+
+	switch strings.ToLower(format) {
+	case BoundaryFormatGeoJSON, "json":
+		features, err = parseGeoJSONFeatures(data)
+	case BoundaryFormatKML, "kmz":
+		features, err = parseKMLFeatures(data)
+	case BoundaryFormatShapefile, "shp", "zip":
+		features, err = parseShapefileFeatures(data)
+	default:
+		return nil, fmt.Errorf("unsupported boundary format: %s", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(features) == 0 {
+		return nil, fmt.Errorf("no features found in %s data", format)
+	}
+	if len(features) > MaxBoundaryFeatures {
+		return nil, fmt.Errorf("exceeded maximum feature limit of %d", MaxBoundaryFeatures)
+	}
+
+	for i, feature := range features {
+		feature.Index = i
+		if feature.Error == "" {
+			feature.Error = validatePolygons(feature.Polygons)
+		}
+	}
+
+	return features, nil
+}
+
+// WKT returns the feature geometry as a POLYGON, or a MULTIPOLYGON when the feature has
+// several parts
+func (f *BoundaryFeature) WKT() string {
+	if len(f.Polygons) == 0 {
+		return ""
+	}
+	if len(f.Polygons) == 1 {
+		return "POLYGON" + polygonWKT(f.Polygons[0])
+	}
+
+	parts := make([]string, len(f.Polygons))
+	for i, polygon := range f.Polygons {
+		parts[i] = polygonWKT(polygon)
+	}
+	return "MULTIPOLYGON(" + strings.Join(parts, ",") + ")"
+}
+
+// Property returns the first non-empty property among keys, matched case-insensitively
+func (f *BoundaryFeature) Property(keys ...string) string {
+	for _, key := range keys {
+		for name, value := range f.Properties {
+			if strings.EqualFold(name, key) && strings.TrimSpace(value) != "" {
+				return strings.TrimSpace(value)
+			}
+		}
+	}
+	return ""
+}
+
+func polygonWKT(polygon Polygon) string {
+	rings := make([]string, len(polygon))
+	for i, ring := range polygon {
+		points := make([]string, len(ring))
+		for j, position := range ring {
+			points[j] = formatCoordinate(position[0]) + " " + formatCoordinate(position[1])
+		}
+		rings[i] = "(" + strings.Join(points, ", ") + ")"
+	}
+	return "(" + strings.Join(rings, ",") + ")"
+}
+
+func formatCoordinate(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// closeRing appends the first position when a ring is not closed
+func closeRing(ring Ring) Ring {
+	if len(ring) > 0 && ring[0] != ring[len(ring)-1] {
+		ring = append(ring, ring[0])
+	}
+	return ring
+}
+
+// validatePolygons checks the structure of a feature geometry. Topology (self-intersection,
+// area limits) is checked by the farm service when the feature is imported.
+func validatePolygons(polygons []Polygon) string {
+	if len(polygons) == 0 {
+		return "feature has no polygon geometry"
+	}
+	for _, polygon := range polygons {
+		if len(polygon) == 0 {
+			return "polygon has no rings"
+		}
+		for _, ring := range polygon {
+			if len(ring) < 4 {
+				return "polygon ring needs at least 4 positions"
+			}
+			for _, position := range ring {
+				lon, lat := position[0], position[1]
+				if math.IsNaN(lon) || math.IsNaN(lat) || lon < -180 || lon > 180 || lat < -90 || lat > 90 {
+					return fmt.Sprintf("coordinate (%s %s) is not a WGS 84 longitude/latitude",
+						formatCoordinate(lon), formatCoordinate(lat))
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// GeoJSON
+
+type geoJSONObject struct {
+	Type       string                 `json:"type"`
+	Features   []geoJSONObject        `json:"features"`
+	Geometry   *geoJSONObject         `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+	ID         interface{}            `json:"id"`
+
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+// parseGeoJSONFeatures reads a FeatureCollection, a single Feature or a bare geometry
+func parseGeoJSONFeatures(data []byte) ([]*BoundaryFeature, error) {
+	var root geoJSONObject
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse GeoJSON: %w", err)
+	}
+
+	switch root.Type {
+	case "FeatureCollection":
+		features := make([]*BoundaryFeature, 0, len(root.Features))
+		for _, object := range root.Features {
+			features = append(features, geoJSONFeature(object))
+		}
+		return features, nil
+	case "Feature":
+		return []*BoundaryFeature{geoJSONFeature(root)}, nil
+	case "":
+		return nil, fmt.Errorf("failed to parse GeoJSON: missing type")
+	default:
+		return []*BoundaryFeature{geoJSONFeature(geoJSONObject{Type: "Feature", Geometry: &root})}, nil
+	}
+}
+
+func geoJSONFeature(object geoJSONObject) *BoundaryFeature {
+	feature := &BoundaryFeature{Properties: make(map[string]string, len(object.Properties))}
+	for key, value := range object.Properties {
+		feature.Properties[key] = formatPropertyValue(value)
+	}
+	if object.ID != nil {
+		if _, exists := feature.Properties["id"]; !exists {
+			feature.Properties["id"] = formatPropertyValue(object.ID)
+		}
+	}
+
+	if object.Type != "Feature" {
+		feature.Error = fmt.Sprintf("expected a Feature, got %q", object.Type)
+		return feature
+	}
+	if object.Geometry == nil {
+		feature.Error = "feature has no geometry"
+		return feature
+	}
+
+	polygons, err := geoJSONPolygons(object.Geometry)
+	if err != nil {
+		feature.Error = err.Error()
+		return feature
+	}
+	feature.Polygons = polygons
+	return feature
+}
+
+func geoJSONPolygons(geometry *geoJSONObject) ([]Polygon, error) {
+	switch geometry.Type {
+	case "Polygon":
+		var coordinates [][][]float64
+		if err := json.Unmarshal(geometry.Coordinates, &coordinates); err != nil {
+			return nil, fmt.Errorf("invalid Polygon coordinates: %w", err)
+		}
+		polygon, err := polygonFromCoordinates(coordinates)
+		if err != nil {
+			return nil, err
+		}
+		return []Polygon{polygon}, nil
+	case "MultiPolygon":
+		var coordinates [][][][]float64
+		if err := json.Unmarshal(geometry.Coordinates, &coordinates); err != nil {
+			return nil, fmt.Errorf("invalid MultiPolygon coordinates: %w", err)
+		}
+		polygons := make([]Polygon, 0, len(coordinates))
+		for _, polygonCoordinates := range coordinates {
+			polygon, err := polygonFromCoordinates(polygonCoordinates)
+			if err != nil {
+				return nil, err
+			}
+			polygons = append(polygons, polygon)
+		}
+		return polygons, nil
+	default:
+		return nil, fmt.Errorf("unsupported geometry type %q, expected Polygon or MultiPolygon", geometry.Type)
+	}
+}
+
+func polygonFromCoordinates(coordinates [][][]float64) (Polygon, error) {
+	polygon := make(Polygon, 0, len(coordinates))
+	for _, ringCoordinates := range coordinates {
+		ring := make(Ring, 0, len(ringCoordinates))
+		for _, position := range ringCoordinates {
+			if len(position) < 2 {
+				return nil, fmt.Errorf("position needs longitude and latitude")
+			}
+			ring = append(ring, Position{position[0], position[1]})
+		}
+		polygon = append(polygon, closeRing(ring))
+	}
+	return polygon, nil
+}
+
+func formatPropertyValue(value interface{}) string {
+	switch v := value.(type) {
+	case map[string]interface{}, []interface{}:
+		encoded, _ := json.Marshal(v)
+		return string(encoded)
+	default:
+		return formatResultValue(v)
+	}
+}
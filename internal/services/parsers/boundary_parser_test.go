@@ -0,0 +1,284 @@
+package parsers
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBoundaryFile_GeoJSON(t *testing.T) {
+	data := `{
+		"type": "FeatureCollection",
+		"features": [
+			{
+				"type": "Feature",
+				"properties": {"Phone": "9876543210", "name": "North field", "survey_no": 42},
+				"geometry": {"type": "Polygon", "coordinates": [[[77.1, 28.6], [77.101, 28.6], [77.101, 28.601], [77.1, 28.601]]]}
+			},
+			{
+				"type": "Feature",
+				"properties": {"phone": "9876543211"},
+				"geometry": {"type": "MultiPolygon", "coordinates": [
+					[[[77.2, 28.6], [77.201, 28.6], [77.201, 28.601], [77.2, 28.6]]],
+					[[[77.3, 28.6], [77.301, 28.6], [77.301, 28.601], [77.3, 28.6]]]
+				]}
+			},
+			{
+				"type": "Feature",
+				"properties": {"phone": "9876543212"},
+				"geometry": {"type": "Point", "coordinates": [77.1, 28.6]}
+			},
+			{
+				"type": "Feature",
+				"properties": {"phone": "9876543213"},
+				"geometry": {"type": "Polygon", "coordinates": [[[8650000, 3300000], [8650100, 3300000], [8650100, 3300100], [8650000, 3300000]]]}
+			}
+		]
+	}`
+
+	features, err := ParseBoundaryFile("geojson", []byte(data))
+	require.NoError(t, err)
+	require.Len(t, features, 4)
+
+	assert.Empty(t, features[0].Error)
+	assert.Equal(t, "9876543210", features[0].Property("phone_number", "phone"))
+	assert.Equal(t, "42", features[0].Properties["survey_no"])
+	assert.Equal(t, "POLYGON((77.1 28.6, 77.101 28.6, 77.101 28.601, 77.1 28.601, 77.1 28.6))", features[0].WKT(),
+		"open rings are closed")
+
+	assert.Empty(t, features[1].Error)
+	assert.Equal(t, 1, features[1].Index)
+	assert.Contains(t, features[1].WKT(), "MULTIPOLYGON(((77.2 28.6")
+
+	assert.Contains(t, features[2].Error, `unsupported geometry type "Point"`)
+	assert.Equal(t, "9876543212", features[2].Property("phone"), "invalid features keep their properties")
+
+	assert.Contains(t, features[3].Error, "not a WGS 84 longitude/latitude")
+}
+
+func TestParseBoundaryFile_GeoJSONBareGeometry(t *testing.T) {
+	data := `{"type": "Polygon", "coordinates": [[[77.1, 28.6], [77.101, 28.6], [77.1, 28.6]]]}`
+
+	features, err := ParseBoundaryFile("geojson", []byte(data))
+	require.NoError(t, err)
+	require.Len(t, features, 1)
+	assert.Contains(t, features[0].Error, "at least 4 positions")
+}
+
+func TestParseBoundaryFile_KML(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8"?>
+<kml xmlns="http://www.opengis.net/kml/2.2">
+  <Document>
+    <Folder>
+      <Placemark>
+        <name>Plot 7</name>
+        <ExtendedData>
+          <Data name="phone"><value>9876543210</value></Data>
+          <SchemaData schemaUrl="#farms"><SimpleData name="khasra">118/2</SimpleData></SchemaData>
+        </ExtendedData>
+        <Polygon>
+          <outerBoundaryIs><LinearRing><coordinates>
+            77.1,28.6,0 77.102,28.6,0 77.102,28.602,0 77.1,28.602,0 77.1,28.6,0
+          </coordinates></LinearRing></outerBoundaryIs>
+          <innerBoundaryIs><LinearRing><coordinates>
+            77.1005,28.6005 77.1010,28.6005 77.1010,28.6010 77.1005,28.6010
+          </coordinates></LinearRing></innerBoundaryIs>
+        </Polygon>
+      </Placemark>
+    </Folder>
+    <Placemark>
+      <name>Well</name>
+      <Point><coordinates>77.1,28.6</coordinates></Point>
+    </Placemark>
+  </Document>
+</kml>`
+
+	features, err := ParseBoundaryFile("kml", []byte(data))
+	require.NoError(t, err)
+	require.Len(t, features, 2)
+
+	assert.Empty(t, features[0].Error)
+	assert.Equal(t, "Plot 7", features[0].Properties["name"])
+	assert.Equal(t, "9876543210", features[0].Properties["phone"])
+	assert.Equal(t, "118/2", features[0].Properties["khasra"])
+	require.Len(t, features[0].Polygons, 1)
+	require.Len(t, features[0].Polygons[0], 2, "inner boundary is kept as a hole")
+	assert.Len(t, features[0].Polygons[0][1], 5)
+
+	assert.Contains(t, features[1].Error, `unsupported geometry type "Point"`)
+}
+
+func TestParseBoundaryFile_KMZ(t *testing.T) {
+	kml := `<kml><Placemark><name>Plot</name><MultiGeometry>
+		<Polygon><outerBoundaryIs><LinearRing><coordinates>77.1,28.6 77.101,28.6 77.101,28.601 77.1,28.6</coordinates></LinearRing></outerBoundaryIs></Polygon>
+		<Polygon><outerBoundaryIs><LinearRing><coordinates>77.2,28.6 77.201,28.6 77.201,28.601 77.2,28.6</coordinates></LinearRing></outerBoundaryIs></Polygon>
+	</MultiGeometry></Placemark></kml>`
+
+	features, err := ParseBoundaryFile("kml", zipArchive(t, map[string][]byte{"doc.kml": []byte(kml)}))
+	require.NoError(t, err)
+	require.Len(t, features, 1)
+	assert.Empty(t, features[0].Error)
+	assert.Len(t, features[0].Polygons, 2)
+}
+
+func TestParseBoundaryFile_Shapefile(t *testing.T) {
+	outer := Ring{{77.1, 28.6}, {77.1, 28.602}, {77.102, 28.602}, {77.102, 28.6}, {77.1, 28.6}} // clockwise
+	hole := Ring{{77.1005, 28.6005}, {77.101, 28.6005}, {77.101, 28.601}, {77.1005, 28.6005}}   // counter-clockwise
+	second := Ring{{77.2, 28.6}, {77.2, 28.601}, {77.201, 28.601}, {77.2, 28.6}}
+
+	shp := testShp([][]Ring{{outer, hole}, {second}, nil})
+	dbf := testDbf([]string{"PHONE", "NAME"}, [][]string{
+		{"9876543210", "North field"},
+		{"9876543211", "South field"},
+		{"9876543212", "Empty"},
+	}, 1)
+
+	archive := zipArchive(t, map[string][]byte{
+		"farms/farms.shp": shp,
+		"farms/farms.dbf": dbf,
+		"farms/farms.prj": []byte(`GEOGCS["GCS_WGS_1984",DATUM["D_WGS_1984",SPHEROID["WGS_1984",6378137,298.257223563]]]`),
+	})
+
+	features, err := ParseBoundaryFile("shapefile", archive)
+	require.NoError(t, err)
+	require.Len(t, features, 2, "deleted records are skipped")
+
+	assert.Empty(t, features[0].Error)
+	assert.Equal(t, "9876543210", features[0].Properties["PHONE"])
+	assert.Equal(t, "North field", features[0].Property("name"))
+	require.Len(t, features[0].Polygons, 1)
+	assert.Len(t, features[0].Polygons[0], 2, "counter-clockwise ring is a hole of the previous outer ring")
+
+	assert.Equal(t, "9876543212", features[1].Properties["PHONE"])
+	assert.Equal(t, "feature has no geometry", features[1].Error)
+}
+
+func TestParseBoundaryFile_ShapefileProjected(t *testing.T) {
+	archive := zipArchive(t, map[string][]byte{
+		"farms.shp": testShp([][]Ring{nil}),
+		"farms.dbf": testDbf([]string{"PHONE"}, [][]string{{"9876543210"}}, -1),
+		"farms.prj": []byte(`PROJCS["WGS_1984_UTM_Zone_43N",GEOGCS["GCS_WGS_1984"]]`),
+	})
+
+	_, err := ParseBoundaryFile("shapefile", archive)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "projection must be WGS 84")
+}
+
+func TestParseBoundaryFile_ShapefileMissingDbf(t *testing.T) {
+	archive := zipArchive(t, map[string][]byte{"farms.shp": testShp([][]Ring{nil})})
+
+	_, err := ParseBoundaryFile("shapefile", archive)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing farms.dbf")
+}
+
+func TestParseBoundaryFile_Errors(t *testing.T) {
+	_, err := ParseBoundaryFile("gpx", []byte("<gpx/>"))
+	assert.ErrorContains(t, err, "unsupported boundary format")
+
+	_, err = ParseBoundaryFile("geojson", []byte(`{"type": "FeatureCollection", "features": []}`))
+	assert.ErrorContains(t, err, "no features found")
+
+	_, err = ParseBoundaryFile("geojson", []byte(`not json`))
+	assert.ErrorContains(t, err, "failed to parse GeoJSON")
+}
+
+func zipArchive(t *testing.T, files map[string][]byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := writer.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	return buf.Bytes()
+}
+
+// testShp builds a .shp file with one polygon record per entry; a nil entry is a null shape
+func testShp(shapes [][]Ring) []byte {
+	var records bytes.Buffer
+	for i, rings := range shapes {
+		var content bytes.Buffer
+		if rings == nil {
+			_ = binary.Write(&content, binary.LittleEndian, int32(shapeNull))
+		} else {
+			_ = binary.Write(&content, binary.LittleEndian, int32(shapePolygon))
+			content.Write(make([]byte, 32)) // bounding box
+			numPoints := 0
+			for _, ring := range rings {
+				numPoints += len(ring)
+			}
+			_ = binary.Write(&content, binary.LittleEndian, int32(len(rings)))
+			_ = binary.Write(&content, binary.LittleEndian, int32(numPoints))
+			start := 0
+			for _, ring := range rings {
+				_ = binary.Write(&content, binary.LittleEndian, int32(start))
+				start += len(ring)
+			}
+			for _, ring := range rings {
+				for _, position := range ring {
+					_ = binary.Write(&content, binary.LittleEndian, math.Float64bits(position[0]))
+					_ = binary.Write(&content, binary.LittleEndian, math.Float64bits(position[1]))
+				}
+			}
+		}
+		_ = binary.Write(&records, binary.BigEndian, int32(i+1))
+		_ = binary.Write(&records, binary.BigEndian, int32(content.Len()/2))
+		records.Write(content.Bytes())
+	}
+
+	header := make([]byte, shpHeaderLength)
+	binary.BigEndian.PutUint32(header[0:4], 9994)
+	binary.BigEndian.PutUint32(header[24:28], uint32((shpHeaderLength+records.Len())/2))
+	binary.LittleEndian.PutUint32(header[28:32], 1000)
+	binary.LittleEndian.PutUint32(header[32:36], shapePolygon)
+	return append(header, records.Bytes()...)
+}
+
+// testDbf builds a .dbf file of 20 character fields; the record at index deleted is
+// marked deleted
+func testDbf(fields []string, rows [][]string, deleted int) []byte {
+	const width = 20
+	headerLength := dbfFieldLength + len(fields)*dbfFieldLength + 1
+	recordLength := 1 + len(fields)*width
+
+	var buf bytes.Buffer
+	header := make([]byte, dbfFieldLength)
+	header[0] = 0x03
+	binary.LittleEndian.PutUint32(header[4:8], uint32(len(rows)))
+	binary.LittleEndian.PutUint16(header[8:10], uint16(headerLength))
+	binary.LittleEndian.PutUint16(header[10:12], uint16(recordLength))
+	buf.Write(header)
+
+	for _, name := range fields {
+		descriptor := make([]byte, dbfFieldLength)
+		copy(descriptor, name)
+		descriptor[11] = 'C'
+		descriptor[16] = width
+		buf.Write(descriptor)
+	}
+	buf.WriteByte(dbfTerminator)
+
+	for i, row := range rows {
+		if i == deleted {
+			buf.WriteByte(dbfDeleted)
+		} else {
+			buf.WriteByte(' ')
+		}
+		for _, value := range row {
+			cell := bytes.Repeat([]byte(" "), width)
+			copy(cell, value)
+			buf.Write(cell)
+		}
+	}
+	return buf.Bytes()
+}
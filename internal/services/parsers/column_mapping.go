@@ -0,0 +1,375 @@
+package parsers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/bulk"
+	"github.com/Kisanlink/farmers-module/internal/entities/requests"
+	"github.com/xuri/excelize/v2"
+)
+
+// MappingPreview shows how a column mapping profile reads an uploaded file
+type MappingPreview struct {
+	SourceHeaders   []string
+	UnmappedHeaders []string
+	MissingSources  []string
+	MissingRequired []string
+	TotalRows       int
+	Records         []MappedRecord
+}
+
+// MappedRecord is a source row together with the farmer record mapped from it
+type MappedRecord struct {
+	RowNumber int
+	Source    map[string]string
+	Farmer    *requests.FarmerBulkData
+	Error     string
+}
+
+// ParseWithMapping parses a CSV, Excel or JSON upload whose columns are described by a
+// column mapping profile instead of the standard template headers
+func (p *FileParserImpl) ParseWithMapping(format string, data []byte, profile *bulk.ColumnMappingProfile) ([]*requests.FarmerBulkData, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("empty %s data", strings.ToUpper(format))
+	}
+
+	return collectFarmers(func(fn FarmerRecordFunc) (int, error) {
+		return p.StreamWithMapping(format, bytes.NewReader(data), profile, fn, nil)
+	})
+}
+
+// PreviewMapping maps up to limit rows of an upload with a column mapping profile and
+// reports each mapped record, along with source columns the profile does not account for
+func (p *FileParserImpl) PreviewMapping(format string, data []byte, profile *bulk.ColumnMappingProfile, limit int) (*MappingPreview, error) {
+	headers, rows, err := p.readTable(format, data)
+	if err != nil {
+		return nil, err
+	}
+
+	mapper := newColumnMapper(profile, headers, p.normalizeHeaders)
+
+	preview := &MappingPreview{
+		SourceHeaders:   headers,
+		UnmappedHeaders: mapper.unmapped,
+		MissingSources:  mapper.missing,
+	}
+	if err := p.validateHeaders(mapper.targets); err != nil {
+		covered := make(map[string]bool, len(mapper.targets))
+		for _, target := range mapper.targets {
+			covered[target] = true
+		}
+		for _, required := range p.config.RequiredFields {
+			if !covered[required] {
+				preview.MissingRequired = append(preview.MissingRequired, required)
+			}
+		}
+	}
+
+	// Map without dropping invalid rows so the preview can show why they would fail
+	lenientConfig := *p.config
+	lenientConfig.SkipRecordValidation = true
+	lenient := &FileParserImpl{config: &lenientConfig}
+
+	for i, row := range rows {
+		if isBlankRow(row) {
+			continue
+		}
+		preview.TotalRows++
+		if len(preview.Records) >= limit {
+			continue
+		}
+
+		source := make(map[string]string, len(headers))
+		for j, header := range headers {
+			if j < len(row) {
+				source[header] = row[j]
+			}
+		}
+
+		record := MappedRecord{RowNumber: i + 2, Source: source}
+		farmer, _ := lenient.parseCSVRecord(mapper.targets, mapper.apply(row), i+1)
+		record.Farmer = farmer
+		if err := p.validateFarmerData(farmer, i+1); err != nil {
+			record.Error = err.Error()
+		}
+
+		preview.Records = append(preview.Records, record)
+	}
+
+	return preview, nil
+}
+
+// readTable reads an upload into raw headers and string rows without interpreting columns
+func (p *FileParserImpl) readTable(format string, data []byte) ([]string, [][]string, error) {
+	if len(data) == 0 {
+		return nil, nil, fmt.Errorf("empty %s data", strings.ToUpper(format))
+	}
+
+	var records [][]string
+	switch strings.ToLower(format) {
+	case "csv":
+		delimiter, err := p.detectDelimiter(data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to detect CSV delimiter: %w", err)
+		}
+		reader := csv.NewReader(strings.NewReader(string(data)))
+		reader.Comma = delimiter
+		reader.TrimLeadingSpace = true
+		reader.FieldsPerRecord = -1
+		if records, err = reader.ReadAll(); err != nil {
+			return nil, nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+	case "excel", "xlsx":
+		file, err := excelize.OpenReader(strings.NewReader(string(data)))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open Excel file: %w", err)
+		}
+		defer func() { _ = file.Close() }()
+
+		sheets := file.GetSheetList()
+		if len(sheets) == 0 {
+			return nil, nil, fmt.Errorf("no sheets found in Excel file")
+		}
+		if records, err = file.GetRows(sheets[0]); err != nil {
+			return nil, nil, fmt.Errorf("failed to read Excel rows: %w", err)
+		}
+	case "json":
+		return readJSONTable(data)
+	default:
+		return nil, nil, fmt.Errorf("unsupported format: %s", format)
+	}
+
+	if len(records) == 0 || len(records[0]) == 0 {
+		return nil, nil, fmt.Errorf("no headers found in %s", strings.ToUpper(format))
+	}
+
+	return records[0], records[1:], nil
+}
+
+// readJSONTable flattens a JSON array of objects into a table. Nested objects become
+// "parent.child" columns; the header order is alphabetical.
+func readJSONTable(data []byte) ([]string, [][]string, error) {
+	var objects []map[string]interface{}
+	if err := json.Unmarshal(data, &objects); err != nil {
+		var single map[string]interface{}
+		if err2 := json.Unmarshal(data, &single); err2 != nil {
+			return nil, nil, fmt.Errorf("failed to parse JSON: %w", err)
+		}
+		objects = []map[string]interface{}{single}
+	}
+
+	flat := make([]map[string]string, len(objects))
+	headerSet := make(map[string]bool)
+	for i, object := range objects {
+		flat[i] = make(map[string]string)
+		flattenJSON("", object, flat[i])
+		for key := range flat[i] {
+			headerSet[key] = true
+		}
+	}
+
+	headers := make([]string, 0, len(headerSet))
+	for header := range headerSet {
+		headers = append(headers, header)
+	}
+	sort.Strings(headers)
+
+	rows := make([][]string, len(flat))
+	for i, values := range flat {
+		rows[i] = make([]string, len(headers))
+		for j, header := range headers {
+			rows[i][j] = values[header]
+		}
+	}
+
+	return headers, rows, nil
+}
+
+func flattenJSON(prefix string, object map[string]interface{}, out map[string]string) {
+	for key, value := range object {
+		if nested, ok := value.(map[string]interface{}); ok {
+			flattenJSON(prefix+key+".", nested, out)
+			continue
+		}
+		out[prefix+key] = formatResultValue(value)
+	}
+}
+
+// columnMapper applies the mappings of a profile to the rows of one file. targets holds the
+// template column each output value belongs to, so mapped rows can reuse parseCSVRecord.
+type columnMapper struct {
+	mappings    []bulk.ColumnMapping
+	columnIndex map[string]int
+	targets     []string
+	targetOf    []int // mapping index -> position in targets
+	passThrough []int // source column positions copied after the mapped targets
+	unmapped    []string
+	missing     []string
+}
+
+func newColumnMapper(profile *bulk.ColumnMappingProfile, headers []string, normalize func([]string) []string) *columnMapper {
+	m := &columnMapper{
+		mappings:    profile.Mappings,
+		columnIndex: make(map[string]int, len(headers)),
+	}
+	for i, header := range headers {
+		key := headerKey(header)
+		if _, exists := m.columnIndex[key]; !exists {
+			m.columnIndex[key] = i
+		}
+	}
+
+	// One output column per distinct target, in order of first appearance
+	targetPos := make(map[string]int)
+	used := make(map[int]bool)
+	for _, mapping := range profile.Mappings {
+		target := strings.TrimPrefix(mapping.Target, bulk.CustomFieldPrefix)
+		pos, exists := targetPos[target]
+		if !exists {
+			pos = len(m.targets)
+			targetPos[target] = pos
+			m.targets = append(m.targets, target)
+		}
+		m.targetOf = append(m.targetOf, pos)
+
+		for _, source := range mappingSources(mapping) {
+			if index, ok := m.columnIndex[headerKey(source)]; ok {
+				used[index] = true
+			} else {
+				m.missing = appendUnique(m.missing, source)
+			}
+		}
+	}
+
+	normalized := normalize(headers)
+	for i, header := range headers {
+		if used[i] || strings.TrimSpace(header) == "" {
+			continue
+		}
+		m.unmapped = append(m.unmapped, header)
+		if _, mapped := targetPos[normalized[i]]; profile.KeepUnmapped && !mapped {
+			m.passThrough = append(m.passThrough, i)
+			m.targets = append(m.targets, normalized[i])
+		}
+	}
+
+	return m
+}
+
+// apply returns the values of one row in the order of targets
+func (m *columnMapper) apply(row []string) []string {
+	values := make([]string, len(m.targets))
+	for i, mapping := range m.mappings {
+		pos := m.targetOf[i]
+		if values[pos] != "" {
+			continue // an earlier mapping to the same target already produced a value
+		}
+		values[pos] = m.value(mapping, row)
+	}
+
+	mappedCount := len(m.targets) - len(m.passThrough)
+	for i, index := range m.passThrough {
+		values[mappedCount+i] = m.cell(row, index)
+	}
+
+	return values
+}
+
+// value derives the value of one mapping for a row
+func (m *columnMapper) value(mapping bulk.ColumnMapping, row []string) string {
+	var value string
+
+	switch mapping.EffectiveTransform() {
+	case bulk.TransformCopy:
+		value = m.column(row, mapping.Source)
+	case bulk.TransformSplitName:
+		parts := strings.Fields(m.column(row, mapping.Source))
+		if len(parts) > 0 {
+			if mapping.Part == bulk.NamePartFirst {
+				value = parts[0]
+			} else {
+				value = strings.Join(parts[1:], " ")
+			}
+		}
+	case bulk.TransformConcat:
+		separator := mapping.Separator
+		if separator == "" {
+			separator = " "
+		}
+		var parts []string
+		for _, source := range mapping.Sources {
+			if part := m.column(row, source); part != "" {
+				parts = append(parts, part)
+			}
+		}
+		value = strings.Join(parts, separator)
+	case bulk.TransformLookup:
+		value = m.column(row, mapping.Source)
+		for from, to := range mapping.Lookup {
+			if strings.EqualFold(strings.TrimSpace(from), value) {
+				value = to
+				break
+			}
+		}
+	}
+
+	if value == "" {
+		value = mapping.Default
+	}
+	return value
+}
+
+func (m *columnMapper) column(row []string, source string) string {
+	index, ok := m.columnIndex[headerKey(source)]
+	if !ok {
+		return ""
+	}
+	return m.cell(row, index)
+}
+
+func (m *columnMapper) cell(row []string, index int) string {
+	if index >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[index])
+}
+
+func mappingSources(mapping bulk.ColumnMapping) []string {
+	switch mapping.EffectiveTransform() {
+	case bulk.TransformConcat:
+		return mapping.Sources
+	case bulk.TransformDefault:
+		return nil
+	default:
+		return []string{mapping.Source}
+	}
+}
+
+// headerKey matches source headers case-insensitively and ignoring repeated whitespace,
+// so "Mobile  No" and "mobile no" refer to the same column
+func headerKey(header string) string {
+	return strings.ToLower(strings.Join(strings.Fields(header), " "))
+}
+
+func isBlankRow(row []string) bool {
+	for _, value := range row {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, existing := range values {
+		if existing == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
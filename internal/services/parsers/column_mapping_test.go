@@ -0,0 +1,130 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/bulk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testMappingProfile() *bulk.ColumnMappingProfile {
+	profile := bulk.NewColumnMappingProfile()
+	profile.FPOOrgID = "fpo_123"
+	profile.Name = "Register"
+	profile.Mappings = []bulk.ColumnMapping{
+		{Target: "first_name", Source: "Farmer Name", Transform: bulk.TransformSplitName, Part: bulk.NamePartFirst},
+		{Target: "last_name", Source: "Farmer Name", Transform: bulk.TransformSplitName, Part: bulk.NamePartLast},
+		{Target: "phone_number", Source: "Mobile No"},
+		{Target: "gender", Source: "Sex", Transform: bulk.TransformLookup, Lookup: map[string]string{"M": "male", "F": "female"}},
+		{Target: "street_address", Sources: []string{"Village", "Tehsil"}, Transform: bulk.TransformConcat, Separator: ", "},
+		{Target: "country", Transform: bulk.TransformDefault, Default: "India"},
+		{Target: "custom_fields.member_no", Source: "Member No"},
+	}
+	return profile
+}
+
+func TestFileParser_ParseWithMapping(t *testing.T) {
+	parser := NewFileParser()
+
+	csvData := `Member No,Farmer Name,Mobile  No,Sex,Village,Tehsil,Crop
+M-1,Ramesh Kumar Yadav,9876543210,m,Khandwa,Harsud,Wheat
+M-2,Sita Devi,9876543211,F,Ratlam,,Soybean`
+
+	farmers, err := parser.ParseWithMapping("csv", []byte(csvData), testMappingProfile())
+	require.NoError(t, err)
+	require.Len(t, farmers, 2)
+
+	assert.Equal(t, "Ramesh", farmers[0].FirstName)
+	assert.Equal(t, "Kumar Yadav", farmers[0].LastName)
+	assert.Equal(t, "9876543210", farmers[0].PhoneNumber)
+	assert.Equal(t, "male", farmers[0].Gender)
+	assert.Equal(t, "Khandwa, Harsud", farmers[0].StreetAddress)
+	assert.Equal(t, "India", farmers[0].Country)
+	assert.Equal(t, "M-1", farmers[0].CustomFields["member_no"])
+	assert.NotContains(t, farmers[0].CustomFields, "crop", "unmapped columns are dropped by default")
+
+	assert.Equal(t, "female", farmers[1].Gender)
+	assert.Equal(t, "Ratlam", farmers[1].StreetAddress)
+}
+
+func TestFileParser_ParseWithMapping_KeepUnmapped(t *testing.T) {
+	parser := NewFileParser()
+	profile := testMappingProfile()
+	profile.KeepUnmapped = true
+
+	jsonData := `[{"Farmer Name":"Ramesh Kumar","Mobile No":"9876543210","Email":"ramesh@example.com","land":{"acres":"2.5"}}]`
+
+	farmers, err := parser.ParseWithMapping("json", []byte(jsonData), profile)
+	require.NoError(t, err)
+	require.Len(t, farmers, 1)
+
+	assert.Equal(t, "ramesh@example.com", farmers[0].Email, "unmapped template columns are read as farmer fields")
+	assert.Equal(t, "2.5", farmers[0].CustomFields["land_acres"])
+}
+
+func TestFileParser_ParseWithMapping_MissingRequiredTarget(t *testing.T) {
+	parser := NewFileParser()
+	profile := testMappingProfile()
+	profile.Mappings = profile.Mappings[:2] // no phone_number mapping
+
+	_, err := parser.ParseWithMapping("csv", []byte("Farmer Name\nRamesh Kumar"), profile)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid column mapping")
+}
+
+func TestFileParser_PreviewMapping(t *testing.T) {
+	parser := NewFileParser()
+
+	csvData := `Farmer Name,Mobile No,Sex,Village,Remarks
+Ramesh Kumar,9876543210,M,Khandwa,
+Sita,12345,F,Ratlam,
+Mohan Lal,9876543212,M,Harsud,`
+
+	preview, err := parser.PreviewMapping("csv", []byte(csvData), testMappingProfile(), 2)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, preview.TotalRows)
+	require.Len(t, preview.Records, 2)
+	assert.ElementsMatch(t, []string{"Tehsil", "Member No"}, preview.MissingSources)
+	assert.Equal(t, []string{"Remarks"}, preview.UnmappedHeaders)
+	assert.Empty(t, preview.MissingRequired)
+
+	assert.Equal(t, 2, preview.Records[0].RowNumber)
+	assert.Empty(t, preview.Records[0].Error)
+	assert.Equal(t, "Ramesh", preview.Records[0].Farmer.FirstName)
+	assert.Equal(t, "Khandwa", preview.Records[0].Source["Village"])
+
+	// Invalid rows are still mapped so the preview can show why they would fail
+	assert.NotEmpty(t, preview.Records[1].Error)
+	require.NotNil(t, preview.Records[1].Farmer)
+	assert.Equal(t, "Sita", preview.Records[1].Farmer.FirstName)
+}
+
+func TestColumnMappingProfile_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mapping bulk.ColumnMapping
+		errMsg  string
+	}{
+		{"unknown target", bulk.ColumnMapping{Target: "aadhaar", Source: "Aadhaar"}, "unknown target field"},
+		{"copy without source", bulk.ColumnMapping{Target: "email"}, "source is required"},
+		{"split without part", bulk.ColumnMapping{Target: "first_name", Source: "Name", Transform: bulk.TransformSplitName}, "part must be"},
+		{"concat with one source", bulk.ColumnMapping{Target: "city", Sources: []string{"Village"}, Transform: bulk.TransformConcat}, "at least two sources"},
+		{"lookup without table", bulk.ColumnMapping{Target: "gender", Source: "Sex", Transform: bulk.TransformLookup}, "lookup table is required"},
+		{"unknown transform", bulk.ColumnMapping{Target: "email", Source: "Email", Transform: "upper"}, "unknown transform"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := testMappingProfile()
+			profile.Mappings = append(profile.Mappings, tt.mapping)
+
+			err := profile.Validate()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+
+	assert.NoError(t, testMappingProfile().Validate())
+}
@@ -0,0 +1,154 @@
+package parsers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/requests"
+)
+
+// MaxCropCycleRows is the maximum number of rows read from one crop cycle import
+const MaxCropCycleRows = 10000
+
+// cropCycleColumns maps the accepted headers of a crop cycle import to their field.
+// Headers are normalized the same way as farmer uploads ("Sowing Date" is "sowing_date").
+var cropCycleColumns = map[string]string{
+	"farm_id":                 "farm_id",
+	"crop":                    "crop",
+	"crop_name":               "crop",
+	"variety":                 "variety",
+	"variety_name":            "variety",
+	"season":                  "season",
+	"area_ha":                 "area_ha",
+	"area":                    "area_ha",
+	"area_hectares":           "area_ha",
+	"sowing_date":             "sowing_date",
+	"start_date":              "sowing_date",
+	"activity_type":           "activity_type",
+	"activity":                "activity_type",
+	"activity_date":           "activity_date",
+	"planned_date":            "activity_date",
+	"completed_date":          "completed_date",
+	"activity_completed_date": "completed_date",
+	"notes":                   "notes",
+	"external_id":             "external_id",
+}
+
+// cropCycleRequiredColumns must be present in every crop cycle import. The season may
+// come from the import options instead of a column.
+var cropCycleRequiredColumns = []string{"farm_id", "crop", "sowing_date"}
+
+// CropCycleTemplateHeaders are the columns of a crop cycle import, in template order
+var CropCycleTemplateHeaders = []string{
+	"farm_id",
+	"crop",
+	"variety",
+	"season",
+	"area_ha",
+	"sowing_date",
+	"activity_type",
+	"activity_date",
+	"completed_date",
+	"notes",
+	"external_id",
+}
+
+// ParseCropCycleFile reads the rows of a CSV or Excel crop cycle import. Rows are returned
+// as entered; blank rows are skipped and unknown columns are kept as custom fields.
+func ParseCropCycleFile(format string, data []byte) ([]*requests.CropCycleBulkData, error) {
+	parser := &FileParserImpl{config: DefaultParserConfig()}
+
+	switch strings.ToLower(format) {
+	case "csv", "excel", "xlsx":
+	default:
+		return nil, fmt.Errorf("unsupported crop cycle import format: %s", format)
+	}
+
+	headers, rows, err := parser.readTable(format, data)
+	if err != nil {
+		return nil, err
+	}
+	headers = parser.normalizeHeaders(headers)
+
+	present := make(map[string]bool)
+	for _, header := range headers {
+		if field, ok := cropCycleColumns[header]; ok {
+			present[field] = true
+		}
+	}
+	var missing []string
+	for _, required := range cropCycleRequiredColumns {
+		if !present[required] {
+			missing = append(missing, required)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing required columns: %v", missing)
+	}
+
+	var records []*requests.CropCycleBulkData
+	for _, row := range rows {
+		if isBlankRow(row) {
+			continue
+		}
+		if len(records) >= MaxCropCycleRows {
+			return nil, fmt.Errorf("exceeded maximum record limit of %d", MaxCropCycleRows)
+		}
+		records = append(records, cropCycleRecord(headers, row))
+	}
+
+	if len(records) == 0 {
+		return nil, fmt.Errorf("no crop cycle records found")
+	}
+
+	return records, nil
+}
+
+func cropCycleRecord(headers []string, row []string) *requests.CropCycleBulkData {
+	record := &requests.CropCycleBulkData{}
+	fields := map[string]*string{
+		"farm_id":        &record.FarmID,
+		"crop":           &record.Crop,
+		"variety":        &record.Variety,
+		"season":         &record.Season,
+		"area_ha":        &record.AreaHa,
+		"sowing_date":    &record.SowingDate,
+		"activity_type":  &record.ActivityType,
+		"activity_date":  &record.ActivityDate,
+		"completed_date": &record.CompletedDate,
+		"notes":          &record.Notes,
+		"external_id":    &record.ExternalID,
+	}
+
+	for i, header := range headers {
+		if i >= len(row) {
+			break
+		}
+		value := strings.TrimSpace(row[i])
+		if value == "" || header == "" || isCropCycleResultColumn(header) {
+			continue
+		}
+		if field, ok := cropCycleColumns[header]; ok {
+			// The first of several aliased columns wins
+			if *fields[field] == "" {
+				*fields[field] = value
+			}
+			continue
+		}
+		if record.CustomFields == nil {
+			record.CustomFields = make(map[string]interface{})
+		}
+		record.CustomFields[header] = value
+	}
+
+	return record
+}
+
+func isCropCycleResultColumn(header string) bool {
+	for _, column := range CropCycleResultColumns {
+		if header == column {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCropCycleFile_CSV(t *testing.T) {
+	csvData := "Farm ID,Crop Name,Variety,Season,Area,Sowing Date,Activity,Village\n" +
+		"FARM00000001,Paddy,IR-64,KHARIF,1.5,2024-06-20,Sowing,Khandwa\n" +
+		",,,,,,,\n" +
+		"FARM00000002,Wheat,,rabi,abc,15/11/2024,,\n"
+
+	rows, err := ParseCropCycleFile("csv", []byte(csvData))
+	require.NoError(t, err)
+	require.Len(t, rows, 2, "blank rows are skipped")
+
+	assert.Equal(t, "FARM00000001", rows[0].FarmID)
+	assert.Equal(t, "Paddy", rows[0].Crop)
+	assert.Equal(t, "IR-64", rows[0].Variety)
+	assert.Equal(t, "1.5", rows[0].AreaHa)
+	assert.Equal(t, "2024-06-20", rows[0].SowingDate)
+	assert.Equal(t, "Sowing", rows[0].ActivityType)
+	assert.Equal(t, "Khandwa", rows[0].CustomFields["village"])
+
+	// Values are kept as entered; the pipeline reports on them
+	assert.Equal(t, "abc", rows[1].AreaHa)
+	assert.Equal(t, "rabi", rows[1].Season)
+	assert.Nil(t, rows[1].CustomFields)
+}
+
+func TestParseCropCycleFile_ResultFileRoundTrip(t *testing.T) {
+	content, err := WriteCropCycleResultCSV([]ResultRow{{
+		RecordIndex: 0,
+		Input: map[string]interface{}{
+			"farm_id":       "FARM00000001",
+			"crop":          "Paddy",
+			"sowing_date":   "2024-06-20",
+			"custom_fields": map[string]interface{}{"village": "Khandwa"},
+		},
+		Status:    "failed",
+		Error:     "crop \"Paddy\" not found in crop master data",
+		ErrorCode: "CROP_NOT_FOUND",
+	}})
+	require.NoError(t, err)
+
+	rows, err := ParseCropCycleFile("csv", content)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, "FARM00000001", rows[0].FarmID)
+	assert.Equal(t, map[string]interface{}{"village": "Khandwa"}, rows[0].CustomFields, "result columns are not read back")
+}
+
+func TestParseCropCycleFile_Errors(t *testing.T) {
+	_, err := ParseCropCycleFile("csv", []byte("farm_id,crop\nFARM00000001,Paddy\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "sowing_date")
+
+	_, err = ParseCropCycleFile("json", []byte(`[]`))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported")
+}
@@ -1,12 +1,14 @@
 package parsers
 
 import (
+	"bytes"
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
 
+	"github.com/Kisanlink/farmers-module/internal/entities/bulk"
 	"github.com/Kisanlink/farmers-module/internal/entities/requests"
 	"github.com/xuri/excelize/v2"
 )
@@ -16,10 +18,29 @@ type FileParser interface {
 	ParseCSV(data []byte) ([]*requests.FarmerBulkData, error)
 	ParseExcel(data []byte) ([]*requests.FarmerBulkData, error)
 	ParseJSON(data []byte) ([]*requests.FarmerBulkData, error)
+	ParseWithMapping(format string, data []byte, profile *bulk.ColumnMappingProfile) ([]*requests.FarmerBulkData, error)
+	PreviewMapping(format string, data []byte, profile *bulk.ColumnMappingProfile, limit int) (*MappingPreview, error)
 	GenerateCSVTemplate(includeExample bool) ([]byte, error)
 	GenerateExcelTemplate(includeExample bool) ([]byte, error)
 }
 
+// templateHeaders are the columns of the upload template, in order
+var templateHeaders = []string{
+	"first_name",
+	"last_name",
+	"country_code",
+	"phone_number",
+	"email",
+	"date_of_birth",
+	"gender",
+	"street_address",
+	"city",
+	"state",
+	"postal_code",
+	"land_ownership_type",
+	"external_id",
+}
+
 // FileParserImpl implements FileParser
 type FileParserImpl struct {
 	config *ParserConfig
@@ -32,11 +53,14 @@ type ParserConfig struct {
 	AllowedDelimiters []rune
 	DateFormats       []string
 	DefaultCountry    string
+	// SkipRecordValidation returns rows that fail record validation instead of dropping them,
+	// so that callers can report on every row (used by bulk dry-run validation)
+	SkipRecordValidation bool
 }
 
-// NewFileParser creates a new file parser with default configuration
-func NewFileParser() FileParser {
-	config := &ParserConfig{
+// DefaultParserConfig returns the default parser configuration
+func DefaultParserConfig() *ParserConfig {
+	return &ParserConfig{
 		MaxRecords:        10000,
 		RequiredFields:    []string{"first_name", "last_name", "phone_number"},
 		AllowedDelimiters: []rune{',', ';', '\t'},
@@ -48,7 +72,15 @@ func NewFileParser() FileParser {
 		},
 		DefaultCountry: "India",
 	}
+}
+
+// NewFileParser creates a new file parser with default configuration
+func NewFileParser() FileParser {
+	return NewFileParserWithConfig(DefaultParserConfig())
+}
 
+// NewFileParserWithConfig creates a new file parser with the given configuration
+func NewFileParserWithConfig(config *ParserConfig) FileParser {
 	return &FileParserImpl{
 		config: config,
 	}
@@ -60,68 +92,9 @@ func (p *FileParserImpl) ParseCSV(data []byte) ([]*requests.FarmerBulkData, erro
 		return nil, fmt.Errorf("empty CSV data")
 	}
 
-	// Detect delimiter
-	delimiter, err := p.detectDelimiter(data)
-	if err != nil {
-		return nil, fmt.Errorf("failed to detect CSV delimiter: %w", err)
-	}
-
-	// Create CSV reader
-	reader := csv.NewReader(strings.NewReader(string(data)))
-	reader.Comma = delimiter
-	reader.TrimLeadingSpace = true
-
-	// Read all records
-	records, err := reader.ReadAll()
-	if err != nil {
-		return nil, fmt.Errorf("failed to read CSV: %w", err)
-	}
-
-	if len(records) == 0 {
-		return nil, fmt.Errorf("no records found in CSV")
-	}
-
-	// First row should be headers
-	headers := p.normalizeHeaders(records[0])
-	if len(headers) == 0 {
-		return nil, fmt.Errorf("no headers found in CSV")
-	}
-
-	// Validate required headers
-	if err := p.validateHeaders(headers); err != nil {
-		return nil, fmt.Errorf("invalid CSV headers: %w", err)
-	}
-
-	// Parse data rows
-	var farmers []*requests.FarmerBulkData
-	for i, record := range records[1:] {
-		if len(record) == 0 {
-			continue // Skip empty rows
-		}
-
-		// Check max records limit
-		if len(farmers) >= p.config.MaxRecords {
-			return nil, fmt.Errorf("exceeded maximum record limit of %d", p.config.MaxRecords)
-		}
-
-		farmer, err := p.parseCSVRecord(headers, record, i+1)
-		if err != nil {
-			// Log warning but continue - validation will happen in the processing pipeline
-			// This allows bulk uploads to proceed with partial valid data
-			fmt.Printf("[WARN] Skipping row %d: %v\n", i+2, err)
-			continue
-		}
-
-		if farmer != nil {
-			farmers = append(farmers, farmer)
-		}
-	}
-
-	if len(farmers) == 0 {
-		return nil, fmt.Errorf("no valid farmer records found - all rows failed validation")
-	}
-
-	return farmers, nil
+	return collectFarmers(func(fn FarmerRecordFunc) (int, error) {
+		return p.StreamCSV(bytes.NewReader(data), fn, nil)
+	})
 }
 
 // ParseExcel parses Excel data and returns farmer bulk data
@@ -130,66 +103,21 @@ func (p *FileParserImpl) ParseExcel(data []byte) ([]*requests.FarmerBulkData, er
 		return nil, fmt.Errorf("empty Excel data")
 	}
 
-	// Create a temporary file-like reader
-	file, err := excelize.OpenReader(strings.NewReader(string(data)))
-	if err != nil {
-		return nil, fmt.Errorf("failed to open Excel file: %w", err)
-	}
-	defer func() { _ = file.Close() }()
-
-	// Get first sheet name
-	sheets := file.GetSheetList()
-	if len(sheets) == 0 {
-		return nil, fmt.Errorf("no sheets found in Excel file")
-	}
-
-	sheetName := sheets[0]
-
-	// Get all rows from the first sheet
-	rows, err := file.GetRows(sheetName)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read Excel rows: %w", err)
-	}
-
-	if len(rows) == 0 {
-		return nil, fmt.Errorf("no rows found in Excel sheet")
-	}
-
-	// First row should be headers
-	if len(rows[0]) == 0 {
-		return nil, fmt.Errorf("no headers found in Excel sheet")
-	}
-
-	headers := p.normalizeHeaders(rows[0])
-
-	// Validate required headers
-	if err := p.validateHeaders(headers); err != nil {
-		return nil, fmt.Errorf("invalid Excel headers: %w", err)
-	}
+	return collectFarmers(func(fn FarmerRecordFunc) (int, error) {
+		return p.StreamExcel(bytes.NewReader(data), fn, nil)
+	})
+}
 
-	// Parse data rows
+// collectFarmers gathers the records of a streaming parse into a slice. Rows that fail
+// validation are skipped while parsing, so a file without any valid row is an error.
+func collectFarmers(stream func(fn FarmerRecordFunc) (int, error)) ([]*requests.FarmerBulkData, error) {
 	var farmers []*requests.FarmerBulkData
-	for i, row := range rows[1:] {
-		if len(row) == 0 {
-			continue // Skip empty rows
-		}
-
-		// Check max records limit
-		if len(farmers) >= p.config.MaxRecords {
-			return nil, fmt.Errorf("exceeded maximum record limit of %d", p.config.MaxRecords)
-		}
-
-		farmer, err := p.parseCSVRecord(headers, row, i+1) // Reuse CSV parsing logic
-		if err != nil {
-			// Log warning but continue - validation will happen in the processing pipeline
-			// This allows bulk uploads to proceed with partial valid data
-			fmt.Printf("[WARN] Skipping Excel row %d: %v\n", i+2, err)
-			continue
-		}
-
-		if farmer != nil {
-			farmers = append(farmers, farmer)
-		}
+	_, err := stream(func(farmer *requests.FarmerBulkData) error {
+		farmers = append(farmers, farmer)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	if len(farmers) == 0 {
@@ -229,8 +157,10 @@ func (p *FileParserImpl) ParseJSON(data []byte) ([]*requests.FarmerBulkData, err
 
 	// Validate each farmer record
 	for i, farmer := range farmers {
-		if err := p.validateFarmerData(farmer, i); err != nil {
-			return nil, fmt.Errorf("validation error for record %d: %w", i, err)
+		if !p.config.SkipRecordValidation {
+			if err := p.validateFarmerData(farmer, i); err != nil {
+				return nil, fmt.Errorf("validation error for record %d: %w", i, err)
+			}
 		}
 
 		// Set defaults
@@ -242,21 +172,7 @@ func (p *FileParserImpl) ParseJSON(data []byte) ([]*requests.FarmerBulkData, err
 
 // GenerateCSVTemplate generates a CSV template with headers
 func (p *FileParserImpl) GenerateCSVTemplate(includeExample bool) ([]byte, error) {
-	headers := []string{
-		"first_name",
-		"last_name",
-		"country_code",
-		"phone_number",
-		"email",
-		"date_of_birth",
-		"gender",
-		"street_address",
-		"city",
-		"state",
-		"postal_code",
-		"land_ownership_type",
-		"external_id",
-	}
+	headers := templateHeaders
 
 	var rows [][]string
 	rows = append(rows, headers)
@@ -309,22 +225,7 @@ func (p *FileParserImpl) GenerateExcelTemplate(includeExample bool) ([]byte, err
 		return nil, fmt.Errorf("failed to create Excel sheet: %w", err)
 	}
 
-	// Set headers
-	headers := []string{
-		"first_name",
-		"last_name",
-		"country_code",
-		"phone_number",
-		"email",
-		"date_of_birth",
-		"gender",
-		"street_address",
-		"city",
-		"state",
-		"postal_code",
-		"land_ownership_type",
-		"external_id",
-	}
+	headers := templateHeaders
 
 	// Write headers
 	for i, header := range headers {
@@ -484,14 +385,20 @@ func (p *FileParserImpl) parseCSVRecord(headers []string, record []string, rowNu
 		case "password":
 			farmer.Password = value
 		default:
+			// Outcome columns of a re-uploaded result file are not farmer data
+			if isResultColumn(header) {
+				continue
+			}
 			// Store as custom field
 			farmer.CustomFields[header] = value
 		}
 	}
 
 	// Validate required fields
-	if err := p.validateFarmerData(farmer, rowNum); err != nil {
-		return nil, err
+	if !p.config.SkipRecordValidation {
+		if err := p.validateFarmerData(farmer, rowNum); err != nil {
+			return nil, err
+		}
 	}
 
 	// Set defaults
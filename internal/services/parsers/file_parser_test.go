@@ -151,6 +151,32 @@ func TestFileParser_ParseJSON(t *testing.T) {
 	}
 }
 
+func TestFileParser_SkipRecordValidation(t *testing.T) {
+	config := DefaultParserConfig()
+	config.SkipRecordValidation = true
+	parser := NewFileParserWithConfig(config)
+
+	csvData := `first_name,last_name,phone_number
+John,Doe,9876543210
+,Smith,123
+Jane,,9876543211`
+
+	farmers, err := parser.ParseCSV([]byte(csvData))
+	require.NoError(t, err)
+	assert.Len(t, farmers, 3, "invalid rows are kept for reporting")
+	assert.Equal(t, "123", farmers[1].PhoneNumber)
+
+	jsonData := `[{"first_name": "John", "email": "john@example.com"}]`
+	jsonFarmers, err := parser.ParseJSON([]byte(jsonData))
+	require.NoError(t, err)
+	assert.Len(t, jsonFarmers, 1)
+
+	// The default parser still drops invalid CSV rows
+	strictFarmers, err := NewFileParser().ParseCSV([]byte(csvData))
+	require.NoError(t, err)
+	assert.Len(t, strictFarmers, 1)
+}
+
 func TestFileParser_GenerateCSVTemplate(t *testing.T) {
 	parser := NewFileParser()
 
@@ -0,0 +1,215 @@
+package parsers
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type kmlPlacemark struct {
+	Name         string            `xml:"name"`
+	ExtendedData kmlExtendedData   `xml:"ExtendedData"`
+	Polygons     []kmlPolygon      `xml:"Polygon"`
+	Multi        *kmlMultiGeometry `xml:"MultiGeometry"`
+	Point        *struct{}         `xml:"Point"`
+	LineString   *struct{}         `xml:"LineString"`
+}
+
+type kmlExtendedData struct {
+	Data []struct {
+		Name  string `xml:"name,attr"`
+		Value string `xml:"value"`
+	} `xml:"Data"`
+	SchemaData []struct {
+		SimpleData []struct {
+			Name  string `xml:"name,attr"`
+			Value string `xml:",chardata"`
+		} `xml:"SimpleData"`
+	} `xml:"SchemaData"`
+}
+
+type kmlMultiGeometry struct {
+	Polygons []kmlPolygon       `xml:"Polygon"`
+	Multi    []kmlMultiGeometry `xml:"MultiGeometry"`
+}
+
+type kmlPolygon struct {
+	Outer string   `xml:"outerBoundaryIs>LinearRing>coordinates"`
+	Inner []string `xml:"innerBoundaryIs>LinearRing>coordinates"`
+}
+
+// parseKMLFeatures reads the placemarks of a KML document, or of the main document of a
+// KMZ archive. Placemarks may be nested in any number of folders.
+func parseKMLFeatures(data []byte) ([]*BoundaryFeature, error) {
+	if bytes.HasPrefix(data, []byte("PK")) {
+		kml, err := kmlFromKMZ(data)
+		if err != nil {
+			return nil, err
+		}
+		data = kml
+	}
+
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	var features []*BoundaryFeature
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse KML: %w", err)
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "Placemark" {
+			continue
+		}
+
+		var placemark kmlPlacemark
+		if err := decoder.DecodeElement(&placemark, &start); err != nil {
+			return nil, fmt.Errorf("failed to parse KML placemark: %w", err)
+		}
+		features = append(features, kmlFeature(placemark))
+	}
+
+	return features, nil
+}
+
+func kmlFeature(placemark kmlPlacemark) *BoundaryFeature {
+	feature := &BoundaryFeature{Properties: make(map[string]string)}
+	if name := strings.TrimSpace(placemark.Name); name != "" {
+		feature.Properties["name"] = name
+	}
+	for _, data := range placemark.ExtendedData.Data {
+		feature.Properties[data.Name] = strings.TrimSpace(data.Value)
+	}
+	for _, schemaData := range placemark.ExtendedData.SchemaData {
+		for _, simpleData := range schemaData.SimpleData {
+			feature.Properties[simpleData.Name] = strings.TrimSpace(simpleData.Value)
+		}
+	}
+
+	kmlPolygons := placemark.Polygons
+	if placemark.Multi != nil {
+		kmlPolygons = append(kmlPolygons, placemark.Multi.polygons()...)
+	}
+
+	if len(kmlPolygons) == 0 {
+		switch {
+		case placemark.Point != nil:
+			feature.Error = "unsupported geometry type \"Point\", expected Polygon or MultiGeometry"
+		case placemark.LineString != nil:
+			feature.Error = "unsupported geometry type \"LineString\", expected Polygon or MultiGeometry"
+		default:
+			feature.Error = "placemark has no polygon geometry"
+		}
+		return feature
+	}
+
+	for _, kmlPolygon := range kmlPolygons {
+		polygon, err := kmlPolygon.polygon()
+		if err != nil {
+			feature.Error = err.Error()
+			feature.Polygons = nil
+			return feature
+		}
+		feature.Polygons = append(feature.Polygons, polygon)
+	}
+	return feature
+}
+
+func (m *kmlMultiGeometry) polygons() []kmlPolygon {
+	polygons := append([]kmlPolygon{}, m.Polygons...)
+	for i := range m.Multi {
+		polygons = append(polygons, m.Multi[i].polygons()...)
+	}
+	return polygons
+}
+
+func (p kmlPolygon) polygon() (Polygon, error) {
+	outer, err := parseKMLCoordinates(p.Outer)
+	if err != nil {
+		return nil, err
+	}
+	polygon := Polygon{outer}
+	for _, inner := range p.Inner {
+		ring, err := parseKMLCoordinates(inner)
+		if err != nil {
+			return nil, err
+		}
+		polygon = append(polygon, ring)
+	}
+	return polygon, nil
+}
+
+// parseKMLCoordinates reads a KML coordinates string: whitespace separated
+// "longitude,latitude[,altitude]" tuples
+func parseKMLCoordinates(text string) (Ring, error) {
+	var ring Ring
+	for _, tuple := range strings.Fields(text) {
+		values := strings.Split(tuple, ",")
+		if len(values) < 2 {
+			return nil, fmt.Errorf("invalid KML coordinate %q", tuple)
+		}
+		lon, err := strconv.ParseFloat(values[0], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid KML longitude %q", values[0])
+		}
+		lat, err := strconv.ParseFloat(values[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid KML latitude %q", values[1])
+		}
+		ring = append(ring, Position{lon, lat})
+	}
+	return closeRing(ring), nil
+}
+
+// kmlFromKMZ returns the main KML document of a KMZ archive: doc.kml, or else the first
+// .kml file in the archive
+func kmlFromKMZ(data []byte) ([]byte, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open KMZ archive: %w", err)
+	}
+
+	var document *zip.File
+	for _, file := range archive.File {
+		if !strings.EqualFold(fileExtension(file.Name), ".kml") {
+			continue
+		}
+		if document == nil || strings.EqualFold(file.Name, "doc.kml") {
+			document = file
+		}
+	}
+	if document == nil {
+		return nil, fmt.Errorf("KMZ archive contains no .kml document")
+	}
+
+	return readZipFile(document)
+}
+
+func readZipFile(file *zip.File) ([]byte, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", file.Name, err)
+	}
+	defer func() { _ = reader.Close() }()
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", file.Name, err)
+	}
+	return content, nil
+}
+
+func fileExtension(name string) string {
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		return name[i:]
+	}
+	return ""
+}
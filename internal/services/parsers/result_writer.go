@@ -0,0 +1,319 @@
+package parsers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Result columns appended to every input row of a bulk result file
+const (
+	ResultColumnRecordIndex = "record_index"
+	ResultColumnStatus      = "result_status"
+	ResultColumnAAAUserID   = "aaa_user_id"
+	ResultColumnFarmerID    = "farmer_id"
+	ResultColumnFarmID      = "farm_id"
+	ResultColumnCropCycleID = "crop_cycle_id"
+	ResultColumnActivityID  = "activity_id"
+	ResultColumnError       = "error"
+	ResultColumnErrorCode   = "error_code"
+)
+
+// ResultColumns lists the outcome columns of a result file in output order. The parsers
+// ignore these columns, so a result file can be corrected and uploaded again as-is.
+var ResultColumns = []string{
+	ResultColumnRecordIndex,
+	ResultColumnStatus,
+	ResultColumnAAAUserID,
+	ResultColumnFarmerID,
+	ResultColumnError,
+	ResultColumnErrorCode,
+}
+
+// FarmResultColumns lists the outcome columns of a farm import result file in output order
+var FarmResultColumns = []string{
+	ResultColumnRecordIndex,
+	ResultColumnStatus,
+	ResultColumnAAAUserID,
+	ResultColumnFarmerID,
+	ResultColumnFarmID,
+	ResultColumnError,
+	ResultColumnErrorCode,
+}
+
+// CropCycleResultColumns lists the outcome columns of a crop cycle import result file in
+// output order. The farm ID is an input column of these imports.
+var CropCycleResultColumns = []string{
+	ResultColumnRecordIndex,
+	ResultColumnStatus,
+	ResultColumnFarmerID,
+	ResultColumnCropCycleID,
+	ResultColumnActivityID,
+	ResultColumnError,
+	ResultColumnErrorCode,
+}
+
+// resultLayout describes the columns of a result file: the standard input columns, the
+// input map whose keys become extra columns, and the outcome columns
+type resultLayout struct {
+	inputColumns  []string
+	nestedKey     string
+	resultColumns []string
+}
+
+// farmerResultLayout writes the upload template columns. Passwords from the original upload
+// are never echoed back.
+var farmerResultLayout = resultLayout{
+	inputColumns:  append(append([]string{}, templateHeaders...), "country"),
+	nestedKey:     "custom_fields",
+	resultColumns: ResultColumns,
+}
+
+// farmResultLayout writes the fields read from each boundary feature followed by the
+// feature's own properties
+var farmResultLayout = resultLayout{
+	inputColumns:  []string{"feature_index", "name", "phone_number", "ownership_type", "external_id", "wkt"},
+	nestedKey:     "properties",
+	resultColumns: FarmResultColumns,
+}
+
+// cropCycleResultLayout writes the crop cycle import columns followed by unrecognised
+// columns of the original upload
+var cropCycleResultLayout = resultLayout{
+	inputColumns:  CropCycleTemplateHeaders,
+	nestedKey:     "custom_fields",
+	resultColumns: CropCycleResultColumns,
+}
+
+// ResultRow is a single row of a bulk result file: the original input record and its outcome
+type ResultRow struct {
+	RecordIndex int
+	Input       map[string]interface{}
+	Status      string
+	AAAUserID   string
+	FarmerID    string
+	FarmID      string
+	CropCycleID string
+	ActivityID  string
+	Error       string
+	ErrorCode   string
+}
+
+// WriteResultCSV writes result rows as CSV using the upload template headers
+func WriteResultCSV(rows []ResultRow) ([]byte, error) {
+	return writeResultCSV(rows, farmerResultLayout)
+}
+
+// WriteFarmResultCSV writes farm import result rows as CSV
+func WriteFarmResultCSV(rows []ResultRow) ([]byte, error) {
+	return writeResultCSV(rows, farmResultLayout)
+}
+
+// WriteCropCycleResultCSV writes crop cycle import result rows as CSV
+func WriteCropCycleResultCSV(rows []ResultRow) ([]byte, error) {
+	return writeResultCSV(rows, cropCycleResultLayout)
+}
+
+func writeResultCSV(rows []ResultRow, layout resultLayout) ([]byte, error) {
+	headers, records := resultTable(rows, layout)
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	if err := writer.Write(headers); err != nil {
+		return nil, fmt.Errorf("failed to write CSV header: %w", err)
+	}
+	for _, record := range records {
+		if err := writer.Write(record); err != nil {
+			return nil, fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf("failed to flush CSV writer: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// WriteResultExcel writes result rows as an Excel workbook using the upload template headers
+func WriteResultExcel(rows []ResultRow) ([]byte, error) {
+	return writeResultExcel(rows, farmerResultLayout)
+}
+
+// WriteFarmResultExcel writes farm import result rows as an Excel workbook
+func WriteFarmResultExcel(rows []ResultRow) ([]byte, error) {
+	return writeResultExcel(rows, farmResultLayout)
+}
+
+// WriteCropCycleResultExcel writes crop cycle import result rows as an Excel workbook
+func WriteCropCycleResultExcel(rows []ResultRow) ([]byte, error) {
+	return writeResultExcel(rows, cropCycleResultLayout)
+}
+
+func writeResultExcel(rows []ResultRow, layout resultLayout) ([]byte, error) {
+	headers, records := resultTable(rows, layout)
+
+	file := excelize.NewFile()
+	defer func() { _ = file.Close() }()
+
+	sheetName := "Results"
+	index, err := file.NewSheet(sheetName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Excel sheet: %w", err)
+	}
+
+	for i, record := range append([][]string{headers}, records...) {
+		cell, err := excelize.CoordinatesToCellName(1, i+1)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve Excel cell: %w", err)
+		}
+
+		values := make([]interface{}, len(record))
+		for j, value := range record {
+			values[j] = value
+		}
+		if err := file.SetSheetRow(sheetName, cell, &values); err != nil {
+			return nil, fmt.Errorf("failed to write Excel row: %w", err)
+		}
+	}
+
+	file.SetActiveSheet(index)
+	_ = file.DeleteSheet("Sheet1") // Remove default sheet
+
+	var buf bytes.Buffer
+	if err := file.Write(&buf); err != nil {
+		return nil, fmt.Errorf("failed to write Excel file: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// WriteResultJSON writes result rows as a JSON array of farmer records. Outcome fields are
+// added alongside the input fields and are ignored when the file is parsed again.
+func WriteResultJSON(rows []ResultRow) ([]byte, error) {
+	return writeResultJSON(rows, ResultColumns)
+}
+
+// WriteFarmResultJSON writes farm import result rows as a JSON array
+func WriteFarmResultJSON(rows []ResultRow) ([]byte, error) {
+	return writeResultJSON(rows, FarmResultColumns)
+}
+
+// WriteCropCycleResultJSON writes crop cycle import result rows as a JSON array
+func WriteCropCycleResultJSON(rows []ResultRow) ([]byte, error) {
+	return writeResultJSON(rows, CropCycleResultColumns)
+}
+
+func writeResultJSON(rows []ResultRow, resultColumns []string) ([]byte, error) {
+	records := make([]map[string]interface{}, 0, len(rows))
+	for _, row := range rows {
+		record := make(map[string]interface{}, len(row.Input)+len(resultColumns))
+		for key, value := range row.Input {
+			if key == "password" {
+				continue
+			}
+			record[key] = value
+		}
+		outcome := row.outcome()
+		for _, column := range resultColumns[1:] {
+			record[column] = outcome[column]
+		}
+		record[ResultColumnRecordIndex] = row.RecordIndex
+		records = append(records, record)
+	}
+
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal JSON results: %w", err)
+	}
+
+	return data, nil
+}
+
+// outcome returns the string valued result columns of a row
+func (r ResultRow) outcome() map[string]string {
+	return map[string]string{
+		ResultColumnStatus:      r.Status,
+		ResultColumnAAAUserID:   r.AAAUserID,
+		ResultColumnFarmerID:    r.FarmerID,
+		ResultColumnFarmID:      r.FarmID,
+		ResultColumnCropCycleID: r.CropCycleID,
+		ResultColumnActivityID:  r.ActivityID,
+		ResultColumnError:       r.Error,
+		ResultColumnErrorCode:   r.ErrorCode,
+	}
+}
+
+// resultTable flattens result rows into a header row and data rows. The keys of the nested
+// input map (custom fields, feature properties) become their own columns, placed between
+// the standard and the result columns.
+func resultTable(rows []ResultRow, layout resultLayout) ([]string, [][]string) {
+	customSet := make(map[string]bool)
+	for _, row := range rows {
+		if custom, ok := row.Input[layout.nestedKey].(map[string]interface{}); ok {
+			for key := range custom {
+				customSet[key] = true
+			}
+		}
+	}
+
+	customColumns := make([]string, 0, len(customSet))
+	for key := range customSet {
+		customColumns = append(customColumns, key)
+	}
+	sort.Strings(customColumns)
+
+	headers := make([]string, 0, len(layout.inputColumns)+len(customColumns)+len(layout.resultColumns))
+	headers = append(headers, layout.inputColumns...)
+	headers = append(headers, customColumns...)
+	headers = append(headers, layout.resultColumns...)
+
+	records := make([][]string, 0, len(rows))
+	for _, row := range rows {
+		custom, _ := row.Input[layout.nestedKey].(map[string]interface{})
+		outcome := row.outcome()
+
+		record := make([]string, 0, len(headers))
+		for _, column := range layout.inputColumns {
+			record = append(record, formatResultValue(row.Input[column]))
+		}
+		for _, column := range customColumns {
+			record = append(record, formatResultValue(custom[column]))
+		}
+		record = append(record, strconv.Itoa(row.RecordIndex))
+		for _, column := range layout.resultColumns[1:] {
+			record = append(record, outcome[column])
+		}
+		records = append(records, record)
+	}
+
+	return headers, records
+}
+
+func formatResultValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case float64:
+		// JSON numbers decode as float64; keep identifiers like 9876543210 intact
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func isResultColumn(header string) bool {
+	for _, column := range ResultColumns {
+		if header == column {
+			return true
+		}
+	}
+	return false
+}
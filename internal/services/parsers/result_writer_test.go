@@ -0,0 +1,131 @@
+package parsers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testResultRows() []ResultRow {
+	return []ResultRow{
+		{
+			RecordIndex: 0,
+			Input: map[string]interface{}{
+				"first_name":    "Ramesh",
+				"last_name":     "Kumar",
+				"phone_number":  "9876543210",
+				"external_id":   "EXT-1",
+				"password":      "Secret@123",
+				"custom_fields": map[string]interface{}{"village": "Khandwa", "family_size": float64(5)},
+			},
+			Status:    "success",
+			AAAUserID: "USER00000001",
+			FarmerID:  "FMRR0000000001",
+		},
+		{
+			RecordIndex: 1,
+			Input: map[string]interface{}{
+				"first_name":   "Suresh",
+				"last_name":    "Patel",
+				"phone_number": "9876543211",
+				"external_id":  "EXT-2",
+			},
+			Status:    "failed",
+			Error:     "pipeline execution failed: AAA unavailable",
+			ErrorCode: "PROCESSING_ERROR",
+		},
+	}
+}
+
+func TestWriteResultCSV_RoundTrip(t *testing.T) {
+	data, err := WriteResultCSV(testResultRows())
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 3)
+	assert.True(t, strings.HasPrefix(lines[0], "first_name,last_name,country_code,phone_number"))
+	assert.True(t, strings.HasSuffix(lines[0], "family_size,village,record_index,result_status,aaa_user_id,farmer_id,error,error_code"))
+	assert.Contains(t, lines[1], "USER00000001,FMRR0000000001")
+	assert.Contains(t, lines[2], "failed,,,pipeline execution failed: AAA unavailable,PROCESSING_ERROR")
+	assert.NotContains(t, string(data), "Secret@123")
+
+	// The result file can be uploaded again; outcome columns are not treated as custom fields
+	farmers, err := NewFileParser().ParseCSV(data)
+	require.NoError(t, err)
+	require.Len(t, farmers, 2)
+	assert.Equal(t, "EXT-2", farmers[1].ExternalID)
+	assert.Equal(t, map[string]interface{}{"family_size": "5", "village": "Khandwa"}, farmers[0].CustomFields)
+	assert.Empty(t, farmers[1].CustomFields)
+}
+
+func TestWriteResultExcel_RoundTrip(t *testing.T) {
+	data, err := WriteResultExcel(testResultRows())
+	require.NoError(t, err)
+
+	farmers, err := NewFileParser().ParseExcel(data)
+	require.NoError(t, err)
+	require.Len(t, farmers, 2)
+	assert.Equal(t, "Ramesh", farmers[0].FirstName)
+	assert.Equal(t, "9876543211", farmers[1].PhoneNumber)
+	assert.NotContains(t, farmers[1].CustomFields, ResultColumnError)
+}
+
+func TestWriteResultJSON_RoundTrip(t *testing.T) {
+	data, err := WriteResultJSON(testResultRows())
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"result_status": "failed"`)
+	assert.Contains(t, string(data), `"error_code": "PROCESSING_ERROR"`)
+	assert.NotContains(t, string(data), "Secret@123")
+
+	farmers, err := NewFileParser().ParseJSON(data)
+	require.NoError(t, err)
+	require.Len(t, farmers, 2)
+	assert.Equal(t, "EXT-1", farmers[0].ExternalID)
+	assert.Equal(t, "Khandwa", farmers[0].CustomFields["village"])
+}
+
+func TestWriteFarmResultCSV(t *testing.T) {
+	rows := []ResultRow{
+		{
+			RecordIndex: 0,
+			Input: map[string]interface{}{
+				"feature_index": float64(0),
+				"name":          "North field",
+				"phone_number":  "9876543210",
+				"wkt":           "POLYGON((77.1 28.6, 77.101 28.6, 77.101 28.601, 77.1 28.6))",
+				"properties":    map[string]interface{}{"khasra": "118/2"},
+			},
+			Status:    "success",
+			AAAUserID: "USER00000001",
+			FarmerID:  "FMRR0000000001",
+			FarmID:    "FARM00000001",
+		},
+		{
+			RecordIndex: 1,
+			Input:       map[string]interface{}{"feature_index": float64(1), "phone_number": "9876543211"},
+			Status:      "failed",
+			Error:       "no farmer of the FPO found for phone 9876543211",
+			ErrorCode:   "FARMER_NOT_FOUND",
+		},
+	}
+
+	data, err := WriteFarmResultCSV(rows)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, "feature_index,name,phone_number,ownership_type,external_id,wkt,khasra,record_index,result_status,aaa_user_id,farmer_id,farm_id,error,error_code", lines[0])
+	assert.Contains(t, lines[1], "118/2,0,success,USER00000001,FMRR0000000001,FARM00000001")
+	assert.True(t, strings.HasSuffix(lines[2], "FARMER_NOT_FOUND"))
+
+	jsonData, err := WriteFarmResultJSON(rows)
+	require.NoError(t, err)
+	assert.Contains(t, string(jsonData), `"farm_id": "FARM00000001"`)
+
+	// Farmer result files keep their own columns
+	farmerData, err := WriteResultJSON(testResultRows())
+	require.NoError(t, err)
+	assert.NotContains(t, string(farmerData), ResultColumnFarmID)
+}
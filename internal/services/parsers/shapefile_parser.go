@@ -0,0 +1,254 @@
+package parsers
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Shapefile shape types with polygon geometry. Z and M values are ignored.
+const (
+	shapeNull     = 0
+	shapePolygon  = 5
+	shapePolygonZ = 15
+	shapePolygonM = 25
+)
+
+const (
+	shpHeaderLength = 100
+	dbfFieldLength  = 32
+	dbfTerminator   = 0x0D
+	dbfDeleted      = '*'
+)
+
+// parseShapefileFeatures reads a zipped ESRI Shapefile. The archive must hold one .shp with
+// its .dbf attribute table; a .prj, when present, must declare WGS 84 geographic coordinates.
+func parseShapefileFeatures(data []byte) ([]*BoundaryFeature, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open shapefile archive: %w", err)
+	}
+
+	files := make(map[string]*zip.File)
+	var shpName string
+	for _, file := range archive.File {
+		if strings.HasPrefix(file.Name, "__MACOSX/") {
+			continue
+		}
+		ext := strings.ToLower(fileExtension(file.Name))
+		if ext == ".shp" {
+			if shpName != "" {
+				return nil, fmt.Errorf("shapefile archive must contain exactly one .shp file")
+			}
+			shpName = file.Name
+		}
+		files[strings.ToLower(file.Name)] = file
+	}
+	if shpName == "" {
+		return nil, fmt.Errorf("shapefile archive contains no .shp file")
+	}
+
+	base := strings.ToLower(strings.TrimSuffix(shpName, fileExtension(shpName)))
+	dbfFile, ok := files[base+".dbf"]
+	if !ok {
+		return nil, fmt.Errorf("shapefile archive is missing %s.dbf", base)
+	}
+
+	if prjFile, ok := files[base+".prj"]; ok {
+		prj, err := readZipFile(prjFile)
+		if err != nil {
+			return nil, err
+		}
+		if !isWGS84Projection(string(prj)) {
+			return nil, fmt.Errorf("shapefile projection must be WGS 84 (EPSG:4326)")
+		}
+	}
+
+	shp, err := readZipFile(files[strings.ToLower(shpName)])
+	if err != nil {
+		return nil, err
+	}
+	dbf, err := readZipFile(dbfFile)
+	if err != nil {
+		return nil, err
+	}
+
+	shapes, err := readShapes(shp)
+	if err != nil {
+		return nil, err
+	}
+	records, err := readDBFRecords(dbf)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) != len(shapes) {
+		return nil, fmt.Errorf("shapefile has %d shapes but %d attribute records", len(shapes), len(records))
+	}
+
+	features := make([]*BoundaryFeature, 0, len(shapes))
+	for i, shape := range shapes {
+		if records[i] == nil {
+			continue // deleted record
+		}
+		features = append(features, &BoundaryFeature{
+			Properties: records[i],
+			Polygons:   shape.polygons,
+			Error:      shape.err,
+		})
+	}
+	return features, nil
+}
+
+// isWGS84Projection reports whether a .prj declares geographic WGS 84 coordinates
+func isWGS84Projection(prj string) bool {
+	prj = strings.ToUpper(strings.TrimSpace(prj))
+	if !strings.HasPrefix(prj, "GEOGCS") {
+		return false
+	}
+	return strings.Contains(prj, "WGS_1984") || strings.Contains(prj, "WGS 84") || strings.Contains(prj, "WGS84")
+}
+
+type shapeRecord struct {
+	polygons []Polygon
+	err      string
+}
+
+// readShapes reads the records of a .shp file. Record headers are big-endian, record
+// contents little-endian.
+func readShapes(shp []byte) ([]shapeRecord, error) {
+	if len(shp) < shpHeaderLength || binary.BigEndian.Uint32(shp[0:4]) != 9994 {
+		return nil, fmt.Errorf("invalid .shp file header")
+	}
+
+	var shapes []shapeRecord
+	offset := shpHeaderLength
+	for offset+8 <= len(shp) {
+		contentLength := int(binary.BigEndian.Uint32(shp[offset+4:offset+8])) * 2
+		start := offset + 8
+		end := start + contentLength
+		if contentLength < 4 || end > len(shp) {
+			return nil, fmt.Errorf("truncated .shp record %d", len(shapes)+1)
+		}
+		shapes = append(shapes, readShape(shp[start:end]))
+		offset = end
+
+		if len(shapes) > MaxBoundaryFeatures {
+			return nil, fmt.Errorf("exceeded maximum feature limit of %d", MaxBoundaryFeatures)
+		}
+	}
+	return shapes, nil
+}
+
+func readShape(content []byte) shapeRecord {
+	shapeType := binary.LittleEndian.Uint32(content[0:4])
+	switch shapeType {
+	case shapeNull:
+		return shapeRecord{err: "feature has no geometry"}
+	case shapePolygon, shapePolygonZ, shapePolygonM:
+	default:
+		return shapeRecord{err: fmt.Sprintf("unsupported shape type %d, expected Polygon", shapeType)}
+	}
+
+	// type (4) + bounding box (32) + part count (4) + point count (4)
+	if len(content) < 44 {
+		return shapeRecord{err: "truncated polygon record"}
+	}
+	numParts := int(binary.LittleEndian.Uint32(content[36:40]))
+	numPoints := int(binary.LittleEndian.Uint32(content[40:44]))
+	pointsStart := 44 + numParts*4
+	if numParts <= 0 || numPoints <= 0 || pointsStart+numPoints*16 > len(content) {
+		return shapeRecord{err: "truncated polygon record"}
+	}
+
+	parts := make([]int, numParts+1)
+	for i := 0; i < numParts; i++ {
+		parts[i] = int(binary.LittleEndian.Uint32(content[44+i*4:]))
+	}
+	parts[numParts] = numPoints
+
+	var polygons []Polygon
+	for i := 0; i < numParts; i++ {
+		if parts[i] < 0 || parts[i] >= parts[i+1] || parts[i+1] > numPoints {
+			return shapeRecord{err: "invalid polygon part index"}
+		}
+
+		ring := make(Ring, 0, parts[i+1]-parts[i])
+		for p := parts[i]; p < parts[i+1]; p++ {
+			at := pointsStart + p*16
+			x := math.Float64frombits(binary.LittleEndian.Uint64(content[at:]))
+			y := math.Float64frombits(binary.LittleEndian.Uint64(content[at+8:]))
+			ring = append(ring, Position{x, y})
+		}
+		ring = closeRing(ring)
+
+		// Outer rings are clockwise and holes counter-clockwise; a hole belongs to the
+		// outer ring before it
+		if ringArea(ring) > 0 && len(polygons) > 0 {
+			polygons[len(polygons)-1] = append(polygons[len(polygons)-1], ring)
+		} else {
+			polygons = append(polygons, Polygon{ring})
+		}
+	}
+	return shapeRecord{polygons: polygons}
+}
+
+// ringArea returns the signed planar area of a ring: positive when counter-clockwise
+func ringArea(ring Ring) float64 {
+	var area float64
+	for i := 0; i+1 < len(ring); i++ {
+		area += ring[i][0]*ring[i+1][1] - ring[i+1][0]*ring[i][1]
+	}
+	return area / 2
+}
+
+// readDBFRecords reads the attribute records of a dBASE file. Deleted records are
+// returned as nil so they stay aligned with the shapes.
+func readDBFRecords(dbf []byte) ([]map[string]string, error) {
+	if len(dbf) < dbfFieldLength {
+		return nil, fmt.Errorf("invalid .dbf file header")
+	}
+	recordCount := int(binary.LittleEndian.Uint32(dbf[4:8]))
+	headerLength := int(binary.LittleEndian.Uint16(dbf[8:10]))
+	recordLength := int(binary.LittleEndian.Uint16(dbf[10:12]))
+	if headerLength > len(dbf) || recordLength <= 0 {
+		return nil, fmt.Errorf("invalid .dbf file header")
+	}
+
+	type dbfField struct {
+		name   string
+		length int
+	}
+	var fields []dbfField
+	for at := dbfFieldLength; at+dbfFieldLength <= headerLength && dbf[at] != dbfTerminator; at += dbfFieldLength {
+		name := string(bytes.TrimRight(dbf[at:at+11], "\x00"))
+		fields = append(fields, dbfField{name: strings.TrimSpace(name), length: int(dbf[at+16])})
+	}
+
+	records := make([]map[string]string, 0, recordCount)
+	for i := 0; i < recordCount; i++ {
+		start := headerLength + i*recordLength
+		if start+recordLength > len(dbf) {
+			return nil, fmt.Errorf("truncated .dbf record %d", i+1)
+		}
+		record := dbf[start : start+recordLength]
+		if record[0] == dbfDeleted {
+			records = append(records, nil)
+			continue
+		}
+
+		properties := make(map[string]string, len(fields))
+		at := 1
+		for _, field := range fields {
+			if at+field.length > len(record) {
+				break
+			}
+			properties[field.name] = strings.TrimSpace(string(record[at : at+field.length]))
+			at += field.length
+		}
+		records = append(records, properties)
+	}
+	return records, nil
+}
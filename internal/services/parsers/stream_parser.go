@@ -0,0 +1,368 @@
+package parsers
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/bulk"
+	"github.com/Kisanlink/farmers-module/internal/entities/requests"
+	"github.com/xuri/excelize/v2"
+)
+
+// DefaultMaxStreamRecords is the record limit of streaming parsers. Streamed uploads never
+// hold more than one chunk of records in memory, so the limit is far above MaxRecords.
+const DefaultMaxStreamRecords = 500000
+
+// FarmerRecordFunc receives the records of a streamed upload in file order. Returning an
+// error stops parsing and the parser returns that error.
+type FarmerRecordFunc func(farmer *requests.FarmerBulkData) error
+
+// InvalidRowFunc receives the rows of a streamed upload that fail record validation, with
+// their row number in the file and their values by column. Returning an error stops parsing
+// and the parser returns that error.
+type InvalidRowFunc func(rowNumber int, values map[string]string, err error) error
+
+// StreamingFileParser reads uploads one record at a time instead of materialising every
+// record, so large files are parsed with bounded memory. Each method returns the number of
+// records handed to fn. Rows failing validation are handed to onInvalid, or skipped when it
+// is nil.
+type StreamingFileParser interface {
+	StreamCSV(r io.Reader, fn FarmerRecordFunc, onInvalid InvalidRowFunc) (int, error)
+	StreamExcel(r io.Reader, fn FarmerRecordFunc, onInvalid InvalidRowFunc) (int, error)
+	StreamJSON(r io.Reader, fn FarmerRecordFunc) (int, error)
+	StreamWithMapping(format string, r io.Reader, profile *bulk.ColumnMappingProfile, fn FarmerRecordFunc, onInvalid InvalidRowFunc) (int, error)
+}
+
+// DefaultStreamingParserConfig returns the default configuration of streaming parsers
+func DefaultStreamingParserConfig() *ParserConfig {
+	config := DefaultParserConfig()
+	config.MaxRecords = DefaultMaxStreamRecords
+	return config
+}
+
+// NewStreamingFileParser creates a streaming file parser with the given configuration
+func NewStreamingFileParser(config *ParserConfig) StreamingFileParser {
+	return &FileParserImpl{
+		config: config,
+	}
+}
+
+// rowReader returns the next row of a table, or io.EOF after the last row
+type rowReader func() ([]string, error)
+
+// StreamCSV parses CSV data with the template headers row by row
+func (p *FileParserImpl) StreamCSV(r io.Reader, fn FarmerRecordFunc, onInvalid InvalidRowFunc) (int, error) {
+	reader, err := p.newCSVReader(r)
+	if err != nil {
+		return 0, err
+	}
+
+	headers, err := reader.Read()
+	if err == io.EOF {
+		return 0, fmt.Errorf("no records found in CSV")
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read CSV: %w", err)
+	}
+
+	headers = p.normalizeHeaders(headers)
+	if err := p.validateHeaders(headers); err != nil {
+		return 0, fmt.Errorf("invalid CSV headers: %w", err)
+	}
+
+	return p.streamRows("CSV", headers, csvRows(reader), nil, fn, onInvalid)
+}
+
+// StreamExcel parses the first sheet of an Excel workbook with the template headers using
+// excelize's row iterator. Large worksheets are unpacked to a temporary file by excelize
+// rather than held in memory.
+func (p *FileParserImpl) StreamExcel(r io.Reader, fn FarmerRecordFunc, onInvalid InvalidRowFunc) (int, error) {
+	file, rows, err := openExcelRows(r)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = file.Close() }()
+	defer func() { _ = rows.Close() }()
+
+	if !rows.Next() {
+		return 0, fmt.Errorf("no rows found in Excel sheet")
+	}
+	headers, err := rows.Columns()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read Excel rows: %w", err)
+	}
+	if len(headers) == 0 {
+		return 0, fmt.Errorf("no headers found in Excel sheet")
+	}
+
+	headers = p.normalizeHeaders(headers)
+	if err := p.validateHeaders(headers); err != nil {
+		return 0, fmt.Errorf("invalid Excel headers: %w", err)
+	}
+
+	return p.streamRows("Excel", headers, excelRows(rows), nil, fn, onInvalid)
+}
+
+// StreamJSON parses a JSON array of farmer objects, or a single object, decoding one
+// element at a time. As with ParseJSON, a record that fails validation fails the upload.
+func (p *FileParserImpl) StreamJSON(r io.Reader, fn FarmerRecordFunc) (int, error) {
+	buffered := bufio.NewReader(r)
+	first, err := firstNonSpace(buffered)
+	if err == io.EOF {
+		return 0, fmt.Errorf("empty JSON data")
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	decoder := json.NewDecoder(buffered)
+	yield := func(farmer *requests.FarmerBulkData, index int) error {
+		if !p.config.SkipRecordValidation {
+			if err := p.validateFarmerData(farmer, index); err != nil {
+				return fmt.Errorf("validation error for record %d: %w", index, err)
+			}
+		}
+		p.setFarmerDefaults(farmer)
+		return fn(farmer)
+	}
+
+	if first == '{' {
+		var farmer requests.FarmerBulkData
+		if err := decoder.Decode(&farmer); err != nil {
+			return 0, fmt.Errorf("failed to parse JSON: %w", err)
+		}
+		if err := yield(&farmer, 0); err != nil {
+			return 0, err
+		}
+		return 1, nil
+	}
+
+	if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+		return 0, fmt.Errorf("failed to parse JSON: expected an array or object of farmer records")
+	}
+
+	count := 0
+	for decoder.More() {
+		if p.limitReached(count) {
+			return count, fmt.Errorf("exceeded maximum record limit of %d", p.config.MaxRecords)
+		}
+
+		var farmer requests.FarmerBulkData
+		if err := decoder.Decode(&farmer); err != nil {
+			return count, fmt.Errorf("failed to parse JSON record %d: %w", count, err)
+		}
+		if err := yield(&farmer, count); err != nil {
+			return count, err
+		}
+		count++
+	}
+
+	if _, err := decoder.Token(); err != nil {
+		return count, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	return count, nil
+}
+
+// StreamWithMapping parses an upload whose columns are described by a column mapping
+// profile. CSV and Excel files are streamed; the columns of a JSON upload are only known
+// once every object has been read, so JSON is read whole.
+func (p *FileParserImpl) StreamWithMapping(format string, r io.Reader, profile *bulk.ColumnMappingProfile, fn FarmerRecordFunc, onInvalid InvalidRowFunc) (int, error) {
+	var headers []string
+	var next rowReader
+
+	switch strings.ToLower(format) {
+	case "csv":
+		reader, err := p.newCSVReader(r)
+		if err != nil {
+			return 0, err
+		}
+		if headers, err = reader.Read(); err != nil {
+			return 0, fmt.Errorf("no headers found in CSV")
+		}
+		next = csvRows(reader)
+	case "excel", "xlsx":
+		file, rows, err := openExcelRows(r)
+		if err != nil {
+			return 0, err
+		}
+		defer func() { _ = file.Close() }()
+		defer func() { _ = rows.Close() }()
+
+		if rows.Next() {
+			headers, _ = rows.Columns()
+		}
+		next = excelRows(rows)
+	case "json":
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return 0, fmt.Errorf("failed to read JSON: %w", err)
+		}
+		var table [][]string
+		if headers, table, err = p.readTable(format, data); err != nil {
+			return 0, err
+		}
+		next = tableRows(table)
+	default:
+		return 0, fmt.Errorf("unsupported format: %s", format)
+	}
+
+	if len(headers) == 0 {
+		return 0, fmt.Errorf("no headers found in %s", strings.ToUpper(format))
+	}
+
+	mapper := newColumnMapper(profile, headers, p.normalizeHeaders)
+	if err := p.validateHeaders(mapper.targets); err != nil {
+		return 0, fmt.Errorf("invalid column mapping: %w", err)
+	}
+
+	return p.streamRows("mapped", mapper.targets, next, mapper.apply, fn, onInvalid)
+}
+
+// streamRows parses the data rows of a table into farmer records. Rows that fail record
+// validation are handed to onInvalid, when set, so that they can be reported on; transform,
+// when set, rearranges each row into the order of headers.
+func (p *FileParserImpl) streamRows(kind string, headers []string, next rowReader, transform func([]string) []string, fn FarmerRecordFunc, onInvalid InvalidRowFunc) (int, error) {
+	count := 0
+	for i := 0; ; i++ {
+		row, err := next()
+		if err == io.EOF {
+			return count, nil
+		}
+		if err != nil {
+			return count, fmt.Errorf("failed to read %s row %d: %w", kind, i+2, err)
+		}
+		if isBlankRow(row) {
+			continue
+		}
+
+		if p.limitReached(count) {
+			return count, fmt.Errorf("exceeded maximum record limit of %d", p.config.MaxRecords)
+		}
+
+		if transform != nil {
+			row = transform(row)
+		}
+		farmer, err := p.parseCSVRecord(headers, row, i+1)
+		if err != nil {
+			if onInvalid != nil {
+				if err := onInvalid(i+2, rowValues(headers, row), err); err != nil {
+					return count, err
+				}
+			}
+			continue
+		}
+
+		if err := fn(farmer); err != nil {
+			return count, err
+		}
+		count++
+	}
+}
+
+// limitReached reports whether count records use up the configured limit; a limit of
+// zero or less means no limit
+func (p *FileParserImpl) limitReached(count int) bool {
+	return p.config.MaxRecords > 0 && count >= p.config.MaxRecords
+}
+
+// newCSVReader returns a CSV reader for r using the delimiter detected in its first bytes
+func (p *FileParserImpl) newCSVReader(r io.Reader) (*csv.Reader, error) {
+	buffered := bufio.NewReader(r)
+	sample, err := buffered.Peek(1000)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return nil, fmt.Errorf("failed to read CSV: %w", err)
+	}
+	if len(sample) == 0 {
+		return nil, fmt.Errorf("empty CSV data")
+	}
+
+	delimiter, err := p.detectDelimiter(sample)
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect CSV delimiter: %w", err)
+	}
+
+	reader := csv.NewReader(buffered)
+	reader.Comma = delimiter
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+	return reader, nil
+}
+
+// rowValues returns the values of a row by column header
+func rowValues(headers, row []string) map[string]string {
+	values := make(map[string]string, len(headers))
+	for i, header := range headers {
+		if i < len(row) && row[i] != "" {
+			values[header] = row[i]
+		}
+	}
+	return values
+}
+
+func csvRows(reader *csv.Reader) rowReader {
+	return reader.Read
+}
+
+// openExcelRows opens a workbook and returns a row iterator over its first sheet
+func openExcelRows(r io.Reader) (*excelize.File, *excelize.Rows, error) {
+	file, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open Excel file: %w", err)
+	}
+
+	sheets := file.GetSheetList()
+	if len(sheets) == 0 {
+		_ = file.Close()
+		return nil, nil, fmt.Errorf("no sheets found in Excel file")
+	}
+
+	rows, err := file.Rows(sheets[0])
+	if err != nil {
+		_ = file.Close()
+		return nil, nil, fmt.Errorf("failed to read Excel rows: %w", err)
+	}
+
+	return file, rows, nil
+}
+
+func excelRows(rows *excelize.Rows) rowReader {
+	return func() ([]string, error) {
+		if !rows.Next() {
+			if err := rows.Error(); err != nil {
+				return nil, err
+			}
+			return nil, io.EOF
+		}
+		return rows.Columns()
+	}
+}
+
+func tableRows(table [][]string) rowReader {
+	i := 0
+	return func() ([]string, error) {
+		if i >= len(table) {
+			return nil, io.EOF
+		}
+		i++
+		return table[i-1], nil
+	}
+}
+
+// firstNonSpace returns the first byte of r that is not white space without consuming it
+func firstNonSpace(r *bufio.Reader) (byte, error) {
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		if b != ' ' && b != '\t' && b != '\n' && b != '\r' {
+			return b, r.UnreadByte()
+		}
+	}
+}
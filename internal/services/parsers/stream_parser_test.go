@@ -0,0 +1,150 @@
+package parsers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/bulk"
+	"github.com/Kisanlink/farmers-module/internal/entities/requests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+// collect returns a FarmerRecordFunc that appends records to farmers
+func collect(farmers *[]*requests.FarmerBulkData) FarmerRecordFunc {
+	return func(farmer *requests.FarmerBulkData) error {
+		*farmers = append(*farmers, farmer)
+		return nil
+	}
+}
+
+func TestStreamCSV(t *testing.T) {
+	parser := NewStreamingFileParser(DefaultStreamingParserConfig())
+
+	csvData := "first_name,last_name,phone_number,village\n" +
+		"John,Doe,9876543210,Khandwa\n" +
+		",,,\n" +
+		"Jane,,9876543211\n" +
+		"Ravi,Patel,+91 98765 43212,Nashik\n"
+
+	var farmers []*requests.FarmerBulkData
+	var invalidRows []int
+	var invalidValues map[string]string
+	count, err := parser.StreamCSV(strings.NewReader(csvData), collect(&farmers), func(rowNumber int, values map[string]string, err error) error {
+		assert.Error(t, err)
+		invalidRows = append(invalidRows, rowNumber)
+		invalidValues = values
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, count, "blank rows and rows failing validation are not yielded")
+	require.Len(t, farmers, 2)
+	assert.Equal(t, "John", farmers[0].FirstName)
+	assert.Equal(t, "Khandwa", farmers[0].CustomFields["village"])
+	assert.Equal(t, "9876543212", farmers[1].PhoneNumber)
+
+	assert.Equal(t, []int{4}, invalidRows, "rows failing validation are reported with their file row number")
+	assert.Equal(t, map[string]string{"first_name": "Jane", "phone_number": "9876543211"}, invalidValues)
+}
+
+func TestStreamCSV_StopsWhenHandlerFails(t *testing.T) {
+	parser := NewStreamingFileParser(DefaultStreamingParserConfig())
+
+	var buf strings.Builder
+	buf.WriteString("first_name,last_name,phone_number\n")
+	for i := 0; i < 10; i++ {
+		fmt.Fprintf(&buf, "Farmer,%d,98765432%02d\n", i, i)
+	}
+
+	stop := errors.New("stop")
+	seen := 0
+	count, err := parser.StreamCSV(strings.NewReader(buf.String()), func(farmer *requests.FarmerBulkData) error {
+		seen++
+		if seen == 3 {
+			return stop
+		}
+		return nil
+	}, nil)
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, 3, seen, "no record is read after the handler fails")
+}
+
+func TestStreamCSV_MaxRecords(t *testing.T) {
+	config := DefaultStreamingParserConfig()
+	config.MaxRecords = 1
+	parser := NewStreamingFileParser(config)
+
+	csvData := "first_name,last_name,phone_number\nJohn,Doe,9876543210\nJane,Doe,9876543211\n"
+	_, err := parser.StreamCSV(strings.NewReader(csvData), func(*requests.FarmerBulkData) error { return nil }, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exceeded maximum record limit of 1")
+}
+
+func TestStreamExcel(t *testing.T) {
+	file := excelize.NewFile()
+	rows := [][]interface{}{
+		{"First Name", "Last Name", "Phone Number"},
+		{"John", "Doe", "9876543210"},
+		{},
+		{"Jane", "Smith", "9876543211"},
+	}
+	for i, row := range rows {
+		require.NoError(t, file.SetSheetRow("Sheet1", fmt.Sprintf("A%d", i+1), &row))
+	}
+	var data bytes.Buffer
+	require.NoError(t, file.Write(&data))
+
+	parser := NewStreamingFileParser(DefaultStreamingParserConfig())
+	var farmers []*requests.FarmerBulkData
+	count, err := parser.StreamExcel(bytes.NewReader(data.Bytes()), collect(&farmers), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, "Smith", farmers[1].LastName)
+}
+
+func TestStreamJSON(t *testing.T) {
+	parser := NewStreamingFileParser(DefaultStreamingParserConfig())
+
+	var farmers []*requests.FarmerBulkData
+	count, err := parser.StreamJSON(strings.NewReader(` [
+		{"first_name": "John", "last_name": "Doe", "phone_number": "9876543210"},
+		{"first_name": "Jane", "last_name": "Smith", "phone_number": "9876543211"}
+	]`), collect(&farmers))
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, "India", farmers[1].Country, "defaults are applied")
+
+	farmers = nil
+	count, err = parser.StreamJSON(strings.NewReader(`{"first_name": "John", "last_name": "Doe", "phone_number": "9876543210"}`), collect(&farmers))
+	require.NoError(t, err)
+	assert.Equal(t, 1, count, "a single object is one record")
+
+	_, err = parser.StreamJSON(strings.NewReader(`[{"first_name": "John", "phone_number": "9876543210"}]`), collect(&farmers))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "validation error for record 0")
+
+	_, err = parser.StreamJSON(strings.NewReader(`"farmers"`), collect(&farmers))
+	require.Error(t, err)
+}
+
+func TestStreamWithMapping(t *testing.T) {
+	parser := NewStreamingFileParser(DefaultStreamingParserConfig())
+	profile := &bulk.ColumnMappingProfile{Mappings: []bulk.ColumnMapping{
+		{Target: "first_name", Source: "Farmer Name"},
+		{Target: "last_name", Source: "Surname"},
+		{Target: "phone_number", Source: "Mobile No"},
+	}}
+
+	csvData := "Farmer Name,Surname,Mobile No\nJohn,Doe,9876543210\n"
+
+	var farmers []*requests.FarmerBulkData
+	count, err := parser.StreamWithMapping("csv", strings.NewReader(csvData), profile, collect(&farmers), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, "9876543210", farmers[0].PhoneNumber)
+}
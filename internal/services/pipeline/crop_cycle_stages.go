@@ -0,0 +1,449 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Kisanlink/farmers-module/internal/entities"
+	"github.com/Kisanlink/farmers-module/internal/entities/crop"
+	"github.com/Kisanlink/farmers-module/internal/entities/crop_cycle"
+	"github.com/Kisanlink/farmers-module/internal/entities/crop_variety"
+	"github.com/Kisanlink/farmers-module/internal/entities/farm"
+	"github.com/Kisanlink/farmers-module/internal/entities/farm_activity"
+	"github.com/Kisanlink/farmers-module/internal/entities/requests"
+	"github.com/Kisanlink/farmers-module/internal/interfaces"
+	"github.com/Kisanlink/farmers-module/pkg/common"
+	"go.uber.org/zap"
+)
+
+// cropCycleRecordKey is the metadata key under which the crop cycle stages share their record
+const cropCycleRecordKey = "crop_cycle_record"
+
+// cropCycleSeasons are the seasons accepted by crop cycles
+var cropCycleSeasons = map[string]bool{"RABI": true, "KHARIF": true, "ZAID": true, "PERENNIAL": true, "OTHER": true}
+
+// cropCycleDateFormats are the date layouts accepted in crop cycle imports
+var cropCycleDateFormats = []string{"2006-01-02", "02/01/2006", "02-01-2006", "2006/01/02"}
+
+// CropMasterDataInterface resolves crop and variety names against the crop master data
+type CropMasterDataInterface interface {
+	FindCropByName(ctx context.Context, name string) (*crop.Crop, error)
+	FindVarietyByName(ctx context.Context, cropID, name string) (*crop_variety.CropVariety, error)
+}
+
+// CropCycleStoreInterface reads and writes the farms, crop cycles and activities of an import
+type CropCycleStoreInterface interface {
+	GetFarm(ctx context.Context, farmID string) (*farm.Farm, error)
+	// FindCropCycle returns the planned or active cycle of a crop sown on the given date, or nil
+	FindCropCycle(ctx context.Context, farmID, cropID, season string, startDate time.Time) (*crop_cycle.CropCycle, error)
+	ValidateAreaAllocation(ctx context.Context, farmID, cycleID string, areaHa float64) error
+	CreateCropCycle(ctx context.Context, cycle *crop_cycle.CropCycle) error
+	CreateFarmActivity(ctx context.Context, activity *farm_activity.FarmActivity) error
+}
+
+// CropCycleRecord is the interpreted form of a crop cycle import row. The validation stage
+// creates it and the later stages fill in what they resolve or create.
+type CropCycleRecord struct {
+	Season       string
+	AreaHa       *float64
+	SowingDate   time.Time
+	ActivityType string
+	ActivityDate *time.Time
+	CompletedAt  *time.Time
+
+	Crop         *crop.Crop
+	Variety      *crop_variety.CropVariety
+	Farm         *farm.Farm
+	Cycle        *crop_cycle.CropCycle
+	CycleCreated bool
+	Activity     *farm_activity.FarmActivity
+}
+
+// GetCropCycleRecord returns the record shared by the crop cycle stages
+func GetCropCycleRecord(procCtx *ProcessingContext) (*CropCycleRecord, bool) {
+	value, exists := procCtx.GetMetadata(cropCycleRecordKey)
+	if !exists {
+		return nil, false
+	}
+	record, ok := value.(*CropCycleRecord)
+	return record, ok
+}
+
+// cropCycleStageData returns the row and record a crop cycle stage works on
+func cropCycleStageData(data interface{}, stageName string) (*ProcessingContext, *requests.CropCycleBulkData, *CropCycleRecord, error) {
+	procCtx, ok := data.(*ProcessingContext)
+	if !ok {
+		return nil, nil, nil, fmt.Errorf("invalid data type for %s stage", stageName)
+	}
+
+	// The row is carried in the generic record slot of the processing context
+	row, ok := procCtx.FarmerData.(*requests.CropCycleBulkData)
+	if !ok {
+		return nil, nil, nil, fmt.Errorf("invalid crop cycle data type")
+	}
+
+	record, _ := GetCropCycleRecord(procCtx)
+	return procCtx, row, record, nil
+}
+
+// cropCycleError reports a record failure with an error code. The pipeline fills in the
+// stage index and duration.
+func cropCycleError(stageName, code, message string, err error) error {
+	if err != nil {
+		message = fmt.Sprintf("%s: %v", message, err)
+	}
+	return NewPipelineError(stageName, 0, code, message, false, 0, err)
+}
+
+// CropCycleValidationStage validates a crop cycle import row and interprets its values
+type CropCycleValidationStage struct {
+	*BasePipelineStage
+	defaultSeason string
+}
+
+// NewCropCycleValidationStage creates a new crop cycle validation stage. The default season
+// is used for rows without one.
+func NewCropCycleValidationStage(defaultSeason string, logger interfaces.Logger) PipelineStage {
+	return &CropCycleValidationStage{
+		BasePipelineStage: NewBasePipelineStage("crop_cycle_validation", 10*time.Second, false, logger),
+		defaultSeason:     defaultSeason,
+	}
+}
+
+// Process validates the row and stores the interpreted record
+func (cvs *CropCycleValidationStage) Process(ctx context.Context, data interface{}) (interface{}, error) {
+	procCtx, row, _, err := cropCycleStageData(data, cvs.name)
+	if err != nil {
+		return nil, err
+	}
+
+	cvs.logger.Debug("Validating crop cycle data",
+		zap.String("operation_id", procCtx.OperationID),
+		zap.Int("record_index", procCtx.RecordIndex),
+		zap.String("farm_id", row.FarmID),
+	)
+
+	if row.FarmID == "" {
+		return nil, cropCycleError(cvs.name, "REQUIRED_FIELD", "farm_id is required", nil)
+	}
+	if row.Crop == "" {
+		return nil, cropCycleError(cvs.name, "REQUIRED_FIELD", "crop is required", nil)
+	}
+
+	record := &CropCycleRecord{}
+
+	record.Season = strings.ToUpper(row.Season)
+	if record.Season == "" {
+		record.Season = strings.ToUpper(cvs.defaultSeason)
+	}
+	if record.Season == "" {
+		return nil, cropCycleError(cvs.name, "REQUIRED_FIELD", "season is required", nil)
+	}
+	if !cropCycleSeasons[record.Season] {
+		return nil, cropCycleError(cvs.name, "INVALID_FORMAT", fmt.Sprintf("invalid season %q (must be RABI, KHARIF, ZAID, PERENNIAL or OTHER)", row.Season), nil)
+	}
+
+	if row.AreaHa != "" {
+		area, err := strconv.ParseFloat(row.AreaHa, 64)
+		if err != nil || area <= 0 {
+			return nil, cropCycleError(cvs.name, "INVALID_FORMAT", fmt.Sprintf("invalid area_ha %q (must be a positive number of hectares)", row.AreaHa), nil)
+		}
+		record.AreaHa = &area
+	}
+
+	if row.SowingDate == "" {
+		return nil, cropCycleError(cvs.name, "REQUIRED_FIELD", "sowing_date is required", nil)
+	}
+	sowingDate, ok := parseCropCycleDate(row.SowingDate)
+	if !ok {
+		return nil, cropCycleError(cvs.name, "INVALID_FORMAT", fmt.Sprintf("invalid sowing_date %q", row.SowingDate), nil)
+	}
+	record.SowingDate = sowingDate
+
+	record.ActivityType = strings.ToUpper(strings.Join(strings.Fields(row.ActivityType), "_"))
+	if record.ActivityType == "" && (row.ActivityDate != "" || row.CompletedDate != "") {
+		return nil, cropCycleError(cvs.name, "REQUIRED_FIELD", "activity_type is required when an activity date is given", nil)
+	}
+	if row.ActivityDate != "" {
+		activityDate, ok := parseCropCycleDate(row.ActivityDate)
+		if !ok {
+			return nil, cropCycleError(cvs.name, "INVALID_FORMAT", fmt.Sprintf("invalid activity_date %q", row.ActivityDate), nil)
+		}
+		record.ActivityDate = &activityDate
+	}
+	if row.CompletedDate != "" {
+		completedAt, ok := parseCropCycleDate(row.CompletedDate)
+		if !ok {
+			return nil, cropCycleError(cvs.name, "INVALID_FORMAT", fmt.Sprintf("invalid completed_date %q", row.CompletedDate), nil)
+		}
+		record.CompletedAt = &completedAt
+	}
+
+	procCtx.SetMetadata(cropCycleRecordKey, record)
+	procCtx.SetStageResult(cvs.name, map[string]interface{}{
+		"status":       "success",
+		"validated_at": time.Now(),
+	})
+
+	return procCtx, nil
+}
+
+func parseCropCycleDate(value string) (time.Time, bool) {
+	for _, layout := range cropCycleDateFormats {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// CropResolutionStage resolves the crop and variety names of a row against the master data
+type CropResolutionStage struct {
+	*BasePipelineStage
+	masterData CropMasterDataInterface
+}
+
+// NewCropResolutionStage creates a new crop resolution stage
+func NewCropResolutionStage(masterData CropMasterDataInterface, logger interfaces.Logger) PipelineStage {
+	return &CropResolutionStage{
+		BasePipelineStage: NewBasePipelineStage("crop_resolution", 10*time.Second, true, logger),
+		masterData:        masterData,
+	}
+}
+
+// Process resolves the crop and variety of the row
+func (crs *CropResolutionStage) Process(ctx context.Context, data interface{}) (interface{}, error) {
+	procCtx, row, record, err := cropCycleStageData(data, crs.name)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil {
+		return nil, fmt.Errorf("crop cycle validation result not found")
+	}
+
+	cropEntity, err := crs.masterData.FindCropByName(ctx, row.Crop)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve crop: %w", err)
+	}
+	if cropEntity == nil {
+		return nil, cropCycleError(crs.name, "CROP_NOT_FOUND", fmt.Sprintf("crop %q not found in crop master data", row.Crop), nil)
+	}
+	record.Crop = cropEntity
+
+	if row.Variety != "" {
+		variety, err := crs.masterData.FindVarietyByName(ctx, cropEntity.ID, row.Variety)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve variety: %w", err)
+		}
+		if variety == nil {
+			return nil, cropCycleError(crs.name, "VARIETY_NOT_FOUND", fmt.Sprintf("variety %q not found for crop %s", row.Variety, cropEntity.Name), nil)
+		}
+		record.Variety = variety
+	}
+
+	result := map[string]interface{}{"crop_id": cropEntity.ID}
+	if record.Variety != nil {
+		result["variety_id"] = record.Variety.ID
+	}
+	procCtx.SetStageResult(crs.name, result)
+
+	return procCtx, nil
+}
+
+// AreaAllocationStage checks that the farm belongs to the FPO and that a new crop cycle fits
+// in the farm's unallocated area. Rows that belong to an existing cycle allocate nothing.
+type AreaAllocationStage struct {
+	*BasePipelineStage
+	store CropCycleStoreInterface
+}
+
+// NewAreaAllocationStage creates a new area allocation stage
+func NewAreaAllocationStage(store CropCycleStoreInterface, logger interfaces.Logger) PipelineStage {
+	return &AreaAllocationStage{
+		BasePipelineStage: NewBasePipelineStage("area_allocation", 15*time.Second, true, logger),
+		store:             store,
+	}
+}
+
+// Process resolves the farm and existing cycle of the row and validates its area
+func (aas *AreaAllocationStage) Process(ctx context.Context, data interface{}) (interface{}, error) {
+	procCtx, row, record, err := cropCycleStageData(data, aas.name)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil || record.Crop == nil {
+		return nil, fmt.Errorf("crop resolution result not found")
+	}
+
+	farmEntity, err := aas.store.GetFarm(ctx, row.FarmID)
+	if err != nil && !errors.Is(err, common.ErrNotFound) {
+		return nil, fmt.Errorf("failed to get farm: %w", err)
+	}
+	if farmEntity == nil || farmEntity.AAAOrgID != procCtx.FPOOrgID {
+		return nil, cropCycleError(aas.name, "FARM_NOT_FOUND", fmt.Sprintf("farm %s not found in the FPO", row.FarmID), nil)
+	}
+	record.Farm = farmEntity
+
+	cycle, err := aas.store.FindCropCycle(ctx, farmEntity.ID, record.Crop.ID, record.Season, record.SowingDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find crop cycle: %w", err)
+	}
+	if cycle != nil {
+		record.Cycle = cycle
+		procCtx.SetStageResult(aas.name, map[string]interface{}{
+			"crop_cycle_id": cycle.ID,
+			"existing":      true,
+		})
+		return procCtx, nil
+	}
+
+	if record.AreaHa != nil {
+		if err := aas.store.ValidateAreaAllocation(ctx, farmEntity.ID, "", *record.AreaHa); err != nil {
+			var exceeded *common.AreaExceededError
+			if errors.As(err, &exceeded) {
+				return nil, cropCycleError(aas.name, "AREA_EXCEEDED", "area allocation exceeded", err)
+			}
+			return nil, fmt.Errorf("failed to validate area allocation: %w", err)
+		}
+	}
+
+	procCtx.SetStageResult(aas.name, map[string]interface{}{
+		"farm_id":  farmEntity.ID,
+		"existing": false,
+	})
+
+	return procCtx, nil
+}
+
+// CropCycleCreationStage creates the crop cycle of a row unless it already exists
+type CropCycleCreationStage struct {
+	*BasePipelineStage
+	store CropCycleStoreInterface
+}
+
+// NewCropCycleCreationStage creates a new crop cycle creation stage
+func NewCropCycleCreationStage(store CropCycleStoreInterface, logger interfaces.Logger) PipelineStage {
+	return &CropCycleCreationStage{
+		BasePipelineStage: NewBasePipelineStage("crop_cycle_creation", 15*time.Second, true, logger),
+		store:             store,
+	}
+}
+
+// Process creates the crop cycle
+func (ccs *CropCycleCreationStage) Process(ctx context.Context, data interface{}) (interface{}, error) {
+	procCtx, _, record, err := cropCycleStageData(data, ccs.name)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil || record.Farm == nil {
+		return nil, fmt.Errorf("area allocation result not found")
+	}
+
+	if record.Cycle == nil {
+		sowingDate := record.SowingDate
+		cycle := &crop_cycle.CropCycle{
+			FarmID:    record.Farm.ID,
+			FarmerID:  record.Farm.FarmerID,
+			AreaHa:    record.AreaHa,
+			Season:    record.Season,
+			Status:    "PLANNED",
+			StartDate: &sowingDate,
+			CropID:    record.Crop.ID,
+			Outcome:   make(entities.JSONB),
+		}
+		if record.Variety != nil {
+			cycle.VarietyID = &record.Variety.ID
+		}
+
+		if err := cycle.Validate(); err != nil {
+			return nil, cropCycleError(ccs.name, "INVALID_INPUT", "invalid crop cycle", err)
+		}
+		if err := ccs.store.CreateCropCycle(ctx, cycle); err != nil {
+			return nil, fmt.Errorf("failed to create crop cycle: %w", err)
+		}
+
+		ccs.logger.Debug("Created crop cycle",
+			zap.String("operation_id", procCtx.OperationID),
+			zap.Int("record_index", procCtx.RecordIndex),
+			zap.String("crop_cycle_id", cycle.ID),
+		)
+
+		record.Cycle = cycle
+		record.CycleCreated = true
+	}
+
+	procCtx.SetStageResult(ccs.name, map[string]interface{}{
+		"crop_cycle_id": record.Cycle.ID,
+		"created":       record.CycleCreated,
+	})
+
+	return procCtx, nil
+}
+
+// FarmActivityCreationStage adds the activity of a row to its crop cycle. Rows without an
+// activity type pass through unchanged.
+type FarmActivityCreationStage struct {
+	*BasePipelineStage
+	store CropCycleStoreInterface
+}
+
+// NewFarmActivityCreationStage creates a new farm activity creation stage
+func NewFarmActivityCreationStage(store CropCycleStoreInterface, logger interfaces.Logger) PipelineStage {
+	return &FarmActivityCreationStage{
+		BasePipelineStage: NewBasePipelineStage("farm_activity_creation", 15*time.Second, true, logger),
+		store:             store,
+	}
+}
+
+// Process creates the farm activity
+func (fas *FarmActivityCreationStage) Process(ctx context.Context, data interface{}) (interface{}, error) {
+	procCtx, row, record, err := cropCycleStageData(data, fas.name)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil || record.Cycle == nil {
+		return nil, fmt.Errorf("crop cycle creation result not found")
+	}
+	if record.ActivityType == "" {
+		return procCtx, nil
+	}
+
+	activity := &farm_activity.FarmActivity{
+		CropCycleID:  record.Cycle.ID,
+		FarmerID:     record.Cycle.FarmerID,
+		ActivityType: record.ActivityType,
+		PlannedAt:    record.ActivityDate,
+		CreatedBy:    procCtx.UserID,
+		Status:       "PLANNED",
+		Output:       make(entities.JSONB),
+		Metadata: entities.JSONB{
+			"import_operation_id": procCtx.OperationID,
+			"import_record_index": procCtx.RecordIndex,
+		},
+	}
+	if record.CompletedAt != nil {
+		activity.Status = "COMPLETED"
+		activity.CompletedAt = record.CompletedAt
+	}
+	if row.Notes != "" {
+		activity.Metadata["notes"] = row.Notes
+	}
+
+	if err := activity.Validate(); err != nil {
+		return nil, cropCycleError(fas.name, "INVALID_INPUT", "invalid farm activity", err)
+	}
+	if err := fas.store.CreateFarmActivity(ctx, activity); err != nil {
+		return nil, fmt.Errorf("failed to create farm activity: %w", err)
+	}
+
+	record.Activity = activity
+	procCtx.SetStageResult(fas.name, map[string]interface{}{
+		"activity_id": activity.ID,
+	})
+
+	return procCtx, nil
+}
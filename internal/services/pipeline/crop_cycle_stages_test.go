@@ -0,0 +1,215 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/crop"
+	"github.com/Kisanlink/farmers-module/internal/entities/crop_cycle"
+	"github.com/Kisanlink/farmers-module/internal/entities/crop_variety"
+	"github.com/Kisanlink/farmers-module/internal/entities/farm"
+	"github.com/Kisanlink/farmers-module/internal/entities/farm_activity"
+	"github.com/Kisanlink/farmers-module/internal/entities/requests"
+	"github.com/Kisanlink/farmers-module/pkg/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// stubCropCycleStore implements CropMasterDataInterface and CropCycleStoreInterface in memory
+type stubCropCycleStore struct {
+	crops       map[string]*crop.Crop
+	varieties   map[string]*crop_variety.CropVariety
+	farms       map[string]*farm.Farm
+	cycles      []*crop_cycle.CropCycle
+	activities  []*farm_activity.FarmActivity
+	availableHa float64
+}
+
+func newStubCropCycleStore() *stubCropCycleStore {
+	paddy := &crop.Crop{Name: "Paddy"}
+	paddy.ID = "CROP00000001"
+	variety := &crop_variety.CropVariety{CropID: paddy.ID, Name: "IR-64"}
+	variety.ID = "CRPV00000001"
+	farmEntity := &farm.Farm{FarmerID: "FMRR00000001", AAAOrgID: "fpo_123"}
+	farmEntity.ID = "FARM00000001"
+
+	return &stubCropCycleStore{
+		crops:       map[string]*crop.Crop{"paddy": paddy},
+		varieties:   map[string]*crop_variety.CropVariety{"ir-64": variety},
+		farms:       map[string]*farm.Farm{farmEntity.ID: farmEntity},
+		availableHa: 2,
+	}
+}
+
+func (s *stubCropCycleStore) FindCropByName(ctx context.Context, name string) (*crop.Crop, error) {
+	return s.crops[strings.ToLower(name)], nil
+}
+
+func (s *stubCropCycleStore) FindVarietyByName(ctx context.Context, cropID, name string) (*crop_variety.CropVariety, error) {
+	if variety, ok := s.varieties[strings.ToLower(name)]; ok && variety.CropID == cropID {
+		return variety, nil
+	}
+	return nil, nil
+}
+
+func (s *stubCropCycleStore) GetFarm(ctx context.Context, farmID string) (*farm.Farm, error) {
+	if farmEntity, ok := s.farms[farmID]; ok {
+		return farmEntity, nil
+	}
+	return nil, common.ErrNotFound
+}
+
+func (s *stubCropCycleStore) FindCropCycle(ctx context.Context, farmID, cropID, season string, startDate time.Time) (*crop_cycle.CropCycle, error) {
+	for _, cycle := range s.cycles {
+		if cycle.FarmID == farmID && cycle.CropID == cropID && cycle.Season == season && cycle.StartDate.Equal(startDate) {
+			return cycle, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *stubCropCycleStore) ValidateAreaAllocation(ctx context.Context, farmID, cycleID string, areaHa float64) error {
+	if areaHa > s.availableHa {
+		return &common.AreaExceededError{FarmID: farmID, FarmArea: 2, RequestedArea: areaHa, AvailableArea: s.availableHa}
+	}
+	return nil
+}
+
+func (s *stubCropCycleStore) CreateCropCycle(ctx context.Context, cycle *crop_cycle.CropCycle) error {
+	cycle.ID = "CRCY0000000" + string(rune('1'+len(s.cycles)))
+	s.cycles = append(s.cycles, cycle)
+	if cycle.AreaHa != nil {
+		s.availableHa -= *cycle.AreaHa
+	}
+	return nil
+}
+
+func (s *stubCropCycleStore) CreateFarmActivity(ctx context.Context, activity *farm_activity.FarmActivity) error {
+	activity.ID = "FACT0000000" + string(rune('1'+len(s.activities)))
+	s.activities = append(s.activities, activity)
+	return nil
+}
+
+func newCropCyclePipeline(store *stubCropCycleStore, defaultSeason string) ProcessingPipeline {
+	logger := newTestLogger()
+	logger.On("Error", mock.Anything, mock.Anything).Return()
+
+	return NewPipeline(logger).
+		AddStage(NewCropCycleValidationStage(defaultSeason, logger)).
+		AddStage(NewCropResolutionStage(store, logger)).
+		AddStage(NewAreaAllocationStage(store, logger)).
+		AddStage(NewCropCycleCreationStage(store, logger)).
+		AddStage(NewFarmActivityCreationStage(store, logger))
+}
+
+func runCropCycleRow(t *testing.T, pipe ProcessingPipeline, index int, row *requests.CropCycleBulkData) (*ProcessingContext, error) {
+	t.Helper()
+	procCtx := NewProcessingContext("BLKO00000001", "fpo_123", "user_123", index, row)
+	_, err := pipe.Execute(context.Background(), procCtx)
+	return procCtx, err
+}
+
+func TestCropCyclePipeline_CreatesCycleAndActivity(t *testing.T) {
+	store := newStubCropCycleStore()
+	pipe := newCropCyclePipeline(store, "KHARIF")
+
+	procCtx, err := runCropCycleRow(t, pipe, 0, &requests.CropCycleBulkData{
+		FarmID: "FARM00000001", Crop: "paddy", Variety: "ir-64", AreaHa: "1.5", SowingDate: "20/06/2024",
+		ActivityType: "land preparation", CompletedDate: "2024-06-15", Notes: "Tractor",
+	})
+	require.NoError(t, err)
+
+	record, ok := GetCropCycleRecord(procCtx)
+	require.True(t, ok)
+	assert.True(t, record.CycleCreated)
+
+	require.Len(t, store.cycles, 1)
+	cycle := store.cycles[0]
+	assert.Equal(t, "KHARIF", cycle.Season, "the default season applies to rows without one")
+	assert.Equal(t, "FMRR00000001", cycle.FarmerID)
+	assert.Equal(t, "CROP00000001", cycle.CropID)
+	require.NotNil(t, cycle.VarietyID)
+	assert.Equal(t, "CRPV00000001", *cycle.VarietyID)
+	assert.Equal(t, time.Date(2024, 6, 20, 0, 0, 0, 0, time.UTC), *cycle.StartDate)
+
+	require.Len(t, store.activities, 1)
+	activity := store.activities[0]
+	assert.Equal(t, "LAND_PREPARATION", activity.ActivityType)
+	assert.Equal(t, "COMPLETED", activity.Status)
+	assert.Equal(t, cycle.ID, activity.CropCycleID)
+	assert.Equal(t, "user_123", activity.CreatedBy)
+	assert.Equal(t, "Tractor", activity.Metadata["notes"])
+}
+
+func TestCropCyclePipeline_SharesCycleBetweenRows(t *testing.T) {
+	store := newStubCropCycleStore()
+	pipe := newCropCyclePipeline(store, "")
+
+	for i, activityType := range []string{"SOWING", "IRRIGATION"} {
+		_, err := runCropCycleRow(t, pipe, i, &requests.CropCycleBulkData{
+			FarmID: "FARM00000001", Crop: "Paddy", Season: "kharif", AreaHa: "1.5", SowingDate: "2024-06-20",
+			ActivityType: activityType,
+		})
+		require.NoError(t, err)
+	}
+
+	assert.Len(t, store.cycles, 1, "the second row reuses the cycle and allocates no area")
+	require.Len(t, store.activities, 2)
+	assert.Equal(t, store.activities[0].CropCycleID, store.activities[1].CropCycleID)
+}
+
+func TestCropCyclePipeline_ErrorCodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		row      *requests.CropCycleBulkData
+		wantCode string
+	}{
+		{
+			name:     "missing season",
+			row:      &requests.CropCycleBulkData{FarmID: "FARM00000001", Crop: "Paddy", SowingDate: "2024-06-20"},
+			wantCode: "REQUIRED_FIELD",
+		},
+		{
+			name:     "invalid area",
+			row:      &requests.CropCycleBulkData{FarmID: "FARM00000001", Crop: "Paddy", Season: "KHARIF", AreaHa: "-1", SowingDate: "2024-06-20"},
+			wantCode: "INVALID_FORMAT",
+		},
+		{
+			name:     "unknown crop",
+			row:      &requests.CropCycleBulkData{FarmID: "FARM00000001", Crop: "Quinoa", Season: "KHARIF", SowingDate: "2024-06-20"},
+			wantCode: "CROP_NOT_FOUND",
+		},
+		{
+			name:     "unknown variety",
+			row:      &requests.CropCycleBulkData{FarmID: "FARM00000001", Crop: "Paddy", Variety: "Basmati", Season: "KHARIF", SowingDate: "2024-06-20"},
+			wantCode: "VARIETY_NOT_FOUND",
+		},
+		{
+			name:     "farm of another FPO",
+			row:      &requests.CropCycleBulkData{FarmID: "FARM00000009", Crop: "Paddy", Season: "KHARIF", SowingDate: "2024-06-20"},
+			wantCode: "FARM_NOT_FOUND",
+		},
+		{
+			name:     "area exceeds the farm",
+			row:      &requests.CropCycleBulkData{FarmID: "FARM00000001", Crop: "Paddy", Season: "KHARIF", AreaHa: "3", SowingDate: "2024-06-20"},
+			wantCode: "AREA_EXCEEDED",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newStubCropCycleStore()
+			_, err := runCropCycleRow(t, newCropCyclePipeline(store, ""), 0, tt.row)
+			require.Error(t, err)
+
+			var stageErr *PipelineError
+			require.True(t, errors.As(err, &stageErr))
+			assert.Equal(t, tt.wantCode, stageErr.ErrorCode)
+			assert.Empty(t, store.cycles)
+		})
+	}
+}
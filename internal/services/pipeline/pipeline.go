@@ -2,6 +2,7 @@ package pipeline
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -25,6 +26,63 @@ type PipelineStage interface {
 	GetTimeout() time.Duration
 }
 
+// RecordChecker is implemented by stages that can evaluate a record without side effects.
+// The bulk service uses it to run a pipeline as a dry run.
+type RecordChecker interface {
+	Check(ctx context.Context, procCtx *ProcessingContext) (*CheckResult, error)
+}
+
+// Duplicate sources reported by CheckResult
+const (
+	DuplicateSourceFile = "file"
+	DuplicateSourceFPO  = "fpo"
+)
+
+// ValidationIssue describes a single problem found in a record
+type ValidationIssue struct {
+	Field   string      `json:"field"`
+	Value   interface{} `json:"value,omitempty"`
+	Message string      `json:"message"`
+	Code    string      `json:"code"`
+}
+
+// DuplicateMatch describes an earlier occurrence of the same farmer
+type DuplicateMatch struct {
+	Source      string `json:"source"` // file, fpo
+	Field       string `json:"field"`
+	Value       string `json:"value"`
+	RecordIndex *int   `json:"record_index,omitempty"` // first occurrence in the same file
+	FarmerID    string `json:"farmer_id,omitempty"`    // existing farmer in the FPO
+	AAAUserID   string `json:"aaa_user_id,omitempty"`
+}
+
+// CheckResult collects the outcome of RecordChecker.Check
+type CheckResult struct {
+	Errors     []ValidationIssue `json:"errors,omitempty"`
+	Warnings   []ValidationIssue `json:"warnings,omitempty"`
+	Duplicates []DuplicateMatch  `json:"duplicates,omitempty"`
+}
+
+// AddError records a blocking issue
+func (cr *CheckResult) AddError(field string, value interface{}, message, code string) {
+	cr.Errors = append(cr.Errors, ValidationIssue{Field: field, Value: value, Message: message, Code: code})
+}
+
+// AddWarning records a non-blocking issue
+func (cr *CheckResult) AddWarning(field, message, code string) {
+	cr.Warnings = append(cr.Warnings, ValidationIssue{Field: field, Message: message, Code: code})
+}
+
+// Merge appends the issues of another result
+func (cr *CheckResult) Merge(other *CheckResult) {
+	if other == nil {
+		return
+	}
+	cr.Errors = append(cr.Errors, other.Errors...)
+	cr.Warnings = append(cr.Warnings, other.Warnings...)
+	cr.Duplicates = append(cr.Duplicates, other.Duplicates...)
+}
+
 // PipelineImpl implements ProcessingPipeline
 type PipelineImpl struct {
 	stages []PipelineStage
@@ -72,6 +130,13 @@ func (p *PipelineImpl) Execute(ctx context.Context, data interface{}) (interface
 		duration := time.Since(startTime)
 
 		if err != nil {
+			// Stages report their error code without knowing where they run in the pipeline
+			var stageErr *PipelineError
+			if errors.As(err, &stageErr) {
+				stageErr.StageIndex = i
+				stageErr.Duration = duration
+			}
+
 			p.logger.Error("Pipeline stage failed",
 				zap.Int("stage_index", i),
 				zap.String("stage_name", stage.GetName()),
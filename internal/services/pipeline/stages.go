@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/Kisanlink/farmers-module/internal/entities/requests"
@@ -65,6 +66,57 @@ func (vs *ValidationStage) Process(ctx context.Context, data interface{}) (inter
 	return procCtx, nil
 }
 
+// Check evaluates the record against the same rules as Process and reports every issue found
+// instead of stopping at the first failing rule
+func (vs *ValidationStage) Check(ctx context.Context, procCtx *ProcessingContext) (*CheckResult, error) {
+	farmerData, ok := procCtx.FarmerData.(*requests.FarmerBulkData)
+	if !ok {
+		return nil, fmt.Errorf("invalid farmer data type")
+	}
+
+	result := &CheckResult{}
+
+	// Required fields
+	if farmerData.FirstName == "" {
+		result.AddError("first_name", nil, "first_name is required", "REQUIRED_FIELD")
+	}
+	if farmerData.LastName == "" {
+		result.AddError("last_name", nil, "last_name is required", "REQUIRED_FIELD")
+	}
+	if farmerData.PhoneNumber == "" {
+		result.AddError("phone_number", nil, "phone_number is required", "REQUIRED_FIELD")
+	}
+
+	// Field formats
+	if farmerData.PhoneNumber != "" && !vs.isValidPhoneNumber(farmerData.PhoneNumber) {
+		result.AddError("phone_number", farmerData.PhoneNumber, "invalid phone number format", "INVALID_FORMAT")
+	}
+	if farmerData.Email != "" && !vs.isValidEmail(farmerData.Email) {
+		result.AddError("email", farmerData.Email, "invalid email format", "INVALID_FORMAT")
+	}
+	if farmerData.Gender != "" && !vs.isValidGender(farmerData.Gender) {
+		result.AddError("gender", farmerData.Gender, "invalid gender (must be male, female, or other)", "INVALID_FORMAT")
+	}
+
+	// Values that are accepted today but are likely to be stored incorrectly
+	if farmerData.DateOfBirth != "" {
+		if _, err := time.Parse("2006-01-02", farmerData.DateOfBirth); err != nil {
+			result.AddWarning("date_of_birth", "date_of_birth is not in YYYY-MM-DD format", "UNEXPECTED_FORMAT")
+		}
+	}
+	if len(farmerData.FirstName) == 1 {
+		result.AddWarning("first_name", "first_name has a single character", "SHORT_VALUE")
+	}
+	if len(farmerData.LastName) == 1 {
+		result.AddWarning("last_name", "last_name has a single character", "SHORT_VALUE")
+	}
+	if farmerData.Email == "" {
+		result.AddWarning("email", "email is not provided, credentials can only be sent by SMS", "MISSING_OPTIONAL")
+	}
+
+	return result, nil
+}
+
 func (vs *ValidationStage) validateRequiredFields(farmer *requests.FarmerBulkData) error {
 	var errors []string
 
@@ -149,6 +201,7 @@ type FarmerServiceInterface interface {
 	CreateFarmer(ctx context.Context, req *requests.CreateFarmerRequest) (*responses.FarmerResponse, error)
 	GetFarmerByUserID(ctx context.Context, aaaUserID string) (*responses.FarmerResponse, error)
 	UpdateFarmer(ctx context.Context, req *requests.UpdateFarmerRequest) (*responses.FarmerResponse, error)
+	ListFarmers(ctx context.Context, req *requests.ListFarmersRequest) (*responses.FarmerListResponse, error)
 }
 
 // AAAServiceInterface defines the interface for AAA service used by pipeline
@@ -168,6 +221,12 @@ type FarmerLinkageServiceInterface interface {
 type DeduplicationStage struct {
 	*BasePipelineStage
 	farmerService FarmerServiceInterface
+
+	// seen tracks phone numbers and external IDs of records already checked by this
+	// stage instance, so a single stage can detect duplicates within one upload
+	mu              sync.Mutex
+	seenPhones      map[string]int
+	seenExternalIDs map[string]int
 }
 
 // NewDeduplicationStage creates a new deduplication stage
@@ -175,9 +234,75 @@ func NewDeduplicationStage(farmerService FarmerServiceInterface, logger interfac
 	return &DeduplicationStage{
 		BasePipelineStage: NewBasePipelineStage("deduplication", 10*time.Second, true, logger),
 		farmerService:     farmerService,
+		seenPhones:        make(map[string]int),
+		seenExternalIDs:   make(map[string]int),
 	}
 }
 
+// Check reports duplicates of the record without writing anything: earlier rows of the
+// same upload with the same phone number or external ID, and farmers already linked to the FPO
+func (ds *DeduplicationStage) Check(ctx context.Context, procCtx *ProcessingContext) (*CheckResult, error) {
+	farmerData, ok := procCtx.FarmerData.(*requests.FarmerBulkData)
+	if !ok {
+		return nil, fmt.Errorf("invalid farmer data type")
+	}
+
+	result := &CheckResult{}
+
+	ds.mu.Lock()
+	if farmerData.PhoneNumber != "" {
+		if firstIndex, exists := ds.seenPhones[farmerData.PhoneNumber]; exists {
+			result.Duplicates = append(result.Duplicates, DuplicateMatch{
+				Source:      DuplicateSourceFile,
+				Field:       "phone_number",
+				Value:       farmerData.PhoneNumber,
+				RecordIndex: &firstIndex,
+			})
+		} else {
+			ds.seenPhones[farmerData.PhoneNumber] = procCtx.RecordIndex
+		}
+	}
+	if farmerData.ExternalID != "" {
+		if firstIndex, exists := ds.seenExternalIDs[farmerData.ExternalID]; exists {
+			result.Duplicates = append(result.Duplicates, DuplicateMatch{
+				Source:      DuplicateSourceFile,
+				Field:       "external_id",
+				Value:       farmerData.ExternalID,
+				RecordIndex: &firstIndex,
+			})
+		} else {
+			ds.seenExternalIDs[farmerData.ExternalID] = procCtx.RecordIndex
+		}
+	}
+	ds.mu.Unlock()
+
+	if farmerData.PhoneNumber == "" || procCtx.FPOOrgID == "" {
+		return result, nil
+	}
+
+	existing, err := ds.farmerService.ListFarmers(ctx, &requests.ListFarmersRequest{
+		AAAOrgID:    procCtx.FPOOrgID,
+		PhoneNumber: farmerData.PhoneNumber,
+		Page:        1,
+		PageSize:    1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up existing farmers: %w", err)
+	}
+
+	if existing != nil && len(existing.Data) > 0 && existing.Data[0] != nil {
+		result.Duplicates = append(result.Duplicates, DuplicateMatch{
+			Source:    DuplicateSourceFPO,
+			Field:     "phone_number",
+			Value:     farmerData.PhoneNumber,
+			FarmerID:  existing.Data[0].ID,
+			AAAUserID: existing.Data[0].AAAUserID,
+		})
+	}
+
+	return result, nil
+}
+
 // Process checks for duplicate farmers (pre-check stage)
 // Note: The actual duplicate handling is done in the FarmerRegistrationStage
 // based on the deduplication_mode setting (skip, update, error)
@@ -0,0 +1,141 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/requests"
+	"github.com/Kisanlink/farmers-module/internal/entities/responses"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// stubFarmerService implements FarmerServiceInterface for deduplication checks
+type stubFarmerService struct {
+	existingByPhone map[string]*responses.FarmerProfileData
+	listCalls       int
+}
+
+func (s *stubFarmerService) CreateFarmer(ctx context.Context, req *requests.CreateFarmerRequest) (*responses.FarmerResponse, error) {
+	panic("CreateFarmer must not be called during a dry run")
+}
+
+func (s *stubFarmerService) GetFarmerByUserID(ctx context.Context, aaaUserID string) (*responses.FarmerResponse, error) {
+	return nil, nil
+}
+
+func (s *stubFarmerService) UpdateFarmer(ctx context.Context, req *requests.UpdateFarmerRequest) (*responses.FarmerResponse, error) {
+	panic("UpdateFarmer must not be called during a dry run")
+}
+
+func (s *stubFarmerService) ListFarmers(ctx context.Context, req *requests.ListFarmersRequest) (*responses.FarmerListResponse, error) {
+	s.listCalls++
+	resp := &responses.FarmerListResponse{}
+	if farmer, ok := s.existingByPhone[req.PhoneNumber]; ok {
+		resp.Data = []*responses.FarmerProfileData{farmer}
+	}
+	return resp, nil
+}
+
+func newTestLogger() *MockLogger {
+	logger := &MockLogger{}
+	logger.On("Debug", mock.Anything, mock.Anything).Return()
+	return logger
+}
+
+func TestValidationStage_Check(t *testing.T) {
+	stage := NewValidationStage(newTestLogger()).(RecordChecker)
+
+	tests := []struct {
+		name           string
+		farmer         *requests.FarmerBulkData
+		expectErrors   []string
+		expectWarnings []string
+	}{
+		{
+			name: "valid record",
+			farmer: &requests.FarmerBulkData{
+				FirstName: "Ramesh", LastName: "Kumar", PhoneNumber: "9876543210", Email: "ramesh@example.com",
+			},
+		},
+		{
+			name:           "all required fields missing",
+			farmer:         &requests.FarmerBulkData{Email: "ramesh@example.com"},
+			expectErrors:   []string{"first_name", "last_name", "phone_number"},
+			expectWarnings: nil,
+		},
+		{
+			name: "invalid formats reported together",
+			farmer: &requests.FarmerBulkData{
+				FirstName: "Ramesh", LastName: "Kumar", PhoneNumber: "12345", Email: "invalid", Gender: "x",
+			},
+			expectErrors: []string{"phone_number", "email", "gender"},
+		},
+		{
+			name: "warnings do not block",
+			farmer: &requests.FarmerBulkData{
+				FirstName: "R", LastName: "Kumar", PhoneNumber: "9876543210", DateOfBirth: "15/06/1985",
+			},
+			expectWarnings: []string{"date_of_birth", "first_name", "email"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			procCtx := NewProcessingContext("", "fpo_1", "", 0, tt.farmer)
+			result, err := stage.Check(context.Background(), procCtx)
+			require.NoError(t, err)
+
+			var errorFields, warningFields []string
+			for _, issue := range result.Errors {
+				errorFields = append(errorFields, issue.Field)
+			}
+			for _, issue := range result.Warnings {
+				warningFields = append(warningFields, issue.Field)
+			}
+			assert.Equal(t, tt.expectErrors, errorFields)
+			assert.Equal(t, tt.expectWarnings, warningFields)
+		})
+	}
+}
+
+func TestDeduplicationStage_Check(t *testing.T) {
+	farmerService := &stubFarmerService{
+		existingByPhone: map[string]*responses.FarmerProfileData{
+			"9876543299": {ID: "FMRR0000000001", AAAUserID: "USER00000001"},
+		},
+	}
+	stage := NewDeduplicationStage(farmerService, newTestLogger()).(RecordChecker)
+
+	records := []*requests.FarmerBulkData{
+		{PhoneNumber: "9876543210", ExternalID: "EXT-1"},
+		{PhoneNumber: "9876543211", ExternalID: "EXT-1"},
+		{PhoneNumber: "9876543210", ExternalID: "EXT-3"},
+		{PhoneNumber: "9876543299", ExternalID: "EXT-4"},
+	}
+
+	var results []*CheckResult
+	for i, record := range records {
+		result, err := stage.Check(context.Background(), NewProcessingContext("", "fpo_1", "", i, record))
+		require.NoError(t, err)
+		results = append(results, result)
+	}
+
+	assert.Empty(t, results[0].Duplicates)
+
+	require.Len(t, results[1].Duplicates, 1)
+	assert.Equal(t, DuplicateSourceFile, results[1].Duplicates[0].Source)
+	assert.Equal(t, "external_id", results[1].Duplicates[0].Field)
+	assert.Equal(t, 0, *results[1].Duplicates[0].RecordIndex)
+
+	require.Len(t, results[2].Duplicates, 1)
+	assert.Equal(t, "phone_number", results[2].Duplicates[0].Field)
+	assert.Equal(t, 0, *results[2].Duplicates[0].RecordIndex)
+
+	require.Len(t, results[3].Duplicates, 1)
+	assert.Equal(t, DuplicateSourceFPO, results[3].Duplicates[0].Source)
+	assert.Equal(t, "FMRR0000000001", results[3].Duplicates[0].FarmerID)
+
+	assert.Equal(t, len(records), farmerService.listCalls)
+}
@@ -0,0 +1,300 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	cropCycleEntity "github.com/Kisanlink/farmers-module/internal/entities/crop_cycle"
+	farmEntity "github.com/Kisanlink/farmers-module/internal/entities/farm"
+	"github.com/Kisanlink/farmers-module/internal/entities/plot"
+	"github.com/Kisanlink/farmers-module/internal/entities/requests"
+	"github.com/Kisanlink/farmers-module/internal/entities/responses"
+	farmRepo "github.com/Kisanlink/farmers-module/internal/repo/farm"
+	plotRepo "github.com/Kisanlink/farmers-module/internal/repo/plot"
+	"github.com/Kisanlink/farmers-module/pkg/common"
+	"github.com/Kisanlink/farmers-module/pkg/geo"
+	"gorm.io/gorm"
+)
+
+// PlotServiceImpl implements PlotService
+type PlotServiceImpl struct {
+	plotRepo   plotRepo.PlotRepository
+	farmRepo   *farmRepo.FarmRepository
+	aaaService AAAService
+	db         *gorm.DB
+}
+
+// NewPlotService creates a new plot service
+func NewPlotService(plotRepo plotRepo.PlotRepository, farmRepo *farmRepo.FarmRepository, aaaService AAAService, db *gorm.DB) PlotService {
+	return &PlotServiceImpl{
+		plotRepo:   plotRepo,
+		farmRepo:   farmRepo,
+		aaaService: aaaService,
+		db:         db,
+	}
+}
+
+// CreatePlot adds a plot to a farm. Its boundary must lie within the farm's. Plots may
+// overlap one another, for example when a farm is laid out differently from season to
+// season; crop cycles may not be grown on overlapping plots at the same time.
+func (s *PlotServiceImpl) CreatePlot(ctx context.Context, req interface{}) (interface{}, error) {
+	createReq, ok := req.(*requests.CreatePlotRequest)
+	if !ok {
+		return nil, fmt.Errorf("invalid request type for CreatePlot")
+	}
+	if createReq.Geometry == nil {
+		return nil, fmt.Errorf("%w: geometry is required", common.ErrInvalidInput)
+	}
+
+	farm, err := getFarmForAction(ctx, s.farmRepo, s.aaaService, createReq.FarmID, "update")
+	if err != nil {
+		return nil, err
+	}
+
+	p := plot.NewPlot()
+	p.FarmID = farm.ID
+	p.AAAOrgID = farm.AAAOrgID
+	p.Name = createReq.Name
+	p.CreatedBy = createReq.UserID
+	if err := setPlotGeometry(p, farm, createReq.Geometry); err != nil {
+		return nil, err
+	}
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", common.ErrInvalidInput, err)
+	}
+
+	if err := s.plotRepo.Create(ctx, p); err != nil {
+		return nil, err
+	}
+
+	response := responses.NewPlotResponse(convertPlotToData(p), "Plot created successfully")
+	response.SetRequestID(createReq.RequestID)
+	return &response, nil
+}
+
+// UpdatePlot renames a plot and, when a geometry is given, redraws its boundary. A plot
+// with planned or active crop cycles cannot be redrawn, since the cycles were checked
+// against its boundary.
+func (s *PlotServiceImpl) UpdatePlot(ctx context.Context, req interface{}) (interface{}, error) {
+	updateReq, ok := req.(*requests.UpdatePlotRequest)
+	if !ok {
+		return nil, fmt.Errorf("invalid request type for UpdatePlot")
+	}
+
+	farm, err := getFarmForAction(ctx, s.farmRepo, s.aaaService, updateReq.FarmID, "update")
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.getFarmPlot(ctx, farm.ID, updateReq.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	p.Name = updateReq.Name
+	p.UpdatedBy = updateReq.UserID
+	if updateReq.Geometry != nil {
+		if err := s.checkNoOpenCycles(ctx, p, "redrawn"); err != nil {
+			return nil, err
+		}
+		if err := setPlotGeometry(p, farm, updateReq.Geometry); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", common.ErrInvalidInput, err)
+	}
+
+	if err := s.plotRepo.Update(ctx, p); err != nil {
+		return nil, err
+	}
+
+	response := responses.NewPlotResponse(convertPlotToData(p), "Plot updated successfully")
+	response.SetRequestID(updateReq.RequestID)
+	return &response, nil
+}
+
+// DeletePlot removes a plot from a farm. A plot with planned or active crop cycles cannot
+// be removed; the cycles of past seasons keep their plot ID.
+func (s *PlotServiceImpl) DeletePlot(ctx context.Context, req interface{}) error {
+	deleteReq, ok := req.(*requests.DeletePlotRequest)
+	if !ok {
+		return fmt.Errorf("invalid request type for DeletePlot")
+	}
+
+	farm, err := getFarmForAction(ctx, s.farmRepo, s.aaaService, deleteReq.FarmID, "update")
+	if err != nil {
+		return err
+	}
+	p, err := s.getFarmPlot(ctx, farm.ID, deleteReq.ID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkNoOpenCycles(ctx, p, "deleted"); err != nil {
+		return err
+	}
+
+	return s.plotRepo.Delete(ctx, p.ID, deleteReq.UserID)
+}
+
+// ListFarmPlots lists the plots of a farm
+func (s *PlotServiceImpl) ListFarmPlots(ctx context.Context, farmID string) (interface{}, error) {
+	if _, err := getFarmForAction(ctx, s.farmRepo, s.aaaService, farmID, "read"); err != nil {
+		return nil, err
+	}
+
+	plots, err := s.plotRepo.ListByFarm(ctx, farmID)
+	if err != nil {
+		return nil, err
+	}
+	data := make([]*responses.PlotData, len(plots))
+	for i, p := range plots {
+		data[i] = convertPlotToData(p)
+	}
+
+	response := responses.NewPlotListResponse(data, 1, len(data), int64(len(data)))
+	return &response, nil
+}
+
+// GetPlotMap returns the plots of a farm as a GeoJSON FeatureCollection, each with the crop
+// cycles grown on it in a season. Without a season the planned and active cycles are given.
+func (s *PlotServiceImpl) GetPlotMap(ctx context.Context, req interface{}) (interface{}, error) {
+	mapReq, ok := req.(*requests.PlotMapRequest)
+	if !ok {
+		return nil, fmt.Errorf("invalid request type for GetPlotMap")
+	}
+
+	if _, err := getFarmForAction(ctx, s.farmRepo, s.aaaService, mapReq.FarmID, "read"); err != nil {
+		return nil, err
+	}
+	if s.db == nil {
+		return nil, fmt.Errorf("database connection not available")
+	}
+
+	plots, err := s.plotRepo.ListByFarm(ctx, mapReq.FarmID)
+	if err != nil {
+		return nil, err
+	}
+
+	query := s.db.WithContext(ctx).Preload("Crop").
+		Where("farm_id = ? AND plot_id IS NOT NULL AND status != ? AND deleted_at IS NULL", mapReq.FarmID, "CANCELLED")
+	if mapReq.Season != "" {
+		query = query.Where("season = ?", mapReq.Season)
+	} else {
+		query = query.Where("status IN (?)", []string{"PLANNED", "ACTIVE"})
+	}
+	if mapReq.Year != 0 {
+		from := time.Date(mapReq.Year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		query = query.Where("start_date >= ? AND start_date < ?", from, from.AddDate(1, 0, 0))
+	}
+	var cycles []*cropCycleEntity.CropCycle
+	if err := query.Order("start_date, id").Find(&cycles).Error; err != nil {
+		return nil, fmt.Errorf("failed to load crop cycles of plots: %w", err)
+	}
+
+	cyclesByPlot := make(map[string][]*responses.PlotCycleProperties)
+	for _, cycle := range cycles {
+		cyclesByPlot[*cycle.PlotID] = append(cyclesByPlot[*cycle.PlotID], &responses.PlotCycleProperties{
+			CropCycleID: cycle.ID,
+			CropID:      cycle.CropID,
+			CropName:    cycle.GetCropName(),
+			VarietyID:   cycle.VarietyID,
+			Season:      cycle.Season,
+			Status:      cycle.Status,
+			StartDate:   cycle.StartDate,
+			AreaHa:      cycle.AreaHa,
+		})
+	}
+
+	features := make([]*responses.PlotFeature, len(plots))
+	for i, p := range plots {
+		_, geometry := farmGeometry(p.Geometry)
+		if geometry == nil {
+			geometry = json.RawMessage("null")
+		}
+		plotCycles := cyclesByPlot[p.ID]
+		if plotCycles == nil {
+			plotCycles = []*responses.PlotCycleProperties{}
+		}
+		features[i] = &responses.PlotFeature{
+			Type:     "Feature",
+			ID:       p.ID,
+			Geometry: geometry,
+			Properties: &responses.PlotFeatureProperties{
+				FarmID: p.FarmID,
+				Name:   p.Name,
+				AreaHa: p.AreaHa,
+				Cycles: plotCycles,
+			},
+		}
+	}
+
+	return responses.NewPlotFeatureCollection(features), nil
+}
+
+// getFarmPlot returns a plot of the given farm, or common.ErrNotFound
+func (s *PlotServiceImpl) getFarmPlot(ctx context.Context, farmID, plotID string) (*plot.Plot, error) {
+	p, err := s.plotRepo.GetByID(ctx, plotID)
+	if err != nil {
+		return nil, err
+	}
+	if p.FarmID != farmID {
+		return nil, common.ErrNotFound
+	}
+	return p, nil
+}
+
+// checkNoOpenCycles refuses a change to a plot that planned or active crop cycles are grown on
+func (s *PlotServiceImpl) checkNoOpenCycles(ctx context.Context, p *plot.Plot, change string) error {
+	count, err := s.plotRepo.CountOpenCycles(ctx, p.ID)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: plot %s has %d planned or active crop cycle(s) and cannot be %s until they end",
+			common.ErrInvalidInput, p.ID, count, change)
+	}
+	return nil
+}
+
+// setPlotGeometry sets a plot's boundary after checking that it lies within its farm's
+func setPlotGeometry(p *plot.Plot, farm *farmEntity.Farm, geometry *requests.GeometryData) error {
+	if err := geometry.ResolveWKT(); err != nil {
+		return fmt.Errorf("%w: %v", common.ErrInvalidInput, err)
+	}
+	if geometry.WKT == "" {
+		return fmt.Errorf("%w: geometry is required", common.ErrInvalidInput)
+	}
+	if err := p.SetGeometry(geometry.WKT); err != nil {
+		return fmt.Errorf("%w: %v", common.ErrInvalidInput, err)
+	}
+
+	farmShape, err := geo.ParseGeometry(farm.Geometry)
+	if err != nil {
+		return fmt.Errorf("%w: farm %s has no boundary to lay plots out in", common.ErrInvalidInput, farm.ID)
+	}
+	plotShape, err := p.Shape()
+	if err != nil {
+		return fmt.Errorf("%w: %v", common.ErrInvalidInput, err)
+	}
+	if outside := plot.OutsideAreaHa(plotShape, farmShape); outside >= plot.ToleranceHa {
+		return fmt.Errorf("%w: plot extends %.4f ha beyond the boundary of farm %s", common.ErrInvalidInput, outside, farm.ID)
+	}
+	return nil
+}
+
+func convertPlotToData(p *plot.Plot) *responses.PlotData {
+	geometryWKT, geometryGeoJSON := farmGeometry(p.Geometry)
+	return &responses.PlotData{
+		ID:        p.ID,
+		FarmID:    p.FarmID,
+		AAAOrgID:  p.AAAOrgID,
+		Name:      p.Name,
+		Geometry:  geometryWKT,
+		GeoJSON:   geometryGeoJSON,
+		AreaHa:    p.AreaHa,
+		CreatedAt: p.CreatedAt,
+		UpdatedAt: p.UpdatedAt,
+	}
+}
@@ -0,0 +1,211 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Kisanlink/farmers-module/internal/auth"
+	cropCycleEntity "github.com/Kisanlink/farmers-module/internal/entities/crop_cycle"
+	"github.com/Kisanlink/farmers-module/internal/entities/plot"
+	"github.com/Kisanlink/farmers-module/internal/entities/requests"
+	"github.com/Kisanlink/farmers-module/internal/entities/responses"
+	"github.com/Kisanlink/farmers-module/internal/repo/crop_cycle"
+	farmRepo "github.com/Kisanlink/farmers-module/internal/repo/farm"
+	plotRepo "github.com/Kisanlink/farmers-module/internal/repo/plot"
+	"github.com/Kisanlink/farmers-module/pkg/common"
+	"github.com/Kisanlink/kisanlink-db/pkg/base"
+	"github.com/Kisanlink/kisanlink-db/pkg/core/hash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// A 0.002° square farm and its west and east halves
+const (
+	plotTestFarm = "POLYGON((78 17,78.002 17,78.002 17.002,78 17.002,78 17))"
+	westHalf     = "POLYGON((78 17,78.001 17,78.001 17.002,78 17.002,78 17))"
+	eastHalf     = "POLYGON((78.001 17,78.002 17,78.002 17.002,78.001 17.002,78.001 17))"
+)
+
+// newPlotTestDB creates a database with FARM1 of org-1, laid out as plotTestFarm
+func newPlotTestDB(t *testing.T) *gorm.DB {
+	db := newSQLiteDB(t, farmsTable, plotsTable, cropCyclesTable)
+	insertFarm(t, db, "FARM1", "org-1", plotTestFarm)
+	require.NoError(t, db.Exec("UPDATE farms SET area_ha_computed = 4.7 WHERE id = 'FARM1'").Error)
+	return db
+}
+
+// farmEditorContext authenticates user-1, who may update FARM1
+func farmEditorContext(aaa *MockAAAService) context.Context {
+	aaa.On("CheckPermission", mock.Anything, "user-1", "farm", "update", "FARM1", "org-1").Return(true, nil)
+	aaa.On("CheckPermission", mock.Anything, "user-1", "cycle", "update", mock.Anything, "org-1").Return(true, nil)
+	return auth.SetUserInContext(context.Background(), &auth.UserContext{AAAUserID: "user-1"})
+}
+
+func createTestPlot(t *testing.T, db *gorm.DB, name, wkt string) *plot.Plot {
+	p := plot.NewPlot()
+	p.FarmID, p.AAAOrgID, p.Name = "FARM1", "org-1", name
+	require.NoError(t, p.SetGeometry(wkt))
+	require.NoError(t, db.Create(p).Error)
+	return p
+}
+
+func createTestCycle(t *testing.T, db *gorm.DB, status string, plotID *string, areaHa float64) *cropCycleEntity.CropCycle {
+	cycle := &cropCycleEntity.CropCycle{
+		BaseModel: *base.NewBaseModel("CRCY", hash.Medium),
+		FarmID:    "FARM1",
+		FarmerID:  "FMRRFARM1",
+		PlotID:    plotID,
+		AreaHa:    &areaHa,
+		Season:    "KHARIF",
+		Status:    status,
+		CropID:    "CROP1",
+	}
+	require.NoError(t, db.Create(cycle).Error)
+	return cycle
+}
+
+func newPlotTestService(db *gorm.DB, aaa *MockAAAService) *PlotServiceImpl {
+	farms := farmRepo.NewFarmRepository(&sqliteManager{db: db})
+	return &PlotServiceImpl{plotRepo: plotRepo.NewPlotRepository(db), farmRepo: farms, aaaService: aaa, db: db}
+}
+
+func TestCreatePlot_MustLieWithinFarm(t *testing.T) {
+	aaa := &MockAAAService{}
+	ctx := farmEditorContext(aaa)
+	db := newPlotTestDB(t)
+	service := newPlotTestService(db, aaa)
+
+	createPlot := func(name, wkt string) (*responses.PlotResponse, error) {
+		result, err := service.CreatePlot(ctx, &requests.CreatePlotRequest{
+			FarmID:      "FARM1",
+			PlotRequest: requests.PlotRequest{Name: name, Geometry: &requests.GeometryData{WKT: wkt}},
+		})
+		if err != nil {
+			return nil, err
+		}
+		return result.(*responses.PlotResponse), nil
+	}
+
+	west, err := createPlot("West", westHalf)
+	require.NoError(t, err)
+	assert.InDelta(t, 2.35, west.Data.AreaHa, 0.1)
+
+	// Plots may overlap one another, and may fill the farm
+	_, err = createPlot("Whole", plotTestFarm)
+	require.NoError(t, err)
+
+	// A plot reaching past the farm's eastern edge is refused
+	_, err = createPlot("Spill", "POLYGON((78.001 17,78.003 17,78.003 17.002,78.001 17.002,78.001 17))")
+	assert.ErrorIs(t, err, common.ErrInvalidInput)
+	assert.Contains(t, err.Error(), "beyond the boundary of farm FARM1")
+
+	var count int64
+	require.NoError(t, db.Model(&plot.Plot{}).Count(&count).Error)
+	assert.Equal(t, int64(2), count)
+}
+
+func TestCreatePlot_RequiresFarmUpdatePermission(t *testing.T) {
+	aaa := &MockAAAService{}
+	aaa.On("CheckPermission", mock.Anything, "user-2", "farm", "update", "FARM1", "org-1").Return(false, nil)
+	ctx := auth.SetUserInContext(context.Background(), &auth.UserContext{AAAUserID: "user-2"})
+	service := newPlotTestService(newPlotTestDB(t), aaa)
+
+	_, err := service.CreatePlot(ctx, &requests.CreatePlotRequest{
+		FarmID:      "FARM1",
+		PlotRequest: requests.PlotRequest{Name: "West", Geometry: &requests.GeometryData{WKT: westHalf}},
+	})
+	assert.ErrorIs(t, err, common.ErrForbidden)
+}
+
+func TestUpdateAndDeletePlot_RefusedWhileCyclesAreOpen(t *testing.T) {
+	aaa := &MockAAAService{}
+	ctx := farmEditorContext(aaa)
+	db := newPlotTestDB(t)
+	service := newPlotTestService(db, aaa)
+	west := createTestPlot(t, db, "West", westHalf)
+	cycle := createTestCycle(t, db, "ACTIVE", &west.ID, 2)
+
+	// An open cycle's plot may be renamed but not redrawn or deleted
+	_, err := service.UpdatePlot(ctx, &requests.UpdatePlotRequest{ID: west.ID, FarmID: "FARM1", PlotRequest: requests.PlotRequest{Name: "West field"}})
+	require.NoError(t, err)
+	_, err = service.UpdatePlot(ctx, &requests.UpdatePlotRequest{
+		ID:          west.ID,
+		FarmID:      "FARM1",
+		PlotRequest: requests.PlotRequest{Name: "West field", Geometry: &requests.GeometryData{WKT: eastHalf}},
+	})
+	assert.ErrorIs(t, err, common.ErrInvalidInput)
+	assert.Contains(t, err.Error(), "cannot be redrawn")
+	err = service.DeletePlot(ctx, &requests.DeletePlotRequest{ID: west.ID, FarmID: "FARM1"})
+	assert.ErrorIs(t, err, common.ErrInvalidInput)
+	assert.Contains(t, err.Error(), "cannot be deleted")
+
+	// Once the cycle has ended the plot is free to change
+	require.NoError(t, db.Model(cycle).Update("status", "COMPLETED").Error)
+	result, err := service.UpdatePlot(ctx, &requests.UpdatePlotRequest{
+		ID:          west.ID,
+		FarmID:      "FARM1",
+		PlotRequest: requests.PlotRequest{Name: "East field", Geometry: &requests.GeometryData{WKT: eastHalf}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "East field", result.(*responses.PlotResponse).Data.Name)
+	require.NoError(t, service.DeletePlot(ctx, &requests.DeletePlotRequest{ID: west.ID, FarmID: "FARM1"}))
+
+	// A plot is only found through its own farm
+	other := createTestPlot(t, db, "Other", westHalf)
+	err = service.DeletePlot(ctx, &requests.DeletePlotRequest{ID: other.ID, FarmID: "FARM2"})
+	assert.Error(t, err)
+}
+
+func TestUpdateCycle_PlotAttachment(t *testing.T) {
+	aaa := &MockAAAService{}
+	ctx := farmEditorContext(aaa)
+	db := newPlotTestDB(t)
+	service := &CropCycleServiceImpl{cropCycleRepo: crop_cycle.NewRepository(&sqliteManager{db: db}), aaaService: aaa}
+	west := createTestPlot(t, db, "West", westHalf)
+	east := createTestPlot(t, db, "East", eastHalf)
+	whole := createTestPlot(t, db, "Whole", plotTestFarm)
+
+	growing := createTestCycle(t, db, "ACTIVE", &west.ID, 2)
+	planned := createTestCycle(t, db, "PLANNED", nil, 1)
+
+	updateCycle := func(cycleID string, plotID string) (*cropCycleEntity.CropCycle, error) {
+		_, err := service.UpdateCycle(ctx, &requests.UpdateCycleRequest{BaseRequest: requests.BaseRequest{OrgID: "org-1"}, ID: cycleID, PlotID: &plotID})
+		if err != nil {
+			return nil, err
+		}
+		stored := &cropCycleEntity.CropCycle{}
+		require.NoError(t, db.First(stored, "id = ?", cycleID).Error)
+		return stored, nil
+	}
+
+	// A plot overlapping the one an open cycle is grown on is occupied
+	_, err := updateCycle(planned.ID, whole.ID)
+	var occupied *common.PlotOccupiedError
+	require.True(t, errors.As(err, &occupied), "got %v", err)
+	assert.Equal(t, west.ID, occupied.OccupiedByPlot)
+	assert.Equal(t, growing.ID, occupied.OccupiedBy)
+
+	// Moving onto a free plot takes the whole plot unless an area is given
+	stored, err := updateCycle(planned.ID, east.ID)
+	require.NoError(t, err)
+	require.NotNil(t, stored.PlotID)
+	assert.Equal(t, east.ID, *stored.PlotID)
+	assert.InDelta(t, east.AreaHa, *stored.AreaHa, 0.0001)
+
+	// An empty plot ID detaches the cycle and keeps its area
+	stored, err = updateCycle(growing.ID, "")
+	require.NoError(t, err)
+	assert.Nil(t, stored.PlotID)
+	assert.Equal(t, 2.0, *stored.AreaHa)
+
+	// Ended cycles keep the plot they were grown on
+	ended := createTestCycle(t, db, "COMPLETED", &whole.ID, 4)
+	_, err = updateCycle(ended.ID, "")
+	assert.Error(t, err)
+	stored = &cropCycleEntity.CropCycle{}
+	require.NoError(t, db.First(stored, "id = ?", ended.ID).Error)
+	assert.Equal(t, &whole.ID, stored.PlotID)
+}
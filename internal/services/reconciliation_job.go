@@ -13,6 +13,8 @@ import (
 	"github.com/Kisanlink/farmers-module/internal/entities/farm"
 	farmactivity "github.com/Kisanlink/farmers-module/internal/entities/farm_activity"
 	farmerentity "github.com/Kisanlink/farmers-module/internal/entities/farmer"
+	"github.com/Kisanlink/farmers-module/internal/entities/idempotency"
+	landparcel "github.com/Kisanlink/farmers-module/internal/entities/land_parcel"
 	"github.com/Kisanlink/farmers-module/internal/interfaces"
 	"github.com/Kisanlink/kisanlink-db/pkg/base"
 	"gorm.io/gorm"
@@ -32,17 +34,19 @@ type ReconciliationJob struct {
 
 // ReconciliationReport contains the results of a reconciliation run
 type ReconciliationReport struct {
-	StartTime            time.Time `json:"start_time"`
-	EndTime              time.Time `json:"end_time"`
-	Duration             string    `json:"duration"`
-	RolesProcessed       int       `json:"roles_processed"`
-	RolesFixed           int       `json:"roles_fixed"`
-	RolesStillPending    int       `json:"roles_still_pending"`
-	OrphanedDeleted      int       `json:"orphaned_deleted"`
-	FPOLinksProcessed    int       `json:"fpo_links_processed"`
-	FPOLinksFixed        int       `json:"fpo_links_fixed"`
-	FPOLinksStillPending int       `json:"fpo_links_still_pending"`
-	Errors               []string  `json:"errors,omitempty"`
+	StartTime             time.Time `json:"start_time"`
+	EndTime               time.Time `json:"end_time"`
+	Duration              string    `json:"duration"`
+	RolesProcessed        int       `json:"roles_processed"`
+	RolesFixed            int       `json:"roles_fixed"`
+	RolesStillPending     int       `json:"roles_still_pending"`
+	OrphanedDeleted       int       `json:"orphaned_deleted"`
+	FPOLinksProcessed     int       `json:"fpo_links_processed"`
+	FPOLinksFixed         int       `json:"fpo_links_fixed"`
+	FPOLinksStillPending  int       `json:"fpo_links_still_pending"`
+	IdempotencyKeysPurged int64     `json:"idempotency_keys_purged"`
+	FarmOwnershipsUpdated int       `json:"farm_ownerships_updated"`
+	Errors                []string  `json:"errors,omitempty"`
 }
 
 // NewReconciliationJob creates a new reconciliation job
@@ -141,6 +145,12 @@ func (j *ReconciliationJob) Reconcile(ctx context.Context) (*ReconciliationRepor
 	// Reconcile pending FPO config links
 	j.reconcileFPOConfigLinks(ctx, report)
 
+	// Drop idempotency keys whose replay window has passed
+	j.purgeExpiredIdempotencyKeys(ctx, report)
+
+	// Move farms whose leases started or ended to the ownership type their parcels now give
+	j.refreshLeasedFarmOwnership(ctx, report)
+
 	report.EndTime = time.Now()
 	report.Duration = report.EndTime.Sub(report.StartTime).String()
 
@@ -189,6 +199,47 @@ func (j *ReconciliationJob) cleanupOrphanedFarmers(ctx context.Context, report *
 	}
 }
 
+// purgeExpiredIdempotencyKeys deletes stored responses that are no longer replayed
+func (j *ReconciliationJob) purgeExpiredIdempotencyKeys(ctx context.Context, report *ReconciliationReport) {
+	result := j.db.WithContext(ctx).
+		Where("expires_at <= ?", time.Now()).
+		Delete(&idempotency.IdempotencyKey{})
+	if result.Error != nil {
+		report.Errors = append(report.Errors, fmt.Sprintf("failed to purge expired idempotency keys: %v", result.Error))
+		return
+	}
+	report.IdempotencyKeysPurged = result.RowsAffected
+}
+
+// refreshLeasedFarmOwnership derives the ownership type of every farm with leased parcels
+// again, since it changes when a lease starts or ends without any write to the farm
+func (j *ReconciliationJob) refreshLeasedFarmOwnership(ctx context.Context, report *ReconciliationReport) {
+	var farms []*farm.Farm
+	err := j.db.WithContext(ctx).
+		Where("deleted_at IS NULL").
+		Where("id IN (?)", j.db.Model(&landparcel.LandParcel{}).
+			Select("farm_id").
+			Where("tenure = ? AND deleted_at IS NULL", landparcel.TenureLeased)).
+		Find(&farms).Error
+	if err != nil {
+		report.Errors = append(report.Errors, fmt.Sprintf("failed to query farms with leased parcels: %v", err))
+		return
+	}
+
+	now := time.Now()
+	for _, f := range farms {
+		previous := f.OwnershipType
+		ownership, err := refreshFarmOwnershipType(ctx, j.db, f, now)
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("farm %s: %v", f.ID, err))
+			continue
+		}
+		if ownership != previous {
+			report.FarmOwnershipsUpdated++
+		}
+	}
+}
+
 // reconcileRoleAssignments retries failed role assignments
 func (j *ReconciliationJob) reconcileRoleAssignments(ctx context.Context, report *ReconciliationReport) {
 	// Query farmers with role_assignment_pending = true
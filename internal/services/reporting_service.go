@@ -6,6 +6,9 @@ import (
 	"time"
 
 	"github.com/Kisanlink/farmers-module/internal/auth"
+	cropCycleEntity "github.com/Kisanlink/farmers-module/internal/entities/crop_cycle"
+	farmEntity "github.com/Kisanlink/farmers-module/internal/entities/farm"
+	farmActivityEntity "github.com/Kisanlink/farmers-module/internal/entities/farm_activity"
 	farmerentity "github.com/Kisanlink/farmers-module/internal/entities/farmer"
 	"github.com/Kisanlink/farmers-module/internal/entities/requests"
 	"github.com/Kisanlink/farmers-module/internal/entities/responses"
@@ -225,26 +228,12 @@ func (s *ReportingServiceImpl) OrgDashboardCounters(ctx context.Context, req int
 		return nil, common.ErrForbidden
 	}
 
-	// Get total farmers count
-	farmerFilterBuilder := base.NewFilterBuilder().
-		Where("aaa_org_id", base.OpEqual, request.OrgID)
-	totalFarmers, err := s.repoFactory.FarmerRepo.Count(ctx, farmerFilterBuilder.Build(), &farmerentity.Farmer{})
-	if err != nil {
-		return nil, fmt.Errorf("failed to count farmers: %w", err)
-	}
-
-	// Get active farmers (those with active linkages)
-	linkageFilterBuilder := base.NewFilterBuilder().
-		Where("aaa_org_id", base.OpEqual, request.OrgID).
-		Where("status", base.OpEqual, "ACTIVE")
-	activeFarmers, err := s.repoFactory.FarmerLinkageRepo.Count(ctx, linkageFilterBuilder.Build(), &farmerentity.FarmerLink{})
-	if err != nil {
-		return nil, fmt.Errorf("failed to count active farmers: %w", err)
-	}
-
 	// Get farms data
 	farmFilterBuilder2 := base.NewFilterBuilder().
 		Where("aaa_org_id", base.OpEqual, request.OrgID)
+	for column, code := range request.AdminAreaFilter.Columns() {
+		farmFilterBuilder2 = farmFilterBuilder2.Where(column, base.OpEqual, code)
+	}
 	farms, err := s.repoFactory.FarmRepo.Find(ctx, farmFilterBuilder2.Build())
 	if err != nil {
 		return nil, fmt.Errorf("failed to get farms: %w", err)
@@ -259,6 +248,35 @@ func (s *ReportingServiceImpl) OrgDashboardCounters(ctx context.Context, req int
 		farmIDs = append(farmIDs, farm.ID)
 	}
 
+	// Get total farmers count
+	farmerFilterBuilder := base.NewFilterBuilder().
+		Where("aaa_org_id", base.OpEqual, request.OrgID)
+
+	// Get active farmers (those with active linkages)
+	linkageFilterBuilder := base.NewFilterBuilder().
+		Where("aaa_org_id", base.OpEqual, request.OrgID).
+		Where("status", base.OpEqual, "ACTIVE")
+
+	// Within an administrative area, only the farmers of the farms in it count
+	if !request.AdminAreaFilter.IsEmpty() {
+		farmerIDs, userIDs := farmOwners(farms)
+		farmerFilterBuilder = farmerFilterBuilder.Where("id", base.OpIn, farmerIDs)
+		linkageFilterBuilder = linkageFilterBuilder.Where("aaa_user_id", base.OpIn, userIDs)
+	}
+
+	var totalFarmers, activeFarmers int64
+	if request.AdminAreaFilter.IsEmpty() || len(farms) > 0 {
+		totalFarmers, err = s.repoFactory.FarmerRepo.Count(ctx, farmerFilterBuilder.Build(), &farmerentity.Farmer{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to count farmers: %w", err)
+		}
+
+		activeFarmers, err = s.repoFactory.FarmerLinkageRepo.Count(ctx, linkageFilterBuilder.Build(), &farmerentity.FarmerLink{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to count active farmers: %w", err)
+		}
+	}
+
 	// Get crop cycles data
 	cycleFilterBuilder2 := base.NewFilterBuilder()
 	if len(farmIDs) > 0 {
@@ -274,9 +292,13 @@ func (s *ReportingServiceImpl) OrgDashboardCounters(ctx context.Context, req int
 		cycleFilterBuilder2 = cycleFilterBuilder2.Where("end_date", base.OpLessEqual, *request.EndDate)
 	}
 
-	cycles, err := s.repoFactory.CropCycleRepo.Find(ctx, cycleFilterBuilder2.Build())
-	if err != nil {
-		return nil, fmt.Errorf("failed to get crop cycles: %w", err)
+	// Without farms there are no cycles; an unfiltered query would count every cycle
+	var cycles []*cropCycleEntity.CropCycle
+	if len(farmIDs) > 0 {
+		cycles, err = s.repoFactory.CropCycleRepo.Find(ctx, cycleFilterBuilder2.Build())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get crop cycles: %w", err)
+		}
 	}
 
 	// Analyze cycles
@@ -335,9 +357,12 @@ func (s *ReportingServiceImpl) OrgDashboardCounters(ctx context.Context, req int
 		activityFilterBuilder2 = activityFilterBuilder2.Where("planned_at", base.OpLessEqual, *request.EndDate)
 	}
 
-	activities, err := s.repoFactory.FarmActivityRepo.Find(ctx, activityFilterBuilder2.Build())
-	if err != nil {
-		return nil, fmt.Errorf("failed to get farm activities: %w", err)
+	var activities []*farmActivityEntity.FarmActivity
+	if len(cycleIDs) > 0 {
+		activities, err = s.repoFactory.FarmActivityRepo.Find(ctx, activityFilterBuilder2.Build())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get farm activities: %w", err)
+		}
 	}
 
 	// Analyze activities
@@ -413,3 +438,22 @@ func (s *ReportingServiceImpl) OrgDashboardCounters(ctx context.Context, req int
 		Data: dashboardData,
 	}, nil
 }
+
+// farmOwners returns the distinct farmer IDs and AAA user IDs of the farms
+func farmOwners(farms []*farmEntity.Farm) ([]string, []string) {
+	farmerIDs := make([]string, 0, len(farms))
+	userIDs := make([]string, 0, len(farms))
+	seenFarmers := make(map[string]bool, len(farms))
+	seenUsers := make(map[string]bool, len(farms))
+	for _, farm := range farms {
+		if farm.FarmerID != "" && !seenFarmers[farm.FarmerID] {
+			seenFarmers[farm.FarmerID] = true
+			farmerIDs = append(farmerIDs, farm.FarmerID)
+		}
+		if !seenUsers[farm.AAAUserID] {
+			seenUsers[farm.AAAUserID] = true
+			userIDs = append(userIDs, farm.AAAUserID)
+		}
+	}
+	return farmerIDs, userIDs
+}
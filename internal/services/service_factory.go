@@ -7,6 +7,7 @@ import (
 
 	"github.com/Kisanlink/farmers-module/internal/clients/aaa"
 	"github.com/Kisanlink/farmers-module/internal/config"
+	"github.com/Kisanlink/farmers-module/internal/entities/bulk"
 	"github.com/Kisanlink/farmers-module/internal/interfaces"
 	"github.com/Kisanlink/farmers-module/internal/repo"
 	repofpo "github.com/Kisanlink/farmers-module/internal/repo/fpo"
@@ -26,12 +27,17 @@ type ServiceFactory struct {
 	KisanSathiService    KisanSathiService
 
 	// Farm Management Services
-	FarmService FarmService
+	FarmService          FarmService
+	LandParcelService    LandParcelService
+	PlotService          PlotService
+	AdminBoundaryService AdminBoundaryService
 
 	// Crop Management Services
-	CropService         CropService
-	CropCycleService    CropCycleService
-	FarmActivityService FarmActivityService
+	CropService            CropService
+	CropCycleService       CropCycleService
+	FarmActivityService    FarmActivityService
+	InputProductService    InputProductService
+	CultivationCostService CultivationCostService
 
 	// Data Quality Services
 	DataQualityService DataQualityService
@@ -46,7 +52,9 @@ type ServiceFactory struct {
 	AdministrativeService AdministrativeService
 
 	// Bulk Operations Services
-	BulkFarmerService BulkFarmerService
+	BulkFarmerService          BulkFarmerService
+	BulkFarmImportService      BulkFarmImportService
+	BulkCropCycleImportService BulkCropCycleImportService
 
 	// AAA Integration Service
 	AAAService AAAService
@@ -60,8 +68,15 @@ type ServiceFactory struct {
 	// Stage Management Services
 	StageService StageService
 
+	// Idempotency-Key handling for mutating endpoints
+	IdempotencyService IdempotencyService
+
+	// Offline sync for mobile clients
+	SyncService SyncService
+
 	// Background Jobs
 	ReconciliationJob *ReconciliationJob
+	BulkJobWorker     *BulkJobWorker
 
 	// Admin Services
 	PermanentDeleteService *PermanentDeleteService
@@ -111,17 +126,43 @@ func NewServiceFactory(repoFactory *repo.RepositoryFactory, postgresManager *db.
 		gormDB = db
 	}
 	farmService := NewFarmService(repoFactory.FarmRepo, repoFactory.FarmerRepo, aaaService, gormDB)
+	landParcelService := NewLandParcelService(repoFactory.LandParcelRepo, repoFactory.FarmRepo, aaaService, gormDB)
+	plotService := NewPlotService(repoFactory.PlotRepo, repoFactory.FarmRepo, aaaService, gormDB)
+	adminBoundaryService := NewAdminBoundaryService(repoFactory.AdminBoundaryRepo, repoFactory.FarmRepo, aaaService)
 
 	// Initialize crop management services
 	cropService := NewCropService(repoFactory.CropRepo, repoFactory.CropVarietyRepo, aaaService)
-	cropCycleService := NewCropCycleService(repoFactory.CropCycleRepo, farmService, aaaService)
-	farmActivityService := NewFarmActivityService(repoFactory.FarmActivityRepo, repoFactory.CropCycleRepo, repoFactory.CropStageRepo, repoFactory.FarmerLinkageRepo, aaaService)
+	cropCycleService := NewCropCycleService(
+		repoFactory.CropCycleRepo,
+		repoFactory.CropStageRepo,
+		repoFactory.ActivityTemplateRepo,
+		repoFactory.FarmActivityRepo,
+		farmService,
+		aaaService,
+	)
+	farmActivityService := NewFarmActivityService(
+		repoFactory.FarmActivityRepo,
+		repoFactory.CropCycleRepo,
+		repoFactory.CropStageRepo,
+		repoFactory.StageTransitionRepo,
+		repoFactory.FarmerLinkageRepo,
+		aaaService,
+	)
+	inputProductService := NewInputProductService(repoFactory.InputProductRepo, aaaService)
+	cultivationCostService := NewCultivationCostService(
+		repoFactory.ActivityInputRepo,
+		repoFactory.InputProductRepo,
+		repoFactory.FarmActivityRepo,
+		repoFactory.CropCycleRepo,
+		repoFactory.FarmRepo,
+		aaaService,
+	)
 
 	// Initialize notification service
 	notificationService := NewNotificationService(aaaService)
 
 	// Initialize data quality service
-	dataQualityService := NewDataQualityService(gormDB, repoFactory.FarmRepo, repoFactory.FarmerLinkageRepo, aaaService, notificationService)
+	dataQualityService := NewDataQualityService(gormDB, repoFactory.FarmRepo, repoFactory.FarmerLinkageRepo, repoFactory.FarmOverlapRepo, aaaService, notificationService)
 
 	// Initialize lookup service
 	lookupService := NewLookupService(gormDB)
@@ -143,6 +184,8 @@ func NewServiceFactory(repoFactory *repo.RepositoryFactory, postgresManager *db.
 	bulkFarmerService := NewBulkFarmerService(
 		repoFactory.BulkOperationRepo,
 		repoFactory.ProcessingDetailRepo,
+		repoFactory.JobQueueRepo,
+		repoFactory.ColumnMappingProfileRepo,
 		farmerService,
 		farmerLinkageService,
 		aaaService,
@@ -150,6 +193,35 @@ func NewServiceFactory(repoFactory *repo.RepositoryFactory, postgresManager *db.
 		cfg.AAA.DefaultPassword,
 	)
 
+	// Initialize bulk farm import service; its records are processed by the bulk job worker
+	bulkFarmImportService := NewBulkFarmImportService(
+		repoFactory.BulkOperationRepo,
+		repoFactory.ProcessingDetailRepo,
+		repoFactory.JobQueueRepo,
+		repoFactory.FarmRepo,
+		repoFactory.FarmerRepo,
+		farmService,
+		logger,
+	)
+
+	// Initialize bulk crop cycle import service; its records are processed by the bulk job worker
+	bulkCropCycleImportService := NewBulkCropCycleImportService(
+		repoFactory.BulkOperationRepo,
+		repoFactory.ProcessingDetailRepo,
+		repoFactory.JobQueueRepo,
+		repoFactory.CropRepo,
+		repoFactory.CropVarietyRepo,
+		repoFactory.CropCycleRepo,
+		repoFactory.FarmActivityRepo,
+		repoFactory.FarmRepo,
+		logger,
+	)
+
+	// Initialize bulk job worker for queued bulk operations
+	bulkJobWorker := NewBulkJobWorker(bulkFarmerService, repoFactory.JobQueueRepo, logger, 2*time.Second)
+	bulkJobWorker.registerProcessor(bulk.OperationFarmImport, bulkFarmImportService)
+	bulkJobWorker.registerProcessor(bulk.OperationCropCycleImport, bulkCropCycleImportService)
+
 	// Initialize audit service
 	auditService := audit.NewAuditService(logger.GetZapLogger(), nil) // No remote client for now
 
@@ -157,9 +229,21 @@ func NewServiceFactory(repoFactory *repo.RepositoryFactory, postgresManager *db.
 	stageService := NewStageService(
 		repoFactory.StageRepo,
 		repoFactory.CropStageRepo,
+		repoFactory.ActivityTemplateRepo,
 		aaaService,
 	)
 
+	// Initialize idempotency service; an invalid TTL falls back to the default
+	idempotencyTTL, err := time.ParseDuration(cfg.Idempotency.KeyTTL)
+	if err != nil {
+		log.Printf("Warning: Invalid IDEMPOTENCY_KEY_TTL %q, using %s", cfg.Idempotency.KeyTTL, DefaultIdempotencyKeyTTL)
+		idempotencyTTL = DefaultIdempotencyKeyTTL
+	}
+	idempotencyService := NewIdempotencyService(repoFactory.IdempotencyKeyRepo, idempotencyTTL, logger)
+
+	// Initialize offline sync service
+	syncService := NewSyncService(repoFactory.ChangeFeedRepo, farmerService, farmService, cropCycleService, farmActivityService, logger)
+
 	// Initialize reconciliation job (runs 4 times per day - every 6 hours)
 	reconciliationJob := NewReconciliationJob(gormDB, aaaService, logger, 6*time.Hour)
 
@@ -167,26 +251,36 @@ func NewServiceFactory(repoFactory *repo.RepositoryFactory, postgresManager *db.
 	permanentDeleteService := NewPermanentDeleteService(gormDB, aaaService, logger)
 
 	return &ServiceFactory{
-		FarmerService:          farmerService,
-		FarmerLinkageService:   farmerLinkageService,
-		FPOService:             fpoService,
-		FPOLifecycleService:    fpoLifecycleService,
-		FPOConfigService:       fpoConfigService,
-		KisanSathiService:      kisanSathiService,
-		FarmService:            farmService,
-		CropService:            cropService,
-		CropCycleService:       cropCycleService,
-		FarmActivityService:    farmActivityService,
-		DataQualityService:     dataQualityService,
-		LookupService:          lookupService,
-		ReportingService:       reportingService,
-		AdministrativeService:  administrativeService,
-		BulkFarmerService:      bulkFarmerService,
-		AAAService:             aaaService,
-		AuditService:           auditService,
-		AAAClient:              aaaClient,
-		StageService:           stageService,
-		ReconciliationJob:      reconciliationJob,
-		PermanentDeleteService: permanentDeleteService,
+		FarmerService:              farmerService,
+		FarmerLinkageService:       farmerLinkageService,
+		FPOService:                 fpoService,
+		FPOLifecycleService:        fpoLifecycleService,
+		FPOConfigService:           fpoConfigService,
+		KisanSathiService:          kisanSathiService,
+		FarmService:                farmService,
+		LandParcelService:          landParcelService,
+		PlotService:                plotService,
+		AdminBoundaryService:       adminBoundaryService,
+		CropService:                cropService,
+		CropCycleService:           cropCycleService,
+		FarmActivityService:        farmActivityService,
+		InputProductService:        inputProductService,
+		CultivationCostService:     cultivationCostService,
+		DataQualityService:         dataQualityService,
+		LookupService:              lookupService,
+		ReportingService:           reportingService,
+		AdministrativeService:      administrativeService,
+		BulkFarmerService:          bulkFarmerService,
+		BulkFarmImportService:      bulkFarmImportService,
+		BulkCropCycleImportService: bulkCropCycleImportService,
+		AAAService:                 aaaService,
+		AuditService:               auditService,
+		AAAClient:                  aaaClient,
+		StageService:               stageService,
+		IdempotencyService:         idempotencyService,
+		SyncService:                syncService,
+		ReconciliationJob:          reconciliationJob,
+		BulkJobWorker:              bulkJobWorker,
+		PermanentDeleteService:     permanentDeleteService,
 	}
 }
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Kisanlink/kisanlink-db/pkg/base"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// sqliteManager serves repositories built on BaseFilterableRepository from SQLite. Filters
+// may use the equality, IN and IS NULL conditions and the preloads the repositories build;
+// soft-deleted rows are skipped.
+type sqliteManager struct {
+	db *gorm.DB
+}
+
+func (m *sqliteManager) GetDB(ctx context.Context, readOnly bool) (*gorm.DB, error) {
+	return m.db, nil
+}
+
+func (m *sqliteManager) Create(ctx context.Context, model interface{}) error {
+	return m.db.WithContext(ctx).Create(model).Error
+}
+
+func (m *sqliteManager) Update(ctx context.Context, model interface{}) error {
+	return m.db.WithContext(ctx).Save(model).Error
+}
+
+func (m *sqliteManager) GetByID(ctx context.Context, id interface{}, model interface{}) error {
+	return m.db.WithContext(ctx).Where("id = ? AND deleted_at IS NULL", id).First(model).Error
+}
+
+func (m *sqliteManager) List(ctx context.Context, filter *base.Filter, model interface{}) error {
+	query, err := m.filtered(ctx, filter)
+	if err != nil {
+		return err
+	}
+	for _, sort := range filter.Sort {
+		query = query.Order(sort.Field + " " + sort.Direction)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit).Offset(filter.Offset)
+	}
+	return query.Find(model).Error
+}
+
+func (m *sqliteManager) Count(ctx context.Context, filter *base.Filter, model interface{}) (int64, error) {
+	query, err := m.filtered(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	var count int64
+	err = query.Model(model).Count(&count).Error
+	return count, err
+}
+
+func (m *sqliteManager) filtered(ctx context.Context, filter *base.Filter) (*gorm.DB, error) {
+	query := m.db.WithContext(ctx).Where("deleted_at IS NULL")
+	if filter == nil {
+		return query, nil
+	}
+	for _, condition := range filter.Group.Conditions {
+		switch condition.Operator {
+		case base.OpEqual:
+			query = query.Where(condition.Field+" = ?", condition.Value)
+		case base.OpIn:
+			query = query.Where(condition.Field+" IN ?", condition.Value)
+		case base.OpIsNull:
+			query = query.Where(condition.Field + " IS NULL")
+		default:
+			return nil, fmt.Errorf("unsupported filter operator %s", condition.Operator)
+		}
+	}
+	for _, preload := range filter.Preloads {
+		query = query.Preload(preload.Relation)
+	}
+	return query, nil
+}
+
+// newSQLiteDB creates an in-memory SQLite database with the given tables
+func newSQLiteDB(t *testing.T, tables ...string) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	for _, table := range tables {
+		require.NoError(t, db.Exec(table).Error)
+	}
+	return db
+}
+
+// farmsTable is the farms table without its PostGIS columns and generated area
+const farmsTable = `
+	CREATE TABLE farms (
+		id VARCHAR(255) PRIMARY KEY,
+		created_at DATETIME NOT NULL,
+		updated_at DATETIME NOT NULL,
+		created_by VARCHAR(255),
+		updated_by VARCHAR(255),
+		deleted_at DATETIME,
+		deleted_by VARCHAR(255),
+		farmer_id VARCHAR(255) DEFAULT '',
+		aaa_user_id VARCHAR(255) NOT NULL,
+		aaa_org_id VARCHAR(255) NOT NULL,
+		name VARCHAR(255),
+		ownership_type VARCHAR(20) DEFAULT 'OWN',
+		geometry TEXT,
+		area_ha REAL,
+		area_ha_computed REAL,
+		soil_type_id VARCHAR(255),
+		primary_irrigation_source_id VARCHAR(255),
+		bore_well_count INTEGER DEFAULT 0,
+		other_irrigation_details TEXT,
+		metadata TEXT DEFAULT '{}',
+		state_code VARCHAR(20),
+		district_code VARCHAR(20),
+		block_code VARCHAR(20),
+		village_code VARCHAR(20)
+	)`
+
+// insertFarm stores a farm of an organization with the given boundary
+func insertFarm(t *testing.T, db *gorm.DB, id, orgID, wkt string) {
+	require.NoError(t, db.Exec(`INSERT INTO farms (id, created_at, updated_at, farmer_id, aaa_user_id, aaa_org_id, geometry)
+		VALUES (?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, ?, ?, ?, ?)`,
+		id, "FMRR"+id, "user-"+id, orgID, wkt).Error)
+}
+
+// plotsTable is the plots table with its boundary held as WKT
+const plotsTable = `
+	CREATE TABLE plots (
+		id VARCHAR(255) PRIMARY KEY,
+		created_at DATETIME NOT NULL,
+		updated_at DATETIME NOT NULL,
+		created_by VARCHAR(255),
+		updated_by VARCHAR(255),
+		deleted_at DATETIME,
+		deleted_by VARCHAR(255),
+		farm_id VARCHAR(255) NOT NULL,
+		aaa_org_id VARCHAR(255) NOT NULL,
+		name VARCHAR(255) NOT NULL,
+		geometry TEXT NOT NULL,
+		area_ha REAL NOT NULL DEFAULT 0
+	)`
+
+// cropCyclesTable is the crop_cycles table without its enum types
+const cropCyclesTable = `
+	CREATE TABLE crop_cycles (
+		id VARCHAR(255) PRIMARY KEY,
+		created_at DATETIME NOT NULL,
+		updated_at DATETIME NOT NULL,
+		created_by VARCHAR(255),
+		updated_by VARCHAR(255),
+		deleted_at DATETIME,
+		deleted_by VARCHAR(255),
+		farm_id VARCHAR(255) NOT NULL,
+		farmer_id VARCHAR(255) NOT NULL,
+		plot_id VARCHAR(255),
+		area_ha REAL,
+		season VARCHAR(20) NOT NULL,
+		status VARCHAR(20) NOT NULL DEFAULT 'PLANNED',
+		start_date DATE,
+		end_date DATE,
+		crop_id VARCHAR(255) NOT NULL,
+		variety_id VARCHAR(255),
+		outcome TEXT DEFAULT '{}',
+		tree_count INTEGER,
+		planting_year INTEGER
+	)`
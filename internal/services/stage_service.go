@@ -16,6 +16,7 @@ import (
 type StageServiceImpl struct {
 	stageRepo     *stage.StageRepository
 	cropStageRepo *stage.CropStageRepository
+	templateRepo  stage.ActivityTemplateRepository
 	aaaService    AAAService
 }
 
@@ -23,11 +24,13 @@ type StageServiceImpl struct {
 func NewStageService(
 	stageRepo *stage.StageRepository,
 	cropStageRepo *stage.CropStageRepository,
+	templateRepo stage.ActivityTemplateRepository,
 	aaaService AAAService,
 ) StageService {
 	return &StageServiceImpl{
 		stageRepo:     stageRepo,
 		cropStageRepo: cropStageRepo,
+		templateRepo:  templateRepo,
 		aaaService:    aaaService,
 	}
 }
@@ -0,0 +1,351 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Kisanlink/farmers-module/internal/auth"
+	cropCycleEntity "github.com/Kisanlink/farmers-module/internal/entities/crop_cycle"
+	"github.com/Kisanlink/farmers-module/internal/entities/requests"
+	"github.com/Kisanlink/farmers-module/internal/entities/responses"
+	stageEntity "github.com/Kisanlink/farmers-module/internal/entities/stage"
+	"github.com/Kisanlink/farmers-module/pkg/common"
+)
+
+// GetStageProgress gets stage-wise progress for a crop cycle: the activity completion of each
+// stage, the window each stage was expected to run in from the cycle's start date and the
+// stage durations, and when the cycle actually entered and left each stage. The current stage
+// is the latest stage the cycle entered; a cycle is in its first stage from its start date.
+func (s *FarmActivityServiceImpl) GetStageProgress(ctx context.Context, cropCycleID string) (interface{}, error) {
+	// Get crop cycle to verify it exists and get crop info
+	cropCycle := &cropCycleEntity.CropCycle{}
+	_, err := s.cropCycleRepo.GetByID(ctx, cropCycleID, cropCycle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get crop cycle: %w", err)
+	}
+
+	progressData, err := s.buildStageProgress(ctx, cropCycle)
+	if err != nil {
+		return nil, err
+	}
+
+	response := responses.NewStageProgressResponse(progressData, "Stage progress retrieved successfully")
+	return &response, nil
+}
+
+// AdvanceStage records that a crop cycle entered a stage of its crop, by default the stage
+// after its current one. Stages are otherwise entered when their first activity is completed.
+func (s *FarmActivityServiceImpl) AdvanceStage(ctx context.Context, req interface{}) (interface{}, error) {
+	advanceReq, ok := req.(*requests.AdvanceStageRequest)
+	if !ok {
+		return nil, common.ErrInvalidInput
+	}
+
+	// Extract authenticated user from context
+	userCtx, err := auth.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user context: %w", err)
+	}
+
+	// Check if authenticated user can update crop cycle
+	hasPermission, err := s.aaaService.CheckPermission(ctx, userCtx.AAAUserID, "cycle", "update", advanceReq.CropCycleID, advanceReq.OrgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !hasPermission {
+		return nil, common.ErrForbidden
+	}
+
+	cropCycle := &cropCycleEntity.CropCycle{}
+	_, err = s.cropCycleRepo.GetByID(ctx, advanceReq.CropCycleID, cropCycle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get crop cycle: %w", err)
+	}
+	if cropCycle.Status == "COMPLETED" || cropCycle.Status == "CANCELLED" {
+		return nil, fmt.Errorf("%w: cannot advance cycle in terminal state: %s", common.ErrInvalidInput, cropCycle.Status)
+	}
+	if cropCycle.StartDate == nil {
+		return nil, fmt.Errorf("%w: crop cycle %s has no start date", common.ErrInvalidInput, cropCycle.ID)
+	}
+
+	cropStages, err := s.cropStageRepo.GetCropStages(ctx, cropCycle.CropID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get crop stages: %w", err)
+	}
+	transitions, err := s.transitionRepo.ListByCycle(ctx, cropCycle.ID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	schedule := cropCycleEntity.BuildStageSchedule(cropStages, cropCycle.StartDate, transitions, now)
+
+	// Find the stage to enter
+	var target *cropCycleEntity.StageWindow
+	if advanceReq.CropStageID != nil {
+		for _, window := range schedule.Stages {
+			if window.CropStage.ID == *advanceReq.CropStageID {
+				target = window
+				break
+			}
+		}
+		if target == nil {
+			return nil, fmt.Errorf("%w: %s is not a stage of crop %s", common.ErrInvalidInput, *advanceReq.CropStageID, cropCycle.CropID)
+		}
+	} else {
+		next := 0
+		if schedule.Current != nil {
+			for i, window := range schedule.Stages {
+				if window == schedule.Current {
+					next = i + 1
+				}
+			}
+		}
+		if next >= len(schedule.Stages) {
+			return nil, fmt.Errorf("%w: crop cycle %s is already in the last stage of its crop", common.ErrInvalidInput, cropCycle.ID)
+		}
+		target = schedule.Stages[next]
+	}
+	if target.ActualStart != nil {
+		return nil, fmt.Errorf("%w: crop cycle %s already entered stage %s on %s",
+			common.ErrInvalidInput, cropCycle.ID, target.CropStage.ID, target.ActualStart.Format("2006-01-02"))
+	}
+	if schedule.Current != nil && target.CropStage.StageOrder <= schedule.Current.CropStage.StageOrder {
+		return nil, fmt.Errorf("%w: crop cycle %s is already in a later stage", common.ErrInvalidInput, cropCycle.ID)
+	}
+
+	startedAt := now
+	if advanceReq.StartedAt != nil {
+		startedAt = *advanceReq.StartedAt
+	}
+	if startedAt.After(now) {
+		return nil, fmt.Errorf("%w: started_at cannot be in the future", common.ErrInvalidInput)
+	}
+	if startedAt.Before(*cropCycle.StartDate) {
+		return nil, fmt.Errorf("%w: started_at cannot be before the cycle's start date", common.ErrInvalidInput)
+	}
+	if schedule.Current != nil && startedAt.Before(*schedule.Current.ActualStart) {
+		return nil, fmt.Errorf("%w: started_at cannot be before the current stage started", common.ErrInvalidInput)
+	}
+
+	transition := cropCycleEntity.NewStageTransition()
+	transition.CropCycleID = cropCycle.ID
+	transition.CropStageID = target.CropStage.ID
+	transition.StartedAt = startedAt
+	transition.Source = cropCycleEntity.StageTransitionSourceManual
+	transition.Notes = advanceReq.Notes
+	transition.CreatedBy = userCtx.AAAUserID
+	if err := transition.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.transitionRepo.Create(ctx, transition); err != nil {
+		return nil, err
+	}
+
+	progressData, err := s.buildStageProgress(ctx, cropCycle)
+	if err != nil {
+		return nil, err
+	}
+
+	response := responses.NewStageProgressResponse(progressData, "Crop cycle advanced to the next stage successfully")
+	response.SetRequestID(advanceReq.RequestID)
+	return &response, nil
+}
+
+// ListDelayedCycles lists the planned and active crop cycles of an organisation that are
+// behind their stage calendar by at least the given number of days, most delayed first, for
+// field staff to follow up
+func (s *FarmActivityServiceImpl) ListDelayedCycles(ctx context.Context, req interface{}) (interface{}, error) {
+	listReq, ok := req.(*requests.ListDelayedCyclesRequest)
+	if !ok {
+		return nil, common.ErrInvalidInput
+	}
+
+	// Extract authenticated user from context
+	userCtx, err := auth.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user context: %w", err)
+	}
+
+	// Check if authenticated user can list crop cycles
+	hasPermission, err := s.aaaService.CheckPermission(ctx, userCtx.AAAUserID, "cycle", "list", "", listReq.OrgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !hasPermission {
+		return nil, common.ErrForbidden
+	}
+
+	if listReq.OrgID == "" {
+		return nil, fmt.Errorf("%w: organization context is required", common.ErrInvalidInput)
+	}
+	if listReq.MinDelayDays < 1 {
+		listReq.MinDelayDays = 1
+	}
+	if listReq.Page < 1 {
+		listReq.Page = 1
+	}
+	if listReq.PageSize < 1 || listReq.PageSize > 100 {
+		listReq.PageSize = 20
+	}
+
+	cycles, err := s.cropCycleRepo.ListSownOpenCycles(ctx, listReq.OrgID, listReq.FarmID)
+	if err != nil {
+		return nil, err
+	}
+	cycleIDs := make([]string, len(cycles))
+	for i, cycle := range cycles {
+		cycleIDs[i] = cycle.ID
+	}
+	transitions, err := s.transitionRepo.ListByCycles(ctx, cycleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	stagesByCrop := make(map[string][]*stageEntity.CropStage)
+	var delayed []*responses.DelayedCycleData
+	for _, cycle := range cycles {
+		cropStages, ok := stagesByCrop[cycle.CropID]
+		if !ok {
+			cropStages, err = s.cropStageRepo.GetCropStages(ctx, cycle.CropID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get crop stages: %w", err)
+			}
+			stagesByCrop[cycle.CropID] = cropStages
+		}
+
+		schedule := cropCycleEntity.BuildStageSchedule(cropStages, cycle.StartDate, transitions[cycle.ID], now)
+		if schedule.Status != cropCycleEntity.ScheduleStatusBehind || schedule.DelayDays < listReq.MinDelayDays {
+			continue
+		}
+
+		current := schedule.Current
+		data := &responses.DelayedCycleData{
+			CropCycleID:       cycle.ID,
+			FarmID:            cycle.FarmID,
+			FarmerID:          cycle.FarmerID,
+			CropID:            cycle.CropID,
+			CropName:          cycle.GetCropName(),
+			Season:            cycle.Season,
+			Status:            cycle.Status,
+			StartDate:         cycle.StartDate,
+			CurrentStageID:    current.CropStage.ID,
+			ExpectedStartDate: current.ExpectedStart,
+			ExpectedEndDate:   current.ExpectedEnd,
+			ActualStartDate:   current.ActualStart,
+			DelayDays:         schedule.DelayDays,
+		}
+		if current.CropStage.Stage != nil {
+			data.CurrentStageName = current.CropStage.Stage.StageName
+		}
+		delayed = append(delayed, data)
+	}
+
+	sort.SliceStable(delayed, func(i, j int) bool {
+		return delayed[i].DelayDays > delayed[j].DelayDays
+	})
+
+	total := len(delayed)
+	from := (listReq.Page - 1) * listReq.PageSize
+	if from > total {
+		from = total
+	}
+	to := from + listReq.PageSize
+	if to > total {
+		to = total
+	}
+
+	response := responses.NewDelayedCyclesResponse(delayed[from:to], listReq.Page, listReq.PageSize, int64(total))
+	response.SetRequestID(listReq.RequestID)
+	return &response, nil
+}
+
+// buildStageProgress combines a crop cycle's activity completion per stage with its stage
+// calendar
+func (s *FarmActivityServiceImpl) buildStageProgress(ctx context.Context, cropCycle *cropCycleEntity.CropCycle) (*responses.StageProgressData, error) {
+	// Get the crop's stages in order
+	cropStages, err := s.cropStageRepo.GetCropStages(ctx, cropCycle.CropID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get crop stages: %w", err)
+	}
+
+	// Get completion statistics from repository
+	stats, err := s.farmActivityRepo.GetStageCompletionStats(ctx, cropCycle.ID, cropStages)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stage completion stats: %w", err)
+	}
+
+	transitions, err := s.transitionRepo.ListByCycle(ctx, cropCycle.ID)
+	if err != nil {
+		return nil, err
+	}
+	schedule := cropCycleEntity.BuildStageSchedule(cropStages, cropCycle.StartDate, transitions, time.Now())
+
+	// Convert stats to response format; stats and schedule are both in stage order
+	stageStats := make([]*responses.StageCompletionStat, len(stats))
+	totalCompletedActivities := 0
+	totalActivities := 0
+	var currentStage *responses.StageCompletionStat
+
+	for i, stat := range stats {
+		window := schedule.Stages[i]
+		responseStat := &responses.StageCompletionStat{
+			CropStageID:          stat.CropStageID,
+			StageID:              stat.StageID,
+			StageName:            stat.StageName,
+			StageOrder:           stat.StageOrder,
+			DurationDays:         stat.DurationDays,
+			TotalActivities:      stat.TotalActivities,
+			CompletedActivities:  stat.CompletedActivities,
+			InProgressActivities: stat.InProgressActivities,
+			PlannedActivities:    stat.PlannedActivities,
+			CompletionPercent:    stat.CompletionPercent,
+			ExpectedStartDate:    window.ExpectedStart,
+			ExpectedEndDate:      window.ExpectedEnd,
+			ActualStartDate:      window.ActualStart,
+			ActualEndDate:        window.ActualEnd,
+			StartDelayDays:       window.StartDelayDays(),
+		}
+		stageStats[i] = responseStat
+		totalCompletedActivities += stat.CompletedActivities
+		totalActivities += stat.TotalActivities
+		if window == schedule.Current {
+			currentStage = responseStat
+		}
+	}
+
+	// Calculate overall completion percentage
+	overallPercent := 0.0
+	if totalActivities > 0 {
+		overallPercent = float64(totalCompletedActivities) / float64(totalActivities) * 100
+	}
+
+	// Without a start date the current stage is the first incomplete stage in order, or the
+	// last stage when all are complete
+	if currentStage == nil && cropCycle.StartDate == nil {
+		for _, stat := range stageStats {
+			if stat.CompletionPercent < 100 {
+				currentStage = stat
+				break
+			}
+		}
+		if currentStage == nil && len(stageStats) > 0 {
+			currentStage = stageStats[len(stageStats)-1]
+		}
+	}
+
+	return &responses.StageProgressData{
+		CropCycleID:    cropCycle.ID,
+		CropID:         cropCycle.CropID,
+		CropName:       cropCycle.GetCropName(),
+		StartDate:      cropCycle.StartDate,
+		CurrentStage:   currentStage,
+		Stages:         stageStats,
+		OverallPercent: overallPercent,
+		TotalStages:    len(stageStats),
+		ScheduleStatus: string(schedule.Status),
+		DelayDays:      schedule.DelayDays,
+	}, nil
+}
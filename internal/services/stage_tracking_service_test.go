@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Kisanlink/farmers-module/internal/auth"
+	"github.com/Kisanlink/farmers-module/internal/entities/crop"
+	cropCycleEntity "github.com/Kisanlink/farmers-module/internal/entities/crop_cycle"
+	"github.com/Kisanlink/farmers-module/internal/entities/requests"
+	"github.com/Kisanlink/farmers-module/internal/entities/responses"
+	stageEntity "github.com/Kisanlink/farmers-module/internal/entities/stage"
+	"github.com/Kisanlink/farmers-module/internal/repo/crop_cycle"
+	stageRepo "github.com/Kisanlink/farmers-module/internal/repo/stage"
+	"github.com/Kisanlink/farmers-module/pkg/common"
+	"github.com/Kisanlink/kisanlink-db/pkg/base"
+	"github.com/Kisanlink/kisanlink-db/pkg/core/hash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// stageTransitionsTable is the crop_cycle_stage_transitions table with started_at as a DATETIME
+const stageTransitionsTable = `
+	CREATE TABLE crop_cycle_stage_transitions (
+		id VARCHAR(255) PRIMARY KEY,
+		created_at DATETIME NOT NULL,
+		updated_at DATETIME NOT NULL,
+		created_by VARCHAR(255),
+		updated_by VARCHAR(255),
+		deleted_at DATETIME,
+		deleted_by VARCHAR(255),
+		crop_cycle_id VARCHAR(255) NOT NULL,
+		crop_stage_id VARCHAR(20) NOT NULL,
+		started_at DATETIME NOT NULL,
+		source VARCHAR(20) NOT NULL,
+		activity_id VARCHAR(255),
+		notes TEXT
+	)`
+
+// daysAgo returns midnight UTC the given number of days before today
+func daysAgo(n int) time.Time {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	return today.AddDate(0, 0, -n)
+}
+
+// createSownCycle stores a cycle of CROP1 sown the given number of days ago, or unsown when
+// sownDaysAgo is negative
+func createSownCycle(t *testing.T, db *gorm.DB, farmID, status string, sownDaysAgo int) *cropCycleEntity.CropCycle {
+	cycle := &cropCycleEntity.CropCycle{
+		BaseModel: *base.NewBaseModel("CRCY", hash.Medium),
+		FarmID:    farmID,
+		FarmerID:  "FMRR" + farmID,
+		Season:    "KHARIF",
+		Status:    status,
+		CropID:    "CROP1",
+	}
+	if sownDaysAgo >= 0 {
+		sown := daysAgo(sownDaysAgo)
+		cycle.StartDate = &sown
+	}
+	require.NoError(t, db.Create(cycle).Error)
+	return cycle
+}
+
+func recordStageStart(t *testing.T, db *gorm.DB, cycle *cropCycleEntity.CropCycle, cs *stageEntity.CropStage, startedDaysAgo int) {
+	transition := cropCycleEntity.NewStageTransition()
+	transition.CropCycleID, transition.CropStageID = cycle.ID, cs.ID
+	transition.StartedAt = daysAgo(startedDaysAgo)
+	transition.Source = cropCycleEntity.StageTransitionSourceManual
+	require.NoError(t, db.Create(transition).Error)
+}
+
+func TestListDelayedCycles(t *testing.T) {
+	db := newSQLiteDB(t, farmsTable, cropCyclesTable, stageTransitionsTable)
+	require.NoError(t, db.AutoMigrate(&crop.Crop{}, &stageEntity.Stage{}, &stageEntity.CropStage{}))
+	insertFarm(t, db, "FARM1", "org-1", "")
+	insertFarm(t, db, "FARM2", "org-1", "")
+	insertFarm(t, db, "FARM3", "org-2", "")
+
+	aaa := &MockAAAService{}
+	aaa.On("CheckPermission", mock.Anything, "user-1", "cycle", "list", "", "org-1").Return(true, nil)
+	aaa.On("CheckPermission", mock.Anything, "user-1", "cycle", "list", "", "org-2").Return(false, nil)
+	ctx := auth.SetUserInContext(context.Background(), &auth.UserContext{AAAUserID: "user-1"})
+	service := &FarmActivityServiceImpl{
+		cropCycleRepo:  crop_cycle.NewRepository(&sqliteManager{db: db}),
+		cropStageRepo:  stageRepo.NewCropStageRepository(&sqliteManager{db: db}),
+		transitionRepo: crop_cycle.NewStageTransitionRepository(db),
+		aaaService:     aaa,
+	}
+
+	// Germination lasts 10 days, then vegetative growth 20 and flowering 30
+	createCropStage(t, db, 1, days(10), stageEntity.DurationUnitDays)
+	vegetative := createCropStage(t, db, 2, days(20), stageEntity.DurationUnitDays)
+	createCropStage(t, db, 3, days(30), stageEntity.DurationUnitDays)
+
+	longGerminating := createSownCycle(t, db, "FARM2", "ACTIVE", 30) // 20 days past germination
+	lateVegetative := createSownCycle(t, db, "FARM1", "ACTIVE", 40)  // entered vegetative growth late
+	recordStageStart(t, db, lateVegetative, vegetative, 25)          // and is 10 days past its end
+	germinating := createSownCycle(t, db, "FARM1", "ACTIVE", 15)     // 5 days past germination
+	slightlyLate := createSownCycle(t, db, "FARM1", "PLANNED", 12)   // 2 days past germination
+	createSownCycle(t, db, "FARM1", "ACTIVE", 5)                     // on track
+	createSownCycle(t, db, "FARM1", "ACTIVE", -1)                    // not sown
+	createSownCycle(t, db, "FARM1", "COMPLETED", 100)                // ended
+	createSownCycle(t, db, "FARM3", "ACTIVE", 50)                    // another organisation's
+
+	listDelayed := func(req *requests.ListDelayedCyclesRequest) (*responses.DelayedCyclesResponse, []string) {
+		if req.OrgID == "" {
+			req.OrgID = "org-1"
+		}
+		result, err := service.ListDelayedCycles(ctx, req)
+		require.NoError(t, err)
+		response := result.(*responses.DelayedCyclesResponse)
+		ids := make([]string, len(response.Data))
+		for i, cycle := range response.Data {
+			ids[i] = cycle.CropCycleID
+		}
+		return response, ids
+	}
+
+	// Most delayed first
+	response, ids := listDelayed(&requests.ListDelayedCyclesRequest{})
+	assert.Equal(t, []string{longGerminating.ID, lateVegetative.ID, germinating.ID, slightlyLate.ID}, ids)
+	assert.Equal(t, []int{20, 10, 5, 2}, []int{response.Data[0].DelayDays, response.Data[1].DelayDays, response.Data[2].DelayDays, response.Data[3].DelayDays})
+	assert.Equal(t, vegetative.ID, response.Data[1].CurrentStageID)
+	assert.Equal(t, "Stage C", response.Data[1].CurrentStageName)
+	assert.Equal(t, 4, response.Pagination.Total)
+
+	_, ids = listDelayed(&requests.ListDelayedCyclesRequest{MinDelayDays: 5})
+	assert.Equal(t, []string{longGerminating.ID, lateVegetative.ID, germinating.ID}, ids)
+
+	_, ids = listDelayed(&requests.ListDelayedCyclesRequest{FarmID: "FARM1"})
+	assert.Equal(t, []string{lateVegetative.ID, germinating.ID, slightlyLate.ID}, ids)
+
+	// Pages are cut from the sorted list
+	response, ids = listDelayed(&requests.ListDelayedCyclesRequest{Page: 2, PageSize: 3})
+	assert.Equal(t, []string{slightlyLate.ID}, ids)
+	assert.Equal(t, 4, response.Pagination.Total)
+	response, ids = listDelayed(&requests.ListDelayedCyclesRequest{Page: 3, PageSize: 3})
+	assert.Empty(t, ids)
+	assert.Equal(t, 4, response.Pagination.Total)
+
+	// Out of range page sizes fall back to 20
+	response, ids = listDelayed(&requests.ListDelayedCyclesRequest{PageSize: 500})
+	assert.Len(t, ids, 4)
+	assert.Equal(t, 20, response.Pagination.PerPage)
+
+	_, err := service.ListDelayedCycles(ctx, &requests.ListDelayedCyclesRequest{BaseRequest: requests.BaseRequest{OrgID: "org-2"}})
+	assert.ErrorIs(t, err, common.ErrForbidden)
+}
@@ -0,0 +1,528 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/changefeed"
+	"github.com/Kisanlink/farmers-module/internal/entities/requests"
+	"github.com/Kisanlink/farmers-module/internal/entities/responses"
+	"github.com/Kisanlink/farmers-module/internal/interfaces"
+	changefeedRepo "github.com/Kisanlink/farmers-module/internal/repo/changefeed"
+	"github.com/Kisanlink/farmers-module/pkg/common"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultSyncChangesLimit is the page size of the change feed when none is requested
+	DefaultSyncChangesLimit = 200
+
+	// MaxSyncChangesLimit is the largest page of the change feed
+	MaxSyncChangesLimit = 1000
+
+	// syncSettleWindow holds back the most recent changes from the feed. A transaction that
+	// commits after a later one has been read could otherwise slip in behind a client's
+	// cursor; no write transaction is expected to take longer than this window.
+	syncSettleWindow = 5 * time.Second
+)
+
+// SyncService serves offline clients, such as the KisanSathi app: a feed of the farmers,
+// farms, crop cycles and farm activities changed since a cursor, and the application of
+// mutations made offline
+type SyncService interface {
+	// GetChanges returns the records in the user's scope changed since the request cursor.
+	// Records of a farmer newly assigned to the user may predate the cursor, so clients run
+	// a full sync, without a cursor, when their assignments change.
+	GetChanges(ctx context.Context, req *requests.SyncChangesRequest) (*responses.SyncChangesResponse, error)
+
+	// PushChanges applies offline mutations in order and reports the outcome of each
+	PushChanges(ctx context.Context, req *requests.SyncPushRequest) (*responses.SyncPushResponse, error)
+}
+
+// SyncServiceImpl implements SyncService. Pushed mutations go through the farmer, farm, crop
+// cycle and farm activity services, so they are validated and authorized exactly like the
+// equivalent API requests.
+type SyncServiceImpl struct {
+	feedRepo            changefeedRepo.ChangeFeedRepository
+	farmerService       FarmerService
+	farmService         FarmService
+	cropCycleService    CropCycleService
+	farmActivityService FarmActivityService
+	logger              interfaces.Logger
+	now                 func() time.Time
+}
+
+// NewSyncService creates a new sync service
+func NewSyncService(
+	feedRepo changefeedRepo.ChangeFeedRepository,
+	farmerService FarmerService,
+	farmService FarmService,
+	cropCycleService CropCycleService,
+	farmActivityService FarmActivityService,
+	logger interfaces.Logger,
+) SyncService {
+	return &SyncServiceImpl{
+		feedRepo:            feedRepo,
+		farmerService:       farmerService,
+		farmService:         farmService,
+		cropCycleService:    cropCycleService,
+		farmActivityService: farmActivityService,
+		logger:              logger,
+		now:                 time.Now,
+	}
+}
+
+// GetChanges returns a page of the change feed of the requesting user
+func (s *SyncServiceImpl) GetChanges(ctx context.Context, req *requests.SyncChangesRequest) (*responses.SyncChangesResponse, error) {
+	if req.UserID == "" {
+		return nil, common.ErrUnauthorized
+	}
+
+	cursor, err := changefeed.DecodeCursor(req.Cursor)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", common.ErrInvalidInput, err)
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = DefaultSyncChangesLimit
+	}
+	if limit > MaxSyncChangesLimit {
+		limit = MaxSyncChangesLimit
+	}
+
+	// Each entity contributes at most limit+1 changes; the feed is the first limit of them
+	// all and there is more to come when any is left over
+	until := s.now().Add(-syncSettleWindow)
+	var changes []*changefeed.Change
+	for _, entity := range changefeed.Entities {
+		entityChanges, err := s.feedRepo.ListChanges(ctx, req.UserID, entity, cursor, until, limit+1)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, entityChanges...)
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changeBefore(changes[i], changes[j])
+	})
+
+	hasMore := len(changes) > limit
+	if hasMore {
+		changes = changes[:limit]
+	}
+
+	data := &responses.SyncChangesData{
+		Changes:    make([]*responses.SyncRecordData, 0, len(changes)),
+		NextCursor: req.Cursor,
+		HasMore:    hasMore,
+	}
+	for _, change := range changes {
+		data.Changes = append(data.Changes, syncRecordData(change))
+	}
+	if len(changes) > 0 {
+		data.NextCursor = changefeed.CursorAt(changes[len(changes)-1]).Encode()
+	}
+
+	response := responses.NewSyncChangesResponse(data, "Changes retrieved successfully")
+	response.SetRequestID(req.RequestID)
+	return &response, nil
+}
+
+// PushChanges applies offline mutations. A mutation that fails does not stop the others,
+// but later mutations that depend on a record it should have created fail in turn.
+func (s *SyncServiceImpl) PushChanges(ctx context.Context, req *requests.SyncPushRequest) (*responses.SyncPushResponse, error) {
+	if req.UserID == "" {
+		return nil, common.ErrUnauthorized
+	}
+
+	// Server IDs of the records created by this push, by the ID the client gave them
+	createdIDs := make(map[string]string)
+
+	data := &responses.SyncPushData{Results: make([]*responses.SyncMutationResult, 0, len(req.Mutations))}
+	for i := range req.Mutations {
+		result := s.applyMutation(ctx, req, &req.Mutations[i], createdIDs)
+		switch changefeed.MutationStatus(result.Status) {
+		case changefeed.MutationApplied:
+			data.Applied++
+		case changefeed.MutationConflict:
+			data.Conflicts++
+		case changefeed.MutationRejected:
+			data.Rejected++
+		default:
+			data.Failed++
+		}
+		data.Results = append(data.Results, result)
+	}
+
+	s.logger.Info("Applied offline sync push",
+		zap.String("request_id", req.RequestID),
+		zap.String("user_id", req.UserID),
+		zap.Int("mutations", len(req.Mutations)),
+		zap.Int("applied", data.Applied),
+		zap.Int("conflicts", data.Conflicts),
+		zap.Int("rejected", data.Rejected),
+		zap.Int("failed", data.Failed),
+	)
+
+	response := responses.NewSyncPushResponse(data, "Changes pushed successfully")
+	response.SetRequestID(req.RequestID)
+	return &response, nil
+}
+
+// applyMutation applies one mutation. Changes to existing records are only applied when the
+// client edited the current server copy, identified by its updated_at; otherwise the result
+// is a conflict carrying the server copy for the client to merge and push again. The check
+// is optimistic: a write racing with the push between the check and the update is not
+// detected.
+func (s *SyncServiceImpl) applyMutation(ctx context.Context, req *requests.SyncPushRequest, m *requests.SyncMutation, createdIDs map[string]string) *responses.SyncMutationResult {
+	result := &responses.SyncMutationResult{
+		MutationID: m.MutationID,
+		Entity:     m.Entity,
+		Operation:  m.Operation,
+	}
+	entity := changefeed.Entity(m.Entity)
+	op := changefeed.Operation(m.Operation)
+
+	if !entity.Supports(op) {
+		return rejectMutation(result, fmt.Sprintf("operation %q is not supported for entity %q", m.Operation, m.Entity))
+	}
+
+	data, err := resolveCreatedIDs(m.Data, createdIDs)
+	if err != nil {
+		return rejectMutation(result, "data must be a JSON object")
+	}
+
+	if op == changefeed.OperationCreate {
+		id, err := s.create(ctx, req, entity, data)
+		if err != nil {
+			return s.failMutation(req, result, err)
+		}
+		result.ClientID = m.ID
+		result.ID = id
+		if m.ID != "" {
+			createdIDs[m.ID] = id
+		}
+		return s.appliedMutation(ctx, result, entity, id)
+	}
+
+	id := m.ID
+	if createdID, ok := createdIDs[id]; ok {
+		id = createdID
+	}
+	result.ID = id
+	if id == "" {
+		return rejectMutation(result, "id is required")
+	}
+
+	current, err := s.feedRepo.GetChange(ctx, entity, id)
+	if err != nil {
+		return s.failMutation(req, result, err)
+	}
+	if current != nil {
+		inScope, err := s.feedRepo.FarmerInScope(ctx, req.UserID, current.FarmerID)
+		if err != nil {
+			return s.failMutation(req, result, err)
+		}
+		if !inScope {
+			current = nil
+		}
+	}
+	if current == nil {
+		return rejectMutation(result, fmt.Sprintf("%s %s not found", m.Entity, id))
+	}
+
+	if current.Deleted() {
+		if op == changefeed.OperationDelete {
+			result.Status = string(changefeed.MutationApplied)
+			result.Server = syncRecordData(current)
+			return result
+		}
+		return conflictMutation(result, current)
+	}
+
+	// Records created by this push are current by definition
+	if _, created := createdIDs[m.ID]; !created {
+		if m.BaseUpdatedAt == nil {
+			return rejectMutation(result, "base_updated_at is required")
+		}
+		if !sameTimestamp(*m.BaseUpdatedAt, current.UpdatedAt) {
+			return conflictMutation(result, current)
+		}
+	}
+
+	if err := s.modify(ctx, req, entity, op, id, data); err != nil {
+		return s.failMutation(req, result, err)
+	}
+	return s.appliedMutation(ctx, result, entity, id)
+}
+
+// create creates a record through its domain service and returns its ID
+func (s *SyncServiceImpl) create(ctx context.Context, req *requests.SyncPushRequest, entity changefeed.Entity, data json.RawMessage) (string, error) {
+	switch entity {
+	case changefeed.EntityFarmer:
+		var createReq requests.CreateFarmerRequest
+		if err := decodeMutationData(req, data, &createReq, &createReq.BaseRequest); err != nil {
+			return "", err
+		}
+		response, err := s.farmerService.CreateFarmer(ctx, &createReq)
+		if err != nil {
+			return "", err
+		}
+		if response == nil || response.Data == nil {
+			return "", fmt.Errorf("failed to create farmer: empty response")
+		}
+		return response.Data.ID, nil
+
+	case changefeed.EntityFarm:
+		var createReq requests.CreateFarmRequest
+		if err := decodeMutationData(req, data, &createReq, &createReq.BaseRequest); err != nil {
+			return "", err
+		}
+		result, err := s.farmService.CreateFarm(ctx, &createReq)
+		if err != nil {
+			return "", err
+		}
+		response, ok := result.(*responses.FarmResponse)
+		if !ok || response.Data == nil {
+			return "", fmt.Errorf("failed to create farm: unexpected response")
+		}
+		return response.Data.ID, nil
+
+	case changefeed.EntityCropCycle:
+		var startReq requests.StartCycleRequest
+		if err := decodeMutationData(req, data, &startReq, &startReq.BaseRequest); err != nil {
+			return "", err
+		}
+		result, err := s.cropCycleService.StartCycle(ctx, &startReq)
+		if err != nil {
+			return "", err
+		}
+		response, ok := result.(responses.CropCycleResponse)
+		if !ok || response.Data == nil {
+			return "", fmt.Errorf("failed to start crop cycle: unexpected response")
+		}
+		return response.Data.ID, nil
+
+	case changefeed.EntityFarmActivity:
+		var createReq requests.CreateActivityRequest
+		if err := decodeMutationData(req, data, &createReq, &createReq.BaseRequest); err != nil {
+			return "", err
+		}
+		result, err := s.farmActivityService.CreateActivity(ctx, &createReq)
+		if err != nil {
+			return "", err
+		}
+		response, ok := result.(*responses.FarmActivityResponse)
+		if !ok || response.Data == nil {
+			return "", fmt.Errorf("failed to create farm activity: unexpected response")
+		}
+		return response.Data.ID, nil
+	}
+	return "", fmt.Errorf("%w: unknown entity %q", common.ErrInvalidInput, entity)
+}
+
+// modify updates, ends, completes or deletes an existing record through its domain service
+func (s *SyncServiceImpl) modify(ctx context.Context, req *requests.SyncPushRequest, entity changefeed.Entity, op changefeed.Operation, id string, data json.RawMessage) error {
+	switch {
+	case entity == changefeed.EntityFarmer && op == changefeed.OperationUpdate:
+		var updateReq requests.UpdateFarmerRequest
+		if err := decodeMutationData(req, data, &updateReq, &updateReq.BaseRequest); err != nil {
+			return err
+		}
+		updateReq.FarmerID, updateReq.AAAUserID = id, ""
+		_, err := s.farmerService.UpdateFarmer(ctx, &updateReq)
+		return err
+
+	case entity == changefeed.EntityFarmer && op == changefeed.OperationDelete:
+		deleteReq := requests.DeleteFarmerRequest{FarmerID: id}
+		deleteReq.SetRequestID(req.RequestID)
+		deleteReq.SetUserContext(req.UserID, req.OrgID)
+		return s.farmerService.DeleteFarmer(ctx, &deleteReq)
+
+	case entity == changefeed.EntityFarm && op == changefeed.OperationUpdate:
+		var updateReq requests.UpdateFarmRequest
+		if err := decodeMutationData(req, data, &updateReq, &updateReq.BaseRequest); err != nil {
+			return err
+		}
+		updateReq.ID = id
+		_, err := s.farmService.UpdateFarm(ctx, &updateReq)
+		return err
+
+	case entity == changefeed.EntityFarm && op == changefeed.OperationDelete:
+		deleteReq := requests.NewDeleteFarmRequest()
+		deleteReq.ID = id
+		deleteReq.SetRequestID(req.RequestID)
+		deleteReq.SetUserContext(req.UserID, req.OrgID)
+		return s.farmService.DeleteFarm(ctx, &deleteReq)
+
+	case entity == changefeed.EntityCropCycle && op == changefeed.OperationUpdate:
+		var updateReq requests.UpdateCycleRequest
+		if err := decodeMutationData(req, data, &updateReq, &updateReq.BaseRequest); err != nil {
+			return err
+		}
+		updateReq.ID = id
+		_, err := s.cropCycleService.UpdateCycle(ctx, &updateReq)
+		return err
+
+	case entity == changefeed.EntityCropCycle && op == changefeed.OperationEnd:
+		var endReq requests.EndCycleRequest
+		if err := decodeMutationData(req, data, &endReq, &endReq.BaseRequest); err != nil {
+			return err
+		}
+		endReq.ID = id
+		_, err := s.cropCycleService.EndCycle(ctx, &endReq)
+		return err
+
+	case entity == changefeed.EntityFarmActivity && op == changefeed.OperationUpdate:
+		var updateReq requests.UpdateActivityRequest
+		if err := decodeMutationData(req, data, &updateReq, &updateReq.BaseRequest); err != nil {
+			return err
+		}
+		updateReq.ID = id
+		_, err := s.farmActivityService.UpdateActivity(ctx, &updateReq)
+		return err
+
+	case entity == changefeed.EntityFarmActivity && op == changefeed.OperationComplete:
+		var completeReq requests.CompleteActivityRequest
+		if err := decodeMutationData(req, data, &completeReq, &completeReq.BaseRequest); err != nil {
+			return err
+		}
+		completeReq.ID = id
+		_, err := s.farmActivityService.CompleteActivity(ctx, &completeReq)
+		return err
+
+	case entity == changefeed.EntityFarmActivity && op == changefeed.OperationDelete:
+		return s.farmActivityService.DeleteActivity(ctx, id)
+	}
+	return fmt.Errorf("%w: operation %q is not supported for entity %q", common.ErrInvalidInput, op, entity)
+}
+
+// appliedMutation completes the result of an applied mutation with the new server copy
+func (s *SyncServiceImpl) appliedMutation(ctx context.Context, result *responses.SyncMutationResult, entity changefeed.Entity, id string) *responses.SyncMutationResult {
+	result.Status = string(changefeed.MutationApplied)
+	current, err := s.feedRepo.GetChange(ctx, entity, id)
+	if err != nil {
+		// The mutation is applied; the client picks up the server copy from the feed
+		s.logger.Warn("Failed to read synced record",
+			zap.String("entity", string(entity)),
+			zap.String("id", id),
+			zap.Error(err),
+		)
+		return result
+	}
+	if current != nil {
+		result.Server = syncRecordData(current)
+	}
+	return result
+}
+
+// failMutation reports an error of a domain service. Invalid and forbidden mutations are
+// rejected; other errors may be transient and the mutation can be pushed again.
+func (s *SyncServiceImpl) failMutation(req *requests.SyncPushRequest, result *responses.SyncMutationResult, err error) *responses.SyncMutationResult {
+	result.Error = err.Error()
+	if errors.Is(err, common.ErrInvalidInput) || errors.Is(err, common.ErrForbidden) || errors.Is(err, common.ErrNotFound) ||
+		common.IsValidationError(err) || common.IsPermissionError(err) || common.IsNotFoundError(err) {
+		result.Status = string(changefeed.MutationRejected)
+		return result
+	}
+
+	s.logger.Error("Failed to apply offline mutation",
+		zap.String("request_id", req.RequestID),
+		zap.String("mutation_id", result.MutationID),
+		zap.String("entity", result.Entity),
+		zap.String("operation", result.Operation),
+		zap.Error(err),
+	)
+	result.Status = string(changefeed.MutationFailed)
+	return result
+}
+
+func rejectMutation(result *responses.SyncMutationResult, message string) *responses.SyncMutationResult {
+	result.Status = string(changefeed.MutationRejected)
+	result.Error = message
+	return result
+}
+
+func conflictMutation(result *responses.SyncMutationResult, current *changefeed.Change) *responses.SyncMutationResult {
+	result.Status = string(changefeed.MutationConflict)
+	result.Error = fmt.Sprintf("%s %s was changed on the server", current.Entity, current.ID)
+	result.Server = syncRecordData(current)
+	return result
+}
+
+// decodeMutationData decodes the data of a mutation into a service request and gives it the
+// request ID and user of the push
+func decodeMutationData(req *requests.SyncPushRequest, data json.RawMessage, target interface{}, base *requests.BaseRequest) error {
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, target); err != nil {
+			return fmt.Errorf("%w: invalid mutation data: %v", common.ErrInvalidInput, err)
+		}
+	}
+	base.SetRequestID(req.RequestID)
+	base.SetUserContext(req.UserID, req.OrgID)
+	return nil
+}
+
+// syncReferenceFields are the fields of mutation data that refer to other synced records
+var syncReferenceFields = []string{"farmer_id", "farm_id", "crop_cycle_id"}
+
+// resolveCreatedIDs replaces references to records created earlier in the push, made with
+// the ID the client gave them, by their server IDs
+func resolveCreatedIDs(data json.RawMessage, createdIDs map[string]string) (json.RawMessage, error) {
+	if len(data) == 0 || len(createdIDs) == 0 {
+		return data, nil
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	resolved := false
+	for _, name := range syncReferenceFields {
+		if value, ok := fields[name].(string); ok {
+			if id, created := createdIDs[value]; created {
+				fields[name] = id
+				resolved = true
+			}
+		}
+	}
+	if !resolved {
+		return data, nil
+	}
+	return json.Marshal(fields)
+}
+
+// changeBefore orders changes by change time, entity rank and ID, the order of cursors
+func changeBefore(a, b *changefeed.Change) bool {
+	if at, bt := a.ChangedAt(), b.ChangedAt(); !at.Equal(bt) {
+		return at.Before(bt)
+	}
+	if a.Entity != b.Entity {
+		return a.Entity.Rank() < b.Entity.Rank()
+	}
+	return a.ID < b.ID
+}
+
+// sameTimestamp compares timestamps at the microsecond precision of PostgreSQL
+func sameTimestamp(a, b time.Time) bool {
+	return a.Truncate(time.Microsecond).Equal(b.Truncate(time.Microsecond))
+}
+
+func syncRecordData(change *changefeed.Change) *responses.SyncRecordData {
+	data := &responses.SyncRecordData{
+		Entity:    string(change.Entity),
+		ID:        change.ID,
+		UpdatedAt: change.UpdatedAt,
+		DeletedAt: change.DeletedAt,
+	}
+	if !change.Deleted() {
+		data.Record = change.Record
+	}
+	return data
+}
@@ -0,0 +1,233 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Kisanlink/farmers-module/internal/entities/changefeed"
+	farmEntity "github.com/Kisanlink/farmers-module/internal/entities/farm"
+	"github.com/Kisanlink/farmers-module/internal/entities/requests"
+	"github.com/Kisanlink/farmers-module/internal/entities/responses"
+	"github.com/Kisanlink/farmers-module/internal/interfaces"
+	"github.com/Kisanlink/farmers-module/pkg/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryChangeFeedRepo implements ChangeFeedRepository over in-memory changes
+type memoryChangeFeedRepo struct {
+	changes []*changefeed.Change
+	scope   map[string]bool
+}
+
+func (r *memoryChangeFeedRepo) ListChanges(ctx context.Context, userID string, entity changefeed.Entity, after changefeed.Cursor, until time.Time, limit int) ([]*changefeed.Change, error) {
+	var result []*changefeed.Change
+	for _, change := range r.changes {
+		if change.Entity != entity || !r.scope[change.FarmerID] || change.ChangedAt().After(until) {
+			continue
+		}
+		if !after.IsZero() {
+			position := &changefeed.Change{Entity: after.Entity, ID: after.ID, UpdatedAt: after.ChangedAt}
+			if !changeBefore(position, change) {
+				continue
+			}
+		}
+		result = append(result, change)
+	}
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+// GetChange returns a copy of the stored change, as a fresh read from the database would
+func (r *memoryChangeFeedRepo) GetChange(ctx context.Context, entity changefeed.Entity, id string) (*changefeed.Change, error) {
+	for _, change := range r.changes {
+		if change.Entity == entity && change.ID == id {
+			copied := *change
+			if farm, ok := change.Record.(*farmEntity.Farm); ok {
+				farmCopy := *farm
+				copied.Record = &farmCopy
+			}
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryChangeFeedRepo) FarmerInScope(ctx context.Context, userID, farmerID string) (bool, error) {
+	return r.scope[farmerID], nil
+}
+
+// syncFarmService creates and updates farms in a memoryChangeFeedRepo
+type syncFarmService struct {
+	FarmService
+	repo    *memoryChangeFeedRepo
+	now     time.Time
+	created int
+}
+
+func (s *syncFarmService) CreateFarm(ctx context.Context, req interface{}) (interface{}, error) {
+	createReq := req.(*requests.CreateFarmRequest)
+	if createReq.FarmerID == "" {
+		return nil, common.ErrInvalidFarmData
+	}
+	s.created++
+	id := "FARM0000000" + string(rune('0'+s.created))
+	s.repo.changes = append(s.repo.changes, &changefeed.Change{
+		Entity: changefeed.EntityFarm, ID: id, FarmerID: createReq.FarmerID, UpdatedAt: s.now,
+		Record: &farmEntity.Farm{FarmerID: createReq.FarmerID, Name: createReq.Name},
+	})
+	response := responses.NewFarmResponse(&responses.FarmData{ID: id, FarmerID: createReq.FarmerID}, "Farm created successfully")
+	return &response, nil
+}
+
+func (s *syncFarmService) UpdateFarm(ctx context.Context, req interface{}) (interface{}, error) {
+	updateReq := req.(*requests.UpdateFarmRequest)
+	for _, change := range s.repo.changes {
+		if change.Entity == changefeed.EntityFarm && change.ID == updateReq.ID {
+			s.now = s.now.Add(time.Minute)
+			change.UpdatedAt = s.now
+			change.Record.(*farmEntity.Farm).Name = updateReq.Name
+		}
+	}
+	return &responses.FarmResponse{}, nil
+}
+
+type nopSyncLogger struct {
+	interfaces.Logger
+}
+
+func (nopSyncLogger) Info(string, ...interface{})  {}
+func (nopSyncLogger) Warn(string, ...interface{})  {}
+func (nopSyncLogger) Error(string, ...interface{}) {}
+
+func newTestSyncService(repo *memoryChangeFeedRepo, farms FarmService, now time.Time) *SyncServiceImpl {
+	service := NewSyncService(repo, nil, farms, nil, nil, nopSyncLogger{}).(*SyncServiceImpl)
+	service.now = func() time.Time { return now }
+	return service
+}
+
+func TestSyncService_GetChangesPagesThroughFeed(t *testing.T) {
+	base := time.Date(2024, 11, 1, 10, 0, 0, 0, time.UTC)
+	deletedAt := base.Add(3 * time.Minute)
+	repo := &memoryChangeFeedRepo{
+		scope: map[string]bool{"FMRR0000000001": true},
+		changes: []*changefeed.Change{
+			{Entity: changefeed.EntityFarm, ID: "FARM00000002", FarmerID: "FMRR0000000001", UpdatedAt: base},
+			{Entity: changefeed.EntityFarmer, ID: "FMRR0000000001", FarmerID: "FMRR0000000001", UpdatedAt: base},
+			{Entity: changefeed.EntityFarm, ID: "FARM00000001", FarmerID: "FMRR0000000001", UpdatedAt: base, DeletedAt: &deletedAt},
+			{Entity: changefeed.EntityFarm, ID: "FARM00000003", FarmerID: "FMRR0000000009", UpdatedAt: base},
+			{Entity: changefeed.EntityCropCycle, ID: "CRCY00000001", FarmerID: "FMRR0000000001", UpdatedAt: base.Add(time.Minute)},
+			// Changed within the settle window, held back until it has settled
+			{Entity: changefeed.EntityFarm, ID: "FARM00000004", FarmerID: "FMRR0000000001", UpdatedAt: base.Add(time.Hour)},
+		},
+	}
+	service := newTestSyncService(repo, nil, base.Add(time.Hour))
+	ctx := context.Background()
+
+	var ids []string
+	cursor := ""
+	for page := 0; page < 5; page++ {
+		response, err := service.GetChanges(ctx, &requests.SyncChangesRequest{
+			BaseRequest: requests.BaseRequest{UserID: "USER00000001"},
+			Cursor:      cursor,
+			Limit:       2,
+		})
+		require.NoError(t, err)
+		for _, change := range response.Data.Changes {
+			ids = append(ids, change.ID)
+		}
+		cursor = response.Data.NextCursor
+		if !response.Data.HasMore {
+			break
+		}
+	}
+
+	// Parents come first at equal timestamps; the deletion sorts at its deletion time
+	assert.Equal(t, []string{"FMRR0000000001", "FARM00000002", "CRCY00000001", "FARM00000001"}, ids)
+
+	// Polling again from the last cursor returns nothing new
+	response, err := service.GetChanges(ctx, &requests.SyncChangesRequest{
+		BaseRequest: requests.BaseRequest{UserID: "USER00000001"},
+		Cursor:      cursor,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, response.Data.Changes)
+	assert.Equal(t, cursor, response.Data.NextCursor)
+
+	_, err = service.GetChanges(ctx, &requests.SyncChangesRequest{
+		BaseRequest: requests.BaseRequest{UserID: "USER00000001"},
+		Cursor:      "not-a-cursor",
+	})
+	assert.ErrorIs(t, err, common.ErrInvalidInput)
+}
+
+func TestSyncService_PushChanges(t *testing.T) {
+	base := time.Date(2024, 11, 1, 10, 0, 0, 0, time.UTC)
+	deletedAt := base.Add(time.Minute)
+	name := "North field"
+	repo := &memoryChangeFeedRepo{
+		scope: map[string]bool{"FMRR0000000001": true},
+		changes: []*changefeed.Change{
+			{Entity: changefeed.EntityFarm, ID: "FARM00000101", FarmerID: "FMRR0000000001", UpdatedAt: base, Record: &farmEntity.Farm{Name: &name}},
+			{Entity: changefeed.EntityFarm, ID: "FARM00000102", FarmerID: "FMRR0000000001", UpdatedAt: base, DeletedAt: &deletedAt},
+			{Entity: changefeed.EntityFarm, ID: "FARM00000103", FarmerID: "FMRR0000000009", UpdatedAt: base},
+		},
+	}
+	farms := &syncFarmService{repo: repo, now: base.Add(time.Hour)}
+	service := newTestSyncService(repo, farms, base.Add(2*time.Hour))
+
+	stale := base.Add(-time.Minute)
+	response, err := service.PushChanges(context.Background(), &requests.SyncPushRequest{
+		BaseRequest: requests.BaseRequest{UserID: "USER00000001"},
+		Mutations: []requests.SyncMutation{
+			{MutationID: "m1", Entity: "farm", Operation: "create", ID: "local-1", Data: json.RawMessage(`{"farmer_id":"FMRR0000000001","aaa_org_id":"ORGN00000001"}`)},
+			{MutationID: "m2", Entity: "farm", Operation: "update", ID: "local-1", Data: json.RawMessage(`{"name":"Created offline"}`)},
+			{MutationID: "m3", Entity: "farm", Operation: "update", ID: "FARM00000101", BaseUpdatedAt: &stale, Data: json.RawMessage(`{"name":"Stale edit"}`)},
+			{MutationID: "m4", Entity: "farm", Operation: "update", ID: "FARM00000101", BaseUpdatedAt: &base, Data: json.RawMessage(`{"name":"Current edit"}`)},
+			{MutationID: "m5", Entity: "farm", Operation: "delete", ID: "FARM00000102", BaseUpdatedAt: &base},
+			{MutationID: "m6", Entity: "farm", Operation: "update", ID: "FARM00000103", BaseUpdatedAt: &base, Data: json.RawMessage(`{}`)},
+			{MutationID: "m7", Entity: "farm", Operation: "create", Data: json.RawMessage(`{"aaa_org_id":"ORGN00000001"}`)},
+			{MutationID: "m8", Entity: "crop_cycle", Operation: "delete", ID: "CRCY00000001", BaseUpdatedAt: &base},
+		},
+	})
+	require.NoError(t, err)
+
+	results := response.Data.Results
+	require.Len(t, results, 8)
+
+	// Created offline, then edited through its client ID
+	assert.Equal(t, "APPLIED", results[0].Status)
+	assert.Equal(t, "local-1", results[0].ClientID)
+	assert.Equal(t, "FARM00000001", results[0].ID)
+	assert.Equal(t, "APPLIED", results[1].Status)
+	assert.Equal(t, "FARM00000001", results[1].ID)
+	assert.Equal(t, "Created offline", *results[1].Server.Record.(*farmEntity.Farm).Name)
+
+	// An edit of an outdated copy conflicts and returns the server copy
+	assert.Equal(t, "CONFLICT", results[2].Status)
+	require.NotNil(t, results[2].Server)
+	assert.Equal(t, "North field", *results[2].Server.Record.(*farmEntity.Farm).Name)
+	assert.True(t, results[2].Server.UpdatedAt.Equal(base))
+
+	assert.Equal(t, "APPLIED", results[3].Status)
+	assert.Equal(t, "Current edit", *results[3].Server.Record.(*farmEntity.Farm).Name)
+
+	// Deleting a deleted record is a no-op
+	assert.Equal(t, "APPLIED", results[4].Status)
+	require.NotNil(t, results[4].Server.DeletedAt)
+
+	// Records outside the caller's scope are not revealed
+	assert.Equal(t, "REJECTED", results[5].Status)
+	assert.Contains(t, results[5].Error, "not found")
+
+	assert.Equal(t, "REJECTED", results[6].Status)
+	assert.Equal(t, "REJECTED", results[7].Status)
+
+	assert.Equal(t, 4, response.Data.Applied)
+	assert.Equal(t, 1, response.Data.Conflicts)
+	assert.Equal(t, 3, response.Data.Rejected)
+}
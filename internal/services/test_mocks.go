@@ -282,7 +282,7 @@ func (m *MockFarmerLinkageRepoShared) GetByID(ctx context.Context, id string) (*
 	return args.Get(0).(*farmerentity.FarmerLink), args.Error(1)
 }
 
-func (m *MockFarmerLinkageRepoShared) Delete(ctx context.Context, id string) error {
+func (m *MockFarmerLinkageRepoShared) Delete(ctx context.Context, id string, model *farmerentity.FarmerLink) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
@@ -378,6 +378,21 @@ func (m *MockDataQualityService) DetectFarmOverlaps(ctx context.Context, req any
 	return args.Get(0), args.Error(1)
 }
 
+func (m *MockDataQualityService) ListOverlapConflicts(ctx context.Context, req any) (any, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0), args.Error(1)
+}
+
+func (m *MockDataQualityService) UpdateOverlapConflict(ctx context.Context, req any) (any, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0), args.Error(1)
+}
+
+func (m *MockDataQualityService) ResolveOverlapConflict(ctx context.Context, req any) (any, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0), args.Error(1)
+}
+
 // MockNotificationService is a mock implementation of NotificationService for testing
 type MockNotificationService struct {
 	mock.Mock
@@ -507,7 +522,7 @@ func (m *MockFarmerRepository) GetByID(ctx context.Context, id string) (*farmere
 	return args.Get(0).(*farmerentity.Farmer), args.Error(1)
 }
 
-func (m *MockFarmerRepository) Delete(ctx context.Context, id string) error {
+func (m *MockFarmerRepository) Delete(ctx context.Context, id string, model *farmerentity.Farmer) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
@@ -624,6 +639,42 @@ func (m *MockFarmService) ListFarms(ctx context.Context, req interface{}) (inter
 	return args.Get(0), args.Error(1)
 }
 
+func (m *MockFarmService) SearchFarms(ctx context.Context, req interface{}) (interface{}, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0), args.Error(1)
+}
+
+func (m *MockFarmService) ExportFarms(ctx context.Context, req interface{}) (interface{}, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0), args.Error(1)
+}
+
+func (m *MockFarmService) GetFarmTile(ctx context.Context, req interface{}) ([]byte, error) {
+	args := m.Called(ctx, req)
+	tile, _ := args.Get(0).([]byte)
+	return tile, args.Error(1)
+}
+
+func (m *MockFarmService) ListFarmGeometryVersions(ctx context.Context, farmID string) (interface{}, error) {
+	args := m.Called(ctx, farmID)
+	return args.Get(0), args.Error(1)
+}
+
+func (m *MockFarmService) DiffFarmGeometryVersions(ctx context.Context, req interface{}) (interface{}, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0), args.Error(1)
+}
+
+func (m *MockFarmService) GetFarmGeometryAsOf(ctx context.Context, req interface{}) (interface{}, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0), args.Error(1)
+}
+
+func (m *MockFarmService) ValidateGeometry(ctx context.Context, wkt string) error {
+	args := m.Called(ctx, wkt)
+	return args.Error(0)
+}
+
 func (m *MockFarmService) GetFarmsByFarmer(ctx context.Context, farmerID string) (interface{}, error) {
 	args := m.Called(ctx, farmerID)
 	return args.Get(0), args.Error(1)
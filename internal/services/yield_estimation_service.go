@@ -0,0 +1,164 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Kisanlink/farmers-module/internal/auth"
+	cropCycleEntity "github.com/Kisanlink/farmers-module/internal/entities/crop_cycle"
+	"github.com/Kisanlink/farmers-module/internal/entities/requests"
+	"github.com/Kisanlink/farmers-module/internal/entities/responses"
+	"github.com/Kisanlink/farmers-module/internal/repo/crop_cycle"
+	"github.com/Kisanlink/farmers-module/pkg/common"
+)
+
+// GetYieldEstimate gets the yield expected of a crop cycle from its variety's benchmarks and,
+// when its outcome records a yield, how the actual yield compares with it. The comparison
+// uses the benchmarks as they are now; the one recorded when the cycle ended is kept in its
+// outcome.
+func (s *CropCycleServiceImpl) GetYieldEstimate(ctx context.Context, req interface{}) (interface{}, error) {
+	estimateReq, ok := req.(*requests.GetYieldEstimateRequest)
+	if !ok {
+		return nil, common.ErrInvalidInput
+	}
+
+	// Extract authenticated user from context
+	userCtx, err := auth.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user context: %w", err)
+	}
+
+	// Check if authenticated user can read crop cycle
+	hasPermission, err := s.aaaService.CheckPermission(ctx, userCtx.AAAUserID, "cycle", "read", estimateReq.ID, estimateReq.OrgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !hasPermission {
+		return nil, common.ErrForbidden
+	}
+
+	cycle, err := s.cropCycleRepo.GetWithBenchmarks(ctx, estimateReq.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &responses.YieldEstimateData{
+		CropCycleID: cycle.ID,
+		FarmID:      cycle.FarmID,
+		CropID:      cycle.CropID,
+		CropName:    cycle.GetCropName(),
+		VarietyID:   cycle.VarietyID,
+		VarietyName: cycle.GetVarietyName(),
+		Season:      cycle.Season,
+		Status:      cycle.Status,
+		AreaHa:      cycle.AreaHa,
+		Estimate:    cycle.EstimateYield(),
+	}
+	if perHectare, total, unit := cycle.CanonicalYield(); unit != "" {
+		data.Actual = &cropCycleEntity.NormalizedYield{YieldUnit: unit, YieldPerHectare: perHectare, TotalYield: total}
+		data.Variance = cycle.YieldVariance(data.Estimate)
+	}
+
+	response := responses.NewYieldEstimateResponse(data, "Yield estimate retrieved successfully")
+	response.SetRequestID(estimateReq.RequestID)
+	return &response, nil
+}
+
+// GetProductionForecast estimates the production of an organisation's planned and active crop
+// cycles from their varieties' benchmarks and adds it up by crop and season, optionally for a
+// farm, a crop, a season and cycles started in a year
+func (s *CropCycleServiceImpl) GetProductionForecast(ctx context.Context, req interface{}) (interface{}, error) {
+	forecastReq, ok := req.(*requests.ProductionForecastRequest)
+	if !ok {
+		return nil, common.ErrInvalidInput
+	}
+
+	// Extract authenticated user from context
+	userCtx, err := auth.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user context: %w", err)
+	}
+
+	// Check if authenticated user can list crop cycles
+	hasPermission, err := s.aaaService.CheckPermission(ctx, userCtx.AAAUserID, "cycle", "list", "", forecastReq.OrgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permission: %w", err)
+	}
+	if !hasPermission {
+		return nil, common.ErrForbidden
+	}
+
+	if forecastReq.OrgID == "" {
+		return nil, fmt.Errorf("%w: organization context is required", common.ErrInvalidInput)
+	}
+
+	cycles, err := s.cropCycleRepo.ListOpenCycles(ctx, crop_cycle.OpenCycleFilter{
+		OrgID:  forecastReq.OrgID,
+		FarmID: forecastReq.FarmID,
+		CropID: forecastReq.CropID,
+		Season: forecastReq.Season,
+		Year:   forecastReq.Year,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	data := &responses.ProductionForecastData{
+		FarmID:      forecastReq.FarmID,
+		CropID:      forecastReq.CropID,
+		Season:      forecastReq.Season,
+		Year:        forecastReq.Year,
+		TotalCycles: len(cycles),
+		GeneratedAt: time.Now(),
+	}
+	byCropSeason := make(map[string]*responses.CropForecastData)
+	productionWithArea := make(map[*responses.CropForecastData]float64)
+	for _, cycle := range cycles {
+		key := cycle.CropID + "|" + cycle.Season
+		crop, ok := byCropSeason[key]
+		if !ok {
+			crop = &responses.CropForecastData{CropID: cycle.CropID, CropName: cycle.GetCropName(), Season: cycle.Season}
+			byCropSeason[key] = crop
+			data.Crops = append(data.Crops, crop)
+		}
+		crop.Cycles++
+		if cycle.AreaHa != nil {
+			crop.AreaHa += *cycle.AreaHa
+		}
+
+		estimate := cycle.EstimateYield()
+		if estimate == nil || estimate.TotalYield == nil {
+			data.UnestimatedCycles++
+			continue
+		}
+		crop.YieldUnit = estimate.YieldUnit
+		crop.EstimatedCycles++
+		crop.EstimatedProduction += *estimate.TotalYield
+		if cycle.AreaHa != nil {
+			crop.EstimatedAreaHa += *cycle.AreaHa
+			productionWithArea[crop] += *estimate.TotalYield
+		}
+	}
+
+	// Yields are given to two decimal places
+	for _, crop := range data.Crops {
+		if crop.EstimatedAreaHa > 0 {
+			perHectare := math.Round(productionWithArea[crop]/crop.EstimatedAreaHa*100) / 100
+			crop.YieldPerHectare = &perHectare
+		}
+		crop.EstimatedProduction = math.Round(crop.EstimatedProduction*100) / 100
+	}
+	sort.SliceStable(data.Crops, func(i, j int) bool {
+		if data.Crops[i].CropName != data.Crops[j].CropName {
+			return data.Crops[i].CropName < data.Crops[j].CropName
+		}
+		return data.Crops[i].Season < data.Crops[j].Season
+	})
+
+	response := responses.NewProductionForecastResponse(data, "Production forecast retrieved successfully")
+	response.SetRequestID(forecastReq.RequestID)
+	return &response, nil
+}
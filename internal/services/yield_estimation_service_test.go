@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Kisanlink/farmers-module/internal/auth"
+	"github.com/Kisanlink/farmers-module/internal/entities/crop"
+	cropCycleEntity "github.com/Kisanlink/farmers-module/internal/entities/crop_cycle"
+	"github.com/Kisanlink/farmers-module/internal/entities/crop_variety"
+	"github.com/Kisanlink/farmers-module/internal/entities/requests"
+	"github.com/Kisanlink/farmers-module/internal/entities/responses"
+	"github.com/Kisanlink/farmers-module/internal/repo/crop_cycle"
+	"github.com/Kisanlink/farmers-module/pkg/common"
+	"github.com/Kisanlink/farmers-module/pkg/units"
+	"github.com/Kisanlink/kisanlink-db/pkg/base"
+	"github.com/Kisanlink/kisanlink-db/pkg/core/hash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func createCrop(t *testing.T, db *gorm.DB, name, unit string) *crop.Crop {
+	c := crop.NewCrop()
+	c.Name, c.Unit, c.Category = name, unit, "CEREALS"
+	require.NoError(t, db.Create(c).Error)
+	return c
+}
+
+func createVariety(t *testing.T, db *gorm.DB, c *crop.Crop, yieldPerAcre float64) *crop_variety.CropVariety {
+	variety := crop_variety.NewCropVariety()
+	variety.CropID, variety.Name, variety.YieldPerAcre = c.ID, c.Name+" variety", &yieldPerAcre
+	require.NoError(t, db.Omit(clause.Associations).Create(variety).Error)
+	return variety
+}
+
+func TestGetProductionForecast(t *testing.T) {
+	db := newSQLiteDB(t, farmsTable, cropCyclesTable)
+	require.NoError(t, db.AutoMigrate(&crop.Crop{}, &crop_variety.CropVariety{}))
+	insertFarm(t, db, "FARM1", "org-1", "")
+	insertFarm(t, db, "FARM2", "org-1", "")
+	insertFarm(t, db, "FARM3", "org-2", "")
+
+	aaa := &MockAAAService{}
+	aaa.On("CheckPermission", mock.Anything, "user-1", "cycle", "list", "", "org-1").Return(true, nil)
+	aaa.On("CheckPermission", mock.Anything, "user-1", "cycle", "list", "", "org-2").Return(false, nil)
+	ctx := auth.SetUserInContext(context.Background(), &auth.UserContext{AAAUserID: "user-1"})
+	service := &CropCycleServiceImpl{cropCycleRepo: crop_cycle.NewRepository(&sqliteManager{db: db}), aaaService: aaa}
+
+	paddy := createCrop(t, db, "Paddy", "quintal")
+	cotton := createCrop(t, db, "Cotton", "kg")
+	paddyVariety := createVariety(t, db, paddy, 20)
+	cottonVariety := createVariety(t, db, cotton, 400)
+
+	createCycle := func(farmID string, c *crop.Crop, variety *crop_variety.CropVariety, season, status string, areaHa float64) {
+		cycle := &cropCycleEntity.CropCycle{
+			BaseModel: *base.NewBaseModel("CRCY", hash.Medium),
+			FarmID:    farmID,
+			FarmerID:  "FMRR" + farmID,
+			Season:    season,
+			Status:    status,
+			CropID:    c.ID,
+		}
+		if variety != nil {
+			cycle.VarietyID = &variety.ID
+		}
+		if areaHa > 0 {
+			cycle.AreaHa = &areaHa
+		}
+		require.NoError(t, db.Omit(clause.Associations).Create(cycle).Error)
+	}
+	createCycle("FARM1", paddy, paddyVariety, "KHARIF", "ACTIVE", 2)
+	createCycle("FARM1", paddy, paddyVariety, "KHARIF", "PLANNED", 1)
+	createCycle("FARM1", paddy, nil, "KHARIF", "ACTIVE", 1)          // no benchmark
+	createCycle("FARM1", paddy, paddyVariety, "KHARIF", "ACTIVE", 0) // no area
+	createCycle("FARM1", paddy, paddyVariety, "RABI", "PLANNED", 1)
+	createCycle("FARM2", cotton, cottonVariety, "KHARIF", "ACTIVE", 0.5)
+	createCycle("FARM1", paddy, paddyVariety, "KHARIF", "COMPLETED", 5) // ended
+	createCycle("FARM3", paddy, paddyVariety, "KHARIF", "ACTIVE", 5)    // another organisation's
+
+	acresPerHectare, err := units.Convert(1, units.Hectare, "acre", "")
+	require.NoError(t, err)
+	paddyPerHectare := 20 * acresPerHectare * 100 // quintals as kg
+	cottonPerHectare := 400 * acresPerHectare
+
+	forecast := func(req *requests.ProductionForecastRequest) *responses.ProductionForecastData {
+		req.OrgID = "org-1"
+		result, err := service.GetProductionForecast(ctx, req)
+		require.NoError(t, err)
+		return result.(*responses.ProductionForecastResponse).Data
+	}
+
+	// Crops are added up by crop and season, by crop name
+	data := forecast(&requests.ProductionForecastRequest{})
+	assert.Equal(t, 6, data.TotalCycles)
+	assert.Equal(t, 2, data.UnestimatedCycles)
+	require.Len(t, data.Crops, 3)
+
+	cottonKharif, paddyKharif, paddyRabi := data.Crops[0], data.Crops[1], data.Crops[2]
+	assert.Equal(t, "Cotton", cottonKharif.CropName)
+	assert.Equal(t, "kg", cottonKharif.YieldUnit)
+	assert.InDelta(t, cottonPerHectare*0.5, cottonKharif.EstimatedProduction, 0.01)
+
+	assert.Equal(t, "Paddy", paddyKharif.CropName)
+	assert.Equal(t, "KHARIF", paddyKharif.Season)
+	assert.Equal(t, "kg", paddyKharif.YieldUnit)
+	assert.Equal(t, 4, paddyKharif.Cycles)
+	assert.Equal(t, 2, paddyKharif.EstimatedCycles)
+	assert.Equal(t, 4.0, paddyKharif.AreaHa)
+	assert.Equal(t, 3.0, paddyKharif.EstimatedAreaHa)
+	assert.InDelta(t, paddyPerHectare*3, paddyKharif.EstimatedProduction, 0.05)
+	require.NotNil(t, paddyKharif.YieldPerHectare)
+	assert.InDelta(t, paddyPerHectare, *paddyKharif.YieldPerHectare, 0.01)
+
+	assert.Equal(t, "RABI", paddyRabi.Season)
+	assert.Equal(t, 1, paddyRabi.Cycles)
+	assert.InDelta(t, paddyPerHectare, paddyRabi.EstimatedProduction, 0.05)
+
+	// Filters narrow the cycles forecast
+	data = forecast(&requests.ProductionForecastRequest{FarmID: "FARM2"})
+	require.Len(t, data.Crops, 1)
+	assert.Equal(t, cotton.ID, data.Crops[0].CropID)
+
+	data = forecast(&requests.ProductionForecastRequest{CropID: paddy.ID, Season: "RABI"})
+	assert.Equal(t, 1, data.TotalCycles)
+	require.Len(t, data.Crops, 1)
+	assert.Equal(t, "RABI", data.Crops[0].Season)
+
+	data = forecast(&requests.ProductionForecastRequest{Season: "ZAID"})
+	assert.Zero(t, data.TotalCycles)
+	assert.Empty(t, data.Crops)
+
+	_, err = service.GetProductionForecast(ctx, &requests.ProductionForecastRequest{BaseRequest: requests.BaseRequest{OrgID: "org-2"}})
+	assert.ErrorIs(t, err, common.ErrForbidden)
+}
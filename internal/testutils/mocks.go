@@ -19,11 +19,19 @@ type MockBulkFarmerService struct {
 	BulkAddFarmersToFPOFunc    func(ctx context.Context, req *requests.BulkFarmerAdditionRequest) (*responses.BulkOperationData, error)
 	GetBulkOperationStatusFunc func(ctx context.Context, operationID string) (*responses.BulkOperationStatusData, error)
 	CancelBulkOperationFunc    func(ctx context.Context, operationID string) error
+	PauseBulkOperationFunc     func(ctx context.Context, operationID string) error
+	ResumeBulkOperationFunc    func(ctx context.Context, operationID string) (*responses.BulkOperationData, error)
 	RetryFailedRecordsFunc     func(ctx context.Context, req *requests.RetryBulkOperationRequest) (*responses.BulkOperationData, error)
 	ValidateBulkDataFunc       func(ctx context.Context, req *requests.ValidateBulkDataRequest) (*responses.BulkValidationData, error)
 	ParseBulkFileFunc          func(ctx context.Context, format string, data []byte) ([]*requests.FarmerBulkData, error)
-	GenerateResultFileFunc     func(ctx context.Context, operationID string, format string) ([]byte, error)
+	GenerateResultFileFunc     func(ctx context.Context, operationID string, format string, includeAll bool) ([]byte, error)
 	GetBulkUploadTemplateFunc  func(ctx context.Context, format string, includeExample bool) (*responses.BulkTemplateData, error)
+	CreateMappingProfileFunc   func(ctx context.Context, req *requests.ColumnMappingProfileRequest) (*responses.ColumnMappingProfileData, error)
+	UpdateMappingProfileFunc   func(ctx context.Context, req *requests.ColumnMappingProfileRequest) (*responses.ColumnMappingProfileData, error)
+	GetMappingProfileFunc      func(ctx context.Context, profileID, orgID string) (*responses.ColumnMappingProfileData, error)
+	ListMappingProfilesFunc    func(ctx context.Context, fpoOrgID, orgID string) ([]*responses.ColumnMappingProfileData, error)
+	DeleteMappingProfileFunc   func(ctx context.Context, profileID, orgID string) error
+	PreviewColumnMappingFunc   func(ctx context.Context, req *requests.PreviewColumnMappingRequest) (*responses.ColumnMappingPreviewData, error)
 }
 
 func (m *MockBulkFarmerService) BulkAddFarmersToFPO(ctx context.Context, req *requests.BulkFarmerAdditionRequest) (*responses.BulkOperationData, error) {
@@ -47,6 +55,20 @@ func (m *MockBulkFarmerService) CancelBulkOperation(ctx context.Context, operati
 	return nil
 }
 
+func (m *MockBulkFarmerService) PauseBulkOperation(ctx context.Context, operationID string) error {
+	if m.PauseBulkOperationFunc != nil {
+		return m.PauseBulkOperationFunc(ctx, operationID)
+	}
+	return nil
+}
+
+func (m *MockBulkFarmerService) ResumeBulkOperation(ctx context.Context, operationID string) (*responses.BulkOperationData, error) {
+	if m.ResumeBulkOperationFunc != nil {
+		return m.ResumeBulkOperationFunc(ctx, operationID)
+	}
+	return &responses.BulkOperationData{}, nil
+}
+
 func (m *MockBulkFarmerService) RetryFailedRecords(ctx context.Context, req *requests.RetryBulkOperationRequest) (*responses.BulkOperationData, error) {
 	if m.RetryFailedRecordsFunc != nil {
 		return m.RetryFailedRecordsFunc(ctx, req)
@@ -68,9 +90,9 @@ func (m *MockBulkFarmerService) ParseBulkFile(ctx context.Context, format string
 	return []*requests.FarmerBulkData{}, nil
 }
 
-func (m *MockBulkFarmerService) GenerateResultFile(ctx context.Context, operationID string, format string) ([]byte, error) {
+func (m *MockBulkFarmerService) GenerateResultFile(ctx context.Context, operationID string, format string, includeAll bool) ([]byte, error) {
 	if m.GenerateResultFileFunc != nil {
-		return m.GenerateResultFileFunc(ctx, operationID, format)
+		return m.GenerateResultFileFunc(ctx, operationID, format, includeAll)
 	}
 	return []byte{}, nil
 }
@@ -82,6 +104,72 @@ func (m *MockBulkFarmerService) GetBulkUploadTemplate(ctx context.Context, forma
 	return &responses.BulkTemplateData{}, nil
 }
 
+func (m *MockBulkFarmerService) CreateMappingProfile(ctx context.Context, req *requests.ColumnMappingProfileRequest) (*responses.ColumnMappingProfileData, error) {
+	if m.CreateMappingProfileFunc != nil {
+		return m.CreateMappingProfileFunc(ctx, req)
+	}
+	return &responses.ColumnMappingProfileData{}, nil
+}
+
+func (m *MockBulkFarmerService) UpdateMappingProfile(ctx context.Context, req *requests.ColumnMappingProfileRequest) (*responses.ColumnMappingProfileData, error) {
+	if m.UpdateMappingProfileFunc != nil {
+		return m.UpdateMappingProfileFunc(ctx, req)
+	}
+	return &responses.ColumnMappingProfileData{}, nil
+}
+
+func (m *MockBulkFarmerService) GetMappingProfile(ctx context.Context, profileID, orgID string) (*responses.ColumnMappingProfileData, error) {
+	if m.GetMappingProfileFunc != nil {
+		return m.GetMappingProfileFunc(ctx, profileID, orgID)
+	}
+	return &responses.ColumnMappingProfileData{}, nil
+}
+
+func (m *MockBulkFarmerService) ListMappingProfiles(ctx context.Context, fpoOrgID, orgID string) ([]*responses.ColumnMappingProfileData, error) {
+	if m.ListMappingProfilesFunc != nil {
+		return m.ListMappingProfilesFunc(ctx, fpoOrgID, orgID)
+	}
+	return []*responses.ColumnMappingProfileData{}, nil
+}
+
+func (m *MockBulkFarmerService) DeleteMappingProfile(ctx context.Context, profileID, orgID string) error {
+	if m.DeleteMappingProfileFunc != nil {
+		return m.DeleteMappingProfileFunc(ctx, profileID, orgID)
+	}
+	return nil
+}
+
+func (m *MockBulkFarmerService) PreviewColumnMapping(ctx context.Context, req *requests.PreviewColumnMappingRequest) (*responses.ColumnMappingPreviewData, error) {
+	if m.PreviewColumnMappingFunc != nil {
+		return m.PreviewColumnMappingFunc(ctx, req)
+	}
+	return &responses.ColumnMappingPreviewData{}, nil
+}
+
+// MockBulkFarmImportService provides a mock implementation for testing
+type MockBulkFarmImportService struct {
+	ImportFarmsFunc func(ctx context.Context, req *requests.BulkFarmImportRequest) (*responses.BulkOperationData, error)
+}
+
+func (m *MockBulkFarmImportService) ImportFarms(ctx context.Context, req *requests.BulkFarmImportRequest) (*responses.BulkOperationData, error) {
+	if m.ImportFarmsFunc != nil {
+		return m.ImportFarmsFunc(ctx, req)
+	}
+	return &responses.BulkOperationData{}, nil
+}
+
+// MockBulkCropCycleImportService provides a mock implementation for testing
+type MockBulkCropCycleImportService struct {
+	ImportCropCyclesFunc func(ctx context.Context, req *requests.BulkCropCycleImportRequest) (*responses.BulkOperationData, error)
+}
+
+func (m *MockBulkCropCycleImportService) ImportCropCycles(ctx context.Context, req *requests.BulkCropCycleImportRequest) (*responses.BulkOperationData, error) {
+	if m.ImportCropCyclesFunc != nil {
+		return m.ImportCropCyclesFunc(ctx, req)
+	}
+	return &responses.BulkOperationData{}, nil
+}
+
 // MockBulkOperationRepository provides a mock implementation for testing
 type MockBulkOperationRepository struct {
 	CreateFunc              func(ctx context.Context, operation *bulk.BulkOperation) error
@@ -89,6 +177,7 @@ type MockBulkOperationRepository struct {
 	UpdateFunc              func(ctx context.Context, operation *bulk.BulkOperation) error
 	UpdateStatusFunc        func(ctx context.Context, id string, status bulk.OperationStatus) error
 	UpdateProgressFunc      func(ctx context.Context, id string, processed, successful, failed, skipped int) error
+	SetResultFileURLFunc    func(ctx context.Context, id string, url string) error
 	ListFunc                func(ctx context.Context, filter *base.Filter) ([]*bulk.BulkOperation, error)
 	ListByFPOFunc           func(ctx context.Context, fpoOrgID string, filter *base.Filter) ([]*bulk.BulkOperation, error)
 	DeleteFunc              func(ctx context.Context, id string) error
@@ -130,6 +219,13 @@ func (m *MockBulkOperationRepository) UpdateProgress(ctx context.Context, id str
 	return nil
 }
 
+func (m *MockBulkOperationRepository) SetResultFileURL(ctx context.Context, id string, url string) error {
+	if m.SetResultFileURLFunc != nil {
+		return m.SetResultFileURLFunc(ctx, id, url)
+	}
+	return nil
+}
+
 func (m *MockBulkOperationRepository) List(ctx context.Context, filter *base.Filter) ([]*bulk.BulkOperation, error) {
 	if m.ListFunc != nil {
 		return m.ListFunc(ctx, filter)
@@ -3,6 +3,7 @@ package common
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -20,6 +21,10 @@ var (
 	ErrInvalidFarmActivityData = errors.New("invalid farm activity data")
 	ErrInvalidStageForCrop     = errors.New("crop stage does not belong to the crop")
 
+	// Idempotency errors
+	ErrIdempotencyKeyReused     = errors.New("idempotency key was already used for a different request")
+	ErrIdempotencyKeyInProgress = errors.New("a request with this idempotency key is still being processed")
+
 	// General errors
 	ErrNotFound      = errors.New("resource not found")
 	ErrUnauthorized  = errors.New("unauthorized")
@@ -43,6 +48,49 @@ func (e *AreaExceededError) Error() string {
 		e.RequestedArea, e.AvailableArea, e.FarmID, e.FarmArea, e.AllocatedArea)
 }
 
+// ParcelShareExceededError represents an error when claims on a land record parcel would add
+// up to more than the whole parcel
+type ParcelShareExceededError struct {
+	RecordKey    string // state/district/tehsil/village/survey number
+	Tenure       string
+	ClaimedPct   float64 // Share already claimed by other farms
+	RequestedPct float64
+}
+
+func (e *ParcelShareExceededError) Error() string {
+	return fmt.Sprintf("requested %s share of %.2f%% in survey number %s exceeds the %.2f%% not already claimed",
+		strings.ToLower(e.Tenure), e.RequestedPct, e.RecordKey, 100-e.ClaimedPct)
+}
+
+// FarmOverlapError represents an error when a farm boundary would overlap other farms of the
+// organization
+type FarmOverlapError struct {
+	OverlappingFarmIDs []string
+	OverlapAreaHa      float64
+}
+
+func (e *FarmOverlapError) Error() string {
+	return fmt.Sprintf("farm boundary overlaps %d existing farm(s) by %.4f ha: %s",
+		len(e.OverlappingFarmIDs), e.OverlapAreaHa, strings.Join(e.OverlappingFarmIDs, ", "))
+}
+
+// PlotOccupiedError represents an error when a crop cycle would be grown on ground that
+// another planned or active cycle of the farm already occupies
+type PlotOccupiedError struct {
+	PlotID         string
+	OccupiedByPlot string
+	OccupiedBy     string // Crop cycle on the overlapping plot
+	OverlapAreaHa  float64
+}
+
+func (e *PlotOccupiedError) Error() string {
+	if e.OccupiedByPlot == e.PlotID {
+		return fmt.Sprintf("plot %s is already occupied by crop cycle %s", e.PlotID, e.OccupiedBy)
+	}
+	return fmt.Sprintf("plot %s overlaps plot %s by %.4f ha, which crop cycle %s already occupies",
+		e.PlotID, e.OccupiedByPlot, e.OverlapAreaHa, e.OccupiedBy)
+}
+
 // ConcurrentModificationError represents an error when a resource was modified by another request
 type ConcurrentModificationError struct {
 	ResourceID   string
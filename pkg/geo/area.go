@@ -0,0 +1,326 @@
+package geo
+
+import "math"
+
+// WGS 84 ellipsoid
+const (
+	semiMajorAxis = 6378137.0
+	flattening    = 1 / 298.257223563
+)
+
+var (
+	eccentricitySq = flattening * (2 - flattening)
+	eccentricity   = math.Sqrt(eccentricitySq)
+)
+
+// SquareMetresPerHectare converts square metres to hectares
+const SquareMetresPerHectare = 10000.0
+
+// point is a position projected onto the plane, in metres
+type point struct{ x, y float64 }
+
+// projection is a Lambert cylindrical equal-area projection of the WGS 84 ellipsoid. Areas in
+// the plane equal areas on the ellipsoid, so planar area and clipping give geodesic areas.
+// Edges are straight in the projection rather than geodesics, which differs from PostGIS
+// geography by far less than the stored precision for polygons the size of farms.
+// Coordinates are taken relative to an origin so that small polygons keep full precision.
+type projection struct {
+	lon0, y0 float64
+}
+
+func newProjection(origin Position) projection {
+	return projection{lon0: origin[0], y0: authalicY(origin[1])}
+}
+
+func (p projection) project(pos Position) point {
+	return point{
+		x: semiMajorAxis * (pos[0] - p.lon0) * math.Pi / 180,
+		y: authalicY(pos[1]) - p.y0,
+	}
+}
+
+// authalicY returns the equal-area northing of a latitude: a·q(φ)/2
+func authalicY(lat float64) float64 {
+	sinLat := math.Sin(lat * math.Pi / 180)
+	esin := eccentricity * sinLat
+	q := (1 - eccentricitySq) * (sinLat/(1-esin*esin) - math.Log((1-esin)/(1+esin))/(2*eccentricity))
+	return semiMajorAxis * q / 2
+}
+
+// Area returns the geodesic area of the geometry in square metres. Holes are subtracted and
+// ring orientation is ignored.
+func Area(m MultiPolygon) float64 {
+	origin, ok := firstPosition(m)
+	if !ok {
+		return 0
+	}
+	proj := newProjection(origin)
+
+	total := 0.0
+	for _, polygon := range m {
+		for i, ring := range polygon {
+			area := math.Abs(signedArea(projectRing(proj, ring)))
+			if i == 0 {
+				total += area
+			} else {
+				total -= area
+			}
+		}
+	}
+	return math.Max(total, 0)
+}
+
+// AreaHa returns the geodesic area of the geometry in hectares
+func AreaHa(m MultiPolygon) float64 {
+	return Area(m) / SquareMetresPerHectare
+}
+
+// IntersectionArea returns the geodesic area shared by two geometries in square metres
+func IntersectionArea(a, b MultiPolygon) float64 {
+	if !a.Bounds().Intersects(b.Bounds()) {
+		return 0
+	}
+	origin, _ := firstPosition(a)
+	proj := newProjection(origin)
+
+	// Each geometry is the signed sum of the triangles fanned from a vertex to its edges, so
+	// the shared area is the signed sum of the pairwise triangle intersections
+	trianglesA := fanTriangles(proj, a)
+	trianglesB := fanTriangles(proj, b)
+
+	total := 0.0
+	for _, ta := range trianglesA {
+		for _, tb := range trianglesB {
+			if !ta.bounds.overlaps(tb.bounds) {
+				continue
+			}
+			total += ta.sign * tb.sign * math.Abs(signedArea(clipConvex(ta.vertices[:], tb.vertices[:])))
+		}
+	}
+	return math.Max(total, 0)
+}
+
+// IntersectionAreaHa returns the geodesic area shared by two geometries in hectares
+func IntersectionAreaHa(a, b MultiPolygon) float64 {
+	return IntersectionArea(a, b) / SquareMetresPerHectare
+}
+
+// Intersects reports whether two geometries share at least one point, including geometries
+// that only touch along an edge, as PostGIS ST_Intersects does
+func Intersects(a, b MultiPolygon) bool {
+	if !a.Bounds().Intersects(b.Bounds()) {
+		return false
+	}
+	for _, pa := range a {
+		for _, ra := range pa {
+			for _, pb := range b {
+				for _, rb := range pb {
+					if ringsCross(ra, rb) {
+						return true
+					}
+				}
+			}
+		}
+	}
+	// No edges meet, so either one geometry lies inside the other or they are disjoint
+	if origin, ok := firstPosition(a); ok && contains(b, origin) {
+		return true
+	}
+	if origin, ok := firstPosition(b); ok && contains(a, origin) {
+		return true
+	}
+	return false
+}
+
+func firstPosition(m MultiPolygon) (Position, bool) {
+	for _, polygon := range m {
+		for _, ring := range polygon {
+			if len(ring) > 0 {
+				return ring[0], true
+			}
+		}
+	}
+	return Position{}, false
+}
+
+func projectRing(proj projection, ring Ring) []point {
+	points := make([]point, len(ring))
+	for i, pos := range ring {
+		points[i] = proj.project(pos)
+	}
+	return points
+}
+
+// signedArea returns the shoelace area of a ring, positive when counter-clockwise
+func signedArea(points []point) float64 {
+	if len(points) < 3 {
+		return 0
+	}
+	sum := 0.0
+	for i := range points {
+		j := (i + 1) % len(points)
+		sum += points[i].x*points[j].y - points[j].x*points[i].y
+	}
+	return sum / 2
+}
+
+type box struct{ minX, minY, maxX, maxY float64 }
+
+func (b box) overlaps(other box) bool {
+	return b.minX < other.maxX && other.minX < b.maxX && b.minY < other.maxY && other.minY < b.maxY
+}
+
+// triangle is a counter-clockwise triangle weighted +1 or -1
+type triangle struct {
+	vertices [3]point
+	sign     float64
+	bounds   box
+}
+
+// fanTriangles decomposes a geometry into signed triangles. Outer rings are walked
+// counter-clockwise and holes clockwise, so the weights of the triangles covering any point
+// sum to one inside the geometry and to zero outside it.
+func fanTriangles(proj projection, m MultiPolygon) []triangle {
+	var triangles []triangle
+	for _, polygon := range m {
+		for i, ring := range polygon {
+			points := projectRing(proj, ring)
+			area := signedArea(points)
+			if area == 0 {
+				continue
+			}
+			ringSign := 1.0
+			if (i == 0) != (area > 0) {
+				ringSign = -1
+			}
+
+			apex := points[0]
+			for k := 1; k+1 < len(points); k++ {
+				t := [3]point{apex, points[k], points[k+1]}
+				orientation := signedArea(t[:])
+				if orientation == 0 {
+					continue
+				}
+				sign := ringSign
+				if orientation < 0 {
+					t[1], t[2] = t[2], t[1]
+					sign = -sign
+				}
+				triangles = append(triangles, triangle{vertices: t, sign: sign, bounds: triangleBounds(t)})
+			}
+		}
+	}
+	return triangles
+}
+
+func triangleBounds(t [3]point) box {
+	return box{
+		minX: math.Min(t[0].x, math.Min(t[1].x, t[2].x)),
+		minY: math.Min(t[0].y, math.Min(t[1].y, t[2].y)),
+		maxX: math.Max(t[0].x, math.Max(t[1].x, t[2].x)),
+		maxY: math.Max(t[0].y, math.Max(t[1].y, t[2].y)),
+	}
+}
+
+// clipConvex clips a polygon against a convex counter-clockwise polygon (Sutherland-Hodgman)
+func clipConvex(subject, clip []point) []point {
+	output := subject
+	for i := range clip {
+		if len(output) == 0 {
+			break
+		}
+		a, b := clip[i], clip[(i+1)%len(clip)]
+		input := output
+		output = make([]point, 0, len(input)+1)
+		for j := range input {
+			current, previous := input[j], input[(j+len(input)-1)%len(input)]
+			currentInside, previousInside := side(a, b, current) >= 0, side(a, b, previous) >= 0
+			if currentInside {
+				if !previousInside {
+					output = append(output, lineIntersection(a, b, previous, current))
+				}
+				output = append(output, current)
+			} else if previousInside {
+				output = append(output, lineIntersection(a, b, previous, current))
+			}
+		}
+	}
+	return output
+}
+
+// side is positive when p lies to the left of the directed line a→b
+func side(a, b, p point) float64 {
+	return (b.x-a.x)*(p.y-a.y) - (b.y-a.y)*(p.x-a.x)
+}
+
+// lineIntersection returns where segment p→q crosses the line a→b
+func lineIntersection(a, b, p, q point) point {
+	sp, sq := side(a, b, p), side(a, b, q)
+	t := sp / (sp - sq)
+	return point{x: p.x + t*(q.x-p.x), y: p.y + t*(q.y-p.y)}
+}
+
+// ringsCross reports whether any edge of one ring meets an edge of the other
+func ringsCross(a, b Ring) bool {
+	for i := 0; i+1 < len(a); i++ {
+		for j := 0; j+1 < len(b); j++ {
+			if segmentsMeet(a[i], a[i+1], b[j], b[j+1]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func orient(a, b, c Position) float64 {
+	return (b[0]-a[0])*(c[1]-a[1]) - (b[1]-a[1])*(c[0]-a[0])
+}
+
+func segmentsMeet(p1, p2, q1, q2 Position) bool {
+	d1, d2 := orient(q1, q2, p1), orient(q1, q2, p2)
+	d3, d4 := orient(p1, p2, q1), orient(p1, p2, q2)
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return true
+	}
+	return (d1 == 0 && onSegment(q1, q2, p1)) || (d2 == 0 && onSegment(q1, q2, p2)) ||
+		(d3 == 0 && onSegment(p1, p2, q1)) || (d4 == 0 && onSegment(p1, p2, q2))
+}
+
+// onSegment reports whether a point collinear with a→b lies between them
+func onSegment(a, b, p Position) bool {
+	return math.Min(a[0], b[0]) <= p[0] && p[0] <= math.Max(a[0], b[0]) &&
+		math.Min(a[1], b[1]) <= p[1] && p[1] <= math.Max(a[1], b[1])
+}
+
+// contains reports whether a position lies inside the geometry, outside its holes
+func contains(m MultiPolygon, pos Position) bool {
+	for _, polygon := range m {
+		if len(polygon) == 0 || !ringContains(polygon[0], pos) {
+			continue
+		}
+		inHole := false
+		for _, hole := range polygon[1:] {
+			if ringContains(hole, pos) {
+				inHole = true
+				break
+			}
+		}
+		if !inHole {
+			return true
+		}
+	}
+	return false
+}
+
+// ringContains tests a position against a ring by ray casting
+func ringContains(ring Ring, pos Position) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		a, b := ring[i], ring[j]
+		if (a[1] > pos[1]) != (b[1] > pos[1]) &&
+			pos[0] < (b[0]-a[0])*(pos[1]-a[1])/(b[1]-a[1])+a[0] {
+			inside = !inside
+		}
+	}
+	return inside
+}
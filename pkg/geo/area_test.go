@@ -0,0 +1,83 @@
+package geo
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustParse(t *testing.T, wkt string) MultiPolygon {
+	t.Helper()
+	geometry, err := ParseWKT(wkt)
+	require.NoError(t, err)
+	return geometry
+}
+
+func TestArea_MatchesPostGISGeography(t *testing.T) {
+	// SELECT ST_Area('POLYGON((0 0,0 1,1 1,1 0,0 0))'::geography) on PostGIS
+	degree := mustParse(t, "POLYGON((0 0,0 1,1 1,1 0,0 0))")
+	assert.InEpsilon(t, 12308778361.469, Area(degree), 1e-4)
+
+	// A 0.001° cell near Hyderabad against the ellipsoid's meridian and prime vertical radii
+	lat := 17.0005 * math.Pi / 180
+	w := 1 - eccentricitySq*math.Sin(lat)*math.Sin(lat)
+	meridian := semiMajorAxis * (1 - eccentricitySq) / math.Pow(w, 1.5)
+	primeVertical := semiMajorAxis / math.Sqrt(w)
+	step := 0.001 * math.Pi / 180
+	expected := meridian * step * primeVertical * math.Cos(lat) * step
+
+	cell := mustParse(t, "POLYGON((78 17,78.001 17,78.001 17.001,78 17.001,78 17))")
+	assert.InEpsilon(t, expected, Area(cell), 1e-6)
+	assert.InEpsilon(t, expected/10000, AreaHa(cell), 1e-6)
+
+	// Orientation does not matter
+	reversed := mustParse(t, "POLYGON((78 17,78 17.001,78.001 17.001,78.001 17,78 17))")
+	assert.InEpsilon(t, Area(cell), Area(reversed), 1e-12)
+}
+
+func TestArea_SubtractsHoles(t *testing.T) {
+	outer := mustParse(t, "POLYGON((78 17,78.002 17,78.002 17.002,78 17.002,78 17))")
+	withHole := mustParse(t, "POLYGON((78 17,78.002 17,78.002 17.002,78 17.002,78 17),(78.0005 17.0005,78.0015 17.0005,78.0015 17.0015,78.0005 17.0015,78.0005 17.0005))")
+	hole := mustParse(t, "POLYGON((78.0005 17.0005,78.0015 17.0005,78.0015 17.0015,78.0005 17.0015,78.0005 17.0005))")
+
+	assert.InEpsilon(t, Area(outer)-Area(hole), Area(withHole), 1e-9)
+	assert.InEpsilon(t, 0.75*Area(outer), Area(withHole), 1e-4)
+}
+
+func TestIntersectionArea(t *testing.T) {
+	square := mustParse(t, "POLYGON((78 17,78.002 17,78.002 17.002,78 17.002,78 17))")
+
+	// Overlapping by half of its width
+	shifted := mustParse(t, "POLYGON((78.001 17,78.003 17,78.003 17.002,78.001 17.002,78.001 17))")
+	assert.InEpsilon(t, Area(square)/2, IntersectionArea(square, shifted), 1e-4)
+	assert.InEpsilon(t, IntersectionArea(square, shifted), IntersectionArea(shifted, square), 1e-9)
+
+	// An L-shaped farm with a clockwise ring covers three quarters of the square
+	lShape := mustParse(t, "POLYGON((78 17,78 17.002,78.001 17.002,78.001 17.001,78.002 17.001,78.002 17,78 17))")
+	assert.InEpsilon(t, 0.75*Area(square), IntersectionArea(square, lShape), 1e-4)
+	assert.InEpsilon(t, Area(lShape), IntersectionArea(square, lShape), 1e-9)
+
+	// A hole removes its area from the overlap
+	withHole := mustParse(t, "POLYGON((78 17,78.002 17,78.002 17.002,78 17.002,78 17),(78.0005 17.0005,78.0015 17.0005,78.0015 17.0015,78.0005 17.0015,78.0005 17.0005))")
+	assert.InEpsilon(t, Area(withHole), IntersectionArea(square, withHole), 1e-9)
+
+	// Neighbours sharing a boundary intersect without overlapping
+	neighbour := mustParse(t, "POLYGON((78.002 17,78.004 17,78.004 17.002,78.002 17.002,78.002 17))")
+	assert.InDelta(t, 0, IntersectionArea(square, neighbour), 1e-6)
+	assert.True(t, Intersects(square, neighbour))
+
+	distant := mustParse(t, "POLYGON((79 17,79.002 17,79.002 17.002,79 17.002,79 17))")
+	assert.Zero(t, IntersectionArea(square, distant))
+	assert.False(t, Intersects(square, distant))
+
+	// A farm inside another intersects it without any crossing edges
+	inner := mustParse(t, "POLYGON((78.0005 17.0005,78.0015 17.0005,78.0015 17.0015,78.0005 17.0015,78.0005 17.0005))")
+	assert.True(t, Intersects(square, inner))
+	assert.True(t, Intersects(inner, square))
+	assert.True(t, Intersects(withHole, inner)) // along the rim of the hole
+	inHole := mustParse(t, "POLYGON((78.0008 17.0008,78.0012 17.0008,78.0012 17.0012,78.0008 17.0012,78.0008 17.0008))")
+	assert.False(t, Intersects(withHole, inHole))
+	assert.InEpsilon(t, Area(inner), IntersectionArea(square, inner), 1e-9)
+}
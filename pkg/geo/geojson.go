@@ -0,0 +1,131 @@
+package geo
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// geoJSONObject is the subset of an RFC 7946 object read by ParseGeoJSON
+type geoJSONObject struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates,omitempty"`
+	Geometry    *geoJSONObject  `json:"geometry,omitempty"`
+}
+
+// geoJSONGeometry is an RFC 7946 Polygon or MultiPolygon
+type geoJSONGeometry struct {
+	Type        string      `json:"type"`
+	Coordinates interface{} `json:"coordinates"`
+}
+
+// ParseGeoJSON reads an RFC 7946 Polygon or MultiPolygon geometry, or a Feature holding
+// one. Rings that are not closed are closed.
+func ParseGeoJSON(data []byte) (MultiPolygon, error) {
+	var object geoJSONObject
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, fmt.Errorf("invalid GeoJSON: %w", err)
+	}
+	if object.Type == "Feature" {
+		if object.Geometry == nil {
+			return nil, fmt.Errorf("GeoJSON feature has no geometry")
+		}
+		object = *object.Geometry
+	}
+
+	var geometry MultiPolygon
+	switch object.Type {
+	case "Polygon":
+		var coordinates [][][]float64
+		if err := json.Unmarshal(object.Coordinates, &coordinates); err != nil {
+			return nil, fmt.Errorf("invalid GeoJSON polygon coordinates: %w", err)
+		}
+		geometry = MultiPolygon{nil}
+		if err := readGeoJSONPolygon(coordinates, &geometry[0]); err != nil {
+			return nil, err
+		}
+	case "MultiPolygon":
+		var coordinates [][][][]float64
+		if err := json.Unmarshal(object.Coordinates, &coordinates); err != nil {
+			return nil, fmt.Errorf("invalid GeoJSON multipolygon coordinates: %w", err)
+		}
+		geometry = make(MultiPolygon, len(coordinates))
+		for i := range coordinates {
+			if err := readGeoJSONPolygon(coordinates[i], &geometry[i]); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, fmt.Errorf("only Polygon and MultiPolygon GeoJSON geometries are supported, got %q", object.Type)
+	}
+	if len(geometry) == 0 {
+		return nil, fmt.Errorf("geometry is empty")
+	}
+	return geometry, nil
+}
+
+func readGeoJSONPolygon(coordinates [][][]float64, polygon *Polygon) error {
+	if len(coordinates) == 0 {
+		return fmt.Errorf("polygon has no rings")
+	}
+	for _, positions := range coordinates {
+		ring := make(Ring, 0, len(positions)+1)
+		for _, position := range positions {
+			if len(position) < 2 {
+				return fmt.Errorf("GeoJSON position needs a longitude and a latitude")
+			}
+			lon, lat := position[0], position[1]
+			if lon < -180 || lon > 180 || lat < -90 || lat > 90 {
+				return fmt.Errorf("coordinate (%v %v) is not a WGS 84 longitude/latitude", lon, lat)
+			}
+			ring = append(ring, Position{lon, lat})
+		}
+		if len(ring) > 0 && ring[0] != ring[len(ring)-1] {
+			ring = append(ring, ring[0])
+		}
+		if len(ring) < 4 {
+			return fmt.Errorf("polygon ring needs at least 4 positions")
+		}
+		*polygon = append(*polygon, ring)
+	}
+	return nil
+}
+
+// GeoJSON returns the geometry as an RFC 7946 Polygon, or a MultiPolygon when it has
+// several parts. Rings follow the right-hand rule: exteriors counter-clockwise and holes
+// clockwise.
+func (m MultiPolygon) GeoJSON() json.RawMessage {
+	polygons := make([][][][2]float64, len(m))
+	for i, polygon := range m {
+		polygons[i] = make([][][2]float64, len(polygon))
+		for j, ring := range polygon {
+			counterClockwise := planarArea(ring) > 0
+			exterior := j == 0
+			positions := make([][2]float64, len(ring))
+			for k, p := range ring {
+				if counterClockwise == exterior {
+					positions[k] = p
+				} else {
+					positions[len(ring)-1-k] = p
+				}
+			}
+			polygons[i][j] = positions
+		}
+	}
+
+	geometry := geoJSONGeometry{Type: "MultiPolygon", Coordinates: polygons}
+	if len(polygons) == 1 {
+		geometry = geoJSONGeometry{Type: "Polygon", Coordinates: polygons[0]}
+	}
+	data, _ := json.Marshal(geometry)
+	return data
+}
+
+// planarArea returns the signed shoelace area of a ring in square degrees, positive when
+// counter-clockwise. It is only used for orientation.
+func planarArea(ring Ring) float64 {
+	sum := 0.0
+	for i := 0; i+1 < len(ring); i++ {
+		sum += ring[i][0]*ring[i+1][1] - ring[i+1][0]*ring[i][1]
+	}
+	return sum / 2
+}
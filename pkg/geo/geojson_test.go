@@ -0,0 +1,76 @@
+package geo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeoJSON_RoundTrip(t *testing.T) {
+	// Clockwise exterior with a counter-clockwise hole, the reverse of the right-hand rule
+	wkt := "POLYGON((78 17, 78 17.002, 78.002 17.002, 78.002 17, 78 17),(78.0005 17.0005, 78.0015 17.0005, 78.0015 17.0015, 78.0005 17.0015, 78.0005 17.0005))"
+	geometry := mustParse(t, wkt)
+
+	var object struct {
+		Type        string         `json:"type"`
+		Coordinates [][][2]float64 `json:"coordinates"`
+	}
+	require.NoError(t, json.Unmarshal(geometry.GeoJSON(), &object))
+	assert.Equal(t, "Polygon", object.Type)
+	assert.Equal(t, [2]float64{78.002, 17}, object.Coordinates[0][1], "exterior is counter-clockwise")
+	assert.Equal(t, [2]float64{78.0005, 17.0015}, object.Coordinates[1][1], "hole is clockwise")
+
+	parsed, err := ParseGeoJSON(geometry.GeoJSON())
+	require.NoError(t, err)
+	assert.InEpsilon(t, Area(geometry), Area(parsed), 1e-12)
+
+	// A Feature wrapping a MultiPolygon with unclosed rings
+	feature := `{"type":"Feature","properties":{"name":"North"},"geometry":{"type":"MultiPolygon","coordinates":[[[[78,17],[78.001,17],[78.001,17.001]]],[[[79,17],[79.001,17],[79.001,17.001],[79,17]]]]}}`
+	parsed, err = ParseGeoJSON([]byte(feature))
+	require.NoError(t, err)
+	require.Len(t, parsed, 2)
+	assert.Len(t, parsed[0][0], 4)
+	assert.Equal(t, "MULTIPOLYGON(((78 17, 78.001 17, 78.001 17.001, 78 17)),((79 17, 79.001 17, 79.001 17.001, 79 17)))", parsed.WKT())
+
+	for _, invalid := range []string{
+		`{"type":"Point","coordinates":[78,17]}`,
+		`{"type":"Feature","geometry":null}`,
+		`{"type":"Polygon","coordinates":[[[78,17],[78.001,17]]]}`,
+		`{"type":"Polygon","coordinates":[[[200,17],[78.001,17],[78.001,17.001],[200,17]]]}`,
+		`not json`,
+	} {
+		_, err := ParseGeoJSON([]byte(invalid))
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestParseGeometry_HexEWKB(t *testing.T) {
+	// SRID=4326;POLYGON Z((78 17 5,78.001 17 5,78.001 17.001 5,78 17 5)) as PostGIS returns it
+	var buf bytes.Buffer
+	buf.WriteByte(1)
+	binary.Write(&buf, binary.LittleEndian, uint32(wkbPolygon|ewkbZFlag|ewkbSRIDFlag))
+	binary.Write(&buf, binary.LittleEndian, uint32(4326))
+	binary.Write(&buf, binary.LittleEndian, uint32(1))
+	binary.Write(&buf, binary.LittleEndian, uint32(4))
+	for _, p := range [][3]float64{{78, 17, 5}, {78.001, 17, 5}, {78.001, 17.001, 5}, {78, 17, 5}} {
+		binary.Write(&buf, binary.LittleEndian, p)
+	}
+	encoded := hex.EncodeToString(buf.Bytes())
+
+	geometry, err := ParseGeometry(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, "POLYGON((78 17, 78.001 17, 78.001 17.001, 78 17))", geometry.WKT())
+	assert.Equal(t, "MULTIPOLYGON(((78 17, 78.001 17, 78.001 17.001, 78 17)))", geometry.MultiWKT())
+
+	_, err = ParseGeometry(encoded[:len(encoded)-16])
+	assert.Error(t, err)
+
+	geometry, err = ParseGeometry("POLYGON((78 17, 78.001 17, 78.001 17.001, 78 17))")
+	require.NoError(t, err)
+	assert.Len(t, geometry[0][0], 4)
+}
@@ -0,0 +1,53 @@
+// Package geo provides the polygon operations used on farm boundaries when PostGIS is not
+// available. Coordinates are WGS 84 longitude/latitude (EPSG:4326) and areas are geodesic,
+// matching PostGIS ST_Area on geography.
+package geo
+
+import "math"
+
+// Position is a longitude/latitude pair
+type Position [2]float64
+
+// Ring is a closed linear ring of positions
+type Ring []Position
+
+// Polygon is an outer ring followed by its holes
+type Polygon []Ring
+
+// MultiPolygon is a set of polygons. A POLYGON is read as a MultiPolygon with one part.
+type MultiPolygon []Polygon
+
+// Bounds is a longitude/latitude bounding box
+type Bounds struct {
+	MinLon, MinLat, MaxLon, MaxLat float64
+}
+
+// Bounds returns the bounding box of the geometry
+func (m MultiPolygon) Bounds() Bounds {
+	b := Bounds{MinLon: math.Inf(1), MinLat: math.Inf(1), MaxLon: math.Inf(-1), MaxLat: math.Inf(-1)}
+	for _, polygon := range m {
+		for _, ring := range polygon {
+			for _, p := range ring {
+				b.MinLon = math.Min(b.MinLon, p[0])
+				b.MinLat = math.Min(b.MinLat, p[1])
+				b.MaxLon = math.Max(b.MaxLon, p[0])
+				b.MaxLat = math.Max(b.MaxLat, p[1])
+			}
+		}
+	}
+	return b
+}
+
+// IsEmpty reports whether the box contains no positions
+func (b Bounds) IsEmpty() bool {
+	return b.MinLon > b.MaxLon || b.MinLat > b.MaxLat
+}
+
+// Intersects reports whether two boxes share at least one point
+func (b Bounds) Intersects(other Bounds) bool {
+	if b.IsEmpty() || other.IsEmpty() {
+		return false
+	}
+	return b.MinLon <= other.MaxLon && other.MinLon <= b.MaxLon &&
+		b.MinLat <= other.MaxLat && other.MinLat <= b.MaxLat
+}
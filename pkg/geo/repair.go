@@ -0,0 +1,218 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+)
+
+// Vertices closer than duplicateToleranceM are treated as one, and a vertex where the
+// boundary turns back on itself at less than spikeAngle is a spike
+const (
+	duplicateToleranceM = 0.01
+	spikeAngle          = 3 * math.Pi / 180
+)
+
+// RepairOptions controls Repair
+type RepairOptions struct {
+	// SimplifyToleranceM is the largest distance in metres a removed vertex may lie from the
+	// simplified boundary. Zero disables simplification.
+	SimplifyToleranceM float64
+}
+
+// Repair cleans a captured boundary: it removes duplicate vertices and spikes, drops rings
+// that collapse, simplifies within the tolerance and winds exteriors counter-clockwise and
+// holes clockwise. Vertices are only ever removed, never moved. It returns the repaired
+// geometry and a description of each kind of correction made; self-intersections are left
+// for PostGIS ST_MakeValid.
+func Repair(m MultiPolygon, opts RepairOptions) (MultiPolygon, []string, error) {
+	origin, ok := firstPosition(m)
+	if !ok {
+		return nil, nil, fmt.Errorf("geometry is empty")
+	}
+	proj := newProjection(origin)
+
+	var duplicates, spikes, dropped, reversed, before, after int
+	var repaired MultiPolygon
+	for _, polygon := range m {
+		var rings Polygon
+		for i, ring := range polygon {
+			vertices := openRing(ring)
+			kept := removeDuplicates(proj, vertices)
+			duplicates += len(vertices) - len(kept)
+			vertices = kept
+			kept = removeSpikes(proj, vertices)
+			spikes += len(vertices) - len(kept)
+			vertices = kept
+
+			if len(vertices) < 3 {
+				dropped++
+				if i == 0 {
+					// Without its exterior the polygon's holes are dropped too
+					dropped += len(polygon) - 1
+					break
+				}
+				continue
+			}
+
+			before += len(vertices)
+			if opts.SimplifyToleranceM > 0 {
+				vertices = simplifyRing(proj, vertices, opts.SimplifyToleranceM)
+			}
+			after += len(vertices)
+
+			closed := append(Ring{}, vertices...)
+			closed = append(closed, vertices[0])
+			if counterClockwise := planarArea(closed) > 0; counterClockwise != (i == 0) {
+				for l, r := 0, len(closed)-1; l < r; l, r = l+1, r-1 {
+					closed[l], closed[r] = closed[r], closed[l]
+				}
+				reversed++
+			}
+			rings = append(rings, closed)
+		}
+		if len(rings) > 0 {
+			repaired = append(repaired, rings)
+		}
+	}
+	if len(repaired) == 0 {
+		return nil, nil, fmt.Errorf("geometry has no area left after removing duplicate vertices and spikes")
+	}
+
+	var corrections []string
+	if duplicates > 0 {
+		corrections = append(corrections, fmt.Sprintf("removed %s", plural(duplicates, "duplicate vertex", "duplicate vertices")))
+	}
+	if spikes > 0 {
+		corrections = append(corrections, fmt.Sprintf("removed %s", plural(spikes, "spike", "spikes")))
+	}
+	if dropped > 0 {
+		corrections = append(corrections, fmt.Sprintf("dropped %s with no area", plural(dropped, "ring", "rings")))
+	}
+	if after < before {
+		corrections = append(corrections, fmt.Sprintf("simplified boundary from %d to %d vertices within %g m", before, after, opts.SimplifyToleranceM))
+	}
+	if reversed > 0 {
+		corrections = append(corrections, fmt.Sprintf("reversed the winding of %s", plural(reversed, "ring", "rings")))
+	}
+	return repaired, corrections, nil
+}
+
+func plural(n int, singular, pluralForm string) string {
+	if n == 1 {
+		return "1 " + singular
+	}
+	return fmt.Sprintf("%d %s", n, pluralForm)
+}
+
+// openRing returns the vertices of a ring without the closing position
+func openRing(ring Ring) []Position {
+	if len(ring) > 1 && ring[0] == ring[len(ring)-1] {
+		return ring[:len(ring)-1]
+	}
+	return ring
+}
+
+func distance(proj projection, a, b Position) float64 {
+	p, q := proj.project(a), proj.project(b)
+	return math.Hypot(p.x-q.x, p.y-q.y)
+}
+
+// removeDuplicates drops each vertex that repeats the vertex before it, the last one
+// included as it wraps round to the first
+func removeDuplicates(proj projection, vertices []Position) []Position {
+	var kept []Position
+	for _, v := range vertices {
+		if len(kept) > 0 && distance(proj, kept[len(kept)-1], v) < duplicateToleranceM {
+			continue
+		}
+		kept = append(kept, v)
+	}
+	for len(kept) > 1 && distance(proj, kept[len(kept)-1], kept[0]) < duplicateToleranceM {
+		kept = kept[:len(kept)-1]
+	}
+	return kept
+}
+
+// removeSpikes repeatedly drops vertices where the boundary doubles back, since removing
+// one spike can expose another behind it
+func removeSpikes(proj projection, vertices []Position) []Position {
+	kept := append([]Position(nil), vertices...)
+	for changed := true; changed && len(kept) >= 3; {
+		changed = false
+		for i := 0; i < len(kept) && len(kept) >= 3; i++ {
+			prev := proj.project(kept[(i+len(kept)-1)%len(kept)])
+			cur := proj.project(kept[i])
+			next := proj.project(kept[(i+1)%len(kept)])
+			ax, ay := prev.x-cur.x, prev.y-cur.y
+			bx, by := next.x-cur.x, next.y-cur.y
+			if math.Abs(math.Atan2(ax*by-ay*bx, ax*bx+ay*by)) < spikeAngle {
+				kept = append(kept[:i], kept[i+1:]...)
+				changed = true
+				i--
+			}
+		}
+	}
+	return kept
+}
+
+// simplifyRing applies Douglas-Peucker to a ring split at the vertex farthest from the first.
+// Rings that would fall below three vertices are returned unchanged.
+func simplifyRing(proj projection, vertices []Position, toleranceM float64) []Position {
+	points := make([]point, len(vertices)+1)
+	for i, v := range vertices {
+		points[i] = proj.project(v)
+	}
+	points[len(vertices)] = points[0]
+
+	far, farthest := 0, -1.0
+	for i, p := range points[:len(vertices)] {
+		if d := math.Hypot(p.x-points[0].x, p.y-points[0].y); d > farthest {
+			far, farthest = i, d
+		}
+	}
+
+	keep := make([]bool, len(points))
+	keep[0], keep[far] = true, true
+	douglasPeucker(points, 0, far, toleranceM, keep)
+	douglasPeucker(points, far, len(vertices), toleranceM, keep)
+
+	var simplified []Position
+	for i, v := range vertices {
+		if keep[i] {
+			simplified = append(simplified, v)
+		}
+	}
+	if len(simplified) < 3 {
+		return vertices
+	}
+	return simplified
+}
+
+func douglasPeucker(points []point, first, last int, toleranceM float64, keep []bool) {
+	if last-first < 2 {
+		return
+	}
+	index, maxDistance := -1, toleranceM
+	for i := first + 1; i < last; i++ {
+		if d := segmentDistance(points[first], points[last], points[i]); d > maxDistance {
+			index, maxDistance = i, d
+		}
+	}
+	if index < 0 {
+		return
+	}
+	keep[index] = true
+	douglasPeucker(points, first, index, toleranceM, keep)
+	douglasPeucker(points, index, last, toleranceM, keep)
+}
+
+// segmentDistance returns the distance from p to the segment ab
+func segmentDistance(a, b, p point) float64 {
+	dx, dy := b.x-a.x, b.y-a.y
+	lengthSq := dx*dx + dy*dy
+	if lengthSq == 0 {
+		return math.Hypot(p.x-a.x, p.y-a.y)
+	}
+	t := math.Max(0, math.Min(1, ((p.x-a.x)*dx+(p.y-a.y)*dy)/lengthSq))
+	return math.Hypot(p.x-a.x-t*dx, p.y-a.y-t*dy)
+}
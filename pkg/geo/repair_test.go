@@ -0,0 +1,43 @@
+package geo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepair(t *testing.T) {
+	// A clockwise ~200 m square walked with a repeated vertex, a GPS spike out of its south
+	// edge and an almost straight northern edge, and a counter-clockwise hole
+	geometry := mustParse(t, "POLYGON((78 17, 78 17.002, 78.001 17.0020001, 78.002 17.002, 78.002 17, 78.002 17, 78.0012 17, 78.001 16.99, 78.0008 17, 78 17),"+
+		"(78.0005 17.0005, 78.0015 17.0005, 78.0015 17.0015, 78.0005 17.0015, 78.0005 17.0005))")
+
+	repaired, corrections, err := Repair(geometry, RepairOptions{SimplifyToleranceM: 0.5})
+	require.NoError(t, err)
+	assert.Equal(t, "POLYGON((78 17, 78.002 17, 78.002 17.002, 78 17.002, 78 17),"+
+		"(78.0005 17.0005, 78.0005 17.0015, 78.0015 17.0015, 78.0015 17.0005, 78.0005 17.0005))", repaired.WKT())
+	assert.Equal(t, []string{
+		"removed 1 duplicate vertex",
+		"removed 1 spike",
+		"simplified boundary from 11 to 8 vertices within 0.5 m",
+		"reversed the winding of 2 rings",
+	}, corrections)
+
+	// A clean counter-clockwise polygon needs no corrections
+	clean := mustParse(t, "POLYGON((78 17, 78.001 17, 78.001 17.001, 78 17.001, 78 17))")
+	repaired, corrections, err = Repair(clean, RepairOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, clean, repaired)
+	assert.Empty(t, corrections)
+
+	// A part that collapses to a line is dropped; nothing left at all is an error
+	withSliver := mustParse(t, "MULTIPOLYGON(((78 17, 78.001 17, 78.001 17.001, 78 17)),((79 17, 79.001 17, 79 17, 79 17)))")
+	repaired, corrections, err = Repair(withSliver, RepairOptions{})
+	require.NoError(t, err)
+	assert.Len(t, repaired, 1)
+	assert.Contains(t, corrections, "dropped 1 ring with no area")
+
+	_, _, err = Repair(mustParse(t, "POLYGON((79 17, 79.001 17, 79 17, 79 17))"), RepairOptions{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,192 @@
+package geo
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// WKB geometry type codes and EWKB flags
+const (
+	wkbPolygon      = 3
+	wkbMultiPolygon = 6
+	ewkbZFlag       = 0x80000000
+	ewkbMFlag       = 0x40000000
+	ewkbSRIDFlag    = 0x20000000
+)
+
+// ParseGeometry reads a geometry as stored in the farms table: hex-encoded EWKB as PostGIS
+// returns it, or WKT
+func ParseGeometry(s string) (MultiPolygon, error) {
+	text := strings.TrimSpace(s)
+	if isHex(text) {
+		data, err := hex.DecodeString(text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hex WKB: %w", err)
+		}
+		return ParseWKB(data)
+	}
+	return ParseWKT(text)
+}
+
+func isHex(s string) bool {
+	if len(s) < 18 || len(s)%2 != 0 {
+		return false
+	}
+	for _, c := range s {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseWKB reads a POLYGON or MULTIPOLYGON in well-known binary or PostGIS extended WKB.
+// Z and M ordinates are dropped.
+func ParseWKB(data []byte) (MultiPolygon, error) {
+	r := &wkbReader{data: data}
+	geometryType, dims, err := r.header()
+	if err != nil {
+		return nil, err
+	}
+
+	var geometry MultiPolygon
+	switch geometryType {
+	case wkbPolygon:
+		polygon, err := r.polygon(dims)
+		if err != nil {
+			return nil, err
+		}
+		geometry = MultiPolygon{polygon}
+	case wkbMultiPolygon:
+		count, err := r.uint32()
+		if err != nil {
+			return nil, err
+		}
+		for i := uint32(0); i < count; i++ {
+			partType, partDims, err := r.header()
+			if err != nil {
+				return nil, err
+			}
+			if partType != wkbPolygon {
+				return nil, fmt.Errorf("multipolygon part has WKB type %d", partType)
+			}
+			polygon, err := r.polygon(partDims)
+			if err != nil {
+				return nil, err
+			}
+			geometry = append(geometry, polygon)
+		}
+	default:
+		return nil, fmt.Errorf("only POLYGON and MULTIPOLYGON geometries are supported, got WKB type %d", geometryType)
+	}
+	if len(geometry) == 0 {
+		return nil, fmt.Errorf("geometry is empty")
+	}
+	return geometry, nil
+}
+
+type wkbReader struct {
+	data  []byte
+	pos   int
+	order binary.ByteOrder
+}
+
+// header reads a byte order, a geometry type and any SRID, and returns the base type and
+// the number of ordinates per position
+func (r *wkbReader) header() (uint32, int, error) {
+	if r.pos >= len(r.data) {
+		return 0, 0, fmt.Errorf("truncated WKB")
+	}
+	switch r.data[r.pos] {
+	case 0:
+		r.order = binary.BigEndian
+	case 1:
+		r.order = binary.LittleEndian
+	default:
+		return 0, 0, fmt.Errorf("invalid WKB byte order %d", r.data[r.pos])
+	}
+	r.pos++
+
+	code, err := r.uint32()
+	if err != nil {
+		return 0, 0, err
+	}
+	dims := 2
+	if code&ewkbZFlag != 0 {
+		dims++
+	}
+	if code&ewkbMFlag != 0 {
+		dims++
+	}
+	if code&ewkbSRIDFlag != 0 {
+		srid, err := r.uint32()
+		if err != nil {
+			return 0, 0, err
+		}
+		if srid != 4326 {
+			return 0, 0, fmt.Errorf("geometry must use SRID 4326, got %d", srid)
+		}
+	}
+	code &^= ewkbZFlag | ewkbMFlag | ewkbSRIDFlag
+
+	// ISO WKB encodes Z, M and ZM as 1000, 2000 and 3000 added to the type
+	switch code / 1000 {
+	case 1, 2:
+		dims++
+	case 3:
+		dims += 2
+	}
+	return code % 1000, dims, nil
+}
+
+func (r *wkbReader) uint32() (uint32, error) {
+	if r.pos+4 > len(r.data) {
+		return 0, fmt.Errorf("truncated WKB")
+	}
+	value := r.order.Uint32(r.data[r.pos:])
+	r.pos += 4
+	return value, nil
+}
+
+func (r *wkbReader) float64() (float64, error) {
+	if r.pos+8 > len(r.data) {
+		return 0, fmt.Errorf("truncated WKB")
+	}
+	value := math.Float64frombits(r.order.Uint64(r.data[r.pos:]))
+	r.pos += 8
+	return value, nil
+}
+
+func (r *wkbReader) polygon(dims int) (Polygon, error) {
+	ringCount, err := r.uint32()
+	if err != nil {
+		return nil, err
+	}
+	polygon := make(Polygon, 0, ringCount)
+	for i := uint32(0); i < ringCount; i++ {
+		positionCount, err := r.uint32()
+		if err != nil {
+			return nil, err
+		}
+		if int(positionCount)*dims*8 > len(r.data)-r.pos {
+			return nil, fmt.Errorf("truncated WKB")
+		}
+		ring := make(Ring, positionCount)
+		for j := range ring {
+			for k := 0; k < dims; k++ {
+				value, err := r.float64()
+				if err != nil {
+					return nil, err
+				}
+				if k < 2 {
+					ring[j][k] = value
+				}
+			}
+		}
+		polygon = append(polygon, ring)
+	}
+	return polygon, nil
+}
@@ -0,0 +1,240 @@
+package geo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseWKT reads a POLYGON or MULTIPOLYGON in well-known text, optionally prefixed with an
+// EWKT SRID (which must be 4326). Rings that are not closed are closed.
+func ParseWKT(wkt string) (MultiPolygon, error) {
+	text := strings.TrimSpace(wkt)
+	if strings.HasPrefix(strings.ToUpper(text), "SRID=") {
+		semicolon := strings.Index(text, ";")
+		if semicolon < 0 {
+			return nil, fmt.Errorf("malformed SRID prefix")
+		}
+		if srid := strings.TrimSpace(text[len("SRID="):semicolon]); srid != "4326" {
+			return nil, fmt.Errorf("geometry must use SRID 4326, got %s", srid)
+		}
+		text = strings.TrimSpace(text[semicolon+1:])
+	}
+
+	p := &wktParser{text: text}
+	keyword := strings.ToUpper(p.word())
+	switch strings.ToUpper(p.peekWord()) {
+	case "Z", "M", "ZM":
+		p.word()
+	}
+	if strings.EqualFold(p.peekWord(), "EMPTY") {
+		return nil, fmt.Errorf("geometry is empty")
+	}
+
+	var geometry MultiPolygon
+	var err error
+	switch keyword {
+	case "POLYGON":
+		var polygon Polygon
+		polygon, err = p.polygon()
+		geometry = MultiPolygon{polygon}
+	case "MULTIPOLYGON":
+		geometry, err = p.multiPolygon()
+	case "":
+		return nil, fmt.Errorf("geometry cannot be empty")
+	default:
+		return nil, fmt.Errorf("only POLYGON and MULTIPOLYGON geometries are supported, got %s", keyword)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if p.skipSpace(); p.pos < len(p.text) {
+		return nil, fmt.Errorf("unexpected %q after geometry", p.text[p.pos:])
+	}
+	return geometry, nil
+}
+
+type wktParser struct {
+	text string
+	pos  int
+}
+
+func (p *wktParser) skipSpace() {
+	for p.pos < len(p.text) && strings.ContainsRune(" \t\r\n", rune(p.text[p.pos])) {
+		p.pos++
+	}
+}
+
+func (p *wktParser) word() string {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.text) && isLetter(p.text[p.pos]) {
+		p.pos++
+	}
+	return p.text[start:p.pos]
+}
+
+func (p *wktParser) peekWord() string {
+	pos := p.pos
+	word := p.word()
+	p.pos = pos
+	return word
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z')
+}
+
+func (p *wktParser) expect(c byte) error {
+	p.skipSpace()
+	if p.pos >= len(p.text) || p.text[p.pos] != c {
+		return fmt.Errorf("expected %q at offset %d", c, p.pos)
+	}
+	p.pos++
+	return nil
+}
+
+// more consumes a comma and reports whether another element follows
+func (p *wktParser) more() bool {
+	p.skipSpace()
+	if p.pos < len(p.text) && p.text[p.pos] == ',' {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *wktParser) multiPolygon() (MultiPolygon, error) {
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+	var geometry MultiPolygon
+	for {
+		polygon, err := p.polygon()
+		if err != nil {
+			return nil, err
+		}
+		geometry = append(geometry, polygon)
+		if !p.more() {
+			break
+		}
+	}
+	return geometry, p.expect(')')
+}
+
+func (p *wktParser) polygon() (Polygon, error) {
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+	var polygon Polygon
+	for {
+		ring, err := p.ring()
+		if err != nil {
+			return nil, err
+		}
+		polygon = append(polygon, ring)
+		if !p.more() {
+			break
+		}
+	}
+	return polygon, p.expect(')')
+}
+
+func (p *wktParser) ring() (Ring, error) {
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+	var ring Ring
+	for {
+		position, err := p.position()
+		if err != nil {
+			return nil, err
+		}
+		ring = append(ring, position)
+		if !p.more() {
+			break
+		}
+	}
+	if err := p.expect(')'); err != nil {
+		return nil, err
+	}
+	if ring[0] != ring[len(ring)-1] {
+		ring = append(ring, ring[0])
+	}
+	if len(ring) < 4 {
+		return nil, fmt.Errorf("polygon ring needs at least 4 positions")
+	}
+	return ring, nil
+}
+
+func (p *wktParser) position() (Position, error) {
+	lon, err := p.number()
+	if err != nil {
+		return Position{}, err
+	}
+	lat, err := p.number()
+	if err != nil {
+		return Position{}, err
+	}
+	if lon < -180 || lon > 180 || lat < -90 || lat > 90 {
+		return Position{}, fmt.Errorf("coordinate (%v %v) is not a WGS 84 longitude/latitude", lon, lat)
+	}
+	// Skip any Z or M ordinate
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.text) || strings.ContainsRune(",)", rune(p.text[p.pos])) {
+			break
+		}
+		if _, err := p.number(); err != nil {
+			return Position{}, err
+		}
+	}
+	return Position{lon, lat}, nil
+}
+
+func (p *wktParser) number() (float64, error) {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.text) && strings.ContainsRune("+-.0123456789eE", rune(p.text[p.pos])) {
+		p.pos++
+	}
+	value, err := strconv.ParseFloat(p.text[start:p.pos], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid coordinate at offset %d", start)
+	}
+	return value, nil
+}
+
+// WKT returns the geometry as a POLYGON, or a MULTIPOLYGON when it has several parts
+func (m MultiPolygon) WKT() string {
+	if len(m) == 1 {
+		return "POLYGON" + polygonWKT(m[0])
+	}
+	return m.MultiWKT()
+}
+
+// MultiWKT returns the geometry as a MULTIPOLYGON even when it has a single part, as a
+// geometry(MULTIPOLYGON) column requires
+func (m MultiPolygon) MultiWKT() string {
+	parts := make([]string, len(m))
+	for i, polygon := range m {
+		parts[i] = polygonWKT(polygon)
+	}
+	return "MULTIPOLYGON(" + strings.Join(parts, ",") + ")"
+}
+
+func polygonWKT(polygon Polygon) string {
+	rings := make([]string, len(polygon))
+	for i, ring := range polygon {
+		positions := make([]string, len(ring))
+		for j, p := range ring {
+			positions[j] = formatCoordinate(p[0]) + " " + formatCoordinate(p[1])
+		}
+		rings[i] = "(" + strings.Join(positions, ", ") + ")"
+	}
+	return "(" + strings.Join(rings, ",") + ")"
+}
+
+func formatCoordinate(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,37 @@
+package geo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWKT(t *testing.T) {
+	geometry, err := ParseWKT("SRID=4326;polygon ((78 17, 78.1 17, 78.1 17.1, 78 17))")
+	require.NoError(t, err)
+	require.Len(t, geometry, 1)
+	require.Len(t, geometry[0], 1)
+	assert.Equal(t, Ring{{78, 17}, {78.1, 17}, {78.1, 17.1}, {78, 17}}, geometry[0][0])
+
+	// Unclosed rings are closed and Z ordinates dropped
+	geometry, err = ParseWKT("MULTIPOLYGON Z (((78 17 1,78.1 17 1,78.1 17.1 1)),((79 17 0,79.1 17 0,79.1 17.1 0,79 17 0),(79.01 17.01 0,79.02 17.01 0,79.02 17.02 0,79.01 17.01 0)))")
+	require.NoError(t, err)
+	require.Len(t, geometry, 2)
+	assert.Len(t, geometry[0][0], 4)
+	assert.Len(t, geometry[1], 2)
+
+	for _, wkt := range []string{
+		"",
+		"POLYGON EMPTY",
+		"POINT(78 17)",
+		"SRID=3857;POLYGON((0 0,1 0,1 1,0 0))",
+		"POLYGON((78 17,78.1 17))",
+		"POLYGON((78 17,78.1 17,78.1 17.1,78 17)",
+		"POLYGON((78 17,78.1 17,78.1 17.1,78 17)) extra",
+		"POLYGON((78 95,78.1 17,78.1 17.1,78 95))",
+	} {
+		_, err := ParseWKT(wkt)
+		assert.Error(t, err, wkt)
+	}
+}
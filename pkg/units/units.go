@@ -0,0 +1,266 @@
+package units
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Dimension is the kind of quantity a unit measures
+type Dimension string
+
+const (
+	DimensionMass   Dimension = "MASS"
+	DimensionArea   Dimension = "AREA"
+	DimensionVolume Dimension = "VOLUME"
+	DimensionCount  Dimension = "COUNT"
+)
+
+// Canonical units that quantities of each dimension are stored and aggregated in
+const (
+	Kilogram = "kg"
+	Hectare  = "ha"
+	Litre    = "l"
+	Number   = "nos"
+)
+
+// Dimensions lists the dimensions in listing order
+var Dimensions = []Dimension{DimensionMass, DimensionArea, DimensionVolume, DimensionCount}
+
+// IsValid reports whether the dimension is one of the known dimensions
+func (d Dimension) IsValid() bool {
+	for _, dimension := range Dimensions {
+		if d == dimension {
+			return true
+		}
+	}
+	return false
+}
+
+// Canonical returns the code of the dimension's canonical unit
+func (d Dimension) Canonical() string {
+	switch d {
+	case DimensionMass:
+		return Kilogram
+	case DimensionArea:
+		return Hectare
+	case DimensionVolume:
+		return Litre
+	case DimensionCount:
+		return Number
+	}
+	return ""
+}
+
+// Unit is a unit of measure. Factor is the number of canonical units in one unit; it is
+// zero for local units whose size differs between states, which have StateFactors keyed by
+// state code instead.
+type Unit struct {
+	Code         string             `json:"code" example:"quintal"`
+	Name         string             `json:"name" example:"quintal"`
+	Dimension    Dimension          `json:"dimension" example:"MASS"`
+	Factor       float64            `json:"factor,omitempty" example:"100"`
+	StateFactors map[string]float64 `json:"state_factors,omitempty"`
+	Aliases      []string           `json:"aliases,omitempty"`
+}
+
+// IsLocal reports whether the unit's size depends on the state it is used in
+func (u *Unit) IsLocal() bool {
+	return len(u.StateFactors) > 0
+}
+
+// FactorIn returns the number of canonical units in one unit as used in a state. The state
+// is only needed for local units.
+func (u *Unit) FactorIn(stateCode string) (float64, error) {
+	if !u.IsLocal() {
+		return u.Factor, nil
+	}
+	stateCode = normalizeStateCode(stateCode)
+	if stateCode == "" {
+		return 0, fmt.Errorf("the size of a %s differs between states; the state is needed to convert it", u.Code)
+	}
+	factor, ok := u.StateFactors[stateCode]
+	if !ok {
+		return 0, fmt.Errorf("the size of a %s in state %s is not known", u.Code, stateCode)
+	}
+	return factor, nil
+}
+
+const (
+	squareMetre = 0.0001                   // hectares
+	squareFoot  = 0.09290304 * squareMetre // hectares
+	acre        = 0.40468564224            // hectares
+)
+
+// registry holds the known units in listing order
+var registry = []*Unit{
+	// Mass
+	{Code: "g", Name: "gram", Dimension: DimensionMass, Factor: 0.001, Aliases: []string{"gm", "gms", "gram", "grams"}},
+	{Code: Kilogram, Name: "kilogram", Dimension: DimensionMass, Factor: 1, Aliases: []string{"kgs", "kilo", "kilogram", "kilograms"}},
+	{Code: "quintal", Name: "quintal", Dimension: DimensionMass, Factor: 100, Aliases: []string{"q", "qtl", "qtls", "quintals"}},
+	{Code: "tonne", Name: "tonne", Dimension: DimensionMass, Factor: 1000, Aliases: []string{"t", "mt", "ton", "tons", "tonnes", "metric ton"}},
+	{Code: "maund", Name: "maund", Dimension: DimensionMass, Factor: 37.3242, Aliases: []string{"maunds", "mann"}},
+
+	// Area
+	{Code: "sqm", Name: "square metre", Dimension: DimensionArea, Factor: squareMetre, Aliases: []string{"m2", "sq m", "square metre", "square meter"}},
+	{Code: "sqft", Name: "square foot", Dimension: DimensionArea, Factor: squareFoot, Aliases: []string{"ft2", "sq ft", "square foot", "square feet"}},
+	{Code: Hectare, Name: "hectare", Dimension: DimensionArea, Factor: 1, Aliases: []string{"hectare", "hectares"}},
+	{Code: "acre", Name: "acre", Dimension: DimensionArea, Factor: acre, Aliases: []string{"ac", "acres"}},
+	{Code: "guntha", Name: "guntha", Dimension: DimensionArea, Factor: acre / 40, Aliases: []string{"gunta", "gunthas", "guntas"}},
+	{Code: "cent", Name: "cent", Dimension: DimensionArea, Factor: acre / 100, Aliases: []string{"cents"}},
+	{Code: "kanal", Name: "kanal", Dimension: DimensionArea, Factor: acre / 8, Aliases: []string{"kanals"}},
+	{Code: "marla", Name: "marla", Dimension: DimensionArea, Factor: acre / 160, Aliases: []string{"marlas"}},
+	{Code: "bigha", Name: "bigha", Dimension: DimensionArea, StateFactors: bighaFactors(1), Aliases: []string{"bighas"}},
+	{Code: "biswa", Name: "biswa", Dimension: DimensionArea, StateFactors: bighaFactors(20), Aliases: []string{"biswas"}},
+
+	// Volume
+	{Code: "ml", Name: "millilitre", Dimension: DimensionVolume, Factor: 0.001, Aliases: []string{"millilitre", "milliliter", "millilitres", "milliliters"}},
+	{Code: Litre, Name: "litre", Dimension: DimensionVolume, Factor: 1, Aliases: []string{"ltr", "ltrs", "litre", "liter", "litres", "liters"}},
+	{Code: "kl", Name: "kilolitre", Dimension: DimensionVolume, Factor: 1000, Aliases: []string{"kilolitre", "kiloliter", "kilolitres", "kiloliters"}},
+
+	// Count
+	{Code: Number, Name: "number", Dimension: DimensionCount, Factor: 1, Aliases: []string{"no", "number", "numbers", "piece", "pieces", "pcs", "count"}},
+	{Code: "dozen", Name: "dozen", Dimension: DimensionCount, Factor: 12, Aliases: []string{"dozens", "doz"}},
+	{Code: "hundred", Name: "hundred", Dimension: DimensionCount, Factor: 100, Aliases: []string{"hundreds"}},
+	{Code: "thousand", Name: "thousand", Dimension: DimensionCount, Factor: 1000, Aliases: []string{"thousands"}},
+}
+
+// bighaSquareFeet is the size of a bigha in square feet by state code. States that do not
+// use the bigha are left out.
+var bighaSquareFeet = map[string]float64{
+	"2":  8712,  // Himachal Pradesh
+	"3":  9070,  // Punjab
+	"5":  6804,  // Uttarakhand
+	"6":  27225, // Haryana
+	"8":  27225, // Rajasthan (pucca bigha)
+	"9":  27225, // Uttar Pradesh
+	"10": 27225, // Bihar
+	"16": 14400, // Tripura
+	"18": 14400, // Assam
+	"19": 14400, // West Bengal
+	"20": 27225, // Jharkhand
+	"23": 12000, // Madhya Pradesh
+	"24": 17424, // Gujarat
+}
+
+// bighaFactors returns the size in hectares of a bigha divided into parts, by state code
+func bighaFactors(parts float64) map[string]float64 {
+	factors := make(map[string]float64, len(bighaSquareFeet))
+	for stateCode, squareFeet := range bighaSquareFeet {
+		factors[stateCode] = squareFeet * squareFoot / parts
+	}
+	return factors
+}
+
+// byName indexes the units by code and alias
+var byName = func() map[string]*Unit {
+	index := make(map[string]*Unit)
+	for _, unit := range registry {
+		index[unit.Code] = unit
+		for _, alias := range unit.Aliases {
+			index[alias] = unit
+		}
+	}
+	return index
+}()
+
+// normalizeName folds a unit name for lookup: case, surrounding space, repeated inner space
+// and a trailing full stop are ignored
+func normalizeName(name string) string {
+	name = strings.TrimSuffix(strings.TrimSpace(strings.ToLower(name)), ".")
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// normalizeStateCode drops leading zeros so that "09" and "9" name the same state
+func normalizeStateCode(stateCode string) string {
+	stateCode = strings.TrimSpace(stateCode)
+	trimmed := strings.TrimLeft(stateCode, "0")
+	if trimmed == "" && stateCode != "" {
+		return "0"
+	}
+	return trimmed
+}
+
+// Lookup finds a unit by its code or one of its aliases, ignoring case
+func Lookup(name string) (*Unit, bool) {
+	unit, ok := byName[normalizeName(name)]
+	return unit, ok
+}
+
+// Parse finds a unit by its code or one of its aliases, or fails if it is not known
+func Parse(name string) (*Unit, error) {
+	unit, ok := Lookup(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown unit %q", strings.TrimSpace(name))
+	}
+	return unit, nil
+}
+
+// List returns the known units of a dimension, or of all dimensions when it is empty, in
+// listing order
+func List(dimension Dimension) []*Unit {
+	var list []*Unit
+	for _, unit := range registry {
+		if dimension == "" || unit.Dimension == dimension {
+			list = append(list, unit)
+		}
+	}
+	return list
+}
+
+// States returns the codes of the states that local units have sizes for, in numeric order
+func States() []string {
+	codes := make([]string, 0, len(bighaSquareFeet))
+	for stateCode := range bighaSquareFeet {
+		codes = append(codes, stateCode)
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		if len(codes[i]) != len(codes[j]) {
+			return len(codes[i]) < len(codes[j])
+		}
+		return codes[i] < codes[j]
+	})
+	return codes
+}
+
+// Convert converts a value from one unit to another of the same dimension. The state code
+// is only needed when either unit is a local unit.
+func Convert(value float64, from, to, stateCode string) (float64, error) {
+	fromUnit, err := Parse(from)
+	if err != nil {
+		return 0, err
+	}
+	toUnit, err := Parse(to)
+	if err != nil {
+		return 0, err
+	}
+	if fromUnit.Dimension != toUnit.Dimension {
+		return 0, fmt.Errorf("cannot convert %s (%s) to %s (%s)", fromUnit.Code, strings.ToLower(string(fromUnit.Dimension)), toUnit.Code, strings.ToLower(string(toUnit.Dimension)))
+	}
+	if fromUnit == toUnit {
+		return value, nil
+	}
+	fromFactor, err := fromUnit.FactorIn(stateCode)
+	if err != nil {
+		return 0, err
+	}
+	toFactor, err := toUnit.FactorIn(stateCode)
+	if err != nil {
+		return 0, err
+	}
+	return value * fromFactor / toFactor, nil
+}
+
+// ToCanonical converts a value to the canonical unit of its unit's dimension and returns it
+// with that unit's code
+func ToCanonical(value float64, unit, stateCode string) (float64, string, error) {
+	u, err := Parse(unit)
+	if err != nil {
+		return 0, "", err
+	}
+	factor, err := u.FactorIn(stateCode)
+	if err != nil {
+		return 0, "", err
+	}
+	return value * factor, u.Dimension.Canonical(), nil
+}
@@ -0,0 +1,103 @@
+package units
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookup_CodesAndAliases(t *testing.T) {
+	for _, name := range []string{"kg", "KG", " Kgs ", "kilograms", "kg."} {
+		unit, ok := Lookup(name)
+		require.True(t, ok, name)
+		assert.Equal(t, Kilogram, unit.Code)
+	}
+
+	unit, ok := Lookup("Sq  Ft")
+	require.True(t, ok)
+	assert.Equal(t, "sqft", unit.Code)
+
+	_, ok = Lookup("bag")
+	assert.False(t, ok)
+
+	_, err := Parse("bag")
+	assert.EqualError(t, err, `unknown unit "bag"`)
+}
+
+func TestRegistry_AliasesAreUnique(t *testing.T) {
+	seen := make(map[string]string)
+	for _, unit := range registry {
+		assert.True(t, unit.Dimension.IsValid(), unit.Code)
+		assert.True(t, unit.Factor > 0 || unit.IsLocal(), unit.Code)
+		for _, name := range append([]string{unit.Code}, unit.Aliases...) {
+			assert.Equal(t, name, normalizeName(name), "%s is not in lookup form", name)
+			if other, ok := seen[name]; ok {
+				t.Errorf("%s names both %s and %s", name, other, unit.Code)
+			}
+			seen[name] = unit.Code
+		}
+	}
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     float64
+		from, to  string
+		stateCode string
+		expected  float64
+	}{
+		{"quintal to kg", 12, "qtl", "kg", "", 1200},
+		{"kg to tonne", 2500, "kg", "tonne", "", 2.5},
+		{"acre to hectare", 1, "acre", "ha", "", 0.40468564224},
+		{"guntha to acre", 40, "guntha", "acre", "", 1},
+		{"same unit", 7, "kg", "Kilogram", "", 7},
+		{"bigha in Assam", 1, "bigha", "sqft", "18", 14400},
+		{"bigha in Uttar Pradesh", 1, "bigha", "sqft", "09", 27225},
+		{"biswa in Rajasthan", 20, "biswa", "bigha", "8", 1},
+		{"ml to litre", 500, "ml", "l", "", 0.5},
+		{"dozen to numbers", 3, "dozen", "nos", "", 36},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := Convert(tt.value, tt.from, tt.to, tt.stateCode)
+			require.NoError(t, err)
+			assert.InEpsilon(t, tt.expected, value, 1e-9)
+		})
+	}
+}
+
+func TestConvert_Errors(t *testing.T) {
+	_, err := Convert(1, "kg", "ha", "")
+	assert.EqualError(t, err, "cannot convert kg (mass) to ha (area)")
+
+	_, err = Convert(1, "bigha", "ha", "")
+	assert.EqualError(t, err, "the size of a bigha differs between states; the state is needed to convert it")
+
+	_, err = Convert(1, "bigha", "ha", "27")
+	assert.EqualError(t, err, "the size of a bigha in state 27 is not known")
+
+	_, err = Convert(1, "bag", "kg", "")
+	assert.Error(t, err)
+}
+
+func TestToCanonical(t *testing.T) {
+	value, unit, err := ToCanonical(18, "quintal", "")
+	require.NoError(t, err)
+	assert.Equal(t, Kilogram, unit)
+	assert.InEpsilon(t, 1800, value, 1e-9)
+
+	value, unit, err = ToCanonical(1, "bigha", "19")
+	require.NoError(t, err)
+	assert.Equal(t, Hectare, unit)
+	assert.InEpsilon(t, 0.13378, value, 1e-4)
+}
+
+func TestListAndStates(t *testing.T) {
+	assert.Len(t, List(""), len(registry))
+	for _, unit := range List(DimensionVolume) {
+		assert.Equal(t, DimensionVolume, unit.Dimension)
+	}
+	assert.Equal(t, []string{"2", "3", "5", "6", "8", "9", "10", "16", "18", "19", "20", "23", "24"}, States())
+}